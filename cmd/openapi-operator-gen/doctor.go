@@ -0,0 +1,49 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/bluecontainer/openapi-operator-gen/pkg/doctor"
+)
+
+var doctorCmd = &cobra.Command{
+	Use:   "doctor [directory]",
+	Short: "Check a generated operator for common problems",
+	Long: `Inspect a generated operator directory (default: the current directory) and
+print a prioritized checklist of problems:
+
+  - spec drift: the spec changed since the last generation
+  - generator version mismatch
+  - go.mod not tidied (go.sum missing or incomplete)
+  - CRD manifests close to or over the 262144-byte kubectl apply limit
+  - controllers missing, edited or orphaned compared with the last generation
+  - CRDs whose controllers aren't registered in cmd/manager/main.go
+
+Exits with a non-zero status if any error is found.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runDoctor,
+}
+
+func init() {
+	rootCmd.AddCommand(doctorCmd)
+}
+
+func runDoctor(cmd *cobra.Command, args []string) error {
+	directory := "."
+	if len(args) > 0 {
+		directory = args[0]
+	}
+
+	report, err := doctor.Run(directory, version)
+	if err != nil {
+		return err
+	}
+	fmt.Print(report.String())
+	if report.HasErrors() {
+		cmd.SilenceUsage = true
+		return fmt.Errorf("doctor found errors in %s", directory)
+	}
+	return nil
+}
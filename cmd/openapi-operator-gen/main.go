@@ -3,6 +3,7 @@ package main
 import (
 	"fmt"
 	"os"
+	"path/filepath"
 	"runtime/debug"
 	"strings"
 
@@ -28,14 +29,29 @@ var (
 	configFile string
 
 	// Filter flags (comma-separated strings, parsed into slices)
-	includePaths      string
-	excludePaths      string
-	includeTags       string
-	excludeTags       string
-	includeOperations string
-	excludeOperations string
-	updateWithPost    string
-	idFieldMap        string
+	includePaths       string
+	excludePaths       string
+	includeTags        string
+	excludeTags        string
+	includeOperations  string
+	excludeOperations  string
+	updateWithPost     string
+	idFieldMap         string
+	pluralOverrides    string
+	emitConditions     string
+	constantPathParams string
+
+	// Default endpoint target (key=value pairs, parsed into config.TargetDefault)
+	defaultTarget string
+
+	// Success status codes per HTTP method (METHOD=CODE[|CODE...] pairs)
+	successCodes string
+
+	// HTTP/2 toggle for the generated controllers' HTTP client
+	http2Enabled bool
+
+	// Print the mapped Kinds and exit without generating
+	listKinds bool
 )
 
 func init() {
@@ -160,21 +176,52 @@ func init() {
 	generateCmd.Flags().StringVarP(&configFile, "config", "c", "", "Path to config file (default: searches for .openapi-operator-gen.yaml)")
 
 	// Generate command flags
-	generateCmd.Flags().StringVarP(&cfg.SpecPath, "spec", "s", "", "Path or URL to OpenAPI specification file")
+	generateCmd.Flags().StringVarP(&cfg.SpecPath, "spec", "s", "", "Path or URL to OpenAPI specification file, or a directory of split spec files")
+	generateCmd.Flags().StringVar(&cfg.SpecFormat, "spec-format", config.SpecFormatAuto, "Force how the spec is decoded: auto, yaml or json (json fixes JSON specs served as text/plain)")
+	generateCmd.Flags().StringVar(&cfg.SpecCacheDir, "spec-cache", "", "Directory caching a --spec URL, revalidated with conditional GETs and reused offline (e.g., ~/.cache/oog)")
+	generateCmd.Flags().StringVar(&cfg.SpecRootFile, "spec-root-file", "", "Root document inside a --spec directory (default: the single file with an openapi:/swagger: key)")
 	generateCmd.Flags().StringVarP(&cfg.OutputDir, "output", "o", "./generated", "Output directory for generated code")
 	generateCmd.Flags().StringVarP(&cfg.APIGroup, "group", "g", "", "Kubernetes API group (e.g., myapp.example.com)")
 	generateCmd.Flags().StringVarP(&cfg.APIVersion, "version", "v", "v1alpha1", "Kubernetes API version")
 	generateCmd.Flags().StringVarP((*string)(&cfg.MappingMode), "mapping", "m", "per-resource", "Resource mapping mode: per-resource or single-crd")
 	generateCmd.Flags().StringVar(&cfg.ModuleName, "module", "github.com/bluecontainer/generated-operator", "Go module name for generated code")
+	generateCmd.Flags().StringVar(&cfg.ImportPrefix, "output-module-path", "", "Import path of the output directory when nested in an existing module (default: --module)")
+	generateCmd.Flags().BoolVar(&cfg.SkipGoMod, "skip-go-mod", false, "Skip generating go.mod files (for output nested in an existing module)")
 	generateCmd.Flags().BoolVar(&cfg.GenerateCRDs, "generate-crds", false, "Generate CRD YAML manifests directly (default: use controller-gen)")
+	generateCmd.Flags().BoolVar(&cfg.TypesOnly, "types-only", false, "Generate only the API types, CRD YAML and samples, without controllers, main.go, go.mod, Dockerfile or Makefile")
 	generateCmd.Flags().StringVar(&cfg.RootKind, "root-kind", "", "Kind name for root '/' endpoint (default: derived from spec filename)")
+	generateCmd.Flags().StringVar((*string)(&cfg.ControllerFileNaming), "controller-file-naming", "kind", "Controller file naming: kind or operation-id")
+	generateCmd.Flags().BoolVar(&cfg.DeriveOwners, "derive-owners", false, "Make resources nested under another resource's path (/orders/{orderId}/items) owned by its CRs, as x-k8s-owner does")
+	generateCmd.Flags().StringVar((*string)(&cfg.FieldNameCase), "field-name-case", "camel", "JSON names of CR fields: camel (petName), original (the API's names) or snake (pet_name)")
 	generateCmd.Flags().BoolVar(&cfg.GenerateAggregate, "aggregate", false, "Generate a Status Aggregator CRD for observing multiple resource types")
 	generateCmd.Flags().BoolVar(&cfg.GenerateBundle, "bundle", false, "Generate an Inline Composition Bundle CRD for creating multiple resources")
+	generateCmd.Flags().BoolVar(&cfg.BundleAdopt, "bundle-adopt", false, "Make the bundle controller adopt pre-existing, unowned child CRs so they are garbage-collected with the bundle")
 	generateCmd.Flags().BoolVar(&cfg.GenerateKubectlPlugin, "kubectl-plugin", false, "Generate a kubectl plugin for managing and diagnosing operator resources")
+	generateCmd.Flags().BoolVar(&cfg.GenerateKrewManifest, "krew-manifest", false, "Generate a krew manifest for distributing the kubectl plugin (requires --kubectl-plugin)")
 	generateCmd.Flags().BoolVar(&cfg.GenerateRundeckProject, "rundeck-project", false, "Generate a Rundeck project with jobs using the kubectl plugin (requires --kubectl-plugin)")
+	generateCmd.Flags().BoolVar(&cfg.GenerateWebhookPatches, "webhook-patches", false, "Generate kustomize patches for a conversion webhook with cert-manager CA injection")
+	generateCmd.Flags().BoolVar(&cfg.GenerateDashboard, "dashboard", false, "Generate a Grafana dashboard for the operator metrics")
+	generateCmd.Flags().BoolVar(&cfg.GenerateTilt, "tilt", false, "Generate a Tiltfile for a live-reload development loop")
+	generateCmd.Flags().BoolVar(&cfg.GenerateE2E, "e2e", false, "Generate an end-to-end test (make test-e2e) that deploys the operator to a kind cluster and waits for a sample CR to become Ready")
+	generateCmd.Flags().BoolVar(&cfg.GenerateWebhookReceiver, "webhook-receiver", false, "Generate a receiver for the spec's OpenAPI 3.1 webhooks that creates or updates CRs when the REST API calls back")
+	generateCmd.Flags().BoolVar(&cfg.ValidateOnly, "validate-only", false, "Parse, map and render all templates in memory without writing any files")
+	generateCmd.Flags().BoolVar(&listKinds, "list-kinds", false, "Print one Kind<TAB>type<TAB>plural line per CRD the spec maps to (type is resource, query or action) and exit without generating")
+	generateCmd.Flags().BoolVar(&cfg.MergeControllers, "merge", false, "Keep controllers edited since the last generation and write the new version as <file>.new for manual merging")
+	generateCmd.Flags().BoolVar(&cfg.GitInit, "git-init", false, "Initialize the output directory as a git repository with an initial commit (skipped if already in a repository)")
+	generateCmd.Flags().StringVar(&cfg.AppendTo, "append-to", "", "Add this spec's CRDs to the existing generated operator in this directory instead of generating a new one (use a different --group)")
+	generateCmd.Flags().BoolVar(&cfg.PatchExistingCRDs, "patch-existing-crds", false, "Write MIGRATION.md with suggested kubectl commands for migrating existing CRs across the CRD changes since the previous generation")
 	generateCmd.Flags().StringVar(&cfg.ManagedCRsDir, "managed-crs", "", "Directory containing CR YAML files for managed Rundeck lifecycle jobs")
 	generateCmd.Flags().BoolVar(&cfg.StandaloneNodeSource, "standalone-node-source", false, "Use standalone kubectl-rundeck-nodes plugin instead of generating a per-API node source plugin")
 	generateCmd.Flags().StringVar(&updateWithPost, "update-with-post", "", "Use POST for updates when PUT is not available. Value: '*' for all, or comma-separated paths (e.g., /store/order,/users/*)")
+	generateCmd.Flags().StringVar(&cfg.FinalizerName, "finalizer-name", "", "Finalizer added by the generated controllers (default: <group>/finalizer)")
+	generateCmd.Flags().StringVar(&cfg.SampleNamespace, "sample-namespace", "", "Namespace of the sample CRs and the kubectl plugin's default namespace (default: the spec's x-k8s-namespace, else default)")
+	generateCmd.Flags().BoolVar(&cfg.UseETag, "use-etag", false, "Send If-Match with the stored ETag on updates when the GET response declares an ETag header")
+	generateCmd.Flags().StringVar(&cfg.AcceptHeader, "accept-header", "", "Accept header the controllers send on every request (default: derived from each operation's response media types, preferring application/json)")
+	generateCmd.Flags().StringSliceVar(&cfg.ExcludeStatusFields, "exclude-status-fields", nil, "Status fields to drop from the CRDs, as field or Kind.field (comma-separated: response,responses,Pet.lastGetTime); conditions, observedGeneration and the fields the controllers read back are protected")
+	generateCmd.Flags().BoolVar(&cfg.GroupKindPluralCheck, "group-kind-plural-check", false, "Validate the API group and version and every generated Kind, plural and short name against Kubernetes naming rules before generating")
+	generateCmd.Flags().StringVar(&cfg.IdempotencyHeader, "idempotency-header", "", "Header (e.g., Idempotency-Key) carrying a key derived from the CR's UID and generation on create and action requests")
+	generateCmd.Flags().BoolVar(&cfg.NoGenerationPredicate, "no-generation-predicate", false, "Reconcile resource CRs on every update, including status writes, instead of only on spec and annotation changes")
+	generateCmd.Flags().BoolVar(&cfg.NoStatusSubresource, "no-status-subresource", false, "Generate CRDs without the status subresource; controllers write status with a full object update")
 
 	// Resource filtering flags
 	generateCmd.Flags().StringVar(&includePaths, "include-paths", "", "Only include paths matching these patterns (comma-separated, glob supported: /users,/pets/*)")
@@ -183,14 +230,56 @@ func init() {
 	generateCmd.Flags().StringVar(&excludeTags, "exclude-tags", "", "Exclude endpoints with these OpenAPI tags (comma-separated: deprecated,internal)")
 	generateCmd.Flags().StringVar(&includeOperations, "include-operations", "", "Only include operations with these operationIds (comma-separated, glob supported: getPet*,createPet)")
 	generateCmd.Flags().StringVar(&excludeOperations, "exclude-operations", "", "Exclude operations with these operationIds (comma-separated, glob supported: *Deprecated,deletePet)")
+	generateCmd.Flags().IntVar(&cfg.MaxCRDs, "max-crds", 0, "Fail if the spec maps to more than this many CRDs; use filters to narrow it (0 means unlimited)")
 
 	// ID field merging flags
 	generateCmd.Flags().BoolVar(&cfg.NoIDMerge, "no-id-merge", false, "Disable automatic merging of path ID parameters with body 'id' fields")
 	generateCmd.Flags().StringVar(&idFieldMap, "id-field-map", "", "Explicit path param to body field mappings (comma-separated: orderId=id,petId=id)")
+	generateCmd.Flags().StringVar(&constantPathParams, "exclude-path-params", "", "Path params with a fixed value, baked into the URLs instead of becoming spec fields (comma-separated: version=v1,tenant=acme)")
+	generateCmd.Flags().StringVar(&pluralOverrides, "plural-overrides", "", "Exact CRD plurals per Kind, overriding x-k8s-plural and the pluralization heuristic (comma-separated: Datum=data,Person=people)")
 
 	// Target API deployment generation
+	generateCmd.Flags().StringVar(&cfg.ServerSelector, "server-selector", "", "Server from the spec's servers list (x-name, x-environment, description or URL) the operator targets by default; the operator's --server flag overrides it")
+	generateCmd.Flags().StringVar(&cfg.Environment, "environment", "", "Environment (e.g., prod or staging) whose spec server, matched by its x-environment extension, is the spec base URL and the operator's default server")
 	generateCmd.Flags().StringVar(&cfg.TargetAPIImage, "target-api-image", "", "Container image for target REST API (generates Deployment+Service manifest)")
 	generateCmd.Flags().IntVar(&cfg.TargetAPIPort, "target-api-port", 0, "Container port for target REST API (overrides port from spec URL, default: 8080)")
+	generateCmd.Flags().StringVar(&defaultTarget, "default-target", "", "Default spec.target for generated CRs as key=value pairs (e.g., deployment=petstore-api,namespace=backend)")
+	generateCmd.Flags().StringVar(&successCodes, "success-codes", "", "Response codes treated as success per HTTP method as METHOD=CODE[|CODE...] pairs (e.g., DELETE=204,POST=201|202; default: any 2xx)")
+
+	// Controller HTTP client tuning
+	generateCmd.Flags().IntVar(&cfg.HTTPTransport.MaxIdleConns, "http-max-idle-conns", 0, "Max idle connections kept by the controller HTTP client, in total and per host (default: 100)")
+	generateCmd.Flags().IntVar(&cfg.HTTPTransport.MaxConnsPerHost, "http-max-conns-per-host", 0, "Max connections per host for the controller HTTP client (default: 0, no limit)")
+	generateCmd.Flags().DurationVar(&cfg.HTTPTransport.IdleConnTimeout, "http-idle-conn-timeout", 0, "How long idle connections are kept open by the controller HTTP client (default: 90s)")
+	generateCmd.Flags().BoolVar(&cfg.SecurityContext.AllowRunAsRoot, "security-allow-run-as-root", false, "Drop runAsNonRoot from the manager Deployment's securityContext")
+	generateCmd.Flags().BoolVar(&cfg.SecurityContext.WritableRootFilesystem, "security-writable-root-fs", false, "Drop readOnlyRootFilesystem from the manager container's securityContext")
+	generateCmd.Flags().StringSliceVar(&cfg.SecurityContext.AddCapabilities, "security-add-capabilities", nil, "Capabilities to add back to the manager container after dropping ALL (e.g., NET_BIND_SERVICE)")
+	generateCmd.Flags().StringVar(&cfg.SecurityContext.SeccompProfile, "security-seccomp-profile", "", "Seccomp profile type of the manager pod (default: RuntimeDefault)")
+	generateCmd.Flags().BoolVar(&http2Enabled, "http2", true, "Enable HTTP/2 for the controller HTTP client")
+
+	// Container image
+	generateCmd.Flags().StringVar(&cfg.BaseImage, "controller-base-image", "", "Builder stage image of the generated Dockerfile (default: golang:1.25)")
+	generateCmd.Flags().StringVar(&cfg.RuntimeImage, "runtime-image", "", "Runtime stage image of the generated Dockerfile; must run as non-root unless --security-allow-run-as-root (default: gcr.io/distroless/static:nonroot)")
+
+	// Profiling
+	generateCmd.Flags().IntVar(&cfg.MaxQueryResults, "status-result-limit", 0, "Max results a query controller stores in status; resultCount keeps the full count (0 means unlimited)")
+	generateCmd.Flags().StringVar(&cfg.PauseConfigMapRef, "pause-configmap", "", "ConfigMap (namespace/name or name) the generated controllers check for an operator-wide per-Kind pause switch")
+	generateCmd.Flags().Float32Var(&cfg.KubeClientQPS, "client-qps", 0, "Default of the generated operator's --kube-api-qps flag, the queries per second its Kubernetes client may send (default: 20)")
+	generateCmd.Flags().IntVar(&cfg.KubeClientBurst, "client-burst", 0, "Default of the generated operator's --kube-api-burst flag, the request burst its Kubernetes client may send above its QPS (default: 30)")
+	generateCmd.Flags().DurationVar(&cfg.SlowReconcileThreshold, "slow-reconcile-threshold", 0, "Reconcile duration above which the generated controllers emit a Warning event (default: 10s)")
+	generateCmd.Flags().DurationVar(&cfg.DeleteTimeout, "delete-timeout", 0, "Retry a failed DELETE of the external resource, keeping the CR's finalizer and setting DeletionBlocked, until this long after deletion was requested (0 removes the finalizer after one attempt)")
+	generateCmd.Flags().BoolVar(&cfg.OrphanOnDeleteTimeout, "orphan-on-delete-timeout", false, "Remove the finalizer once --delete-timeout has passed, orphaning the external resource")
+	generateCmd.Flags().StringVar(&emitConditions, "emit-conditions", "", "Condition types the controllers set instead of Ready, Reconciling and Stalled (comma-separated: ready=Available,reconciling=Progressing,stalled=Degraded)")
+	generateCmd.Flags().BoolVar(&cfg.AllowExtraHeaders, "allow-extra-headers", false, "Add spec.extraHeaders to resource, query and action CRDs, sent as HTTP headers on each REST API request")
+	generateCmd.Flags().BoolVar(&cfg.ReconcileOnConfigMapChange, "reconcile-on-configmap-change", false, "Watch the ConfigMaps and Secrets CRs reference (action spec.dataFrom) and re-reconcile those CRs when they change")
+	generateCmd.Flags().BoolVar(&cfg.SupportDryRun, "support-dry-run", false, "Add a --dry-run-external flag to the manager that logs REST API writes instead of sending them")
+	generateCmd.Flags().BoolVar(&cfg.HighAvailability, "ha", false, "Run 2 manager replicas with leader election, a PodDisruptionBudget and a PriorityClass")
+	generateCmd.Flags().StringVar(&cfg.PriorityClassName, "priority-class", "", "Existing PriorityClass for the manager pods (default with --ha: a generated one)")
+	generateCmd.Flags().StringSliceVar(&cfg.WatchNamespaces, "watch-namespaces", nil, "Namespaces the operator watches by default (comma-separated); its --watch-namespaces flag overrides them (default: all namespaces)")
+	generateCmd.Flags().BoolVar(&cfg.EnableTracing, "tracing", false, "Export OpenTelemetry spans for reconciles and REST API calls from the generated manager")
+	generateCmd.Flags().BoolVar(&cfg.EnablePprof, "profile", false, "Expose /debug/pprof in the generated manager")
+	generateCmd.Flags().StringVar(&cfg.PprofAddr, "pprof-addr", "", "Default bind address of the generated manager's pprof handler (default: 127.0.0.1:6060)")
+	generateCmd.Flags().BoolVar(&cfg.EmbedSpec, "embed-spec", false, "Embed the OpenAPI spec in the operator binary (spec_embed.go) and serve it on the metrics server")
+	generateCmd.Flags().BoolVar(&cfg.KubebuilderLayout, "kubebuilder", false, "Write a kubebuilder PROJECT file and the manager entrypoint to cmd/main.go, so kubebuilder create api/webhook work on the operator")
 
 	// Note: spec and group are no longer marked as required since they can come from config file
 }
@@ -239,6 +328,44 @@ func parseIDFieldMap(s string) map[string]string {
 	return result
 }
 
+// specServers converts the parsed spec's servers list for the generator config
+func specServers(servers []parser.Server) []config.SpecServer {
+	var result []config.SpecServer
+	for _, s := range servers {
+		result = append(result, config.SpecServer{Name: s.Name, Environment: s.Environment, URL: s.URL, Description: s.Description})
+	}
+	return result
+}
+
+// specWebhooks converts the parsed spec's webhooks for the generator config
+func specWebhooks(webhooks []parser.Webhook) []config.SpecWebhook {
+	var result []config.SpecWebhook
+	for _, w := range webhooks {
+		result = append(result, config.SpecWebhook{Name: w.Name, Method: w.Method, OperationID: w.OperationID, Summary: w.Summary, PayloadSchemaRef: w.PayloadSchemaRef, Kind: w.Kind})
+	}
+	return result
+}
+
+// runListKinds prints the Kinds the spec maps to, one Kind<TAB>type<TAB>plural line
+// each. Parser diagnostics go to stderr so stdout holds only the list.
+func runListKinds() error {
+	p := parser.NewParserWithFilter(cfg.RootKind, config.NewPathFilter(cfg))
+	p.SpecRootFile = cfg.SpecRootFile
+	p.SpecFormat = cfg.SpecFormat
+	p.ConstantPathParams = cfg.ConstantPathParams
+	p.SpecCacheDir = cfg.SpecCacheDir
+	p.LogWriter = os.Stderr
+	spec, err := p.Parse(cfg.SpecPath)
+	if err != nil {
+		return fmt.Errorf("failed to parse OpenAPI spec: %w", err)
+	}
+	crds, err := mapper.NewMapper(cfg).MapResources(spec)
+	if err != nil {
+		return fmt.Errorf("failed to map resources: %w", err)
+	}
+	return mapper.WriteKindList(os.Stdout, crds)
+}
+
 func runGenerate(cmd *cobra.Command, args []string) error {
 	// Load config file if specified or found
 	var cfgFilePath string
@@ -254,7 +381,9 @@ func runGenerate(cmd *cobra.Command, args []string) error {
 			return fmt.Errorf("failed to load config file %s: %w", cfgFilePath, err)
 		}
 		if fileCfg != nil {
-			fmt.Printf("Using config file: %s\n", cfgFilePath)
+			if !listKinds {
+				fmt.Printf("Using config file: %s\n", cfgFilePath)
+			}
 			config.MergeConfigFile(cfg, fileCfg)
 		}
 	}
@@ -289,17 +418,60 @@ func runGenerate(cmd *cobra.Command, args []string) error {
 	if idFieldMap != "" {
 		cfg.IDFieldMap = parseIDFieldMap(idFieldMap)
 	}
+	if pluralOverrides != "" {
+		cfg.PluralOverrides = parseIDFieldMap(pluralOverrides)
+	}
+	if emitConditions != "" {
+		cfg.ConditionTypes = parseIDFieldMap(emitConditions)
+	}
+	if constantPathParams != "" {
+		cfg.ConstantPathParams = parseIDFieldMap(constantPathParams)
+	}
+	if defaultTarget != "" {
+		target, err := config.ParseTargetDefault(defaultTarget)
+		if err != nil {
+			return fmt.Errorf("invalid --default-target: %w", err)
+		}
+		cfg.DefaultTarget = target
+	}
+	if successCodes != "" {
+		codes, err := config.ParseSuccessCodes(successCodes)
+		if err != nil {
+			return fmt.Errorf("invalid --success-codes: %w", err)
+		}
+		cfg.SuccessCodes = codes
+	}
+	if cmd.Flags().Changed("http2") {
+		cfg.HTTPTransport.HTTP2 = &http2Enabled
+	}
 
 	// Validate configuration
 	if err := cfg.Validate(); err != nil {
 		return fmt.Errorf("invalid configuration: %w", err)
 	}
+	if listKinds {
+		return runListKinds()
+	}
+	var appendTarget *generator.AppendTarget
+	if cfg.AppendTo != "" {
+		target, err := generator.LoadAppendTarget(cfg.AppendTo)
+		if err != nil {
+			return fmt.Errorf("--append-to: %w", err)
+		}
+		appendTarget = target
+		cfg.ModuleName = target.ModulePath
+		// The new CRDs follow the operator's layout, whatever --kubebuilder says
+		cfg.KubebuilderLayout = target.Kubebuilder
+	}
 
 	fmt.Printf("Generating operator code from OpenAPI spec: %s\n", cfg.SpecPath)
 	fmt.Printf("Output directory: %s\n", cfg.OutputDir)
 	fmt.Printf("API Group: %s\n", cfg.APIGroup)
 	fmt.Printf("API Version: %s\n", cfg.APIVersion)
 	fmt.Printf("Mapping mode: %s\n", cfg.MappingMode)
+	if cfg.ValidateOnly {
+		fmt.Println("Validate only: output is rendered in memory and discarded")
+	}
 	if len(cfg.IncludePaths) > 0 {
 		fmt.Printf("Include paths: %s\n", strings.Join(cfg.IncludePaths, ", "))
 	}
@@ -336,6 +508,10 @@ func runGenerate(cmd *cobra.Command, args []string) error {
 	fmt.Println("Parsing OpenAPI specification...")
 	filter := config.NewPathFilter(cfg)
 	p := parser.NewParserWithFilter(cfg.RootKind, filter)
+	p.SpecRootFile = cfg.SpecRootFile
+	p.SpecFormat = cfg.SpecFormat
+	p.ConstantPathParams = cfg.ConstantPathParams
+	p.SpecCacheDir = cfg.SpecCacheDir
 	spec, err := p.Parse(cfg.SpecPath)
 	if err != nil {
 		return fmt.Errorf("failed to parse OpenAPI spec: %w", err)
@@ -348,6 +524,16 @@ func runGenerate(cmd *cobra.Command, args []string) error {
 
 	// Store spec base URL for target API deployment generation
 	cfg.SpecBaseURL = spec.BaseURL
+	cfg.SpecServers = specServers(spec.Servers)
+	cfg.SpecWebhooks = specWebhooks(spec.Webhooks)
+	if err := cfg.ApplyEnvironment(); err != nil {
+		return fmt.Errorf("invalid --environment: %w", err)
+	}
+	cfg.SpecVersion = spec.Version
+	cfg.SpecHomepage = spec.Homepage
+	if err := cfg.ApplySpecNamespace(spec.Namespace); err != nil {
+		return fmt.Errorf("invalid OpenAPI spec: %w", err)
+	}
 
 	// Map resources to CRDs
 	fmt.Println("Mapping resources to CRD definitions...")
@@ -362,6 +548,24 @@ func runGenerate(cmd *cobra.Command, args []string) error {
 	}
 	fmt.Println()
 
+	if appendTarget != nil {
+		return runAppend(appendTarget, crds)
+	}
+
+	// Generate the migration guide from the previous generation, before its spec copy is replaced
+	if cfg.PatchExistingCRDs {
+		fmt.Println("Comparing with the previous generation...")
+		oldCRDs, oldSpecVersion, err := generator.PreviousCRDs(cfg.OutputDir)
+		if err != nil {
+			return fmt.Errorf("--patch-existing-crds: %w", err)
+		}
+		if err := generator.NewMigrationGenerator(cfg).Generate(oldCRDs, crds, oldSpecVersion); err != nil {
+			return fmt.Errorf("failed to generate MIGRATION.md: %w", err)
+		}
+		fmt.Println("  Generated MIGRATION.md")
+		fmt.Println()
+	}
+
 	// Generate types
 	fmt.Println("Generating Go type definitions...")
 	typesGen := generator.NewTypesGenerator(cfg)
@@ -372,14 +576,20 @@ func runGenerate(cmd *cobra.Command, args []string) error {
 	fmt.Println("  Generated api/<version>/groupversion_info.go")
 	fmt.Println()
 
-	// Generate CRD YAML (optional - controller-gen is recommended)
-	if cfg.GenerateCRDs {
+	// Generate CRD YAML (optional - controller-gen is recommended; always written in types-only mode)
+	if cfg.GenerateCRDs || cfg.TypesOnly {
 		fmt.Println("Generating CRD YAML manifests...")
 		crdGen := generator.NewCRDGenerator(cfg)
 		if err := crdGen.Generate(crds); err != nil {
 			return fmt.Errorf("failed to generate CRD YAML: %w", err)
 		}
 		fmt.Println("  Generated config/crd/bases/*.yaml")
+		for _, warning := range crdGen.SizeWarnings {
+			fmt.Printf("  Warning: %s\n", warning)
+		}
+		if len(crdGen.SizeWarnings) > 0 {
+			fmt.Printf("  To shrink them: %s\n", generator.CRDSizeFix)
+		}
 		fmt.Println()
 	} else {
 		fmt.Println("Skipping CRD YAML generation (use 'make generate' to generate with controller-gen)")
@@ -419,6 +629,12 @@ func runGenerate(cmd *cobra.Command, args []string) error {
 	fmt.Println("  Generated config/samples/*.yaml")
 	fmt.Println()
 
+	if cfg.TypesOnly {
+		fmt.Println("Types only: skipping controllers, main.go, go.mod, Dockerfile and Makefile")
+		fmt.Println()
+		return finishGenerate()
+	}
+
 	// Generate controllers (pass aggregate and bundle to include in main.go registration)
 	fmt.Println("Generating controller reconciliation logic...")
 	controllerGen := generator.NewControllerGenerator(cfg)
@@ -426,7 +642,21 @@ func runGenerate(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to generate controllers: %w", err)
 	}
 	fmt.Println("  Generated internal/controller/*_controller.go")
-	fmt.Println("  Generated cmd/manager/main.go")
+	for _, f := range controllerGen.PendingMerges() {
+		fmt.Printf("  Kept hand-edited %s; wrote %s for manual merge\n", strings.TrimSuffix(f, ".new"), f)
+	}
+	fmt.Printf("  Generated %s\n", cfg.MainPath())
+	if cfg.KubebuilderLayout {
+		fmt.Println("  Generated PROJECT")
+	}
+	if cfg.GenerateWebhookReceiver {
+		if len(cfg.SpecWebhooks) > 0 {
+			fmt.Println("  Generated internal/webhookreceiver/receiver.go")
+			fmt.Println("  Generated config/webhook-receiver/service.yaml")
+		} else {
+			fmt.Println("  Skipped the webhook receiver: the spec declares no webhooks")
+		}
+	}
 	fmt.Println("  Generated go.mod")
 	fmt.Println("  Generated Dockerfile")
 	fmt.Println("  Generated Makefile")
@@ -441,6 +671,25 @@ func runGenerate(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to generate docker-compose.yaml: %w", err)
 	}
 	fmt.Println("  Generated docker-compose.yaml")
+	if cfg.GenerateTilt {
+		if err := controllerGen.GenerateTilt(); err != nil {
+			return fmt.Errorf("failed to generate Tiltfile: %w", err)
+		}
+		fmt.Println("  Generated Tiltfile")
+	}
+	if cfg.GenerateE2E {
+		if err := controllerGen.GenerateE2E(crds); err != nil {
+			return fmt.Errorf("failed to generate e2e test: %w", err)
+		}
+		fmt.Println("  Generated test/e2e/e2e_test.go")
+	}
+	if cfg.GenerateDashboard {
+		path, err := controllerGen.GenerateDashboard(crds, aggregate, bundle)
+		if err != nil {
+			return fmt.Errorf("failed to generate Grafana dashboard: %w", err)
+		}
+		fmt.Printf("  Generated %s\n", path)
+	}
 	fmt.Println()
 
 	// Generate aggregate controller if enabled
@@ -528,6 +777,9 @@ func runGenerate(cmd *cobra.Command, args []string) error {
 		fmt.Println("    Generated kubectl-plugin/pkg/output/output.go")
 		fmt.Println("  Generated kubectl-plugin/go.mod")
 		fmt.Println("  Generated kubectl-plugin/Makefile")
+		if cfg.GenerateKrewManifest {
+			fmt.Println("  Generated kubectl-plugin/plugin.yaml")
+		}
 		fmt.Println()
 	}
 
@@ -569,8 +821,43 @@ func runGenerate(cmd *cobra.Command, args []string) error {
 		fmt.Println()
 	}
 
+	return finishGenerate()
+}
+
+// finishGenerate saves the generation manifest, optionally initializes the git
+// repository and prints the next steps
+func finishGenerate() error {
+	if cfg.ValidateOnly {
+		fmt.Println("Validation successful: spec parsed, mapped and rendered without errors (no files written)")
+		return nil
+	}
+
+	if err := generator.SaveManifest(cfg); err != nil {
+		return fmt.Errorf("failed to save generation manifest: %w", err)
+	}
+
+	if cfg.GitInit {
+		created, err := generator.InitGitRepo(cfg.OutputDir, fmt.Sprintf("Generate operator from %s", filepath.Base(cfg.SpecPath)))
+		if err != nil {
+			return fmt.Errorf("failed to initialize git repository: %w", err)
+		}
+		if created {
+			fmt.Println("Initialized git repository with an initial commit of the generated files")
+		} else {
+			fmt.Println("Skipping git init: output directory is already in a git repository")
+		}
+		fmt.Println()
+	}
+
 	fmt.Println("Code generation complete!")
 	fmt.Println()
+	if cfg.TypesOnly {
+		fmt.Println("Next steps:")
+		fmt.Printf("  1. Copy %s/api/%s into your operator module\n", cfg.OutputDir, cfg.APIVersion)
+		fmt.Println("  2. controller-gen object paths=./api/...  # Generate deep copy methods")
+		fmt.Printf("  3. kubectl apply -f %s/config/crd/bases  # Install CRDs to cluster\n", cfg.OutputDir)
+		return nil
+	}
 	fmt.Println("Next steps:")
 	fmt.Printf("  1. cd %s\n", cfg.OutputDir)
 	fmt.Println("  2. go mod tidy")
@@ -586,3 +873,46 @@ func runGenerate(cmd *cobra.Command, args []string) error {
 
 	return nil
 }
+
+// runAppend adds the mapped CRDs to the existing operator in --append-to: it writes their
+// API package and controllers, and merges their registration into main.go and go.mod
+func runAppend(target *generator.AppendTarget, crds []*mapper.CRDDefinition) error {
+	if err := target.CheckCollisions(cfg, crds); err != nil {
+		return fmt.Errorf("--append-to: %w", err)
+	}
+
+	fmt.Println("Generating Go type definitions...")
+	if err := generator.NewTypesGenerator(cfg).Generate(crds); err != nil {
+		return fmt.Errorf("failed to generate types: %w", err)
+	}
+	fmt.Printf("  Generated %s/types.go\n", cfg.APIDir())
+	fmt.Printf("  Generated %s/groupversion_info.go\n", cfg.APIDir())
+	fmt.Println()
+
+	fmt.Println("Generating controller reconciliation logic...")
+	if err := generator.NewControllerGenerator(cfg).GenerateAppend(crds); err != nil {
+		return fmt.Errorf("failed to append controllers: %w", err)
+	}
+	fmt.Println("  Generated internal/controller/*_controller.go")
+	fmt.Printf("  Updated %s\n", cfg.MainPath())
+	if !cfg.SkipGoMod {
+		fmt.Println("  Updated go.mod")
+	}
+	if cfg.KubebuilderLayout {
+		fmt.Println("  Updated PROJECT")
+	}
+	fmt.Println()
+
+	if cfg.ValidateOnly {
+		fmt.Println("Validation successful: spec parsed, mapped and rendered without errors (no files written)")
+		return nil
+	}
+	fmt.Println("Code generation complete!")
+	fmt.Println()
+	fmt.Println("Next steps:")
+	fmt.Printf("  1. cd %s\n", cfg.OutputDir)
+	fmt.Println("  2. go mod tidy")
+	fmt.Println("  3. make generate manifests  # Generate deep copy methods, CRDs and RBAC for the new kinds")
+	fmt.Println("  4. make build")
+	return nil
+}
@@ -1,10 +1,19 @@
 package config
 
 import (
+	"fmt"
+	"mime"
 	"net/url"
 	"path"
 	"path/filepath"
+	"slices"
 	"strings"
+	"time"
+
+	"github.com/iancoleman/strcase"
+	"k8s.io/apimachinery/pkg/util/validation"
+
+	"github.com/bluecontainer/openapi-operator-gen/pkg/controller"
 )
 
 // MappingMode defines how REST resources map to CRDs
@@ -17,10 +26,44 @@ const (
 	SingleCRD MappingMode = "single-crd"
 )
 
+// ControllerFileNaming defines how generated controller files are named
+type ControllerFileNaming string
+
+const (
+	// ControllerFileNamingKind names controller files after the Kind (e.g., pet_controller.go)
+	ControllerFileNamingKind ControllerFileNaming = "kind"
+	// ControllerFileNamingOperationID names controller files after the CRD's primary
+	// operationId (e.g., get_pet_by_id_controller.go), falling back to the Kind
+	ControllerFileNamingOperationID ControllerFileNaming = "operation-id"
+)
+
+// FieldNameCase defines how the JSON names of CR fields are derived from the API's
+// property and parameter names
+type FieldNameCase string
+
+const (
+	// FieldNameCaseCamel converts names to lowerCamelCase (e.g., pet_name -> petName)
+	FieldNameCaseCamel FieldNameCase = "camel"
+	// FieldNameCaseOriginal keeps the API's names, so CRs mirror its payloads
+	FieldNameCaseOriginal FieldNameCase = "original"
+	// FieldNameCaseSnake converts names to snake_case (e.g., petName -> pet_name)
+	FieldNameCaseSnake FieldNameCase = "snake"
+)
+
 // Config holds the generator configuration
 type Config struct {
-	// SpecPath is the path to the OpenAPI specification file
+	// SpecPath is the path to the OpenAPI specification file, or a directory of
+	// split spec files
 	SpecPath string
+	// SpecRootFile names the root document inside a SpecPath directory, for
+	// directories with more than one openapi:/swagger: document
+	SpecRootFile string
+	// SpecFormat forces how the spec is decoded: "auto" (default), "yaml" or
+	// "json". Useful for extension-less files or URLs with the wrong content type
+	SpecFormat string
+	// SpecCacheDir caches a SpecPath URL on disk, revalidated with conditional GETs,
+	// so repeated runs are fast and work offline. Empty disables the cache
+	SpecCacheDir string
 	// OutputDir is the directory where generated code will be written
 	OutputDir string
 	// APIGroup is the Kubernetes API group (e.g., "myapp.example.com")
@@ -31,12 +74,28 @@ type Config struct {
 	MappingMode MappingMode
 	// ModuleName is the Go module name for generated code
 	ModuleName string
+	// ImportPrefix is the import path of the output directory, used for the generated code's
+	// own imports. Defaults to ModuleName; set it when generating into a subdirectory of an
+	// existing module (e.g., github.com/myorg/monorepo/operators/petstore).
+	ImportPrefix string
+	// SkipGoMod skips generating go.mod files, for output nested inside an existing module
+	SkipGoMod bool
 	// GenerateCRDs controls whether to generate CRD YAML manifests directly.
 	// When false (default), CRDs should be generated using controller-gen.
 	GenerateCRDs bool
 	// RootKind is the Kind name to use for the root "/" endpoint.
 	// If not specified, it's derived from the OpenAPI spec file name.
 	RootKind string
+	// ControllerFileNaming determines how controller files are named: "kind" (default) or "operation-id".
+	// This is cosmetic; it also adds operationId comments to the controller registrations in main.go.
+	ControllerFileNaming ControllerFileNaming
+	// FieldNameCase determines the JSON names of the CR fields generated from the API's
+	// properties and parameters: "camel" (default), "original" or "snake"
+	FieldNameCase FieldNameCase
+	// DeriveOwners makes each resource nested under another resource's path (e.g.,
+	// /orders/{orderId}/items under /orders/{orderId}) owned by it, as x-k8s-owner does:
+	// its controller sets an owner reference to the parent CR
+	DeriveOwners bool
 	// GeneratorVersion is the version of openapi-operator-gen used to generate the code.
 	// This is embedded in the generated go.mod to ensure correct dependency versions.
 	GeneratorVersion string
@@ -50,6 +109,10 @@ type Config struct {
 	// GenerateBundle controls whether to generate an Inline Composition Bundle CRD (Option 2).
 	// When true, generates a bundle CRD that creates and manages multiple child resources.
 	GenerateBundle bool
+	// BundleAdopt controls whether the bundle controller adopts pre-existing child CRs that
+	// match a bundle resource but have no controller owner, so deleting the bundle
+	// garbage-collects them too. Bundles can override it with spec.adopt.
+	BundleAdopt bool
 
 	// GenerateKubectlPlugin controls whether to generate a kubectl plugin alongside the operator.
 	// When true, generates a kubectl plugin for managing and diagnosing operator resources.
@@ -60,6 +123,71 @@ type Config struct {
 	// Requires GenerateKubectlPlugin to be true.
 	GenerateRundeckProject bool
 
+	// GenerateKrewManifest controls whether to generate a krew plugin manifest
+	// (kubectl-plugin/plugin.yaml) for distributing the kubectl plugin.
+	// Requires GenerateKubectlPlugin to be true.
+	GenerateKrewManifest bool
+
+	// GenerateDashboard controls whether to generate a Grafana dashboard (dashboards/<app>.json)
+	// with panels for the operator's reconcile, external API and custom resource metrics.
+	GenerateDashboard bool
+
+	// GenerateWebhookPatches controls whether to generate the kustomize scaffolding for a
+	// conversion webhook with cert-manager CA injection: config/crd/patches, config/certmanager,
+	// config/webhook and a manager patch, wired into config/kustomization.yaml.
+	GenerateWebhookPatches bool
+
+	// TypesOnly generates only the API types (api/<version>), the CRD YAML manifests and the
+	// samples, for importing into a larger operator: controllers, main.go, go.mod, Dockerfile,
+	// Makefile and the rest of the operator scaffold are skipped.
+	TypesOnly bool
+
+	// ValidateOnly runs parsing, mapping and template execution without writing any output.
+	// Generated files are rendered in memory and discarded.
+	ValidateOnly bool
+
+	// MergeControllers preserves hand-edited controllers on regeneration: a controller is
+	// only overwritten if it is unchanged since it was generated (per ControllerHashes in the
+	// output directory's .openapi-operator-gen.yaml); otherwise the new version is written
+	// alongside as <file>.new for manual merging.
+	MergeControllers bool
+
+	// GitInit initializes the output directory as a git repository with an initial commit
+	// of the generated files and spec, so the diff tool has a baseline from the first
+	// generation. Skipped when the output directory is already inside a repository.
+	GitInit bool
+
+	// PatchExistingCRDs writes MIGRATION.md to the output directory, with suggested kubectl
+	// commands for migrating existing CRs across the changes between the CRDs of the previous
+	// generation (mapped from its saved config and spec copy) and this one.
+	PatchExistingCRDs bool
+
+	// AppendTo is the directory of an existing generated operator to add this spec's CRDs to.
+	// Their types go to api/<group>/<version> and their controllers to internal/controller;
+	// the manager's main.go and go.mod (and a kubebuilder PROJECT file) are edited in place
+	// and no other file is touched.
+	AppendTo string
+
+	// KubebuilderLayout writes a kubebuilder PROJECT file listing the generated resources and
+	// moves the manager entrypoint to cmd/main.go, with the scaffold markers that kubebuilder
+	// create api and create webhook insert their code at.
+	KubebuilderLayout bool
+
+	// GenerateTilt controls whether to generate a Tiltfile for a live-reload development loop.
+	// Kept out of the default output because it is only useful with Tilt installed.
+	GenerateTilt bool
+
+	// GenerateE2E controls whether to generate an end-to-end test (test/e2e) that deploys
+	// the operator, and the target API when TargetAPIImage is set, to a kind cluster and
+	// waits for a sample CR to become Ready. Run with make test-e2e.
+	GenerateE2E bool
+
+	// GenerateWebhookReceiver controls whether to generate a receiver for the spec's
+	// OpenAPI 3.1 webhooks: an HTTP server the manager runs, with a handler per webhook
+	// operation, and a Service in front of it. A handler whose payload maps to a Kind
+	// creates or updates that Kind's CRs; the others are stubs to fill in.
+	GenerateWebhookReceiver bool
+
 	// StandaloneNodeSource controls whether to use the standalone kubectl-rundeck-nodes
 	// Rundeck plugin for node sources instead of generating a per-API plugin.
 	// When true, skips node source plugin generation and uses the k8s-workload-nodes provider.
@@ -73,6 +201,52 @@ type Config struct {
 	// This is useful for APIs that use POST for both creation and updates.
 	UpdateWithPost []string
 
+	// FinalizerName is the finalizer added by resource, query and action controllers.
+	// Defaults to "<group>/finalizer"; set a distinct name when several operators share a group.
+	FinalizerName string
+
+	// SampleNamespace is the namespace of the generated sample CRs and the kubectl
+	// plugin's fallback namespace. Overrides the spec's x-k8s-namespace extension;
+	// defaults to "default".
+	SampleNamespace string
+
+	// UseETag enables optimistic concurrency for resources whose GET response declares an ETag header.
+	// The controller stores the ETag in status and sends it as If-Match on updates.
+	UseETag bool
+
+	// IdempotencyHeader, when set, is the header (e.g., Idempotency-Key) on which resource
+	// controllers send a key derived from the CR's UID and generation with create requests,
+	// and action controllers one that also includes the execution count, so a request
+	// retried after a failed status update doesn't create a duplicate.
+	IdempotencyHeader string
+
+	// AcceptHeader, when set, is the Accept header the controllers send on every request.
+	// By default each operation's is derived from the media types of its success responses,
+	// preferring application/json, then a JSON media type such as application/vnd.x+json.
+	AcceptHeader string
+
+	// ExcludeStatusFields drops status fields the operator doesn't need from the generated
+	// CRDs, reducing their size and what is stored in etcd. An entry is a status field's
+	// JSON name, removed from every CRD that has it, or "Kind.field" for one Kind
+	// (e.g., "response" or "Pet.lastGetTime"). ProtectedStatusFields can't be excluded.
+	ExcludeStatusFields []string
+
+	// GroupKindPluralCheck validates the API group and version and every generated Kind,
+	// plural and short name against the Kubernetes naming rules before any file is
+	// written, so a spec with unusual path or operation names fails with an actionable
+	// error instead of at kubectl apply.
+	GroupKindPluralCheck bool
+
+	// NoStatusSubresource generates CRDs without the status subresource, for clusters or
+	// CRD setups that can't serve it. The controllers then write status with a full
+	// object update, which also bumps metadata.generation.
+	NoStatusSubresource bool
+
+	// NoGenerationPredicate makes resource controllers reconcile on every update of their
+	// CR, including their own status writes. By default they only react to spec
+	// (generation) and annotation changes; periodic requeues still detect drift.
+	NoGenerationPredicate bool
+
 	// Resource Filtering Options
 	// IncludePaths specifies paths to include (glob patterns supported).
 	// If set, only paths matching these patterns will be processed.
@@ -100,6 +274,10 @@ type Config struct {
 	// Supports glob patterns: "*Deprecated", "internal*"
 	// Example: "deletePet,deprecatedGetPets"
 	ExcludeOperations []string
+	// MaxCRDs fails generation when the spec maps to more CRDs than this, so an
+	// enormous gateway spec doesn't silently become an unmanageable operator.
+	// 0 (the default) means no limit.
+	MaxCRDs int
 
 	// ID Field Merging Options
 	// NoIDMerge disables automatic ID field merging.
@@ -111,6 +289,25 @@ type Config struct {
 	// This overrides auto-detection for specific parameters.
 	IDFieldMap map[string]string
 
+	// PluralOverrides sets the exact CRD plural for a Kind (e.g., "Datum" -> "data"),
+	// taking precedence over the x-k8s-plural extension and the pluralization heuristic.
+	PluralOverrides map[string]string
+
+	// ConstantPathParams maps path parameters with a fixed value (e.g., "version" -> "v1"
+	// for /api/{version}/pets) to that value. They are baked into the URLs at generate
+	// time instead of becoming spec fields.
+	ConstantPathParams map[string]string
+
+	// ServerSelector picks an entry of the spec's servers list (by x-name, description or
+	// URL) as the generated operator's default base URL. The operator's --server flag or
+	// REST_API_SERVER env var selects a different one at deploy time.
+	ServerSelector string
+
+	// Environment picks the spec server whose x-environment extension matches (e.g.,
+	// "staging") for SpecBaseURL, and makes it the operator's default server unless
+	// ServerSelector is set. See ApplyEnvironment.
+	Environment string
+
 	// TargetAPIImage is the container image for the target REST API.
 	// When set, generates a Deployment+Service manifest for the target API.
 	TargetAPIImage string
@@ -120,20 +317,255 @@ type Config struct {
 	// Default: 0 (use spec URL port, or 8080 if not specified).
 	TargetAPIPort int
 
+	// DefaultTarget presets spec.target in the generated CRDs for operations without an
+	// x-k8s-target-default extension. CRs can still set their own target.
+	DefaultTarget *TargetDefault
+
+	// SuccessCodes lists, per HTTP method, the response status codes the generated
+	// controllers treat as success (e.g., "DELETE" -> [204]); any other code is a failure.
+	// Methods without an entry accept any 2xx. The x-k8s-success-codes extension of an
+	// operation takes precedence.
+	SuccessCodes map[string][]int
+
 	// ManagedCRsDir is the directory containing CR YAML files for managed Rundeck lifecycle jobs.
 	// When set, generates per-CR apply/get/patch/delete/status jobs.
 	ManagedCRsDir string
 
+	// HTTPTransport tunes the HTTP transport the generated controllers use to call the REST API.
+	// The values become the defaults of the generated operator's --http-* flags.
+	HTTPTransport HTTPTransportConfig
+
+	// SecurityContext relaxes the hardened securityContext of the generated manager Deployment.
+	// The zero value keeps it hardened (restricted pod security level).
+	SecurityContext SecurityContextConfig
+
+	// AllowExtraHeaders adds an optional spec.extraHeaders map to resource, query and action
+	// CRDs. Its headers are added to every REST API request for the CR, for per-CR tenant
+	// or trace headers.
+	AllowExtraHeaders bool
+
+	// ReconcileOnConfigMapChange makes controllers watch the ConfigMaps and Secrets their
+	// CRs reference (an action's spec.dataFrom) and re-reconcile those CRs when they change.
+	// An action whose dataFrom object changed since its last execution runs again.
+	ReconcileOnConfigMapChange bool
+
+	// SupportDryRun adds a --dry-run-external flag to the generated manager. With it the
+	// controllers log the POST/PUT/PATCH/DELETE calls they would make and set a DryRun
+	// condition instead of sending them; GETs still run, so drift is still reported.
+	SupportDryRun bool
+
+	// HighAvailability runs HAReplicas manager replicas with leader election on by default,
+	// a PodDisruptionBudget keeping all but one of them available, and a PriorityClass.
+	HighAvailability bool
+	// PriorityClassName is an existing PriorityClass for the manager pods. Empty with
+	// HighAvailability generates one.
+	PriorityClassName string
+
+	// WatchNamespaces are the namespaces the generated operator watches by default, via
+	// the manager cache's DefaultNamespaces; empty watches all namespaces. The operator's
+	// --watch-namespaces flag or WATCH_NAMESPACES env var overrides them at deploy time.
+	WatchNamespaces []string
+
+	// EnableTracing makes the generated manager export OpenTelemetry spans for each
+	// reconcile and outbound REST API call. Off by default, so only metrics are exported
+	// when OTEL_EXPORTER_OTLP_ENDPOINT is set.
+	EnableTracing bool
+
+	// EnablePprof wires a pprof HTTP handler (/debug/pprof) into the generated manager.
+	// Off by default; the handler listens on PprofAddr.
+	EnablePprof bool
+	// PprofAddr is the default for the generated manager's --pprof-bind-address flag.
+	// Default: 127.0.0.1:6060 (reach it with kubectl port-forward).
+	PprofAddr string
+
+	// EmbedSpec adds spec_embed.go, which embeds the copied OpenAPI spec in the
+	// operator binary with //go:embed; the manager serves it on the metrics server
+	// (/openapi.json or /openapi.yaml).
+	EmbedSpec bool
+
+	// BaseImage is the builder stage image of the generated Dockerfile.
+	// Default: golang:1.25.
+	BaseImage string
+	// RuntimeImage is the runtime stage image of the generated Dockerfile. The manager
+	// runs as UID 65532 unless SecurityContext.AllowRunAsRoot is set, so the image must
+	// not need root. Default: gcr.io/distroless/static:nonroot.
+	RuntimeImage string
+
+	// SlowReconcileThreshold is the default of the generated operator's
+	// --slow-reconcile-threshold flag: reconciles slower than this emit a Warning event.
+	// Default: 10s.
+	SlowReconcileThreshold time.Duration
+
+	// KubeClientQPS and KubeClientBurst are the defaults of the generated operator's
+	// --kube-api-qps and --kube-api-burst flags: the rate limit of its Kubernetes client.
+	// Default: 20 and 30, controller-runtime's own defaults.
+	KubeClientQPS   float32
+	KubeClientBurst int
+
+	// DeleteTimeout makes the generated controllers keep a CR's finalizer and retry a
+	// failed DELETE of its external resource, setting the DeletionBlocked condition, until
+	// this long after deletion was requested. 0 (the default) removes the finalizer after
+	// one attempt, orphaning the external resource if the DELETE failed.
+	DeleteTimeout time.Duration
+	// OrphanOnDeleteTimeout removes the finalizer once DeleteTimeout has passed, orphaning
+	// the external resource. Without it the CR stays until the DELETE succeeds or its
+	// spec.onDelete is set to Orphan.
+	OrphanOnDeleteTimeout bool
+
+	// ConditionTypes renames the status conditions the generated controllers set, keyed by
+	// their meaning: ConditionReady, ConditionReconciling and ConditionStalled (e.g.,
+	// "ready" -> "Available"). Unset meanings keep their default condition types.
+	ConditionTypes map[string]string
+
+	// MaxQueryResults caps the number of results the generated query controllers store
+	// in status. status.resultCount still reports the full count and status.truncated is
+	// set when results were clipped. 0 (the default) stores every result.
+	MaxQueryResults int
+
+	// PauseConfigMapRef is the default of the generated operator's --pause-configmap
+	// flag: a "namespace/name" or "name" (operator namespace) ConfigMap whose per-Kind
+	// keys pause reconciliation operator-wide. Empty disables the switch.
+	PauseConfigMapRef string
+
 	// SpecHash is the SHA-256 hash of the spec file content at generation time.
 	// Used for quick change detection without re-parsing the spec.
 	// Format: "sha256:<hex>"
 	SpecHash string
 
+	// ControllerHashes maps each generated controller (path relative to OutputDir) to the
+	// SHA-256 hash of its content as generated. Set by the controller generator and saved
+	// so that MergeControllers can tell hand-edited controllers apart.
+	ControllerHashes map[string]string
+
+	// GeneratedFiles maps every file written by the generators (path relative to
+	// OutputDir) to the SHA-256 hash of its content. Filled in by the file sink and
+	// saved as the generation manifest.
+	GeneratedFiles map[string]string
+
+	// KindSpecHashes maps each Kind last regenerated on its own (the MCP generate tool's
+	// only_kind) to the hash of the spec it was generated from. A copy of that spec is
+	// kept per Kind, so later partial runs render the Kind's types as they were. A full
+	// generation clears it.
+	KindSpecHashes map[string]string
+
 	// SpecBaseURL is the base URL extracted from the OpenAPI spec's servers field.
 	// Set programmatically after parsing, not from CLI flags.
 	SpecBaseURL string
+
+	// SpecServers is the OpenAPI spec's servers list.
+	// Set programmatically after parsing, not from CLI flags.
+	SpecServers []SpecServer
+
+	// SpecWebhooks are the operations of the OpenAPI spec's webhooks object.
+	// Set programmatically after parsing, not from CLI flags.
+	SpecWebhooks []SpecWebhook
+
+	// SpecVersion and SpecHomepage come from the OpenAPI spec's info section.
+	// Set programmatically after parsing, not from CLI flags.
+	SpecVersion  string
+	SpecHomepage string
+
+	// SpecNamespace is the spec's x-k8s-namespace extension, used when SampleNamespace
+	// isn't set. Set programmatically with ApplySpecNamespace.
+	SpecNamespace string
+}
+
+// SpecServer is an entry of the OpenAPI spec's servers list
+type SpecServer struct {
+	Name        string // x-name extension, if set
+	Environment string // x-environment extension, if set
+	URL         string // Server URL with its variables set to their defaults
+	Description string
+}
+
+// SpecWebhook is an operation of the OpenAPI spec's webhooks object, a request the REST
+// API sends when something happens on its side
+type SpecWebhook struct {
+	Name             string // Key in the webhooks object, e.g. "newPet"
+	Method           string // e.g. POST
+	OperationID      string
+	Summary          string
+	PayloadSchemaRef string // Component schema of the JSON payload, if it uses $ref
+	Kind             string // x-k8s-kind extension, if set
+}
+
+// HTTPTransportConfig holds connection pooling settings for the generated controllers' HTTP client
+type HTTPTransportConfig struct {
+	// MaxIdleConns limits idle (keep-alive) connections, both in total and per host.
+	// Default: 100.
+	MaxIdleConns int
+	// MaxConnsPerHost limits the total connections per host.
+	// Default: 0 (no limit).
+	MaxConnsPerHost int
+	// IdleConnTimeout is how long an idle connection is kept open.
+	// Default: 90s.
+	IdleConnTimeout time.Duration
+	// HTTP2 enables HTTP/2 for requests to the REST API; false forces HTTP/1.1.
+	// Default: nil (enabled); an explicit --http2=true is kept over http2: false in the config file.
+	HTTP2 *bool
 }
 
+// HTTP2Enabled reports whether HTTP/2 is enabled, which it is unless HTTP2 is set to false
+func (t HTTPTransportConfig) HTTP2Enabled() bool {
+	return t.HTTP2 == nil || *t.HTTP2
+}
+
+// SecurityContextConfig relaxes the manager Deployment's securityContext. By default the pod
+// runs as non-root with the RuntimeDefault seccomp profile, and the container has a read-only
+// root filesystem, no privilege escalation and all capabilities dropped.
+type SecurityContextConfig struct {
+	// AllowRunAsRoot drops runAsNonRoot, for images whose user is root.
+	AllowRunAsRoot bool
+	// WritableRootFilesystem drops readOnlyRootFilesystem, for managers that write to disk.
+	WritableRootFilesystem bool
+	// AddCapabilities are added back after dropping ALL (e.g., NET_BIND_SERVICE).
+	AddCapabilities []string
+	// SeccompProfile is the pod's seccomp profile type.
+	// Default: RuntimeDefault.
+	SeccompProfile string
+}
+
+// Spec formats accepted by SpecFormat
+const (
+	// SpecFormatAuto tries JSON first and falls back to YAML
+	SpecFormatAuto = "auto"
+	// SpecFormatYAML decodes the spec as YAML only
+	SpecFormatYAML = "yaml"
+	// SpecFormatJSON decodes the spec as JSON only
+	SpecFormatJSON = "json"
+)
+
+// DefaultSeccompProfile is the seccomp profile type of the generated manager pod
+const DefaultSeccompProfile = "RuntimeDefault"
+
+// HAReplicas is the manager Deployment's replica count with HighAvailability
+const HAReplicas = 2
+
+// Default HTTP transport settings, the ones the controller runtime library applies
+const (
+	DefaultHTTPMaxIdleConns    = controller.DefaultMaxIdleConns
+	DefaultHTTPIdleConnTimeout = controller.DefaultIdleConnTimeout
+)
+
+// DefaultSlowReconcileThreshold is the reconcile duration above which the generated
+// controllers emit a SlowReconcile Warning event
+const DefaultSlowReconcileThreshold = 10 * time.Second
+
+// Default rate limit of the generated operator's Kubernetes client
+const (
+	DefaultKubeClientQPS   = 20
+	DefaultKubeClientBurst = 30
+)
+
+// DefaultPprofAddr is the bind address of the generated manager's pprof handler
+const DefaultPprofAddr = "127.0.0.1:6060"
+
+// Default images of the generated Dockerfile
+const (
+	DefaultBaseImage    = "golang:1.25"
+	DefaultRuntimeImage = "gcr.io/distroless/static:nonroot"
+)
+
 // Validate checks if the configuration is valid
 func (c *Config) Validate() error {
 	if c.SpecPath == "" {
@@ -151,16 +583,541 @@ func (c *Config) Validate() error {
 	if c.MappingMode == "" {
 		c.MappingMode = PerResource
 	}
+	switch c.SpecFormat {
+	case "":
+		c.SpecFormat = SpecFormatAuto
+	case SpecFormatAuto, SpecFormatYAML, SpecFormatJSON:
+	default:
+		return &ValidationError{Field: "SpecFormat", Message: "spec format must be auto, yaml or json"}
+	}
+	switch c.ControllerFileNaming {
+	case "":
+		c.ControllerFileNaming = ControllerFileNamingKind
+	case ControllerFileNamingKind, ControllerFileNamingOperationID:
+	default:
+		return &ValidationError{Field: "ControllerFileNaming", Message: "controller file naming must be kind or operation-id"}
+	}
+	switch c.FieldNameCase {
+	case "":
+		c.FieldNameCase = FieldNameCaseCamel
+	case FieldNameCaseCamel, FieldNameCaseOriginal, FieldNameCaseSnake:
+	default:
+		return &ValidationError{Field: "FieldNameCase", Message: "field name case must be camel, original or snake"}
+	}
+	if c.GenerateKrewManifest && !c.GenerateKubectlPlugin {
+		return &ValidationError{Field: "GenerateKrewManifest", Message: "krew manifest requires the kubectl plugin"}
+	}
 	if c.ModuleName == "" {
 		c.ModuleName = "github.com/bluecontainer/generated-operator"
 	}
+	if c.PriorityClassName != "" {
+		if errs := validation.IsDNS1123Subdomain(c.PriorityClassName); len(errs) > 0 {
+			return &ValidationError{Field: "PriorityClassName", Message: fmt.Sprintf("invalid priority class name %q: %s", c.PriorityClassName, strings.Join(errs, "; "))}
+		}
+	}
+	for _, namespace := range c.WatchNamespaces {
+		if err := ValidateNamespace(namespace); err != nil {
+			return &ValidationError{Field: "WatchNamespaces", Message: err.Error()}
+		}
+	}
+	if c.SampleNamespace != "" {
+		if err := ValidateNamespace(c.SampleNamespace); err != nil {
+			return &ValidationError{Field: "SampleNamespace", Message: err.Error()}
+		}
+	}
+	if c.TypesOnly {
+		if err := c.validateTypesOnly(); err != nil {
+			return err
+		}
+	}
+	if c.AppendTo != "" {
+		if err := c.validateAppendTo(); err != nil {
+			return err
+		}
+		c.OutputDir = c.AppendTo
+	}
+	if c.IdempotencyHeader != "" && !validHeaderName(c.IdempotencyHeader) {
+		return &ValidationError{Field: "IdempotencyHeader", Message: fmt.Sprintf("invalid header name %q", c.IdempotencyHeader)}
+	}
+	if c.AcceptHeader != "" {
+		for _, mediaRange := range strings.Split(c.AcceptHeader, ",") {
+			if mediaType, _, err := mime.ParseMediaType(mediaRange); err != nil || !strings.Contains(mediaType, "/") {
+				return &ValidationError{Field: "AcceptHeader", Message: fmt.Sprintf("invalid Accept header %q: expected media types such as application/json", c.AcceptHeader)}
+			}
+		}
+	}
+	if c.GroupKindPluralCheck {
+		if err := ValidateGroup(c.APIGroup); err != nil {
+			return &ValidationError{Field: "APIGroup", Message: err.Error()}
+		}
+		if errs := validation.IsDNS1035Label(c.APIVersion); len(errs) > 0 {
+			return &ValidationError{Field: "APIVersion", Message: fmt.Sprintf("invalid API version %q: %s", c.APIVersion, strings.Join(errs, "; "))}
+		}
+	}
+	for _, entry := range c.ExcludeStatusFields {
+		if err := validateExcludedStatusField(entry); err != nil {
+			return &ValidationError{Field: "ExcludeStatusFields", Message: err.Error()}
+		}
+	}
+	if c.FinalizerName == "" {
+		c.FinalizerName = DefaultFinalizerName(c.APIGroup)
+	} else if err := validateFinalizerName(c.FinalizerName); err != nil {
+		return &ValidationError{Field: "FinalizerName", Message: err.Error()}
+	}
 	// Derive RootKind from spec file name if not provided
 	if c.RootKind == "" {
 		c.RootKind = c.deriveRootKindFromSpecPath()
 	}
+	if c.HTTPTransport.MaxIdleConns < 0 || c.HTTPTransport.MaxConnsPerHost < 0 || c.HTTPTransport.IdleConnTimeout < 0 {
+		return &ValidationError{Field: "HTTPTransport", Message: "HTTP transport settings must not be negative"}
+	}
+	if c.HTTPTransport.MaxIdleConns == 0 {
+		c.HTTPTransport.MaxIdleConns = DefaultHTTPMaxIdleConns
+	}
+	if c.HTTPTransport.IdleConnTimeout == 0 {
+		c.HTTPTransport.IdleConnTimeout = DefaultHTTPIdleConnTimeout
+	}
+	if c.SlowReconcileThreshold < 0 {
+		return &ValidationError{Field: "SlowReconcileThreshold", Message: "slow reconcile threshold must not be negative"}
+	}
+	if c.SlowReconcileThreshold == 0 {
+		c.SlowReconcileThreshold = DefaultSlowReconcileThreshold
+	}
+	if c.KubeClientQPS < 0 || c.KubeClientBurst < 0 {
+		return &ValidationError{Field: "KubeClientQPS", Message: "Kubernetes client QPS and burst must not be negative"}
+	}
+	if c.KubeClientQPS == 0 {
+		c.KubeClientQPS = DefaultKubeClientQPS
+	}
+	if c.KubeClientBurst == 0 {
+		c.KubeClientBurst = DefaultKubeClientBurst
+	}
+	if c.DeleteTimeout < 0 {
+		return &ValidationError{Field: "DeleteTimeout", Message: "delete timeout must not be negative"}
+	}
+	if c.OrphanOnDeleteTimeout && c.DeleteTimeout == 0 {
+		return &ValidationError{Field: "OrphanOnDeleteTimeout", Message: "orphan on delete timeout requires a delete timeout"}
+	}
+	if err := c.validateConditionTypes(); err != nil {
+		return err
+	}
+	if c.PprofAddr == "" {
+		c.PprofAddr = DefaultPprofAddr
+	}
+	if c.BaseImage == "" {
+		c.BaseImage = DefaultBaseImage
+	}
+	if c.RuntimeImage == "" {
+		c.RuntimeImage = DefaultRuntimeImage
+	}
+	if c.MaxQueryResults < 0 {
+		return &ValidationError{Field: "MaxQueryResults", Message: "status result limit must not be negative"}
+	}
+	if c.MaxCRDs < 0 {
+		return &ValidationError{Field: "MaxCRDs", Message: "max CRDs must not be negative"}
+	}
+	for kind, plural := range c.PluralOverrides {
+		if err := ValidatePlural(plural); err != nil {
+			return &ValidationError{Field: "PluralOverrides", Message: fmt.Sprintf("%s: %v", kind, err)}
+		}
+	}
+	for param, value := range c.ConstantPathParams {
+		if param == "" || value == "" {
+			return &ValidationError{Field: "ConstantPathParams", Message: fmt.Sprintf("%q=%q: constant path params need a name and a value", param, value)}
+		}
+	}
+	if len(c.SuccessCodes) > 0 {
+		normalized := make(map[string][]int, len(c.SuccessCodes))
+		for method, codes := range c.SuccessCodes {
+			normalized[strings.ToUpper(method)] = codes
+		}
+		c.SuccessCodes = normalized
+	}
+	if err := validateSuccessCodes(c.SuccessCodes); err != nil {
+		return &ValidationError{Field: "SuccessCodes", Message: err.Error()}
+	}
+	if c.PauseConfigMapRef != "" {
+		namespace, name, found := strings.Cut(c.PauseConfigMapRef, "/")
+		if namespace == "" || (found && name == "") || strings.Contains(name, "/") {
+			return &ValidationError{Field: "PauseConfigMapRef", Message: "pause ConfigMap must be namespace/name or name"}
+		}
+	}
+	return nil
+}
+
+// DefaultFinalizerName returns the finalizer name used when none is configured
+func DefaultFinalizerName(apiGroup string) string {
+	return apiGroup + "/finalizer"
+}
+
+// ResolvedFinalizerName returns FinalizerName, or the default for the API group when unset
+func (c *Config) ResolvedFinalizerName() string {
+	if c.FinalizerName == "" {
+		return DefaultFinalizerName(c.APIGroup)
+	}
+	return c.FinalizerName
+}
+
+// validateTypesOnly rejects the options that need the operator scaffold TypesOnly skips
+func (c *Config) validateTypesOnly() error {
+	conflicts := []struct {
+		set  bool
+		name string
+	}{
+		{c.GenerateKubectlPlugin, "kubectl plugin"},
+		{c.GenerateRundeckProject, "Rundeck project"},
+		{c.GenerateDashboard, "dashboard"},
+		{c.GenerateWebhookPatches, "webhook patches"},
+		{c.GenerateTilt, "Tiltfile"},
+		{c.GenerateE2E, "e2e test"},
+		{c.GenerateWebhookReceiver, "webhook receiver"},
+		{c.TargetAPIImage != "", "target API image"},
+	}
+	for _, conflict := range conflicts {
+		if conflict.set {
+			return &ValidationError{Field: "TypesOnly", Message: fmt.Sprintf("types-only generation can't be combined with the %s, which needs the operator scaffold", conflict.name)}
+		}
+	}
 	return nil
 }
 
+// validateAppendTo rejects options that would write files outside the new CRDs' types and
+// controllers when appending to an existing operator
+func (c *Config) validateAppendTo() error {
+	conflicts := []struct {
+		set  bool
+		name string
+	}{
+		{c.TypesOnly, "types-only mode"},
+		{c.GenerateAggregate, "aggregate CRD"},
+		{c.GenerateBundle, "bundle CRD"},
+		{c.GenerateKubectlPlugin, "kubectl plugin"},
+		{c.GenerateRundeckProject, "Rundeck project"},
+		{c.GenerateDashboard, "dashboard"},
+		{c.GenerateWebhookPatches, "webhook patches"},
+		{c.GenerateTilt, "Tiltfile"},
+		{c.GenerateWebhookReceiver, "webhook receiver"},
+		{c.TargetAPIImage != "", "target API image"},
+		{c.EmbedSpec, "embedded spec"},
+		{c.MergeControllers, "controller merge mode"},
+		{c.GitInit, "git init"},
+		{c.PatchExistingCRDs, "migration guide"},
+		{c.ImportPrefix != "", "output module path"},
+	}
+	for _, conflict := range conflicts {
+		if conflict.set {
+			return &ValidationError{Field: "AppendTo", Message: fmt.Sprintf("appending to an existing operator can't be combined with the %s, which rewrites the operator scaffold", conflict.name)}
+		}
+	}
+	return nil
+}
+
+// validHeaderName reports whether name is an HTTP header field name (an RFC 7230 token)
+func validHeaderName(name string) bool {
+	if name == "" {
+		return false
+	}
+	for _, r := range name {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+		case strings.ContainsRune("!#$%&'*+-.^_`|~", r):
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// validateFinalizerName checks that name is a domain-qualified name such as example.com/cleanup,
+// which is what the API server expects for finalizers.
+func validateFinalizerName(name string) error {
+	if !strings.Contains(name, "/") {
+		return fmt.Errorf("finalizer name %q must be domain-qualified (e.g., example.com/finalizer)", name)
+	}
+	if errs := validation.IsQualifiedName(name); len(errs) > 0 {
+		return fmt.Errorf("invalid finalizer name %q: %s", name, strings.Join(errs, "; "))
+	}
+	return nil
+}
+
+// ProtectedStatusFields are the status fields ExcludeStatusFields can't remove: the
+// standard conditions and observedGeneration, and the fields the controllers read back
+// on later reconciles
+var ProtectedStatusFields = map[string]bool{
+	"conditions":          true,
+	"observedGeneration":  true,
+	"state":               true,
+	"externalID":          true,
+	"etag":                true,
+	"driftDetected":       true,
+	"createdByController": true,
+	"originalState":       true,
+	"lastQueryTime":       true,
+	"lastExecutionTime":   true,
+	"dataFromVersion":     true,
+	"executionCount":      true,
+}
+
+// ParseExcludedStatusField splits an ExcludeStatusFields entry into its Kind ("" for a
+// global entry) and status field name
+func ParseExcludedStatusField(entry string) (kind, field string) {
+	if kind, field, ok := strings.Cut(entry, "."); ok {
+		return kind, field
+	}
+	return "", entry
+}
+
+func validateExcludedStatusField(entry string) error {
+	kind, field := ParseExcludedStatusField(entry)
+	if field == "" || strings.Contains(field, ".") || (kind == "" && strings.Contains(entry, ".")) {
+		return fmt.Errorf("invalid status field %q: expected a field name or Kind.field", entry)
+	}
+	if ProtectedStatusFields[field] {
+		return fmt.Errorf("status field %q can't be excluded: the controllers depend on it", field)
+	}
+	return nil
+}
+
+// Meanings of the status conditions the generated controllers set, the keys of
+// Config.ConditionTypes
+const (
+	// ConditionReady is true when the external resource matches the CR
+	ConditionReady = "ready"
+	// ConditionReconciling is true while the controller is creating or updating it
+	ConditionReconciling = "reconciling"
+	// ConditionStalled is true when the last reconcile failed
+	ConditionStalled = "stalled"
+)
+
+// DefaultConditionTypes are the condition types set for each meaning unless
+// Config.ConditionTypes renames them; Reconciling and Stalled follow kstatus
+var DefaultConditionTypes = map[string]string{
+	ConditionReady:       "Ready",
+	ConditionReconciling: "Reconciling",
+	ConditionStalled:     "Stalled",
+}
+
+// ConditionType returns the condition type set for a meaning (ConditionReady,
+// ConditionReconciling or ConditionStalled)
+func (c *Config) ConditionType(meaning string) string {
+	if name := c.ConditionTypes[meaning]; name != "" {
+		return name
+	}
+	return DefaultConditionTypes[meaning]
+}
+
+// validateConditionTypes checks that ConditionTypes only renames known meanings, to valid
+// and distinct condition types
+func (c *Config) validateConditionTypes() error {
+	for meaning, name := range c.ConditionTypes {
+		if _, ok := DefaultConditionTypes[meaning]; !ok {
+			return &ValidationError{Field: "ConditionTypes", Message: fmt.Sprintf("unknown condition %q: must be %s, %s or %s", meaning, ConditionReady, ConditionReconciling, ConditionStalled)}
+		}
+		if err := ValidateConditionType(name); err != nil {
+			return &ValidationError{Field: "ConditionTypes", Message: fmt.Sprintf("%s: %v", meaning, err)}
+		}
+	}
+	seen := make(map[string]string, len(DefaultConditionTypes))
+	for _, meaning := range []string{ConditionReady, ConditionReconciling, ConditionStalled} {
+		name := c.ConditionType(meaning)
+		if other, ok := seen[name]; ok {
+			return &ValidationError{Field: "ConditionTypes", Message: fmt.Sprintf("%s and %s both use the condition type %q", other, meaning, name)}
+		}
+		seen[name] = meaning
+	}
+	return nil
+}
+
+// ValidateConditionType checks that a condition type is a valid metav1.Condition type: a
+// qualified name such as "Available" or "example.com/Degraded"
+func ValidateConditionType(name string) error {
+	if errs := validation.IsQualifiedName(name); len(errs) > 0 {
+		return fmt.Errorf("invalid condition type %q: %s", name, strings.Join(errs, "; "))
+	}
+	return nil
+}
+
+// ValidatePlural checks that a CRD plural (from PluralOverrides or x-k8s-plural) is a
+// valid resource name: a lowercase DNS-1035 label such as "data" or "policies"
+func ValidatePlural(plural string) error {
+	if errs := validation.IsDNS1035Label(plural); len(errs) > 0 {
+		return fmt.Errorf("invalid plural %q: %s", plural, strings.Join(errs, "; "))
+	}
+	return nil
+}
+
+// ValidateGroup checks that an API group is a DNS-1123 subdomain with at least one dot,
+// as CRDs require, such as "petstore.example.com"
+func ValidateGroup(group string) error {
+	if errs := validation.IsDNS1123Subdomain(group); len(errs) > 0 {
+		return fmt.Errorf("invalid API group %q: %s", group, strings.Join(errs, "; "))
+	}
+	if !strings.Contains(group, ".") {
+		return fmt.Errorf("invalid API group %q: must contain at least one dot, e.g. %s.example.com", group, group)
+	}
+	return nil
+}
+
+// ValidateKind checks that a Kind is PascalCase: an uppercase ASCII letter followed by
+// letters and digits, short enough that its lowercase singular is a valid resource name
+func ValidateKind(kind string) error {
+	if kind == "" || kind[0] < 'A' || kind[0] > 'Z' {
+		return fmt.Errorf("invalid Kind %q: must start with an uppercase letter", kind)
+	}
+	for _, r := range kind {
+		if !(r >= 'A' && r <= 'Z' || r >= 'a' && r <= 'z' || r >= '0' && r <= '9') {
+			return fmt.Errorf("invalid Kind %q: must contain only letters and digits", kind)
+		}
+	}
+	if len(kind) > validation.DNS1035LabelMaxLength {
+		return fmt.Errorf("invalid Kind %q: must be no more than %d characters", kind, validation.DNS1035LabelMaxLength)
+	}
+	return nil
+}
+
+// ValidateCRDName checks that a CRD's name, "<plural>.<group>", is a DNS-1123 subdomain
+func ValidateCRDName(plural, group string) error {
+	name := plural + "." + group
+	if errs := validation.IsDNS1123Subdomain(name); len(errs) > 0 {
+		return fmt.Errorf("invalid CRD name %q: %s", name, strings.Join(errs, "; "))
+	}
+	return nil
+}
+
+// ValidateShortName checks that a CRD short name is a lowercase DNS-1035 label such as "pe"
+func ValidateShortName(shortName string) error {
+	if errs := validation.IsDNS1035Label(shortName); len(errs) > 0 {
+		return fmt.Errorf("invalid short name %q: %s", shortName, strings.Join(errs, "; "))
+	}
+	return nil
+}
+
+// ValidateNamespace checks that a namespace name is a DNS-1123 label such as "team-a"
+func ValidateNamespace(namespace string) error {
+	if errs := validation.IsDNS1123Label(namespace); len(errs) > 0 {
+		return fmt.Errorf("invalid namespace %q: %s", namespace, strings.Join(errs, "; "))
+	}
+	return nil
+}
+
+// ApplySpecNamespace records the spec's x-k8s-namespace extension after checking it
+func (c *Config) ApplySpecNamespace(namespace string) error {
+	if namespace != "" {
+		if err := ValidateNamespace(namespace); err != nil {
+			return fmt.Errorf("x-k8s-namespace: %w", err)
+		}
+	}
+	c.SpecNamespace = namespace
+	return nil
+}
+
+// ApplyEnvironment sets SpecBaseURL to the URL of the first spec server whose
+// x-environment matches Environment, compared case-insensitively. It must run after
+// SpecServers is set, and does nothing when Environment is empty.
+func (c *Config) ApplyEnvironment() error {
+	if c.Environment == "" {
+		return nil
+	}
+	server, ok := c.EnvironmentServer()
+	if !ok {
+		var environments []string
+		for _, s := range c.SpecServers {
+			if s.Environment != "" && !slices.Contains(environments, s.Environment) {
+				environments = append(environments, s.Environment)
+			}
+		}
+		if len(environments) == 0 {
+			return fmt.Errorf("no server has x-environment %q: the spec's servers declare no x-environment", c.Environment)
+		}
+		return fmt.Errorf("no server has x-environment %q; available: %s", c.Environment, strings.Join(environments, ", "))
+	}
+	c.SpecBaseURL = server.URL
+	return nil
+}
+
+// EnvironmentServer returns the first spec server whose x-environment matches Environment
+func (c *Config) EnvironmentServer() (SpecServer, bool) {
+	for _, s := range c.SpecServers {
+		if s.Environment != "" && strings.EqualFold(s.Environment, c.Environment) {
+			return s, true
+		}
+	}
+	return SpecServer{}, false
+}
+
+// ResolvedSampleNamespace returns SampleNamespace, else the spec's x-k8s-namespace,
+// else "default"
+func (c *Config) ResolvedSampleNamespace() string {
+	switch {
+	case c.SampleNamespace != "":
+		return c.SampleNamespace
+	case c.SpecNamespace != "":
+		return c.SpecNamespace
+	}
+	return "default"
+}
+
+// ResolvedImportPrefix returns ImportPrefix, or ModuleName when unset
+func (c *Config) ResolvedImportPrefix() string {
+	if c.ImportPrefix == "" {
+		return c.ModuleName
+	}
+	return c.ImportPrefix
+}
+
+// APIDir returns the directory of the API types package relative to the output directory:
+// api/<version>, or api/<group>/<version> (the multi-group layout) when appending to an
+// existing operator
+func (c *Config) APIDir() string {
+	if c.AppendTo != "" {
+		return path.Join("api", GroupDirName(c.APIGroup), c.APIVersion)
+	}
+	return path.Join("api", c.APIVersion)
+}
+
+// JSONFieldName returns the JSON name of the CR field for an API property or parameter
+// name, following FieldNameCase
+func (c *Config) JSONFieldName(name string) string {
+	switch c.FieldNameCase {
+	case FieldNameCaseOriginal:
+		return name
+	case FieldNameCaseSnake:
+		return strcase.ToSnake(name)
+	}
+	return strcase.ToLowerCamel(name)
+}
+
+// MainPath returns the manager entrypoint relative to the output directory:
+// cmd/manager/main.go, or cmd/main.go in the kubebuilder layout
+func (c *Config) MainPath() string {
+	if c.KubebuilderLayout {
+		return "cmd/main.go"
+	}
+	return "cmd/manager/main.go"
+}
+
+// APIImportPath returns the import path of the API types package
+func (c *Config) APIImportPath() string {
+	return c.ResolvedImportPrefix() + "/" + c.APIDir()
+}
+
+// GroupDirName returns the directory name of an API group in the multi-group layout: its
+// first label, reduced to lowercase letters and digits (e.g., "billing" for billing.example.com)
+func GroupDirName(group string) string {
+	label, _, _ := strings.Cut(strings.ToLower(group), ".")
+	var b strings.Builder
+	for _, r := range label {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			b.WriteRune(r)
+		}
+	}
+	name := b.String()
+	if name == "" || (name[0] >= '0' && name[0] <= '9') {
+		name = "group" + name
+	}
+	return name
+}
+
 // ShouldUpdateWithPost checks if a given path should use POST for updates.
 // Returns true if:
 // - UpdateWithPost contains "*" (all resources)
@@ -1,7 +1,10 @@
 package config
 
 import (
+	"reflect"
+	"strings"
 	"testing"
+	"time"
 )
 
 func TestConfig_Validate(t *testing.T) {
@@ -96,6 +99,508 @@ func TestConfig_Validate(t *testing.T) {
 	}
 }
 
+func TestConfig_Validate_HTTPTransport(t *testing.T) {
+	cfg := Config{SpecPath: "/spec.yaml", OutputDir: "/out", APIGroup: "test.example.com"}
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("Validate() unexpected error: %v", err)
+	}
+	if cfg.HTTPTransport.MaxIdleConns != DefaultHTTPMaxIdleConns {
+		t.Errorf("MaxIdleConns = %d, want %d", cfg.HTTPTransport.MaxIdleConns, DefaultHTTPMaxIdleConns)
+	}
+	if cfg.HTTPTransport.IdleConnTimeout != DefaultHTTPIdleConnTimeout {
+		t.Errorf("IdleConnTimeout = %v, want %v", cfg.HTTPTransport.IdleConnTimeout, DefaultHTTPIdleConnTimeout)
+	}
+	if cfg.HTTPTransport.MaxConnsPerHost != 0 {
+		t.Errorf("MaxConnsPerHost = %d, want 0", cfg.HTTPTransport.MaxConnsPerHost)
+	}
+
+	cfg = Config{
+		SpecPath:      "/spec.yaml",
+		OutputDir:     "/out",
+		APIGroup:      "test.example.com",
+		HTTPTransport: HTTPTransportConfig{MaxConnsPerHost: -1},
+	}
+	err := cfg.Validate()
+	valErr, ok := err.(*ValidationError)
+	if !ok || valErr.Field != "HTTPTransport" {
+		t.Errorf("Validate() expected HTTPTransport error, got %v", err)
+	}
+}
+
+func TestConfig_Validate_KubeClient(t *testing.T) {
+	cfg := Config{SpecPath: "/spec.yaml", OutputDir: "/out", APIGroup: "test.example.com"}
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("Validate() unexpected error: %v", err)
+	}
+	if cfg.KubeClientQPS != DefaultKubeClientQPS || cfg.KubeClientBurst != DefaultKubeClientBurst {
+		t.Errorf("KubeClientQPS, KubeClientBurst = %v, %d, want %v, %d", cfg.KubeClientQPS, cfg.KubeClientBurst, DefaultKubeClientQPS, DefaultKubeClientBurst)
+	}
+
+	cfg = Config{SpecPath: "/spec.yaml", OutputDir: "/out", APIGroup: "test.example.com", KubeClientQPS: 100}
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("Validate() unexpected error: %v", err)
+	}
+	if cfg.KubeClientQPS != 100 || cfg.KubeClientBurst != DefaultKubeClientBurst {
+		t.Errorf("KubeClientQPS, KubeClientBurst = %v, %d, want 100, %d", cfg.KubeClientQPS, cfg.KubeClientBurst, DefaultKubeClientBurst)
+	}
+
+	cfg = Config{SpecPath: "/spec.yaml", OutputDir: "/out", APIGroup: "test.example.com", KubeClientBurst: -1}
+	err := cfg.Validate()
+	valErr, ok := err.(*ValidationError)
+	if !ok || valErr.Field != "KubeClientQPS" {
+		t.Errorf("Validate() expected KubeClientQPS error, got %v", err)
+	}
+}
+
+func TestConfig_Validate_PprofAddr(t *testing.T) {
+	cfg := Config{SpecPath: "/spec.yaml", OutputDir: "/out", APIGroup: "test.example.com"}
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("Validate() unexpected error: %v", err)
+	}
+	if cfg.PprofAddr != DefaultPprofAddr {
+		t.Errorf("PprofAddr = %q, want %q", cfg.PprofAddr, DefaultPprofAddr)
+	}
+
+	cfg = Config{SpecPath: "/spec.yaml", OutputDir: "/out", APIGroup: "test.example.com", PprofAddr: ":6061"}
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("Validate() unexpected error: %v", err)
+	}
+	if cfg.PprofAddr != ":6061" {
+		t.Errorf("PprofAddr = %q, want %q", cfg.PprofAddr, ":6061")
+	}
+}
+
+func TestConfig_Validate_Images(t *testing.T) {
+	cfg := Config{SpecPath: "/spec.yaml", OutputDir: "/out", APIGroup: "test.example.com"}
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("Validate() unexpected error: %v", err)
+	}
+	if cfg.BaseImage != DefaultBaseImage || cfg.RuntimeImage != DefaultRuntimeImage {
+		t.Errorf("images = %q, %q, want %q, %q", cfg.BaseImage, cfg.RuntimeImage, DefaultBaseImage, DefaultRuntimeImage)
+	}
+
+	cfg = Config{SpecPath: "/spec.yaml", OutputDir: "/out", APIGroup: "test.example.com", RuntimeImage: "gcr.io/distroless/base:nonroot"}
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("Validate() unexpected error: %v", err)
+	}
+	if cfg.RuntimeImage != "gcr.io/distroless/base:nonroot" {
+		t.Errorf("RuntimeImage = %q, want %q", cfg.RuntimeImage, "gcr.io/distroless/base:nonroot")
+	}
+}
+
+func TestConfig_Validate_SlowReconcileThreshold(t *testing.T) {
+	cfg := Config{SpecPath: "/spec.yaml", OutputDir: "/out", APIGroup: "test.example.com"}
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("Validate() unexpected error: %v", err)
+	}
+	if cfg.SlowReconcileThreshold != DefaultSlowReconcileThreshold {
+		t.Errorf("SlowReconcileThreshold = %v, want %v", cfg.SlowReconcileThreshold, DefaultSlowReconcileThreshold)
+	}
+
+	cfg = Config{SpecPath: "/spec.yaml", OutputDir: "/out", APIGroup: "test.example.com", SlowReconcileThreshold: -time.Second}
+	err := cfg.Validate()
+	valErr, ok := err.(*ValidationError)
+	if !ok || valErr.Field != "SlowReconcileThreshold" {
+		t.Errorf("Validate() expected SlowReconcileThreshold error, got %v", err)
+	}
+}
+
+func TestConfig_Validate_DeleteTimeout(t *testing.T) {
+	tests := []struct {
+		name      string
+		timeout   time.Duration
+		orphan    bool
+		wantField string
+	}{
+		{name: "disabled"},
+		{name: "retry", timeout: 10 * time.Minute},
+		{name: "orphan", timeout: 10 * time.Minute, orphan: true},
+		{name: "negative", timeout: -time.Second, wantField: "DeleteTimeout"},
+		{name: "orphan without timeout", orphan: true, wantField: "OrphanOnDeleteTimeout"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := Config{SpecPath: "/spec.yaml", OutputDir: "/out", APIGroup: "test.example.com",
+				DeleteTimeout: tt.timeout, OrphanOnDeleteTimeout: tt.orphan}
+			err := cfg.Validate()
+			if tt.wantField == "" {
+				if err != nil {
+					t.Errorf("Validate() unexpected error: %v", err)
+				}
+				return
+			}
+			valErr, ok := err.(*ValidationError)
+			if !ok || valErr.Field != tt.wantField {
+				t.Errorf("Validate() expected %s error, got %v", tt.wantField, err)
+			}
+		})
+	}
+}
+
+func TestConfig_Validate_ConditionTypes(t *testing.T) {
+	tests := []struct {
+		name       string
+		conditions map[string]string
+		wantErr    string
+	}{
+		{name: "defaults"},
+		{name: "renamed", conditions: map[string]string{"ready": "Available", "stalled": "Degraded"}},
+		{name: "unknown meaning", conditions: map[string]string{"healthy": "Available"}, wantErr: `unknown condition "healthy"`},
+		{name: "invalid name", conditions: map[string]string{"ready": "not ready"}, wantErr: "invalid condition type"},
+		{name: "duplicate name", conditions: map[string]string{"stalled": "Ready"}, wantErr: "Ready"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := Config{SpecPath: "/spec.yaml", OutputDir: "/out", APIGroup: "test.example.com",
+				ConditionTypes: tt.conditions}
+			err := cfg.Validate()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("Validate() unexpected error: %v", err)
+				}
+				return
+			}
+			valErr, ok := err.(*ValidationError)
+			if !ok || valErr.Field != "ConditionTypes" || !strings.Contains(valErr.Message, tt.wantErr) {
+				t.Errorf("Validate() expected ConditionTypes error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+
+	cfg := Config{ConditionTypes: map[string]string{"ready": "Available"}}
+	if got := cfg.ConditionType(ConditionReady); got != "Available" {
+		t.Errorf("ConditionType(ready) = %q, want Available", got)
+	}
+	if got := cfg.ConditionType(ConditionStalled); got != "Stalled" {
+		t.Errorf("ConditionType(stalled) = %q, want Stalled", got)
+	}
+}
+
+func TestConfig_Validate_PluralOverrides(t *testing.T) {
+	cfg := Config{SpecPath: "/spec.yaml", OutputDir: "/out", APIGroup: "test.example.com",
+		PluralOverrides: map[string]string{"Datum": "data"}}
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("Validate() unexpected error: %v", err)
+	}
+
+	cfg = Config{SpecPath: "/spec.yaml", OutputDir: "/out", APIGroup: "test.example.com",
+		PluralOverrides: map[string]string{"Datum": "Data"}}
+	err := cfg.Validate()
+	valErr, ok := err.(*ValidationError)
+	if !ok || valErr.Field != "PluralOverrides" {
+		t.Errorf("Validate() expected PluralOverrides error, got %v", err)
+	}
+}
+
+func TestConfig_Validate_ConstantPathParams(t *testing.T) {
+	cfg := Config{SpecPath: "/spec.yaml", OutputDir: "/out", APIGroup: "test.example.com",
+		ConstantPathParams: map[string]string{"version": "v1"}}
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("Validate() unexpected error: %v", err)
+	}
+
+	cfg = Config{SpecPath: "/spec.yaml", OutputDir: "/out", APIGroup: "test.example.com",
+		ConstantPathParams: map[string]string{"version": ""}}
+	err := cfg.Validate()
+	valErr, ok := err.(*ValidationError)
+	if !ok || valErr.Field != "ConstantPathParams" {
+		t.Errorf("Validate() expected ConstantPathParams error, got %v", err)
+	}
+}
+
+func TestConfig_Validate_PriorityClassName(t *testing.T) {
+	cfg := Config{SpecPath: "/spec.yaml", OutputDir: "/out", APIGroup: "test.example.com",
+		HighAvailability: true, PriorityClassName: "system-cluster-critical"}
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("Validate() unexpected error: %v", err)
+	}
+
+	cfg = Config{SpecPath: "/spec.yaml", OutputDir: "/out", APIGroup: "test.example.com",
+		PriorityClassName: "Critical_Class"}
+	err := cfg.Validate()
+	valErr, ok := err.(*ValidationError)
+	if !ok || valErr.Field != "PriorityClassName" {
+		t.Errorf("Validate() expected PriorityClassName error, got %v", err)
+	}
+}
+
+func TestConfig_Validate_WatchNamespaces(t *testing.T) {
+	cfg := Config{SpecPath: "/spec.yaml", OutputDir: "/out", APIGroup: "test.example.com",
+		WatchNamespaces: []string{"team-a", "team-b"}}
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("Validate() unexpected error: %v", err)
+	}
+
+	cfg = Config{SpecPath: "/spec.yaml", OutputDir: "/out", APIGroup: "test.example.com",
+		WatchNamespaces: []string{"team-a", "Team_B"}}
+	err := cfg.Validate()
+	valErr, ok := err.(*ValidationError)
+	if !ok || valErr.Field != "WatchNamespaces" {
+		t.Errorf("Validate() expected WatchNamespaces error, got %v", err)
+	}
+}
+
+func TestConfig_Validate_ControllerFileNaming(t *testing.T) {
+	cfg := Config{SpecPath: "/spec.yaml", OutputDir: "/out", APIGroup: "test.example.com"}
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("Validate() unexpected error: %v", err)
+	}
+	if cfg.ControllerFileNaming != ControllerFileNamingKind {
+		t.Errorf("ControllerFileNaming = %q, want %q", cfg.ControllerFileNaming, ControllerFileNamingKind)
+	}
+
+	cfg = Config{
+		SpecPath:             "/spec.yaml",
+		OutputDir:            "/out",
+		APIGroup:             "test.example.com",
+		ControllerFileNaming: ControllerFileNamingOperationID,
+	}
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("Validate() unexpected error: %v", err)
+	}
+
+	cfg.ControllerFileNaming = "operationId"
+	err := cfg.Validate()
+	valErr, ok := err.(*ValidationError)
+	if !ok || valErr.Field != "ControllerFileNaming" {
+		t.Errorf("Validate() expected ControllerFileNaming error, got %v", err)
+	}
+}
+
+func TestConfig_Validate_FieldNameCase(t *testing.T) {
+	cfg := Config{SpecPath: "/spec.yaml", OutputDir: "/out", APIGroup: "test.example.com"}
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("Validate() unexpected error: %v", err)
+	}
+	if cfg.FieldNameCase != FieldNameCaseCamel {
+		t.Errorf("FieldNameCase = %q, want %q", cfg.FieldNameCase, FieldNameCaseCamel)
+	}
+
+	cfg.FieldNameCase = "kebab"
+	err := cfg.Validate()
+	valErr, ok := err.(*ValidationError)
+	if !ok || valErr.Field != "FieldNameCase" {
+		t.Errorf("Validate() expected FieldNameCase error, got %v", err)
+	}
+}
+
+func TestConfig_Validate_KrewManifest(t *testing.T) {
+	cfg := Config{SpecPath: "/spec.yaml", OutputDir: "/out", APIGroup: "test.example.com", GenerateKrewManifest: true}
+	err := cfg.Validate()
+	valErr, ok := err.(*ValidationError)
+	if !ok || valErr.Field != "GenerateKrewManifest" {
+		t.Errorf("Validate() expected GenerateKrewManifest error, got %v", err)
+	}
+
+	cfg.GenerateKubectlPlugin = true
+	if err := cfg.Validate(); err != nil {
+		t.Errorf("Validate() unexpected error: %v", err)
+	}
+}
+
+func TestConfig_JSONFieldName(t *testing.T) {
+	tests := []struct {
+		fieldNameCase FieldNameCase
+		name          string
+		want          string
+	}{
+		{FieldNameCaseCamel, "pet_name", "petName"},
+		{FieldNameCaseCamel, "petName", "petName"},
+		{FieldNameCaseOriginal, "pet_name", "pet_name"},
+		{FieldNameCaseOriginal, "PetName", "PetName"},
+		{FieldNameCaseSnake, "petName", "pet_name"},
+		{FieldNameCaseSnake, "pet_name", "pet_name"},
+	}
+	for _, tt := range tests {
+		cfg := Config{FieldNameCase: tt.fieldNameCase}
+		if got := cfg.JSONFieldName(tt.name); got != tt.want {
+			t.Errorf("JSONFieldName(%q) with %s = %q, want %q", tt.name, tt.fieldNameCase, got, tt.want)
+		}
+	}
+}
+
+func TestConfig_Validate_FinalizerName(t *testing.T) {
+	cfg := Config{SpecPath: "/spec.yaml", OutputDir: "/out", APIGroup: "test.example.com"}
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("Validate() unexpected error: %v", err)
+	}
+	if cfg.FinalizerName != "test.example.com/finalizer" {
+		t.Errorf("FinalizerName = %q, want %q", cfg.FinalizerName, "test.example.com/finalizer")
+	}
+
+	tests := []struct {
+		name    string
+		wantErr bool
+	}{
+		{"test.example.com/team-a-finalizer", false},
+		{"cleanup.example.com/finalizer", false},
+		{"finalizer", true},                      // not domain-qualified
+		{"test.example.com/", true},              // empty name
+		{"Test_Example/finalizer", true},         // prefix is not a DNS subdomain
+		{"test.example.com/bad finalizer", true}, // invalid character
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := Config{SpecPath: "/spec.yaml", OutputDir: "/out", APIGroup: "test.example.com", FinalizerName: tt.name}
+			err := cfg.Validate()
+			if tt.wantErr {
+				valErr, ok := err.(*ValidationError)
+				if !ok || valErr.Field != "FinalizerName" {
+					t.Errorf("Validate() expected FinalizerName error, got %v", err)
+				}
+			} else if err != nil {
+				t.Errorf("Validate() unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestConfig_Validate_IdempotencyHeader(t *testing.T) {
+	tests := []struct {
+		header  string
+		wantErr bool
+	}{
+		{"Idempotency-Key", false},
+		{"X-Request-Id", false},
+		{"Idempotency Key", true},
+		{"Idempotency-Key:", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.header, func(t *testing.T) {
+			cfg := Config{SpecPath: "/spec.yaml", OutputDir: "/out", APIGroup: "test.example.com", IdempotencyHeader: tt.header}
+			err := cfg.Validate()
+			if tt.wantErr {
+				valErr, ok := err.(*ValidationError)
+				if !ok || valErr.Field != "IdempotencyHeader" {
+					t.Errorf("Validate() expected IdempotencyHeader error, got %v", err)
+				}
+			} else if err != nil {
+				t.Errorf("Validate() unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestConfig_Validate_TypesOnly(t *testing.T) {
+	base := Config{SpecPath: "/spec.yaml", OutputDir: "/out", APIGroup: "test.example.com", TypesOnly: true, GenerateAggregate: true}
+	if err := base.Validate(); err != nil {
+		t.Fatalf("Validate() unexpected error: %v", err)
+	}
+
+	for name, mutate := range map[string]func(*Config){
+		"kubectl plugin": func(c *Config) { c.GenerateKubectlPlugin = true },
+		"dashboard":      func(c *Config) { c.GenerateDashboard = true },
+		"target image":   func(c *Config) { c.TargetAPIImage = "example/api:latest" },
+	} {
+		t.Run(name, func(t *testing.T) {
+			cfg := Config{SpecPath: "/spec.yaml", OutputDir: "/out", APIGroup: "test.example.com", TypesOnly: true}
+			mutate(&cfg)
+			valErr, ok := cfg.Validate().(*ValidationError)
+			if !ok || valErr.Field != "TypesOnly" {
+				t.Errorf("Validate() expected TypesOnly error, got %v", valErr)
+			}
+		})
+	}
+}
+
+func TestConfig_Validate_AppendTo(t *testing.T) {
+	cfg := Config{SpecPath: "/spec.yaml", OutputDir: "/out", APIGroup: "test.example.com", AppendTo: "/operator"}
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("Validate() unexpected error: %v", err)
+	}
+	if cfg.OutputDir != "/operator" {
+		t.Errorf("OutputDir = %q, want the --append-to directory", cfg.OutputDir)
+	}
+
+	for name, mutate := range map[string]func(*Config){
+		"types only":    func(c *Config) { c.TypesOnly = true },
+		"aggregate":     func(c *Config) { c.GenerateAggregate = true },
+		"embed spec":    func(c *Config) { c.EmbedSpec = true },
+		"import prefix": func(c *Config) { c.ImportPrefix = "github.com/example/other" },
+	} {
+		t.Run(name, func(t *testing.T) {
+			cfg := Config{SpecPath: "/spec.yaml", OutputDir: "/out", APIGroup: "test.example.com", AppendTo: "/operator"}
+			mutate(&cfg)
+			valErr, ok := cfg.Validate().(*ValidationError)
+			if !ok || valErr.Field != "AppendTo" {
+				t.Errorf("Validate() expected AppendTo error, got %v", valErr)
+			}
+		})
+	}
+}
+
+func TestConfig_MainPath(t *testing.T) {
+	cfg := Config{}
+	if got := cfg.MainPath(); got != "cmd/manager/main.go" {
+		t.Errorf("MainPath() = %q, want cmd/manager/main.go", got)
+	}
+	cfg.KubebuilderLayout = true
+	if got := cfg.MainPath(); got != "cmd/main.go" {
+		t.Errorf("MainPath() = %q, want cmd/main.go", got)
+	}
+}
+
+func TestConfig_APIDir(t *testing.T) {
+	cfg := Config{APIGroup: "podinfo.example.com", APIVersion: "v1", ModuleName: "github.com/example/op"}
+	if got := cfg.APIDir(); got != "api/v1" {
+		t.Errorf("APIDir() = %q, want api/v1", got)
+	}
+	cfg.AppendTo = "/operator"
+	if got := cfg.APIDir(); got != "api/podinfo/v1" {
+		t.Errorf("APIDir() = %q, want api/podinfo/v1", got)
+	}
+	if got := cfg.APIImportPath(); got != "github.com/example/op/api/podinfo/v1" {
+		t.Errorf("APIImportPath() = %q", got)
+	}
+
+	for group, want := range map[string]string{
+		"podinfo.example.com": "podinfo",
+		"My-API.example.com":  "myapi",
+		"2fa.example.com":     "group2fa",
+	} {
+		if got := GroupDirName(group); got != want {
+			t.Errorf("GroupDirName(%q) = %q, want %q", group, got, want)
+		}
+	}
+}
+
+func TestConfig_ResolvedFinalizerName(t *testing.T) {
+	cfg := Config{APIGroup: "test.example.com"}
+	if got := cfg.ResolvedFinalizerName(); got != "test.example.com/finalizer" {
+		t.Errorf("ResolvedFinalizerName() = %q, want default", got)
+	}
+	cfg.FinalizerName = "test.example.com/custom"
+	if got := cfg.ResolvedFinalizerName(); got != "test.example.com/custom" {
+		t.Errorf("ResolvedFinalizerName() = %q, want %q", got, "test.example.com/custom")
+	}
+}
+
+func TestConfig_SampleNamespace(t *testing.T) {
+	cfg := Config{SpecPath: "/spec.yaml", OutputDir: "/out", APIGroup: "test.example.com", SampleNamespace: "Team_A"}
+	valErr, ok := cfg.Validate().(*ValidationError)
+	if !ok || valErr.Field != "SampleNamespace" {
+		t.Errorf("Validate() expected SampleNamespace error, got %v", valErr)
+	}
+
+	cfg = Config{}
+	if got := cfg.ResolvedSampleNamespace(); got != "default" {
+		t.Errorf("ResolvedSampleNamespace() = %q, want default", got)
+	}
+	if err := cfg.ApplySpecNamespace("bad namespace"); err == nil {
+		t.Error("ApplySpecNamespace() expected error for an invalid namespace")
+	}
+	if err := cfg.ApplySpecNamespace("team-a"); err != nil {
+		t.Fatalf("ApplySpecNamespace() unexpected error: %v", err)
+	}
+	if got := cfg.ResolvedSampleNamespace(); got != "team-a" {
+		t.Errorf("ResolvedSampleNamespace() = %q, want the spec namespace", got)
+	}
+	cfg.SampleNamespace = "team-b"
+	if got := cfg.ResolvedSampleNamespace(); got != "team-b" {
+		t.Errorf("ResolvedSampleNamespace() = %q, want SampleNamespace to win", got)
+	}
+}
+
 func TestConfig_deriveRootKindFromSpecPath(t *testing.T) {
 	tests := []struct {
 		specPath string
@@ -1047,3 +1552,146 @@ func TestConfig_GetIDFieldMapping(t *testing.T) {
 		})
 	}
 }
+
+func TestParseTargetDefault(t *testing.T) {
+	target, err := ParseTargetDefault("namespace=backend, deployment=petstore-api")
+	if err != nil {
+		t.Fatalf("ParseTargetDefault() unexpected error: %v", err)
+	}
+	if target.Deployment != "petstore-api" || target.Namespace != "backend" {
+		t.Errorf("ParseTargetDefault() = %+v", target)
+	}
+	if got := target.String(); got != "deployment=petstore-api,namespace=backend" {
+		t.Errorf("String() = %q", got)
+	}
+
+	for _, invalid := range []string{"", "deployment", "service=api"} {
+		if _, err := ParseTargetDefault(invalid); err == nil {
+			t.Errorf("ParseTargetDefault(%q) expected error", invalid)
+		}
+	}
+}
+
+func TestParseSuccessCodes(t *testing.T) {
+	codes, err := ParseSuccessCodes("delete=204, POST=202|201|201")
+	if err != nil {
+		t.Fatalf("ParseSuccessCodes() unexpected error: %v", err)
+	}
+	want := map[string][]int{"DELETE": {204}, "POST": {201, 202}}
+	if !reflect.DeepEqual(codes, want) {
+		t.Errorf("ParseSuccessCodes() = %v, want %v", codes, want)
+	}
+
+	for _, invalid := range []string{"POST", "POST=", "POST=abc", "POST=99", "TRACE=200"} {
+		if _, err := ParseSuccessCodes(invalid); err == nil {
+			t.Errorf("ParseSuccessCodes(%q) expected error", invalid)
+		}
+	}
+}
+
+func TestConfig_Validate_AcceptHeader(t *testing.T) {
+	cfg := Config{SpecPath: "/spec.yaml", OutputDir: "/out", APIGroup: "test.example.com", AcceptHeader: "application/vnd.widget+json, application/json;q=0.9"}
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("Validate() unexpected error: %v", err)
+	}
+
+	cfg.AcceptHeader = "json"
+	valErr, ok := cfg.Validate().(*ValidationError)
+	if !ok || valErr.Field != "AcceptHeader" {
+		t.Errorf("Validate() expected AcceptHeader error, got %v", valErr)
+	}
+}
+
+func TestConfig_Validate_ExcludeStatusFields(t *testing.T) {
+	cfg := Config{SpecPath: "/spec.yaml", OutputDir: "/out", APIGroup: "test.example.com", ExcludeStatusFields: []string{"response", "Pet.lastGetTime"}}
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("Validate() unexpected error: %v", err)
+	}
+
+	for _, entry := range []string{"conditions", "Pet.observedGeneration", "externalID", "executionCount", "Backup.executionCount", "", "Pet.", ".message", "Pet.response.data"} {
+		cfg.ExcludeStatusFields = []string{entry}
+		valErr, ok := cfg.Validate().(*ValidationError)
+		if !ok || valErr.Field != "ExcludeStatusFields" {
+			t.Errorf("Validate(%q) expected ExcludeStatusFields error, got %v", entry, valErr)
+		}
+	}
+}
+
+func TestConfig_Validate_GroupKindPluralCheck(t *testing.T) {
+	cfg := Config{SpecPath: "/spec.yaml", OutputDir: "/out", APIGroup: "petstore", GroupKindPluralCheck: true}
+	valErr, ok := cfg.Validate().(*ValidationError)
+	if !ok || valErr.Field != "APIGroup" {
+		t.Errorf("Validate() expected APIGroup error, got %v", valErr)
+	}
+
+	cfg.APIGroup, cfg.APIVersion = "petstore.example.com", "V1"
+	valErr, ok = cfg.Validate().(*ValidationError)
+	if !ok || valErr.Field != "APIVersion" {
+		t.Errorf("Validate() expected APIVersion error, got %v", valErr)
+	}
+
+	cfg.APIVersion = "v1alpha1"
+	if err := cfg.Validate(); err != nil {
+		t.Errorf("Validate() unexpected error: %v", err)
+	}
+}
+
+func TestValidateKind(t *testing.T) {
+	for _, kind := range []string{"Pet", "StoreInventoryQuery", "V2Order"} {
+		if err := ValidateKind(kind); err != nil {
+			t.Errorf("ValidateKind(%q) unexpected error: %v", kind, err)
+		}
+	}
+	for _, kind := range []string{"", "pet", "2Pet", "Pet_Tag", "Pet-Tag", "PetStoreInventoryQueryWithAnUnreasonablyLongNameThatNeverEndsAtAll"} {
+		if err := ValidateKind(kind); err == nil {
+			t.Errorf("ValidateKind(%q) expected an error", kind)
+		}
+	}
+}
+
+func TestConfig_Validate_SuccessCodes(t *testing.T) {
+	cfg := Config{SpecPath: "/spec.yaml", OutputDir: "/out", APIGroup: "test.example.com", SuccessCodes: map[string][]int{"post": {201}}}
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("Validate() unexpected error: %v", err)
+	}
+	if _, ok := cfg.SuccessCodes["POST"]; !ok {
+		t.Errorf("Validate() did not normalize method: %v", cfg.SuccessCodes)
+	}
+
+	cfg.SuccessCodes = map[string][]int{"POST": {600}}
+	valErr, ok := cfg.Validate().(*ValidationError)
+	if !ok || valErr.Field != "SuccessCodes" {
+		t.Errorf("Validate() expected SuccessCodes error, got %v", valErr)
+	}
+}
+
+func TestConfig_ApplyEnvironment(t *testing.T) {
+	servers := []SpecServer{
+		{URL: "https://api.example.com/v1", Environment: "prod"},
+		{URL: "https://staging.example.com/v1", Environment: "staging"},
+		{URL: "https://staging2.example.com/v1", Environment: "staging"},
+	}
+
+	cfg := Config{SpecServers: servers, Environment: "Staging"}
+	if err := cfg.ApplyEnvironment(); err != nil {
+		t.Fatalf("ApplyEnvironment() unexpected error: %v", err)
+	}
+	if cfg.SpecBaseURL != "https://staging.example.com/v1" {
+		t.Errorf("SpecBaseURL = %q, want the first staging server", cfg.SpecBaseURL)
+	}
+
+	cfg = Config{SpecServers: servers, Environment: "qa"}
+	if err := cfg.ApplyEnvironment(); err == nil || err.Error() != `no server has x-environment "qa"; available: prod, staging` {
+		t.Errorf("ApplyEnvironment() = %v, want the available environments", err)
+	}
+
+	cfg = Config{SpecServers: []SpecServer{{URL: "https://api.example.com/v1"}}, Environment: "prod"}
+	if err := cfg.ApplyEnvironment(); err == nil {
+		t.Error("ApplyEnvironment() expected an error when no server declares x-environment")
+	}
+
+	cfg = Config{SpecBaseURL: "https://api.example.com/v1"}
+	if err := cfg.ApplyEnvironment(); err != nil || cfg.SpecBaseURL != "https://api.example.com/v1" {
+		t.Errorf("ApplyEnvironment() without an environment = %v, SpecBaseURL %q", err, cfg.SpecBaseURL)
+	}
+}
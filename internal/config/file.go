@@ -1,9 +1,12 @@
 package config
 
 import (
+	"bytes"
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
+	"time"
 
 	"gopkg.in/yaml.v3"
 )
@@ -14,6 +17,15 @@ type ConfigFile struct {
 	// Spec is the path or URL to the OpenAPI specification file
 	Spec string `yaml:"spec,omitempty"`
 
+	// SpecRootFile is the root document when Spec is a directory of split files
+	SpecRootFile string `yaml:"specRootFile,omitempty"`
+
+	// SpecFormat forces YAML or JSON decoding of the spec (auto, yaml, json)
+	SpecFormat string `yaml:"specFormat,omitempty"`
+
+	// SpecCache is the directory caching a spec fetched from a URL
+	SpecCache string `yaml:"specCache,omitempty"`
+
 	// Output is the directory where generated code will be written
 	Output string `yaml:"output,omitempty"`
 
@@ -26,31 +38,87 @@ type ConfigFile struct {
 	// Module is the Go module name for generated code
 	Module string `yaml:"module,omitempty"`
 
+	// ImportPrefix is the import path of the output directory (defaults to module)
+	ImportPrefix string `yaml:"importPrefix,omitempty"`
+
+	// SkipGoMod skips generating go.mod files, for output nested inside an existing module
+	SkipGoMod *bool `yaml:"skipGoMod,omitempty"`
+
 	// Mapping determines how REST resources map to CRDs: "per-resource" or "single-crd"
 	Mapping string `yaml:"mapping,omitempty"`
 
 	// RootKind is the Kind name to use for the root "/" endpoint
 	RootKind string `yaml:"rootKind,omitempty"`
 
+	// ControllerFileNaming determines how controller files are named: "kind" or "operation-id"
+	ControllerFileNaming string `yaml:"controllerFileNaming,omitempty"`
+
+	// FieldNameCase determines the JSON names of CR fields: "camel", "original" or "snake"
+	FieldNameCase string `yaml:"fieldNameCase,omitempty"`
+
+	// DeriveOwners makes resources nested under another resource's path owned by it
+	DeriveOwners *bool `yaml:"deriveOwners,omitempty"`
+
 	// GenerateCRDs controls whether to generate CRD YAML manifests directly
 	GenerateCRDs *bool `yaml:"generateCRDs,omitempty"`
 
+	// TypesOnly generates only the API types, CRD YAML and samples, without the operator scaffold
+	TypesOnly *bool `yaml:"typesOnly,omitempty"`
+
 	// Aggregate controls whether to generate a Status Aggregator CRD
 	Aggregate *bool `yaml:"aggregate,omitempty"`
 
 	// Bundle controls whether to generate an Inline Composition Bundle CRD
 	Bundle *bool `yaml:"bundle,omitempty"`
 
+	// BundleAdopt controls whether the bundle controller adopts unowned pre-existing children
+	BundleAdopt *bool `yaml:"bundleAdopt,omitempty"`
+
 	// Filters contains path, tag, and operation filtering options
 	Filters *FilterConfig `yaml:"filters,omitempty"`
 
 	// IDMerge contains ID field merging options
 	IDMerge *IDMergeConfig `yaml:"idMerge,omitempty"`
 
+	// PluralOverrides sets the exact CRD plural for a Kind
+	// Example: {"Datum": "data"}
+	PluralOverrides map[string]string `yaml:"pluralOverrides,omitempty"`
+
+	// ConstantPathParams bakes fixed path parameter values into the URLs
+	// Example: {"version": "v1"}
+	ConstantPathParams map[string]string `yaml:"constantPathParams,omitempty"`
+
 	// UpdateWithPost specifies which resources should use POST for updates when PUT is not available
 	// Can be: ["*"] for all, or specific paths like ["/store/order", "/users/*"]
 	UpdateWithPost []string `yaml:"updateWithPost,omitempty"`
 
+	// FinalizerName overrides the default "<group>/finalizer" finalizer
+	FinalizerName string `yaml:"finalizerName,omitempty"`
+
+	// SampleNamespace is the namespace of the sample CRs and the kubectl plugin's default
+	SampleNamespace string `yaml:"sampleNamespace,omitempty"`
+
+	// UseETag enables If-Match on updates for resources whose GET response declares an ETag
+	UseETag *bool `yaml:"useETag,omitempty"`
+
+	// IdempotencyHeader is the header carrying an idempotency key on create and action requests
+	IdempotencyHeader string `yaml:"idempotencyHeader,omitempty"`
+
+	// AcceptHeader is the Accept header sent on every request (default: derived per operation)
+	AcceptHeader string `yaml:"acceptHeader,omitempty"`
+
+	// ExcludeStatusFields are status fields dropped from the CRDs ("field" or "Kind.field")
+	ExcludeStatusFields []string `yaml:"excludeStatusFields,omitempty"`
+
+	// GroupKindPluralCheck validates the group and generated Kinds, plurals and short names
+	GroupKindPluralCheck *bool `yaml:"groupKindPluralCheck,omitempty"`
+
+	// StatusSubresource enables the CRDs' status subresource (default: true)
+	StatusSubresource *bool `yaml:"statusSubresource,omitempty"`
+
+	// GenerationPredicate limits resource controllers to spec and annotation changes (default: true)
+	GenerationPredicate *bool `yaml:"generationPredicate,omitempty"`
+
 	// KubectlPlugin controls whether to generate a kubectl plugin
 	KubectlPlugin *bool `yaml:"kubectlPlugin,omitempty"`
 
@@ -58,6 +126,32 @@ type ConfigFile struct {
 	// Requires kubectlPlugin to be true
 	RundeckProject *bool `yaml:"rundeckProject,omitempty"`
 
+	// KrewManifest controls whether to generate a krew manifest for the kubectl plugin
+	// Requires kubectlPlugin to be true
+	KrewManifest *bool `yaml:"krewManifest,omitempty"`
+
+	// Dashboard controls whether to generate a Grafana dashboard for the operator metrics
+	Dashboard *bool `yaml:"dashboard,omitempty"`
+
+	// WebhookPatches controls whether to generate conversion webhook and CA injection patches
+	WebhookPatches *bool `yaml:"webhookPatches,omitempty"`
+
+	// Tilt controls whether to generate a Tiltfile for local development
+	Tilt *bool `yaml:"tilt,omitempty"`
+
+	// E2E controls whether to generate a kind-based end-to-end test
+	E2E *bool `yaml:"e2e,omitempty"`
+
+	// WebhookReceiver controls whether to generate a receiver for the spec's webhooks
+	WebhookReceiver *bool `yaml:"webhookReceiver,omitempty"`
+
+	// ServerSelector picks the spec server (x-name, description or URL) the generated
+	// operator targets by default
+	ServerSelector string `yaml:"serverSelector,omitempty"`
+
+	// Environment picks the spec server with this x-environment (e.g., prod or staging)
+	Environment string `yaml:"environment,omitempty"`
+
 	// TargetAPIImage is the container image for the target REST API
 	// When set, generates a Deployment+Service manifest for the target API
 	TargetAPIImage string `yaml:"targetAPIImage,omitempty"`
@@ -66,15 +160,113 @@ type ConfigFile struct {
 	// Overrides the port extracted from the OpenAPI spec's servers URL
 	TargetAPIPort *int `yaml:"targetAPIPort,omitempty"`
 
+	// DefaultTarget presets spec.target in the generated CRDs
+	// (overridden per operation by x-k8s-target-default)
+	DefaultTarget *TargetDefault `yaml:"defaultTarget,omitempty"`
+
+	// SuccessCodes lists the response codes treated as success per HTTP method
+	// (overridden per operation by x-k8s-success-codes)
+	SuccessCodes map[string][]int `yaml:"successCodes,omitempty"`
+
 	// ManagedCRs is the directory containing CR YAML files for managed Rundeck lifecycle jobs
 	ManagedCRs string `yaml:"managedCRs,omitempty"`
 
+	// HTTPTransport contains connection pooling options for the controllers' HTTP client
+	HTTPTransport *HTTPTransportFileConfig `yaml:"httpTransport,omitempty"`
+
+	// SecurityContext relaxes the hardened securityContext of the manager Deployment
+	SecurityContext *SecurityContextFileConfig `yaml:"securityContext,omitempty"`
+
+	// AllowExtraHeaders adds spec.extraHeaders, sent as HTTP headers on each REST API request
+	AllowExtraHeaders *bool `yaml:"allowExtraHeaders,omitempty"`
+
+	// ReconcileOnConfigMapChange re-reconciles CRs when the ConfigMaps and Secrets they
+	// reference change
+	ReconcileOnConfigMapChange *bool `yaml:"reconcileOnConfigMapChange,omitempty"`
+
+	// SupportDryRun adds the manager's --dry-run-external flag, which logs REST API writes
+	// instead of sending them
+	SupportDryRun *bool `yaml:"supportDryRun,omitempty"`
+
+	// HighAvailability adds replicas, a PodDisruptionBudget and a PriorityClass to the manager
+	HighAvailability *bool `yaml:"highAvailability,omitempty"`
+
+	// PriorityClassName is an existing PriorityClass for the manager pods
+	PriorityClassName string `yaml:"priorityClassName,omitempty"`
+
+	// WatchNamespaces are the namespaces the generated operator watches by default
+	WatchNamespaces []string `yaml:"watchNamespaces,omitempty"`
+
+	// Tracing enables OpenTelemetry trace export in the generated manager
+	Tracing *bool `yaml:"tracing,omitempty"`
+
+	// Pprof enables the /debug/pprof handler in the generated manager
+	Pprof *bool `yaml:"pprof,omitempty"`
+
+	// PprofAddr is the bind address of the generated manager's pprof handler
+	PprofAddr string `yaml:"pprofAddr,omitempty"`
+
+	// EmbedSpec embeds the copied OpenAPI spec in the generated operator binary
+	EmbedSpec *bool `yaml:"embedSpec,omitempty"`
+
+	// Kubebuilder writes a kubebuilder PROJECT file and the manager entrypoint to cmd/main.go
+	Kubebuilder *bool `yaml:"kubebuilder,omitempty"`
+
+	// BaseImage is the builder stage image of the generated Dockerfile (default: golang:1.25)
+	BaseImage string `yaml:"baseImage,omitempty"`
+
+	// RuntimeImage is the runtime stage image of the generated Dockerfile
+	// (default: gcr.io/distroless/static:nonroot)
+	RuntimeImage string `yaml:"runtimeImage,omitempty"`
+
+	// SlowReconcileThreshold is the reconcile duration above which the generated
+	// controllers emit a Warning event (e.g., "10s")
+	SlowReconcileThreshold string `yaml:"slowReconcileThreshold,omitempty"`
+
+	// ClientQPS and ClientBurst are the rate limit of the generated operator's Kubernetes
+	// client (default: 20 and 30)
+	ClientQPS   *float32 `yaml:"clientQPS,omitempty"`
+	ClientBurst *int     `yaml:"clientBurst,omitempty"`
+
+	// DeleteTimeout is how long the generated controllers retry a failed DELETE before
+	// marking the CR DeletionBlocked (e.g., "10m")
+	DeleteTimeout string `yaml:"deleteTimeout,omitempty"`
+
+	// OrphanOnDeleteTimeout removes the finalizer once DeleteTimeout has passed
+	OrphanOnDeleteTimeout *bool `yaml:"orphanOnDeleteTimeout,omitempty"`
+
+	// ConditionTypes renames the status conditions the controllers set
+	// Example: {"ready": "Available", "reconciling": "Progressing", "stalled": "Degraded"}
+	ConditionTypes map[string]string `yaml:"conditionTypes,omitempty"`
+
+	// StatusResultLimit caps the number of results query controllers store in status
+	StatusResultLimit *int `yaml:"statusResultLimit,omitempty"`
+
+	// MaxCRDs fails generation when the spec maps to more CRDs than this
+	MaxCRDs *int `yaml:"maxCRDs,omitempty"`
+
+	// PauseConfigMapRef is the ConfigMap ("namespace/name" or "name") holding the
+	// operator-wide per-Kind pause switch
+	PauseConfigMapRef string `yaml:"pauseConfigMapRef,omitempty"`
+
 	// SpecHash is the SHA-256 hash of the spec file content at generation time.
 	// Used for quick change detection without re-parsing the spec.
 	SpecHash string `yaml:"specHash,omitempty"`
 
 	// GeneratorVersion is the version of openapi-operator-gen that last generated this operator.
 	GeneratorVersion string `yaml:"generatorVersion,omitempty"`
+
+	// ControllerHashes records the hash of each controller as generated, keyed by path
+	// relative to the output directory. Used by regenerate's merge mode.
+	ControllerHashes map[string]string `yaml:"controllerHashes,omitempty"`
+
+	// GeneratedFiles records the hash of every file written by the last generation,
+	// keyed by path relative to the output directory. Used by describe's file inventory.
+	GeneratedFiles map[string]string `yaml:"generatedFiles,omitempty"`
+
+	// KindSpecHashes records the hash of the spec each Kind regenerated on its own
+	// (only_kind) was generated from, keyed by Kind. Its spec copy is in .kind-specs/<Kind>.
+	KindSpecHashes map[string]string `yaml:"kindSpecHashes,omitempty"`
 }
 
 // FilterConfig contains filtering options for paths, tags, and operations
@@ -114,6 +306,36 @@ type IDMergeConfig struct {
 	FieldMap map[string]string `yaml:"fieldMap,omitempty"`
 }
 
+// HTTPTransportFileConfig contains HTTP transport tuning options
+type HTTPTransportFileConfig struct {
+	// MaxIdleConns limits idle (keep-alive) connections, both in total and per host
+	MaxIdleConns *int `yaml:"maxIdleConns,omitempty"`
+
+	// MaxConnsPerHost limits the total connections per host (0 means no limit)
+	MaxConnsPerHost *int `yaml:"maxConnsPerHost,omitempty"`
+
+	// IdleConnTimeout is how long an idle connection is kept open (e.g., "90s")
+	IdleConnTimeout string `yaml:"idleConnTimeout,omitempty"`
+
+	// HTTP2 enables HTTP/2 (default: true)
+	HTTP2 *bool `yaml:"http2,omitempty"`
+}
+
+// SecurityContextFileConfig contains options to relax the manager Deployment's securityContext
+type SecurityContextFileConfig struct {
+	// AllowRunAsRoot drops runAsNonRoot
+	AllowRunAsRoot *bool `yaml:"allowRunAsRoot,omitempty"`
+
+	// WritableRootFilesystem drops readOnlyRootFilesystem
+	WritableRootFilesystem *bool `yaml:"writableRootFilesystem,omitempty"`
+
+	// AddCapabilities are added back after dropping ALL
+	AddCapabilities []string `yaml:"addCapabilities,omitempty"`
+
+	// SeccompProfile is the pod's seccomp profile type (default: RuntimeDefault)
+	SeccompProfile string `yaml:"seccompProfile,omitempty"`
+}
+
 // LoadConfigFile loads a configuration file from the specified path.
 // Supports YAML format. Returns nil config if file doesn't exist.
 func LoadConfigFile(path string) (*ConfigFile, error) {
@@ -131,10 +353,58 @@ func LoadConfigFile(path string) (*ConfigFile, error) {
 	if err := yaml.Unmarshal(data, &cfg); err != nil {
 		return nil, fmt.Errorf("failed to parse config file: %w", err)
 	}
+	if err := cfg.validate(); err != nil {
+		return nil, fmt.Errorf("invalid config file: %w", err)
+	}
 
 	return &cfg, nil
 }
 
+// validate checks the settings whose YAML type doesn't catch a bad value
+func (f *ConfigFile) validate() error {
+	if f.HTTPTransport != nil && f.HTTPTransport.IdleConnTimeout != "" {
+		if _, err := time.ParseDuration(f.HTTPTransport.IdleConnTimeout); err != nil {
+			return fmt.Errorf("invalid httpTransport.idleConnTimeout: %w", err)
+		}
+	}
+	return nil
+}
+
+// Set overrides one setting of the config file from a "key=value" pair. The key is the
+// setting's YAML key, dotted for a nested one (e.g., "httpTransport.maxIdleConns"), and
+// the value is YAML: true, 30s, [response, responses] or {orderId: id}. Unknown keys and
+// values of the wrong type are errors.
+func (f *ConfigFile) Set(pair string) error {
+	key, value, ok := strings.Cut(pair, "=")
+	key = strings.TrimSpace(key)
+	if !ok || key == "" {
+		return fmt.Errorf("invalid setting %q: expected key=value", pair)
+	}
+
+	var parsed interface{}
+	if err := yaml.Unmarshal([]byte(value), &parsed); err != nil {
+		return fmt.Errorf("invalid value for %s: %w", key, err)
+	}
+	parts := strings.Split(key, ".")
+	for i := len(parts) - 1; i >= 0; i-- {
+		parsed = map[string]interface{}{parts[i]: parsed}
+	}
+	data, err := yaml.Marshal(parsed)
+	if err != nil {
+		return fmt.Errorf("invalid value for %s: %w", key, err)
+	}
+
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(f); err != nil {
+		if strings.Contains(err.Error(), "not found in type") {
+			return fmt.Errorf("unknown setting %q", key)
+		}
+		return fmt.Errorf("invalid value %q for %s: %w", value, key, err)
+	}
+	return f.validate()
+}
+
 // FindConfigFile searches for a config file in standard locations.
 // Returns the path to the first found config file, or empty string if none found.
 // Search order:
@@ -190,6 +460,15 @@ func MergeConfigFile(cfg *Config, file *ConfigFile) {
 	if cfg.SpecPath == "" && file.Spec != "" {
 		cfg.SpecPath = file.Spec
 	}
+	if cfg.SpecRootFile == "" && file.SpecRootFile != "" {
+		cfg.SpecRootFile = file.SpecRootFile
+	}
+	if (cfg.SpecFormat == "" || cfg.SpecFormat == SpecFormatAuto) && file.SpecFormat != "" {
+		cfg.SpecFormat = file.SpecFormat
+	}
+	if cfg.SpecCacheDir == "" && file.SpecCache != "" {
+		cfg.SpecCacheDir = file.SpecCache
+	}
 	if cfg.OutputDir == "./generated" && file.Output != "" {
 		// ./generated is the default, so override if config file specifies something
 		cfg.OutputDir = file.Output
@@ -205,6 +484,12 @@ func MergeConfigFile(cfg *Config, file *ConfigFile) {
 		// default module name, so override if config file specifies something
 		cfg.ModuleName = file.Module
 	}
+	if cfg.ImportPrefix == "" && file.ImportPrefix != "" {
+		cfg.ImportPrefix = file.ImportPrefix
+	}
+	if file.SkipGoMod != nil && !cfg.SkipGoMod {
+		cfg.SkipGoMod = *file.SkipGoMod
+	}
 	if cfg.MappingMode == PerResource && file.Mapping != "" {
 		// per-resource is the default
 		cfg.MappingMode = MappingMode(file.Mapping)
@@ -212,28 +497,98 @@ func MergeConfigFile(cfg *Config, file *ConfigFile) {
 	if cfg.RootKind == "" && file.RootKind != "" {
 		cfg.RootKind = file.RootKind
 	}
+	if (cfg.ControllerFileNaming == "" || cfg.ControllerFileNaming == ControllerFileNamingKind) && file.ControllerFileNaming != "" {
+		// kind is the default
+		cfg.ControllerFileNaming = ControllerFileNaming(file.ControllerFileNaming)
+	}
+	if (cfg.FieldNameCase == "" || cfg.FieldNameCase == FieldNameCaseCamel) && file.FieldNameCase != "" {
+		// camel is the default
+		cfg.FieldNameCase = FieldNameCase(file.FieldNameCase)
+	}
+	if file.DeriveOwners != nil && !cfg.DeriveOwners {
+		cfg.DeriveOwners = *file.DeriveOwners
+	}
 
 	// Merge boolean fields (only if config file explicitly sets them)
 	if file.GenerateCRDs != nil && !cfg.GenerateCRDs {
 		cfg.GenerateCRDs = *file.GenerateCRDs
 	}
+	if file.TypesOnly != nil && !cfg.TypesOnly {
+		cfg.TypesOnly = *file.TypesOnly
+	}
 	if file.Aggregate != nil && !cfg.GenerateAggregate {
 		cfg.GenerateAggregate = *file.Aggregate
 	}
 	if file.Bundle != nil && !cfg.GenerateBundle {
 		cfg.GenerateBundle = *file.Bundle
 	}
+	if file.BundleAdopt != nil && !cfg.BundleAdopt {
+		cfg.BundleAdopt = *file.BundleAdopt
+	}
 	if file.KubectlPlugin != nil && !cfg.GenerateKubectlPlugin {
 		cfg.GenerateKubectlPlugin = *file.KubectlPlugin
 	}
 	if file.RundeckProject != nil && !cfg.GenerateRundeckProject {
 		cfg.GenerateRundeckProject = *file.RundeckProject
 	}
+	if file.KrewManifest != nil && !cfg.GenerateKrewManifest {
+		cfg.GenerateKrewManifest = *file.KrewManifest
+	}
+	if file.Dashboard != nil && !cfg.GenerateDashboard {
+		cfg.GenerateDashboard = *file.Dashboard
+	}
+	if file.WebhookPatches != nil && !cfg.GenerateWebhookPatches {
+		cfg.GenerateWebhookPatches = *file.WebhookPatches
+	}
+	if file.Tilt != nil && !cfg.GenerateTilt {
+		cfg.GenerateTilt = *file.Tilt
+	}
+	if file.E2E != nil && !cfg.GenerateE2E {
+		cfg.GenerateE2E = *file.E2E
+	}
+	if file.WebhookReceiver != nil && !cfg.GenerateWebhookReceiver {
+		cfg.GenerateWebhookReceiver = *file.WebhookReceiver
+	}
 
 	// Merge UpdateWithPost (only if CLI didn't set it)
 	if len(cfg.UpdateWithPost) == 0 && len(file.UpdateWithPost) > 0 {
 		cfg.UpdateWithPost = file.UpdateWithPost
 	}
+	if cfg.FinalizerName == "" && file.FinalizerName != "" {
+		cfg.FinalizerName = file.FinalizerName
+	}
+	if cfg.SampleNamespace == "" && file.SampleNamespace != "" {
+		cfg.SampleNamespace = file.SampleNamespace
+	}
+	if file.UseETag != nil && !cfg.UseETag {
+		cfg.UseETag = *file.UseETag
+	}
+	if cfg.IdempotencyHeader == "" && file.IdempotencyHeader != "" {
+		cfg.IdempotencyHeader = file.IdempotencyHeader
+	}
+	if cfg.AcceptHeader == "" && file.AcceptHeader != "" {
+		cfg.AcceptHeader = file.AcceptHeader
+	}
+	if len(cfg.ExcludeStatusFields) == 0 && len(file.ExcludeStatusFields) > 0 {
+		cfg.ExcludeStatusFields = file.ExcludeStatusFields
+	}
+	if file.GroupKindPluralCheck != nil && !cfg.GroupKindPluralCheck {
+		cfg.GroupKindPluralCheck = *file.GroupKindPluralCheck
+	}
+	if file.StatusSubresource != nil && !cfg.NoStatusSubresource {
+		cfg.NoStatusSubresource = !*file.StatusSubresource
+	}
+	if file.GenerationPredicate != nil && !cfg.NoGenerationPredicate {
+		cfg.NoGenerationPredicate = !*file.GenerationPredicate
+	}
+
+	// Merge ServerSelector (only if CLI didn't set it)
+	if cfg.ServerSelector == "" && file.ServerSelector != "" {
+		cfg.ServerSelector = file.ServerSelector
+	}
+	if cfg.Environment == "" && file.Environment != "" {
+		cfg.Environment = file.Environment
+	}
 
 	// Merge TargetAPIImage (only if CLI didn't set it)
 	if cfg.TargetAPIImage == "" && file.TargetAPIImage != "" {
@@ -245,6 +600,16 @@ func MergeConfigFile(cfg *Config, file *ConfigFile) {
 		cfg.TargetAPIPort = *file.TargetAPIPort
 	}
 
+	// Merge DefaultTarget (only if CLI didn't set it)
+	if cfg.DefaultTarget == nil && file.DefaultTarget != nil {
+		cfg.DefaultTarget = file.DefaultTarget
+	}
+
+	// Merge SuccessCodes (only if CLI didn't set it)
+	if cfg.SuccessCodes == nil && len(file.SuccessCodes) > 0 {
+		cfg.SuccessCodes = file.SuccessCodes
+	}
+
 	// Merge filter options
 	if file.Filters != nil {
 		if len(cfg.IncludePaths) == 0 && len(file.Filters.IncludePaths) > 0 {
@@ -272,6 +637,119 @@ func MergeConfigFile(cfg *Config, file *ConfigFile) {
 		cfg.ManagedCRsDir = file.ManagedCRs
 	}
 
+	// Merge HTTP transport options (only if CLI didn't set them)
+	if file.HTTPTransport != nil {
+		if cfg.HTTPTransport.MaxIdleConns == 0 && file.HTTPTransport.MaxIdleConns != nil {
+			cfg.HTTPTransport.MaxIdleConns = *file.HTTPTransport.MaxIdleConns
+		}
+		if cfg.HTTPTransport.MaxConnsPerHost == 0 && file.HTTPTransport.MaxConnsPerHost != nil {
+			cfg.HTTPTransport.MaxConnsPerHost = *file.HTTPTransport.MaxConnsPerHost
+		}
+		// LoadConfigFile rejects an idleConnTimeout that doesn't parse
+		if cfg.HTTPTransport.IdleConnTimeout == 0 && file.HTTPTransport.IdleConnTimeout != "" {
+			if d, err := time.ParseDuration(file.HTTPTransport.IdleConnTimeout); err == nil {
+				cfg.HTTPTransport.IdleConnTimeout = d
+			}
+		}
+		if cfg.HTTPTransport.HTTP2 == nil && file.HTTPTransport.HTTP2 != nil {
+			http2 := *file.HTTPTransport.HTTP2
+			cfg.HTTPTransport.HTTP2 = &http2
+		}
+	}
+
+	// Merge securityContext relaxations (only if CLI didn't set them)
+	if file.SecurityContext != nil {
+		if !cfg.SecurityContext.AllowRunAsRoot && file.SecurityContext.AllowRunAsRoot != nil {
+			cfg.SecurityContext.AllowRunAsRoot = *file.SecurityContext.AllowRunAsRoot
+		}
+		if !cfg.SecurityContext.WritableRootFilesystem && file.SecurityContext.WritableRootFilesystem != nil {
+			cfg.SecurityContext.WritableRootFilesystem = *file.SecurityContext.WritableRootFilesystem
+		}
+		if len(cfg.SecurityContext.AddCapabilities) == 0 && len(file.SecurityContext.AddCapabilities) > 0 {
+			cfg.SecurityContext.AddCapabilities = file.SecurityContext.AddCapabilities
+		}
+		if cfg.SecurityContext.SeccompProfile == "" && file.SecurityContext.SeccompProfile != "" {
+			cfg.SecurityContext.SeccompProfile = file.SecurityContext.SeccompProfile
+		}
+	}
+
+	if file.AllowExtraHeaders != nil && !cfg.AllowExtraHeaders {
+		cfg.AllowExtraHeaders = *file.AllowExtraHeaders
+	}
+	if file.ReconcileOnConfigMapChange != nil && !cfg.ReconcileOnConfigMapChange {
+		cfg.ReconcileOnConfigMapChange = *file.ReconcileOnConfigMapChange
+	}
+	if file.SupportDryRun != nil && !cfg.SupportDryRun {
+		cfg.SupportDryRun = *file.SupportDryRun
+	}
+	if file.HighAvailability != nil && !cfg.HighAvailability {
+		cfg.HighAvailability = *file.HighAvailability
+	}
+	if cfg.PriorityClassName == "" && file.PriorityClassName != "" {
+		cfg.PriorityClassName = file.PriorityClassName
+	}
+	if len(cfg.WatchNamespaces) == 0 && len(file.WatchNamespaces) > 0 {
+		cfg.WatchNamespaces = file.WatchNamespaces
+	}
+
+	if file.Tracing != nil && !cfg.EnableTracing {
+		cfg.EnableTracing = *file.Tracing
+	}
+
+	// Merge pprof options (only if CLI didn't set them)
+	if file.Pprof != nil && !cfg.EnablePprof {
+		cfg.EnablePprof = *file.Pprof
+	}
+	if cfg.PprofAddr == "" && file.PprofAddr != "" {
+		cfg.PprofAddr = file.PprofAddr
+	}
+	if file.EmbedSpec != nil && !cfg.EmbedSpec {
+		cfg.EmbedSpec = *file.EmbedSpec
+	}
+	if file.Kubebuilder != nil && !cfg.KubebuilderLayout {
+		cfg.KubebuilderLayout = *file.Kubebuilder
+	}
+
+	// Merge Dockerfile images (only if CLI didn't set them)
+	if cfg.BaseImage == "" && file.BaseImage != "" {
+		cfg.BaseImage = file.BaseImage
+	}
+	if cfg.RuntimeImage == "" && file.RuntimeImage != "" {
+		cfg.RuntimeImage = file.RuntimeImage
+	}
+
+	if cfg.SlowReconcileThreshold == 0 && file.SlowReconcileThreshold != "" {
+		if d, err := time.ParseDuration(file.SlowReconcileThreshold); err == nil {
+			cfg.SlowReconcileThreshold = d
+		}
+	}
+	if cfg.KubeClientQPS == 0 && file.ClientQPS != nil {
+		cfg.KubeClientQPS = *file.ClientQPS
+	}
+	if cfg.KubeClientBurst == 0 && file.ClientBurst != nil {
+		cfg.KubeClientBurst = *file.ClientBurst
+	}
+	if cfg.DeleteTimeout == 0 && file.DeleteTimeout != "" {
+		if d, err := time.ParseDuration(file.DeleteTimeout); err == nil {
+			cfg.DeleteTimeout = d
+		}
+	}
+	if file.OrphanOnDeleteTimeout != nil && !cfg.OrphanOnDeleteTimeout {
+		cfg.OrphanOnDeleteTimeout = *file.OrphanOnDeleteTimeout
+	}
+	if cfg.ConditionTypes == nil && len(file.ConditionTypes) > 0 {
+		cfg.ConditionTypes = file.ConditionTypes
+	}
+	if cfg.MaxQueryResults == 0 && file.StatusResultLimit != nil {
+		cfg.MaxQueryResults = *file.StatusResultLimit
+	}
+	if cfg.MaxCRDs == 0 && file.MaxCRDs != nil {
+		cfg.MaxCRDs = *file.MaxCRDs
+	}
+	if cfg.PauseConfigMapRef == "" && file.PauseConfigMapRef != "" {
+		cfg.PauseConfigMapRef = file.PauseConfigMapRef
+	}
+
 	// Merge ID merge options
 	if file.IDMerge != nil {
 		if !cfg.NoIDMerge && file.IDMerge.Disabled {
@@ -281,6 +759,13 @@ func MergeConfigFile(cfg *Config, file *ConfigFile) {
 			cfg.IDFieldMap = file.IDMerge.FieldMap
 		}
 	}
+
+	if cfg.PluralOverrides == nil && len(file.PluralOverrides) > 0 {
+		cfg.PluralOverrides = file.PluralOverrides
+	}
+	if cfg.ConstantPathParams == nil && len(file.ConstantPathParams) > 0 {
+		cfg.ConstantPathParams = file.ConstantPathParams
+	}
 }
 
 // GenerateExampleConfig generates an example configuration file content
@@ -289,8 +774,21 @@ func GenerateExampleConfig() string {
 # All options can be overridden by CLI flags
 
 # OpenAPI specification path or URL (required)
+# May also be a directory of split files that $ref each other
 spec: ./api/openapi.yaml
 
+# Root document inside a spec directory, when more than one file has an
+# openapi:/swagger: key (auto-detected otherwise)
+# specRootFile: openapi.yaml
+
+# Force how the spec is decoded: auto, yaml or json (default: auto).
+# Use json for a JSON spec served with a non-JSON content type
+# specFormat: json
+
+# Cache a spec URL in this directory. The cached copy is revalidated with
+# ETag/Last-Modified and used when the server can't be reached
+# specCache: ~/.cache/openapi-operator-gen
+
 # Output directory for generated code
 output: ./generated
 
@@ -303,27 +801,87 @@ version: v1alpha1
 # Go module name for generated code
 module: github.com/myorg/myapp-operator
 
+# Import path of the output directory when generating into a subdirectory of an
+# existing module (defaults to module), and skip generating go.mod there
+# importPrefix: github.com/myorg/monorepo/operators/myapp
+# skipGoMod: true
+
 # Resource mapping mode: per-resource or single-crd
 mapping: per-resource
 
 # Kind name for root "/" endpoint (derived from spec filename if not set)
 # rootKind: MyApp
 
+# Controller file naming: kind (pet_controller.go) or operation-id (get_pet_by_id_controller.go)
+# controllerFileNaming: kind
+
+# JSON names of CR fields: camel (petName), original (the API's names) or snake (pet_name)
+# fieldNameCase: camel
+
+# Make resources nested under another resource's path (/orders/{orderId}/items) owned
+# by its CRs, as the x-k8s-owner extension does
+# deriveOwners: true
+
 # Generate CRD YAML manifests directly (default: use controller-gen)
 generateCRDs: false
 
+# Generate only the API types, CRD YAML and samples (no controllers, main.go, go.mod,
+# Dockerfile or Makefile), for importing into a larger operator
+# typesOnly: true
+
 # Generate a Status Aggregator CRD for observing multiple resources
 aggregate: true
 
 # Generate an Inline Composition Bundle CRD for creating multiple resources
 bundle: true
 
+# Adopt pre-existing, unowned child CRs into the bundle (bundles can override with spec.adopt)
+# bundleAdopt: true
+
+# Generate a Tiltfile for a live-reload development loop
+# tilt: true
+
+# Generate an end-to-end test (test/e2e, make test-e2e) that deploys to a kind cluster
+# e2e: true
+
+# Generate a receiver for the spec's OpenAPI 3.1 webhooks (internal/webhookreceiver)
+# webhookReceiver: true
+
+# Generate a krew manifest (kubectl-plugin/plugin.yaml) for the kubectl plugin
+# Requires kubectlPlugin: true
+# krewManifest: true
+
+# Generate a Grafana dashboard (dashboards/<app>.json) for the operator metrics
+# dashboard: true
+
+# Generate conversion webhook and cert-manager CA injection patches (config/crd/patches)
+# webhookPatches: true
+
+# Server from the spec's servers list (x-name, description or URL) the operator targets
+# by default; override at deploy time with --server or REST_API_SERVER
+# serverSelector: production
+
+# Target the spec server whose x-environment extension matches (prod, staging, dev)
+# environment: staging
+
 # Container image for the target REST API (generates a Deployment+Service manifest)
 # targetAPIImage: myregistry/myapi:latest
 
 # Container port for the target REST API (overrides port from spec URL, default: 8080)
 # targetAPIPort: 8080
 
+# Preset spec.target for generated CRs (per-operation x-k8s-target-default takes precedence)
+# defaultTarget:
+#   deployment: petstore-api
+#   namespace: backend
+#   # baseURL: http://petstore-api.backend.svc:8080
+
+# Response codes treated as success per HTTP method; any other code is a failure
+# (per-operation x-k8s-success-codes takes precedence, default: any 2xx)
+# successCodes:
+#   DELETE: [204]
+#   POST: [201, 202]
+
 # Use POST for updates when PUT is not available
 # Can be ["*"] for all, or specific paths
 updateWithPost:
@@ -331,6 +889,27 @@ updateWithPost:
   # - /store/order
   # - /users/*
 
+# Finalizer added by the generated controllers (default: <group>/finalizer)
+# finalizerName: myapp.example.com/my-operator-finalizer
+
+# Namespace of the sample CRs and the kubectl plugin's default namespace
+# (default: the spec's x-k8s-namespace extension, else "default")
+# sampleNamespace: team-a
+
+# Send If-Match with the stored ETag on updates when the GET response declares an ETag
+# useETag: true
+
+# Send a key derived from the CR's UID and generation on this header with create and
+# action requests, so retries don't create duplicates
+# idempotencyHeader: Idempotency-Key
+
+# Generate CRDs without the status subresource; controllers update the whole object
+# statusSubresource: false
+
+# Reconcile resource CRs on every update, including the controller's own status writes
+# (by default only spec/generation and annotation changes trigger a reconcile)
+# generationPredicate: false
+
 # Path, tag, and operation filtering
 filters:
   # Only include paths matching these patterns (glob supported)
@@ -364,6 +943,86 @@ filters:
     # - *Deprecated
     # - deletePet
 
+# HTTP transport tuning for the controllers' REST API client
+# These become the defaults of the generated operator's --http-* flags
+# httpTransport:
+#   maxIdleConns: 100
+#   maxConnsPerHost: 0        # 0 means no limit
+#   idleConnTimeout: 90s
+#   http2: true
+
+# The manager Deployment gets a hardened securityContext (restricted pod security level).
+# Relax it only if the manager needs to:
+# securityContext:
+#   allowRunAsRoot: true
+#   writableRootFilesystem: true
+#   addCapabilities: [NET_BIND_SERVICE]
+#   seccompProfile: Unconfined   # default: RuntimeDefault
+
+# Add spec.extraHeaders to resource, query and action CRDs; its headers are sent
+# with every REST API request for the CR (e.g., a tenant ID). Off by default
+# allowExtraHeaders: true
+
+# Watch the ConfigMaps and Secrets CRs reference (an action's spec.dataFrom) and
+# re-reconcile those CRs when they change; an action runs again when its dataFrom
+# object changed since its last execution (off by default)
+# reconcileOnConfigMapChange: true
+
+# Add a --dry-run-external flag (or DRY_RUN_EXTERNAL=true) to the manager: the
+# controllers log the REST API writes they would make and set a DryRun condition
+# instead of sending them; GETs still run (off by default)
+# supportDryRun: true
+
+# Run 2 manager replicas with leader election, a PodDisruptionBudget and a
+# generated PriorityClass (off by default)
+# highAvailability: true
+# priorityClassName: system-cluster-critical   # use an existing PriorityClass instead
+# watchNamespaces: [team-a, team-b]   # namespaces the operator watches by default
+
+# Export OpenTelemetry spans for reconciles and REST API calls (off by default)
+# tracing: true
+
+# Expose /debug/pprof in the generated manager (off by default)
+# pprof: true
+# pprofAddr: 127.0.0.1:6060
+
+# Embed the OpenAPI spec in the operator binary and serve it at /openapi.json
+# (or /openapi.yaml) on the metrics server (off by default)
+# embedSpec: true
+
+# Images of the generated Dockerfile. The manager runs as UID 65532 (non-root)
+# unless securityContext.allowRunAsRoot is set.
+# baseImage: golang:1.25
+# runtimeImage: gcr.io/distroless/static:nonroot
+
+# Emit a SlowReconcile Warning event when a reconcile takes longer than this
+# slowReconcileThreshold: 10s
+
+# Rate limit of the operator's Kubernetes client (defaults of its --kube-api-qps and
+# --kube-api-burst flags); raise them for operators managing many CRs
+# clientQPS: 20
+# clientBurst: 30
+
+# Retry a failed DELETE of the external resource, keeping the CR and marking it
+# DeletionBlocked, until this long after deletion was requested (off by default:
+# the finalizer is removed after one attempt)
+# deleteTimeout: 10m
+# Then remove the finalizer anyway, orphaning the external resource
+# orphanOnDeleteTimeout: true
+
+# Rename the status conditions the controllers set, to match an existing convention
+# conditionTypes:
+#   ready: Available
+#   reconciling: Progressing
+#   stalled: Degraded
+
+# Store at most this many query results in status (status.resultCount keeps the full count)
+# statusResultLimit: 500
+
+# ConfigMap ("namespace/name" or "name" in the operator namespace) whose keys pause
+# reconciliation per Kind ("Pet: \"true\"") or for every Kind ("all: \"true\"")
+# pauseConfigMapRef: operator-pause
+
 # ID field merging options
 idMerge:
   # Disable automatic merging of path ID parameters with body 'id' fields
@@ -373,19 +1032,56 @@ idMerge:
   fieldMap:
     # orderId: id
     # petId: id
+
+# Path parameters with a fixed value, baked into the URLs instead of becoming spec fields
+# constantPathParams:
+#   version: v1
 `
 }
 
 // WriteConfigFile writes a configuration file from the current Config values.
 // This saves the resolved configuration so it can be re-used with "openapi-operator-gen generate".
 func WriteConfigFile(path string, cfg *Config) error {
+	data, err := MarshalConfigFile(cfg)
+	if err != nil {
+		return err
+	}
+
+	// Create parent directories if needed
+	dir := filepath.Dir(path)
+	if dir != "" && dir != "." {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return fmt.Errorf("failed to create directory: %w", err)
+		}
+	}
+
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write config file: %w", err)
+	}
+
+	return nil
+}
+
+// MarshalConfigFile renders the current Config values as config file YAML,
+// in the format written by WriteConfigFile.
+func MarshalConfigFile(cfg *Config) ([]byte, error) {
 	// Build ConfigFile from Config
 	file := ConfigFile{
-		Spec:    cfg.SpecPath,
-		Output:  cfg.OutputDir,
-		Group:   cfg.APIGroup,
-		Version: cfg.APIVersion,
-		Module:  cfg.ModuleName,
+		Spec:         cfg.SpecPath,
+		SpecRootFile: cfg.SpecRootFile,
+		SpecCache:    cfg.SpecCacheDir,
+		Output:       cfg.OutputDir,
+		Group:        cfg.APIGroup,
+		Version:      cfg.APIVersion,
+		Module:       cfg.ModuleName,
+		ImportPrefix: cfg.ImportPrefix,
+	}
+	if cfg.SpecFormat != "" && cfg.SpecFormat != SpecFormatAuto {
+		file.SpecFormat = cfg.SpecFormat
+	}
+	if cfg.SkipGoMod {
+		v := true
+		file.SkipGoMod = &v
 	}
 
 	if cfg.MappingMode != PerResource {
@@ -394,10 +1090,24 @@ func WriteConfigFile(path string, cfg *Config) error {
 	if cfg.RootKind != "" {
 		file.RootKind = cfg.RootKind
 	}
+	if cfg.ControllerFileNaming != "" && cfg.ControllerFileNaming != ControllerFileNamingKind {
+		file.ControllerFileNaming = string(cfg.ControllerFileNaming)
+	}
+	if cfg.FieldNameCase != "" && cfg.FieldNameCase != FieldNameCaseCamel {
+		file.FieldNameCase = string(cfg.FieldNameCase)
+	}
+	if cfg.DeriveOwners {
+		v := true
+		file.DeriveOwners = &v
+	}
 	if cfg.GenerateCRDs {
 		v := true
 		file.GenerateCRDs = &v
 	}
+	if cfg.TypesOnly {
+		v := true
+		file.TypesOnly = &v
+	}
 	if cfg.GenerateAggregate {
 		v := true
 		file.Aggregate = &v
@@ -406,33 +1116,219 @@ func WriteConfigFile(path string, cfg *Config) error {
 		v := true
 		file.Bundle = &v
 	}
+	if cfg.BundleAdopt {
+		v := true
+		file.BundleAdopt = &v
+	}
 	if cfg.GenerateKubectlPlugin {
 		v := true
 		file.KubectlPlugin = &v
 	}
+	if cfg.GenerateKrewManifest {
+		v := true
+		file.KrewManifest = &v
+	}
+	if cfg.GenerateDashboard {
+		v := true
+		file.Dashboard = &v
+	}
+	if cfg.GenerateWebhookPatches {
+		v := true
+		file.WebhookPatches = &v
+	}
 	if cfg.GenerateRundeckProject {
 		v := true
 		file.RundeckProject = &v
 	}
+	if cfg.GenerateTilt {
+		v := true
+		file.Tilt = &v
+	}
+	if cfg.GenerateE2E {
+		v := true
+		file.E2E = &v
+	}
+	if cfg.GenerateWebhookReceiver {
+		v := true
+		file.WebhookReceiver = &v
+	}
 	if len(cfg.UpdateWithPost) > 0 {
 		file.UpdateWithPost = cfg.UpdateWithPost
 	}
+	if cfg.FinalizerName != "" && cfg.FinalizerName != DefaultFinalizerName(cfg.APIGroup) {
+		file.FinalizerName = cfg.FinalizerName
+	}
+	file.SampleNamespace = cfg.SampleNamespace
+	if cfg.UseETag {
+		v := true
+		file.UseETag = &v
+	}
+	file.IdempotencyHeader = cfg.IdempotencyHeader
+	file.AcceptHeader = cfg.AcceptHeader
+	file.ExcludeStatusFields = cfg.ExcludeStatusFields
+	if cfg.GroupKindPluralCheck {
+		v := true
+		file.GroupKindPluralCheck = &v
+	}
+	if cfg.NoStatusSubresource {
+		v := false
+		file.StatusSubresource = &v
+	}
+	if cfg.NoGenerationPredicate {
+		v := false
+		file.GenerationPredicate = &v
+	}
+	if cfg.ServerSelector != "" {
+		file.ServerSelector = cfg.ServerSelector
+	}
+	file.Environment = cfg.Environment
 	if cfg.TargetAPIImage != "" {
 		file.TargetAPIImage = cfg.TargetAPIImage
 	}
 	if cfg.TargetAPIPort != 0 {
 		file.TargetAPIPort = &cfg.TargetAPIPort
 	}
+	if cfg.DefaultTarget != nil {
+		file.DefaultTarget = cfg.DefaultTarget
+	}
+	if len(cfg.SuccessCodes) > 0 {
+		file.SuccessCodes = cfg.SuccessCodes
+	}
 	if cfg.SpecHash != "" {
 		file.SpecHash = cfg.SpecHash
 	}
 	if cfg.GeneratorVersion != "" {
 		file.GeneratorVersion = cfg.GeneratorVersion
 	}
+	if len(cfg.ControllerHashes) > 0 {
+		file.ControllerHashes = cfg.ControllerHashes
+	}
+	if len(cfg.GeneratedFiles) > 0 {
+		file.GeneratedFiles = cfg.GeneratedFiles
+	}
+	if len(cfg.KindSpecHashes) > 0 {
+		file.KindSpecHashes = cfg.KindSpecHashes
+	}
 	if cfg.ManagedCRsDir != "" {
 		file.ManagedCRs = cfg.ManagedCRsDir
 	}
 
+	// HTTP transport (only non-default values)
+	transport := HTTPTransportFileConfig{}
+	hasTransport := false
+	if cfg.HTTPTransport.MaxIdleConns != 0 && cfg.HTTPTransport.MaxIdleConns != DefaultHTTPMaxIdleConns {
+		transport.MaxIdleConns = &cfg.HTTPTransport.MaxIdleConns
+		hasTransport = true
+	}
+	if cfg.HTTPTransport.MaxConnsPerHost != 0 {
+		transport.MaxConnsPerHost = &cfg.HTTPTransport.MaxConnsPerHost
+		hasTransport = true
+	}
+	if cfg.HTTPTransport.IdleConnTimeout != 0 && cfg.HTTPTransport.IdleConnTimeout != DefaultHTTPIdleConnTimeout {
+		transport.IdleConnTimeout = cfg.HTTPTransport.IdleConnTimeout.String()
+		hasTransport = true
+	}
+	if !cfg.HTTPTransport.HTTP2Enabled() {
+		v := false
+		transport.HTTP2 = &v
+		hasTransport = true
+	}
+	if hasTransport {
+		file.HTTPTransport = &transport
+	}
+
+	// securityContext (only relaxations of the hardened default)
+	sc := SecurityContextFileConfig{
+		AddCapabilities: cfg.SecurityContext.AddCapabilities,
+	}
+	if cfg.SecurityContext.AllowRunAsRoot {
+		v := true
+		sc.AllowRunAsRoot = &v
+	}
+	if cfg.SecurityContext.WritableRootFilesystem {
+		v := true
+		sc.WritableRootFilesystem = &v
+	}
+	if cfg.SecurityContext.SeccompProfile != "" && cfg.SecurityContext.SeccompProfile != DefaultSeccompProfile {
+		sc.SeccompProfile = cfg.SecurityContext.SeccompProfile
+	}
+	if sc.AllowRunAsRoot != nil || sc.WritableRootFilesystem != nil || len(sc.AddCapabilities) > 0 || sc.SeccompProfile != "" {
+		file.SecurityContext = &sc
+	}
+	if cfg.AllowExtraHeaders {
+		v := true
+		file.AllowExtraHeaders = &v
+	}
+	if cfg.ReconcileOnConfigMapChange {
+		v := true
+		file.ReconcileOnConfigMapChange = &v
+	}
+	if cfg.SupportDryRun {
+		v := true
+		file.SupportDryRun = &v
+	}
+	if cfg.HighAvailability {
+		v := true
+		file.HighAvailability = &v
+	}
+	file.PriorityClassName = cfg.PriorityClassName
+	file.WatchNamespaces = cfg.WatchNamespaces
+	if cfg.EnableTracing {
+		v := true
+		file.Tracing = &v
+	}
+	if cfg.EnablePprof {
+		v := true
+		file.Pprof = &v
+	}
+	if cfg.PprofAddr != "" && cfg.PprofAddr != DefaultPprofAddr {
+		file.PprofAddr = cfg.PprofAddr
+	}
+	if cfg.EmbedSpec {
+		v := true
+		file.EmbedSpec = &v
+	}
+	if cfg.KubebuilderLayout {
+		v := true
+		file.Kubebuilder = &v
+	}
+	if cfg.BaseImage != "" && cfg.BaseImage != DefaultBaseImage {
+		file.BaseImage = cfg.BaseImage
+	}
+	if cfg.RuntimeImage != "" && cfg.RuntimeImage != DefaultRuntimeImage {
+		file.RuntimeImage = cfg.RuntimeImage
+	}
+	if cfg.SlowReconcileThreshold != 0 && cfg.SlowReconcileThreshold != DefaultSlowReconcileThreshold {
+		file.SlowReconcileThreshold = cfg.SlowReconcileThreshold.String()
+	}
+	if cfg.KubeClientQPS != 0 && cfg.KubeClientQPS != DefaultKubeClientQPS {
+		qps := cfg.KubeClientQPS
+		file.ClientQPS = &qps
+	}
+	if cfg.KubeClientBurst != 0 && cfg.KubeClientBurst != DefaultKubeClientBurst {
+		burst := cfg.KubeClientBurst
+		file.ClientBurst = &burst
+	}
+	if cfg.DeleteTimeout != 0 {
+		file.DeleteTimeout = cfg.DeleteTimeout.String()
+	}
+	if cfg.OrphanOnDeleteTimeout {
+		v := true
+		file.OrphanOnDeleteTimeout = &v
+	}
+	if len(cfg.ConditionTypes) > 0 {
+		file.ConditionTypes = cfg.ConditionTypes
+	}
+	if cfg.MaxQueryResults != 0 {
+		file.StatusResultLimit = &cfg.MaxQueryResults
+	}
+	if cfg.MaxCRDs != 0 {
+		file.MaxCRDs = &cfg.MaxCRDs
+	}
+	if cfg.PauseConfigMapRef != "" {
+		file.PauseConfigMapRef = cfg.PauseConfigMapRef
+	}
+
 	// Filters
 	hasFilters := len(cfg.IncludePaths) > 0 || len(cfg.ExcludePaths) > 0 ||
 		len(cfg.IncludeTags) > 0 || len(cfg.ExcludeTags) > 0 ||
@@ -455,25 +1351,18 @@ func WriteConfigFile(path string, cfg *Config) error {
 			FieldMap: cfg.IDFieldMap,
 		}
 	}
-
-	data, err := yaml.Marshal(&file)
-	if err != nil {
-		return fmt.Errorf("failed to marshal config: %w", err)
+	if len(cfg.PluralOverrides) > 0 {
+		file.PluralOverrides = cfg.PluralOverrides
 	}
-
-	// Create parent directories if needed
-	dir := filepath.Dir(path)
-	if dir != "" && dir != "." {
-		if err := os.MkdirAll(dir, 0755); err != nil {
-			return fmt.Errorf("failed to create directory: %w", err)
-		}
+	if len(cfg.ConstantPathParams) > 0 {
+		file.ConstantPathParams = cfg.ConstantPathParams
 	}
 
-	if err := os.WriteFile(path, data, 0644); err != nil {
-		return fmt.Errorf("failed to write config file: %w", err)
+	data, err := yaml.Marshal(&file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal config: %w", err)
 	}
-
-	return nil
+	return data, nil
 }
 
 // WriteExampleConfig writes an example config file to the specified path
@@ -3,7 +3,9 @@ package config
 import (
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 )
 
 func TestLoadConfigFile(t *testing.T) {
@@ -104,6 +106,16 @@ func TestLoadConfigFile_NotFound(t *testing.T) {
 	}
 }
 
+func TestLoadConfigFile_InvalidIdleConnTimeout(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ".openapi-operator-gen.yaml")
+	if err := os.WriteFile(path, []byte("httpTransport:\n  idleConnTimeout: 90\n"), 0644); err != nil {
+		t.Fatalf("failed to write config file: %v", err)
+	}
+	if _, err := LoadConfigFile(path); err == nil || !strings.Contains(err.Error(), "idleConnTimeout") {
+		t.Errorf("expected an idleConnTimeout parse error, got %v", err)
+	}
+}
+
 func TestMergeConfigFile(t *testing.T) {
 	// Start with default CLI config
 	cfg := &Config{
@@ -115,11 +127,57 @@ func TestMergeConfigFile(t *testing.T) {
 
 	// Config file with some values
 	aggregate := true
+	tilt := true
+	e2e := true
+	useETag := true
+	pprof := true
+	tracing := true
+	kubebuilder := true
+	statusResultLimit := 50
+	maxCRDs := 40
+	krewManifest := true
+	webhookPatches := true
+	skipGoMod := true
+	dashboard := true
+	bundleAdopt := true
+	deriveOwners := true
+	clientQPS := float32(50)
+	clientBurst := 100
 	fileCfg := &ConfigFile{
-		Spec:      "./api/openapi.yaml",
-		Group:     "test.example.com",
-		Output:    "./custom-output",
-		Aggregate: &aggregate,
+		Spec:                   "./api/openapi.yaml",
+		SpecRootFile:           "root.yaml",
+		SpecFormat:             "json",
+		SpecCache:              "~/.cache/oog",
+		PauseConfigMapRef:      "ops/operator-pause",
+		StatusResultLimit:      &statusResultLimit,
+		MaxCRDs:                &maxCRDs,
+		Group:                  "test.example.com",
+		Output:                 "./custom-output",
+		Aggregate:              &aggregate,
+		Tilt:                   &tilt,
+		E2E:                    &e2e,
+		UseETag:                &useETag,
+		Pprof:                  &pprof,
+		Tracing:                &tracing,
+		Kubebuilder:            &kubebuilder,
+		PprofAddr:              "0.0.0.0:6061",
+		RuntimeImage:           "gcr.io/distroless/base:nonroot",
+		ServerSelector:         "staging",
+		SlowReconcileThreshold: "30s",
+		ClientQPS:              &clientQPS,
+		ClientBurst:            &clientBurst,
+		KrewManifest:           &krewManifest,
+		WebhookPatches:         &webhookPatches,
+		ImportPrefix:           "github.com/example/monorepo/operators/test",
+		SkipGoMod:              &skipGoMod,
+		Dashboard:              &dashboard,
+		BundleAdopt:            &bundleAdopt,
+		DefaultTarget:          &TargetDefault{BaseURL: "http://api.backend.svc:8080"},
+		FinalizerName:          "test.example.com/custom-finalizer",
+		ControllerFileNaming:   "operation-id",
+		FieldNameCase:          "snake",
+		DeriveOwners:           &deriveOwners,
+		ConditionTypes:         map[string]string{"ready": "Available"},
 		Filters: &FilterConfig{
 			IncludePaths: []string{"/users", "/pets"},
 		},
@@ -131,6 +189,24 @@ func TestMergeConfigFile(t *testing.T) {
 	if cfg.SpecPath != "./api/openapi.yaml" {
 		t.Errorf("expected spec './api/openapi.yaml', got %q", cfg.SpecPath)
 	}
+	if cfg.SpecRootFile != "root.yaml" {
+		t.Errorf("expected specRootFile 'root.yaml', got %q", cfg.SpecRootFile)
+	}
+	if cfg.SpecFormat != "json" {
+		t.Errorf("expected specFormat 'json', got %q", cfg.SpecFormat)
+	}
+	if cfg.SpecCacheDir != "~/.cache/oog" {
+		t.Errorf("expected specCache '~/.cache/oog', got %q", cfg.SpecCacheDir)
+	}
+	if cfg.MaxQueryResults != 50 {
+		t.Errorf("expected statusResultLimit 50, got %d", cfg.MaxQueryResults)
+	}
+	if cfg.MaxCRDs != 40 {
+		t.Errorf("expected maxCRDs 40, got %d", cfg.MaxCRDs)
+	}
+	if cfg.PauseConfigMapRef != "ops/operator-pause" {
+		t.Errorf("expected pauseConfigMapRef 'ops/operator-pause', got %q", cfg.PauseConfigMapRef)
+	}
 	if cfg.APIGroup != "test.example.com" {
 		t.Errorf("expected group 'test.example.com', got %q", cfg.APIGroup)
 	}
@@ -140,6 +216,69 @@ func TestMergeConfigFile(t *testing.T) {
 	if !cfg.GenerateAggregate {
 		t.Error("expected aggregate to be true")
 	}
+	if !cfg.GenerateTilt {
+		t.Error("expected tilt to be true")
+	}
+	if !cfg.GenerateE2E {
+		t.Error("expected e2e to be true")
+	}
+	if !cfg.UseETag {
+		t.Error("expected useETag to be true")
+	}
+	if !cfg.EnableTracing {
+		t.Error("expected tracing to be true")
+	}
+	if !cfg.KubebuilderLayout {
+		t.Error("expected kubebuilder layout to be true")
+	}
+	if !cfg.EnablePprof || cfg.PprofAddr != "0.0.0.0:6061" {
+		t.Errorf("expected pprof enabled on '0.0.0.0:6061', got %v %q", cfg.EnablePprof, cfg.PprofAddr)
+	}
+	if cfg.ServerSelector != "staging" {
+		t.Errorf("expected serverSelector 'staging', got %q", cfg.ServerSelector)
+	}
+	if cfg.RuntimeImage != "gcr.io/distroless/base:nonroot" {
+		t.Errorf("expected runtimeImage 'gcr.io/distroless/base:nonroot', got %q", cfg.RuntimeImage)
+	}
+	if cfg.SlowReconcileThreshold != 30*time.Second {
+		t.Errorf("expected slowReconcileThreshold 30s, got %v", cfg.SlowReconcileThreshold)
+	}
+	if cfg.KubeClientQPS != 50 || cfg.KubeClientBurst != 100 {
+		t.Errorf("expected clientQPS 50 and clientBurst 100, got %v and %d", cfg.KubeClientQPS, cfg.KubeClientBurst)
+	}
+	if !cfg.GenerateKrewManifest {
+		t.Error("expected krewManifest to be true")
+	}
+	if !cfg.GenerateWebhookPatches {
+		t.Error("expected webhookPatches to be true")
+	}
+	if cfg.ImportPrefix != "github.com/example/monorepo/operators/test" || !cfg.SkipGoMod {
+		t.Errorf("expected importPrefix and skipGoMod, got %q %v", cfg.ImportPrefix, cfg.SkipGoMod)
+	}
+	if !cfg.BundleAdopt {
+		t.Error("expected bundleAdopt to be true")
+	}
+	if !cfg.GenerateDashboard {
+		t.Error("expected dashboard to be true")
+	}
+	if cfg.DefaultTarget == nil || cfg.DefaultTarget.BaseURL != "http://api.backend.svc:8080" {
+		t.Errorf("expected defaultTarget baseURL, got %+v", cfg.DefaultTarget)
+	}
+	if cfg.FinalizerName != "test.example.com/custom-finalizer" {
+		t.Errorf("expected finalizerName 'test.example.com/custom-finalizer', got %q", cfg.FinalizerName)
+	}
+	if cfg.ControllerFileNaming != ControllerFileNamingOperationID {
+		t.Errorf("expected controllerFileNaming 'operation-id', got %q", cfg.ControllerFileNaming)
+	}
+	if cfg.FieldNameCase != FieldNameCaseSnake {
+		t.Errorf("expected fieldNameCase 'snake', got %q", cfg.FieldNameCase)
+	}
+	if !cfg.DeriveOwners {
+		t.Error("expected deriveOwners to be true")
+	}
+	if cfg.ConditionType(ConditionReady) != "Available" {
+		t.Errorf("expected conditionTypes ready 'Available', got %q", cfg.ConditionType(ConditionReady))
+	}
 	if len(cfg.IncludePaths) != 2 {
 		t.Errorf("expected 2 includePaths, got %d", len(cfg.IncludePaths))
 	}
@@ -184,6 +323,180 @@ func TestMergeConfigFile_CLIOverrides(t *testing.T) {
 	}
 }
 
+func TestMergeConfigFile_HTTPTransport(t *testing.T) {
+	cfg := &Config{}
+
+	maxIdle := 500
+	maxPerHost := 50
+	http2 := false
+	fileCfg := &ConfigFile{
+		HTTPTransport: &HTTPTransportFileConfig{
+			MaxIdleConns:    &maxIdle,
+			MaxConnsPerHost: &maxPerHost,
+			IdleConnTimeout: "30s",
+			HTTP2:           &http2,
+		},
+	}
+
+	MergeConfigFile(cfg, fileCfg)
+
+	if cfg.HTTPTransport.MaxIdleConns != 500 {
+		t.Errorf("expected MaxIdleConns 500, got %d", cfg.HTTPTransport.MaxIdleConns)
+	}
+	if cfg.HTTPTransport.MaxConnsPerHost != 50 {
+		t.Errorf("expected MaxConnsPerHost 50, got %d", cfg.HTTPTransport.MaxConnsPerHost)
+	}
+	if cfg.HTTPTransport.IdleConnTimeout != 30*time.Second {
+		t.Errorf("expected IdleConnTimeout 30s, got %v", cfg.HTTPTransport.IdleConnTimeout)
+	}
+	if cfg.HTTPTransport.HTTP2Enabled() {
+		t.Error("expected HTTP/2 to be disabled")
+	}
+
+	// CLI values take precedence
+	http2Enabled := true
+	cfg = &Config{HTTPTransport: HTTPTransportConfig{MaxIdleConns: 10, HTTP2: &http2Enabled}}
+	MergeConfigFile(cfg, fileCfg)
+	if cfg.HTTPTransport.MaxIdleConns != 10 {
+		t.Errorf("expected CLI MaxIdleConns preserved, got %d", cfg.HTTPTransport.MaxIdleConns)
+	}
+	if !cfg.HTTPTransport.HTTP2Enabled() {
+		t.Error("expected CLI --http2=true to override http2: false")
+	}
+}
+
+func TestConfigFile_Set(t *testing.T) {
+	maxIdle := 500
+	file := &ConfigFile{
+		Spec:          "petstore.yaml",
+		Filters:       &FilterConfig{ExcludePaths: []string{"/admin/*"}, IncludeTags: []string{"public"}},
+		HTTPTransport: &HTTPTransportFileConfig{MaxIdleConns: &maxIdle},
+	}
+
+	for _, pair := range []string{
+		"useETag=true",
+		"maxCRDs=20",
+		"acceptHeader=application/json",
+		"filters.excludePaths=[/internal/*, /debug/*]",
+		"pluralOverrides={Datum: data}",
+		"httpTransport.idleConnTimeout=30s",
+	} {
+		if err := file.Set(pair); err != nil {
+			t.Fatalf("Set(%q) unexpected error: %v", pair, err)
+		}
+	}
+
+	if file.UseETag == nil || !*file.UseETag {
+		t.Error("expected useETag to be set")
+	}
+	if file.MaxCRDs == nil || *file.MaxCRDs != 20 {
+		t.Errorf("expected maxCRDs 20, got %v", file.MaxCRDs)
+	}
+	if file.AcceptHeader != "application/json" {
+		t.Errorf("expected acceptHeader application/json, got %q", file.AcceptHeader)
+	}
+	if len(file.Filters.ExcludePaths) != 2 || file.Filters.ExcludePaths[0] != "/internal/*" || len(file.Filters.IncludeTags) != 1 {
+		t.Errorf("expected excludePaths to be replaced and includeTags kept, got %+v", file.Filters)
+	}
+	if file.PluralOverrides["Datum"] != "data" {
+		t.Errorf("expected pluralOverrides Datum=data, got %v", file.PluralOverrides)
+	}
+	if file.HTTPTransport.IdleConnTimeout != "30s" || file.HTTPTransport.MaxIdleConns == nil || *file.HTTPTransport.MaxIdleConns != 500 {
+		t.Errorf("expected a nested setting to keep its siblings, got %+v", file.HTTPTransport)
+	}
+	if file.Spec != "petstore.yaml" {
+		t.Errorf("expected other settings to be kept, got spec %q", file.Spec)
+	}
+
+	for pair, want := range map[string]string{
+		"useEtag=true":                       `unknown setting "useEtag"`,
+		"httpTransport.maxIdle=1":            `unknown setting "httpTransport.maxIdle"`,
+		"maxCRDs=lots":                       "invalid value",
+		"filters.excludePaths={a: b}":        "invalid value",
+		"useETag":                            "expected key=value",
+		"=true":                              "expected key=value",
+		"httpTransport.idleConnTimeout=soon": "invalid httpTransport.idleConnTimeout",
+	} {
+		err := file.Set(pair)
+		if err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("Set(%q) expected error containing %q, got %v", pair, want, err)
+		}
+	}
+}
+
+func TestWriteConfigFile_SecurityContextRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ".openapi-operator-gen.yaml")
+	cfg := &Config{
+		SpecPath: "./spec.yaml",
+		SecurityContext: SecurityContextConfig{
+			WritableRootFilesystem: true,
+			AddCapabilities:        []string{"NET_BIND_SERVICE"},
+			SeccompProfile:         DefaultSeccompProfile,
+		},
+	}
+
+	if err := WriteConfigFile(path, cfg); err != nil {
+		t.Fatalf("WriteConfigFile failed: %v", err)
+	}
+	fileCfg, err := LoadConfigFile(path)
+	if err != nil {
+		t.Fatalf("LoadConfigFile failed: %v", err)
+	}
+	if fileCfg.SecurityContext == nil {
+		t.Fatal("expected securityContext section to be written")
+	}
+	if fileCfg.SecurityContext.SeccompProfile != "" || fileCfg.SecurityContext.AllowRunAsRoot != nil {
+		t.Error("expected default seccompProfile and allowRunAsRoot to be omitted")
+	}
+
+	loaded := ConfigFromFile(fileCfg)
+	if !loaded.SecurityContext.WritableRootFilesystem || loaded.SecurityContext.AllowRunAsRoot {
+		t.Errorf("expected only a writable root filesystem, got %+v", loaded.SecurityContext)
+	}
+	if len(loaded.SecurityContext.AddCapabilities) != 1 || loaded.SecurityContext.AddCapabilities[0] != "NET_BIND_SERVICE" {
+		t.Errorf("expected NET_BIND_SERVICE capability, got %v", loaded.SecurityContext.AddCapabilities)
+	}
+}
+
+func TestWriteConfigFile_HTTPTransportRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ".openapi-operator-gen.yaml")
+	http2 := false
+	cfg := &Config{
+		SpecPath: "./spec.yaml",
+		HTTPTransport: HTTPTransportConfig{
+			MaxIdleConns:    DefaultHTTPMaxIdleConns,
+			MaxConnsPerHost: 25,
+			IdleConnTimeout: 2 * time.Minute,
+			HTTP2:           &http2,
+		},
+	}
+
+	if err := WriteConfigFile(path, cfg); err != nil {
+		t.Fatalf("WriteConfigFile failed: %v", err)
+	}
+	fileCfg, err := LoadConfigFile(path)
+	if err != nil {
+		t.Fatalf("LoadConfigFile failed: %v", err)
+	}
+	if fileCfg.HTTPTransport == nil {
+		t.Fatal("expected httpTransport section to be written")
+	}
+	if fileCfg.HTTPTransport.MaxIdleConns != nil {
+		t.Error("expected default maxIdleConns to be omitted")
+	}
+
+	loaded := ConfigFromFile(fileCfg)
+	if loaded.HTTPTransport.MaxConnsPerHost != 25 {
+		t.Errorf("expected MaxConnsPerHost 25, got %d", loaded.HTTPTransport.MaxConnsPerHost)
+	}
+	if loaded.HTTPTransport.IdleConnTimeout != 2*time.Minute {
+		t.Errorf("expected IdleConnTimeout 2m, got %v", loaded.HTTPTransport.IdleConnTimeout)
+	}
+	if loaded.HTTPTransport.HTTP2Enabled() {
+		t.Error("expected HTTP/2 to stay disabled")
+	}
+}
+
 func TestFindConfigFile(t *testing.T) {
 	// Create a temp directory and change to it
 	tmpDir := t.TempDir()
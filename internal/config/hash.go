@@ -6,14 +6,20 @@ import (
 	"io"
 	"net/http"
 	"os"
+	"path/filepath"
+	"sort"
 	"strings"
 )
 
-// HashSpecFile computes the SHA-256 hash of a spec file or URL.
-// Returns a string in the format "sha256:<hex>".
+// HashSpecFile computes the SHA-256 hash of a spec file, URL, or directory of split
+// spec files. Returns a string in the format "sha256:<hex>".
 func HashSpecFile(specPath string) (string, error) {
 	var data []byte
 
+	if info, err := os.Stat(specPath); err == nil && info.IsDir() {
+		return hashSpecDir(specPath)
+	}
+
 	if strings.HasPrefix(specPath, "http://") || strings.HasPrefix(specPath, "https://") {
 		resp, err := http.Get(specPath)
 		if err != nil {
@@ -46,3 +52,50 @@ func HashSpecBytes(data []byte) string {
 	h := sha256.Sum256(data)
 	return fmt.Sprintf("sha256:%x", h)
 }
+
+// hashSpecDir hashes every YAML/JSON file under dir, in path order, together with
+// its relative path so that renames and moves change the hash too.
+func hashSpecDir(dir string) (string, error) {
+	files, err := SpecDirFiles(dir)
+	if err != nil {
+		return "", err
+	}
+	h := sha256.New()
+	for _, rel := range files {
+		data, err := os.ReadFile(filepath.Join(dir, rel))
+		if err != nil {
+			return "", fmt.Errorf("failed to read spec file: %w", err)
+		}
+		fmt.Fprintf(h, "%s\x00%d\x00", filepath.ToSlash(rel), len(data))
+		h.Write(data)
+	}
+	return fmt.Sprintf("sha256:%x", h.Sum(nil)), nil
+}
+
+// SpecDirFiles lists the YAML/JSON files under a split spec directory, relative to
+// dir and sorted.
+func SpecDirFiles(dir string) ([]string, error) {
+	var files []string
+	err := filepath.WalkDir(dir, func(path string, d os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+		switch strings.ToLower(filepath.Ext(path)) {
+		case ".yaml", ".yml", ".json":
+			rel, err := filepath.Rel(dir, path)
+			if err != nil {
+				return err
+			}
+			files = append(files, rel)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan spec directory: %w", err)
+	}
+	sort.Strings(files)
+	return files, nil
+}
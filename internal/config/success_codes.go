@@ -0,0 +1,84 @@
+package config
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// successCodeMethods are the HTTP methods success codes can be configured for
+var successCodeMethods = []string{"GET", "POST", "PUT", "PATCH", "DELETE"}
+
+// ParseSuccessCodes parses a comma-separated list of METHOD=CODE[|CODE...] entries
+// (e.g., "DELETE=204,POST=201|202") into the response codes each method treats as success
+func ParseSuccessCodes(s string) (map[string][]int, error) {
+	result := make(map[string][]int)
+	for _, part := range strings.Split(s, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		method, codes, ok := strings.Cut(part, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid success codes %q: expected METHOD=CODE[|CODE...]", part)
+		}
+		parsed, err := ParseStatusCodes(strings.Split(codes, "|"))
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", strings.TrimSpace(method), err)
+		}
+		result[strings.ToUpper(strings.TrimSpace(method))] = parsed
+	}
+	if err := validateSuccessCodes(result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// ParseStatusCodes converts the values of an x-k8s-success-codes extension or a
+// --success-codes entry into sorted, de-duplicated HTTP status codes
+func ParseStatusCodes(values []string) ([]int, error) {
+	seen := make(map[int]bool)
+	var codes []int
+	for _, v := range values {
+		v = strings.TrimSpace(v)
+		if v == "" {
+			continue
+		}
+		code, err := strconv.Atoi(v)
+		if err != nil || code < 100 || code > 599 {
+			return nil, fmt.Errorf("invalid HTTP status code %q", v)
+		}
+		if !seen[code] {
+			seen[code] = true
+			codes = append(codes, code)
+		}
+	}
+	if len(codes) == 0 {
+		return nil, fmt.Errorf("at least one status code is required")
+	}
+	sort.Ints(codes)
+	return codes, nil
+}
+
+// validateSuccessCodes checks the methods and codes of a SuccessCodes map
+func validateSuccessCodes(successCodes map[string][]int) error {
+	for method, codes := range successCodes {
+		known := false
+		for _, m := range successCodeMethods {
+			known = known || m == method
+		}
+		if !known {
+			return fmt.Errorf("unknown HTTP method %q (supported: %s)", method, strings.Join(successCodeMethods, ", "))
+		}
+		if len(codes) == 0 {
+			return fmt.Errorf("%s: at least one status code is required", method)
+		}
+		for _, code := range codes {
+			if code < 100 || code > 599 {
+				return fmt.Errorf("%s: invalid HTTP status code %d", method, code)
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,113 @@
+package config
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// TargetDefault presets the endpoint targeting (spec.target) of generated CRs, so users
+// of an API with a well-known in-cluster Service don't have to fill it in. Keys match
+// the TargetSpec fields of the generated types; a CR that sets spec.target overrides it.
+type TargetDefault struct {
+	BaseURL     string `yaml:"baseURL,omitempty"`
+	HelmRelease string `yaml:"helmRelease,omitempty"`
+	StatefulSet string `yaml:"statefulSet,omitempty"`
+	Deployment  string `yaml:"deployment,omitempty"`
+	Namespace   string `yaml:"namespace,omitempty"`
+}
+
+// TargetDefaultEntry is one key/value pair of a TargetDefault
+type TargetDefaultEntry struct {
+	Key   string
+	Value string
+}
+
+// targetDefaultKeys lists the supported keys in TargetSpec field order
+var targetDefaultKeys = []string{"helmRelease", "statefulSet", "deployment", "namespace", "baseURL"}
+
+// field returns a pointer to the TargetDefault field for a key, or nil if unknown
+func (t *TargetDefault) field(key string) *string {
+	switch key {
+	case "baseURL":
+		return &t.BaseURL
+	case "helmRelease":
+		return &t.HelmRelease
+	case "statefulSet":
+		return &t.StatefulSet
+	case "deployment":
+		return &t.Deployment
+	case "namespace":
+		return &t.Namespace
+	}
+	return nil
+}
+
+// Entries returns the non-empty fields in TargetSpec field order
+func (t *TargetDefault) Entries() []TargetDefaultEntry {
+	if t == nil {
+		return nil
+	}
+	var entries []TargetDefaultEntry
+	for _, key := range targetDefaultKeys {
+		if v := *t.field(key); v != "" {
+			entries = append(entries, TargetDefaultEntry{Key: key, Value: v})
+		}
+	}
+	return entries
+}
+
+// IsEmpty reports whether no field is set
+func (t *TargetDefault) IsEmpty() bool {
+	return len(t.Entries()) == 0
+}
+
+// String renders the target in the key=value,... form accepted by ParseTargetDefault
+func (t *TargetDefault) String() string {
+	parts := make([]string, 0, len(targetDefaultKeys))
+	for _, e := range t.Entries() {
+		parts = append(parts, e.Key+"="+e.Value)
+	}
+	return strings.Join(parts, ",")
+}
+
+// ParseTargetDefault parses a target in key=value,... form
+// (e.g., "deployment=petstore-api,namespace=backend").
+func ParseTargetDefault(s string) (*TargetDefault, error) {
+	values := make(map[string]string)
+	for _, part := range strings.Split(s, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		key, value, ok := strings.Cut(part, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid target %q: expected key=value", part)
+		}
+		values[strings.TrimSpace(key)] = strings.TrimSpace(value)
+	}
+	return TargetDefaultFromMap(values)
+}
+
+// TargetDefaultFromMap builds a TargetDefault from the keys of an x-k8s-target-default
+// extension or a parsed key=value list. Unknown keys are rejected.
+func TargetDefaultFromMap(values map[string]string) (*TargetDefault, error) {
+	keys := make([]string, 0, len(values))
+	for k := range values {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	t := &TargetDefault{}
+	for _, k := range keys {
+		f := t.field(k)
+		if f == nil {
+			return nil, fmt.Errorf("unknown target key %q (supported: %s)", k, strings.Join(targetDefaultKeys, ", "))
+		}
+		*f = values[k]
+	}
+	if t.IsEmpty() {
+		return nil, fmt.Errorf("target must set at least one of %s", strings.Join(targetDefaultKeys, ", "))
+	}
+	return t, nil
+}
@@ -167,9 +167,28 @@ func KindToVariableName(kind string) string {
 	return KindToResourceName(kind)
 }
 
+// resourceNames holds the exact plurals registered with RegisterResourceNames
+var resourceNames map[string]string
+
+// RegisterResourceNames sets the exact resource names (plurals) of Kinds whose plural was
+// given with x-k8s-plural, so KindToResourceName doesn't apply the heuristic to them.
+// Generated controllers call it from init; it must not race with KindToResourceName.
+func RegisterResourceNames(names map[string]string) {
+	if resourceNames == nil {
+		resourceNames = make(map[string]string, len(names))
+	}
+	for kind, plural := range names {
+		resourceNames[kind] = plural
+	}
+}
+
 // KindToResourceName converts a Kind name to a Kubernetes resource name (lowercase plural).
 // Example: "Order" -> "orders", "Pet" -> "pets", "StoreInventoryQuery" -> "storeinventoryqueries"
+// Kinds registered with RegisterResourceNames return their registered plural.
 func KindToResourceName(kind string) string {
+	if plural, ok := resourceNames[kind]; ok {
+		return plural
+	}
 	lower := strings.ToLower(kind)
 	// Handle common irregular pluralizations
 	if strings.HasSuffix(lower, "query") {
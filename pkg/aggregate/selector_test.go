@@ -560,6 +560,22 @@ func TestKindToResourceName(t *testing.T) {
 	}
 }
 
+func TestRegisterResourceNames(t *testing.T) {
+	t.Cleanup(func() { resourceNames = nil })
+
+	RegisterResourceNames(map[string]string{"Datum": "data"})
+
+	if got := KindToResourceName("Datum"); got != "data" {
+		t.Errorf("KindToResourceName(Datum) = %q, want the registered plural data", got)
+	}
+	if got := KindToVariableName("Datum"); got != "data" {
+		t.Errorf("KindToVariableName(Datum) = %q, want data", got)
+	}
+	if got := KindToResourceName("Pet"); got != "pets" {
+		t.Errorf("KindToResourceName(Pet) = %q, want the heuristic plural pets", got)
+	}
+}
+
 func TestCompiledSelector_LabelSelectorString(t *testing.T) {
 	tests := []struct {
 		name string
@@ -0,0 +1,42 @@
+package controller
+
+import (
+	"fmt"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// DeletionBlockedConditionType is the condition set on a CR being deleted while the DELETE
+// of its external resource keeps failing.
+const DeletionBlockedConditionType = "DeletionBlocked"
+
+// Reasons of the DeletionBlocked condition.
+const (
+	// DeletionBlockedReasonFailed is set while the controller retries within the delete timeout.
+	DeletionBlockedReasonFailed = "DeleteFailed"
+	// DeletionBlockedReasonTimeout is set once the delete timeout has passed.
+	DeletionBlockedReasonTimeout = "DeleteTimeout"
+)
+
+// DeletionBlockedCondition returns the DeletionBlocked condition for a CR whose external
+// resource failed to delete with err, deletion having been requested at requested, and
+// whether timeout has passed since then.
+func DeletionBlockedCondition(err error, requested, now time.Time, timeout time.Duration) (metav1.Condition, bool) {
+	blockedFor := now.Sub(requested).Round(time.Second)
+	if blockedFor < timeout {
+		return metav1.Condition{
+			Type:    DeletionBlockedConditionType,
+			Status:  metav1.ConditionTrue,
+			Reason:  DeletionBlockedReasonFailed,
+			Message: fmt.Sprintf("Deleting the external resource failed, retrying for up to %s: %v", timeout, err),
+		}, false
+	}
+	return metav1.Condition{
+		Type:   DeletionBlockedConditionType,
+		Status: metav1.ConditionTrue,
+		Reason: DeletionBlockedReasonTimeout,
+		Message: fmt.Sprintf("Deleting the external resource has failed for %s (delete timeout %s); "+
+			"set spec.onDelete to Orphan to remove the CR without deleting it: %v", blockedFor, timeout, err),
+	}, true
+}
@@ -0,0 +1,35 @@
+package controller
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestDeletionBlockedCondition(t *testing.T) {
+	requested := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	err := errors.New("DELETE failed with status 503")
+
+	condition, expired := DeletionBlockedCondition(err, requested, requested.Add(time.Minute), 5*time.Minute)
+	if expired {
+		t.Error("expected the timeout not to have passed after a minute")
+	}
+	if condition.Type != DeletionBlockedConditionType || condition.Reason != DeletionBlockedReasonFailed {
+		t.Errorf("condition = %s/%s, want %s/%s", condition.Type, condition.Reason, DeletionBlockedConditionType, DeletionBlockedReasonFailed)
+	}
+	if !strings.Contains(condition.Message, "status 503") {
+		t.Errorf("Message = %q, want it to include the error", condition.Message)
+	}
+
+	condition, expired = DeletionBlockedCondition(err, requested, requested.Add(5*time.Minute), 5*time.Minute)
+	if !expired {
+		t.Error("expected the timeout to have passed")
+	}
+	if condition.Reason != DeletionBlockedReasonTimeout {
+		t.Errorf("Reason = %q, want %q", condition.Reason, DeletionBlockedReasonTimeout)
+	}
+	if !strings.Contains(condition.Message, "for 5m0s") || !strings.Contains(condition.Message, "spec.onDelete") {
+		t.Errorf("Message = %q, want the blocked duration and the orphan hint", condition.Message)
+	}
+}
@@ -0,0 +1,95 @@
+package controller
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// DryRunConditionType is the condition set on a CR while a dry run skips its REST API writes.
+const DryRunConditionType = "DryRun"
+
+// DryRunConditionReason is the reason of the DryRun condition.
+const DryRunConditionReason = "WriteSkipped"
+
+// maxDryRunBodyLog caps the request body logged for a skipped call.
+const maxDryRunBodyLog = 4096
+
+// ErrDryRun is wrapped by the errors of the REST API calls skipped by DryRunTransport.
+var ErrDryRun = errors.New("dry run")
+
+// DryRunError is the error DryRunTransport returns for a skipped call.
+type DryRunError struct {
+	Method string
+	URL    string
+}
+
+func (e *DryRunError) Error() string {
+	return fmt.Sprintf("dry run: would %s %s", e.Method, e.URL)
+}
+
+// Unwrap lets errors.Is match ErrDryRun.
+func (e *DryRunError) Unwrap() error { return ErrDryRun }
+
+// DryRunTransport lets GET, HEAD and OPTIONS requests through to Base and skips every
+// other request: it logs the method, URL and body through the request context's
+// logger and fails the call with a *DryRunError, so a controller's write path stops
+// before changing anything.
+type DryRunTransport struct {
+	// Base sends the read requests. Nil uses http.DefaultTransport.
+	Base http.RoundTripper
+}
+
+// NewDryRunTransport wraps base in a DryRunTransport.
+func NewDryRunTransport(base http.RoundTripper) *DryRunTransport {
+	return &DryRunTransport{Base: base}
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *DryRunTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		base := t.Base
+		if base == nil {
+			base = http.DefaultTransport
+		}
+		return base.RoundTrip(req)
+	}
+
+	var body string
+	if req.Body != nil {
+		data, _ := io.ReadAll(io.LimitReader(req.Body, maxDryRunBodyLog+1))
+		_ = req.Body.Close()
+		body = string(data)
+		if len(data) > maxDryRunBodyLog {
+			body = string(data[:maxDryRunBodyLog]) + "...(truncated)"
+		}
+	}
+	log.FromContext(req.Context()).Info("Dry run: skipped REST API call",
+		"method", req.Method, "url", req.URL.String(), "body", body)
+	return nil, &DryRunError{Method: req.Method, URL: req.URL.String()}
+}
+
+// IsDryRun reports whether err comes from a call skipped by DryRunTransport.
+func IsDryRun(err error) bool {
+	return errors.Is(err, ErrDryRun)
+}
+
+// DryRunCondition returns the DryRun condition to set on a CR whose reconcile stopped at
+// a skipped call, and false when err isn't from a skipped call.
+func DryRunCondition(err error) (metav1.Condition, bool) {
+	var dryRunErr *DryRunError
+	if !errors.As(err, &dryRunErr) {
+		return metav1.Condition{}, false
+	}
+	return metav1.Condition{
+		Type:    DryRunConditionType,
+		Status:  metav1.ConditionTrue,
+		Reason:  DryRunConditionReason,
+		Message: fmt.Sprintf("Would %s %s; REST API writes are disabled by --dry-run-external", dryRunErr.Method, dryRunErr.URL),
+	}, true
+}
@@ -0,0 +1,64 @@
+package controller
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestDryRunTransport(t *testing.T) {
+	var hits int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits++
+		_, _ = w.Write([]byte(`{"id":1}`))
+	}))
+	defer server.Close()
+
+	client := &http.Client{Transport: NewDryRunTransport(http.DefaultTransport)}
+
+	resp, err := client.Get(server.URL + "/pets/1")
+	if err != nil {
+		t.Fatalf("GET failed: %v", err)
+	}
+	body, _ := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	if string(body) != `{"id":1}` {
+		t.Errorf("GET body = %q, want the server's response", body)
+	}
+
+	for _, method := range []string{http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete} {
+		req, err := http.NewRequest(method, server.URL+"/pets", strings.NewReader(`{"name":"rex"}`))
+		if err != nil {
+			t.Fatalf("NewRequest failed: %v", err)
+		}
+		_, err = client.Do(req)
+		if !IsDryRun(err) {
+			t.Fatalf("%s: expected a dry-run error, got %v", method, err)
+		}
+		var dryRunErr *DryRunError
+		if !errors.As(err, &dryRunErr) || dryRunErr.Method != method {
+			t.Errorf("%s: expected a *DryRunError for the method, got %v", method, err)
+		}
+
+		condition, ok := DryRunCondition(err)
+		if !ok {
+			t.Fatalf("%s: expected a DryRun condition", method)
+		}
+		if condition.Type != DryRunConditionType || condition.Reason != DryRunConditionReason {
+			t.Errorf("%s: condition = %s/%s", method, condition.Type, condition.Reason)
+		}
+		if !strings.Contains(condition.Message, method+" "+server.URL+"/pets") {
+			t.Errorf("%s: condition message %q should name the skipped call", method, condition.Message)
+		}
+	}
+
+	if hits != 1 {
+		t.Errorf("server got %d requests, want only the GET", hits)
+	}
+	if _, ok := DryRunCondition(errors.New("connection refused")); ok {
+		t.Error("expected no DryRun condition for other errors")
+	}
+}
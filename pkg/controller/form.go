@@ -0,0 +1,67 @@
+package controller
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/url"
+)
+
+// FormContentType is the Content-Type of bodies encoded by EncodeForm.
+const FormContentType = "application/x-www-form-urlencoded"
+
+// EncodeForm converts a JSON object body into an application/x-www-form-urlencoded body.
+// Strings, numbers and booleans are sent as their text, arrays of them as repeated
+// keys, and nested objects and arrays of objects as JSON text. Null fields are omitted.
+// Keys are sorted, so the encoding of a spec is stable across reconciles.
+func EncodeForm(data []byte) ([]byte, error) {
+	var fields map[string]interface{}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	if err := decoder.Decode(&fields); err != nil {
+		return nil, fmt.Errorf("form body must be a JSON object: %w", err)
+	}
+
+	values := url.Values{}
+	for key, value := range fields {
+		if items, ok := value.([]interface{}); ok && scalarItems(items) {
+			for _, item := range items {
+				values.Add(key, formText(item))
+			}
+			continue
+		}
+		if value != nil {
+			values.Set(key, formText(value))
+		}
+	}
+	return []byte(values.Encode()), nil
+}
+
+// scalarItems reports whether an array holds only strings, numbers and booleans.
+func scalarItems(items []interface{}) bool {
+	for _, item := range items {
+		switch item.(type) {
+		case string, json.Number, bool:
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// formText renders a decoded JSON value as the text of a form field.
+func formText(value interface{}) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case json.Number:
+		return v.String()
+	case bool:
+		if v {
+			return "true"
+		}
+		return "false"
+	}
+	encoded, _ := json.Marshal(value)
+	return string(encoded)
+}
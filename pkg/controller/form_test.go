@@ -0,0 +1,50 @@
+package controller
+
+import "testing"
+
+func TestEncodeForm(t *testing.T) {
+	tests := []struct {
+		name    string
+		body    string
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "scalars",
+			body: `{"username":"jane doe","age":42,"id":9007199254740993,"admin":false}`,
+			want: "admin=false&age=42&id=9007199254740993&username=jane+doe",
+		},
+		{
+			name: "scalar arrays repeat the key",
+			body: `{"tags":["a","b"],"ids":[1,2]}`,
+			want: "ids=1&ids=2&tags=a&tags=b",
+		},
+		{
+			name: "objects are sent as JSON",
+			body: `{"category":{"id":1},"photos":[{"url":"x"}]}`,
+			want: "category=%7B%22id%22%3A1%7D&photos=%5B%7B%22url%22%3A%22x%22%7D%5D",
+		},
+		{
+			name: "nulls are omitted",
+			body: `{"name":"rex","owner":null}`,
+			want: "name=rex",
+		},
+		{
+			name:    "not an object",
+			body:    `["a"]`,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := EncodeForm([]byte(tt.body))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("EncodeForm error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if string(got) != tt.want {
+				t.Errorf("EncodeForm = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,51 @@
+package controller
+
+import (
+	"net/http"
+	"strings"
+)
+
+// RedactedHeaderValue replaces the values of sensitive headers in logs.
+const RedactedHeaderValue = "REDACTED"
+
+// sensitiveHeaderWords mark a header as sensitive when its lowercased name contains one
+// of them (e.g., Authorization, X-Api-Key, X-Session-Token).
+var sensitiveHeaderWords = []string{
+	"auth", "token", "secret", "password", "cookie", "key", "credential", "signature", "session",
+}
+
+// ApplyExtraHeaders sets the headers of a CR's spec.extraHeaders on a REST API request.
+// Headers the controller already set on the request (Accept, Content-Type, If-Match)
+// are kept.
+func ApplyExtraHeaders(req *http.Request, headers map[string]string) {
+	for name, value := range headers {
+		if req.Header.Get(name) != "" {
+			continue
+		}
+		req.Header.Set(name, value)
+	}
+}
+
+// RedactHeaders returns a copy of headers for logging, with the values of sensitive
+// headers replaced by RedactedHeaderValue.
+func RedactHeaders(headers map[string]string) map[string]string {
+	redacted := make(map[string]string, len(headers))
+	for name, value := range headers {
+		if IsSensitiveHeader(name) {
+			value = RedactedHeaderValue
+		}
+		redacted[name] = value
+	}
+	return redacted
+}
+
+// IsSensitiveHeader reports whether a header likely carries a credential.
+func IsSensitiveHeader(name string) bool {
+	lower := strings.ToLower(name)
+	for _, word := range sensitiveHeaderWords {
+		if strings.Contains(lower, word) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,57 @@
+package controller
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestApplyExtraHeaders(t *testing.T) {
+	req, err := http.NewRequest(http.MethodPost, "http://example.com/pets", nil)
+	if err != nil {
+		t.Fatalf("NewRequest failed: %v", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	ApplyExtraHeaders(req, map[string]string{
+		"X-Tenant":     "acme",
+		"traceparent":  "00-abc-def-01",
+		"content-type": "text/plain",
+	})
+
+	if got := req.Header.Get("X-Tenant"); got != "acme" {
+		t.Errorf("X-Tenant = %q, want acme", got)
+	}
+	if got := req.Header.Get("Traceparent"); got != "00-abc-def-01" {
+		t.Errorf("Traceparent = %q, want 00-abc-def-01", got)
+	}
+	if got := req.Header.Get("Content-Type"); got != "application/json" {
+		t.Errorf("expected the controller's Content-Type to be kept, got %q", got)
+	}
+
+	ApplyExtraHeaders(req, nil)
+}
+
+func TestRedactHeaders(t *testing.T) {
+	headers := map[string]string{
+		"X-Tenant":      "acme",
+		"Authorization": "Bearer abc",
+		"X-Api-Key":     "k",
+		"X-Auth-Token":  "t",
+	}
+	got := RedactHeaders(headers)
+
+	want := map[string]string{
+		"X-Tenant":      "acme",
+		"Authorization": RedactedHeaderValue,
+		"X-Api-Key":     RedactedHeaderValue,
+		"X-Auth-Token":  RedactedHeaderValue,
+	}
+	for name, value := range want {
+		if got[name] != value {
+			t.Errorf("%s = %q, want %q", name, got[name], value)
+		}
+	}
+	if headers["Authorization"] != "Bearer abc" {
+		t.Error("RedactHeaders must not modify its input")
+	}
+}
@@ -0,0 +1,36 @@
+package controller
+
+import (
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// IdempotencyKey returns the key sent with a create request for a CR at a given
+// generation. It only changes when the CR is recreated or its spec changes, so a POST
+// retried after a failed status update is recognized by the API as a duplicate.
+func IdempotencyKey(uid types.UID, generation int64) string {
+	return fmt.Sprintf("%s-%d", uid, generation)
+}
+
+// ExecutionIdempotencyKey returns the key sent with an action request. The execution
+// number keeps the periodic executions of an unchanged CR apart, while a retry of the
+// same execution reuses its key.
+func ExecutionIdempotencyKey(uid types.UID, generation, execution int64) string {
+	return fmt.Sprintf("%s-%d-%d", uid, generation, execution)
+}
+
+// SpecVersion returns a number that only changes with the spec, for idempotency keys of
+// CRs whose CRD has no status subresource: their status writes bump metadata.generation
+// too, so the generation would change the key on every retry.
+func SpecVersion(spec interface{}) int64 {
+	data, err := json.Marshal(spec)
+	if err != nil {
+		return 0
+	}
+	h := fnv.New64a()
+	h.Write(data)
+	return int64(h.Sum64() >> 1)
+}
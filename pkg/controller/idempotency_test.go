@@ -0,0 +1,34 @@
+package controller
+
+import "testing"
+
+func TestIdempotencyKey(t *testing.T) {
+	const uid = "6f1c2b8e-0000-4000-8000-000000000001"
+	if got, want := IdempotencyKey(uid, 3), uid+"-3"; got != want {
+		t.Errorf("IdempotencyKey = %q, want %q", got, want)
+	}
+	if IdempotencyKey(uid, 1) == IdempotencyKey(uid, 2) {
+		t.Error("IdempotencyKey should change with the generation")
+	}
+	if got, want := ExecutionIdempotencyKey(uid, 3, 0), uid+"-3-0"; got != want {
+		t.Errorf("ExecutionIdempotencyKey = %q, want %q", got, want)
+	}
+	if ExecutionIdempotencyKey(uid, 1, 1) == ExecutionIdempotencyKey(uid, 1, 2) {
+		t.Error("ExecutionIdempotencyKey should change with the execution")
+	}
+}
+
+func TestSpecVersion(t *testing.T) {
+	type spec struct {
+		Name string `json:"name"`
+	}
+	if SpecVersion(spec{Name: "a"}) != SpecVersion(spec{Name: "a"}) {
+		t.Error("SpecVersion should be stable for an unchanged spec")
+	}
+	if SpecVersion(spec{Name: "a"}) == SpecVersion(spec{Name: "b"}) {
+		t.Error("SpecVersion should change with the spec")
+	}
+	if v := SpecVersion(spec{Name: "a"}); v < 0 {
+		t.Errorf("SpecVersion = %d, want a non-negative number", v)
+	}
+}
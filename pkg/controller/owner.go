@@ -0,0 +1,37 @@
+package controller
+
+import (
+	"fmt"
+	"reflect"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// ResourceID formats a spec field holding a REST API resource ID so it can be compared
+// with another CR's ID field or status.externalID. Pointers are dereferenced; nil and
+// zero values give "", as the ID isn't known yet.
+func ResourceID(v interface{}) string {
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Ptr {
+		if rv.IsNil() {
+			return ""
+		}
+		rv = rv.Elem()
+	}
+	if !rv.IsValid() || rv.IsZero() {
+		return ""
+	}
+	return fmt.Sprint(rv.Interface())
+}
+
+// HasOwnerReference reports whether obj already has an owner reference to the object
+// with the given UID.
+func HasOwnerReference(obj metav1.Object, uid types.UID) bool {
+	for _, ref := range obj.GetOwnerReferences() {
+		if ref.UID == uid {
+			return true
+		}
+	}
+	return false
+}
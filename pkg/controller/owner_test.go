@@ -0,0 +1,47 @@
+package controller
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestResourceID(t *testing.T) {
+	id := int64(42)
+	name := "order-1"
+	empty := ""
+	var nilID *int64
+	tests := []struct {
+		value interface{}
+		want  string
+	}{
+		{int64(42), "42"},
+		{int32(7), "7"},
+		{"order-1", "order-1"},
+		{&id, "42"},
+		{&name, "order-1"},
+		{int64(0), ""},
+		{"", ""},
+		{&empty, ""},
+		{nilID, ""},
+		{nil, ""},
+	}
+	for _, tt := range tests {
+		if got := ResourceID(tt.value); got != tt.want {
+			t.Errorf("ResourceID(%#v) = %q, want %q", tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestHasOwnerReference(t *testing.T) {
+	obj := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{
+		OwnerReferences: []metav1.OwnerReference{{Kind: "Order", Name: "order-1", UID: "uid-1"}},
+	}}
+	if !HasOwnerReference(obj, "uid-1") {
+		t.Error("expected an owner reference to uid-1")
+	}
+	if HasOwnerReference(obj, "uid-2") {
+		t.Error("expected no owner reference to uid-2")
+	}
+}
@@ -0,0 +1,124 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// PauseAllKey is the pause ConfigMap key that pauses every Kind at once.
+const PauseAllKey = "all"
+
+// Default PauseSwitch timings.
+const (
+	// DefaultPauseCacheTTL is how long the ConfigMap contents are reused between reads.
+	DefaultPauseCacheTTL = 10 * time.Second
+	// DefaultPauseRequeueInterval is how often a paused resource is requeued to
+	// notice the switch being lifted.
+	DefaultPauseRequeueInterval = 30 * time.Second
+)
+
+// PauseSwitch reads an operator-wide pause ConfigMap. Each data key is a Kind name
+// (or PauseAllKey) and a value parsed as true by strconv.ParseBool pauses reconciliation
+// of that Kind. A missing ConfigMap pauses nothing. A nil *PauseSwitch is valid and
+// never pauses, so controllers can call it unconditionally.
+type PauseSwitch struct {
+	// Reader fetches the ConfigMap. Use an uncached reader (mgr.GetAPIReader()) so the
+	// manager does not have to watch ConfigMaps.
+	Reader client.Reader
+	// Key identifies the ConfigMap.
+	Key types.NamespacedName
+	// CacheTTL is how long a read is reused. Zero uses DefaultPauseCacheTTL;
+	// a negative value reads the ConfigMap on every call.
+	CacheTTL time.Duration
+
+	mu      sync.Mutex
+	data    map[string]string
+	fetched time.Time
+}
+
+// NewPauseSwitch returns a PauseSwitch for ref, given as "namespace/name" or just
+// "name" in defaultNamespace. An empty ref disables the switch and returns nil.
+func NewPauseSwitch(reader client.Reader, ref, defaultNamespace string) (*PauseSwitch, error) {
+	key, err := ParsePauseConfigMapRef(ref, defaultNamespace)
+	if err != nil || key.Name == "" {
+		return nil, err
+	}
+	return &PauseSwitch{Reader: reader, Key: key}, nil
+}
+
+// ParsePauseConfigMapRef parses a "namespace/name" or "name" ConfigMap reference.
+// An empty ref returns an empty key.
+func ParsePauseConfigMapRef(ref, defaultNamespace string) (types.NamespacedName, error) {
+	ref = strings.TrimSpace(ref)
+	if ref == "" {
+		return types.NamespacedName{}, nil
+	}
+	namespace, name, found := strings.Cut(ref, "/")
+	if !found {
+		namespace, name = strings.TrimSpace(defaultNamespace), namespace
+	}
+	if name == "" || namespace == "" || strings.Contains(name, "/") {
+		return types.NamespacedName{}, fmt.Errorf("invalid pause ConfigMap reference %q: want namespace/name", ref)
+	}
+	return types.NamespacedName{Namespace: namespace, Name: name}, nil
+}
+
+// Paused reports whether reconciliation of kind is paused by the ConfigMap.
+func (s *PauseSwitch) Paused(ctx context.Context, kind string) (bool, error) {
+	if s == nil {
+		return false, nil
+	}
+	data, err := s.load(ctx)
+	if err != nil {
+		return false, err
+	}
+	return isTrueValue(data[kind]) || isTrueValue(data[PauseAllKey]), nil
+}
+
+// String returns the ConfigMap reference for logs and events.
+func (s *PauseSwitch) String() string {
+	if s == nil {
+		return ""
+	}
+	return s.Key.String()
+}
+
+// load returns the ConfigMap data, reusing the last read within CacheTTL
+func (s *PauseSwitch) load(ctx context.Context) (map[string]string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	ttl := s.CacheTTL
+	if ttl == 0 {
+		ttl = DefaultPauseCacheTTL
+	}
+	if !s.fetched.IsZero() && ttl > 0 && time.Since(s.fetched) < ttl {
+		return s.data, nil
+	}
+
+	cm := &corev1.ConfigMap{}
+	if err := s.Reader.Get(ctx, s.Key, cm); err != nil {
+		if !k8serrors.IsNotFound(err) {
+			return nil, fmt.Errorf("failed to read pause ConfigMap %s: %w", s.Key, err)
+		}
+		cm.Data = nil
+	}
+	s.data = cm.Data
+	s.fetched = time.Now()
+	return s.data, nil
+}
+
+// isTrueValue reports whether a ConfigMap value enables a pause
+func isTrueValue(v string) bool {
+	b, err := strconv.ParseBool(strings.TrimSpace(v))
+	return err == nil && b
+}
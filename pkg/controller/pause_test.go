@@ -0,0 +1,114 @@
+package controller
+
+import (
+	"context"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestParsePauseConfigMapRef(t *testing.T) {
+	tests := []struct {
+		ref       string
+		namespace string
+		want      string
+		wantErr   bool
+	}{
+		{ref: "", namespace: "ops", want: "/"},
+		{ref: "pause", namespace: "ops", want: "ops/pause"},
+		{ref: "infra/pause", namespace: "ops", want: "infra/pause"},
+		{ref: "pause", namespace: "", wantErr: true},
+		{ref: "infra/", namespace: "ops", wantErr: true},
+		{ref: "a/b/c", namespace: "ops", wantErr: true},
+	}
+	for _, tt := range tests {
+		key, err := ParsePauseConfigMapRef(tt.ref, tt.namespace)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("ParsePauseConfigMapRef(%q): expected an error", tt.ref)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("ParsePauseConfigMapRef(%q): %v", tt.ref, err)
+			continue
+		}
+		if key.String() != tt.want {
+			t.Errorf("ParsePauseConfigMapRef(%q) = %q, want %q", tt.ref, key.String(), tt.want)
+		}
+	}
+}
+
+func TestPauseSwitch_Paused(t *testing.T) {
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "pause", Namespace: "ops"},
+		Data:       map[string]string{"Pet": "true", "Order": "false"},
+	}
+	reader := fake.NewClientBuilder().WithObjects(cm).Build()
+
+	s, err := NewPauseSwitch(reader, "pause", "ops")
+	if err != nil {
+		t.Fatalf("NewPauseSwitch: %v", err)
+	}
+	s.CacheTTL = -1
+
+	for kind, want := range map[string]bool{"Pet": true, "Order": false, "User": false} {
+		got, err := s.Paused(context.Background(), kind)
+		if err != nil {
+			t.Fatalf("Paused(%s): %v", kind, err)
+		}
+		if got != want {
+			t.Errorf("Paused(%s) = %v, want %v", kind, got, want)
+		}
+	}
+
+	cm.Data = map[string]string{PauseAllKey: "True"}
+	if err := reader.Update(context.Background(), cm); err != nil {
+		t.Fatalf("update ConfigMap: %v", err)
+	}
+	if got, _ := s.Paused(context.Background(), "User"); !got {
+		t.Error("expected the all key to pause every Kind")
+	}
+}
+
+func TestPauseSwitch_MissingConfigMap(t *testing.T) {
+	s, err := NewPauseSwitch(fake.NewClientBuilder().Build(), "ops/pause", "")
+	if err != nil {
+		t.Fatalf("NewPauseSwitch: %v", err)
+	}
+	paused, err := s.Paused(context.Background(), "Pet")
+	if err != nil || paused {
+		t.Errorf("expected a missing ConfigMap to pause nothing, got paused=%v err=%v", paused, err)
+	}
+}
+
+func TestPauseSwitch_NilAndCache(t *testing.T) {
+	var disabled *PauseSwitch
+	if paused, err := disabled.Paused(context.Background(), "Pet"); paused || err != nil {
+		t.Errorf("expected a nil switch to never pause, got paused=%v err=%v", paused, err)
+	}
+	if s, err := NewPauseSwitch(nil, "", "ops"); s != nil || err != nil {
+		t.Errorf("expected an empty ref to disable the switch, got %v, %v", s, err)
+	}
+
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "pause", Namespace: "ops"},
+		Data:       map[string]string{"Pet": "true"},
+	}
+	reader := fake.NewClientBuilder().WithObjects(cm).Build()
+	s, _ := NewPauseSwitch(reader, "ops/pause", "")
+	if paused, _ := s.Paused(context.Background(), "Pet"); !paused {
+		t.Fatal("expected Pet to be paused")
+	}
+
+	// Within the TTL the cached read is reused
+	cm.Data = map[string]string{}
+	if err := reader.Update(context.Background(), cm); err != nil {
+		t.Fatalf("update ConfigMap: %v", err)
+	}
+	if paused, _ := s.Paused(context.Background(), "Pet"); !paused {
+		t.Error("expected the cached pause to hold within the TTL")
+	}
+}
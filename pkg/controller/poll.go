@@ -0,0 +1,38 @@
+package controller
+
+import (
+	"fmt"
+	"slices"
+	"time"
+)
+
+// PollCondition is a resource's x-k8s-poll-condition: a resource the REST API creates
+// asynchronously is ready once the response value at Path is one of Values. Until then
+// the controller keeps it Syncing and polls it every Interval.
+type PollCondition struct {
+	Path       []string
+	Values     []string
+	Interval   time.Duration
+	Expression string // e.g. status == "available", for status messages
+}
+
+// Check reports whether a REST API response body satisfies the condition, and the value
+// it found ("" when the field is missing). Values are compared as text, so "true" matches
+// a boolean and "3" a number.
+func (c PollCondition) Check(raw []byte) (bool, string) {
+	var value interface{}
+	if !ExtractJSONPath(raw, c.Path, &value) {
+		return false, ""
+	}
+	current := fmt.Sprint(value)
+	return slices.Contains(c.Values, current), current
+}
+
+// WaitingMessage describes a resource still waiting for the condition, given the value
+// Check found
+func (c PollCondition) WaitingMessage(current string) string {
+	if current == "" {
+		return fmt.Sprintf("Waiting for %s", c.Expression)
+	}
+	return fmt.Sprintf("Waiting for %s (currently %q)", c.Expression, current)
+}
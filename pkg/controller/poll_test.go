@@ -0,0 +1,45 @@
+package controller
+
+import "testing"
+
+func TestPollCondition_Check(t *testing.T) {
+	condition := PollCondition{
+		Path:       []string{"properties", "status"},
+		Values:     []string{"available", "active"},
+		Expression: `properties.status == "available" || properties.status == "active"`,
+	}
+
+	tests := []struct {
+		name        string
+		raw         string
+		wantMet     bool
+		wantCurrent string
+	}{
+		{name: "ready value", raw: `{"properties": {"status": "available"}}`, wantMet: true, wantCurrent: "available"},
+		{name: "another ready value", raw: `{"properties": {"status": "active"}}`, wantMet: true, wantCurrent: "active"},
+		{name: "not ready yet", raw: `{"properties": {"status": "creating"}}`, wantCurrent: "creating"},
+		{name: "missing field", raw: `{"properties": {}}`},
+		{name: "not JSON", raw: `accepted`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			met, current := condition.Check([]byte(tt.raw))
+			if met != tt.wantMet || current != tt.wantCurrent {
+				t.Errorf("Check() = %v, %q, want %v, %q", met, current, tt.wantMet, tt.wantCurrent)
+			}
+		})
+	}
+
+	ready := PollCondition{Path: []string{"ready"}, Values: []string{"true"}}
+	if met, _ := ready.Check([]byte(`{"ready": true}`)); !met {
+		t.Error("expected a boolean to match its text value")
+	}
+	replicas := PollCondition{Path: []string{"replicas"}, Values: []string{"3"}}
+	if met, _ := replicas.Check([]byte(`{"replicas": 3}`)); !met {
+		t.Error("expected a number to match its text value")
+	}
+
+	if got, want := condition.WaitingMessage("creating"), `Waiting for properties.status == "available" || properties.status == "active" (currently "creating")`; got != want {
+		t.Errorf("WaitingMessage() = %q, want %q", got, want)
+	}
+}
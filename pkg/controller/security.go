@@ -0,0 +1,90 @@
+package controller
+
+import (
+	"net/http"
+	"os"
+	"strings"
+)
+
+// SecurityScheme is an OpenAPI security scheme a REST API request is authenticated with.
+// Its credential is read from the manager's EnvVar environment variable.
+type SecurityScheme struct {
+	Type      string // apiKey, http, oauth2 or openIdConnect
+	In        string // apiKey only: header, query or cookie
+	ParamName string // apiKey only: the header, query parameter or cookie name
+	Scheme    string // http only: the Authorization scheme, e.g. "bearer" or "basic"
+	EnvVar    string
+}
+
+// SecurityRequirement is one OpenAPI security requirement object: all of its schemes are
+// applied to a request together. An empty requirement makes authentication optional.
+type SecurityRequirement []SecurityScheme
+
+// ApplySecurity authenticates req with the first of requirements whose schemes all have a
+// credential set, applying every scheme of it; requirements are alternatives. It returns
+// false, leaving req unauthenticated, when no requirement has all its credentials.
+func ApplySecurity(req *http.Request, requirements []SecurityRequirement) bool {
+	for _, requirement := range requirements {
+		if len(requirement) == 0 {
+			continue
+		}
+		credentials := make([]string, len(requirement))
+		for i, scheme := range requirement {
+			credentials[i] = os.Getenv(scheme.EnvVar)
+			if credentials[i] == "" || !supportedScheme(scheme) {
+				credentials = nil
+				break
+			}
+		}
+		if credentials == nil {
+			continue
+		}
+		for i, scheme := range requirement {
+			applyScheme(req, scheme, credentials[i])
+		}
+		return true
+	}
+	return false
+}
+
+// supportedScheme reports whether applyScheme can present a scheme's credential
+func supportedScheme(scheme SecurityScheme) bool {
+	switch scheme.Type {
+	case "apiKey":
+		return scheme.ParamName != "" && (scheme.In == "header" || scheme.In == "query" || scheme.In == "cookie")
+	case "http", "oauth2", "openIdConnect":
+		return true
+	}
+	return false
+}
+
+// applyScheme adds a credential to req the way scheme declares. Basic credentials are
+// "user:password"; OAuth2 and OpenID Connect credentials are access tokens sent as
+// bearer tokens.
+func applyScheme(req *http.Request, scheme SecurityScheme, credential string) {
+	switch scheme.Type {
+	case "apiKey":
+		switch scheme.In {
+		case "header":
+			req.Header.Set(scheme.ParamName, credential)
+		case "query":
+			query := req.URL.Query()
+			query.Set(scheme.ParamName, credential)
+			req.URL.RawQuery = query.Encode()
+		case "cookie":
+			req.AddCookie(&http.Cookie{Name: scheme.ParamName, Value: credential})
+		}
+	case "http":
+		switch strings.ToLower(scheme.Scheme) {
+		case "basic":
+			user, password, _ := strings.Cut(credential, ":")
+			req.SetBasicAuth(user, password)
+		case "bearer", "":
+			req.Header.Set("Authorization", "Bearer "+credential)
+		default:
+			req.Header.Set("Authorization", scheme.Scheme+" "+credential)
+		}
+	default:
+		req.Header.Set("Authorization", "Bearer "+credential)
+	}
+}
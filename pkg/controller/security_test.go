@@ -0,0 +1,96 @@
+package controller
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestApplySecurity(t *testing.T) {
+	apiKey := SecurityScheme{Type: "apiKey", In: "header", ParamName: "X-API-Key", EnvVar: "TEST_AUTH_API_KEY"}
+	bearer := SecurityScheme{Type: "http", Scheme: "bearer", EnvVar: "TEST_AUTH_BEARER"}
+	basic := SecurityScheme{Type: "http", Scheme: "basic", EnvVar: "TEST_AUTH_BASIC"}
+	queryKey := SecurityScheme{Type: "apiKey", In: "query", ParamName: "api_key", EnvVar: "TEST_AUTH_QUERY_KEY"}
+	cookieKey := SecurityScheme{Type: "apiKey", In: "cookie", ParamName: "session", EnvVar: "TEST_AUTH_COOKIE"}
+	oauth := SecurityScheme{Type: "oauth2", EnvVar: "TEST_AUTH_OAUTH"}
+	mtls := SecurityScheme{Type: "mutualTLS", EnvVar: "TEST_AUTH_MTLS"}
+
+	tests := []struct {
+		name         string
+		env          map[string]string
+		requirements []SecurityRequirement
+		wantApplied  bool
+		wantHeaders  map[string]string
+		wantQuery    string
+	}{
+		{
+			name:         "applies all schemes of a requirement",
+			env:          map[string]string{"TEST_AUTH_API_KEY": "key", "TEST_AUTH_BEARER": "token"},
+			requirements: []SecurityRequirement{{apiKey, bearer}},
+			wantApplied:  true,
+			wantHeaders:  map[string]string{"X-API-Key": "key", "Authorization": "Bearer token"},
+		},
+		{
+			name:         "skips a requirement missing a credential",
+			env:          map[string]string{"TEST_AUTH_API_KEY": "key", "TEST_AUTH_BASIC": "user:pass"},
+			requirements: []SecurityRequirement{{apiKey, bearer}, {basic}},
+			wantApplied:  true,
+			wantHeaders:  map[string]string{"X-API-Key": "", "Authorization": "Basic dXNlcjpwYXNz"},
+		},
+		{
+			name:         "leaves the request unauthenticated without credentials",
+			requirements: []SecurityRequirement{{apiKey, bearer}},
+			wantHeaders:  map[string]string{"X-API-Key": "", "Authorization": ""},
+		},
+		{
+			name:         "prefers credentials over an optional requirement",
+			env:          map[string]string{"TEST_AUTH_BEARER": "token"},
+			requirements: []SecurityRequirement{{}, {bearer}},
+			wantApplied:  true,
+			wantHeaders:  map[string]string{"Authorization": "Bearer token"},
+		},
+		{
+			name:         "adds query and cookie API keys",
+			env:          map[string]string{"TEST_AUTH_QUERY_KEY": "q", "TEST_AUTH_COOKIE": "c"},
+			requirements: []SecurityRequirement{{queryKey, cookieKey}},
+			wantApplied:  true,
+			wantHeaders:  map[string]string{"Cookie": "session=c"},
+			wantQuery:    "api_key=q&limit=10",
+		},
+		{
+			name:         "sends OAuth2 access tokens as bearer tokens",
+			env:          map[string]string{"TEST_AUTH_OAUTH": "access"},
+			requirements: []SecurityRequirement{{oauth}},
+			wantApplied:  true,
+			wantHeaders:  map[string]string{"Authorization": "Bearer access"},
+		},
+		{
+			name:         "skips unsupported schemes",
+			env:          map[string]string{"TEST_AUTH_MTLS": "cert"},
+			requirements: []SecurityRequirement{{mtls}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for name, value := range tt.env {
+				t.Setenv(name, value)
+			}
+			req, err := http.NewRequest(http.MethodGet, "http://example.com/pets?limit=10", nil)
+			if err != nil {
+				t.Fatalf("NewRequest failed: %v", err)
+			}
+
+			if got := ApplySecurity(req, tt.requirements); got != tt.wantApplied {
+				t.Errorf("ApplySecurity() = %v, want %v", got, tt.wantApplied)
+			}
+			for name, want := range tt.wantHeaders {
+				if got := req.Header.Get(name); got != want {
+					t.Errorf("%s = %q, want %q", name, got, want)
+				}
+			}
+			if tt.wantQuery != "" && req.URL.RawQuery != tt.wantQuery {
+				t.Errorf("query = %q, want %q", req.URL.RawQuery, tt.wantQuery)
+			}
+		})
+	}
+}
@@ -0,0 +1,79 @@
+package controller
+
+import (
+	"crypto/tls"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// Default HTTP transport settings for generated controllers.
+const (
+	DefaultMaxIdleConns    = 100
+	DefaultIdleConnTimeout = 90 * time.Second
+)
+
+// HTTPTransportConfig tunes the HTTP transport used by generated controllers
+// to call the REST API.
+type HTTPTransportConfig struct {
+	// MaxIdleConns limits idle (keep-alive) connections, both in total and per host.
+	// Zero uses DefaultMaxIdleConns.
+	MaxIdleConns int
+	// MaxConnsPerHost limits the total connections per host. Zero means no limit.
+	MaxConnsPerHost int
+	// IdleConnTimeout is how long an idle connection is kept open.
+	// Zero uses DefaultIdleConnTimeout.
+	IdleConnTimeout time.Duration
+	// DisableHTTP2 turns off HTTP/2, forcing HTTP/1.1 for all requests.
+	DisableHTTP2 bool
+}
+
+var (
+	sharedTransport     *http.Transport
+	sharedTransportOnce sync.Once
+)
+
+// NewHTTPTransport builds an HTTP transport from the given configuration.
+func NewHTTPTransport(cfg HTTPTransportConfig) *http.Transport {
+	maxIdleConns := cfg.MaxIdleConns
+	if maxIdleConns <= 0 {
+		maxIdleConns = DefaultMaxIdleConns
+	}
+	idleConnTimeout := cfg.IdleConnTimeout
+	if idleConnTimeout <= 0 {
+		idleConnTimeout = DefaultIdleConnTimeout
+	}
+
+	transport := &http.Transport{
+		Proxy: http.ProxyFromEnvironment,
+		DialContext: (&net.Dialer{
+			Timeout:   30 * time.Second,
+			KeepAlive: 30 * time.Second,
+		}).DialContext,
+		ForceAttemptHTTP2: !cfg.DisableHTTP2,
+		MaxIdleConns:      maxIdleConns,
+		// Operators typically talk to a handful of hosts, so allow every idle
+		// connection to be kept for the same host instead of Go's default of 2.
+		MaxIdleConnsPerHost:   maxIdleConns,
+		MaxConnsPerHost:       cfg.MaxConnsPerHost,
+		IdleConnTimeout:       idleConnTimeout,
+		TLSHandshakeTimeout:   10 * time.Second,
+		ExpectContinueTimeout: 1 * time.Second,
+	}
+	if cfg.DisableHTTP2 {
+		// A non-nil, empty TLSNextProto map disables HTTP/2 over TLS
+		transport.TLSNextProto = make(map[string]func(string, *tls.Conn) http.RoundTripper)
+	}
+	return transport
+}
+
+// SharedHTTPTransport returns a transport shared by all controllers in the process,
+// so that connections are pooled across CRD kinds. The configuration passed on the
+// first call wins; later calls return the same transport.
+func SharedHTTPTransport(cfg HTTPTransportConfig) *http.Transport {
+	sharedTransportOnce.Do(func() {
+		sharedTransport = NewHTTPTransport(cfg)
+	})
+	return sharedTransport
+}
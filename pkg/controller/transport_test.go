@@ -0,0 +1,69 @@
+package controller
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNewHTTPTransport_Defaults(t *testing.T) {
+	transport := NewHTTPTransport(HTTPTransportConfig{})
+
+	if transport.MaxIdleConns != DefaultMaxIdleConns {
+		t.Errorf("expected MaxIdleConns %d, got %d", DefaultMaxIdleConns, transport.MaxIdleConns)
+	}
+	if transport.MaxIdleConnsPerHost != DefaultMaxIdleConns {
+		t.Errorf("expected MaxIdleConnsPerHost %d, got %d", DefaultMaxIdleConns, transport.MaxIdleConnsPerHost)
+	}
+	if transport.MaxConnsPerHost != 0 {
+		t.Errorf("expected unlimited MaxConnsPerHost, got %d", transport.MaxConnsPerHost)
+	}
+	if transport.IdleConnTimeout != DefaultIdleConnTimeout {
+		t.Errorf("expected IdleConnTimeout %v, got %v", DefaultIdleConnTimeout, transport.IdleConnTimeout)
+	}
+	if !transport.ForceAttemptHTTP2 {
+		t.Error("expected HTTP/2 to be enabled by default")
+	}
+	if transport.TLSNextProto != nil {
+		t.Error("expected TLSNextProto to be nil when HTTP/2 is enabled")
+	}
+}
+
+func TestNewHTTPTransport_Custom(t *testing.T) {
+	transport := NewHTTPTransport(HTTPTransportConfig{
+		MaxIdleConns:    500,
+		MaxConnsPerHost: 50,
+		IdleConnTimeout: 30 * time.Second,
+		DisableHTTP2:    true,
+	})
+
+	if transport.MaxIdleConns != 500 {
+		t.Errorf("expected MaxIdleConns 500, got %d", transport.MaxIdleConns)
+	}
+	if transport.MaxIdleConnsPerHost != 500 {
+		t.Errorf("expected MaxIdleConnsPerHost 500, got %d", transport.MaxIdleConnsPerHost)
+	}
+	if transport.MaxConnsPerHost != 50 {
+		t.Errorf("expected MaxConnsPerHost 50, got %d", transport.MaxConnsPerHost)
+	}
+	if transport.IdleConnTimeout != 30*time.Second {
+		t.Errorf("expected IdleConnTimeout 30s, got %v", transport.IdleConnTimeout)
+	}
+	if transport.ForceAttemptHTTP2 {
+		t.Error("expected HTTP/2 to be disabled")
+	}
+	if transport.TLSNextProto == nil {
+		t.Error("expected empty TLSNextProto map to disable HTTP/2")
+	}
+}
+
+func TestSharedHTTPTransport(t *testing.T) {
+	first := SharedHTTPTransport(HTTPTransportConfig{MaxIdleConns: 10})
+	second := SharedHTTPTransport(HTTPTransportConfig{MaxIdleConns: 20})
+
+	if first != second {
+		t.Error("expected the same transport to be shared across calls")
+	}
+	if second.MaxIdleConns != 10 {
+		t.Errorf("expected first configuration to win, got MaxIdleConns %d", second.MaxIdleConns)
+	}
+}
@@ -4,6 +4,7 @@ package controller
 import (
 	"encoding/json"
 	"reflect"
+	"strings"
 	"time"
 )
 
@@ -161,6 +162,67 @@ func ToFloat64(v interface{}) (float64, bool) {
 	}
 }
 
+// RemoveFieldPaths deletes the given dot-separated JSON paths (e.g., "credentials.password")
+// from a map. Used to drop writeOnly fields from the spec before drift comparison, since the
+// API never returns them and they would otherwise be reported as drift on every reconcile.
+// Missing paths and non-object intermediate values are ignored.
+func RemoveFieldPaths(m map[string]interface{}, paths []string) {
+	for _, path := range paths {
+		parts := strings.Split(path, ".")
+		current := m
+		for i, part := range parts {
+			if i == len(parts)-1 {
+				delete(current, part)
+				break
+			}
+			next, ok := current[part].(map[string]interface{})
+			if !ok {
+				break
+			}
+			current = next
+		}
+	}
+}
+
+// RenameFields moves the values of a map's keys to new names, given as old name to new
+// name. Used to send spec fields renamed with x-k8s-spec-field under the name the REST API
+// expects. Missing keys are ignored; all values are read before any is written, so names
+// may be swapped.
+func RenameFields(m map[string]interface{}, names map[string]string) {
+	values := make(map[string]interface{}, len(names))
+	for from := range names {
+		if v, ok := m[from]; ok {
+			values[from] = v
+			delete(m, from)
+		}
+	}
+	for from, v := range values {
+		m[names[from]] = v
+	}
+}
+
+// ExtractJSONPath decodes the value at the given keys of a JSON object into target.
+// It returns false, leaving target untouched, if the body isn't a JSON object, a key is
+// missing or null, or the value doesn't decode into target.
+func ExtractJSONPath(raw []byte, path []string, target interface{}) bool {
+	value := json.RawMessage(raw)
+	for _, key := range path {
+		var object map[string]json.RawMessage
+		if err := json.Unmarshal(value, &object); err != nil {
+			return false
+		}
+		next, ok := object[key]
+		if !ok {
+			return false
+		}
+		value = next
+	}
+	if len(value) == 0 || string(value) == "null" {
+		return false
+	}
+	return json.Unmarshal(value, target) == nil
+}
+
 // GetExternalIDIfPresent extracts ExternalID from a resource status if the field exists.
 // Only CRUD resources have ExternalID; Query and Action CRDs do not.
 // This uses reflection to safely access the field without compile-time type dependencies.
@@ -386,3 +386,99 @@ func TestGetExternalIDIfPresent(t *testing.T) {
 		t.Errorf("GetExternalIDIfPresent(nil) = %q, expected empty string", id)
 	}
 }
+
+func TestRemoveFieldPaths(t *testing.T) {
+	spec := map[string]interface{}{
+		"name":     "svc",
+		"password": "s3cret",
+		"credentials": map[string]interface{}{
+			"user":   "admin",
+			"apiKey": "k3y",
+		},
+		"tags": []interface{}{"a"},
+	}
+	RemoveFieldPaths(spec, []string{"password", "credentials.apiKey", "tags.value", "missing.field"})
+
+	expected := map[string]interface{}{
+		"name":        "svc",
+		"credentials": map[string]interface{}{"user": "admin"},
+		"tags":        []interface{}{"a"},
+	}
+	if !ValuesEqual(spec, expected) {
+		t.Errorf("RemoveFieldPaths() = %v, want %v", spec, expected)
+	}
+}
+
+func TestRemoveFieldPaths_NoPerpetualDrift(t *testing.T) {
+	// The API accepts the password on create but never returns it from GET.
+	var apiResponse map[string]interface{}
+	if err := json.Unmarshal([]byte(`{"name":"svc","credentials":{"user":"admin"}}`), &apiResponse); err != nil {
+		t.Fatal(err)
+	}
+	newSpec := func() map[string]interface{} {
+		return map[string]interface{}{
+			"name":        "svc",
+			"credentials": map[string]interface{}{"user": "admin", "password": "s3cret"},
+		}
+	}
+	writeOnly := []string{"credentials.password"}
+
+	if ValuesEqual(newSpec(), apiResponse) {
+		t.Fatal("expected the unfiltered spec to differ from the API response")
+	}
+	// Every subsequent reconcile compares the same spec against the same response;
+	// with writeOnly fields removed none of them should see drift.
+	for i := 0; i < 3; i++ {
+		spec := newSpec()
+		RemoveFieldPaths(spec, writeOnly)
+		if !ValuesEqual(spec, apiResponse) {
+			t.Fatalf("reconcile %d: drift detected for writeOnly field, spec=%v response=%v", i, spec, apiResponse)
+		}
+	}
+}
+
+func TestRenameFields(t *testing.T) {
+	spec := map[string]interface{}{"petType": "dog", "name": "rex", "apiPaused": true}
+	RenameFields(spec, map[string]string{"petType": "type", "apiPaused": "paused", "missing": "gone"})
+
+	expected := map[string]interface{}{"type": "dog", "name": "rex", "paused": true}
+	if !ValuesEqual(spec, expected) {
+		t.Errorf("RenameFields() = %v, want %v", spec, expected)
+	}
+
+	swapped := map[string]interface{}{"a": 1.0, "b": 2.0}
+	RenameFields(swapped, map[string]string{"a": "b", "b": "a"})
+	if !ValuesEqual(swapped, map[string]interface{}{"a": 2.0, "b": 1.0}) {
+		t.Errorf("RenameFields() swap = %v, want a and b swapped", swapped)
+	}
+}
+
+func TestExtractJSONPath(t *testing.T) {
+	body := []byte(`{"id":"abc","properties":{"provisioningState":"Succeeded","replicas":3,"ready":true,"note":null}}`)
+
+	var state string
+	if !ExtractJSONPath(body, []string{"properties", "provisioningState"}, &state) || state != "Succeeded" {
+		t.Errorf("provisioningState = %q, want Succeeded", state)
+	}
+	var replicas int64
+	if !ExtractJSONPath(body, []string{"properties", "replicas"}, &replicas) || replicas != 3 {
+		t.Errorf("replicas = %d, want 3", replicas)
+	}
+	var ready bool
+	if !ExtractJSONPath(body, []string{"properties", "ready"}, &ready) || !ready {
+		t.Error("ready = false, want true")
+	}
+
+	unchanged := "previous"
+	for _, path := range [][]string{{"properties", "missing"}, {"properties", "note"}, {"id", "nested"}, {"properties", "replicas"}} {
+		if ExtractJSONPath(body, path, &unchanged) {
+			t.Errorf("ExtractJSONPath(%v) = true, want false", path)
+		}
+	}
+	if unchanged != "previous" {
+		t.Errorf("target = %q after failed extractions, want unchanged", unchanged)
+	}
+	if ExtractJSONPath([]byte("not json"), []string{"id"}, &state) {
+		t.Error("ExtractJSONPath(invalid JSON) = true, want false")
+	}
+}
@@ -0,0 +1,392 @@
+/*
+Copyright 2024 openapi-operator-gen authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+*/
+
+// Package doctor inspects a generated operator directory for common problems, such
+// as spec drift, an outdated generator, an untidy go.mod, orphaned controllers or
+// CRDs too large to apply.
+package doctor
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/bluecontainer/openapi-operator-gen/internal/config"
+	"github.com/bluecontainer/openapi-operator-gen/pkg/generator"
+	"github.com/bluecontainer/openapi-operator-gen/pkg/mapper"
+	"github.com/bluecontainer/openapi-operator-gen/pkg/parser"
+)
+
+// Severity orders findings; lower values are more urgent
+type Severity int
+
+const (
+	// SeverityError is a problem that breaks the build or the operator
+	SeverityError Severity = iota
+	// SeverityWarning is a problem that should be fixed before the next release
+	SeverityWarning
+	// SeverityInfo is worth knowing but needs no action
+	SeverityInfo
+)
+
+func (s Severity) String() string {
+	switch s {
+	case SeverityError:
+		return "error"
+	case SeverityWarning:
+		return "warning"
+	default:
+		return "info"
+	}
+}
+
+// Finding is a problem reported by one of the checks
+type Finding struct {
+	Severity Severity
+	// Check names the check that reported it (e.g., "spec", "go.mod")
+	Check   string
+	Message string
+	// Fix is the suggested action, if any
+	Fix string
+}
+
+// Report is the result of Run
+type Report struct {
+	Directory string
+	// Problems are sorted by severity, most urgent first
+	Problems []Finding
+	// Passed describes the checks that found nothing
+	Passed []string
+}
+
+// HasErrors reports whether any problem has SeverityError
+func (r *Report) HasErrors() bool {
+	for _, f := range r.Problems {
+		if f.Severity == SeverityError {
+			return true
+		}
+	}
+	return false
+}
+
+// String renders the report as a prioritized checklist
+func (r *Report) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Doctor report for %s\n\n", r.Directory)
+	if len(r.Problems) == 0 {
+		b.WriteString("No problems found.\n")
+	} else {
+		fmt.Fprintf(&b, "PROBLEMS (%d):\n", len(r.Problems))
+		for i, f := range r.Problems {
+			fmt.Fprintf(&b, "  %d. [%s] %s: %s\n", i+1, f.Severity, f.Check, f.Message)
+			if f.Fix != "" {
+				fmt.Fprintf(&b, "     Fix: %s\n", f.Fix)
+			}
+		}
+	}
+	if len(r.Passed) > 0 {
+		b.WriteString("\nPASSED:\n")
+		for _, p := range r.Passed {
+			fmt.Fprintf(&b, "  ✓ %s\n", p)
+		}
+	}
+	return b.String()
+}
+
+// Run checks a generated operator directory. version is the running generator's
+// version, compared against the one recorded at generation time. It fails only
+// when the directory has no saved .openapi-operator-gen.yaml.
+func Run(directory, version string) (*Report, error) {
+	file, err := config.LoadConfigFile(filepath.Join(directory, ".openapi-operator-gen.yaml"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to load config: %w", err)
+	}
+	if file == nil {
+		return nil, fmt.Errorf("no .openapi-operator-gen.yaml found in %s", directory)
+	}
+	cfg := config.ConfigFromFile(file)
+	cfg.OutputDir = directory
+	cfg.ControllerHashes = file.ControllerHashes
+
+	r := &Report{Directory: directory}
+	r.checkSpec(cfg)
+	r.checkGenerator(cfg, version)
+	r.checkCRDSizes(directory)
+	// Types-only output has no go.mod, main.go or controllers to check
+	if !cfg.TypesOnly {
+		r.checkGoMod(directory)
+		kinds, extraControllers := r.checkMain(cfg)
+		r.checkControllers(cfg, extraControllers, kinds != nil)
+	}
+
+	sort.SliceStable(r.Problems, func(i, j int) bool {
+		return r.Problems[i].Severity < r.Problems[j].Severity
+	})
+	return r, nil
+}
+
+func (r *Report) add(severity Severity, check, message, fix string) {
+	r.Problems = append(r.Problems, Finding{Severity: severity, Check: check, Message: message, Fix: fix})
+}
+
+// checkSpec compares the spec with the hash recorded at generation time
+func (r *Report) checkSpec(cfg *config.Config) {
+	if cfg.SpecHash == "" {
+		r.add(SeverityInfo, "spec", "no spec hash recorded, so drift can't be detected",
+			"regenerate to record it")
+		return
+	}
+	current, err := config.HashSpecFile(cfg.SpecPath)
+	if err != nil {
+		r.add(SeverityError, "spec", fmt.Sprintf("spec %s can't be read: %v", cfg.SpecPath, err),
+			"restore the spec, or regenerate with --spec pointing at its new location")
+		return
+	}
+	if current != cfg.SpecHash {
+		r.add(SeverityWarning, "spec", fmt.Sprintf("spec %s changed since the last generation", cfg.SpecPath),
+			"review the changes with the MCP diff tool, then regenerate")
+		return
+	}
+	r.Passed = append(r.Passed, "spec: unchanged since the last generation")
+}
+
+// checkGenerator compares the generator version with the one that generated the operator
+func (r *Report) checkGenerator(cfg *config.Config, version string) {
+	switch {
+	case cfg.GeneratorVersion == "":
+		r.add(SeverityInfo, "generator", "generator version unknown (generated before version tracking was added)",
+			"regenerate to record it")
+	case cfg.GeneratorVersion != version:
+		r.add(SeverityWarning, "generator", fmt.Sprintf("generated by %s, running %s", cfg.GeneratorVersion, version),
+			"regenerate to pick up template changes")
+	default:
+		r.Passed = append(r.Passed, fmt.Sprintf("generator: %s (current)", version))
+	}
+}
+
+// checkCRDSizes reports CRD manifests in config/crd/bases that are over, or close to,
+// the size limits of kubectl apply and the API server. It passes silently when there
+// are no manifests yet (controller-gen writes them on make manifests).
+func (r *Report) checkCRDSizes(directory string) {
+	matches, _ := filepath.Glob(filepath.Join(directory, "config", "crd", "bases", "*.yaml"))
+	sort.Strings(matches)
+	checked := 0
+	problems := len(r.Problems)
+	for _, match := range matches {
+		name := filepath.Base(match)
+		if name == "kustomization.yaml" {
+			continue
+		}
+		content, err := os.ReadFile(match)
+		if err != nil {
+			continue
+		}
+		checked++
+		problem, overLimit := generator.CRDSizeProblem(name, content)
+		switch {
+		case problem == "":
+		case overLimit:
+			r.add(SeverityError, "crd", problem, generator.CRDSizeFix)
+		default:
+			r.add(SeverityWarning, "crd", problem, generator.CRDSizeFix)
+		}
+	}
+	if checked > 0 && len(r.Problems) == problems {
+		r.Passed = append(r.Passed, fmt.Sprintf("crd: %d manifests within the kubectl apply size limit", checked))
+	}
+}
+
+// checkGoMod reports a go.mod that hasn't been tidied: go.sum is missing, or lacks
+// entries for some of the required modules
+func (r *Report) checkGoMod(directory string) {
+	const fix = "run: go mod tidy"
+	goMod, err := os.ReadFile(filepath.Join(directory, "go.mod"))
+	if err != nil {
+		r.add(SeverityError, "go.mod", "go.mod is missing", "regenerate the operator")
+		return
+	}
+	goSum, err := os.ReadFile(filepath.Join(directory, "go.sum"))
+	if err != nil {
+		r.add(SeverityError, "go.mod", "go.sum is missing, so the operator doesn't build", fix)
+		return
+	}
+
+	var missing []string
+	for _, req := range requiredModules(goMod) {
+		if !bytes.Contains(goSum, []byte(req+"/go.mod")) {
+			missing = append(missing, req)
+		}
+	}
+	if len(missing) > 0 {
+		r.add(SeverityWarning, "go.mod", fmt.Sprintf("go.sum has no entries for %s", strings.Join(missing, ", ")), fix)
+		return
+	}
+	r.Passed = append(r.Passed, "go.mod: tidy")
+}
+
+// requiredModules returns the "<module> <version>" of each requirement in go.mod,
+// skipping replaced modules since go.sum doesn't list local replacements
+func requiredModules(goMod []byte) []string {
+	var required []string
+	replaced := map[string]bool{}
+	block := ""
+	scanner := bufio.NewScanner(bytes.NewReader(goMod))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if i := strings.Index(line, "//"); i >= 0 {
+			line = strings.TrimSpace(line[:i])
+		}
+		fields := strings.Fields(line)
+		switch {
+		case len(fields) == 0:
+			continue
+		case len(fields) == 2 && fields[1] == "(":
+			block = fields[0]
+			continue
+		case fields[0] == ")":
+			block = ""
+			continue
+		}
+		directive := block
+		if directive == "" {
+			directive, fields = fields[0], fields[1:]
+		}
+		switch directive {
+		case "require":
+			if len(fields) >= 2 {
+				required = append(required, fields[0]+" "+fields[1])
+			}
+		case "replace":
+			if len(fields) > 0 {
+				replaced[fields[0]] = true
+			}
+		}
+	}
+
+	result := required[:0]
+	for _, req := range required {
+		if !replaced[strings.Fields(req)[0]] {
+			result = append(result, req)
+		}
+	}
+	return result
+}
+
+// checkMain re-maps the spec and checks that every CRD has its controller registered in
+// the manager's main.go. It returns the mapped kinds (nil if the spec can't be mapped)
+// and the aggregate/bundle controller files, which aren't recorded in ControllerHashes.
+func (r *Report) checkMain(cfg *config.Config) ([]string, map[string]bool) {
+	p := parser.NewParserWithFilter(cfg.RootKind, config.NewPathFilter(cfg))
+	p.SpecRootFile = cfg.SpecRootFile
+	p.SpecFormat = cfg.SpecFormat
+	p.ConstantPathParams = cfg.ConstantPathParams
+	p.SpecCacheDir = cfg.SpecCacheDir
+	p.LogWriter = io.Discard
+	spec, err := p.Parse(cfg.SpecPath)
+	if err != nil {
+		r.add(SeverityError, "main.go", fmt.Sprintf("spec can't be parsed, so CRD registration wasn't checked: %v", err),
+			"fix the spec, then regenerate")
+		return nil, nil
+	}
+	m := mapper.NewMapper(cfg)
+	crds, err := m.MapResources(spec)
+	if err != nil {
+		r.add(SeverityError, "main.go", fmt.Sprintf("spec can't be mapped, so CRD registration wasn't checked: %v", err),
+			"fix the spec, then regenerate")
+		return nil, nil
+	}
+
+	kinds := make([]string, 0, len(crds)+2)
+	for _, crd := range crds {
+		kinds = append(kinds, crd.Kind)
+	}
+	extra := map[string]bool{}
+	if cfg.GenerateAggregate {
+		kind := m.CreateAggregateDefinition(crds).Kind
+		kinds = append(kinds, kind)
+		extra[strings.ToLower(kind)+"_controller.go"] = true
+	}
+	if cfg.GenerateBundle {
+		kind := m.CreateBundleDefinition(crds).Kind
+		kinds = append(kinds, kind)
+		extra[strings.ToLower(kind)+"_controller.go"] = true
+	}
+
+	mainPath := filepath.FromSlash(cfg.MainPath())
+	content, err := os.ReadFile(filepath.Join(cfg.OutputDir, mainPath))
+	if err != nil {
+		r.add(SeverityError, "main.go", mainPath+" is missing", "regenerate the operator")
+		return kinds, extra
+	}
+	var unregistered []string
+	for _, kind := range kinds {
+		if !bytes.Contains(content, []byte("&controller."+kind+"Reconciler{")) {
+			unregistered = append(unregistered, kind)
+		}
+	}
+	if len(unregistered) > 0 {
+		r.add(SeverityError, "main.go", fmt.Sprintf("%s not registered in %s", strings.Join(unregistered, ", "), mainPath),
+			"regenerate, or add the missing reconcilers to the manager by hand")
+		return kinds, extra
+	}
+	r.Passed = append(r.Passed, fmt.Sprintf("main.go: all %d CRDs registered", len(kinds)))
+	return kinds, extra
+}
+
+// checkControllers compares internal/controller with the controllers recorded in
+// ControllerHashes: recorded ones that are missing or edited, and unrecorded ones
+// left over from CRDs that are no longer generated. Orphans are only reported when
+// the spec could be mapped, since aggregate/bundle controllers aren't recorded.
+func (r *Report) checkControllers(cfg *config.Config, extra map[string]bool, mapped bool) {
+	if len(cfg.ControllerHashes) == 0 {
+		r.add(SeverityInfo, "files", "no controller hashes recorded (generated by an older version)",
+			"regenerate to record them")
+		return
+	}
+
+	problems := len(r.Problems)
+	recorded := make([]string, 0, len(cfg.ControllerHashes))
+	for rel := range cfg.ControllerHashes {
+		recorded = append(recorded, rel)
+	}
+	sort.Strings(recorded)
+	for _, rel := range recorded {
+		content, err := os.ReadFile(filepath.Join(cfg.OutputDir, filepath.FromSlash(rel)))
+		if err != nil {
+			r.add(SeverityError, "files", rel+" is missing", "regenerate the operator")
+			continue
+		}
+		if config.HashSpecBytes(content) != cfg.ControllerHashes[rel] {
+			r.add(SeverityInfo, "files", rel+" was edited since generation",
+				"regenerate with --merge so your changes aren't overwritten")
+		}
+	}
+
+	if mapped {
+		controllerDir := filepath.Join("internal", "controller")
+		matches, _ := filepath.Glob(filepath.Join(cfg.OutputDir, controllerDir, "*_controller.go"))
+		sort.Strings(matches)
+		for _, match := range matches {
+			name := filepath.Base(match)
+			rel := filepath.ToSlash(filepath.Join(controllerDir, name))
+			if _, ok := cfg.ControllerHashes[rel]; ok || extra[name] {
+				continue
+			}
+			r.add(SeverityWarning, "files", rel+" is orphaned: the last generation didn't produce it",
+				fmt.Sprintf("delete it along with %s_*test.go if its CRD was removed", strings.TrimSuffix(rel, "_controller.go")))
+		}
+	}
+
+	if len(r.Problems) == problems {
+		r.Passed = append(r.Passed, fmt.Sprintf("files: %d controllers match the last generation", len(recorded)))
+	}
+}
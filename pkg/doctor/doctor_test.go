@@ -0,0 +1,259 @@
+/*
+Copyright 2024 openapi-operator-gen authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+*/
+
+package doctor
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/bluecontainer/openapi-operator-gen/internal/config"
+)
+
+const testSpec = `openapi: 3.0.3
+info: {title: Widgets, version: 1.0.0}
+paths:
+  /widgets:
+    post:
+      requestBody: {content: {application/json: {schema: {$ref: '#/components/schemas/Widget'}}}}
+      responses: {"200": {description: ok}}
+  /widgets/{id}:
+    parameters: [{name: id, in: path, required: true, schema: {type: string}}]
+    get:
+      responses: {"200": {description: ok, content: {application/json: {schema: {$ref: '#/components/schemas/Widget'}}}}}
+    delete:
+      responses: {"204": {description: gone}}
+components:
+  schemas:
+    Widget:
+      type: object
+      properties:
+        id: {type: string}
+        name: {type: string}
+`
+
+const testController = "package controller\n"
+
+// writeOperator lays out a minimal healthy operator directory and returns it
+func writeOperator(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	write := func(rel, content string) {
+		t.Helper()
+		path := filepath.Join(dir, filepath.FromSlash(rel))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	write("widgets.yaml", testSpec)
+	write("go.mod", "module example.com/widgets\n\ngo 1.25\n\nrequire (\n\tgithub.com/go-logr/logr v1.4.3\n\tsigs.k8s.io/controller-runtime v0.20.0 // indirect\n)\n\nreplace example.com/local => ../local\n")
+	write("go.sum", "github.com/go-logr/logr v1.4.3 h1:x=\ngithub.com/go-logr/logr v1.4.3/go.mod h1:y=\nsigs.k8s.io/controller-runtime v0.20.0/go.mod h1:z=\n")
+	write("cmd/manager/main.go", "\tif err = (&controller.WidgetReconciler{\n")
+	write("internal/controller/widget_controller.go", testController)
+
+	cfg := &config.Config{
+		SpecPath:         filepath.Join(dir, "widgets.yaml"),
+		APIGroup:         "widgets.example.com",
+		APIVersion:       "v1alpha1",
+		ModuleName:       "example.com/widgets",
+		MappingMode:      config.PerResource,
+		SpecHash:         config.HashSpecBytes([]byte(testSpec)),
+		GeneratorVersion: "v1.2.3",
+		ControllerHashes: map[string]string{
+			"internal/controller/widget_controller.go": config.HashSpecBytes([]byte(testController)),
+		},
+	}
+	data, err := config.MarshalConfigFile(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	write(".openapi-operator-gen.yaml", string(data))
+	return dir
+}
+
+func TestRun_Healthy(t *testing.T) {
+	dir := writeOperator(t)
+
+	report, err := Run(dir, "v1.2.3")
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	if len(report.Problems) != 0 {
+		t.Fatalf("expected no problems, got:\n%s", report)
+	}
+	if len(report.Passed) != 5 {
+		t.Errorf("expected 5 passed checks, got %d:\n%s", len(report.Passed), report)
+	}
+	if !strings.Contains(report.String(), "No problems found.") {
+		t.Errorf("report missing 'No problems found.':\n%s", report)
+	}
+}
+
+func TestRun_Problems(t *testing.T) {
+	dir := writeOperator(t)
+	must := func(err error) {
+		t.Helper()
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	must(os.WriteFile(filepath.Join(dir, "widgets.yaml"), []byte(testSpec+"# changed\n"), 0644))
+	must(os.WriteFile(filepath.Join(dir, "go.sum"), []byte("github.com/go-logr/logr v1.4.3/go.mod h1:y=\n"), 0644))
+	must(os.WriteFile(filepath.Join(dir, "cmd/manager/main.go"), []byte("package main\n"), 0644))
+	must(os.WriteFile(filepath.Join(dir, "internal/controller/widget_controller.go"), []byte(testController+"// edited\n"), 0644))
+	must(os.WriteFile(filepath.Join(dir, "internal/controller/gadget_controller.go"), []byte(testController), 0644))
+
+	report, err := Run(dir, "v2.0.0")
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	if !report.HasErrors() {
+		t.Error("expected HasErrors")
+	}
+
+	want := []struct {
+		severity Severity
+		check    string
+		message  string
+	}{
+		{SeverityError, "main.go", "Widget not registered"},
+		{SeverityWarning, "spec", "changed since the last generation"},
+		{SeverityWarning, "generator", "generated by v1.2.3, running v2.0.0"},
+		{SeverityWarning, "go.mod", "sigs.k8s.io/controller-runtime v0.20.0"},
+		{SeverityWarning, "files", "gadget_controller.go is orphaned"},
+		{SeverityInfo, "files", "widget_controller.go was edited"},
+	}
+	if len(report.Problems) != len(want) {
+		t.Fatalf("expected %d problems, got %d:\n%s", len(want), len(report.Problems), report)
+	}
+	for i, w := range want {
+		got := report.Problems[i]
+		if got.Severity != w.severity || got.Check != w.check || !strings.Contains(got.Message, w.message) {
+			t.Errorf("problem %d = [%s] %s: %s, want [%s] %s: ...%s...", i+1, got.Severity, got.Check, got.Message, w.severity, w.check, w.message)
+		}
+	}
+}
+
+func TestRun_CRDSizes(t *testing.T) {
+	dir := writeOperator(t)
+	bases := filepath.Join(dir, "config", "crd", "bases")
+	if err := os.MkdirAll(bases, 0755); err != nil {
+		t.Fatal(err)
+	}
+	crd := func(descriptionLen int) []byte {
+		return []byte("apiVersion: apiextensions.k8s.io/v1\nkind: CustomResourceDefinition\nspec:\n  description: " + strings.Repeat("x", descriptionLen) + "\n")
+	}
+	for name, content := range map[string][]byte{
+		"kustomization.yaml":               []byte("resources: []\n"),
+		"widgets.example.com_widgets.yaml": crd(100),
+		"widgets.example.com_gadgets.yaml": crd(230000),
+		"widgets.example.com_gizmos.yaml":  crd(300000),
+	} {
+		if err := os.WriteFile(filepath.Join(bases, name), content, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	report, err := Run(dir, "v1.2.3")
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	want := []struct {
+		severity Severity
+		message  string
+	}{
+		{SeverityError, "widgets.example.com_gizmos.yaml is"},
+		{SeverityWarning, "widgets.example.com_gadgets.yaml is"},
+	}
+	if len(report.Problems) != len(want) {
+		t.Fatalf("expected %d problems, got %d:\n%s", len(want), len(report.Problems), report)
+	}
+	for i, w := range want {
+		got := report.Problems[i]
+		if got.Severity != w.severity || got.Check != "crd" || !strings.HasPrefix(got.Message, w.message) || !strings.Contains(got.Fix, "maxDescLen") {
+			t.Errorf("problem %d = [%s] %s: %s, want [%s] crd: %s...", i+1, got.Severity, got.Check, got.Message, w.severity, w.message)
+		}
+	}
+
+	// Manifests within the limit pass
+	for _, name := range []string{"widgets.example.com_gadgets.yaml", "widgets.example.com_gizmos.yaml"} {
+		if err := os.Remove(filepath.Join(bases, name)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	report, err = Run(dir, "v1.2.3")
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	if len(report.Problems) != 0 || !strings.Contains(report.String(), "crd: 1 manifests within the kubectl apply size limit") {
+		t.Errorf("expected the CRD size check to pass, got:\n%s", report)
+	}
+}
+
+func TestRun_TypesOnly(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "widgets.yaml"), []byte(testSpec), 0644); err != nil {
+		t.Fatal(err)
+	}
+	cfg := &config.Config{
+		SpecPath:         filepath.Join(dir, "widgets.yaml"),
+		APIGroup:         "widgets.example.com",
+		APIVersion:       "v1alpha1",
+		MappingMode:      config.PerResource,
+		SpecHash:         config.HashSpecBytes([]byte(testSpec)),
+		GeneratorVersion: "v1.2.3",
+		TypesOnly:        true,
+	}
+	data, err := config.MarshalConfigFile(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, ".openapi-operator-gen.yaml"), data, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	report, err := Run(dir, "v1.2.3")
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	if len(report.Problems) != 0 {
+		t.Errorf("expected no problems without go.mod, main.go or controllers, got:\n%s", report)
+	}
+}
+
+func TestRun_NoConfig(t *testing.T) {
+	if _, err := Run(t.TempDir(), "v1.0.0"); err == nil || !strings.Contains(err.Error(), "no .openapi-operator-gen.yaml") {
+		t.Errorf("Run error = %v, want missing config", err)
+	}
+}
+
+func TestRequiredModules(t *testing.T) {
+	goMod := []byte(`module example.com/op
+
+go 1.25
+
+require github.com/a/b v1.0.0
+
+require (
+	github.com/c/d v0.2.0 // indirect
+	github.com/e/f v0.3.0
+)
+
+replace github.com/e/f => ../f
+`)
+	got := requiredModules(goMod)
+	want := []string{"github.com/a/b v1.0.0", "github.com/c/d v0.2.0"}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("requiredModules = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,140 @@
+/*
+Copyright 2024 openapi-operator-gen authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+*/
+
+package doctor
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/bluecontainer/openapi-operator-gen/internal/config"
+)
+
+// FileStatus is the ownership of a file in a generated operator directory
+type FileStatus string
+
+const (
+	// FileRegenerated was written by the last generation and is unchanged since;
+	// it is overwritten on regeneration
+	FileRegenerated FileStatus = "regenerated"
+	// FileModified was written by the last generation and edited by hand since
+	FileModified FileStatus = "modified"
+	// FileMissing was written by the last generation and deleted since
+	FileMissing FileStatus = "missing"
+	// FileOrphaned sits among per-CRD generated files but the last generation didn't
+	// write it, e.g. the controller of a CRD removed from the spec
+	FileOrphaned FileStatus = "orphaned"
+	// FileDerived is written by make generate (controller-gen), not by the generator
+	FileDerived FileStatus = "derived"
+	// FileUserOwned isn't touched by the generator
+	FileUserOwned FileStatus = "user-owned"
+)
+
+// FileEntry is a file of the inventory, with its path relative to the operator directory
+type FileEntry struct {
+	Path   string
+	Status FileStatus
+}
+
+// inventorySkipDirs aren't walked: VCS data and build output
+var inventorySkipDirs = map[string]bool{".git": true, "bin": true}
+
+// Inventory walks a generated operator directory and classifies every file against the
+// generation manifest (cfg.GeneratedFiles, plus cfg.ControllerHashes so controllers
+// kept in merge mode are still recognized). Hand edits are detected by comparing each
+// file's hash with the recorded one. Files listed in the manifest but no longer on disk
+// are reported as FileMissing. It fails when no manifest was recorded.
+func Inventory(cfg *config.Config) ([]FileEntry, error) {
+	if len(cfg.GeneratedFiles) == 0 {
+		return nil, fmt.Errorf("no generation manifest recorded in .openapi-operator-gen.yaml (generated by an older version); regenerate to record it")
+	}
+
+	manifest := make(map[string]string, len(cfg.GeneratedFiles)+len(cfg.ControllerHashes))
+	for rel, hash := range cfg.GeneratedFiles {
+		manifest[rel] = hash
+	}
+	for rel, hash := range cfg.ControllerHashes {
+		manifest[rel] = hash
+	}
+
+	orphanDirs := map[string]bool{
+		"api/" + cfg.APIVersion: true,
+		"internal/controller":   true,
+		"config/samples":        true,
+	}
+
+	var entries []FileEntry
+	seen := make(map[string]bool)
+	err := filepath.WalkDir(cfg.OutputDir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if p != cfg.OutputDir && inventorySkipDirs[d.Name()] {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		rel, err := filepath.Rel(cfg.OutputDir, p)
+		if err != nil {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+		if rel == ".openapi-operator-gen.yaml" {
+			entries = append(entries, FileEntry{Path: rel, Status: FileRegenerated})
+			return nil
+		}
+
+		seen[rel] = true
+		status, err := classifyFile(p, rel, manifest, orphanDirs)
+		if err != nil {
+			return err
+		}
+		entries = append(entries, FileEntry{Path: rel, Status: status})
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to walk %s: %w", cfg.OutputDir, err)
+	}
+
+	for rel := range manifest {
+		if !seen[rel] {
+			entries = append(entries, FileEntry{Path: rel, Status: FileMissing})
+		}
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Path < entries[j].Path })
+	return entries, nil
+}
+
+// classifyFile returns the status of a file found on disk
+func classifyFile(p, rel string, manifest map[string]string, orphanDirs map[string]bool) (FileStatus, error) {
+	if hash, ok := manifest[rel]; ok {
+		content, err := os.ReadFile(p)
+		if err != nil {
+			return "", err
+		}
+		if config.HashSpecBytes(content) != hash {
+			return FileModified, nil
+		}
+		return FileRegenerated, nil
+	}
+
+	dir, name := path.Split(rel)
+	dir = strings.TrimSuffix(dir, "/")
+	switch {
+	case strings.HasPrefix(name, "zz_generated."), dir == "config/crd/bases", rel == "config/rbac/role.yaml":
+		return FileDerived, nil
+	case orphanDirs[dir]:
+		return FileOrphaned, nil
+	}
+	return FileUserOwned, nil
+}
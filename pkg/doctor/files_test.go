@@ -0,0 +1,90 @@
+/*
+Copyright 2024 openapi-operator-gen authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+*/
+
+package doctor
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/bluecontainer/openapi-operator-gen/internal/config"
+)
+
+func TestInventory(t *testing.T) {
+	dir := t.TempDir()
+	write := func(rel, content string) {
+		t.Helper()
+		path := filepath.Join(dir, filepath.FromSlash(rel))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	write(".openapi-operator-gen.yaml", "apiGroup: widgets.example.com\n")
+	write("Makefile", "all:\n")
+	write("internal/controller/widget_controller.go", testController+"// edited\n")
+	write("internal/controller/gadget_controller.go", testController)
+	write("api/v1alpha1/zz_generated.deepcopy.go", testController)
+	write("config/manager/patch.yaml", "kind: Patch\n")
+	write("bin/manager", "binary")
+	write(".git/HEAD", "ref: refs/heads/main\n")
+
+	cfg := &config.Config{
+		OutputDir:  dir,
+		APIVersion: "v1alpha1",
+		GeneratedFiles: map[string]string{
+			"Makefile":   config.HashSpecBytes([]byte("all:\n")),
+			"Dockerfile": config.HashSpecBytes([]byte("FROM scratch\n")),
+		},
+		ControllerHashes: map[string]string{
+			"internal/controller/widget_controller.go": config.HashSpecBytes([]byte(testController)),
+		},
+	}
+
+	entries, err := Inventory(cfg)
+	if err != nil {
+		t.Fatalf("Inventory failed: %v", err)
+	}
+	got := make(map[string]FileStatus, len(entries))
+	for _, e := range entries {
+		got[e.Path] = e.Status
+	}
+	want := map[string]FileStatus{
+		".openapi-operator-gen.yaml": FileRegenerated,
+		"Makefile":                   FileRegenerated,
+		"Dockerfile":                 FileMissing,
+		"internal/controller/widget_controller.go": FileModified,
+		"internal/controller/gadget_controller.go": FileOrphaned,
+		"api/v1alpha1/zz_generated.deepcopy.go":    FileDerived,
+		"config/manager/patch.yaml":                FileUserOwned,
+	}
+	if len(got) != len(want) {
+		t.Errorf("Inventory() = %v, want %v", got, want)
+	}
+	for path, status := range want {
+		if got[path] != status {
+			t.Errorf("%s: status %q, want %q", path, got[path], status)
+		}
+	}
+	for i := 1; i < len(entries); i++ {
+		if entries[i-1].Path > entries[i].Path {
+			t.Errorf("expected entries sorted by path, got %s before %s", entries[i-1].Path, entries[i].Path)
+		}
+	}
+}
+
+func TestInventory_NoManifest(t *testing.T) {
+	_, err := Inventory(&config.Config{OutputDir: t.TempDir()})
+	if err == nil || !strings.Contains(err.Error(), "no generation manifest") {
+		t.Errorf("expected a missing manifest error, got %v", err)
+	}
+}
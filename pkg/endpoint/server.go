@@ -0,0 +1,73 @@
+/*
+Copyright 2024 openapi-operator-gen authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+*/
+
+package endpoint
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// Server is an entry of the OpenAPI spec's servers list, e.g. a production or
+// staging deployment of the REST API
+type Server struct {
+	// Name is the server's x-name extension, if the spec sets one
+	Name string
+	// Environment is the server's x-environment extension (e.g., prod), if the spec sets one
+	Environment string
+	// URL is the server URL with its variables set to their defaults
+	URL string
+	// Description is the server's description from the spec
+	Description string
+}
+
+// Label returns the server's name, or its environment, description or URL when it has
+// no name
+func (s Server) Label() string {
+	if s.Name != "" {
+		return s.Name
+	}
+	if s.Environment != "" {
+		return s.Environment
+	}
+	if s.Description != "" {
+		return s.Description
+	}
+	return s.URL
+}
+
+// SelectServer returns the server whose name, environment, description or URL equals
+// selector, compared case-insensitively. The selected server must have an absolute URL,
+// since it becomes the operator's base URL.
+func SelectServer(servers []Server, selector string) (Server, error) {
+	selector = strings.TrimSpace(selector)
+	for _, match := range []func(Server) string{
+		func(s Server) string { return s.Name },
+		func(s Server) string { return s.Environment },
+		func(s Server) string { return s.Description },
+		func(s Server) string { return s.URL },
+	} {
+		for _, s := range servers {
+			if v := match(s); v != "" && strings.EqualFold(v, selector) {
+				if u, err := url.Parse(s.URL); err != nil || u.Scheme == "" || u.Host == "" {
+					return Server{}, fmt.Errorf("server %q has a relative URL %q; set a base URL instead", selector, s.URL)
+				}
+				return s, nil
+			}
+		}
+	}
+
+	labels := make([]string, 0, len(servers))
+	for _, s := range servers {
+		labels = append(labels, fmt.Sprintf("%q", s.Label()))
+	}
+	if len(labels) == 0 {
+		return Server{}, fmt.Errorf("no server matches %q: the OpenAPI spec lists no servers", selector)
+	}
+	return Server{}, fmt.Errorf("no server matches %q; available: %s", selector, strings.Join(labels, ", "))
+}
@@ -0,0 +1,61 @@
+/*
+Copyright 2024 openapi-operator-gen authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+*/
+
+package endpoint
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSelectServer(t *testing.T) {
+	servers := []Server{
+		{Name: "prod", URL: "https://api.example.com/v1", Description: "Production"},
+		{URL: "https://staging.example.com/v1", Description: "Staging"},
+		{URL: "https://dev.example.com/v1", Environment: "dev"},
+		{URL: "/v1", Description: "Relative"},
+	}
+
+	tests := []struct {
+		name     string
+		selector string
+		wantURL  string
+		wantErr  string
+	}{
+		{name: "by name", selector: "prod", wantURL: "https://api.example.com/v1"},
+		{name: "by description, case-insensitive", selector: "staging", wantURL: "https://staging.example.com/v1"},
+		{name: "by URL", selector: "https://staging.example.com/v1", wantURL: "https://staging.example.com/v1"},
+		{name: "name before description", selector: "PROD", wantURL: "https://api.example.com/v1"},
+		{name: "by environment", selector: "DEV", wantURL: "https://dev.example.com/v1"},
+		{name: "relative URL", selector: "relative", wantErr: `relative URL "/v1"`},
+		{name: "no match", selector: "qa", wantErr: `available: "prod", "Staging", "dev", "Relative"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := SelectServer(servers, tt.selector)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("SelectServer(%q) error = %v, want containing %q", tt.selector, err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("SelectServer(%q) unexpected error: %v", tt.selector, err)
+			}
+			if got.URL != tt.wantURL {
+				t.Errorf("SelectServer(%q).URL = %q, want %q", tt.selector, got.URL, tt.wantURL)
+			}
+		})
+	}
+}
+
+func TestSelectServer_NoServers(t *testing.T) {
+	if _, err := SelectServer(nil, "prod"); err == nil || !strings.Contains(err.Error(), "lists no servers") {
+		t.Errorf("SelectServer(nil) error = %v, want 'lists no servers'", err)
+	}
+}
@@ -0,0 +1,332 @@
+package generator
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"text/template"
+
+	"github.com/bluecontainer/openapi-operator-gen/internal/config"
+	"github.com/bluecontainer/openapi-operator-gen/pkg/mapper"
+	"github.com/bluecontainer/openapi-operator-gen/pkg/templates"
+)
+
+// generatorModule is the module of the runtime packages the generated controllers import
+const generatorModule = "github.com/bluecontainer/openapi-operator-gen"
+
+var (
+	groupNameMarker  = regexp.MustCompile(`\+groupName=(\S+)`)
+	schemeRegistered = regexp.MustCompile(`SchemeBuilder\.Register\(&(\w+)\{\}`)
+)
+
+// AppendTarget is an existing generated operator that --append-to adds CRDs to
+type AppendTarget struct {
+	// Dir is the operator's root directory
+	Dir string
+	// ModulePath is the module path from the operator's go.mod
+	ModulePath string
+	// Kinds maps each API group the operator serves to its Kinds
+	Kinds map[string][]string
+	// Kubebuilder is true for an operator generated with --kubebuilder, whose entrypoint is
+	// cmd/main.go and whose resources are listed in a PROJECT file
+	Kubebuilder bool
+}
+
+// LoadAppendTarget reads the module path of the operator in dir, and the API groups and
+// Kinds registered by the packages under its api directory
+func LoadAppendTarget(dir string) (*AppendTarget, error) {
+	goMod, err := os.ReadFile(filepath.Join(dir, "go.mod"))
+	if err != nil {
+		return nil, fmt.Errorf("%s is not a generated operator: %w", dir, err)
+	}
+	target := &AppendTarget{Dir: dir, ModulePath: goModModule(goMod), Kinds: make(map[string][]string)}
+	if target.ModulePath == "" {
+		return nil, fmt.Errorf("%s/go.mod has no module directive", dir)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "PROJECT")); err == nil {
+		target.Kubebuilder = true
+	}
+	mainPath := (&config.Config{KubebuilderLayout: target.Kubebuilder}).MainPath()
+	if _, err := os.Stat(filepath.Join(dir, filepath.FromSlash(mainPath))); err != nil {
+		return nil, fmt.Errorf("%s is not a generated operator: %w", dir, err)
+	}
+
+	err = filepath.WalkDir(filepath.Join(dir, "api"), func(path string, d os.DirEntry, err error) error {
+		if err != nil || d.IsDir() || d.Name() != "groupversion_info.go" {
+			return err
+		}
+		info, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		m := groupNameMarker.FindSubmatch(info)
+		if m == nil {
+			return nil
+		}
+		group := string(m[1])
+		files, err := filepath.Glob(filepath.Join(filepath.Dir(path), "*.go"))
+		if err != nil {
+			return err
+		}
+		for _, file := range files {
+			content, err := os.ReadFile(file)
+			if err != nil {
+				return err
+			}
+			for _, kind := range schemeRegistered.FindAllSubmatch(content, -1) {
+				target.Kinds[group] = append(target.Kinds[group], string(kind[1]))
+			}
+		}
+		if _, ok := target.Kinds[group]; !ok {
+			target.Kinds[group] = nil
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the API packages of %s: %w", dir, err)
+	}
+	return target, nil
+}
+
+// CheckCollisions rejects CRDs that the operator can't take alongside its own: an API group
+// it already serves, an API package directory that already exists, or Kinds it already has
+// (their controllers would be redeclared in internal/controller)
+func (t *AppendTarget) CheckCollisions(cfg *config.Config, crds []*mapper.CRDDefinition) error {
+	if kinds, ok := t.Kinds[cfg.APIGroup]; ok {
+		return fmt.Errorf("API group %s is already served by the operator in %s (kinds: %s); use a different --group",
+			cfg.APIGroup, t.Dir, strings.Join(kinds, ", "))
+	}
+	apiDir := filepath.Join(t.Dir, filepath.FromSlash(cfg.APIDir()))
+	if _, err := os.Stat(apiDir); err == nil {
+		return fmt.Errorf("%s already exists; use a --group whose first label differs from the operator's other groups", apiDir)
+	}
+
+	existing := make(map[string]string)
+	for group, kinds := range t.Kinds {
+		for _, kind := range kinds {
+			existing[kind] = group
+		}
+	}
+	var collisions []string
+	for _, crd := range crds {
+		if group, ok := existing[crd.Kind]; ok {
+			collisions = append(collisions, fmt.Sprintf("%s (%s)", crd.Kind, group))
+		}
+	}
+	if len(collisions) > 0 {
+		sort.Strings(collisions)
+		return fmt.Errorf("kinds already defined by the operator in %s: %s; exclude them with the path, tag or operation filters",
+			t.Dir, strings.Join(collisions, ", "))
+	}
+	return nil
+}
+
+// GenerateAppend writes the controllers of crds into the existing operator in the output
+// directory and merges their registration into its main.go and go.mod, and its PROJECT file
+// in the kubebuilder layout. Unlike Generate, it leaves the rest of the operator scaffold
+// untouched.
+func (g *ControllerGenerator) GenerateAppend(crds []*mapper.CRDDefinition) error {
+	controllerDir := filepath.Join(g.config.OutputDir, "internal", "controller")
+	if err := g.files.MkdirAll(controllerDir, 0755); err != nil {
+		return fmt.Errorf("failed to create controller directory: %w", err)
+	}
+	if err := g.checkControllerFileNames(crds, nil, nil); err != nil {
+		return err
+	}
+	g.config.ControllerHashes = make(map[string]string)
+
+	// The envtest integration tests are skipped: the operator's suite_test.go only
+	// registers its own API packages
+	for _, crd := range crds {
+		if err := g.generateController(controllerDir, crd); err != nil {
+			return fmt.Errorf("failed to generate controller for %s: %w", crd.Kind, err)
+		}
+		if err := g.generateControllerTest(controllerDir, crd); err != nil {
+			return fmt.Errorf("failed to generate controller test for %s: %w", crd.Kind, err)
+		}
+	}
+
+	// Operators generated before the condition reasons were shared don't have conditions.go yet
+	if _, err := os.Stat(filepath.Join(controllerDir, "conditions.go")); os.IsNotExist(err) {
+		if err := g.generateConditions(controllerDir); err != nil {
+			return fmt.Errorf("failed to generate conditions.go: %w", err)
+		}
+	}
+
+	if err := g.appendToMain(crds); err != nil {
+		return fmt.Errorf("failed to update main.go: %w", err)
+	}
+	if !g.config.SkipGoMod {
+		if err := g.appendToGoMod(); err != nil {
+			return fmt.Errorf("failed to update go.mod: %w", err)
+		}
+	}
+	if g.config.KubebuilderLayout {
+		if err := g.appendToProject(crds); err != nil {
+			return fmt.Errorf("failed to update PROJECT: %w", err)
+		}
+	}
+	return nil
+}
+
+// appendToMain adds the API package, scheme registration, label filters and controller
+// setup of crds to the operator's main.go
+func (g *ControllerGenerator) appendToMain(crds []*mapper.CRDDefinition) error {
+	mainPath := filepath.Join(g.config.OutputDir, filepath.FromSlash(g.config.MainPath()))
+	content, err := os.ReadFile(mainPath)
+	if err != nil {
+		return err
+	}
+	src := string(content)
+	if g.config.PauseConfigMapRef != "" && !strings.Contains(src, "pauseSwitch, err") {
+		return fmt.Errorf("--pause-configmap needs an operator generated with --pause-configmap")
+	}
+
+	alias := config.GroupDirName(g.config.APIGroup) + g.config.APIVersion
+	data := MainTemplateData{
+		AppName:           strings.Split(g.config.APIGroup, ".")[0],
+		PauseConfigMapRef: g.config.PauseConfigMapRef,
+	}
+	var labelFilters strings.Builder
+	for _, crd := range crds {
+		mainData := CRDMainData{Kind: crd.Kind, IsQuery: crd.IsQuery, IsAction: crd.IsAction, ControllerFile: g.controllerFileName(crd)}
+		if g.config.ControllerFileNaming == config.ControllerFileNamingOperationID {
+			mainData.OperationID = primaryOperationID(crd)
+		}
+		data.CRDs = append(data.CRDs, mainData)
+		fmt.Fprintf(&labelFilters, "\t\t\t\t&%s.%s{}: {\n\t\t\t\t\tLabel: labelSelector,\n\t\t\t\t},\n", alias, crd.Kind)
+	}
+
+	tmpl, err := template.New("main").Parse(templates.MainTemplate)
+	if err != nil {
+		return fmt.Errorf("failed to parse template: %w", err)
+	}
+	var setup bytes.Buffer
+	if err := tmpl.ExecuteTemplate(&setup, "controllerSetup", data); err != nil {
+		return fmt.Errorf("failed to execute template: %w", err)
+	}
+
+	controllerImport := fmt.Sprintf("\t%q\n", g.config.ResolvedImportPrefix()+"/internal/controller")
+	initEnd := "\tutilruntime.Must(clientgoscheme.AddToScheme(scheme))\n"
+	edits := []struct {
+		anchor string
+		text   string
+		after  bool
+	}{
+		{controllerImport, fmt.Sprintf("\t%s %q\n", alias, g.config.APIImportPath()), false},
+		{initEnd, fmt.Sprintf("\tutilruntime.Must(%s.AddToScheme(scheme))\n", alias), true},
+		{"cacheOpts.ByObject = map[client.Object]cache.ByObject{\n", labelFilters.String(), true},
+		{"\tif err := mgr.AddHealthzCheck(", strings.TrimPrefix(setup.String(), "\n") + "\n", false},
+	}
+	for _, edit := range edits {
+		i := strings.Index(src, edit.anchor)
+		if i < 0 {
+			return fmt.Errorf("%s has no %q; was it generated by openapi-operator-gen?", mainPath, strings.TrimSpace(edit.anchor))
+		}
+		if edit.after {
+			i += len(edit.anchor)
+		}
+		src = src[:i] + edit.text + src[i:]
+	}
+	return g.files.WriteFile(mainPath, []byte(src), 0644)
+}
+
+// appendToGoMod adds the requirements of a generated go.mod that the operator's go.mod
+// lacks, and moves the generator runtime module to the version the new controllers were
+// generated against
+func (g *ControllerGenerator) appendToGoMod() error {
+	modPath := filepath.Join(g.config.OutputDir, "go.mod")
+	content, err := os.ReadFile(modPath)
+	if err != nil {
+		return err
+	}
+	generated, err := g.renderGoMod(false, false)
+	if err != nil {
+		return err
+	}
+
+	lines := strings.SplitAfter(string(content), "\n")
+	have := goModRequires(lines)
+	var missing []string
+	for _, req := range goModRequireLines(strings.SplitAfter(string(generated), "\n")) {
+		fields := strings.Fields(req)
+		i, ok := have[fields[0]]
+		switch {
+		case !ok:
+			missing = append(missing, req)
+		case fields[0] == generatorModule:
+			old := strings.Fields(lines[i])
+			if len(old) >= 2 && old[len(old)-2] != fields[1] {
+				lines[i] = strings.Replace(lines[i], old[len(old)-2]+" "+old[len(old)-1], fields[0]+" "+fields[1], 1)
+			}
+		}
+	}
+	if len(missing) > 0 {
+		block := -1
+		for i, line := range lines {
+			if strings.TrimSpace(line) == "require (" {
+				block = i
+				break
+			}
+		}
+		if block < 0 {
+			lines = append(lines, "\nrequire (\n", ")\n")
+			block = len(lines) - 2
+		}
+		end := block + 1
+		for end < len(lines) && strings.TrimSpace(lines[end]) != ")" {
+			end++
+		}
+		lines = append(lines[:end], append(missing, lines[end:]...)...)
+	}
+	return g.files.WriteFile(modPath, []byte(strings.Join(lines, "")), 0644)
+}
+
+// goModModule returns the module path declared in a go.mod
+func goModModule(goMod []byte) string {
+	for _, line := range strings.Split(string(goMod), "\n") {
+		if fields := strings.Fields(line); len(fields) == 2 && fields[0] == "module" {
+			return strings.Trim(fields[1], `"`)
+		}
+	}
+	return ""
+}
+
+// goModRequires maps each module required by a go.mod to the index of its line
+func goModRequires(lines []string) map[string]int {
+	requires := make(map[string]int)
+	inBlock := false
+	for i, line := range lines {
+		fields := strings.Fields(line)
+		switch {
+		case len(fields) == 0 || strings.HasPrefix(fields[0], "//"):
+		case inBlock && fields[0] == ")":
+			inBlock = false
+		case inBlock:
+			requires[fields[0]] = i
+		case fields[0] == "require" && len(fields) > 1 && fields[1] == "(":
+			inBlock = true
+		case fields[0] == "require" && len(fields) > 2:
+			requires[fields[1]] = i
+		}
+	}
+	return requires
+}
+
+// goModRequireLines returns the requirement lines of a go.mod's require blocks
+func goModRequireLines(lines []string) []string {
+	var indexes []int
+	for _, i := range goModRequires(lines) {
+		indexes = append(indexes, i)
+	}
+	sort.Ints(indexes)
+	requires := make([]string, 0, len(indexes))
+	for _, i := range indexes {
+		requires = append(requires, lines[i])
+	}
+	return requires
+}
@@ -2,20 +2,26 @@ package generator
 
 import (
 	"bytes"
+	"encoding/base64"
+	"encoding/json"
 	"fmt"
-	"io"
-	"net/http"
+	"math"
 	"net/url"
 	"os"
 	"path"
 	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
 	"strings"
 	"text/template"
 	"time"
 
 	"github.com/bluecontainer/openapi-operator-gen/internal/config"
 	"github.com/bluecontainer/openapi-operator-gen/pkg/aggregate"
+	"github.com/bluecontainer/openapi-operator-gen/pkg/endpoint"
 	"github.com/bluecontainer/openapi-operator-gen/pkg/mapper"
+	"github.com/bluecontainer/openapi-operator-gen/pkg/parser"
 	"github.com/bluecontainer/openapi-operator-gen/pkg/templates"
 	"github.com/iancoleman/strcase"
 )
@@ -26,14 +32,102 @@ func pluralize(kind string) string {
 	return aggregate.KindToResourceName(kind)
 }
 
+// pluralizeWith returns a pluralize template function that prefers the explicit plurals
+// in names (mapper.CustomPlurals) over the heuristic
+func pluralizeWith(names map[string]string) func(string) string {
+	return func(kind string) string {
+		if plural, ok := names[kind]; ok {
+			return plural
+		}
+		return pluralize(kind)
+	}
+}
+
+// primaryOperationIDOrder ranks CRD actions when choosing the operationId that identifies a CRD
+var primaryOperationIDOrder = []string{"Get", "Query", "Execute", "Create", "Update", "Delete"}
+
+// primaryOperationID returns the operationId that best identifies a CRD: the GET operation
+// for resources, otherwise the first operation with an operationId. Returns "" if none is set.
+func primaryOperationID(crd *mapper.CRDDefinition) string {
+	for _, action := range primaryOperationIDOrder {
+		for _, op := range crd.Operations {
+			if op.CRDAction == action && op.OperationID != "" {
+				return op.OperationID
+			}
+		}
+	}
+	for _, op := range crd.Operations {
+		if op.OperationID != "" {
+			return op.OperationID
+		}
+	}
+	return ""
+}
+
 // ControllerGenerator generates controller reconciliation logic
 type ControllerGenerator struct {
 	config *config.Config
+	files  FileSink
+
+	// previousHashes are the controller hashes saved by the last generation (merge mode only)
+	previousHashes map[string]string
+	// pendingMerges lists the .new files written next to hand-edited controllers
+	pendingMerges []string
+	// embeddedSpec is the spec copy embedded in the operator binary (--embed-spec only)
+	embeddedSpec *EmbeddedSpec
 }
 
 // NewControllerGenerator creates a new controller generator
 func NewControllerGenerator(cfg *config.Config) *ControllerGenerator {
-	return &ControllerGenerator{config: cfg}
+	return &ControllerGenerator{config: cfg, files: newFileSink(cfg)}
+}
+
+// controllerFileUnsafe matches the runs of characters an operationId can't carry into a
+// controller file name, e.g. the dots, slashes or spaces some specs use
+var controllerFileUnsafe = regexp.MustCompile(`[^a-z0-9]+`)
+
+// controllerFileName returns the file name of a CRD's controller according to
+// config.ControllerFileNaming. Operation-id naming reduces the operationId to
+// [a-z0-9_] and falls back to the Kind when the CRD has no operationId or nothing
+// is left of it.
+func (g *ControllerGenerator) controllerFileName(crd *mapper.CRDDefinition) string {
+	base := strings.ToLower(crd.Kind)
+	if g.config.ControllerFileNaming == config.ControllerFileNamingOperationID {
+		opID := strings.ToLower(strcase.ToSnake(primaryOperationID(crd)))
+		if name := strings.Trim(controllerFileUnsafe.ReplaceAllString(opID, "_"), "_"); name != "" {
+			base = name
+		}
+	}
+	return base + "_controller.go"
+}
+
+// checkControllerFileNames fails when two controllers would be written to the same file,
+// e.g. operationIds that only differ in case or punctuation under operation-id naming
+func (g *ControllerGenerator) checkControllerFileNames(crds []*mapper.CRDDefinition, aggregate *mapper.AggregateDefinition, bundle *mapper.BundleDefinition) error {
+	owners := make(map[string]string)
+	claim := func(name, kind string) error {
+		if other, ok := owners[name]; ok {
+			return fmt.Errorf("the controllers of %s and %s would both be written to %s: rename an operationId or use --controller-file-naming kind", other, kind, name)
+		}
+		owners[name] = kind
+		return nil
+	}
+	for _, crd := range crds {
+		if err := claim(g.controllerFileName(crd), crd.Kind); err != nil {
+			return err
+		}
+	}
+	if aggregate != nil {
+		if err := claim(strings.ToLower(aggregate.Kind)+"_controller.go", aggregate.Kind); err != nil {
+			return err
+		}
+	}
+	if bundle != nil {
+		if err := claim(strings.ToLower(bundle.Kind)+"_controller.go", bundle.Kind); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 // ControllerTemplateData holds data for controller template
@@ -41,8 +135,10 @@ type ControllerTemplateData struct {
 	Year               int
 	GeneratorVersion   string
 	APIGroup           string
+	FinalizerName      string // Finalizer added by the controller (e.g., myapp.example.com/finalizer)
 	APIVersion         string
 	ModuleName         string
+	APIPackage         string // Import path of the API types package (e.g., <module>/api/v1alpha1)
 	Kind               string
 	KindLower          string
 	Plural             string
@@ -59,6 +155,7 @@ type ControllerTemplateData struct {
 	UsesSharedType     bool                     // True if ResultItemType is a shared type from another CRD
 	IsPrimitiveArray   bool                     // True if response is a primitive array ([]string, []int, etc.)
 	PrimitiveArrayType string                   // Base type for primitive arrays (e.g., "string", "int64")
+	MaxQueryResults    int                      // Cap on results stored in status (--status-result-limit); 0 is unlimited
 
 	// Action endpoint fields
 	IsAction          bool                     // True if this is an action CRD
@@ -68,6 +165,7 @@ type ControllerTemplateData struct {
 	ParentIDParam     string                   // Parent ID parameter name (e.g., "petId")
 	ParentIDField     string                   // Go field name for parent ID (e.g., "PetId")
 	ParentIDGoType    string                   // Go type for parent ID (e.g., "int64", "string")
+	ParentIDStyle     string                   // Label or matrix style of the parent ID path param ("" for simple)
 	HasParentID       bool                     // True if the action has a parent ID parameter
 	ActionName        string                   // Action name (e.g., "uploadImage")
 	PathParams        []ActionPathParam        // Path parameters other than parent ID
@@ -75,6 +173,10 @@ type ControllerTemplateData struct {
 	HasRequestBody    bool                     // True if there are request body fields
 	HasBinaryBody     bool                     // True if the action accepts binary data uploads
 	BinaryContentType string                   // Content type for binary data
+	WatchDataFrom     bool                     // Watch the spec.dataFrom ConfigMap/Secret and re-execute on change
+	IsMultipart       bool                     // True if the request body is sent as multipart/form-data
+	FormFields        []ActionFormField        // Text parts of a multipart body
+	FileField         string                   // Multipart part carrying the binary upload (e.g., "file")
 
 	// Resource endpoint fields (for standard CRUD resources)
 	ResourcePathParams  []ActionPathParam    // Path parameters for resource endpoints
@@ -91,6 +193,31 @@ type ControllerTemplateData struct {
 	// This is set when --update-with-post flag is used AND HasPut is false AND HasPost is true.
 	UpdateWithPost bool
 
+	// UseETag sends the stored ETag as If-Match on updates (--use-etag and GET declares ETag)
+	UseETag bool
+
+	// StatusFields are response fields copied into the status (x-k8s-status-field)
+	StatusFields []mapper.StatusField
+	// PollCondition polls an asynchronously created resource until it is ready (x-k8s-poll-condition)
+	PollCondition *mapper.PollCondition
+
+	// PauseSwitch checks the operator-wide pause ConfigMap before reconciling (--pause-configmap)
+	PauseSwitch bool
+
+	// DeleteTimeout is how long a failed DELETE is retried before the CR is marked
+	// DeletionBlocked (Go duration expression; empty removes the finalizer after one try)
+	DeleteTimeout string
+	// OrphanOnDeleteTimeout removes the finalizer once DeleteTimeout has passed
+	OrphanOnDeleteTimeout bool
+
+	// StatusSubresource writes status through the status subresource; without it
+	// (--no-status-subresource) the controller updates the whole object
+	StatusSubresource bool
+
+	// GenerationPredicate limits resource reconciles to spec (generation) and annotation
+	// changes, ignoring the controller's own status writes (off with --no-generation-predicate)
+	GenerationPredicate bool
+
 	// Per-method paths (when different methods use different paths)
 	GetPath    string // Path for GET operations (e.g., /pet/{petId})
 	PutPath    string // Path for PUT operations (e.g., /pet - when ID is in body)
@@ -102,12 +229,69 @@ type ControllerTemplateData struct {
 	// ExternalIDRef handling
 	NeedsExternalIDRef bool // True if externalIDRef field is needed (no path params to identify resource)
 
+	// ReadOnly generates an observe-only controller: GET and mirror into status, no writes or finalizer
+	ReadOnly bool
+
+	// Owner is the Kind whose CR the controller sets as the owner of its CRs; nil for none
+	Owner *OwnerData
+
+	// HasExtraHeaders sends the CR's spec.extraHeaders with each REST API request
+	HasExtraHeaders bool
+
+	// SupportDryRun reports REST API writes skipped by --dry-run-external as a DryRun condition
+	SupportDryRun bool
+
+	// FormEncoded sends request bodies as application/x-www-form-urlencoded instead of JSON
+	FormEncoded bool
+
+	// IdempotencyHeader is the header carrying an idempotency key on create and action requests ("" to disable)
+	IdempotencyHeader string
+
+	// SuccessCodes are the response codes treated as success per HTTP method
+	// (x-k8s-success-codes or --success-codes); methods without an entry accept any 2xx
+	SuccessCodes map[string][]int
+
+	// Accept is the Accept header sent per HTTP method, from --accept-header or the media
+	// types of the operation's responses; methods without an entry send application/json
+	Accept map[string]string
+
+	// Conditions are the condition types set by updateStatus, from --emit-conditions
+	Conditions ConditionTypeNames
+
+	// ContentType is the Content-Type of request bodies per HTTP method, from the media type
+	// of the operation's request body; methods without an entry send application/json, or a
+	// merge patch for PATCH
+	ContentType map[string]string
+
+	// Security holds the security requirements of each HTTP method, from the first
+	// operation with the method; methods without an entry send no credentials
+	Security map[string][]mapper.SecurityRequirement
+
+	// WriteOnlyFields are dot-separated JSON paths of writeOnly spec fields, excluded from drift detection
+	WriteOnlyFields []string
+	// SpecFieldRenames maps spec fields renamed with x-k8s-spec-field to the request
+	// body properties they are sent as
+	SpecFieldRenames map[string]string
+
 	// Test helper fields
-	HasInt64PathParams bool // True if any path parameter (PathParams, QueryPathParams, ResourcePathParams) is int64
+	HasIntPathParams bool // True if any path parameter (PathParams, QueryPathParams, ResourcePathParams) is an int64 or int32
 
 	// Integration test fields
 	RequiredFields    []RequiredFieldInfo // Required fields that need sample values in tests
 	HasRequiredFields bool                // True if there are required fields
+	SpecExample       string              // Go literal of the spec's request body example (JSON), overlaid on the test CRs' spec
+}
+
+// OwnerData holds the owner reference a resource controller sets on its CRs: the CR of
+// the owner Kind whose REST API ID, in its ID field or status.externalID, is in IDField
+type OwnerData struct {
+	Kind   string
+	Plural string
+	// IDField is the Go name of the spec field holding the owner's ID (e.g., "OrderId")
+	IDField string
+	// OwnerIDField is the Go name of the owner's spec field holding its ID; empty when it has none
+	OwnerIDField  string
+	HasExternalID bool
 }
 
 // ActionPathParam represents a path parameter in action templates
@@ -117,6 +301,196 @@ type ActionPathParam struct {
 	GoType    string // Go type (e.g., "string", "int64")
 	IsPointer bool   // True if this is a pointer type (e.g., *int64)
 	BaseType  string // Base type without pointer (e.g., "int64" for "*int64")
+	IsArray   bool   // True for array path params (e.g., /items/{ids} with ids=1,2,3)
+	ItemType  string // Go type of array items (e.g., "int64")
+	Style     string // Serialization style of path params (simple, label or matrix); "" for simple scalars
+	Explode   bool   // Serialization explode flag of array path params
+}
+
+// markPathParamStyles fills the serialization of path params from their spec fields, which
+// the mapper records from the parameter's style/explode: array params are joined per
+// style/explode, and scalar params get their label or matrix prefix.
+func markPathParamStyles(params []ActionPathParam, fields []*mapper.FieldDefinition) {
+	for i := range params {
+		for _, field := range fields {
+			if field.Name != params[i].GoName || field.PathStyle == "" {
+				continue
+			}
+			params[i].Style = field.PathStyle
+			if field.ItemType != nil {
+				params[i].IsArray = true
+				params[i].ItemType = field.ItemType.GoType
+				params[i].Explode = field.PathExplode
+			}
+			break
+		}
+	}
+}
+
+// successCodesByMethod collects the success codes of a CRD's operations by HTTP method.
+// When several operations share a method (e.g., a create and an update POST), the first
+// one declaring codes wins.
+func successCodesByMethod(crd *mapper.CRDDefinition) map[string][]int {
+	var codes map[string][]int
+	for _, op := range crd.Operations {
+		if len(op.SuccessCodes) == 0 {
+			continue
+		}
+		if codes == nil {
+			codes = make(map[string][]int)
+		}
+		if _, ok := codes[op.HTTPMethod]; !ok {
+			codes[op.HTTPMethod] = op.SuccessCodes
+		}
+	}
+	return codes
+}
+
+// acceptByMethod returns the Accept header of each HTTP method of a CRD's operations, from
+// the first operation with the method
+func acceptByMethod(crd *mapper.CRDDefinition) map[string]string {
+	accept := make(map[string]string)
+	for _, op := range crd.Operations {
+		if _, ok := accept[op.HTTPMethod]; !ok && op.Accept != "" {
+			accept[op.HTTPMethod] = op.Accept
+		}
+	}
+	return accept
+}
+
+// ConditionTypeNames are the types of the Ready, Reconciling and Stalled conditions the
+// generated controllers set, renamed by config.Config.ConditionTypes
+type ConditionTypeNames struct {
+	Ready       string
+	Reconciling string
+	Stalled     string
+}
+
+// conditionTypeNames returns the condition types of the generated controllers
+func (g *ControllerGenerator) conditionTypeNames() ConditionTypeNames {
+	return ConditionTypeNames{
+		Ready:       g.config.ConditionType(config.ConditionReady),
+		Reconciling: g.config.ConditionType(config.ConditionReconciling),
+		Stalled:     g.config.ConditionType(config.ConditionStalled),
+	}
+}
+
+// contentTypeByMethod returns the request Content-Type of each HTTP method of a CRD's
+// operations, from the first operation with the method that declares one. Form-encoded
+// bodies are left to FormEncoded, and a JSON Patch media type on PATCH is skipped since
+// the controller sends a merge patch.
+func contentTypeByMethod(crd *mapper.CRDDefinition) map[string]string {
+	contentType := make(map[string]string)
+	for _, op := range crd.Operations {
+		if _, ok := contentType[op.HTTPMethod]; ok || op.ContentType == "" || op.ContentType == "application/x-www-form-urlencoded" {
+			continue
+		}
+		if op.HTTPMethod == "PATCH" && strings.HasPrefix(op.ContentType, "application/json-patch+json") {
+			continue
+		}
+		contentType[op.HTTPMethod] = op.ContentType
+	}
+	return contentType
+}
+
+// securityByMethod returns the security requirements of each HTTP method of a CRD's
+// operations, from the first operation with the method
+func securityByMethod(crd *mapper.CRDDefinition) map[string][]mapper.SecurityRequirement {
+	var security map[string][]mapper.SecurityRequirement
+	seen := make(map[string]bool)
+	for _, op := range crd.Operations {
+		if seen[op.HTTPMethod] {
+			continue
+		}
+		seen[op.HTTPMethod] = true
+		if len(op.Security) > 0 {
+			if security == nil {
+				security = make(map[string][]mapper.SecurityRequirement)
+			}
+			security[op.HTTPMethod] = op.Security
+		}
+	}
+	return security
+}
+
+// crdSecuritySchemes returns the distinct security schemes of crds, sorted by name
+func crdSecuritySchemes(crds []*mapper.CRDDefinition) []mapper.SecurityScheme {
+	seen := make(map[string]bool)
+	var schemes []mapper.SecurityScheme
+	for _, crd := range crds {
+		for _, scheme := range crd.SecuritySchemes {
+			if !seen[scheme.Name] {
+				seen[scheme.Name] = true
+				schemes = append(schemes, scheme)
+			}
+		}
+	}
+	sort.Slice(schemes, func(i, j int) bool { return schemes[i].Name < schemes[j].Name })
+	return schemes
+}
+
+// acceptHeader returns the Accept header of an HTTP method, application/json by default
+func acceptHeader(accept map[string]string, method string) string {
+	if v, ok := accept[method]; ok {
+		return v
+	}
+	return "application/json"
+}
+
+// contentTypeHeader returns the request Content-Type of an HTTP method, fallback by default
+func contentTypeHeader(contentType map[string]string, method, fallback string) string {
+	if v, ok := contentType[method]; ok {
+		return v
+	}
+	return fallback
+}
+
+// failedStatusCondition returns the Go condition matching a response that isn't a
+// success: any non-2xx status by default, or any status but the given success codes
+func failedStatusCondition(codes []int) string {
+	if len(codes) == 0 {
+		return "resp.StatusCode < 200 || resp.StatusCode >= 300"
+	}
+	checks := make([]string, len(codes))
+	for i, code := range codes {
+		checks[i] = fmt.Sprintf("resp.StatusCode != %d", code)
+	}
+	return strings.Join(checks, " && ")
+}
+
+// mockStatusCodes maps the status constants used by the generated test servers to their codes
+var mockStatusCodes = map[string]int{
+	"http.StatusOK":        200,
+	"http.StatusCreated":   201,
+	"http.StatusNoContent": 204,
+}
+
+// mockStatus returns the status a generated test server responds with: the usual status
+// constant when the operation accepts it, or else its first configured success code
+func mockStatus(codes []int, status string) string {
+	if len(codes) == 0 {
+		return status
+	}
+	for _, code := range codes {
+		if code == mockStatusCodes[status] {
+			return status
+		}
+	}
+	return strconv.Itoa(codes[0])
+}
+
+// parentIDStyle returns the label or matrix style of an action's parent ID path param,
+// or "" for the simple style
+func (g *ControllerGenerator) parentIDStyle(crd *mapper.CRDDefinition) string {
+	if crd.ParentIDParam == "" || crd.Spec == nil {
+		return ""
+	}
+	for _, field := range crd.Spec.Fields {
+		if strings.EqualFold(field.JSONName, g.config.JSONFieldName(crd.ParentIDParam)) && field.ItemType == nil {
+			return field.PathStyle
+		}
+	}
+	return ""
 }
 
 // ActionRequestBodyField represents a request body field in action templates
@@ -125,6 +499,12 @@ type ActionRequestBodyField struct {
 	GoName   string // Go field name (e.g., "AdditionalMetadata")
 }
 
+// ActionFormField represents a text part of a multipart/form-data action body
+type ActionFormField struct {
+	PartName string // Form part name (e.g., "additional_metadata")
+	GoName   string // Go field name (e.g., "AdditionalMetadata")
+}
+
 // ResourceQueryParam represents a query parameter for resource endpoints
 type ResourceQueryParam struct {
 	Name     string // Parameter name as it appears in URL (e.g., "status")
@@ -132,6 +512,10 @@ type ResourceQueryParam struct {
 	GoName   string // Go field name (e.g., "Status")
 	GoType   string // Go type (e.g., "string", "int64")
 	IsArray  bool   // True if this is an array parameter
+	// AllowEmptyValue is true when an empty value is sent rather than dropped;
+	// optional ones are *string fields where nil means unset
+	AllowEmptyValue bool
+	IsPointer       bool
 }
 
 // RequiredFieldInfo holds information about a required field for test generation
@@ -159,35 +543,77 @@ type MainTemplateData struct {
 	OperatorVersion string // Pseudo-version for go.mod (e.g., v0.0.8-0.20260115203556-d5024c8e6620)
 	CommitHash      string // Git commit hash (12 chars)
 	CommitTimestamp string // Commit timestamp in YYYYMMDDHHMMSS format (UTC)
+	// HTTP transport defaults for the generated operator's --http-* flags
+	HTTPMaxIdleConns    int
+	HTTPMaxConnsPerHost int
+	HTTPIdleConnTimeout string // Go duration expression (e.g., "90 * time.Second")
+	HTTP2               bool
+	// Export OpenTelemetry spans from the generated manager (--tracing)
+	EnableTracing bool
+	// pprof handler for the generated manager (--profile)
+	EnablePprof bool
+	PprofAddr   string
+	// Default of the generated operator's --slow-reconcile-threshold flag
+	SlowReconcileThreshold string // Go duration expression (e.g., "10 * time.Second")
+	// Defaults of the generated operator's --kube-api-qps and --kube-api-burst flags
+	KubeClientQPS   float32
+	KubeClientBurst int
+	// Default of the generated operator's --pause-configmap flag; empty omits the switch
+	PauseConfigMapRef string
+	// Servers from the spec's servers list, selectable with the operator's --server flag
+	Servers []config.SpecServer
+	// Server the operator targets when no other endpoint is configured (--server-selector)
+	ServerSelector string
+	// WatchNamespaces is the default of the operator's --watch-namespaces flag (ns1,ns2)
+	WatchNamespaces string
+	// HighAvailability turns the operator's --leader-elect flag on by default
+	HighAvailability bool
+	// SupportDryRun adds the --dry-run-external flag
+	SupportDryRun bool
+	// EmbeddedSpec is served on the metrics server when the spec is embedded (--embed-spec)
+	EmbeddedSpec *EmbeddedSpec
+	// Kubebuilder adds the scaffold markers kubebuilder create api/webhook insert code at
+	Kubebuilder bool
+	// WebhookReceiver runs the receiver of the spec's webhooks (internal/webhookreceiver)
+	WebhookReceiver bool
 }
 
 // CRDMainData holds CRD data for main.go
 type CRDMainData struct {
-	Kind     string
-	IsQuery  bool
-	IsAction bool
+	Kind           string
+	IsQuery        bool
+	IsAction       bool
+	ControllerFile string // Controller file name (e.g., pet_controller.go)
+	OperationID    string // Primary operationId, only set with operation-id file naming
 }
 
 // Generate generates controller files
 // aggregate and bundle are optional - pass nil if not generating those CRDs
 func (g *ControllerGenerator) Generate(crds []*mapper.CRDDefinition, aggregate *mapper.AggregateDefinition, bundle *mapper.BundleDefinition) error {
 	controllerDir := filepath.Join(g.config.OutputDir, "internal", "controller")
-	if err := os.MkdirAll(controllerDir, 0755); err != nil {
+	if err := g.files.MkdirAll(controllerDir, 0755); err != nil {
 		return fmt.Errorf("failed to create controller directory: %w", err)
 	}
 
+	if err := g.checkControllerFileNames(crds, aggregate, bundle); err != nil {
+		return err
+	}
+
+	if err := g.loadPreviousHashes(); err != nil {
+		return err
+	}
+	g.config.ControllerHashes = make(map[string]string)
+
+	spec, err := embeddedSpec(g.config)
+	if err != nil {
+		return fmt.Errorf("failed to resolve the spec to embed: %w", err)
+	}
+	g.embeddedSpec = spec
+
 	// Generate a controller for each CRD
 	for _, crd := range crds {
-		if err := g.generateController(controllerDir, crd); err != nil {
-			return fmt.Errorf("failed to generate controller for %s: %w", crd.Kind, err)
-		}
-		// Generate test file for the controller
-		if err := g.generateControllerTest(controllerDir, crd); err != nil {
-			return fmt.Errorf("failed to generate controller test for %s: %w", crd.Kind, err)
-		}
-		// Generate integration test file for the controller
-		if err := g.generateIntegrationTest(controllerDir, crd); err != nil {
-			return fmt.Errorf("failed to generate integration test for %s: %w", crd.Kind, err)
+		if err := g.generateKindFiles(controllerDir, crd); err != nil {
+			return err
 		}
 	}
 
@@ -196,6 +622,11 @@ func (g *ControllerGenerator) Generate(crds []*mapper.CRDDefinition, aggregate *
 		return fmt.Errorf("failed to generate suite_test.go: %w", err)
 	}
 
+	// Generate conditions.go with the condition reasons shared by the controllers
+	if err := g.generateConditions(controllerDir); err != nil {
+		return fmt.Errorf("failed to generate conditions.go: %w", err)
+	}
+
 	// Note: controller utility functions (ValuesEqual, GetExternalIDIfPresent, etc.)
 	// are now in the shared library github.com/bluecontainer/openapi-operator-gen/pkg/controller
 
@@ -204,9 +635,25 @@ func (g *ControllerGenerator) Generate(crds []*mapper.CRDDefinition, aggregate *
 		return fmt.Errorf("failed to generate main.go: %w", err)
 	}
 
-	// Generate go.mod for the generated operator
-	if err := g.generateGoMod(aggregate != nil, bundle != nil); err != nil {
-		return fmt.Errorf("failed to generate go.mod: %w", err)
+	// Generate the receiver of the spec's webhooks, which main.go runs
+	if g.webhookReceiverEnabled() {
+		if err := g.generateWebhookReceiver(crds); err != nil {
+			return fmt.Errorf("failed to generate the webhook receiver: %w", err)
+		}
+	}
+
+	// Generate go.mod for the generated operator (skipped when nested in an existing module)
+	if !g.config.SkipGoMod {
+		if err := g.generateGoMod(aggregate != nil, bundle != nil); err != nil {
+			return fmt.Errorf("failed to generate go.mod: %w", err)
+		}
+	}
+
+	// Generate the kubebuilder PROJECT file
+	if g.config.KubebuilderLayout {
+		if err := g.generateProjectFile(crds, aggregate, bundle); err != nil {
+			return fmt.Errorf("failed to generate PROJECT: %w", err)
+		}
 	}
 
 	// Generate Dockerfile
@@ -219,6 +666,11 @@ func (g *ControllerGenerator) Generate(crds []*mapper.CRDDefinition, aggregate *
 		return fmt.Errorf("failed to generate Makefile: %w", err)
 	}
 
+	// Generate .golangci.yml for the Makefile's lint target
+	if err := g.generateGolangciConfig(); err != nil {
+		return fmt.Errorf("failed to generate .golangci.yml: %w", err)
+	}
+
 	// Generate README.md
 	if err := g.generateReadme(crds, aggregate != nil, bundle != nil); err != nil {
 		return fmt.Errorf("failed to generate README: %w", err)
@@ -235,13 +687,15 @@ func (g *ControllerGenerator) Generate(crds []*mapper.CRDDefinition, aggregate *
 	}
 
 	// Compute spec hash for change detection
-	if hash, err := config.HashSpecFile(g.config.SpecPath); err == nil {
-		g.config.SpecHash = hash
-	}
+	recordSpecHash(g.config)
 
 	// Save resolved config for reproducibility (openapi-operator-gen generate can re-use it)
+	configData, err := config.MarshalConfigFile(g.config)
+	if err != nil {
+		return err
+	}
 	configPath := filepath.Join(g.config.OutputDir, ".openapi-operator-gen.yaml")
-	if err := config.WriteConfigFile(configPath, g.config); err != nil {
+	if err := g.files.WriteFile(configPath, configData, 0644); err != nil {
 		return fmt.Errorf("failed to write config file: %w", err)
 	}
 
@@ -250,16 +704,83 @@ func (g *ControllerGenerator) Generate(crds []*mapper.CRDDefinition, aggregate *
 		return fmt.Errorf("failed to generate boilerplate: %w", err)
 	}
 
+	// Generate hack/rbac-audit.sh for the Makefile's rbac-audit target
+	if err := g.generateRBACAuditScript(); err != nil {
+		return fmt.Errorf("failed to generate RBAC audit script: %w", err)
+	}
+
 	// Generate deployment manifests (namespace, service account, deployment, role binding)
-	if err := g.generateDeploymentManifests(); err != nil {
+	if err := g.generateDeploymentManifests(crds, aggregate, bundle); err != nil {
 		return fmt.Errorf("failed to generate deployment manifests: %w", err)
 	}
 
 	// Copy the OpenAPI spec file to the output directory
-	if err := g.copySpecFile(); err != nil {
+	if err := g.copySpecFile(g.config.OutputDir); err != nil {
 		return fmt.Errorf("failed to copy spec file: %w", err)
 	}
 
+	// Embed the spec copy in the operator binary
+	if g.embeddedSpec != nil {
+		if err := g.generateSpecEmbed(g.embeddedSpec); err != nil {
+			return fmt.Errorf("failed to generate spec_embed.go: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// GenerateKind regenerates only one CRD's controller and its tests, leaving main.go,
+// the other controllers and the project files alone. The hashes of the other
+// controllers in config.ControllerHashes are kept, so the Kind must already have been
+// generated: a new Kind also needs main.go and the RBAC rules regenerated.
+func (g *ControllerGenerator) GenerateKind(crd *mapper.CRDDefinition) error {
+	controllerDir := filepath.Join(g.config.OutputDir, "internal", "controller")
+	if err := g.files.MkdirAll(controllerDir, 0755); err != nil {
+		return fmt.Errorf("failed to create controller directory: %w", err)
+	}
+	if err := g.loadPreviousHashes(); err != nil {
+		return err
+	}
+	if g.config.ControllerHashes == nil {
+		g.config.ControllerHashes = make(map[string]string)
+	}
+	return g.generateKindFiles(controllerDir, crd)
+}
+
+// loadPreviousHashes reads the controller hashes saved by the last generation, which
+// merge mode compares the controllers on disk against
+func (g *ControllerGenerator) loadPreviousHashes() error {
+	if !g.config.MergeControllers {
+		return nil
+	}
+	previous, err := config.LoadConfigFile(filepath.Join(g.config.OutputDir, manifestFile))
+	if err != nil {
+		return fmt.Errorf("failed to load previous controller hashes: %w", err)
+	}
+	if previous != nil {
+		g.previousHashes = previous.ControllerHashes
+	}
+	return nil
+}
+
+// generateKindFiles writes a CRD's controller, unit test, integration test and CEL
+// validation test
+func (g *ControllerGenerator) generateKindFiles(controllerDir string, crd *mapper.CRDDefinition) error {
+	if err := g.generateController(controllerDir, crd); err != nil {
+		return fmt.Errorf("failed to generate controller for %s: %w", crd.Kind, err)
+	}
+	// Generate test file for the controller
+	if err := g.generateControllerTest(controllerDir, crd); err != nil {
+		return fmt.Errorf("failed to generate controller test for %s: %w", crd.Kind, err)
+	}
+	// Generate integration test file for the controller
+	if err := g.generateIntegrationTest(controllerDir, crd); err != nil {
+		return fmt.Errorf("failed to generate integration test for %s: %w", crd.Kind, err)
+	}
+	// Generate envtest cases for the CRD's CEL validation rules
+	if err := g.generateCELValidationTest(controllerDir, crd); err != nil {
+		return fmt.Errorf("failed to generate CEL validation test for %s: %w", crd.Kind, err)
+	}
 	return nil
 }
 
@@ -268,13 +789,24 @@ func (g *ControllerGenerator) generateController(outputDir string, crd *mapper.C
 		Year:               time.Now().Year(),
 		GeneratorVersion:   g.config.GeneratorVersion,
 		APIGroup:           crd.APIGroup,
+		FinalizerName:      g.config.ResolvedFinalizerName(),
 		APIVersion:         crd.APIVersion,
-		ModuleName:         g.config.ModuleName,
+		ModuleName:         g.config.ResolvedImportPrefix(),
+		APIPackage:         g.config.APIImportPath(),
 		Kind:               crd.Kind,
 		KindLower:          strings.ToLower(crd.Kind),
 		Plural:             crd.Plural,
 		BasePath:           crd.BasePath,
 		ResourcePath:       crd.ResourcePath,
+		HasExtraHeaders:    crd.HasExtraHeaders,
+		SupportDryRun:      g.config.SupportDryRun,
+		FormEncoded:        crd.FormEncoded,
+		IdempotencyHeader:  g.config.IdempotencyHeader,
+		SuccessCodes:       successCodesByMethod(crd),
+		Accept:             acceptByMethod(crd),
+		ContentType:        contentTypeByMethod(crd),
+		Conditions:         g.conditionTypeNames(),
+		Security:           securityByMethod(crd),
 		IsQuery:            crd.IsQuery,
 		QueryPath:          crd.QueryPath,
 		QueryPathParams:    crd.QueryPathParams,
@@ -286,6 +818,7 @@ func (g *ControllerGenerator) generateController(outputDir string, crd *mapper.C
 		UsesSharedType:     crd.UsesSharedType,
 		IsPrimitiveArray:   crd.IsPrimitiveArray,
 		PrimitiveArrayType: crd.PrimitiveArrayType,
+		MaxQueryResults:    g.config.MaxQueryResults,
 		// Action fields
 		IsAction:          crd.IsAction,
 		ActionPath:        crd.ActionPath,
@@ -294,28 +827,48 @@ func (g *ControllerGenerator) generateController(outputDir string, crd *mapper.C
 		ParentIDParam:     crd.ParentIDParam,
 		ParentIDField:     strcase.ToCamel(crd.ParentIDParam),
 		ParentIDGoType:    crd.ParentIDGoType,
+		ParentIDStyle:     g.parentIDStyle(crd),
 		HasParentID:       crd.ParentIDParam != "",
 		ActionName:        crd.ActionName,
 		HasBinaryBody:     crd.HasBinaryBody,
 		BinaryContentType: crd.BinaryContentType,
+		WatchDataFrom:     crd.WatchDataFrom,
+		IsMultipart:       crd.IsMultipart,
+		FileField:         crd.FileField,
 		// HTTP method availability
 		HasDelete:      crd.HasDelete,
 		HasPost:        crd.HasPost,
 		HasPut:         crd.HasPut,
 		HasPatch:       crd.HasPatch,
 		UpdateWithPost: crd.UpdateWithPost,
+		UseETag:        crd.UseETag,
+		StatusFields:   crd.StatusFields,
+		PollCondition:  crd.PollCondition,
+		PauseSwitch:    g.config.PauseConfigMapRef != "",
+
+		OrphanOnDeleteTimeout: g.config.OrphanOnDeleteTimeout,
+
+		StatusSubresource:   !g.config.NoStatusSubresource,
+		GenerationPredicate: !g.config.NoGenerationPredicate,
 		// Per-method paths
 		GetPath:        crd.GetPath,
 		PutPath:        crd.PutPath,
 		DeletePath:     crd.DeletePath,
 		PutPathDiffers: crd.PutPath != "" && crd.GetPath != "" && crd.PutPath != crd.GetPath,
 	}
+	if crd.Spec != nil {
+		data.WriteOnlyFields = writeOnlyFieldPaths(crd.Spec.Fields, "")
+		data.SpecFieldRenames = specFieldRenames(crd.Spec.Fields)
+	}
+	if g.config.DeleteTimeout > 0 {
+		data.DeleteTimeout = durationLiteral(g.config.DeleteTimeout)
+	}
 
 	// Populate path params (excluding parent ID)
 	if crd.IsAction && crd.Spec != nil {
 		for _, field := range crd.Spec.Fields {
 			// Skip the parent ID field - already handled separately
-			if strings.EqualFold(field.JSONName, strcase.ToLowerCamel(crd.ParentIDParam)) {
+			if strings.EqualFold(field.JSONName, g.config.JSONFieldName(crd.ParentIDParam)) {
 				continue
 			}
 			// Skip targeting fields
@@ -332,13 +885,26 @@ func (g *ControllerGenerator) generateController(outputDir string, crd *mapper.C
 				})
 			} else {
 				// It's a request body field
+				jsonName := field.JSONName
+				if field.APIName != "" {
+					jsonName = field.APIName
+				}
 				data.RequestBodyFields = append(data.RequestBodyFields, ActionRequestBodyField{
-					JSONName: field.JSONName,
+					JSONName: jsonName,
 					GoName:   field.Name,
 				})
 			}
 		}
 		data.HasRequestBody = len(data.RequestBodyFields) > 0
+
+		for _, partName := range crd.FormFields {
+			for _, field := range crd.Spec.Fields {
+				if field.JSONName == g.config.JSONFieldName(partName) || field.APIName == partName {
+					data.FormFields = append(data.FormFields, ActionFormField{PartName: partName, GoName: field.Name})
+					break
+				}
+			}
+		}
 	}
 
 	// Populate path and query params for resource endpoints (non-query, non-action)
@@ -375,16 +941,21 @@ func (g *ControllerGenerator) generateController(outputDir string, crd *mapper.C
 						if mergedFieldName != "" {
 							targetField = mergedFieldName
 						}
-						if strings.EqualFold(field.JSONName, strcase.ToLowerCamel(targetField)) {
+						if strings.EqualFold(field.JSONName, g.config.JSONFieldName(targetField)) {
 							goType = field.GoType
 							goName = field.Name // Use the actual field name (e.g., "Id" not "OrderId")
 
 							// Apply the same pointer logic as resolveGoType in types.go:
-							// Non-required primitive numeric types become pointers in the generated code
+							// Non-required primitive numeric types (and nullable strings/bools) become
+							// pointers in the generated code
 							if !field.Required {
 								switch goType {
 								case "int", "int32", "int64", "float32", "float64":
 									goType = "*" + goType
+								case "string", "bool":
+									if field.Nullable {
+										goType = "*" + goType
+									}
 								}
 							}
 
@@ -420,22 +991,28 @@ func (g *ControllerGenerator) generateController(outputDir string, crd *mapper.C
 				queryParamsSeen[paramName] = true
 				// Find the field in spec to get type info
 				isArray := false
+				allowEmpty := false
+				isPointer := false
 				goType := "string" // default
 				if crd.Spec != nil {
 					for _, field := range crd.Spec.Fields {
-						if strings.EqualFold(field.JSONName, strcase.ToLowerCamel(paramName)) {
+						if strings.EqualFold(field.JSONName, g.config.JSONFieldName(paramName)) {
 							goType = field.GoType
 							isArray = strings.HasPrefix(field.GoType, "[]")
+							allowEmpty = field.AllowEmptyValue
+							isPointer = allowEmpty && !field.Required
 							break
 						}
 					}
 				}
 				data.ResourceQueryParams = append(data.ResourceQueryParams, ResourceQueryParam{
-					Name:     paramName,
-					JSONName: strcase.ToLowerCamel(paramName),
-					GoName:   strcase.ToCamel(paramName),
-					GoType:   goType,
-					IsArray:  isArray,
+					Name:            paramName,
+					JSONName:        g.config.JSONFieldName(paramName),
+					GoName:          strcase.ToCamel(paramName),
+					GoType:          goType,
+					IsArray:         isArray,
+					AllowEmptyValue: allowEmpty,
+					IsPointer:       isPointer,
 				})
 			}
 		}
@@ -444,34 +1021,56 @@ func (g *ControllerGenerator) generateController(outputDir string, crd *mapper.C
 		// Use the NeedsExternalIDRef value from the CRD (set by mapper based on ResourcePath)
 		// This is true when there are no path parameters to identify the resource
 		data.NeedsExternalIDRef = crd.NeedsExternalIDRef
+		data.ReadOnly = crd.ReadOnly
+
+		// The owner's CR is found by its ID field or status.externalID; with neither it can't be
+		if crd.Owner != nil && (crd.Owner.IDField != "" || crd.Owner.HasExternalID) {
+			for _, p := range data.ResourcePathParams {
+				if p.Name == crd.Owner.IDParam && !p.IsArray {
+					data.Owner = &OwnerData{
+						Kind:          crd.Owner.Kind,
+						Plural:        crd.Owner.Plural,
+						IDField:       p.GoName,
+						OwnerIDField:  crd.Owner.IDField,
+						HasExternalID: crd.Owner.HasExternalID,
+					}
+				}
+			}
+		}
 	}
 
-	// Check if any path parameter is int64 (needed for fmt import in tests)
+	// Mark path param styles so the templates join arrays per style/explode and prefix
+	// label and matrix scalars
+	if crd.Spec != nil {
+		markPathParamStyles(data.PathParams, crd.Spec.Fields)
+		markPathParamStyles(data.ResourcePathParams, crd.Spec.Fields)
+	}
+
+	// Check if any path parameter is an integer (needed for fmt import in tests)
 	for _, p := range data.PathParams {
-		if p.GoType == "int64" {
-			data.HasInt64PathParams = true
+		if p.GoType == "int64" || p.GoType == "int32" {
+			data.HasIntPathParams = true
 			break
 		}
 	}
-	if !data.HasInt64PathParams {
+	if !data.HasIntPathParams {
 		for _, p := range data.QueryPathParams {
-			if p.GoType == "int64" {
-				data.HasInt64PathParams = true
+			if p.GoType == "int64" || p.GoType == "int32" || p.GoType == "[]int64" || p.GoType == "[]int32" {
+				data.HasIntPathParams = true
 				break
 			}
 		}
 	}
-	if !data.HasInt64PathParams {
+	if !data.HasIntPathParams {
 		for _, p := range data.ResourcePathParams {
-			if p.GoType == "int64" {
-				data.HasInt64PathParams = true
+			if p.GoType == "int64" || p.GoType == "int32" {
+				data.HasIntPathParams = true
 				break
 			}
 		}
 	}
 
-	filename := fmt.Sprintf("%s_controller.go", strings.ToLower(crd.Kind))
-	fp := filepath.Join(outputDir, filename)
+	fp := filepath.Join(outputDir, g.controllerFileName(crd))
 
 	// Choose appropriate template based on CRD type
 	var tmplContent string
@@ -487,6 +1086,9 @@ func (g *ControllerGenerator) generateController(outputDir string, crd *mapper.C
 		"sub": func(a, b int) int {
 			return a - b
 		},
+		"failedStatus": failedStatusCondition,
+		"accept":       acceptHeader,
+		"contentType":  contentTypeHeader,
 	}
 
 	tmpl, err := template.New("controller").Funcs(funcMap).Parse(tmplContent)
@@ -494,17 +1096,48 @@ func (g *ControllerGenerator) generateController(outputDir string, crd *mapper.C
 		return fmt.Errorf("failed to parse template: %w", err)
 	}
 
-	file, err := os.Create(fp)
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return fmt.Errorf("failed to execute template: %w", err)
+	}
+	content := buf.Bytes()
+
+	rel, err := filepath.Rel(g.config.OutputDir, fp)
 	if err != nil {
-		return fmt.Errorf("failed to create file: %w", err)
+		rel = fp
 	}
-	defer file.Close()
+	rel = filepath.ToSlash(rel)
+	hash := config.HashSpecBytes(content)
+	g.config.ControllerHashes[rel] = hash
 
-	if err := tmpl.Execute(file, data); err != nil {
-		return fmt.Errorf("failed to execute template: %w", err)
+	if g.config.MergeControllers && g.controllerEdited(fp, rel, hash) {
+		fp += ".new"
+		g.pendingMerges = append(g.pendingMerges, rel+".new")
 	}
 
-	return nil
+	return g.files.WriteFile(fp, content, 0644)
+}
+
+// controllerEdited reports whether an existing controller was changed since it was
+// generated, i.e. its content matches neither the saved hash nor the new output.
+// Controllers without a saved hash (generated by an older version) count as edited
+// unless they already match, so nothing is overwritten on a guess.
+func (g *ControllerGenerator) controllerEdited(path, rel, newHash string) bool {
+	existing, err := os.ReadFile(path)
+	if err != nil {
+		return false
+	}
+	current := config.HashSpecBytes(existing)
+	if current == newHash {
+		return false
+	}
+	return current != g.previousHashes[rel]
+}
+
+// PendingMerges returns the <controller>.new files written in merge mode because the
+// existing controller had been edited by hand, relative to the output directory.
+func (g *ControllerGenerator) PendingMerges() []string {
+	return g.pendingMerges
 }
 
 func (g *ControllerGenerator) generateControllerTest(outputDir string, crd *mapper.CRDDefinition) error {
@@ -512,12 +1145,18 @@ func (g *ControllerGenerator) generateControllerTest(outputDir string, crd *mapp
 		Year:               time.Now().Year(),
 		APIGroup:           crd.APIGroup,
 		APIVersion:         crd.APIVersion,
-		ModuleName:         g.config.ModuleName,
+		ModuleName:         g.config.ResolvedImportPrefix(),
+		APIPackage:         g.config.APIImportPath(),
 		Kind:               crd.Kind,
 		KindLower:          strings.ToLower(crd.Kind),
 		Plural:             crd.Plural,
 		BasePath:           crd.BasePath,
 		ResourcePath:       crd.ResourcePath,
+		HasExtraHeaders:    crd.HasExtraHeaders,
+		SupportDryRun:      g.config.SupportDryRun,
+		FormEncoded:        crd.FormEncoded,
+		IdempotencyHeader:  g.config.IdempotencyHeader,
+		SuccessCodes:       successCodesByMethod(crd),
 		IsQuery:            crd.IsQuery,
 		QueryPath:          crd.QueryPath,
 		QueryPathParams:    crd.QueryPathParams,
@@ -532,18 +1171,24 @@ func (g *ControllerGenerator) generateControllerTest(outputDir string, crd *mapp
 		ParentIDParam:     crd.ParentIDParam,
 		ParentIDField:     strcase.ToCamel(crd.ParentIDParam),
 		ParentIDGoType:    crd.ParentIDGoType,
+		ParentIDStyle:     g.parentIDStyle(crd),
 		HasParentID:       crd.ParentIDParam != "",
 		ActionName:        crd.ActionName,
 		HasBinaryBody:     crd.HasBinaryBody,
 		BinaryContentType: crd.BinaryContentType,
+		WatchDataFrom:     crd.WatchDataFrom,
 		HasDelete:         crd.HasDelete,
 		HasPost:           crd.HasPost,
+
+		ReadOnly:            crd.ReadOnly,
+		StatusSubresource:   !g.config.NoStatusSubresource,
+		GenerationPredicate: !g.config.NoGenerationPredicate,
 	}
 
 	// Populate path params for action endpoints (excluding parent ID)
 	if crd.IsAction && crd.Spec != nil {
 		for _, field := range crd.Spec.Fields {
-			if strings.EqualFold(field.JSONName, strcase.ToLowerCamel(crd.ParentIDParam)) {
+			if strings.EqualFold(field.JSONName, g.config.JSONFieldName(crd.ParentIDParam)) {
 				continue
 			}
 			if field.JSONName == "targetPodOrdinal" || field.JSONName == "targetHelmRelease" ||
@@ -590,13 +1235,17 @@ func (g *ControllerGenerator) generateControllerTest(outputDir string, crd *mapp
 						if mergedFieldName != "" {
 							targetField = mergedFieldName
 						}
-						if strings.EqualFold(field.JSONName, strcase.ToLowerCamel(targetField)) {
+						if strings.EqualFold(field.JSONName, g.config.JSONFieldName(targetField)) {
 							goType = field.GoType
 							goName = field.Name
 							if !field.Required {
 								switch goType {
 								case "int", "int32", "int64", "float32", "float64":
 									goType = "*" + goType
+								case "string", "bool":
+									if field.Nullable {
+										goType = "*" + goType
+									}
 								}
 							}
 							if strings.HasPrefix(goType, "*") {
@@ -620,25 +1269,32 @@ func (g *ControllerGenerator) generateControllerTest(outputDir string, crd *mapp
 		}
 	}
 
-	// Check if any path parameter is int64 (needed for fmt import in tests)
+	// Mark path param styles so the templates join arrays per style/explode and prefix
+	// label and matrix scalars
+	if crd.Spec != nil {
+		markPathParamStyles(data.PathParams, crd.Spec.Fields)
+		markPathParamStyles(data.ResourcePathParams, crd.Spec.Fields)
+	}
+
+	// Check if any path parameter is an integer (needed for fmt import in tests)
 	for _, p := range data.PathParams {
-		if p.GoType == "int64" {
-			data.HasInt64PathParams = true
+		if p.GoType == "int64" || p.GoType == "int32" {
+			data.HasIntPathParams = true
 			break
 		}
 	}
-	if !data.HasInt64PathParams {
+	if !data.HasIntPathParams {
 		for _, p := range data.QueryPathParams {
-			if p.GoType == "int64" {
-				data.HasInt64PathParams = true
+			if p.GoType == "int64" || p.GoType == "int32" || p.GoType == "[]int64" || p.GoType == "[]int32" {
+				data.HasIntPathParams = true
 				break
 			}
 		}
 	}
-	if !data.HasInt64PathParams {
+	if !data.HasIntPathParams {
 		for _, p := range data.ResourcePathParams {
-			if p.GoType == "int64" {
-				data.HasInt64PathParams = true
+			if p.GoType == "int64" || p.GoType == "int32" {
+				data.HasIntPathParams = true
 				break
 			}
 		}
@@ -647,60 +1303,465 @@ func (g *ControllerGenerator) generateControllerTest(outputDir string, crd *mapp
 	filename := fmt.Sprintf("%s_controller_test.go", strings.ToLower(crd.Kind))
 	fp := filepath.Join(outputDir, filename)
 
-	tmpl, err := template.New("controller_test").Parse(templates.ControllerTestTemplate)
+	tmpl, err := template.New("controller_test").Funcs(template.FuncMap{
+		"mockStatus": mockStatus,
+	}).Parse(templates.ControllerTestTemplate)
+	if err != nil {
+		return fmt.Errorf("failed to parse template: %w", err)
+	}
+
+	file, err := g.files.Create(fp)
+	if err != nil {
+		return fmt.Errorf("failed to create file: %w", err)
+	}
+	defer file.Close()
+
+	if err := tmpl.Execute(file, data); err != nil {
+		return fmt.Errorf("failed to execute template: %w", err)
+	}
+
+	return nil
+}
+
+// ConditionsTemplateData holds data for the conditions.go template
+type ConditionsTemplateData struct {
+	Year             int
+	GeneratorVersion string
+	Conditions       ConditionTypeNames
+}
+
+// generateConditions writes conditions.go, the condition reason constants shared by
+// every controller in the package
+func (g *ControllerGenerator) generateConditions(outputDir string) error {
+	data := ConditionsTemplateData{
+		Year:             time.Now().Year(),
+		GeneratorVersion: g.config.GeneratorVersion,
+		Conditions:       g.conditionTypeNames(),
+	}
+	return g.executeTemplate(templates.ConditionsTemplate, data, filepath.Join(outputDir, "conditions.go"))
+}
+
+// SuiteTestTemplateData holds data for the suite_test.go template
+type SuiteTestTemplateData struct {
+	Year             int
+	GeneratorVersion string
+	APIVersion       string
+	ModuleName       string
+	KubeVersion      string
+}
+
+func (g *ControllerGenerator) generateSuiteTest(outputDir string) error {
+	data := SuiteTestTemplateData{
+		Year:             time.Now().Year(),
+		GeneratorVersion: g.config.GeneratorVersion,
+		APIVersion:       g.config.APIVersion,
+		ModuleName:       g.config.ResolvedImportPrefix(),
+		KubeVersion:      "1.29.0",
+	}
+
+	fp := filepath.Join(outputDir, "suite_test.go")
+
+	tmpl, err := template.New("suite_test").Parse(templates.SuiteTestTemplate)
 	if err != nil {
 		return fmt.Errorf("failed to parse template: %w", err)
 	}
 
-	file, err := os.Create(fp)
-	if err != nil {
-		return fmt.Errorf("failed to create file: %w", err)
+	file, err := g.files.Create(fp)
+	if err != nil {
+		return fmt.Errorf("failed to create file: %w", err)
+	}
+	defer file.Close()
+
+	if err := tmpl.Execute(file, data); err != nil {
+		return fmt.Errorf("failed to execute template: %w", err)
+	}
+
+	return nil
+}
+
+// CELValidationTestTemplateData holds data for the <kind>_cel_validation_test.go template
+type CELValidationTestTemplateData struct {
+	Year             int
+	GeneratorVersion string
+	APIGroup         string
+	APIVersion       string
+	Kind             string
+	KindLower        string
+	// SpecFields is a spec that satisfies all rules (required fields plus every rule's target field)
+	SpecFields []CELSampleField
+	// Rules are the CEL validation rules to test
+	Rules []mapper.CELValidationRule
+	// ReferenceFields are fields that satisfy the rules by referencing an existing resource
+	ReferenceFields []CELSampleField
+}
+
+// CELSampleField is a spec field with a Go literal sample value for an unstructured object
+type CELSampleField struct {
+	JSONName string
+	Value    string
+}
+
+// generateCELValidationTest generates envtest cases that create CRs violating and satisfying
+// each of the CRD's CEL validation rules. Nothing is generated when the CRD has no rules or
+// when a sample value cannot be derived for one of the fields involved.
+func (g *ControllerGenerator) generateCELValidationTest(outputDir string, crd *mapper.CRDDefinition) error {
+	if len(crd.CELValidationRules) == 0 || crd.Spec == nil {
+		return nil
+	}
+
+	fieldsByJSONName := make(map[string]*mapper.FieldDefinition)
+	for _, field := range crd.Spec.Fields {
+		fieldsByJSONName[field.JSONName] = field
+	}
+
+	data := CELValidationTestTemplateData{
+		Year:             time.Now().Year(),
+		GeneratorVersion: g.config.GeneratorVersion,
+		APIGroup:         crd.APIGroup,
+		APIVersion:       crd.APIVersion,
+		Kind:             crd.Kind,
+		KindLower:        strings.ToLower(crd.Kind),
+		Rules:            crd.CELValidationRules,
+	}
+
+	ruleFields := make(map[string]bool)
+	for _, rule := range crd.CELValidationRules {
+		ruleFields[rule.Field] = true
+	}
+
+	referenceFields := make(map[string]bool)
+	for _, ref := range crd.CELValidationRules[0].ReferenceFields {
+		referenceFields[ref] = true
+		if ref == "externalIDRef" {
+			data.ReferenceFields = append(data.ReferenceFields, CELSampleField{JSONName: ref, Value: `"test-id"`})
+			continue
+		}
+		field, ok := fieldsByJSONName[ref]
+		if !ok {
+			return nil
+		}
+		// A strictly required reference field satisfies every rule, so violations can't be produced
+		if field.Required {
+			return nil
+		}
+		value, ok := celSampleValue(field)
+		if !ok {
+			return nil
+		}
+		data.ReferenceFields = append(data.ReferenceFields, CELSampleField{JSONName: ref, Value: value})
+	}
+
+	for _, field := range crd.Spec.Fields {
+		if referenceFields[field.JSONName] || (!field.Required && !ruleFields[field.JSONName]) {
+			continue
+		}
+		value, ok := celSampleValue(field)
+		if !ok {
+			return nil
+		}
+		data.SpecFields = append(data.SpecFields, CELSampleField{JSONName: field.JSONName, Value: value})
+	}
+
+	filename := fmt.Sprintf("%s_cel_validation_test.go", strings.ToLower(crd.Kind))
+	return g.executeTemplate(templates.CELValidationTestTemplate, data, filepath.Join(outputDir, filename))
+}
+
+// celSampleValue returns a Go literal for a value that satisfies the field's schema when
+// placed in an unstructured object. Returns false for types that can't be sampled reliably.
+func celSampleValue(field *mapper.FieldDefinition) (string, bool) {
+	enum := field.Enum
+	if field.Validation != nil {
+		if field.Validation.Pattern != "" {
+			return "", false
+		}
+		if len(field.Validation.Enum) > 0 {
+			enum = field.Validation.Enum
+		}
+	}
+
+	goType := strings.TrimPrefix(field.GoType, "*")
+	switch goType {
+	case "string":
+		if len(enum) > 0 {
+			return fmt.Sprintf("%q", enum[0]), true
+		}
+		if example, ok := mapper.FormatExample(field.Format); ok {
+			if !celSampleLengthOK(field.Validation, int64(len(example))) {
+				return "", false
+			}
+			return fmt.Sprintf("%q", example), true
+		}
+		value := "test-value"
+		if field.Validation != nil && field.Validation.MaxLength != nil && *field.Validation.MaxLength < int64(len(value)) {
+			value = value[:max(*field.Validation.MaxLength, 0)]
+		}
+		if field.Validation != nil && field.Validation.MinLength != nil && *field.Validation.MinLength > int64(len(value)) {
+			value += strings.Repeat("x", int(*field.Validation.MinLength)-len(value))
+		}
+		if !celSampleLengthOK(field.Validation, int64(len(value))) {
+			return "", false
+		}
+		return fmt.Sprintf("%q", value), true
+	case "int", "int32", "int64":
+		value, ok := celSampleNumber(field.Validation, true)
+		if !ok {
+			return "", false
+		}
+		return fmt.Sprintf("int64(%s)", value), true
+	case "float32", "float64":
+		value, ok := celSampleNumber(field.Validation, false)
+		if !ok {
+			return "", false
+		}
+		return fmt.Sprintf("float64(%s)", value), true
+	case "bool":
+		return "true", true
+	case "[]string":
+		if field.Validation != nil && field.Validation.MaxItems != nil && *field.Validation.MaxItems < 1 {
+			return "", false
+		}
+		return `[]interface{}{"test-value"}`, true
+	}
+	return "", false
+}
+
+// celSampleLengthOK reports whether a string of the given length meets the field's
+// minLength and maxLength
+func celSampleLengthOK(v *mapper.ValidationRules, length int64) bool {
+	if v == nil {
+		return true
+	}
+	return (v.MinLength == nil || length >= *v.MinLength) && (v.MaxLength == nil || length <= *v.MaxLength)
+}
+
+// celSampleNumber returns 1 unless the field's minimum or maximum rules it out, in which
+// case it returns a value within the bounds. Returns false when no value (no integer, for
+// integer fields) meets both.
+func celSampleNumber(v *mapper.ValidationRules, integer bool) (string, bool) {
+	low, high := math.Inf(-1), math.Inf(1)
+	exclusiveLow, exclusiveHigh := false, false
+	if v != nil && v.Minimum != nil {
+		low, exclusiveLow = *v.Minimum, v.ExclusiveMinimum
+	}
+	if v != nil && v.Maximum != nil {
+		high, exclusiveHigh = *v.Maximum, v.ExclusiveMaximum
+	}
+
+	var value float64
+	if integer {
+		// Narrow the bounds to the smallest and largest integers they allow
+		if low != math.Ceil(low) || !exclusiveLow {
+			low = math.Ceil(low)
+		} else {
+			low++
+		}
+		if high != math.Floor(high) || !exclusiveHigh {
+			high = math.Floor(high)
+		} else {
+			high--
+		}
+		if low > high {
+			return "", false
+		}
+		value = math.Min(math.Max(1, low), high)
+		return strconv.FormatInt(int64(value), 10), true
+	}
+
+	inBounds := func(x float64) bool {
+		return (x > low || (!exclusiveLow && x == low)) && (x < high || (!exclusiveHigh && x == high))
 	}
-	defer file.Close()
-
-	if err := tmpl.Execute(file, data); err != nil {
-		return fmt.Errorf("failed to execute template: %w", err)
+	switch {
+	case inBounds(1):
+		value = 1
+	case math.IsInf(high, 1):
+		value = low + 1
+	case math.IsInf(low, -1):
+		value = high - 1
+	default:
+		value = low + (high-low)/2
 	}
+	if !inBounds(value) {
+		return "", false
+	}
+	return strconv.FormatFloat(value, 'f', -1, 64), true
+}
 
-	return nil
+// writeOnlyFieldPaths collects the dot-separated JSON paths of writeOnly fields, descending
+// into nested objects (but not arrays, whose elements have no stable path).
+func writeOnlyFieldPaths(fields []*mapper.FieldDefinition, prefix string) []string {
+	var paths []string
+	for _, f := range fields {
+		path := prefix + f.JSONName
+		if f.WriteOnly {
+			paths = append(paths, path)
+			continue
+		}
+		if len(f.Fields) > 0 {
+			paths = append(paths, writeOnlyFieldPaths(f.Fields, path+".")...)
+		}
+	}
+	return paths
 }
 
-// SuiteTestTemplateData holds data for the suite_test.go template
-type SuiteTestTemplateData struct {
-	Year             int
-	GeneratorVersion string
-	APIVersion       string
-	ModuleName       string
-	KubeVersion      string
+// hasWriteOnlyGeneration reports whether a CRD's status records the generation whose
+// writeOnly spec fields were last sent: true for resources with writeOnly fields
+func hasWriteOnlyGeneration(crd *mapper.CRDDefinition) bool {
+	return !crd.IsQuery && !crd.IsAction && crd.Spec != nil && len(writeOnlyFieldPaths(crd.Spec.Fields, "")) > 0
 }
 
-func (g *ControllerGenerator) generateSuiteTest(outputDir string) error {
-	data := SuiteTestTemplateData{
-		Year:             time.Now().Year(),
-		GeneratorVersion: g.config.GeneratorVersion,
-		APIVersion:       g.config.APIVersion,
-		ModuleName:       g.config.ModuleName,
-		KubeVersion:      "1.29.0",
+// specFieldRenames maps the spec fields renamed with x-k8s-spec-field to their API names
+func specFieldRenames(fields []*mapper.FieldDefinition) map[string]string {
+	var renames map[string]string
+	for _, f := range fields {
+		if f.APIName == "" {
+			continue
+		}
+		if renames == nil {
+			renames = make(map[string]string)
+		}
+		renames[f.JSONName] = f.APIName
 	}
+	return renames
+}
 
-	fp := filepath.Join(outputDir, "suite_test.go")
+// requestExampleMethodOrder decides which operation's request body example seeds the
+// integration tests when a resource has several: the create, then the updates
+var requestExampleMethodOrder = []string{"POST", "PUT", "PATCH"}
 
-	tmpl, err := template.New("suite_test").Parse(templates.SuiteTestTemplate)
-	if err != nil {
-		return fmt.Errorf("failed to parse template: %w", err)
+// specExampleJSON returns the spec's request body example for a CRD as JSON, keeping only
+// the values that fit its spec fields, or "" when there's no usable example
+func specExampleJSON(crd *mapper.CRDDefinition) string {
+	if crd.Spec == nil || crd.IsQuery {
+		return ""
+	}
+	for _, method := range requestExampleMethodOrder {
+		for _, op := range crd.Operations {
+			example, ok := op.RequestExample.(map[string]interface{})
+			if op.HTTPMethod != method || !ok {
+				continue
+			}
+			fitted := exampleObject(example, crd.Spec.Fields)
+			if len(fitted) == 0 {
+				continue
+			}
+			data, err := json.Marshal(fitted)
+			if err != nil {
+				continue
+			}
+			return string(data)
+		}
 	}
+	return ""
+}
 
-	file, err := os.Create(fp)
-	if err != nil {
-		return fmt.Errorf("failed to create file: %w", err)
+// goStringLiteral returns s as a raw string literal, or a quoted one when s contains a backtick
+func goStringLiteral(s string) string {
+	if strings.Contains(s, "`") {
+		return strconv.Quote(s)
 	}
-	defer file.Close()
+	return "`" + s + "`"
+}
 
-	if err := tmpl.Execute(file, data); err != nil {
-		return fmt.Errorf("failed to execute template: %w", err)
+// exampleObject keeps the values of an example object that fit the given fields
+func exampleObject(example map[string]interface{}, fields []*mapper.FieldDefinition) map[string]interface{} {
+	fitted := make(map[string]interface{})
+	for _, field := range fields {
+		// Examples use the API's names for fields renamed with x-k8s-spec-field
+		key := field.JSONName
+		if field.APIName != "" {
+			key = field.APIName
+		}
+		value, ok := example[key]
+		if !ok {
+			continue
+		}
+		if v, ok := exampleValue(value, field); ok {
+			fitted[field.JSONName] = v
+		}
 	}
+	return fitted
+}
 
-	return nil
+// exampleValue checks that an example value unmarshals into a field's Go type, returning
+// it reduced to the field's known nested fields
+func exampleValue(value interface{}, field *mapper.FieldDefinition) (interface{}, bool) {
+	goType := strings.TrimPrefix(field.GoType, "*")
+	switch {
+	case goType == "runtime.RawExtension":
+		return value, true
+	case strings.HasPrefix(goType, "map[string]"):
+		obj, ok := value.(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		valueField := field.ItemType
+		if valueField == nil {
+			valueField = &mapper.FieldDefinition{GoType: strings.TrimPrefix(goType, "map[string]")}
+		}
+		fitted := make(map[string]interface{}, len(obj))
+		for key, item := range obj {
+			v, ok := exampleValue(item, valueField)
+			if !ok {
+				return nil, false
+			}
+			fitted[key] = v
+		}
+		return fitted, true
+	case goType == "[]byte":
+		str, ok := value.(string)
+		if !ok {
+			return nil, false
+		}
+		_, err := base64.StdEncoding.DecodeString(str)
+		return value, err == nil
+	case strings.HasPrefix(goType, "[]"):
+		items, ok := value.([]interface{})
+		if !ok {
+			return nil, false
+		}
+		itemField := field.ItemType
+		if itemField == nil {
+			itemField = &mapper.FieldDefinition{GoType: strings.TrimPrefix(goType, "[]")}
+		}
+		fitted := make([]interface{}, 0, len(items))
+		for _, item := range items {
+			v, ok := exampleValue(item, itemField)
+			if !ok {
+				return nil, false
+			}
+			fitted = append(fitted, v)
+		}
+		return fitted, true
+	case len(field.Fields) > 0:
+		obj, ok := value.(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		return exampleObject(obj, field.Fields), true
+	}
+
+	switch goType {
+	case "string":
+		_, ok := value.(string)
+		return value, ok
+	case "bool":
+		_, ok := value.(bool)
+		return value, ok
+	case "int", "int32", "int64":
+		n, ok := value.(float64)
+		return value, ok && n == math.Trunc(n)
+	case "float32", "float64":
+		_, ok := value.(float64)
+		return value, ok
+	case "metav1.Time":
+		str, ok := value.(string)
+		if !ok {
+			return nil, false
+		}
+		_, err := time.Parse(time.RFC3339, str)
+		return value, err == nil
+	}
+	return nil, false
 }
 
 func (g *ControllerGenerator) generateIntegrationTest(outputDir string, crd *mapper.CRDDefinition) error {
@@ -726,7 +1787,7 @@ func (g *ControllerGenerator) generateIntegrationTest(outputDir string, crd *map
 		Year:            time.Now().Year(),
 		APIGroup:        crd.APIGroup,
 		APIVersion:      crd.APIVersion,
-		ModuleName:      g.config.ModuleName,
+		ModuleName:      g.config.ResolvedImportPrefix(),
 		Kind:            crd.Kind,
 		KindLower:       strings.ToLower(crd.Kind),
 		Plural:          crd.Plural,
@@ -750,6 +1811,9 @@ func (g *ControllerGenerator) generateIntegrationTest(outputDir string, crd *map
 		RequiredFields:    requiredFields,
 		HasRequiredFields: len(requiredFields) > 0,
 	}
+	if example := specExampleJSON(crd); example != "" {
+		data.SpecExample = goStringLiteral(example)
+	}
 
 	filename := fmt.Sprintf("%s_integration_test.go", strings.ToLower(crd.Kind))
 	fp := filepath.Join(outputDir, filename)
@@ -759,7 +1823,7 @@ func (g *ControllerGenerator) generateIntegrationTest(outputDir string, crd *map
 		return fmt.Errorf("failed to parse template: %w", err)
 	}
 
-	file, err := os.Create(fp)
+	file, err := g.files.Create(fp)
 	if err != nil {
 		return fmt.Errorf("failed to create file: %w", err)
 	}
@@ -773,8 +1837,8 @@ func (g *ControllerGenerator) generateIntegrationTest(outputDir string, crd *map
 }
 
 func (g *ControllerGenerator) generateMain(crds []*mapper.CRDDefinition, aggregate *mapper.AggregateDefinition, bundle *mapper.BundleDefinition) error {
-	cmdDir := filepath.Join(g.config.OutputDir, "cmd", "manager")
-	if err := os.MkdirAll(cmdDir, 0755); err != nil {
+	cmdDir := filepath.Join(g.config.OutputDir, filepath.Dir(filepath.FromSlash(g.config.MainPath())))
+	if err := g.files.MkdirAll(cmdDir, 0755); err != nil {
 		return fmt.Errorf("failed to create cmd directory: %w", err)
 	}
 
@@ -798,16 +1862,75 @@ func (g *ControllerGenerator) generateMain(crds []*mapper.CRDDefinition, aggrega
 		GeneratorVersion: g.config.GeneratorVersion,
 		APIVersion:       g.config.APIVersion,
 		APIGroup:         g.config.APIGroup,
-		ModuleName:       g.config.ModuleName,
+		ModuleName:       g.config.ResolvedImportPrefix(),
 		AppName:          strings.Split(g.config.APIGroup, ".")[0],
 		CRDs:             make([]CRDMainData, 0, len(crds)),
 		OperatorVersion:  operatorVersion,
 		CommitHash:       commitHash,
 		CommitTimestamp:  timestamp,
+
+		HTTPMaxIdleConns:    g.config.HTTPTransport.MaxIdleConns,
+		HTTPMaxConnsPerHost: g.config.HTTPTransport.MaxConnsPerHost,
+		HTTPIdleConnTimeout: durationLiteral(g.config.HTTPTransport.IdleConnTimeout),
+		HTTP2:               g.config.HTTPTransport.HTTP2Enabled(),
+
+		EnableTracing: g.config.EnableTracing,
+		EnablePprof:   g.config.EnablePprof,
+		PprofAddr:     g.config.PprofAddr,
+
+		SlowReconcileThreshold: durationLiteral(g.config.SlowReconcileThreshold),
+		KubeClientQPS:          g.config.KubeClientQPS,
+		KubeClientBurst:        g.config.KubeClientBurst,
+		PauseConfigMapRef:      g.config.PauseConfigMapRef,
+		Servers:                g.config.SpecServers,
+		ServerSelector:         g.config.ServerSelector,
+		WatchNamespaces:        strings.Join(g.config.WatchNamespaces, ","),
+		HighAvailability:       g.config.HighAvailability,
+		SupportDryRun:          g.config.SupportDryRun,
+		EmbeddedSpec:           g.embeddedSpec,
+		Kubebuilder:            g.config.KubebuilderLayout,
+		WebhookReceiver:        g.webhookReceiverEnabled(),
+	}
+	selectorFlag := "--server-selector"
+	if data.ServerSelector == "" && g.config.Environment != "" {
+		// The --environment server is the default, unless another one is selected
+		data.ServerSelector = g.config.Environment
+		selectorFlag = "--environment"
+	}
+	if data.ServerSelector != "" {
+		servers := make([]endpoint.Server, 0, len(data.Servers))
+		for _, s := range data.Servers {
+			servers = append(servers, endpoint.Server{Name: s.Name, Environment: s.Environment, URL: s.URL, Description: s.Description})
+		}
+		if _, err := endpoint.SelectServer(servers, data.ServerSelector); err != nil {
+			return fmt.Errorf("invalid %s: %w", selectorFlag, err)
+		}
+	}
+	if g.config.SlowReconcileThreshold == 0 {
+		data.SlowReconcileThreshold = durationLiteral(config.DefaultSlowReconcileThreshold)
+	}
+	if data.KubeClientQPS == 0 {
+		data.KubeClientQPS = config.DefaultKubeClientQPS
+	}
+	if data.KubeClientBurst == 0 {
+		data.KubeClientBurst = config.DefaultKubeClientBurst
+	}
+	if data.PprofAddr == "" {
+		data.PprofAddr = config.DefaultPprofAddr
+	}
+	if data.HTTPMaxIdleConns == 0 {
+		data.HTTPMaxIdleConns = config.DefaultHTTPMaxIdleConns
+	}
+	if g.config.HTTPTransport.IdleConnTimeout == 0 {
+		data.HTTPIdleConnTimeout = durationLiteral(config.DefaultHTTPIdleConnTimeout)
 	}
 
 	for _, crd := range crds {
-		data.CRDs = append(data.CRDs, CRDMainData{Kind: crd.Kind, IsQuery: crd.IsQuery, IsAction: crd.IsAction})
+		mainData := CRDMainData{Kind: crd.Kind, IsQuery: crd.IsQuery, IsAction: crd.IsAction, ControllerFile: g.controllerFileName(crd)}
+		if g.config.ControllerFileNaming == config.ControllerFileNamingOperationID {
+			mainData.OperationID = primaryOperationID(crd)
+		}
+		data.CRDs = append(data.CRDs, mainData)
 	}
 
 	// Add aggregate info if provided
@@ -829,7 +1952,7 @@ func (g *ControllerGenerator) generateMain(crds []*mapper.CRDDefinition, aggrega
 		return fmt.Errorf("failed to parse template: %w", err)
 	}
 
-	file, err := os.Create(filepath)
+	file, err := g.files.Create(filepath)
 	if err != nil {
 		return fmt.Errorf("failed to create file: %w", err)
 	}
@@ -843,6 +1966,15 @@ func (g *ControllerGenerator) generateMain(crds []*mapper.CRDDefinition, aggrega
 }
 
 func (g *ControllerGenerator) generateGoMod(hasAggregate bool, hasBundle bool) error {
+	content, err := g.renderGoMod(hasAggregate, hasBundle)
+	if err != nil {
+		return err
+	}
+	return g.files.WriteFile(filepath.Join(g.config.OutputDir, "go.mod"), content, 0644)
+}
+
+// renderGoMod renders the go.mod of the generated operator
+func (g *ControllerGenerator) renderGoMod(hasAggregate bool, hasBundle bool) ([]byte, error) {
 	// Determine the module version to use in go.mod require directive
 	// If version is a clean semver (vX.Y.Z), use it as-is
 	// Otherwise, construct a proper Go module pseudo-version
@@ -864,8 +1996,15 @@ func (g *ControllerGenerator) generateGoMod(hasAggregate bool, hasBundle bool) e
 		HasAggregate:     hasAggregate,
 		HasBundle:        hasBundle,
 	}
-	outputPath := filepath.Join(g.config.OutputDir, "go.mod")
-	return g.executeTemplate(templates.GoModTemplate, data, outputPath)
+	tmpl, err := template.New("gomod").Parse(templates.GoModTemplate)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse template: %w", err)
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return nil, fmt.Errorf("failed to execute template: %w", err)
+	}
+	return buf.Bytes(), nil
 }
 
 // buildPseudoVersion constructs a Go module pseudo-version from config fields.
@@ -905,6 +2044,14 @@ func (g *ControllerGenerator) buildPseudoVersion() string {
 	return fmt.Sprintf("%s-0.%s-%s", incrementedVersion, normalizedTS, commitHash)
 }
 
+// durationLiteral renders a duration as a Go expression for use in generated code
+func durationLiteral(d time.Duration) string {
+	if d%time.Second == 0 {
+		return fmt.Sprintf("%d * time.Second", int64(d/time.Second))
+	}
+	return fmt.Sprintf("%d * time.Millisecond", int64(d/time.Millisecond))
+}
+
 // normalizeTimestamp converts a timestamp to YYYYMMDDHHMMSS format.
 // Handles ISO format (2026-01-15T20:35:56Z) or passes through if already in target format.
 func normalizeTimestamp(ts string) string {
@@ -1053,8 +2200,26 @@ func extractBaseSemver(version string) string {
 func (g *ControllerGenerator) generateDockerfile() error {
 	data := struct {
 		GeneratorVersion string
+		BaseImage        string
+		RuntimeImage     string
+		// RunAsNonRoot pins the image user to the UID the manager Deployment expects
+		RunAsNonRoot bool
+		// EmbeddedSpec adds the embedded spec to the build context
+		EmbeddedSpec *EmbeddedSpec
+		MainPath     string
 	}{
 		GeneratorVersion: g.config.GeneratorVersion,
+		BaseImage:        g.config.BaseImage,
+		RuntimeImage:     g.config.RuntimeImage,
+		RunAsNonRoot:     !g.config.SecurityContext.AllowRunAsRoot,
+		EmbeddedSpec:     g.embeddedSpec,
+		MainPath:         g.config.MainPath(),
+	}
+	if data.BaseImage == "" {
+		data.BaseImage = config.DefaultBaseImage
+	}
+	if data.RuntimeImage == "" {
+		data.RuntimeImage = config.DefaultRuntimeImage
 	}
 	outputPath := filepath.Join(g.config.OutputDir, "Dockerfile")
 	return g.executeTemplate(templates.DockerfileTemplate, data, outputPath)
@@ -1064,14 +2229,29 @@ func (g *ControllerGenerator) generateMakefile() error {
 	data := struct {
 		AppName          string
 		GeneratorVersion string
+		MainPath         string
+		HasE2E           bool
 	}{
 		AppName:          strings.Split(g.config.APIGroup, ".")[0],
 		GeneratorVersion: g.config.GeneratorVersion,
+		MainPath:         g.config.MainPath(),
+		HasE2E:           g.config.GenerateE2E,
 	}
 	outputPath := filepath.Join(g.config.OutputDir, "Makefile")
 	return g.executeTemplate(templates.MakefileTemplate, data, outputPath)
 }
 
+// generateGolangciConfig writes .golangci.yml, tuned to the generated code (make lint)
+func (g *ControllerGenerator) generateGolangciConfig() error {
+	data := struct {
+		GeneratorVersion string
+	}{
+		GeneratorVersion: g.config.GeneratorVersion,
+	}
+	outputPath := filepath.Join(g.config.OutputDir, ".golangci.yml")
+	return g.executeTemplate(templates.GolangciTemplate, data, outputPath)
+}
+
 func (g *ControllerGenerator) generateReadme(crds []*mapper.CRDDefinition, hasAggregate bool, hasBundle bool) error {
 	// Build CRD info for template
 	type CRDInfo struct {
@@ -1259,7 +2439,7 @@ func (g *ControllerGenerator) generateCopilotInstructions(crds []*mapper.CRDDefi
 	}
 
 	githubDir := filepath.Join(g.config.OutputDir, ".github")
-	if err := os.MkdirAll(githubDir, 0755); err != nil {
+	if err := g.files.MkdirAll(githubDir, 0755); err != nil {
 		return fmt.Errorf("failed to create .github directory: %w", err)
 	}
 	outputPath := filepath.Join(githubDir, "copilot-instructions.md")
@@ -1268,7 +2448,7 @@ func (g *ControllerGenerator) generateCopilotInstructions(crds []*mapper.CRDDefi
 
 func (g *ControllerGenerator) generateBoilerplate() error {
 	hackDir := filepath.Join(g.config.OutputDir, "hack")
-	if err := os.MkdirAll(hackDir, 0755); err != nil {
+	if err := g.files.MkdirAll(hackDir, 0755); err != nil {
 		return fmt.Errorf("failed to create hack directory: %w", err)
 	}
 
@@ -1283,29 +2463,102 @@ func (g *ControllerGenerator) generateBoilerplate() error {
 	return g.executeTemplate(templates.BoilerplateTemplate, data, outputPath)
 }
 
+// generateRBACAuditScript writes hack/rbac-audit.sh, which shows the manager service
+// account's effective permissions (make rbac-audit)
+func (g *ControllerGenerator) generateRBACAuditScript() error {
+	hackDir := filepath.Join(g.config.OutputDir, "hack")
+	if err := g.files.MkdirAll(hackDir, 0755); err != nil {
+		return fmt.Errorf("failed to create hack directory: %w", err)
+	}
+
+	tmpl, err := template.New("rbac-audit").Parse(templates.RBACAuditScriptTemplate)
+	if err != nil {
+		return fmt.Errorf("failed to parse template: %w", err)
+	}
+
+	data := struct {
+		Namespace        string
+		GeneratorVersion string
+	}{
+		Namespace:        strings.Split(g.config.APIGroup, ".")[0] + "-system",
+		GeneratorVersion: g.config.GeneratorVersion,
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return fmt.Errorf("failed to execute template: %w", err)
+	}
+	return g.files.WriteFile(filepath.Join(hackDir, "rbac-audit.sh"), buf.Bytes(), 0755)
+}
+
 // DeploymentManifestData holds data for generating deployment YAML manifests
 type DeploymentManifestData struct {
 	Namespace        string
 	AppName          string
 	GeneratorVersion string
+	// WebhookPatches wires the crd, certmanager and webhook kustomizations into config/kustomization.yaml
+	WebhookPatches bool
+	// WebhookReceiver adds the Service of the manager's webhook receiver (config/webhook-receiver)
+	WebhookReceiver bool
+	// Manager securityContext, hardened unless relaxed via config.SecurityContext
+	RunAsNonRoot           bool
+	ReadOnlyRootFilesystem bool
+	AddCapabilities        []string
+	SeccompProfile         string
+	// HighAvailability adds a PodDisruptionBudget and pod anti-affinity to the replicas
+	HighAvailability bool
+	Replicas         int
+	MinAvailable     int
+	// PriorityClassName is set on the manager pods; GeneratePriorityClass also creates it
+	PriorityClassName     string
+	GeneratePriorityClass bool
+	// WatchNamespaces are the namespaces the operator watches by default; each gets a
+	// RoleBinding in namespaced_role_binding.yaml
+	WatchNamespaces []string
+	// SecuritySchemes are the security schemes of all CRDs, whose credential environment
+	// variables the manager Deployment lists
+	SecuritySchemes []mapper.SecurityScheme
 }
 
-func (g *ControllerGenerator) generateDeploymentManifests() error {
+func (g *ControllerGenerator) generateDeploymentManifests(crds []*mapper.CRDDefinition, aggregate *mapper.AggregateDefinition, bundle *mapper.BundleDefinition) error {
 	// Derive namespace from API group (e.g., petstore.example.com -> petstore-system)
 	data := DeploymentManifestData{
 		Namespace:        strings.Split(g.config.APIGroup, ".")[0] + "-system",
 		AppName:          strings.Split(g.config.APIGroup, ".")[0],
 		GeneratorVersion: g.config.GeneratorVersion,
+		WebhookPatches:   g.config.GenerateWebhookPatches,
+		WebhookReceiver:  g.webhookReceiverEnabled(),
+
+		RunAsNonRoot:           !g.config.SecurityContext.AllowRunAsRoot,
+		ReadOnlyRootFilesystem: !g.config.SecurityContext.WritableRootFilesystem,
+		AddCapabilities:        g.config.SecurityContext.AddCapabilities,
+		SeccompProfile:         g.config.SecurityContext.SeccompProfile,
+
+		HighAvailability:  g.config.HighAvailability,
+		Replicas:          1,
+		PriorityClassName: g.config.PriorityClassName,
+		WatchNamespaces:   g.config.WatchNamespaces,
+		SecuritySchemes:   crdSecuritySchemes(crds),
+	}
+	if data.SeccompProfile == "" {
+		data.SeccompProfile = config.DefaultSeccompProfile
+	}
+	if data.HighAvailability {
+		data.Replicas = config.HAReplicas
+		data.MinAvailable = config.HAReplicas - 1
+		if data.PriorityClassName == "" {
+			data.PriorityClassName = data.AppName + "-controller-manager"
+			data.GeneratePriorityClass = true
+		}
 	}
 
 	// Create config directories
 	managerDir := filepath.Join(g.config.OutputDir, "config", "manager")
-	if err := os.MkdirAll(managerDir, 0755); err != nil {
+	if err := g.files.MkdirAll(managerDir, 0755); err != nil {
 		return fmt.Errorf("failed to create manager directory: %w", err)
 	}
 
 	rbacDir := filepath.Join(g.config.OutputDir, "config", "rbac")
-	if err := os.MkdirAll(rbacDir, 0755); err != nil {
+	if err := g.files.MkdirAll(rbacDir, 0755); err != nil {
 		return fmt.Errorf("failed to create rbac directory: %w", err)
 	}
 
@@ -1327,6 +2580,14 @@ func (g *ControllerGenerator) generateDeploymentManifests() error {
 		return fmt.Errorf("failed to generate role_binding.yaml: %w", err)
 	}
 
+	if len(data.WatchNamespaces) > 0 {
+		// Generate config/rbac/namespaced_role_binding.yaml (RoleBindings limited to the watched namespaces)
+		if err := g.executeTemplate(templates.NamespacedRoleBindingYAMLTemplate, data,
+			filepath.Join(rbacDir, "namespaced_role_binding.yaml")); err != nil {
+			return fmt.Errorf("failed to generate namespaced_role_binding.yaml: %w", err)
+		}
+	}
+
 	// Generate config/rbac/leader_election_role.yaml
 	if err := g.executeTemplate(templates.LeaderElectionRoleTemplate, data,
 		filepath.Join(rbacDir, "leader_election_role.yaml")); err != nil {
@@ -1369,6 +2630,21 @@ func (g *ControllerGenerator) generateDeploymentManifests() error {
 		return fmt.Errorf("failed to generate manager.yaml: %w", err)
 	}
 
+	if data.HighAvailability {
+		// Generate config/manager/pdb.yaml (PodDisruptionBudget)
+		if err := g.executeTemplate(templates.PDBYAMLTemplate, data,
+			filepath.Join(managerDir, "pdb.yaml")); err != nil {
+			return fmt.Errorf("failed to generate pdb.yaml: %w", err)
+		}
+	}
+	if data.GeneratePriorityClass {
+		// Generate config/manager/priority_class.yaml
+		if err := g.executeTemplate(templates.PriorityClassYAMLTemplate, data,
+			filepath.Join(managerDir, "priority_class.yaml")); err != nil {
+			return fmt.Errorf("failed to generate priority_class.yaml: %w", err)
+		}
+	}
+
 	// Generate config/manager/kustomization.yaml
 	if err := g.executeTemplate(templates.KustomizationManagerTemplate, data,
 		filepath.Join(managerDir, "kustomization.yaml")); err != nil {
@@ -1383,7 +2659,7 @@ func (g *ControllerGenerator) generateDeploymentManifests() error {
 
 	// Create config directory
 	defaultDir := filepath.Join(g.config.OutputDir, "config")
-	if err := os.MkdirAll(defaultDir, 0755); err != nil {
+	if err := g.files.MkdirAll(defaultDir, 0755); err != nil {
 		return fmt.Errorf("failed to create default directory: %w", err)
 	}
 
@@ -1393,6 +2669,109 @@ func (g *ControllerGenerator) generateDeploymentManifests() error {
 		return fmt.Errorf("failed to generate default kustomization.yaml: %w", err)
 	}
 
+	if g.config.GenerateWebhookPatches {
+		plurals := make([]string, 0, len(crds)+2)
+		for _, crd := range crds {
+			plurals = append(plurals, crd.Plural)
+		}
+		if aggregate != nil {
+			plurals = append(plurals, aggregate.Plural)
+		}
+		if bundle != nil {
+			plurals = append(plurals, bundle.Plural)
+		}
+		if err := g.generateWebhookPatches(data, plurals); err != nil {
+			return err
+		}
+	}
+	if data.WebhookReceiver {
+		if err := g.generateWebhookReceiverService(data); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// webhookPatchData holds data for the per-CRD conversion webhook and CA injection patches
+type webhookPatchData struct {
+	GeneratorVersion string
+	Namespace        string
+	APIGroup         string
+	Plural           string
+}
+
+// generateWebhookPatches generates the kubebuilder-style scaffolding for a conversion webhook
+// with cert-manager CA injection: a webhook_in_<plural>.yaml and cainjection_in_<plural>.yaml
+// patch per CRD, config/crd/kustomization.yaml applying them to the bases, the cert-manager
+// Issuer/Certificate, the webhook Service and the manager patch mounting the serving cert.
+func (g *ControllerGenerator) generateWebhookPatches(data DeploymentManifestData, plurals []string) error {
+	configDir := filepath.Join(g.config.OutputDir, "config")
+	patchesDir := filepath.Join(configDir, "crd", "patches")
+	certManagerDir := filepath.Join(configDir, "certmanager")
+	webhookDir := filepath.Join(configDir, "webhook")
+	for _, dir := range []string{patchesDir, certManagerDir, webhookDir} {
+		if err := g.files.MkdirAll(dir, 0755); err != nil {
+			return fmt.Errorf("failed to create %s directory: %w", dir, err)
+		}
+	}
+
+	for _, plural := range plurals {
+		patchData := webhookPatchData{
+			GeneratorVersion: g.config.GeneratorVersion,
+			Namespace:        data.Namespace,
+			APIGroup:         g.config.APIGroup,
+			Plural:           plural,
+		}
+		if err := g.executeTemplate(templates.CRDWebhookPatchTemplate, patchData,
+			filepath.Join(patchesDir, "webhook_in_"+plural+".yaml")); err != nil {
+			return fmt.Errorf("failed to generate webhook_in_%s.yaml: %w", plural, err)
+		}
+		if err := g.executeTemplate(templates.CRDCAInjectionPatchTemplate, patchData,
+			filepath.Join(patchesDir, "cainjection_in_"+plural+".yaml")); err != nil {
+			return fmt.Errorf("failed to generate cainjection_in_%s.yaml: %w", plural, err)
+		}
+	}
+
+	// Generate config/crd/kustomization.yaml (bases plus patches)
+	kustomizationData := struct {
+		GeneratorVersion string
+		Plurals          []string
+	}{
+		GeneratorVersion: g.config.GeneratorVersion,
+		Plurals:          plurals,
+	}
+	if err := g.executeTemplate(templates.KustomizationCRDPatchesTemplate, kustomizationData,
+		filepath.Join(configDir, "crd", "kustomization.yaml")); err != nil {
+		return fmt.Errorf("failed to generate crd kustomization.yaml: %w", err)
+	}
+
+	// Generate config/certmanager (self-signed Issuer and serving Certificate)
+	if err := g.executeTemplate(templates.CertificateTemplate, data,
+		filepath.Join(certManagerDir, "certificate.yaml")); err != nil {
+		return fmt.Errorf("failed to generate certificate.yaml: %w", err)
+	}
+	if err := g.executeTemplate(templates.KustomizationCertManagerTemplate, data,
+		filepath.Join(certManagerDir, "kustomization.yaml")); err != nil {
+		return fmt.Errorf("failed to generate certmanager kustomization.yaml: %w", err)
+	}
+
+	// Generate config/webhook (Service in front of the manager's webhook server)
+	if err := g.executeTemplate(templates.WebhookServiceTemplate, data,
+		filepath.Join(webhookDir, "service.yaml")); err != nil {
+		return fmt.Errorf("failed to generate webhook service.yaml: %w", err)
+	}
+	if err := g.executeTemplate(templates.KustomizationWebhookTemplate, data,
+		filepath.Join(webhookDir, "kustomization.yaml")); err != nil {
+		return fmt.Errorf("failed to generate webhook kustomization.yaml: %w", err)
+	}
+
+	// Generate config/manager_webhook_patch.yaml (webhook port and cert volume)
+	if err := g.executeTemplate(templates.ManagerWebhookPatchTemplate, data,
+		filepath.Join(configDir, "manager_webhook_patch.yaml")); err != nil {
+		return fmt.Errorf("failed to generate manager_webhook_patch.yaml: %w", err)
+	}
+
 	return nil
 }
 
@@ -1411,7 +2790,7 @@ type targetAPITemplateData struct {
 }
 
 // resolveTargetAPIData computes the shared template data used by both
-// GenerateTargetAPIDeployment and GenerateDockerCompose.
+// GenerateTargetAPIDeployment, GenerateDockerCompose and GenerateTilt.
 func (g *ControllerGenerator) resolveTargetAPIData() targetAPITemplateData {
 	appName := strings.Split(g.config.APIGroup, ".")[0]
 	namespace := appName + "-system"
@@ -1457,7 +2836,7 @@ func (g *ControllerGenerator) GenerateTargetAPIDeployment() error {
 	data := g.resolveTargetAPIData()
 
 	targetAPIDir := filepath.Join(g.config.OutputDir, "config", "target-api")
-	if err := os.MkdirAll(targetAPIDir, 0755); err != nil {
+	if err := g.files.MkdirAll(targetAPIDir, 0755); err != nil {
 		return fmt.Errorf("failed to create target-api directory: %w", err)
 	}
 
@@ -1474,6 +2853,111 @@ func (g *ControllerGenerator) GenerateDockerCompose() error {
 		filepath.Join(g.config.OutputDir, "docker-compose.yaml"))
 }
 
+// GenerateTilt generates a Tiltfile for a live-reload development loop.
+// This is only called when --tilt is provided.
+func (g *ControllerGenerator) GenerateTilt() error {
+	data := g.resolveTargetAPIData()
+
+	return g.executeTemplate(templates.TiltfileTemplate, data,
+		filepath.Join(g.config.OutputDir, "Tiltfile"))
+}
+
+// e2eTemplateData is the template data of the generated end-to-end test
+type e2eTemplateData struct {
+	targetAPITemplateData
+	Year int
+	// SampleFile is the sample CR the test waits on, relative to the output directory
+	SampleFile string
+	// SampleResource is the sample as a kubectl resource, <plural>.<group>/<name>
+	SampleResource  string
+	SampleNamespace string
+	// ReadyCondition is the condition the test waits for on the sample
+	ReadyCondition string
+}
+
+// GenerateE2E generates test/e2e/e2e_test.go, an end-to-end test behind the e2e build tag
+// that deploys the operator to a kind cluster and waits for a sample CR to become Ready.
+// The sample is the first resource CRD's, falling back to the first CRD's.
+// This is only called when --e2e is provided.
+func (g *ControllerGenerator) GenerateE2E(crds []*mapper.CRDDefinition) error {
+	if len(crds) == 0 {
+		return fmt.Errorf("no CRDs to test")
+	}
+	sample := crds[0]
+	for _, crd := range crds {
+		if !crd.IsQuery && !crd.IsAction {
+			sample = crd
+			break
+		}
+	}
+	kindLower := strings.ToLower(sample.Kind)
+	data := e2eTemplateData{
+		targetAPITemplateData: g.resolveTargetAPIData(),
+		Year:                  time.Now().Year(),
+		SampleFile:            filepath.ToSlash(filepath.Join("config", "samples", fmt.Sprintf("%s_%s.yaml", g.config.APIVersion, kindLower))),
+		SampleResource:        fmt.Sprintf("%s.%s/%s-sample", sample.Plural, sample.APIGroup, kindLower),
+		SampleNamespace:       g.config.ResolvedSampleNamespace(),
+		ReadyCondition:        g.config.ConditionType(config.ConditionReady),
+	}
+
+	e2eDir := filepath.Join(g.config.OutputDir, "test", "e2e")
+	if err := g.files.MkdirAll(e2eDir, 0755); err != nil {
+		return fmt.Errorf("failed to create test/e2e directory: %w", err)
+	}
+	return g.executeTemplate(templates.E2ETestTemplate, data, filepath.Join(e2eDir, "e2e_test.go"))
+}
+
+// GenerateDashboard generates a Grafana dashboard (dashboards/<app>.json) whose panel queries
+// use the metric names and instrumentation scopes of the generated controllers. It returns
+// the path of the dashboard relative to the output directory.
+func (g *ControllerGenerator) GenerateDashboard(crds []*mapper.CRDDefinition, aggregate *mapper.AggregateDefinition, bundle *mapper.BundleDefinition) (string, error) {
+	appName := strings.Split(g.config.APIGroup, ".")[0]
+	titleAppName := appName
+	if len(appName) > 0 {
+		titleAppName = strings.ToUpper(appName[:1]) + appName[1:]
+	}
+
+	var kinds, plurals []string
+	for _, crd := range crds {
+		kinds = append(kinds, strings.ToLower(crd.Kind))
+		plurals = append(plurals, crd.Plural)
+	}
+	if aggregate != nil {
+		kinds = append(kinds, strings.ToLower(aggregate.Kind))
+		plurals = append(plurals, aggregate.Plural)
+	}
+	if bundle != nil {
+		kinds = append(kinds, strings.ToLower(bundle.Kind))
+		plurals = append(plurals, bundle.Plural)
+	}
+
+	data := struct {
+		GeneratorVersion string
+		AppName          string
+		Title            string
+		UID              string
+		ScopePrefix      string // Instrumentation scope of the controllers' meters, up to the kind
+		KindsQuery       string // Options of the kind variable (comma-separated)
+		ResourcesRegex   string // Matches the CRDs in apiserver_storage_objects
+	}{
+		GeneratorVersion: g.config.GeneratorVersion,
+		AppName:          appName,
+		Title:            titleAppName + " Operator",
+		UID:              appName + "-operator",
+		ScopePrefix:      g.config.ResolvedImportPrefix() + "/controller/",
+		KindsQuery:       strings.Join(kinds, ","),
+		ResourcesRegex:   "(" + strings.Join(plurals, "|") + ")." + g.config.APIGroup,
+	}
+
+	dashboardDir := filepath.Join(g.config.OutputDir, "dashboards")
+	if err := g.files.MkdirAll(dashboardDir, 0755); err != nil {
+		return "", fmt.Errorf("failed to create dashboards directory: %w", err)
+	}
+	relPath := filepath.Join("dashboards", appName+".json")
+	return relPath, g.executeTemplate(templates.GrafanaDashboardTemplate, data,
+		filepath.Join(g.config.OutputDir, relPath))
+}
+
 func (g *ControllerGenerator) executeTemplate(tmplContent string, data interface{}, outputPath string) error {
 	tmpl, err := template.New("yaml").Parse(tmplContent)
 	if err != nil {
@@ -1485,7 +2969,7 @@ func (g *ControllerGenerator) executeTemplate(tmplContent string, data interface
 		return fmt.Errorf("failed to execute template: %w", err)
 	}
 
-	return os.WriteFile(outputPath, buf.Bytes(), 0644)
+	return g.files.WriteFile(outputPath, buf.Bytes(), 0644)
 }
 
 // AggregateControllerTemplateData holds data for aggregate controller template
@@ -1502,12 +2986,19 @@ type AggregateControllerTemplateData struct {
 	QueryKinds       []string // Query CRD kinds
 	ActionKinds      []string // Action CRD kinds
 	AllKinds         []string // All kinds combined
+	// ResourceNames maps Kinds with an explicit plural to it, registered with
+	// aggregate.RegisterResourceNames by the generated controller
+	ResourceNames map[string]string
+	// StatusSubresource is false with --no-status-subresource (full object updates)
+	StatusSubresource bool
+	// Conditions are the condition types the controller sets
+	Conditions ConditionTypeNames
 }
 
 // GenerateAggregateController generates the aggregate controller
 func (g *ControllerGenerator) GenerateAggregateController(aggregate *mapper.AggregateDefinition) error {
 	controllerDir := filepath.Join(g.config.OutputDir, "internal", "controller")
-	if err := os.MkdirAll(controllerDir, 0755); err != nil {
+	if err := g.files.MkdirAll(controllerDir, 0755); err != nil {
 		return fmt.Errorf("failed to create controller directory: %w", err)
 	}
 
@@ -1516,7 +3007,7 @@ func (g *ControllerGenerator) GenerateAggregateController(aggregate *mapper.Aggr
 		GeneratorVersion: g.config.GeneratorVersion,
 		APIGroup:         aggregate.APIGroup,
 		APIVersion:       aggregate.APIVersion,
-		ModuleName:       g.config.ModuleName,
+		ModuleName:       g.config.ResolvedImportPrefix(),
 		Kind:             aggregate.Kind,
 		KindLower:        strings.ToLower(aggregate.Kind),
 		Plural:           aggregate.Plural,
@@ -1524,6 +3015,10 @@ func (g *ControllerGenerator) GenerateAggregateController(aggregate *mapper.Aggr
 		QueryKinds:       aggregate.QueryKinds,
 		ActionKinds:      aggregate.ActionKinds,
 		AllKinds:         aggregate.AllKinds,
+		ResourceNames:    aggregate.ResourceNames,
+
+		StatusSubresource: !g.config.NoStatusSubresource,
+		Conditions:        g.conditionTypeNames(),
 	}
 
 	filename := fmt.Sprintf("%s_controller.go", strings.ToLower(aggregate.Kind))
@@ -1531,13 +3026,13 @@ func (g *ControllerGenerator) GenerateAggregateController(aggregate *mapper.Aggr
 
 	tmpl, err := template.New("aggregate_controller").Funcs(template.FuncMap{
 		"lower":     strings.ToLower,
-		"pluralize": pluralize,
+		"pluralize": pluralizeWith(aggregate.ResourceNames),
 	}).Parse(templates.AggregateControllerTemplate)
 	if err != nil {
 		return fmt.Errorf("failed to parse template: %w", err)
 	}
 
-	file, err := os.Create(fp)
+	file, err := g.files.Create(fp)
 	if err != nil {
 		return fmt.Errorf("failed to create file: %w", err)
 	}
@@ -1564,12 +3059,21 @@ type BundleControllerTemplateData struct {
 	QueryKinds       []string // Query CRD kinds
 	ActionKinds      []string // Action CRD kinds
 	AllKinds         []string // All kinds combined
+	BundleAdopt      bool     // Adopt pre-existing children without a controller owner by default
+	ChildKind        string   // Resource kind used by the generated ownership tests
+	// ResourceNames maps Kinds with an explicit plural to it, registered with
+	// aggregate.RegisterResourceNames by the generated controller
+	ResourceNames map[string]string
+	// StatusSubresource is false with --no-status-subresource (full object updates)
+	StatusSubresource bool
+	// Conditions are the condition types the controller sets
+	Conditions ConditionTypeNames
 }
 
 // GenerateBundleController generates the bundle controller
 func (g *ControllerGenerator) GenerateBundleController(bundle *mapper.BundleDefinition) error {
 	controllerDir := filepath.Join(g.config.OutputDir, "internal", "controller")
-	if err := os.MkdirAll(controllerDir, 0755); err != nil {
+	if err := g.files.MkdirAll(controllerDir, 0755); err != nil {
 		return fmt.Errorf("failed to create controller directory: %w", err)
 	}
 
@@ -1578,7 +3082,7 @@ func (g *ControllerGenerator) GenerateBundleController(bundle *mapper.BundleDefi
 		GeneratorVersion: g.config.GeneratorVersion,
 		APIGroup:         bundle.APIGroup,
 		APIVersion:       bundle.APIVersion,
-		ModuleName:       g.config.ModuleName,
+		ModuleName:       g.config.ResolvedImportPrefix(),
 		Kind:             bundle.Kind,
 		KindLower:        strings.ToLower(bundle.Kind),
 		Plural:           bundle.Plural,
@@ -1586,6 +3090,11 @@ func (g *ControllerGenerator) GenerateBundleController(bundle *mapper.BundleDefi
 		QueryKinds:       bundle.QueryKinds,
 		ActionKinds:      bundle.ActionKinds,
 		AllKinds:         bundle.AllKinds,
+		BundleAdopt:      g.config.BundleAdopt,
+		ResourceNames:    bundle.ResourceNames,
+
+		StatusSubresource: !g.config.NoStatusSubresource,
+		Conditions:        g.conditionTypeNames(),
 	}
 
 	filename := fmt.Sprintf("%s_controller.go", strings.ToLower(bundle.Kind))
@@ -1593,13 +3102,13 @@ func (g *ControllerGenerator) GenerateBundleController(bundle *mapper.BundleDefi
 
 	tmpl, err := template.New("bundle_controller").Funcs(template.FuncMap{
 		"lower":     strings.ToLower,
-		"pluralize": pluralize,
+		"pluralize": pluralizeWith(bundle.ResourceNames),
 	}).Parse(templates.BundleControllerTemplate)
 	if err != nil {
 		return fmt.Errorf("failed to parse template: %w", err)
 	}
 
-	file, err := os.Create(fp)
+	file, err := g.files.Create(fp)
 	if err != nil {
 		return fmt.Errorf("failed to create file: %w", err)
 	}
@@ -1609,6 +3118,25 @@ func (g *ControllerGenerator) GenerateBundleController(bundle *mapper.BundleDefi
 		return fmt.Errorf("failed to execute template: %w", err)
 	}
 
+	// Ownership tests sync a CRUD child, so they need at least one resource kind
+	if len(bundle.ResourceKinds) == 0 {
+		return nil
+	}
+	data.ChildKind = bundle.ResourceKinds[0]
+	testTmpl, err := template.New("bundle_controller_test").Parse(templates.BundleControllerTestTemplate)
+	if err != nil {
+		return fmt.Errorf("failed to parse test template: %w", err)
+	}
+	testFile, err := g.files.Create(filepath.Join(controllerDir, fmt.Sprintf("%s_controller_test.go", strings.ToLower(bundle.Kind))))
+	if err != nil {
+		return fmt.Errorf("failed to create test file: %w", err)
+	}
+	defer testFile.Close()
+
+	if err := testTmpl.Execute(testFile, data); err != nil {
+		return fmt.Errorf("failed to execute test template: %w", err)
+	}
+
 	return nil
 }
 
@@ -1622,7 +3150,7 @@ type CELTestTemplateData struct {
 // GenerateCELTest generates the CEL expression unit test file
 func (g *ControllerGenerator) GenerateCELTest(allKinds []string) error {
 	controllerDir := filepath.Join(g.config.OutputDir, "internal", "controller")
-	if err := os.MkdirAll(controllerDir, 0755); err != nil {
+	if err := g.files.MkdirAll(controllerDir, 0755); err != nil {
 		return fmt.Errorf("failed to create controller directory: %w", err)
 	}
 
@@ -1642,7 +3170,7 @@ func (g *ControllerGenerator) GenerateCELTest(allKinds []string) error {
 		return fmt.Errorf("failed to parse template: %w", err)
 	}
 
-	file, err := os.Create(fp)
+	file, err := g.files.Create(fp)
 	if err != nil {
 		return fmt.Errorf("failed to create file: %w", err)
 	}
@@ -1671,7 +3199,7 @@ type CELTestDataTemplateData struct {
 // GenerateCELTestData generates the CEL test data JSON file and README
 func (g *ControllerGenerator) GenerateCELTestData(resourceKinds, queryKinds, actionKinds, allKinds []string, aggregateKind, bundleKind string, crds []*mapper.CRDDefinition) error {
 	testdataDir := filepath.Join(g.config.OutputDir, "testdata")
-	if err := os.MkdirAll(testdataDir, 0755); err != nil {
+	if err := g.files.MkdirAll(testdataDir, 0755); err != nil {
 		return fmt.Errorf("failed to create testdata directory: %w", err)
 	}
 
@@ -1691,7 +3219,7 @@ func (g *ControllerGenerator) GenerateCELTestData(resourceKinds, queryKinds, act
 
 	funcMap := template.FuncMap{
 		"lower":     strings.ToLower,
-		"pluralize": pluralize,
+		"pluralize": pluralizeWith(mapper.CustomPlurals(crds)),
 		"add": func(a, b int) int {
 			return a + b
 		},
@@ -1703,7 +3231,7 @@ func (g *ControllerGenerator) GenerateCELTestData(resourceKinds, queryKinds, act
 		return fmt.Errorf("failed to parse CEL test data template: %w", err)
 	}
 
-	jsonFile, err := os.Create(filepath.Join(testdataDir, "cel-test-data.json"))
+	jsonFile, err := g.files.Create(filepath.Join(testdataDir, "cel-test-data.json"))
 	if err != nil {
 		return fmt.Errorf("failed to create cel-test-data.json: %w", err)
 	}
@@ -1719,7 +3247,7 @@ func (g *ControllerGenerator) GenerateCELTestData(resourceKinds, queryKinds, act
 		return fmt.Errorf("failed to parse CEL test data README template: %w", err)
 	}
 
-	readmeFile, err := os.Create(filepath.Join(testdataDir, "README.md"))
+	readmeFile, err := g.files.Create(filepath.Join(testdataDir, "README.md"))
 	if err != nil {
 		return fmt.Errorf("failed to create testdata README.md: %w", err)
 	}
@@ -1826,7 +3354,7 @@ func (g *ControllerGenerator) generateExampleResourcesCRs(testdataDir string, cr
 	}
 
 	examplePath := filepath.Join(testdataDir, "resources.yaml")
-	file, err := os.Create(examplePath)
+	file, err := g.files.Create(examplePath)
 	if err != nil {
 		return fmt.Errorf("failed to create file: %w", err)
 	}
@@ -1883,6 +3411,9 @@ func (g *ControllerGenerator) generateExampleValueWithSeed(f *mapper.FieldDefini
 
 	switch goType {
 	case "string":
+		if example, ok := mapper.FormatExample(f.Format); ok {
+			return fmt.Sprintf("%q", example)
+		}
 		return fmt.Sprintf("%q", fmt.Sprintf("example-%s-%d", f.JSONName, seed))
 	case "int", "int32", "int64":
 		return fmt.Sprintf("%d", seed*100+seed)
@@ -1910,9 +3441,9 @@ func (g *ControllerGenerator) generateExampleValueWithSeed(f *mapper.FieldDefini
 	}
 }
 
-// copySpecFile copies the OpenAPI spec file to the output directory.
+// copySpecFile copies the OpenAPI spec file to destDir, normally the output directory.
 // If the spec is a URL, it downloads the content. If it's a local file, it copies it.
-func (g *ControllerGenerator) copySpecFile() error {
+func (g *ControllerGenerator) copySpecFile(destDir string) error {
 	specPath := g.config.SpecPath
 	if specPath == "" {
 		// No spec file path configured, skip copy
@@ -1935,21 +3466,15 @@ func (g *ControllerGenerator) copySpecFile() error {
 			destFilename = "openapi-spec.yaml"
 		}
 
-		// Download the file
-		resp, err := http.Get(specPath)
+		// Download the file, through the spec cache when one is configured
+		content, err = parser.ReadSpec(specPath, g.config.SpecCacheDir)
 		if err != nil {
-			return fmt.Errorf("failed to download spec from URL: %w", err)
-		}
-		defer resp.Body.Close()
-
-		if resp.StatusCode != http.StatusOK {
-			return fmt.Errorf("failed to download spec: HTTP %d", resp.StatusCode)
-		}
-
-		content, err = io.ReadAll(resp.Body)
-		if err != nil {
-			return fmt.Errorf("failed to read spec content: %w", err)
+			return fmt.Errorf("failed to download spec: %w", err)
 		}
+	} else if info, err := os.Stat(specPath); err == nil && info.IsDir() {
+		// Copy a split spec directory file by file, keeping its layout so the
+		// relative refs between the files still resolve
+		return g.copySpecDir(specPath, destDir)
 	} else {
 		// Copy local file
 		destFilename = filepath.Base(specPath)
@@ -1961,15 +3486,39 @@ func (g *ControllerGenerator) copySpecFile() error {
 		}
 	}
 
-	// Write to output directory
-	destPath := filepath.Join(g.config.OutputDir, destFilename)
-	if err := os.WriteFile(destPath, content, 0644); err != nil {
+	// Write to the destination directory
+	destPath := filepath.Join(destDir, destFilename)
+	if err := g.files.WriteFile(destPath, content, 0644); err != nil {
 		return fmt.Errorf("failed to write spec file: %w", err)
 	}
 
 	return nil
 }
 
+// copySpecDir copies the spec files of a split spec directory to a directory of the
+// same name in destDir.
+func (g *ControllerGenerator) copySpecDir(specDir, destDir string) error {
+	files, err := config.SpecDirFiles(specDir)
+	if err != nil {
+		return err
+	}
+	destDir = filepath.Join(destDir, filepath.Base(filepath.Clean(specDir)))
+	for _, rel := range files {
+		content, err := os.ReadFile(filepath.Join(specDir, rel))
+		if err != nil {
+			return fmt.Errorf("failed to read spec file: %w", err)
+		}
+		destPath := filepath.Join(destDir, rel)
+		if err := g.files.MkdirAll(filepath.Dir(destPath), 0755); err != nil {
+			return fmt.Errorf("failed to create spec directory: %w", err)
+		}
+		if err := g.files.WriteFile(destPath, content, 0644); err != nil {
+			return fmt.Errorf("failed to write spec file: %w", err)
+		}
+	}
+	return nil
+}
+
 // generateAggregateWithStatus generates an example aggregate CR with populated status for CEL testing
 func (g *ControllerGenerator) generateAggregateWithStatus(testdataDir, aggregateKind string, resourceKinds []string) error {
 	appName := strings.Split(g.config.APIGroup, ".")[0]
@@ -2010,7 +3559,7 @@ func (g *ControllerGenerator) generateAggregateWithStatus(testdataDir, aggregate
 	}
 
 	filePath := filepath.Join(testdataDir, "aggregate-with-status.yaml")
-	file, err := os.Create(filePath)
+	file, err := g.files.Create(filePath)
 	if err != nil {
 		return fmt.Errorf("failed to create file: %w", err)
 	}
@@ -2055,7 +3604,7 @@ func (g *ControllerGenerator) generateBundleWithStatus(testdataDir, bundleKind s
 	}
 
 	filePath := filepath.Join(testdataDir, "bundle-with-status.yaml")
-	file, err := os.Create(filePath)
+	file, err := g.files.Create(filePath)
 	if err != nil {
 		return fmt.Errorf("failed to create file: %w", err)
 	}
@@ -2098,7 +3647,7 @@ func (g *ControllerGenerator) generateAggregateCRTestdata(testdataDir, aggregate
 	}
 
 	filePath := filepath.Join(testdataDir, "aggregate.yaml")
-	file, err := os.Create(filePath)
+	file, err := g.files.Create(filePath)
 	if err != nil {
 		return fmt.Errorf("failed to create file: %w", err)
 	}
@@ -2141,7 +3690,7 @@ func (g *ControllerGenerator) generateBundleCRTestdata(testdataDir, bundleKind s
 	}
 
 	filePath := filepath.Join(testdataDir, "bundle.yaml")
-	file, err := os.Create(filePath)
+	file, err := g.files.Create(filePath)
 	if err != nil {
 		return fmt.Errorf("failed to create file: %w", err)
 	}
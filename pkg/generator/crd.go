@@ -1,12 +1,15 @@
 package generator
 
 import (
+	"bytes"
+	"encoding/json"
 	"fmt"
-	"os"
 	"path/filepath"
 	"strings"
 	"text/template"
 
+	"gopkg.in/yaml.v3"
+
 	"github.com/bluecontainer/openapi-operator-gen/internal/config"
 	"github.com/bluecontainer/openapi-operator-gen/pkg/mapper"
 	"github.com/bluecontainer/openapi-operator-gen/pkg/templates"
@@ -15,11 +18,55 @@ import (
 // CRDGenerator generates CRD YAML manifests
 type CRDGenerator struct {
 	config *config.Config
+	files  FileSink
+	// SizeWarnings lists the generated CRDs that are over, or close to, the size limits
+	// of kubectl apply and the API server (see CRDSizeProblem)
+	SizeWarnings []string
+}
+
+// ClientSideApplyLimit is the 262144-byte limit on a resource's annotations. Client-side
+// kubectl apply stores the whole manifest, as JSON, in the last-applied-configuration
+// annotation, so a larger CRD fails to apply unless --server-side is used.
+const ClientSideApplyLimit = 262144
+
+// maxObjectSize is etcd's default 1.5 MiB request limit, above which the API server
+// rejects a CRD however it is applied ("Request entity too large")
+const maxObjectSize = 1572864
+
+// CRDSizeFix suggests how to shrink a CRD reported by CRDSizeProblem
+const CRDSizeFix = "drop field descriptions by adding crd:maxDescLen=0 to the controller-gen crd command in the Makefile, " +
+	"mark large subtrees x-kubernetes-preserve-unknown-fields, or split the resource into several kinds; " +
+	"below 1.5 MiB, kubectl apply --server-side also works"
+
+// CRDSizeProblem estimates the size of the last-applied-configuration annotation kubectl
+// apply would store for a CRD manifest: the manifest as compact JSON. It describes the
+// problem when that is at least 80% of ClientSideApplyLimit, and reports whether a limit
+// is exceeded; it returns "" for a smaller or unparseable manifest.
+func CRDSizeProblem(name string, manifest []byte) (problem string, overLimit bool) {
+	var doc interface{}
+	if err := yaml.Unmarshal(manifest, &doc); err != nil || doc == nil {
+		return "", false
+	}
+	data, err := json.Marshal(doc)
+	if err != nil {
+		return "", false
+	}
+	size := len(data)
+	switch {
+	case size > maxObjectSize:
+		return fmt.Sprintf("%s is %d bytes as JSON, over the API server's 1.5 MiB request limit", name, size), true
+	case size > ClientSideApplyLimit:
+		return fmt.Sprintf("%s is %d bytes as JSON, over the %d-byte annotation limit of kubectl apply", name, size, ClientSideApplyLimit), true
+	case size >= ClientSideApplyLimit*8/10:
+		return fmt.Sprintf("%s is %d bytes as JSON, %d%% of the %d-byte annotation limit of kubectl apply",
+			name, size, size*100/ClientSideApplyLimit, ClientSideApplyLimit), false
+	}
+	return "", false
 }
 
 // NewCRDGenerator creates a new CRD generator
 func NewCRDGenerator(cfg *config.Config) *CRDGenerator {
-	return &CRDGenerator{config: cfg}
+	return &CRDGenerator{config: cfg, files: newFileSink(cfg)}
 }
 
 // CRDYAMLData holds data for CRD YAML template
@@ -32,7 +79,27 @@ type CRDYAMLData struct {
 	Plural           string
 	ShortNames       []string
 	Scope            string
+	UseETag          bool
+	StatusFields     []mapper.StatusField
 	Spec             *CRDSpecData
+	// ExcludedStatus are the status fields --exclude-status-fields drops, by JSON name
+	ExcludedStatus map[string]bool
+	// KindType is "resource", "query" or "action"
+	KindType string
+	// States are the allowed values of status.state
+	States []string
+	// TargetDefault is the default spec.target (x-k8s-target-default or --default-target)
+	TargetDefault []config.TargetDefaultEntry
+	// HasExtraHeaders adds spec.extraHeaders
+	HasExtraHeaders bool
+	// HasWriteOnlyFields adds status.writeOnlyGeneration
+	HasWriteOnlyFields bool
+	// StatusSubresource enables the status subresource (off with --no-status-subresource)
+	StatusSubresource bool
+	// SpecVersion is the spec's info.version, stamped as the spec-version annotation
+	SpecVersion string
+	// SpecVersionLabel is SpecVersion as an app.kubernetes.io/version label ("" if invalid)
+	SpecVersionLabel string
 }
 
 // CRDSpecData holds spec data for CRD YAML
@@ -47,12 +114,28 @@ type CRDFieldData struct {
 	SchemaType  string
 	Required    bool
 	Enum        []string
+	Immutable   bool
+	// MapValueType is the schema type of the values of a map field ("" for non-maps);
+	// "any" values are left schemaless with x-kubernetes-preserve-unknown-fields
+	MapValueType string
+	// KeyRule is the CEL rule requiring map keys to match the field's patternProperties
+	KeyRule        string
+	KeyRuleMessage string
+	// ValueRule is the CEL rule applying a scalar map field's value constraints
+	ValueRule        string
+	ValueRuleMessage string
+	// NotRule is the CEL rule rejecting values that satisfy a scalar field's not schema
+	NotRule        string
+	NotRuleMessage string
+	// AnyOfRule is the CEL rule requiring one anyOf member of a merged struct field
+	AnyOfRule        string
+	AnyOfRuleMessage string
 }
 
 // Generate generates CRD YAML files
 func (g *CRDGenerator) Generate(crds []*mapper.CRDDefinition) error {
 	outputDir := filepath.Join(g.config.OutputDir, "config", "crd", "bases")
-	if err := os.MkdirAll(outputDir, 0755); err != nil {
+	if err := g.files.MkdirAll(outputDir, 0755); err != nil {
 		return fmt.Errorf("failed to create output directory: %w", err)
 	}
 
@@ -74,6 +157,19 @@ func (g *CRDGenerator) Generate(crds []*mapper.CRDDefinition) error {
 	return nil
 }
 
+// GenerateKind regenerates only one CRD's YAML. The CRD kustomization.yaml is left
+// alone, so the Kind must already have been generated.
+func (g *CRDGenerator) GenerateKind(crd *mapper.CRDDefinition) error {
+	outputDir := filepath.Join(g.config.OutputDir, "config", "crd", "bases")
+	if err := g.files.MkdirAll(outputDir, 0755); err != nil {
+		return fmt.Errorf("failed to create output directory: %w", err)
+	}
+	if err := g.generateCRD(outputDir, crd); err != nil {
+		return fmt.Errorf("failed to generate CRD for %s: %w", crd.Kind, err)
+	}
+	return nil
+}
+
 func (g *CRDGenerator) generateKustomization(outputDir string, crdFiles []string) error {
 	data := struct {
 		GeneratorVersion string
@@ -89,7 +185,7 @@ func (g *CRDGenerator) generateKustomization(outputDir string, crdFiles []string
 	}
 
 	kustomizationPath := filepath.Join(outputDir, "kustomization.yaml")
-	file, err := os.Create(kustomizationPath)
+	file, err := g.files.Create(kustomizationPath)
 	if err != nil {
 		return fmt.Errorf("failed to create file: %w", err)
 	}
@@ -105,6 +201,8 @@ func (g *CRDGenerator) generateKustomization(outputDir string, crdFiles []string
 func (g *CRDGenerator) generateCRD(outputDir string, crd *mapper.CRDDefinition) error {
 	data := CRDYAMLData{
 		GeneratorVersion: g.config.GeneratorVersion,
+		SpecVersion:      g.config.SpecVersion,
+		SpecVersionLabel: specVersionLabel(g.config.SpecVersion),
 		APIGroup:         crd.APIGroup,
 		APIVersion:       crd.APIVersion,
 		Kind:             crd.Kind,
@@ -112,6 +210,17 @@ func (g *CRDGenerator) generateCRD(outputDir string, crd *mapper.CRDDefinition)
 		Plural:           crd.Plural,
 		ShortNames:       crd.ShortNames,
 		Scope:            crd.Scope,
+		UseETag:          crd.UseETag,
+		StatusFields:     crd.StatusFields,
+		ExcludedStatus:   excludedStatus(crd),
+		KindType:         crd.KindType(),
+		States:           crd.States(),
+		TargetDefault:    crd.TargetDefault.Entries(),
+		HasExtraHeaders:  crd.HasExtraHeaders,
+
+		HasWriteOnlyFields: hasWriteOnlyGeneration(crd),
+
+		StatusSubresource: !g.config.NoStatusSubresource,
 	}
 
 	if crd.Spec != nil {
@@ -129,14 +238,16 @@ func (g *CRDGenerator) generateCRD(outputDir string, crd *mapper.CRDDefinition)
 		return fmt.Errorf("failed to parse template: %w", err)
 	}
 
-	file, err := os.Create(filepath)
-	if err != nil {
-		return fmt.Errorf("failed to create file: %w", err)
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return fmt.Errorf("failed to execute template: %w", err)
+	}
+	if err := g.files.WriteFile(filepath, buf.Bytes(), 0644); err != nil {
+		return fmt.Errorf("failed to write file: %w", err)
 	}
-	defer file.Close()
 
-	if err := tmpl.Execute(file, data); err != nil {
-		return fmt.Errorf("failed to execute template: %w", err)
+	if problem, _ := CRDSizeProblem(filename, buf.Bytes()); problem != "" {
+		g.SizeWarnings = append(g.SizeWarnings, problem)
 	}
 
 	return nil
@@ -152,7 +263,21 @@ func (g *CRDGenerator) convertFields(fields []*mapper.FieldDefinition) []CRDFiel
 			SchemaType:  g.mapToSchemaType(f.GoType),
 			Required:    f.Required,
 			Enum:        f.Enum,
+			Immutable:   f.Immutable,
+		}
+		if valueType, ok := strings.CutPrefix(f.GoType, "map[string]"); ok {
+			fd.MapValueType = g.mapToSchemaType(valueType)
+			if fd.MapValueType == "object" || fd.MapValueType == "array" {
+				fd.MapValueType = "any"
+			}
+		}
+		if len(f.KeyPatterns) > 0 {
+			fd.KeyRule = mapKeyRule(f.KeyPatterns)
+			fd.KeyRuleMessage = mapKeyRuleMessage(f.JSONName, f.KeyPatterns)
 		}
+		fd.ValueRule, fd.ValueRuleMessage = mapValueRule(f)
+		fd.NotRule, fd.NotRuleMessage = fieldNotRule(f)
+		fd.AnyOfRule, fd.AnyOfRuleMessage = fieldAnyOfRule(f)
 		result = append(result, fd)
 	}
 
@@ -1,10 +1,16 @@
 package generator
 
 import (
+	"encoding/json"
+	"errors"
+	"fmt"
 	"os"
+	"os/exec"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/bluecontainer/openapi-operator-gen/internal/config"
 	"github.com/bluecontainer/openapi-operator-gen/pkg/mapper"
@@ -184,6 +190,57 @@ func TestCRDGenerator_Generate(t *testing.T) {
 	}
 }
 
+func TestCRDSizeProblem(t *testing.T) {
+	manifest := func(descriptionLen int) []byte {
+		return []byte("apiVersion: apiextensions.k8s.io/v1\nkind: CustomResourceDefinition\nspec:\n  description: " + strings.Repeat("x", descriptionLen) + "\n")
+	}
+
+	tests := []struct {
+		name          string
+		manifest      []byte
+		wantProblem   string
+		wantOverLimit bool
+	}{
+		{name: "small", manifest: manifest(1000)},
+		{name: "approaching the annotation limit", manifest: manifest(220000), wantProblem: "% of the 262144-byte annotation limit"},
+		{name: "over the annotation limit", manifest: manifest(270000), wantProblem: "over the 262144-byte annotation limit", wantOverLimit: true},
+		{name: "over the request limit", manifest: manifest(1600000), wantProblem: "over the API server's 1.5 MiB request limit", wantOverLimit: true},
+		{name: "not YAML", manifest: []byte("\t: [")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			problem, overLimit := CRDSizeProblem("widgets.yaml", tt.manifest)
+			if tt.wantProblem == "" {
+				if problem != "" {
+					t.Errorf("expected no problem, got %q", problem)
+				}
+				return
+			}
+			if !strings.HasPrefix(problem, "widgets.yaml is ") || !strings.Contains(problem, tt.wantProblem) || overLimit != tt.wantOverLimit {
+				t.Errorf("CRDSizeProblem() = %q, %v, want %q, %v", problem, overLimit, tt.wantProblem, tt.wantOverLimit)
+			}
+		})
+	}
+
+	// The generator reports the CRDs it writes
+	cfg := &config.Config{OutputDir: t.TempDir(), APIGroup: "test.example.com", APIVersion: "v1alpha1", ValidateOnly: true}
+	g := NewCRDGenerator(cfg)
+	crds := []*mapper.CRDDefinition{
+		{APIGroup: "test.example.com", APIVersion: "v1alpha1", Kind: "Widget", Plural: "widgets", Scope: "Namespaced",
+			Spec: &mapper.FieldDefinition{Fields: []*mapper.FieldDefinition{
+				{Name: "Name", JSONName: "name", GoType: "string", Description: strings.Repeat("A long description. ", 15000)},
+			}}},
+		{APIGroup: "test.example.com", APIVersion: "v1alpha1", Kind: "Gadget", Plural: "gadgets", Scope: "Namespaced",
+			Spec: &mapper.FieldDefinition{Fields: []*mapper.FieldDefinition{{Name: "Name", JSONName: "name", GoType: "string"}}}},
+	}
+	if err := g.Generate(crds); err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+	if len(g.SizeWarnings) != 1 || !strings.HasPrefix(g.SizeWarnings[0], "test.example.com_widgets.yaml is ") {
+		t.Errorf("expected a size warning for the widgets CRD only, got %q", g.SizeWarnings)
+	}
+}
+
 func TestCRDGenerator_Generate_MultipleCRDs(t *testing.T) {
 	tmpDir := t.TempDir()
 	cfg := &config.Config{
@@ -337,6 +394,21 @@ func TestTypesGenerator_ResolveGoType(t *testing.T) {
 			field:    &mapper.FieldDefinition{GoType: "bool", Required: false},
 			expected: "bool",
 		},
+		{
+			name:     "optional nullable string",
+			field:    &mapper.FieldDefinition{GoType: "string", Nullable: true},
+			expected: "*string",
+		},
+		{
+			name:     "required nullable string",
+			field:    &mapper.FieldDefinition{GoType: "string", Required: true, Nullable: true},
+			expected: "string",
+		},
+		{
+			name:     "optional string allowing empty value",
+			field:    &mapper.FieldDefinition{GoType: "string", AllowEmptyValue: true},
+			expected: "*string",
+		},
 	}
 
 	for _, tt := range tests {
@@ -396,6 +468,58 @@ func TestTypesGenerator_ConvertFieldsWithNestedTypes(t *testing.T) {
 	}
 }
 
+func TestTypesGenerator_ConvertFieldsWithNestedTypes_Title(t *testing.T) {
+	g := &TypesGenerator{config: &config.Config{}, reserved: reservedTypeNames([]*mapper.CRDDefinition{{Kind: "Pet"}})}
+	nestedTypes := make(map[string]NestedTypeData)
+
+	address := func() []*mapper.FieldDefinition {
+		return []*mapper.FieldDefinition{
+			{Name: "Street", JSONName: "street", GoType: "string"},
+			{Name: "City", JSONName: "city", GoType: "string"},
+		}
+	}
+	fields := []*mapper.FieldDefinition{
+		// Titled struct: named after its title
+		{Name: "Home", JSONName: "home", GoType: "struct", Title: "postal address", Fields: address()},
+		// Same title, same shape: shares the type
+		{Name: "Work", JSONName: "work", GoType: "struct", Title: "PostalAddress", Fields: address()},
+		// Same title, different shape: falls back to the path name
+		{Name: "Billing", JSONName: "billing", GoType: "struct", Title: "PostalAddress", Fields: []*mapper.FieldDefinition{
+			{Name: "Iban", JSONName: "iban", GoType: "string"},
+		}},
+		// Title of a type types.go already declares: falls back to the path name
+		{Name: "Owner", JSONName: "owner", GoType: "struct", Title: "PetSpec", Fields: address()},
+		// Title that is not a Go identifier: falls back to the path name
+		{Name: "Legacy", JSONName: "legacy", GoType: "struct", Title: "1st address", Fields: address()},
+		// Titled array items
+		{Name: "Tags", JSONName: "tags", GoType: "[]struct", ItemType: &mapper.FieldDefinition{
+			GoType: "struct",
+			Title:  "Tag",
+			Fields: []*mapper.FieldDefinition{{Name: "Name", JSONName: "name", GoType: "string"}},
+		}},
+	}
+
+	result := g.convertFieldsWithNestedTypes(fields, "Pet", nestedTypes)
+
+	want := []string{"PostalAddress", "PostalAddress", "PetBilling", "PetOwner", "PetLegacy", "[]Tag"}
+	for i, w := range want {
+		if result[i].GoType != w {
+			t.Errorf("field %s: expected GoType %q, got %q", result[i].Name, w, result[i].GoType)
+		}
+	}
+	for _, name := range []string{"PostalAddress", "PetBilling", "PetOwner", "PetLegacy", "Tag"} {
+		if _, ok := nestedTypes[name]; !ok {
+			t.Errorf("expected nested type %s", name)
+		}
+	}
+	if len(nestedTypes) != 5 {
+		t.Errorf("expected 5 nested types, got %d", len(nestedTypes))
+	}
+	if f := nestedTypes["PetBilling"].Fields; len(f) != 1 || f[0].Name != "Iban" {
+		t.Errorf("expected PetBilling to hold the billing fields, got %+v", f)
+	}
+}
+
 func TestTypesGenerator_ConvertFieldsWithNestedTypes_ArrayOfStructs(t *testing.T) {
 	g := &TypesGenerator{config: &config.Config{}}
 	nestedTypes := make(map[string]NestedTypeData)
@@ -505,6 +629,12 @@ func TestTypesGenerator_Generate(t *testing.T) {
 	if !strings.Contains(contentStr, "type WidgetStatus struct") {
 		t.Error("expected WidgetStatus struct in types.go")
 	}
+	if !strings.Contains(contentStr, "ExternalResourceURL string `json:\"externalResourceURL,omitempty\"`") {
+		t.Error("expected ExternalResourceURL field in WidgetStatus")
+	}
+	if !strings.Contains(contentStr, "JSONPath=`.status.externalResourceURL`,priority=1") {
+		t.Error("expected wide printer column for externalResourceURL")
+	}
 }
 
 func TestTypesGenerator_Generate_NestedTypes(t *testing.T) {
@@ -556,6 +686,77 @@ func TestTypesGenerator_Generate_NestedTypes(t *testing.T) {
 	}
 }
 
+func TestTypesGenerator_Generate_ExclusiveBounds(t *testing.T) {
+	tmpDir := t.TempDir()
+	cfg := &config.Config{
+		OutputDir:  tmpDir,
+		APIGroup:   "test.example.com",
+		APIVersion: "v1alpha1",
+		ModuleName: "github.com/example/test-operator",
+	}
+	g := NewTypesGenerator(cfg)
+
+	min := float64(0)
+	max := float64(100)
+	crds := []*mapper.CRDDefinition{
+		{
+			APIGroup:   "test.example.com",
+			APIVersion: "v1alpha1",
+			Kind:       "Gauge",
+			Plural:     "gauges",
+			Spec: &mapper.FieldDefinition{
+				Fields: []*mapper.FieldDefinition{
+					{
+						Name:       "Level",
+						JSONName:   "level",
+						GoType:     "int32",
+						Required:   true,
+						Validation: &mapper.ValidationRules{Minimum: &min, Maximum: &max},
+					},
+					{
+						Name:     "Ratio",
+						JSONName: "ratio",
+						GoType:   "float64",
+						Required: true,
+						Validation: &mapper.ValidationRules{
+							Minimum:          &min,
+							Maximum:          &max,
+							ExclusiveMinimum: true,
+							ExclusiveMaximum: true,
+						},
+					},
+				},
+			},
+		},
+	}
+
+	if err := g.Generate(crds); err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+
+	content, err := os.ReadFile(filepath.Join(tmpDir, "api", "v1alpha1", "types.go"))
+	if err != nil {
+		t.Fatalf("failed to read types.go: %v", err)
+	}
+	contentStr := string(content)
+
+	if !strings.Contains(contentStr, "+kubebuilder:validation:Maximum=100") {
+		t.Error("expected Maximum marker in types.go")
+	}
+	if strings.Count(contentStr, "+kubebuilder:validation:ExclusiveMinimum=true") != 1 {
+		t.Error("expected exactly one ExclusiveMinimum marker")
+	}
+	if strings.Count(contentStr, "+kubebuilder:validation:ExclusiveMaximum=true") != 1 {
+		t.Error("expected exactly one ExclusiveMaximum marker")
+	}
+	ratioIdx := strings.Index(contentStr, "Ratio float64")
+	exclusiveIdx := strings.Index(contentStr, "+kubebuilder:validation:ExclusiveMinimum=true")
+	levelIdx := strings.Index(contentStr, "Level int32")
+	if exclusiveIdx < levelIdx || exclusiveIdx > ratioIdx {
+		t.Error("expected ExclusiveMinimum marker to be attached to the Ratio field")
+	}
+}
+
 // =============================================================================
 // ControllerGenerator Tests
 // =============================================================================
@@ -637,6 +838,190 @@ func TestControllerGenerator_Generate(t *testing.T) {
 	}
 }
 
+func TestControllerGenerator_Generate_SuccessCodes(t *testing.T) {
+	tmpDir := t.TempDir()
+	cfg := &config.Config{
+		OutputDir:  tmpDir,
+		APIGroup:   "test.example.com",
+		APIVersion: "v1alpha1",
+		ModuleName: "github.com/example/test-operator",
+	}
+	g := NewControllerGenerator(cfg)
+
+	crds := []*mapper.CRDDefinition{
+		{
+			APIGroup:   "test.example.com",
+			APIVersion: "v1alpha1",
+			Kind:       "Widget",
+			Plural:     "widgets",
+			BasePath:   "/widgets",
+			HasPost:    true,
+			HasDelete:  true,
+			Operations: []mapper.OperationMapping{
+				{CRDAction: "Create", HTTPMethod: "POST", Path: "/widgets", SuccessCodes: []int{200, 202}},
+				{CRDAction: "Get", HTTPMethod: "GET", Path: "/widgets/{id}", Accept: "application/vnd.widget+json"},
+				{CRDAction: "Delete", HTTPMethod: "DELETE", Path: "/widgets/{id}", SuccessCodes: []int{204}},
+			},
+		},
+	}
+
+	if err := g.Generate(crds, nil, nil); err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+
+	controller, err := os.ReadFile(filepath.Join(tmpDir, "internal", "controller", "widget_controller.go"))
+	if err != nil {
+		t.Fatalf("failed to read controller: %v", err)
+	}
+	for _, want := range []string{
+		"if resp.StatusCode != 200 && resp.StatusCode != 202 {",
+		"if resp.StatusCode != 204 && resp.StatusCode != http.StatusNotFound {",
+		"if resp.StatusCode < 200 || resp.StatusCode >= 300 {",
+		// The GET sends the operation's Accept header; the POST falls back to JSON
+		`req.Header.Set("Accept", "application/vnd.widget+json")`,
+		`req.Header.Set("Accept", "application/json")`,
+	} {
+		if !strings.Contains(string(controller), want) {
+			t.Errorf("expected controller to contain %q", want)
+		}
+	}
+
+	// The mock server of the generated tests answers creates with a configured code
+	controllerTest, err := os.ReadFile(filepath.Join(tmpDir, "internal", "controller", "widget_controller_test.go"))
+	if err != nil {
+		t.Fatalf("failed to read controller test: %v", err)
+	}
+	if !strings.Contains(string(controllerTest), "w.WriteHeader(200)") || strings.Contains(string(controllerTest), "w.WriteHeader(http.StatusCreated)") {
+		t.Error("expected the generated test server to answer POST with 200")
+	}
+	if !strings.Contains(string(controllerTest), "w.WriteHeader(http.StatusNoContent)") {
+		t.Error("expected the generated test server to answer DELETE with 204")
+	}
+}
+
+func TestControllerGenerator_Generate_EmbedSpec(t *testing.T) {
+	specDir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(specDir, "widgets-api", "paths"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	files := map[string]string{
+		"widgets.json":                   `{"openapi": "3.0.0"}`,
+		"widgets-api/openapi.yaml":       "openapi: 3.0.0\n",
+		"widgets-api/paths/widgets.yaml": "get: {}\n",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(specDir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []struct {
+		specPath   string
+		wantEmbed  []string
+		wantMain   string
+		wantDocker string
+	}{
+		{
+			specPath:   "widgets.json",
+			wantEmbed:  []string{"package testoperator", `const SpecFile = "widgets.json"`, `//go:embed "widgets.json"`},
+			wantMain:   `mgr.AddMetricsServerExtraHandler("/openapi.json"`,
+			wantDocker: "COPY spec_embed.go widgets.json ./",
+		},
+		{
+			specPath:   "widgets-api",
+			wantEmbed:  []string{`const SpecFile = "widgets-api/openapi.yaml"`, `//go:embed "widgets-api"`},
+			wantMain:   `mgr.AddMetricsServerExtraHandler("/openapi.yaml"`,
+			wantDocker: "COPY widgets-api/ widgets-api/",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.specPath, func(t *testing.T) {
+			tmpDir := t.TempDir()
+			cfg := &config.Config{
+				SpecPath:   filepath.Join(specDir, tt.specPath),
+				OutputDir:  tmpDir,
+				APIGroup:   "test.example.com",
+				APIVersion: "v1alpha1",
+				ModuleName: "github.com/example/test-operator",
+				EmbedSpec:  true,
+			}
+			crds := []*mapper.CRDDefinition{
+				{APIGroup: "test.example.com", APIVersion: "v1alpha1", Kind: "Widget", Plural: "widgets", BasePath: "/widgets"},
+			}
+			if err := NewControllerGenerator(cfg).Generate(crds, nil, nil); err != nil {
+				t.Fatalf("Generate failed: %v", err)
+			}
+
+			checks := map[string][]string{
+				"spec_embed.go": tt.wantEmbed,
+				filepath.Join("cmd", "manager", "main.go"): {tt.wantMain, `operatorspec "github.com/example/test-operator"`},
+				"Dockerfile": {tt.wantDocker},
+			}
+			for file, wants := range checks {
+				content, err := os.ReadFile(filepath.Join(tmpDir, file))
+				if err != nil {
+					t.Fatalf("failed to read %s: %v", file, err)
+				}
+				for _, want := range wants {
+					if !strings.Contains(string(content), want) {
+						t.Errorf("expected %s to contain %q", file, want)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestControllerGenerator_Generate_Kubebuilder(t *testing.T) {
+	tmpDir := t.TempDir()
+	cfg := &config.Config{
+		OutputDir:         tmpDir,
+		APIGroup:          "test.example.com",
+		APIVersion:        "v1alpha1",
+		ModuleName:        "github.com/example/test-operator",
+		KubebuilderLayout: true,
+	}
+	crds := []*mapper.CRDDefinition{
+		{APIGroup: "test.example.com", APIVersion: "v1alpha1", Kind: "Widget", Plural: "widgets", BasePath: "/widgets", Scope: "Namespaced"},
+	}
+	aggregate := &mapper.AggregateDefinition{APIGroup: "test.example.com", APIVersion: "v1alpha1", Kind: "TestAggregate", Plural: "testaggregates"}
+	if err := NewControllerGenerator(cfg).Generate(crds, aggregate, nil); err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+
+	if _, err := os.Stat(filepath.Join(tmpDir, "cmd", "manager")); !os.IsNotExist(err) {
+		t.Errorf("expected no cmd/manager directory in the kubebuilder layout, got %v", err)
+	}
+	checks := map[string][]string{
+		"PROJECT": {
+			"domain: example.com",
+			"projectName: test",
+			"repo: github.com/example/test-operator",
+			"  group: test\n  kind: Widget\n  path: github.com/example/test-operator/api/v1alpha1\n  version: v1alpha1\n",
+			"  kind: TestAggregate\n",
+			"\nversion: \"3\"\n",
+		},
+		filepath.Join("cmd", "main.go"): {
+			"// +kubebuilder:scaffold:imports",
+			"// +kubebuilder:scaffold:scheme",
+			"// +kubebuilder:scaffold:builder",
+		},
+		"Dockerfile": {"go build -a -o manager cmd/main.go"},
+		"Makefile":   {"go run ./cmd/main.go"},
+	}
+	for file, wants := range checks {
+		content, err := os.ReadFile(filepath.Join(tmpDir, file))
+		if err != nil {
+			t.Fatalf("failed to read %s: %v", file, err)
+		}
+		for _, want := range wants {
+			if !strings.Contains(string(content), want) {
+				t.Errorf("expected %s to contain %q", file, want)
+			}
+		}
+	}
+}
+
 func TestControllerGenerator_Generate_MultipleCRDs(t *testing.T) {
 	tmpDir := t.TempDir()
 	cfg := &config.Config{
@@ -754,6 +1139,208 @@ func TestControllerGenerator_GenerateDockerfile(t *testing.T) {
 	if !strings.Contains(contentStr, "CGO_ENABLED=0") {
 		t.Error("expected CGO_ENABLED=0 in Dockerfile")
 	}
+	if !strings.Contains(contentStr, "USER 65532:65532") {
+		t.Error("expected non-root user in Dockerfile")
+	}
+}
+
+func TestControllerGenerator_GenerateDockerfile_CustomImages(t *testing.T) {
+	tests := []struct {
+		name           string
+		allowRunAsRoot bool
+		wantUser       bool
+	}{
+		{name: "non-root", wantUser: true},
+		{name: "allow run as root", allowRunAsRoot: true, wantUser: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir := t.TempDir()
+			cfg := &config.Config{
+				OutputDir:       tmpDir,
+				BaseImage:       "golang:1.25-bookworm",
+				RuntimeImage:    "registry.example.com/base/static:latest",
+				SecurityContext: config.SecurityContextConfig{AllowRunAsRoot: tt.allowRunAsRoot},
+			}
+			g := NewControllerGenerator(cfg)
+
+			if err := g.generateDockerfile(); err != nil {
+				t.Fatalf("generateDockerfile failed: %v", err)
+			}
+
+			content, err := os.ReadFile(filepath.Join(tmpDir, "Dockerfile"))
+			if err != nil {
+				t.Fatalf("failed to read Dockerfile: %v", err)
+			}
+			contentStr := string(content)
+
+			if !strings.Contains(contentStr, "FROM golang:1.25-bookworm AS builder") {
+				t.Error("expected custom builder image in Dockerfile")
+			}
+			if !strings.Contains(contentStr, "FROM registry.example.com/base/static:latest\n") {
+				t.Error("expected custom runtime image in Dockerfile")
+			}
+			if got := strings.Contains(contentStr, "USER 65532:65532"); got != tt.wantUser {
+				t.Errorf("USER 65532:65532 present = %v, want %v", got, tt.wantUser)
+			}
+		})
+	}
+}
+
+func TestControllerGenerator_GenerateTilt(t *testing.T) {
+	tests := []struct {
+		name           string
+		targetAPIImage string
+		wantContains   []string
+		wantMissing    []string
+	}{
+		{
+			name: "without target API",
+			wantContains: []string{
+				"docker_build(\n    'controller',",
+				"kustomize('config')",
+				"'--namespace-scoped',",
+				"watch_file('internal')",
+				"args = container.get('args', [])",
+			},
+			wantMissing: []string{
+				"config/target-api/deployment.yaml",
+				"resource_deps",
+			},
+		},
+		{
+			name:           "with target API",
+			targetAPIImage: "swaggerapi/petstore3:unstable",
+			wantContains: []string{
+				"k8s_yaml('config/target-api/deployment.yaml')",
+				"port_forwards='8080:8080'",
+				"'--deployment-name=petstore',",
+				"'--base-path=/api/v3',",
+				"resource_deps=['petstore']",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir := t.TempDir()
+			cfg := &config.Config{
+				OutputDir:      tmpDir,
+				APIGroup:       "petstore.example.com",
+				SpecBaseURL:    "http://localhost:8080/api/v3",
+				TargetAPIImage: tt.targetAPIImage,
+				GenerateTilt:   true,
+			}
+			g := NewControllerGenerator(cfg)
+
+			if err := g.GenerateTilt(); err != nil {
+				t.Fatalf("GenerateTilt failed: %v", err)
+			}
+
+			content, err := os.ReadFile(filepath.Join(tmpDir, "Tiltfile"))
+			if err != nil {
+				t.Fatalf("failed to read Tiltfile: %v", err)
+			}
+
+			contentStr := string(content)
+			for _, want := range tt.wantContains {
+				if !strings.Contains(contentStr, want) {
+					t.Errorf("expected Tiltfile to contain %q", want)
+				}
+			}
+			for _, missing := range tt.wantMissing {
+				if strings.Contains(contentStr, missing) {
+					t.Errorf("expected Tiltfile not to contain %q", missing)
+				}
+			}
+		})
+	}
+}
+
+func TestControllerGenerator_GenerateE2E(t *testing.T) {
+	crds := []*mapper.CRDDefinition{
+		{APIGroup: "petstore.example.com", Kind: "PetFindByStatusQuery", Plural: "petfindbystatusqueries", IsQuery: true},
+		{APIGroup: "petstore.example.com", Kind: "Pet", Plural: "pets"},
+	}
+	tests := []struct {
+		name           string
+		targetAPIImage string
+		wantContains   []string
+		wantMissing    []string
+	}{
+		{
+			name: "without target API",
+			wantContains: []string{
+				"//go:build e2e",
+				`sampleFile      = "config/samples/v1alpha1_pet.yaml"`,
+				`sampleResource  = "pets.petstore.example.com/pet-sample"`,
+				`apiBaseURL = envOr("E2E_API_BASE_URL", "")`,
+				"or regenerate with --target-api-image",
+			},
+			wantMissing: []string{
+				"config/target-api/deployment.yaml",
+			},
+		},
+		{
+			name:           "with target API",
+			targetAPIImage: "swaggerapi/petstore3:unstable",
+			wantContains: []string{
+				`{"kubectl", "apply", "-f", "config/target-api/deployment.yaml"},`,
+				`"deployment/petstore"`,
+				`apiBaseURL = envOr("E2E_API_BASE_URL", "http://petstore.petstore-system.svc:8080/api/v3")`,
+			},
+			wantMissing: []string{
+				"or regenerate with --target-api-image",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir := t.TempDir()
+			cfg := &config.Config{
+				OutputDir:      tmpDir,
+				APIGroup:       "petstore.example.com",
+				APIVersion:     "v1alpha1",
+				SpecBaseURL:    "http://localhost:8080/api/v3",
+				TargetAPIImage: tt.targetAPIImage,
+				GenerateE2E:    true,
+			}
+			g := NewControllerGenerator(cfg)
+
+			if err := g.GenerateE2E(crds); err != nil {
+				t.Fatalf("GenerateE2E failed: %v", err)
+			}
+
+			content, err := os.ReadFile(filepath.Join(tmpDir, "test", "e2e", "e2e_test.go"))
+			if err != nil {
+				t.Fatalf("failed to read e2e test: %v", err)
+			}
+
+			contentStr := string(content)
+			for _, want := range tt.wantContains {
+				if !strings.Contains(contentStr, want) {
+					t.Errorf("expected e2e test to contain %q", want)
+				}
+			}
+			for _, missing := range tt.wantMissing {
+				if strings.Contains(contentStr, missing) {
+					t.Errorf("expected e2e test not to contain %q", missing)
+				}
+			}
+			if err := g.generateMakefile(); err != nil {
+				t.Fatalf("generateMakefile failed: %v", err)
+			}
+			makefile, err := os.ReadFile(filepath.Join(tmpDir, "Makefile"))
+			if err != nil {
+				t.Fatalf("failed to read Makefile: %v", err)
+			}
+			if !strings.Contains(string(makefile), "go test -tags e2e ./test/e2e/") {
+				t.Error("expected a test-e2e target in the Makefile")
+			}
+		})
+	}
 }
 
 func TestControllerGenerator_GenerateMakefile(t *testing.T) {
@@ -783,7 +1370,10 @@ func TestControllerGenerator_GenerateMakefile(t *testing.T) {
 		".PHONY: test",
 		".PHONY: docker-build",
 		".PHONY: install",
+		".PHONY: rbac-audit",
+		".PHONY: lint",
 		"controller-gen",
+		"golangci-lint",
 	}
 	for _, target := range targets {
 		if !strings.Contains(contentStr, target) {
@@ -795,6 +1385,9 @@ func TestControllerGenerator_GenerateMakefile(t *testing.T) {
 	if !strings.Contains(contentStr, "CONTROLLER_TOOLS_VERSION ?= v0.17.0") {
 		t.Error("expected controller-tools version in Makefile")
 	}
+	if strings.Contains(contentStr, "test-e2e") {
+		t.Error("expected no test-e2e target without --e2e")
+	}
 }
 
 func TestControllerGenerator_GenerateBoilerplate(t *testing.T) {
@@ -826,6 +1419,40 @@ func TestControllerGenerator_GenerateBoilerplate(t *testing.T) {
 	}
 }
 
+func TestControllerGenerator_GenerateRBACAuditScript(t *testing.T) {
+	tmpDir := t.TempDir()
+	cfg := &config.Config{
+		OutputDir: tmpDir,
+		APIGroup:  "petstore.example.com",
+	}
+	g := NewControllerGenerator(cfg)
+
+	if err := g.generateRBACAuditScript(); err != nil {
+		t.Fatalf("generateRBACAuditScript failed: %v", err)
+	}
+
+	path := filepath.Join(tmpDir, "hack", "rbac-audit.sh")
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatalf("failed to stat rbac-audit.sh: %v", err)
+	}
+	if info.Mode().Perm()&0100 == 0 {
+		t.Errorf("expected rbac-audit.sh to be executable, got mode %v", info.Mode().Perm())
+	}
+
+	content, _ := os.ReadFile(path)
+	contentStr := string(content)
+	if !strings.Contains(contentStr, `NAMESPACE="${NAMESPACE:-petstore-system}"`) {
+		t.Error("expected the operator namespace as the default NAMESPACE")
+	}
+	if !strings.Contains(contentStr, `kubectl auth can-i --list --as="${SUBJECT}"`) {
+		t.Error("expected kubectl auth can-i --list impersonating the service account")
+	}
+	if !strings.Contains(contentStr, "config/rbac") {
+		t.Error("expected the fallback to print the generated RBAC rules")
+	}
+}
+
 func TestControllerGenerator_ControllerContent(t *testing.T) {
 	tmpDir := t.TempDir()
 	cfg := &config.Config{
@@ -872,319 +1499,4566 @@ func TestControllerGenerator_ControllerContent(t *testing.T) {
 	if !strings.Contains(contentStr, "func (r *CatReconciler) Reconcile") {
 		t.Error("expected Reconcile method")
 	}
+
+	// Check external resource link is recorded in status
+	if !strings.Contains(contentStr, "runtime.ResourceLink(r.buildResourceURL(baseURL, instance))") {
+		t.Error("expected setExternalResourceURL to derive the link from buildResourceURL")
+	}
 }
 
-// =============================================================================
-// Edge Cases and Error Handling
-// =============================================================================
-
-func TestCRDGenerator_Generate_NilSpec(t *testing.T) {
+func TestControllerGenerator_OperationIDFileNaming(t *testing.T) {
 	tmpDir := t.TempDir()
 	cfg := &config.Config{
-		OutputDir:  tmpDir,
-		APIGroup:   "test.example.com",
-		APIVersion: "v1",
+		OutputDir:            tmpDir,
+		APIGroup:             "pets.example.com",
+		APIVersion:           "v1alpha1",
+		ModuleName:           "github.com/example/pet-operator",
+		ControllerFileNaming: config.ControllerFileNamingOperationID,
 	}
-	g := NewCRDGenerator(cfg)
+	g := NewControllerGenerator(cfg)
 
 	crds := []*mapper.CRDDefinition{
 		{
-			APIGroup:   "test.example.com",
-			APIVersion: "v1",
-			Kind:       "Widget",
-			Plural:     "widgets",
-			Scope:      "Namespaced",
-			Spec:       nil, // nil spec
+			APIGroup:   "pets.example.com",
+			APIVersion: "v1alpha1",
+			Kind:       "Pet",
+			Plural:     "pets",
+			BasePath:   "/pet",
+			Operations: []mapper.OperationMapping{
+				{CRDAction: "Create", HTTPMethod: "POST", Path: "/pet", OperationID: "addPet"},
+				{CRDAction: "Get", HTTPMethod: "GET", Path: "/pet/{petId}", OperationID: "getPetById"},
+			},
+		},
+		{
+			APIGroup:   "pets.example.com",
+			APIVersion: "v1alpha1",
+			Kind:       "Store",
+			Plural:     "stores",
+			BasePath:   "/store",
 		},
 	}
 
-	// CRD template requires Spec, so this should return an error
-	err := g.Generate(crds)
-	if err == nil {
-		t.Error("expected error for nil spec")
+	if err := g.Generate(crds, nil, nil); err != nil {
+		t.Fatalf("Generate failed: %v", err)
 	}
-}
 
-func TestCRDGenerator_Generate_EmptySpecFields(t *testing.T) {
-	tmpDir := t.TempDir()
-	cfg := &config.Config{
-		OutputDir:  tmpDir,
-		APIGroup:   "test.example.com",
-		APIVersion: "v1",
+	controllerDir := filepath.Join(tmpDir, "internal", "controller")
+	for _, name := range []string{"get_pet_by_id_controller.go", "store_controller.go"} {
+		if _, err := os.Stat(filepath.Join(controllerDir, name)); err != nil {
+			t.Errorf("expected %s to exist: %v", name, err)
+		}
 	}
-	g := NewCRDGenerator(cfg)
-
-	crds := []*mapper.CRDDefinition{
-		{
-			APIGroup:   "test.example.com",
-			APIVersion: "v1",
-			Kind:       "Widget",
-			Plural:     "widgets",
-			Scope:      "Namespaced",
-			Spec: &mapper.FieldDefinition{
-				Fields: []*mapper.FieldDefinition{}, // empty fields
-			},
-		},
+	if _, err := os.Stat(filepath.Join(controllerDir, "pet_controller.go")); err == nil {
+		t.Error("expected pet_controller.go not to be generated with operation-id naming")
 	}
 
-	err := g.Generate(crds)
+	mainContent, err := os.ReadFile(filepath.Join(tmpDir, "cmd", "manager", "main.go"))
 	if err != nil {
-		t.Fatalf("Generate should handle empty spec fields: %v", err)
+		t.Fatalf("failed to read main.go: %v", err)
+	}
+	if !strings.Contains(string(mainContent), "// Pet (operationId: getPetById) - internal/controller/get_pet_by_id_controller.go") {
+		t.Error("expected operationId registration comment for Pet in main.go")
+	}
+	if strings.Contains(string(mainContent), "// Store (operationId:") {
+		t.Error("expected no operationId registration comment for Store without an operationId")
 	}
 }
 
-func TestTypesGenerator_Generate_EmptyCRDs(t *testing.T) {
-	tmpDir := t.TempDir()
+func TestControllerGenerator_OperationIDFileNamingSanitized(t *testing.T) {
 	cfg := &config.Config{
-		OutputDir:  tmpDir,
-		APIGroup:   "test.example.com",
-		APIVersion: "v1",
-		ModuleName: "github.com/example/test",
+		OutputDir:            t.TempDir(),
+		APIGroup:             "pets.example.com",
+		APIVersion:           "v1alpha1",
+		ModuleName:           "github.com/example/pet-operator",
+		ControllerFileNaming: config.ControllerFileNamingOperationID,
 	}
-	g := NewTypesGenerator(cfg)
+	g := NewControllerGenerator(cfg)
 
-	err := g.Generate([]*mapper.CRDDefinition{})
-	if err != nil {
-		t.Fatalf("Generate should handle empty CRDs: %v", err)
+	tests := []struct {
+		opID string
+		want string
+	}{
+		{"pets.list/{all}", "pets_list_all_controller.go"},
+		{"Get Pet (by ID)", "get_pet_by_id_controller.go"},
+		{"...", "pet_controller.go"},
+	}
+	for _, tt := range tests {
+		crd := &mapper.CRDDefinition{Kind: "Pet", Operations: []mapper.OperationMapping{{CRDAction: "Get", OperationID: tt.opID}}}
+		if got := g.controllerFileName(crd); got != tt.want {
+			t.Errorf("controllerFileName(%q) = %q, want %q", tt.opID, got, tt.want)
+		}
 	}
 
-	// Files should still be created
-	typesPath := filepath.Join(tmpDir, "api", "v1", "types.go")
-	if _, err := os.Stat(typesPath); os.IsNotExist(err) {
-		t.Error("expected types.go to exist even with no CRDs")
+	crds := []*mapper.CRDDefinition{
+		{APIGroup: "pets.example.com", APIVersion: "v1alpha1", Kind: "Pet", Plural: "pets", BasePath: "/pet",
+			Operations: []mapper.OperationMapping{{CRDAction: "Get", HTTPMethod: "GET", Path: "/pet/{id}", OperationID: "listPets"}}},
+		{APIGroup: "pets.example.com", APIVersion: "v1alpha1", Kind: "PetList", Plural: "petlists", IsQuery: true, QueryPath: "/pets",
+			Operations: []mapper.OperationMapping{{CRDAction: "Query", HTTPMethod: "GET", Path: "/pets", OperationID: "list-pets"}}},
+	}
+	err := g.Generate(crds, nil, nil)
+	if err == nil || !strings.Contains(err.Error(), "list_pets_controller.go") {
+		t.Errorf("Generate() expected a controller file name collision error, got %v", err)
 	}
 }
 
-func TestControllerGenerator_Generate_EmptyCRDs(t *testing.T) {
+func TestControllerGenerator_FinalizerName(t *testing.T) {
+	crds := []*mapper.CRDDefinition{
+		{APIGroup: "pets.example.com", APIVersion: "v1alpha1", Kind: "Pet", Plural: "pets", BasePath: "/pet", HasDelete: true},
+		{APIGroup: "pets.example.com", APIVersion: "v1alpha1", Kind: "PetFindByTags", Plural: "petfindbytags", IsQuery: true, QueryPath: "/pet/findByTags"},
+	}
+
+	tests := []struct {
+		finalizerName string
+		want          string
+	}{
+		{"", `"pets.example.com/finalizer"`},
+		{"pets.example.com/team-a", `"pets.example.com/team-a"`},
+	}
+	for _, tt := range tests {
+		tmpDir := t.TempDir()
+		cfg := &config.Config{
+			OutputDir:     tmpDir,
+			APIGroup:      "pets.example.com",
+			APIVersion:    "v1alpha1",
+			ModuleName:    "github.com/example/pet-operator",
+			FinalizerName: tt.finalizerName,
+		}
+		if err := NewControllerGenerator(cfg).Generate(crds, nil, nil); err != nil {
+			t.Fatalf("Generate failed: %v", err)
+		}
+		for _, name := range []string{"pet_controller.go", "petfindbytags_controller.go"} {
+			content, err := os.ReadFile(filepath.Join(tmpDir, "internal", "controller", name))
+			if err != nil {
+				t.Fatalf("failed to read %s: %v", name, err)
+			}
+			if !strings.Contains(string(content), "Finalizer") || !strings.Contains(string(content), tt.want) {
+				t.Errorf("finalizerName=%q: expected %s to use finalizer %s", tt.finalizerName, name, tt.want)
+			}
+		}
+	}
+}
+
+func TestControllerGenerator_SyncDuration(t *testing.T) {
 	tmpDir := t.TempDir()
 	cfg := &config.Config{
 		OutputDir:  tmpDir,
-		APIGroup:   "test.example.com",
-		APIVersion: "v1",
-		ModuleName: "github.com/example/test",
+		APIGroup:   "pets.example.com",
+		APIVersion: "v1alpha1",
+		ModuleName: "github.com/example/pet-operator",
+	}
+	crds := []*mapper.CRDDefinition{
+		{APIGroup: "pets.example.com", APIVersion: "v1alpha1", Kind: "Pet", Plural: "pets", BasePath: "/pet"},
+		{APIGroup: "pets.example.com", APIVersion: "v1alpha1", Kind: "PetFindByTags", Plural: "petfindbytags", IsQuery: true, QueryPath: "/pet/findByTags"},
+	}
+	if err := NewControllerGenerator(cfg).Generate(crds, nil, nil); err != nil {
+		t.Fatalf("Generate failed: %v", err)
 	}
-	g := NewControllerGenerator(cfg)
 
-	err := g.Generate([]*mapper.CRDDefinition{}, nil, nil)
+	for _, name := range []string{"pet_controller.go", "petfindbytags_controller.go"} {
+		content, err := os.ReadFile(filepath.Join(tmpDir, "internal", "controller", name))
+		if err != nil {
+			t.Fatalf("failed to read %s: %v", name, err)
+		}
+		for _, want := range []string{"r.recordSyncDuration(ctx, instance)", `"SlowReconcile"`, "resources=events,verbs=create;patch"} {
+			if !strings.Contains(string(content), want) {
+				t.Errorf("expected %s to contain %q", name, want)
+			}
+		}
+	}
+
+	petContent, err := os.ReadFile(filepath.Join(tmpDir, "internal", "controller", "pet_controller.go"))
 	if err != nil {
-		t.Fatalf("Generate should handle empty CRDs: %v", err)
+		t.Fatalf("failed to read pet_controller.go: %v", err)
+	}
+	// Only reconciles that synced record a duration, so periodic no-op reconciles don't churn the status
+	if !strings.Contains(string(petContent), "!instance.Status.LastSyncTime.Time.Before(start)") {
+		t.Error("expected syncDurationSeconds to be recorded only when the reconcile synced")
 	}
 
-	// main.go should still be created
-	mainPath := filepath.Join(tmpDir, "cmd", "manager", "main.go")
-	if _, err := os.Stat(mainPath); os.IsNotExist(err) {
-		t.Error("expected main.go to exist even with no CRDs")
+	mainContent, err := os.ReadFile(filepath.Join(tmpDir, "cmd", "manager", "main.go"))
+	if err != nil {
+		t.Fatalf("failed to read main.go: %v", err)
+	}
+	if !strings.Contains(string(mainContent), `"slow-reconcile-threshold", 10 * time.Second`) {
+		t.Error("expected main.go to default --slow-reconcile-threshold to 10s")
 	}
 }
 
-func TestCRDGenerator_ConvertFields_EmptyFields(t *testing.T) {
-	g := &CRDGenerator{config: &config.Config{}}
-	result := g.convertFields(nil)
-	if len(result) != 0 {
-		t.Errorf("expected empty result for nil fields, got %d", len(result))
+func TestControllerGenerator_Pprof(t *testing.T) {
+	crds := []*mapper.CRDDefinition{
+		{APIGroup: "test.example.com", APIVersion: "v1alpha1", Kind: "Widget", Plural: "widgets", BasePath: "/widgets"},
+	}
+
+	for _, enable := range []bool{false, true} {
+		tmpDir := t.TempDir()
+		cfg := &config.Config{
+			OutputDir:   tmpDir,
+			APIGroup:    "test.example.com",
+			APIVersion:  "v1alpha1",
+			ModuleName:  "github.com/example/widget-operator",
+			EnablePprof: enable,
+			PprofAddr:   "0.0.0.0:6061",
+		}
+		if err := NewControllerGenerator(cfg).Generate(crds, nil, nil); err != nil {
+			t.Fatalf("Generate failed: %v", err)
+		}
+		content, err := os.ReadFile(filepath.Join(tmpDir, "cmd", "manager", "main.go"))
+		if err != nil {
+			t.Fatalf("failed to read main.go: %v", err)
+		}
+		contentStr := string(content)
+		for _, want := range []string{`"pprof-bind-address", "0.0.0.0:6061"`, "PprofBindAddress:       pprofAddr"} {
+			if strings.Contains(contentStr, want) != enable {
+				t.Errorf("EnablePprof=%v: main.go contains %q = %v", enable, want, !enable)
+			}
+		}
 	}
+}
 
-	result = g.convertFields([]*mapper.FieldDefinition{})
-	if len(result) != 0 {
-		t.Errorf("expected empty result for empty fields, got %d", len(result))
+func TestControllerGenerator_Tracing(t *testing.T) {
+	crds := []*mapper.CRDDefinition{
+		{APIGroup: "test.example.com", APIVersion: "v1alpha1", Kind: "Widget", Plural: "widgets", BasePath: "/widgets"},
 	}
-}
 
-func TestTypesGenerator_ConvertFieldsWithNestedTypes_NoNestedTypes(t *testing.T) {
-	g := &TypesGenerator{config: &config.Config{}}
-	nestedTypes := make(map[string]NestedTypeData)
+	for _, enable := range []bool{false, true} {
+		tmpDir := t.TempDir()
+		cfg := &config.Config{
+			OutputDir:     tmpDir,
+			APIGroup:      "test.example.com",
+			APIVersion:    "v1alpha1",
+			ModuleName:    "github.com/example/widget-operator",
+			EnableTracing: enable,
+		}
+		if err := NewControllerGenerator(cfg).Generate(crds, nil, nil); err != nil {
+			t.Fatalf("Generate failed: %v", err)
+		}
+		content, err := os.ReadFile(filepath.Join(tmpDir, "cmd", "manager", "main.go"))
+		if err != nil {
+			t.Fatalf("failed to read main.go: %v", err)
+		}
+		if strings.Contains(string(content), "telemetry.WithoutTracing()") == enable {
+			t.Errorf("EnableTracing=%v: main.go contains telemetry.WithoutTracing() = %v", enable, enable)
+		}
 
-	fields := []*mapper.FieldDefinition{
-		{Name: "Name", JSONName: "name", GoType: "string"},
-		{Name: "Age", JSONName: "age", GoType: "int32"},
+		controller, err := os.ReadFile(filepath.Join(tmpDir, "internal", "controller", "widget_controller.go"))
+		if err != nil {
+			t.Fatalf("failed to read controller: %v", err)
+		}
+		for _, want := range []string{`widgetTracer.Start(ctx, "Widget.Reconcile"`, `widgetTracer.Start(ctx, "Widget.GET"`} {
+			if !strings.Contains(string(controller), want) {
+				t.Errorf("controller missing span %q", want)
+			}
+		}
 	}
+}
 
-	result := g.convertFieldsWithNestedTypes(fields, "User", nestedTypes)
+func TestControllerGenerator_PauseSwitch(t *testing.T) {
+	crds := []*mapper.CRDDefinition{
+		{APIGroup: "test.example.com", APIVersion: "v1alpha1", Kind: "Widget", Plural: "widgets", BasePath: "/widgets"},
+		{APIGroup: "test.example.com", APIVersion: "v1alpha1", Kind: "WidgetSearch", Plural: "widgetsearches", IsQuery: true, QueryPath: "/widgets/search"},
+	}
+
+	for _, ref := range []string{"", "ops/operator-pause"} {
+		tmpDir := t.TempDir()
+		cfg := &config.Config{
+			OutputDir:         tmpDir,
+			APIGroup:          "test.example.com",
+			APIVersion:        "v1alpha1",
+			ModuleName:        "github.com/example/widget-operator",
+			PauseConfigMapRef: ref,
+		}
+		if err := NewControllerGenerator(cfg).Generate(crds, nil, nil); err != nil {
+			t.Fatalf("Generate failed: %v", err)
+		}
+		enabled := ref != ""
 
-	if len(result) != 2 {
-		t.Fatalf("expected 2 fields, got %d", len(result))
-	}
-	if len(nestedTypes) != 0 {
-		t.Errorf("expected no nested types, got %d", len(nestedTypes))
+		mainContent, err := os.ReadFile(filepath.Join(tmpDir, "cmd", "manager", "main.go"))
+		if err != nil {
+			t.Fatalf("failed to read main.go: %v", err)
+		}
+		for _, want := range []string{`"pause-configmap", "ops/operator-pause"`, "controllerutil2.NewPauseSwitch(mgr.GetAPIReader()", "PauseSwitch:            pauseSwitch"} {
+			if strings.Contains(string(mainContent), want) != enabled {
+				t.Errorf("PauseConfigMapRef=%q: main.go contains %q = %v", ref, want, !enabled)
+			}
+		}
+
+		for _, file := range []string{"widget_controller.go", "widgetsearch_controller.go"} {
+			content, err := os.ReadFile(filepath.Join(tmpDir, "internal", "controller", file))
+			if err != nil {
+				t.Fatalf("failed to read %s: %v", file, err)
+			}
+			for _, want := range []string{"r.PauseSwitch.Paused(ctx,", `resources=configmaps,verbs=get`, `corev1.EventTypeNormal, "Paused"`} {
+				if strings.Contains(string(content), want) != enabled {
+					t.Errorf("PauseConfigMapRef=%q: %s contains %q = %v", ref, file, want, !enabled)
+				}
+			}
+		}
 	}
 }
 
-// =============================================================================
-// Integration Test
-// =============================================================================
-
-// =============================================================================
-// Version Handling Tests
-// =============================================================================
+func TestControllerGenerator_DeleteTimeout(t *testing.T) {
+	crds := []*mapper.CRDDefinition{
+		{APIGroup: "test.example.com", APIVersion: "v1alpha1", Kind: "Widget", Plural: "widgets", BasePath: "/widgets", HasPost: true, HasDelete: true, DeletePath: "/widgets/{id}"},
+	}
 
-func TestIsValidSemver(t *testing.T) {
 	tests := []struct {
-		version  string
-		expected bool
+		name    string
+		timeout time.Duration
+		orphan  bool
+		want    []string
+		notWant []string
 	}{
-		{"v1.0.0", true},
-		{"v0.0.1", true},
-		{"v10.20.30", true},
-		{"v0.0.0", true},
-		{"v1.2.3-dirty", false},
-		{"v0.0.7-10-gd5024c8-dirty", false},
-		{"v1.0.0-rc1", false},
-		{"v1.0", false},
-		{"v1", false},
-		{"1.0.0", false},
-		{"", false},
-		{"dev", false},
-		{"latest", false},
+		{
+			name:    "disabled",
+			want:    []string{"proceeding with finalizer removal"},
+			notWant: []string{"DeletionBlockedCondition", "OrphanedOnDeleteTimeout"},
+		},
+		{
+			name:    "retry until the DELETE succeeds",
+			timeout: 10 * time.Minute,
+			want:    []string{"controllerutil2.DeletionBlockedCondition(err, instance.GetDeletionTimestamp().Time, time.Now(), 600 * time.Second)", "stays blocked"},
+			notWant: []string{"proceeding with finalizer removal", "OrphanedOnDeleteTimeout"},
+		},
+		{
+			name:    "orphan on timeout",
+			timeout: 10 * time.Minute,
+			orphan:  true,
+			want:    []string{"if !expired {", `corev1.EventTypeWarning, "OrphanedOnDeleteTimeout"`},
+			notWant: []string{"proceeding with finalizer removal", "stays blocked"},
+		},
 	}
 
 	for _, tt := range tests {
-		t.Run(tt.version, func(t *testing.T) {
-			result := isValidSemver(tt.version)
-			if result != tt.expected {
-				t.Errorf("isValidSemver(%q) = %v, expected %v", tt.version, result, tt.expected)
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir := t.TempDir()
+			cfg := &config.Config{
+				OutputDir:             tmpDir,
+				APIGroup:              "test.example.com",
+				APIVersion:            "v1alpha1",
+				ModuleName:            "github.com/example/widget-operator",
+				DeleteTimeout:         tt.timeout,
+				OrphanOnDeleteTimeout: tt.orphan,
+			}
+			if err := NewControllerGenerator(cfg).Generate(crds, nil, nil); err != nil {
+				t.Fatalf("Generate failed: %v", err)
+			}
+
+			content, err := os.ReadFile(filepath.Join(tmpDir, "internal", "controller", "widget_controller.go"))
+			if err != nil {
+				t.Fatalf("failed to read widget_controller.go: %v", err)
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(string(content), want) {
+					t.Errorf("expected controller to contain %q", want)
+				}
+			}
+			for _, notWant := range tt.notWant {
+				if strings.Contains(string(content), notWant) {
+					t.Errorf("expected controller not to contain %q", notWant)
+				}
 			}
 		})
 	}
 }
 
-func TestExtractBaseSemver(t *testing.T) {
-	tests := []struct {
-		version  string
-		expected string
-	}{
-		// Clean versions pass through
-		{"v1.0.0", "v1.0.0"},
-		{"v0.0.7", "v0.0.7"},
-		// Dirty/commit versions extract base version only
-		{"v0.0.7-dirty", "v0.0.7"},
-		{"v0.0.7-10-gd5024c8-dirty", "v0.0.7"},
-		{"v1.2.3-5-gabcdef", "v1.2.3"},
-		{"v10.20.30-1-g1234567-dirty", "v10.20.30"},
-		{"v0.0.7-10-gd5024c8", "v0.0.7"},
-		// Invalid versions fall back to v0.0.0
-		{"", "v0.0.0"},
-		{"dev", "v0.0.0"},
-		{"latest", "v0.0.0"},
-		{"1.0.0", "v1.0.0"},  // no 'v' prefix - still extracts valid semver
-		{"v1.0", "v0.0.0"},   // only 2 parts
-		{"v1", "v0.0.0"},     // only 1 part
-		{"vX.Y.Z", "v0.0.0"}, // non-numeric
+func TestControllerGenerator_ConditionTypes(t *testing.T) {
+	crds := []*mapper.CRDDefinition{
+		{APIGroup: "test.example.com", APIVersion: "v1alpha1", Kind: "Widget", Plural: "widgets", BasePath: "/widgets", HasPost: true},
+		{APIGroup: "test.example.com", APIVersion: "v1alpha1", Kind: "WidgetSearchQuery", Plural: "widgetsearchqueries", IsQuery: true, QueryPath: "/widgets/search"},
 	}
 
-	for _, tt := range tests {
-		t.Run(tt.version, func(t *testing.T) {
-			result := extractBaseSemver(tt.version)
-			if result != tt.expected {
-				t.Errorf("extractBaseSemver(%q) = %q, expected %q", tt.version, result, tt.expected)
+	tmpDir := t.TempDir()
+	cfg := &config.Config{
+		OutputDir:      tmpDir,
+		APIGroup:       "test.example.com",
+		APIVersion:     "v1alpha1",
+		ModuleName:     "github.com/example/widget-operator",
+		ConditionTypes: map[string]string{"ready": "Available", "reconciling": "Progressing", "stalled": "Degraded"},
+	}
+	if err := NewControllerGenerator(cfg).Generate(crds, nil, nil); err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+
+	for _, file := range []string{"widget_controller.go", "widgetsearchquery_controller.go"} {
+		content, err := os.ReadFile(filepath.Join(tmpDir, "internal", "controller", file))
+		if err != nil {
+			t.Fatalf("failed to read %s: %v", file, err)
+		}
+		if !strings.Contains(string(content), `"Available"`) {
+			t.Errorf("expected %s to set the Available condition", file)
+		}
+		for _, notWant := range []string{`"Ready",`, `"Reconciling",`, `"Stalled",`} {
+			if strings.Contains(string(content), notWant) {
+				t.Errorf("expected %s not to contain %s", file, notWant)
 			}
-		})
+		}
+	}
+
+	content, err := os.ReadFile(filepath.Join(tmpDir, "internal", "controller", "widget_controller.go"))
+	if err != nil {
+		t.Fatalf("failed to read widget_controller.go: %v", err)
+	}
+	for _, want := range []string{`"Progressing"`, `"Degraded"`} {
+		if !strings.Contains(string(content), want) {
+			t.Errorf("expected widget_controller.go to contain %s", want)
+		}
 	}
 }
 
-func TestIncrementPatchVersion(t *testing.T) {
-	tests := []struct {
-		version  string
-		expected string
-	}{
-		{"v0.0.0", "v0.0.1"},
-		{"v0.0.7", "v0.0.8"},
-		{"v1.2.3", "v1.2.4"},
-		{"v10.20.30", "v10.20.31"},
-		{"v0.0.99", "v0.0.100"},
-		// Invalid versions return as-is
-		{"invalid", "invalid"},
-		{"v1.2", "v1.2"},
+func TestControllerGenerator_ConditionReasons(t *testing.T) {
+	crds := []*mapper.CRDDefinition{
+		{APIGroup: "test.example.com", APIVersion: "v1alpha1", Kind: "Widget", Plural: "widgets", BasePath: "/widgets", HasPost: true},
+		{APIGroup: "test.example.com", APIVersion: "v1alpha1", Kind: "WidgetSearchQuery", Plural: "widgetsearchqueries", IsQuery: true, QueryPath: "/widgets/search"},
 	}
 
-	for _, tt := range tests {
-		t.Run(tt.version, func(t *testing.T) {
-			result := incrementPatchVersion(tt.version)
-			if result != tt.expected {
-				t.Errorf("incrementPatchVersion(%q) = %q, expected %q", tt.version, result, tt.expected)
+	tmpDir := t.TempDir()
+	cfg := &config.Config{
+		OutputDir:  tmpDir,
+		APIGroup:   "test.example.com",
+		APIVersion: "v1alpha1",
+		ModuleName: "github.com/example/widget-operator",
+	}
+	if err := NewControllerGenerator(cfg).Generate(crds, nil, nil); err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+
+	controllerDir := filepath.Join(tmpDir, "internal", "controller")
+	tests := map[string][]string{
+		"conditions.go": {
+			`ReasonSyncSucceeded = "SyncSucceeded"`,
+			`ReasonAPIError = "APIError"`,
+			`ReasonEndpointUnreachable = "EndpointUnreachable"`,
+			"ReasonDeleteFailed = controllerutil2.DeletionBlockedReasonFailed",
+			"func failureReason(err error, statusCode int) string {",
+		},
+		"widget_controller.go": {
+			"Reason:             reason,",
+			`r.updateStatus(ctx, instance, "Synced", ReasonSyncSucceeded, "Successfully synced with REST API")`,
+			`r.updateStatus(ctx, instance, "Failed", failureReason(err, 0), err.Error())`,
+		},
+		"widgetsearchquery_controller.go": {
+			`r.updateStatus(ctx, instance, "Queried", ReasonQuerySucceeded, "Query executed successfully", resultCount)`,
+			`r.updateStatus(ctx, instance, "Failed", failureReason(err, statusCode), err.Error(), 0)`,
+		},
+	}
+	for file, wants := range tests {
+		content, err := os.ReadFile(filepath.Join(controllerDir, file))
+		if err != nil {
+			t.Fatalf("failed to read %s: %v", file, err)
+		}
+		for _, want := range wants {
+			if !strings.Contains(string(content), want) {
+				t.Errorf("expected %s to contain %q", file, want)
 			}
-		})
+		}
+		if strings.Contains(string(content), "Reason:             state,") {
+			t.Errorf("expected %s not to use the state as the condition reason", file)
+		}
 	}
 }
 
-// =============================================================================
-// Integration Test
-// =============================================================================
+func TestControllerGenerator_SpecFieldRename(t *testing.T) {
+	renamed := &mapper.FieldDefinition{Name: "PetType", JSONName: "petType", GoType: "string", APIName: "type"}
+	name := &mapper.FieldDefinition{Name: "Name", JSONName: "name", GoType: "string"}
+	crds := []*mapper.CRDDefinition{
+		{
+			APIGroup: "test.example.com", APIVersion: "v1alpha1", Kind: "Pet", Plural: "pets", BasePath: "/pets", HasPost: true,
+			Spec: &mapper.FieldDefinition{Name: "Spec", JSONName: "spec", GoType: "struct", Fields: []*mapper.FieldDefinition{renamed, name}},
+		},
+		{
+			APIGroup: "test.example.com", APIVersion: "v1alpha1", Kind: "PetClassify", Plural: "petclassifies", IsAction: true, ActionPath: "/pets/classify", ActionMethod: "POST",
+			Spec: &mapper.FieldDefinition{Name: "Spec", JSONName: "spec", GoType: "struct", Fields: []*mapper.FieldDefinition{renamed}},
+		},
+	}
 
-func TestFullGeneration(t *testing.T) {
 	tmpDir := t.TempDir()
 	cfg := &config.Config{
 		OutputDir:  tmpDir,
-		APIGroup:   "petstore.example.com",
+		APIGroup:   "test.example.com",
 		APIVersion: "v1alpha1",
-		ModuleName: "github.com/example/petstore-operator",
+		ModuleName: "github.com/example/pet-operator",
+	}
+	if err := NewControllerGenerator(cfg).Generate(crds, nil, nil); err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+
+	content, err := os.ReadFile(filepath.Join(tmpDir, "internal", "controller", "pet_controller.go"))
+	if err != nil {
+		t.Fatalf("failed to read pet_controller.go: %v", err)
+	}
+	if !strings.Contains(string(content), `"petType": "type",`) {
+		t.Error("expected the controller to map petType to type")
+	}
+	if got := strings.Count(string(content), "controllerutil2.RenameFields(specMap, petSpecFieldRenames)"); got != 2 {
+		t.Errorf("expected the renames applied when sending and comparing the spec, got %d uses", got)
+	}
+
+	content, err = os.ReadFile(filepath.Join(tmpDir, "internal", "controller", "petclassify_controller.go"))
+	if err != nil {
+		t.Fatalf("failed to read petclassify_controller.go: %v", err)
+	}
+	if !strings.Contains(string(content), `body["type"] = instance.Spec.PetType`) {
+		t.Error("expected the action to send spec.petType as type")
+	}
+}
+
+func TestControllerGenerator_DryRun(t *testing.T) {
+	crds := []*mapper.CRDDefinition{
+		{APIGroup: "test.example.com", APIVersion: "v1alpha1", Kind: "Widget", Plural: "widgets", BasePath: "/widgets", HasPost: true},
+		{APIGroup: "test.example.com", APIVersion: "v1alpha1", Kind: "WidgetReboot", Plural: "widgetreboots", IsAction: true, ActionPath: "/widgets/{id}/reboot", ActionMethod: "POST"},
+	}
+
+	for _, enabled := range []bool{false, true} {
+		tmpDir := t.TempDir()
+		cfg := &config.Config{
+			OutputDir:     tmpDir,
+			APIGroup:      "test.example.com",
+			APIVersion:    "v1alpha1",
+			ModuleName:    "github.com/example/widget-operator",
+			SupportDryRun: enabled,
+		}
+		if err := NewControllerGenerator(cfg).Generate(crds, nil, nil); err != nil {
+			t.Fatalf("Generate failed: %v", err)
+		}
+
+		mainContent, err := os.ReadFile(filepath.Join(tmpDir, "cmd", "manager", "main.go"))
+		if err != nil {
+			t.Fatalf("failed to read main.go: %v", err)
+		}
+		for _, want := range []string{`"dry-run-external", false`, `os.Getenv("DRY_RUN_EXTERNAL")`, "controllerutil2.NewDryRunTransport(httpClient.Transport)"} {
+			if strings.Contains(string(mainContent), want) != enabled {
+				t.Errorf("SupportDryRun=%v: main.go contains %q = %v", enabled, want, !enabled)
+			}
+		}
+
+		for _, file := range []string{"widget_controller.go", "widgetreboot_controller.go"} {
+			content, err := os.ReadFile(filepath.Join(tmpDir, "internal", "controller", file))
+			if err != nil {
+				t.Fatalf("failed to read %s: %v", file, err)
+			}
+			for _, want := range []string{"controllerutil2.DryRunCondition(", "controllerutil2.DryRunConditionType"} {
+				if strings.Contains(string(content), want) != enabled {
+					t.Errorf("SupportDryRun=%v: %s contains %q = %v", enabled, file, want, !enabled)
+				}
+			}
+		}
 	}
+}
 
+func TestControllerGenerator_Servers(t *testing.T) {
 	crds := []*mapper.CRDDefinition{
+		{APIGroup: "test.example.com", APIVersion: "v1alpha1", Kind: "Widget", Plural: "widgets", BasePath: "/widgets"},
+	}
+	servers := []config.SpecServer{
+		{Name: "prod", URL: "https://api.example.com/v1", Description: "Production"},
+		{URL: "https://staging.example.com/v1", Description: "Staging"},
+	}
+	environmentServers := []config.SpecServer{
+		{URL: "https://api.example.com/v1", Environment: "prod"},
+		{URL: "https://staging.example.com/v1", Environment: "staging"},
+	}
+
+	tests := []struct {
+		name         string
+		servers      []config.SpecServer
+		selector     string
+		environment  string
+		wantContains []string
+		wantMissing  []string
+		wantErr      string
+	}{
 		{
-			APIGroup:   "petstore.example.com",
-			APIVersion: "v1alpha1",
-			Kind:       "Pet",
-			Plural:     "pets",
-			ShortNames: []string{"pe", "pet"},
-			Scope:      "Namespaced",
-			BasePath:   "/pet",
+			name:        "no servers",
+			wantMissing: []string{"apiServers", `"server"`, "REST_API_SERVER"},
+		},
+		{
+			name:    "servers without selector",
+			servers: servers,
+			wantContains: []string{
+				`{Name: "prod", URL: "https://api.example.com/v1", Description: "Production"},`,
+				`flag.StringVar(&serverSelector, "server", "",`,
+				`serverSelector = os.Getenv("REST_API_SERVER")`,
+				"endpoint.SelectServer(apiServers, serverSelector)",
+			},
+			wantMissing: []string{"(default: "},
+		},
+		{
+			name:         "servers with selector",
+			servers:      servers,
+			selector:     "staging",
+			wantContains: []string{`serverSelector = "staging"`, "(default: staging)"},
+		},
+		{
+			name:     "unknown selector",
+			servers:  servers,
+			selector: "dev",
+			wantErr:  `no server matches "dev"`,
+		},
+		{
+			name:        "environment is the default server",
+			servers:     environmentServers,
+			environment: "staging",
+			wantContains: []string{
+				`{Name: "", Environment: "staging", URL: "https://staging.example.com/v1", Description: ""},`,
+				`serverSelector = "staging"`,
+			},
+		},
+		{
+			name:         "selector wins over environment",
+			servers:      environmentServers,
+			selector:     "prod",
+			environment:  "staging",
+			wantContains: []string{`serverSelector = "prod"`},
+			wantMissing:  []string{`serverSelector = "staging"`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir := t.TempDir()
+			cfg := &config.Config{
+				OutputDir:      tmpDir,
+				APIGroup:       "test.example.com",
+				APIVersion:     "v1alpha1",
+				ModuleName:     "github.com/example/widget-operator",
+				SpecServers:    tt.servers,
+				ServerSelector: tt.selector,
+				Environment:    tt.environment,
+			}
+			err := NewControllerGenerator(cfg).Generate(crds, nil, nil)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("Generate error = %v, want containing %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Generate failed: %v", err)
+			}
+
+			content, err := os.ReadFile(filepath.Join(tmpDir, "cmd", "manager", "main.go"))
+			if err != nil {
+				t.Fatalf("failed to read main.go: %v", err)
+			}
+			for _, want := range tt.wantContains {
+				if !strings.Contains(string(content), want) {
+					t.Errorf("main.go missing %q", want)
+				}
+			}
+			for _, unwanted := range tt.wantMissing {
+				if strings.Contains(string(content), unwanted) {
+					t.Errorf("main.go should not contain %q", unwanted)
+				}
+			}
+		})
+	}
+}
+
+func TestControllerGenerator_StatusResultLimit(t *testing.T) {
+	crds := []*mapper.CRDDefinition{
+		{APIGroup: "test.example.com", APIVersion: "v1alpha1", Kind: "WidgetSearch", Plural: "widgetsearches", IsQuery: true, QueryPath: "/widgets/search"},
+	}
+
+	for _, limit := range []int{0, 25} {
+		tmpDir := t.TempDir()
+		cfg := &config.Config{
+			OutputDir:       tmpDir,
+			APIGroup:        "test.example.com",
+			APIVersion:      "v1alpha1",
+			ModuleName:      "github.com/example/widget-operator",
+			MaxQueryResults: limit,
+		}
+		if err := NewControllerGenerator(cfg).Generate(crds, nil, nil); err != nil {
+			t.Fatalf("Generate failed: %v", err)
+		}
+		content, err := os.ReadFile(filepath.Join(tmpDir, "internal", "controller", "widgetsearch_controller.go"))
+		if err != nil {
+			t.Fatalf("failed to read controller: %v", err)
+		}
+		contentStr := string(content)
+
+		enabled := limit > 0
+		for _, want := range []string{"widgetsearchMaxResults = 25", "Raw: r.truncateResults(body)", "return resultCount > widgetsearchMaxResults"} {
+			if strings.Contains(contentStr, want) != enabled {
+				t.Errorf("MaxQueryResults=%d: controller contains %q = %v", limit, want, !enabled)
+			}
+		}
+		if !strings.Contains(contentStr, "instance.Status.Truncated = r.resultsTruncated(resultCount)") {
+			t.Errorf("MaxQueryResults=%d: controller does not set status.truncated", limit)
+		}
+	}
+}
+
+func TestControllerGenerator_MultipartAction(t *testing.T) {
+	tmpDir := t.TempDir()
+	crds := []*mapper.CRDDefinition{
+		{
+			APIGroup:          "test.example.com",
+			APIVersion:        "v1alpha1",
+			Kind:              "DocumentUpload",
+			Plural:            "documentuploads",
+			IsAction:          true,
+			ActionPath:        "/documents/upload",
+			ActionMethod:      "POST",
+			HasBinaryBody:     true,
+			BinaryContentType: "multipart/form-data",
+			IsMultipart:       true,
+			FormFields:        []string{"page_count"},
+			FileField:         "file",
 			Spec: &mapper.FieldDefinition{
 				Fields: []*mapper.FieldDefinition{
-					{Name: "Name", JSONName: "name", GoType: "string", Required: true},
-					{Name: "Status", JSONName: "status", GoType: "string", Enum: []string{"available", "pending", "sold"}},
-					{
-						Name:     "Category",
-						JSONName: "category",
-						GoType:   "struct",
-						Fields: []*mapper.FieldDefinition{
-							{Name: "Id", JSONName: "id", GoType: "int64"},
-							{Name: "Name", JSONName: "name", GoType: "string"},
-						},
-					},
+					{Name: "PageCount", JSONName: "pageCount", GoType: "int64"},
+					{Name: "Data", JSONName: "data", GoType: "string"},
 				},
 			},
 		},
 	}
+	cfg := &config.Config{
+		OutputDir:  tmpDir,
+		APIGroup:   "test.example.com",
+		APIVersion: "v1alpha1",
+		ModuleName: "github.com/example/document-operator",
+	}
+	if err := NewControllerGenerator(cfg).Generate(crds, nil, nil); err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
 
-	// Generate all files
-	typesGen := NewTypesGenerator(cfg)
-	if err := typesGen.Generate(crds); err != nil {
-		t.Fatalf("TypesGenerator.Generate failed: %v", err)
+	content, err := os.ReadFile(filepath.Join(tmpDir, "internal", "controller", "documentupload_controller.go"))
+	if err != nil {
+		t.Fatalf("failed to read controller: %v", err)
 	}
+	contentStr := string(content)
+	for _, want := range []string{
+		`writer.WriteField("page_count", value)`,
+		`r.formValue(instance.Spec.PageCount)`,
+		`form-data; name="file"; filename="file"`,
+		`return r.buildMultipartBody(instance, data)`,
+		`"multipart/form-data; boundary=" + documentuploadMultipartBoundary`,
+	} {
+		if !strings.Contains(contentStr, want) {
+			t.Errorf("controller missing %q", want)
+		}
+	}
+}
 
-	crdGen := NewCRDGenerator(cfg)
-	if err := crdGen.Generate(crds); err != nil {
-		t.Fatalf("CRDGenerator.Generate failed: %v", err)
+func TestControllerGenerator_WatchDataFrom(t *testing.T) {
+	for _, watch := range []bool{false, true} {
+		tmpDir := t.TempDir()
+		crds := []*mapper.CRDDefinition{
+			{
+				APIGroup:          "test.example.com",
+				APIVersion:        "v1alpha1",
+				Kind:              "ImageUpload",
+				Plural:            "imageuploads",
+				IsAction:          true,
+				ActionPath:        "/images",
+				ActionMethod:      "POST",
+				HasBinaryBody:     true,
+				BinaryContentType: "application/octet-stream",
+				WatchDataFrom:     watch,
+			},
+		}
+		cfg := &config.Config{
+			OutputDir:  tmpDir,
+			APIGroup:   "test.example.com",
+			APIVersion: "v1alpha1",
+			ModuleName: "github.com/example/image-operator",
+		}
+		if err := NewControllerGenerator(cfg).Generate(crds, nil, nil); err != nil {
+			t.Fatalf("Generate failed: %v", err)
+		}
+
+		content, err := os.ReadFile(filepath.Join(tmpDir, "internal", "controller", "imageupload_controller.go"))
+		if err != nil {
+			t.Fatalf("failed to read controller: %v", err)
+		}
+		contentStr := string(content)
+		for _, want := range []string{
+			`// +kubebuilder:rbac:groups="",resources=configmaps;secrets,verbs=get;list;watch`,
+			`Watches(&corev1.ConfigMap{}, handler.EnqueueRequestsFromMapFunc(r.requestsForDataFrom))`,
+			`Watches(&corev1.Secret{}, handler.EnqueueRequestsFromMapFunc(r.requestsForDataFrom))`,
+			`version != instance.Status.DataFromVersion`,
+			`instance.Status.DataFromVersion = cm.ResourceVersion`,
+			`"sigs.k8s.io/controller-runtime/pkg/handler"`,
+		} {
+			if strings.Contains(contentStr, want) != watch {
+				t.Errorf("WatchDataFrom=%v: contains %q = %v", watch, want, !watch)
+			}
+		}
 	}
+}
 
-	controllerGen := NewControllerGenerator(cfg)
-	if err := controllerGen.Generate(crds, nil, nil); err != nil {
-		t.Fatalf("ControllerGenerator.Generate failed: %v", err)
+func TestControllerGenerator_FormEncoded(t *testing.T) {
+	tmpDir := t.TempDir()
+	crds := []*mapper.CRDDefinition{
+		{
+			APIGroup:     "test.example.com",
+			APIVersion:   "v1alpha1",
+			Kind:         "Account",
+			Plural:       "accounts",
+			BasePath:     "/accounts",
+			ResourcePath: "/accounts/{id}",
+			HasPost:      true,
+			HasPut:       true,
+			FormEncoded:  true,
+		},
+		{
+			APIGroup:     "test.example.com",
+			APIVersion:   "v1alpha1",
+			Kind:         "Login",
+			Plural:       "logins",
+			IsAction:     true,
+			ActionPath:   "/login",
+			ActionMethod: "POST",
+			FormEncoded:  true,
+			Spec: &mapper.FieldDefinition{
+				Fields: []*mapper.FieldDefinition{{Name: "Username", JSONName: "username", GoType: "string"}},
+			},
+		},
+	}
+	cfg := &config.Config{
+		OutputDir:  tmpDir,
+		APIGroup:   "test.example.com",
+		APIVersion: "v1alpha1",
+		ModuleName: "github.com/example/account-operator",
+	}
+	if err := NewControllerGenerator(cfg).Generate(crds, nil, nil); err != nil {
+		t.Fatalf("Generate failed: %v", err)
 	}
 
-	// Verify all expected files exist
-	expectedFiles := []string{
-		"api/v1alpha1/types.go",
-		"api/v1alpha1/groupversion_info.go",
-		"config/crd/bases/petstore.example.com_pets.yaml",
-		"internal/controller/pet_controller.go",
-		"cmd/manager/main.go",
-		"go.mod",
-		"Dockerfile",
-		"Makefile",
-		"hack/boilerplate.go.txt",
+	files := map[string][]string{
+		"account_controller.go": {
+			"specData, err = controllerutil2.EncodeForm(specData)",
+			"requestBody, err = controllerutil2.EncodeForm(requestBody)",
+			`req.Header.Set("Content-Type", controllerutil2.FormContentType)`,
+		},
+		"login_controller.go": {
+			"return controllerutil2.EncodeForm(encoded)",
+			`req.Header.Set("Content-Type", controllerutil2.FormContentType)`,
+		},
+	}
+	for file, wants := range files {
+		content, err := os.ReadFile(filepath.Join(tmpDir, "internal", "controller", file))
+		if err != nil {
+			t.Fatalf("failed to read %s: %v", file, err)
+		}
+		for _, want := range wants {
+			if !strings.Contains(string(content), want) {
+				t.Errorf("expected %s to contain %q", file, want)
+			}
+		}
+		if strings.Contains(string(content), `req.Header.Set("Content-Type", "application/json")`) {
+			t.Errorf("expected %s to send no JSON bodies", file)
+		}
 	}
+}
 
-	for _, file := range expectedFiles {
-		path := filepath.Join(tmpDir, file)
-		if _, err := os.Stat(path); os.IsNotExist(err) {
-			t.Errorf("expected file %s to exist", file)
+func TestControllerGenerator_RequestContentType(t *testing.T) {
+	tmpDir := t.TempDir()
+	crds := []*mapper.CRDDefinition{
+		{
+			APIGroup:     "test.example.com",
+			APIVersion:   "v1alpha1",
+			Kind:         "Widget",
+			Plural:       "widgets",
+			BasePath:     "/widgets",
+			ResourcePath: "/widgets/{id}",
+			HasPost:      true,
+			HasPatch:     true,
+			Operations: []mapper.OperationMapping{
+				{CRDAction: "Create", HTTPMethod: "POST", Path: "/widgets", ContentType: "application/vnd.example.widget+json"},
+				{CRDAction: "Update", HTTPMethod: "PATCH", Path: "/widgets/{id}", ContentType: "application/json-patch+json"},
+			},
+		},
+		{
+			APIGroup:     "test.example.com",
+			APIVersion:   "v1alpha1",
+			Kind:         "WidgetRename",
+			Plural:       "widgetrenames",
+			IsAction:     true,
+			ActionPath:   "/widgets/{id}/rename",
+			ActionMethod: "POST",
+			Operations: []mapper.OperationMapping{
+				{CRDAction: "Execute", HTTPMethod: "POST", Path: "/widgets/{id}/rename", ContentType: "application/vnd.example.rename+json"},
+			},
+		},
+	}
+	cfg := &config.Config{
+		OutputDir:  tmpDir,
+		APIGroup:   "test.example.com",
+		APIVersion: "v1alpha1",
+		ModuleName: "github.com/example/widget-operator",
+	}
+	if err := NewControllerGenerator(cfg).Generate(crds, nil, nil); err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+
+	files := map[string][]string{
+		"widget_controller.go": {
+			`req.Header.Set("Content-Type", "application/vnd.example.widget+json")`,
+			// JSON Patch doesn't fit the merge patch the controller computes
+			`req.Header.Set("Content-Type", "application/merge-patch+json")`,
+		},
+		"widgetrename_controller.go": {
+			`req.Header.Set("Content-Type", "application/vnd.example.rename+json")`,
+		},
+	}
+	for file, wants := range files {
+		content, err := os.ReadFile(filepath.Join(tmpDir, "internal", "controller", file))
+		if err != nil {
+			t.Fatalf("failed to read %s: %v", file, err)
+		}
+		for _, want := range wants {
+			if !strings.Contains(string(content), want) {
+				t.Errorf("expected %s to contain %q", file, want)
+			}
+		}
+		if strings.Contains(string(content), "json-patch") {
+			t.Errorf("expected %s not to send a JSON Patch media type", file)
 		}
 	}
+}
 
-	// Verify types.go has nested Category type
-	content, _ := os.ReadFile(filepath.Join(tmpDir, "api", "v1alpha1", "types.go"))
-	if !strings.Contains(string(content), "PetCategory") {
-		t.Error("expected PetCategory nested type in types.go")
+func TestControllerGenerator_IdempotencyHeader(t *testing.T) {
+	crds := []*mapper.CRDDefinition{
+		{
+			APIGroup:     "test.example.com",
+			APIVersion:   "v1alpha1",
+			Kind:         "Charge",
+			Plural:       "charges",
+			BasePath:     "/charges",
+			ResourcePath: "/charges/{id}",
+			HasPost:      true,
+		},
+		{
+			APIGroup:     "test.example.com",
+			APIVersion:   "v1alpha1",
+			Kind:         "Refund",
+			Plural:       "refunds",
+			IsAction:     true,
+			ActionPath:   "/refunds",
+			ActionMethod: "POST",
+		},
+	}
+
+	for _, header := range []string{"", "Idempotency-Key"} {
+		tmpDir := t.TempDir()
+		cfg := &config.Config{
+			OutputDir:         tmpDir,
+			APIGroup:          "test.example.com",
+			APIVersion:        "v1alpha1",
+			ModuleName:        "github.com/example/charge-operator",
+			IdempotencyHeader: header,
+		}
+		if err := NewControllerGenerator(cfg).Generate(crds, nil, nil); err != nil {
+			t.Fatalf("Generate failed: %v", err)
+		}
+
+		files := map[string]string{
+			"charge_controller.go": `req.Header.Set("Idempotency-Key", controllerutil2.IdempotencyKey(instance.UID, instance.Generation))`,
+			"refund_controller.go": `req.Header.Set("Idempotency-Key", controllerutil2.ExecutionIdempotencyKey(instance.UID, instance.Generation, instance.Status.ExecutionCount))`,
+		}
+		for file, want := range files {
+			content, err := os.ReadFile(filepath.Join(tmpDir, "internal", "controller", file))
+			if err != nil {
+				t.Fatalf("failed to read %s: %v", file, err)
+			}
+			if got := strings.Contains(string(content), want); got != (header != "") {
+				t.Errorf("header %q: %s contains idempotency key = %v", header, file, got)
+			}
+		}
+	}
+}
+
+func TestControllerGenerator_MergeControllers(t *testing.T) {
+	tmpDir := t.TempDir()
+	crds := []*mapper.CRDDefinition{
+		{APIGroup: "test.example.com", APIVersion: "v1alpha1", Kind: "Widget", Plural: "widgets", BasePath: "/widgets"},
+		{APIGroup: "test.example.com", APIVersion: "v1alpha1", Kind: "Gadget", Plural: "gadgets", BasePath: "/gadgets"},
+	}
+	newConfig := func(merge bool) *config.Config {
+		return &config.Config{
+			OutputDir:        tmpDir,
+			APIGroup:         "test.example.com",
+			APIVersion:       "v1alpha1",
+			ModuleName:       "github.com/example/widget-operator",
+			MergeControllers: merge,
+		}
+	}
+	if err := NewControllerGenerator(newConfig(false)).Generate(crds, nil, nil); err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+	saved, err := config.LoadConfigFile(filepath.Join(tmpDir, ".openapi-operator-gen.yaml"))
+	if err != nil || saved == nil {
+		t.Fatalf("failed to load saved config: %v", err)
+	}
+	if saved.ControllerHashes["internal/controller/widget_controller.go"] == "" {
+		t.Fatalf("expected controller hashes to be saved, got %v", saved.ControllerHashes)
+	}
+
+	// Hand-edit the widget controller, then regenerate in merge mode
+	widgetPath := filepath.Join(tmpDir, "internal", "controller", "widget_controller.go")
+	gadgetPath := filepath.Join(tmpDir, "internal", "controller", "gadget_controller.go")
+	edited := []byte("// hand-tuned\npackage controller\n")
+	if err := os.WriteFile(widgetPath, edited, 0644); err != nil {
+		t.Fatal(err)
+	}
+	gen := NewControllerGenerator(newConfig(true))
+	if err := gen.Generate(crds, nil, nil); err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+
+	if content, _ := os.ReadFile(widgetPath); string(content) != string(edited) {
+		t.Error("hand-edited controller was overwritten in merge mode")
+	}
+	if _, err := os.Stat(widgetPath + ".new"); err != nil {
+		t.Errorf("expected widget_controller.go.new: %v", err)
+	}
+	if _, err := os.Stat(gadgetPath + ".new"); err == nil {
+		t.Error("unchanged controller should be overwritten, not written as .new")
+	}
+	if got := gen.PendingMerges(); len(got) != 1 || got[0] != "internal/controller/widget_controller.go.new" {
+		t.Errorf("PendingMerges() = %v", got)
+	}
+
+	// Without merge mode the edit is overwritten
+	if err := NewControllerGenerator(newConfig(false)).Generate(crds, nil, nil); err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+	if content, _ := os.ReadFile(widgetPath); string(content) == string(edited) {
+		t.Error("expected controller to be overwritten without merge mode")
+	}
+}
+
+func TestControllerGenerator_ManagerSecurityContext(t *testing.T) {
+	crds := []*mapper.CRDDefinition{
+		{APIGroup: "test.example.com", APIVersion: "v1alpha1", Kind: "Widget", Plural: "widgets", BasePath: "/widgets"},
+	}
+	tests := []struct {
+		name     string
+		sc       config.SecurityContextConfig
+		wants    []string
+		notWants []string
+	}{
+		{
+			name: "hardened by default",
+			wants: []string{
+				"runAsNonRoot: true", "type: RuntimeDefault", "allowPrivilegeEscalation: false",
+				"readOnlyRootFilesystem: true", "drop:\n            - ALL",
+			},
+			notWants: []string{"add:"},
+		},
+		{
+			name: "relaxed",
+			sc: config.SecurityContextConfig{
+				AllowRunAsRoot:         true,
+				WritableRootFilesystem: true,
+				AddCapabilities:        []string{"NET_BIND_SERVICE"},
+				SeccompProfile:         "Unconfined",
+			},
+			wants:    []string{"type: Unconfined", "readOnlyRootFilesystem: false", "add:\n            - NET_BIND_SERVICE"},
+			notWants: []string{"runAsNonRoot"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir := t.TempDir()
+			cfg := &config.Config{
+				OutputDir:       tmpDir,
+				APIGroup:        "test.example.com",
+				APIVersion:      "v1alpha1",
+				ModuleName:      "github.com/example/widget-operator",
+				SecurityContext: tt.sc,
+			}
+			if err := NewControllerGenerator(cfg).Generate(crds, nil, nil); err != nil {
+				t.Fatalf("Generate failed: %v", err)
+			}
+			content, err := os.ReadFile(filepath.Join(tmpDir, "config", "manager", "manager.yaml"))
+			if err != nil {
+				t.Fatalf("failed to read manager.yaml: %v", err)
+			}
+			for _, want := range tt.wants {
+				if !strings.Contains(string(content), want) {
+					t.Errorf("manager.yaml missing %q", want)
+				}
+			}
+			for _, notWant := range tt.notWants {
+				if strings.Contains(string(content), notWant) {
+					t.Errorf("manager.yaml should not contain %q", notWant)
+				}
+			}
+		})
+	}
+}
+
+func TestControllerGenerator_HighAvailability(t *testing.T) {
+	crds := []*mapper.CRDDefinition{
+		{APIGroup: "test.example.com", APIVersion: "v1alpha1", Kind: "Widget", Plural: "widgets", BasePath: "/widgets"},
+	}
+	tests := []struct {
+		name              string
+		ha                bool
+		priorityClassName string
+		managerWants      []string
+		managerNotWants   []string
+		kustomizeWants    []string
+		files             map[string]bool
+	}{
+		{
+			name:            "default",
+			managerWants:    []string{"replicas: 1"},
+			managerNotWants: []string{"priorityClassName", "podAntiAffinity"},
+			files:           map[string]bool{"pdb.yaml": false, "priority_class.yaml": false},
+		},
+		{
+			name:           "ha",
+			ha:             true,
+			managerWants:   []string{"replicas: 2", "priorityClassName: test-controller-manager", "podAntiAffinity:"},
+			kustomizeWants: []string{"- pdb.yaml", "- priority_class.yaml"},
+			files:          map[string]bool{"pdb.yaml": true, "priority_class.yaml": true},
+		},
+		{
+			name:              "ha with existing priority class",
+			ha:                true,
+			priorityClassName: "system-cluster-critical",
+			managerWants:      []string{"replicas: 2", "priorityClassName: system-cluster-critical"},
+			kustomizeWants:    []string{"- pdb.yaml"},
+			files:             map[string]bool{"pdb.yaml": true, "priority_class.yaml": false},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir := t.TempDir()
+			cfg := &config.Config{
+				OutputDir:         tmpDir,
+				APIGroup:          "test.example.com",
+				APIVersion:        "v1alpha1",
+				ModuleName:        "github.com/example/widget-operator",
+				HighAvailability:  tt.ha,
+				PriorityClassName: tt.priorityClassName,
+			}
+			if err := NewControllerGenerator(cfg).Generate(crds, nil, nil); err != nil {
+				t.Fatalf("Generate failed: %v", err)
+			}
+			managerDir := filepath.Join(tmpDir, "config", "manager")
+			manager, err := os.ReadFile(filepath.Join(managerDir, "manager.yaml"))
+			if err != nil {
+				t.Fatalf("failed to read manager.yaml: %v", err)
+			}
+			for _, want := range tt.managerWants {
+				if !strings.Contains(string(manager), want) {
+					t.Errorf("manager.yaml missing %q", want)
+				}
+			}
+			for _, notWant := range tt.managerNotWants {
+				if strings.Contains(string(manager), notWant) {
+					t.Errorf("manager.yaml should not contain %q", notWant)
+				}
+			}
+			kustomization, err := os.ReadFile(filepath.Join(managerDir, "kustomization.yaml"))
+			if err != nil {
+				t.Fatalf("failed to read kustomization.yaml: %v", err)
+			}
+			for _, want := range tt.kustomizeWants {
+				if !strings.Contains(string(kustomization), want) {
+					t.Errorf("kustomization.yaml missing %q", want)
+				}
+			}
+			for file, want := range tt.files {
+				if _, err := os.Stat(filepath.Join(managerDir, file)); (err == nil) != want {
+					t.Errorf("%s exists = %v, want %v", file, err == nil, want)
+				}
+			}
+			if tt.ha {
+				pdb, err := os.ReadFile(filepath.Join(managerDir, "pdb.yaml"))
+				if err != nil {
+					t.Fatalf("failed to read pdb.yaml: %v", err)
+				}
+				if !strings.Contains(string(pdb), "minAvailable: 1") {
+					t.Errorf("pdb.yaml should keep all but one replica available, got:\n%s", pdb)
+				}
+			}
+
+			mainGo, err := os.ReadFile(filepath.Join(tmpDir, "cmd", "manager", "main.go"))
+			if err != nil {
+				t.Fatalf("failed to read main.go: %v", err)
+			}
+			wantFlag := `flag.BoolVar(&enableLeaderElection, "leader-elect", ` + strconv.FormatBool(tt.ha) + ","
+			if !strings.Contains(string(mainGo), wantFlag) {
+				t.Errorf("main.go missing %q", wantFlag)
+			}
+		})
+	}
+}
+
+func TestControllerGenerator_WatchNamespaces(t *testing.T) {
+	crds := []*mapper.CRDDefinition{
+		{APIGroup: "test.example.com", APIVersion: "v1alpha1", Kind: "Widget", Plural: "widgets", BasePath: "/widgets"},
+	}
+	tmpDir := t.TempDir()
+	cfg := &config.Config{
+		OutputDir:       tmpDir,
+		APIGroup:        "test.example.com",
+		APIVersion:      "v1alpha1",
+		ModuleName:      "github.com/example/widget-operator",
+		WatchNamespaces: []string{"team-a", "team-b"},
+	}
+	if err := NewControllerGenerator(cfg).Generate(crds, nil, nil); err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+
+	mainGo, err := os.ReadFile(filepath.Join(tmpDir, "cmd", "manager", "main.go"))
+	if err != nil {
+		t.Fatalf("failed to read main.go: %v", err)
+	}
+	if !strings.Contains(string(mainGo), `watchNamespaces = "team-a,team-b"`) {
+		t.Error("main.go should default --watch-namespaces to the configured namespaces")
+	}
+
+	rbacDir := filepath.Join(tmpDir, "config", "rbac")
+	roleBinding, err := os.ReadFile(filepath.Join(rbacDir, "role_binding.yaml"))
+	if err != nil {
+		t.Fatalf("failed to read role_binding.yaml: %v", err)
+	}
+	if !strings.Contains(string(roleBinding), "only watches team-a, team-b by default") {
+		t.Errorf("role_binding.yaml should explain its cluster-wide scope, got:\n%s", roleBinding)
+	}
+	namespaced, err := os.ReadFile(filepath.Join(rbacDir, "namespaced_role_binding.yaml"))
+	if err != nil {
+		t.Fatalf("failed to read namespaced_role_binding.yaml: %v", err)
+	}
+	for _, want := range []string{"kind: RoleBinding", "namespace: team-a", "namespace: team-b", "name: manager-role", "namespace: test-system"} {
+		if !strings.Contains(string(namespaced), want) {
+			t.Errorf("namespaced_role_binding.yaml missing %q", want)
+		}
+	}
+	if got := strings.Count(string(namespaced), "kind: RoleBinding"); got != 2 {
+		t.Errorf("namespaced_role_binding.yaml has %d RoleBindings, want 2", got)
+	}
+
+	// Without watch namespaces the operator keeps watching every namespace
+	tmpDir = t.TempDir()
+	cfg.OutputDir = tmpDir
+	cfg.WatchNamespaces = nil
+	if err := NewControllerGenerator(cfg).Generate(crds, nil, nil); err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(tmpDir, "config", "rbac", "namespaced_role_binding.yaml")); err == nil {
+		t.Error("namespaced_role_binding.yaml should only be generated with watch namespaces")
+	}
+}
+
+func TestControllerGenerator_WebhookPatches(t *testing.T) {
+	tmpDir := t.TempDir()
+	cfg := &config.Config{
+		OutputDir:              tmpDir,
+		APIGroup:               "test.example.com",
+		APIVersion:             "v1alpha1",
+		ModuleName:             "github.com/example/widget-operator",
+		GenerateWebhookPatches: true,
+	}
+	crds := []*mapper.CRDDefinition{
+		{APIGroup: "test.example.com", APIVersion: "v1alpha1", Kind: "Widget", Plural: "widgets", BasePath: "/widgets"},
+	}
+	if err := NewControllerGenerator(cfg).Generate(crds, nil, nil); err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+
+	configDir := filepath.Join(tmpDir, "config")
+	expected := map[string][]string{
+		"crd/patches/webhook_in_widgets.yaml":     {"name: widgets.test.example.com", "strategy: Webhook", "namespace: test-system"},
+		"crd/patches/cainjection_in_widgets.yaml": {"cert-manager.io/inject-ca-from: test-system/serving-cert"},
+		"crd/kustomization.yaml":                  {"- bases", "- path: patches/webhook_in_widgets.yaml", "- path: patches/cainjection_in_widgets.yaml"},
+		"certmanager/certificate.yaml":            {"kind: Certificate", "webhook-service.test-system.svc", "secretName: webhook-server-cert"},
+		"webhook/service.yaml":                    {"name: webhook-service", "targetPort: 9443"},
+		"manager_webhook_patch.yaml":              {"secretName: webhook-server-cert"},
+		"kustomization.yaml":                      {"- crd\n", "- certmanager", "- webhook", "- path: manager_webhook_patch.yaml"},
+	}
+	for file, wants := range expected {
+		content, err := os.ReadFile(filepath.Join(configDir, file))
+		if err != nil {
+			t.Errorf("expected %s: %v", file, err)
+			continue
+		}
+		for _, want := range wants {
+			if !strings.Contains(string(content), want) {
+				t.Errorf("%s missing %q", file, want)
+			}
+		}
+	}
+
+	// Without the option, the default kustomization references the bases directly
+	plainDir := t.TempDir()
+	cfg.OutputDir = plainDir
+	cfg.GenerateWebhookPatches = false
+	if err := NewControllerGenerator(cfg).Generate(crds, nil, nil); err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+	content, err := os.ReadFile(filepath.Join(plainDir, "config", "kustomization.yaml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(content), "- crd/bases") || strings.Contains(string(content), "certmanager") {
+		t.Errorf("unexpected default kustomization without webhook patches:\n%s", content)
+	}
+	if _, err := os.Stat(filepath.Join(plainDir, "config", "crd", "patches")); err == nil {
+		t.Error("config/crd/patches should not be generated without webhook patches")
+	}
+}
+
+func TestControllerGenerator_WebhookReceiver(t *testing.T) {
+	tmpDir := t.TempDir()
+	cfg := &config.Config{
+		OutputDir:               tmpDir,
+		APIGroup:                "test.example.com",
+		APIVersion:              "v1alpha1",
+		ModuleName:              "github.com/example/widget-operator",
+		GenerateWebhookReceiver: true,
+		SpecWebhooks: []config.SpecWebhook{
+			{Name: "widgetChanged", Method: "POST", OperationID: "widgetChanged", Summary: "A widget\nchanged.", PayloadSchemaRef: "Widget"},
+			{Name: "audit", Method: "POST"},
+			{Name: "audit", Method: "PUT"},
+			{Name: "stock level {changed}", Method: "POST"},
+		},
+	}
+	crds := []*mapper.CRDDefinition{
+		{APIGroup: "test.example.com", APIVersion: "v1alpha1", Kind: "Widget", Plural: "widgets", BasePath: "/widgets", Scope: "Namespaced"},
+	}
+	if err := NewControllerGenerator(cfg).Generate(crds, nil, nil); err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+
+	receiver, err := os.ReadFile(filepath.Join(tmpDir, "internal", "webhookreceiver", "receiver.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		`mux.Handle("POST /webhooks/widgetChanged", r.authorize(r.handleWidgetChanged))`,
+		`mux.Handle("POST /webhooks/audit", r.authorize(r.handleAuditPost))`,
+		`mux.Handle("PUT /webhooks/audit", r.authorize(r.handleAuditPut))`,
+		`mux.Handle("POST /webhooks/stock-level-changed", r.authorize(r.handleStockLevelChanged))`,
+		`"webhook", "stock level {changed}"`,
+		"if !slices.Contains(r.Namespaces, ns) {",
+		`if r.Token == "" || subtle.ConstantTimeCompare(`,
+		"// handleWidgetChanged handles the widgetChanged webhook (POST, operationId: widgetChanged): A widget changed.\n",
+		"var spec v1alpha1.WidgetSpec",
+		`r.objectKey(req, "widget", true, payload)`,
+		"controllerutil.CreateOrUpdate(",
+		"func (r *Receiver) handleAuditPut(",
+		"http.StatusAccepted",
+	} {
+		if !strings.Contains(string(receiver), want) {
+			t.Errorf("receiver.go missing %q", want)
+		}
+	}
+
+	expected := map[string][]string{
+		"config/webhook-receiver/service.yaml":       {"name: webhook-receiver", "targetPort: 8082"},
+		"config/webhook-receiver/kustomization.yaml": {"- service.yaml"},
+		"config/kustomization.yaml":                  {"- webhook-receiver"},
+		"config/manager/manager.yaml":                {"- name: WEBHOOK_RECEIVER_TOKEN", "optional: true"},
+		"cmd/manager/main.go": {
+			`"github.com/example/widget-operator/internal/webhookreceiver"`,
+			`"webhook-receiver-bind-address", ":8082"`,
+			`"webhook-receiver-insecure", false`,
+			`if receiverToken == "" && !webhookReceiverInsecure {`,
+			"Namespaces: namespaceList,",
+			"mgr.Add(&webhookreceiver.Receiver{",
+		},
+	}
+	for file, wants := range expected {
+		content, err := os.ReadFile(filepath.Join(tmpDir, file))
+		if err != nil {
+			t.Errorf("expected %s: %v", file, err)
+			continue
+		}
+		for _, want := range wants {
+			if !strings.Contains(string(content), want) {
+				t.Errorf("%s missing %q", file, want)
+			}
+		}
+	}
+
+	// An x-k8s-kind naming a Kind that isn't a generated resource is an error
+	cfg.OutputDir = t.TempDir()
+	cfg.SpecWebhooks = []config.SpecWebhook{{Name: "gadgetChanged", Method: "POST", Kind: "Gadget"}}
+	if err := NewControllerGenerator(cfg).Generate(crds, nil, nil); err == nil || !strings.Contains(err.Error(), `x-k8s-kind "Gadget"`) {
+		t.Errorf("expected an error for the unknown x-k8s-kind, got %v", err)
+	}
+
+	// Names that map to the same receiver method, or to no path at all, are errors
+	for _, webhooks := range [][]config.SpecWebhook{
+		{{Name: "new-pet", Method: "POST"}, {Name: "newPet", Method: "POST"}},
+		{{Name: "{}", Method: "POST"}},
+	} {
+		cfg.OutputDir = t.TempDir()
+		cfg.SpecWebhooks = webhooks
+		if err := NewControllerGenerator(cfg).Generate(crds, nil, nil); err == nil {
+			t.Errorf("expected an error for the webhooks %v", webhooks)
+		}
+	}
+
+	// Without webhooks in the spec there is no receiver
+	cfg.OutputDir = t.TempDir()
+	cfg.SpecWebhooks = nil
+	if err := NewControllerGenerator(cfg).Generate(crds, nil, nil); err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(cfg.OutputDir, "internal", "webhookreceiver")); err == nil {
+		t.Error("internal/webhookreceiver should not be generated without webhooks")
+	}
+}
+
+func TestControllerGenerator_OwnerReference(t *testing.T) {
+	tmpDir := t.TempDir()
+	cfg := &config.Config{
+		OutputDir:  tmpDir,
+		APIGroup:   "test.example.com",
+		APIVersion: "v1alpha1",
+		ModuleName: "github.com/example/order-operator",
+	}
+	crds := []*mapper.CRDDefinition{
+		{
+			APIGroup: "test.example.com", APIVersion: "v1alpha1", Kind: "Order", Plural: "orders",
+			BasePath: "/orders", ResourcePath: "/orders/{orderId}", Scope: "Namespaced", HasPost: true,
+		},
+		{
+			APIGroup: "test.example.com", APIVersion: "v1alpha1", Kind: "Item", Plural: "items",
+			BasePath: "/orders/{orderId}/items", ResourcePath: "/orders/{orderId}/items/{itemId}", Scope: "Namespaced", ReadOnly: true,
+			Operations: []mapper.OperationMapping{
+				{CRDAction: "Get", HTTPMethod: "GET", Path: "/orders/{orderId}/items/{itemId}", PathParams: []string{"orderId", "itemId"}},
+			},
+			Spec: &mapper.FieldDefinition{Fields: []*mapper.FieldDefinition{
+				{Name: "OrderId", JSONName: "orderId", GoType: "int64", Required: true},
+				{Name: "ItemId", JSONName: "itemId", GoType: "string", Required: true},
+			}},
+			Owner: &mapper.OwnerDefinition{Kind: "Order", Plural: "orders", IDParam: "orderId", IDField: "Id", HasExternalID: true},
+		},
+	}
+	if err := NewControllerGenerator(cfg).Generate(crds, nil, nil); err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+
+	item, err := os.ReadFile(filepath.Join(tmpDir, "internal", "controller", "item_controller.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		"// +kubebuilder:rbac:groups=test.example.com,resources=orders,verbs=get;list;watch",
+		"if err := r.setOwnerReference(ctx, instance); err != nil {",
+		"if instance.GetDeletionTimestamp() == nil && !instance.Spec.Paused {",
+		"ownerID := controllerutil2.ResourceID(instance.Spec.OrderId)",
+		"var owners v1alpha1.OrderList",
+		"controllerutil2.ResourceID(owner.Spec.Id) != ownerID && owner.Status.ExternalID != ownerID",
+		"controllerutil.SetOwnerReference(owner, instance, r.Scheme)",
+	} {
+		if !strings.Contains(string(item), want) {
+			t.Errorf("item_controller.go missing %q", want)
+		}
+	}
+
+	order, err := os.ReadFile(filepath.Join(tmpDir, "internal", "controller", "order_controller.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(order), "setOwnerReference") {
+		t.Error("order_controller.go should not set an owner reference")
+	}
+}
+
+func TestControllerGenerator_ImportPrefix(t *testing.T) {
+	crds := []*mapper.CRDDefinition{
+		{APIGroup: "test.example.com", APIVersion: "v1alpha1", Kind: "Widget", Plural: "widgets", BasePath: "/widgets"},
+	}
+	newConfig := func(dir string) *config.Config {
+		return &config.Config{
+			OutputDir:    dir,
+			APIGroup:     "test.example.com",
+			APIVersion:   "v1alpha1",
+			ModuleName:   "github.com/example/widget-operator",
+			ImportPrefix: "github.com/example/monorepo/operators/widget",
+		}
+	}
+
+	tmpDir := t.TempDir()
+	if err := NewControllerGenerator(newConfig(tmpDir)).Generate(crds, nil, nil); err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+	mainGo, err := os.ReadFile(filepath.Join(tmpDir, "cmd", "manager", "main.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(mainGo), `"github.com/example/monorepo/operators/widget/api/v1alpha1"`) {
+		t.Error("expected main.go imports to use the import prefix")
+	}
+	goMod, err := os.ReadFile(filepath.Join(tmpDir, "go.mod"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(goMod), "module github.com/example/widget-operator\n") {
+		t.Errorf("expected go.mod to keep the module name, got:\n%s", goMod)
+	}
+
+	// SkipGoMod leaves go.mod to the enclosing module
+	nestedDir := t.TempDir()
+	cfg := newConfig(nestedDir)
+	cfg.SkipGoMod = true
+	if err := NewControllerGenerator(cfg).Generate(crds, nil, nil); err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(nestedDir, "go.mod")); err == nil {
+		t.Error("go.mod should not be generated with SkipGoMod")
+	}
+}
+
+func TestControllerGenerator_GenerateDashboard(t *testing.T) {
+	tmpDir := t.TempDir()
+	cfg := &config.Config{
+		OutputDir:  tmpDir,
+		APIGroup:   "test.example.com",
+		APIVersion: "v1alpha1",
+		ModuleName: "github.com/example/widget-operator",
+	}
+	crds := []*mapper.CRDDefinition{
+		{APIGroup: "test.example.com", APIVersion: "v1alpha1", Kind: "Widget", Plural: "widgets"},
+		{APIGroup: "test.example.com", APIVersion: "v1alpha1", Kind: "GadgetQuery", Plural: "gadgetqueries", IsQuery: true},
+	}
+	path, err := NewControllerGenerator(cfg).GenerateDashboard(crds, nil, nil)
+	if err != nil {
+		t.Fatalf("GenerateDashboard failed: %v", err)
+	}
+	if path != filepath.Join("dashboards", "test.json") {
+		t.Errorf("unexpected dashboard path %q", path)
+	}
+
+	content, err := os.ReadFile(filepath.Join(tmpDir, path))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var dashboard map[string]interface{}
+	if err := json.Unmarshal(content, &dashboard); err != nil {
+		t.Fatalf("dashboard is not valid JSON: %v", err)
+	}
+
+	for _, want := range []string{
+		`reconcile_total{otel_scope_name=~\"github.com/example/widget-operator/controller/$kind\"`,
+		"reconcile_duration_seconds_bucket",
+		"api_call_total",
+		"api_call_duration_seconds_bucket",
+		"query_total",
+		`"query": "widget,gadgetquery"`,
+		`apiserver_storage_objects{resource=~\"(widgets|gadgetqueries).test.example.com\"}`,
+	} {
+		if !strings.Contains(string(content), want) {
+			t.Errorf("dashboard missing %q", want)
+		}
+	}
+}
+
+func TestControllerGenerator_IntegrationTestExample(t *testing.T) {
+	tmpDir := t.TempDir()
+	cfg := &config.Config{
+		OutputDir:  tmpDir,
+		APIGroup:   "test.example.com",
+		APIVersion: "v1alpha1",
+		ModuleName: "github.com/example/widget-operator",
+	}
+	spec := &mapper.FieldDefinition{
+		Fields: []*mapper.FieldDefinition{
+			{Name: "Name", JSONName: "name", GoType: "string", Required: true},
+			{Name: "Size", JSONName: "size", GoType: "*int64"},
+			{Name: "Labels", JSONName: "labels", GoType: "map[string]string"},
+			{Name: "Tags", JSONName: "tags", GoType: "[]string"},
+			{Name: "Owner", JSONName: "owner", GoType: "*Owner", Fields: []*mapper.FieldDefinition{
+				{Name: "Email", JSONName: "email", GoType: "string"},
+			}},
+		},
+	}
+	example := map[string]interface{}{
+		"name":   "big",
+		"size":   1.5,                                    // not an integer: dropped
+		"labels": map[string]interface{}{"tier": "gold"}, // kept
+		"tags":   []interface{}{"a", 1.0},                // mixed item types: dropped
+		"owner":  map[string]interface{}{"email": "a@example.com", "phone": "123"},
+		"id":     7.0, // not a spec field: dropped
+	}
+	crds := []*mapper.CRDDefinition{
+		{
+			APIGroup: "test.example.com", APIVersion: "v1alpha1", Kind: "Widget", Plural: "widgets", BasePath: "/widgets",
+			HasPost: true, Spec: spec,
+			Operations: []mapper.OperationMapping{
+				{CRDAction: "Create", HTTPMethod: "POST", Path: "/widgets", RequestExample: example},
+			},
+		},
+		{
+			APIGroup: "test.example.com", APIVersion: "v1alpha1", Kind: "Gadget", Plural: "gadgets", BasePath: "/gadgets",
+			HasPost: true, Spec: spec,
+			Operations: []mapper.OperationMapping{
+				{CRDAction: "Create", HTTPMethod: "POST", Path: "/gadgets"},
+			},
+		},
+	}
+	if err := NewControllerGenerator(cfg).Generate(crds, nil, nil); err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+
+	content, err := os.ReadFile(filepath.Join(tmpDir, "internal", "controller", "widget_integration_test.go"))
+	if err != nil {
+		t.Fatalf("failed to read integration test: %v", err)
+	}
+	for _, want := range []string{
+		`"encoding/json"`,
+		"const widgetSpecExample = `" + `{"labels":{"tier":"gold"},"name":"big","owner":{"email":"a@example.com"}}` + "`",
+		"Expect(json.Unmarshal([]byte(widgetSpecExample), &widget.Spec)).To(Succeed())",
+		`Name: "test-value",`,
+	} {
+		if !strings.Contains(string(content), want) {
+			t.Errorf("integration test missing %q", want)
+		}
+	}
+
+	// Without an example, the test CRs keep the synthesized required fields only
+	content, err = os.ReadFile(filepath.Join(tmpDir, "internal", "controller", "gadget_integration_test.go"))
+	if err != nil {
+		t.Fatalf("failed to read integration test: %v", err)
+	}
+	if strings.Contains(string(content), "SpecExample") || strings.Contains(string(content), "encoding/json") {
+		t.Error("expected no spec example without a request body example")
+	}
+}
+
+func TestControllerGenerator_WriteOnlyFields(t *testing.T) {
+	tmpDir := t.TempDir()
+	cfg := &config.Config{
+		OutputDir:  tmpDir,
+		APIGroup:   "test.example.com",
+		APIVersion: "v1alpha1",
+		ModuleName: "github.com/example/widget-operator",
+	}
+	crds := []*mapper.CRDDefinition{
+		{
+			APIGroup: "test.example.com", APIVersion: "v1alpha1", Kind: "Widget", Plural: "widgets", BasePath: "/widgets",
+			HasPost: true, HasPut: true,
+			Spec: &mapper.FieldDefinition{
+				Fields: []*mapper.FieldDefinition{
+					{Name: "Name", JSONName: "name", GoType: "string"},
+					{Name: "Password", JSONName: "password", GoType: "string", WriteOnly: true},
+					{Name: "Auth", JSONName: "auth", GoType: "*Auth", Fields: []*mapper.FieldDefinition{
+						{Name: "Token", JSONName: "token", GoType: "string", WriteOnly: true},
+					}},
+				},
+			},
+		},
+	}
+	if err := NewControllerGenerator(cfg).Generate(crds, nil, nil); err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+	content, err := os.ReadFile(filepath.Join(tmpDir, "internal", "controller", "widget_controller.go"))
+	if err != nil {
+		t.Fatalf("failed to read controller: %v", err)
+	}
+	contentStr := string(content)
+	for _, want := range []string{
+		"var widgetWriteOnlyFields = []string{",
+		`"password",`,
+		`"auth.token",`,
+		"controllerutil2.RemoveFieldPaths(specMap, widgetWriteOnlyFields)",
+		"instance.Generation != instance.Status.WriteOnlyGeneration",
+	} {
+		if !strings.Contains(contentStr, want) {
+			t.Errorf("controller missing %q", want)
+		}
+	}
+	// Only successful writes record the generation sent, not every status update
+	if got := strings.Count(contentStr, "instance.Status.WriteOnlyGeneration = instance.Generation"); got != 2 {
+		t.Errorf("controller records the sent generation %d times, want 2 (POST and PUT)", got)
+	}
+
+	if err := NewTypesGenerator(cfg).Generate(crds); err != nil {
+		t.Fatalf("types Generate failed: %v", err)
+	}
+	types, err := os.ReadFile(filepath.Join(tmpDir, "api", "v1alpha1", "types.go"))
+	if err != nil {
+		t.Fatalf("failed to read types: %v", err)
+	}
+	if !strings.Contains(string(types), "WriteOnlyGeneration int64 `json:\"writeOnlyGeneration,omitempty\"`") {
+		t.Error("types missing status.writeOnlyGeneration")
+	}
+}
+
+func TestControllerGenerator_ArrayPathParams(t *testing.T) {
+	tmpDir := t.TempDir()
+	cfg := &config.Config{
+		OutputDir:  tmpDir,
+		APIGroup:   "test.example.com",
+		APIVersion: "v1alpha1",
+		ModuleName: "github.com/example/widget-operator",
+	}
+	crds := []*mapper.CRDDefinition{
+		{
+			APIGroup: "test.example.com", APIVersion: "v1alpha1", Kind: "Widget", Plural: "widgets",
+			BasePath: "/widgets", ResourcePath: "/widgets/{ids}", HasPost: true, HasPut: true,
+			Operations: []mapper.OperationMapping{
+				{CRDAction: "Get", HTTPMethod: "GET", Path: "/widgets/{ids}", PathParams: []string{"ids"}},
+			},
+			Spec: &mapper.FieldDefinition{
+				Fields: []*mapper.FieldDefinition{
+					{Name: "Ids", JSONName: "ids", GoType: "[]int64", Required: true,
+						ItemType: &mapper.FieldDefinition{GoType: "int64"}, PathStyle: "simple"},
+				},
+			},
+		},
+	}
+	if err := NewControllerGenerator(cfg).Generate(crds, nil, nil); err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+	content, err := os.ReadFile(filepath.Join(tmpDir, "internal", "controller", "widget_controller.go"))
+	if err != nil {
+		t.Fatalf("failed to read controller: %v", err)
+	}
+	contentStr := string(content)
+	for _, want := range []string{
+		`builder.WithPathParamIntArray("ids", instance.Spec.Ids, "simple", false)`,
+		"if len(instance.Spec.Ids) == 0 && instance.Status.ExternalID == \"\" {",
+	} {
+		if !strings.Contains(contentStr, want) {
+			t.Errorf("controller missing %q", want)
+		}
+	}
+}
+
+func TestControllerGenerator_StyledPathParams(t *testing.T) {
+	tmpDir := t.TempDir()
+	cfg := &config.Config{
+		OutputDir:  tmpDir,
+		APIGroup:   "test.example.com",
+		APIVersion: "v1alpha1",
+		ModuleName: "github.com/example/widget-operator",
+	}
+	crds := []*mapper.CRDDefinition{
+		{
+			APIGroup: "test.example.com", APIVersion: "v1alpha1", Kind: "Widget", Plural: "widgets",
+			BasePath: "/widgets", ResourcePath: "/widgets/{widgetId}", HasPost: true, HasPut: true,
+			Operations: []mapper.OperationMapping{
+				{CRDAction: "Get", HTTPMethod: "GET", Path: "/widgets/{widgetId}", PathParams: []string{"widgetId"}},
+			},
+			Spec: &mapper.FieldDefinition{
+				Fields: []*mapper.FieldDefinition{
+					{Name: "WidgetId", JSONName: "widgetId", GoType: "string", Required: true, PathStyle: "label"},
+				},
+			},
+		},
+		{
+			APIGroup: "test.example.com", APIVersion: "v1alpha1", Kind: "WidgetReboot", Plural: "widgetreboots",
+			IsAction: true, ActionPath: "/widgets/{widgetId}/reboot/{mode}", ActionMethod: "POST",
+			ParentIDParam: "widgetId", ParentIDGoType: "string",
+			Spec: &mapper.FieldDefinition{
+				Fields: []*mapper.FieldDefinition{
+					{Name: "WidgetId", JSONName: "widgetId", GoType: "string", Required: true, PathStyle: "matrix"},
+					{Name: "Mode", JSONName: "mode", GoType: "string", Required: true, PathStyle: "label"},
+				},
+			},
+		},
+		{
+			APIGroup: "test.example.com", APIVersion: "v1alpha1", Kind: "WidgetParts", Plural: "widgetparts",
+			IsQuery: true, QueryPath: "/widgets/{widgetId}/parts",
+			QueryPathParams: []mapper.QueryParamField{
+				{Name: "WidgetId", JSONName: "widgetId", GoType: "int64", BaseType: "int64", Required: true, Style: "matrix"},
+			},
+			Spec: &mapper.FieldDefinition{
+				Fields: []*mapper.FieldDefinition{
+					{Name: "WidgetId", JSONName: "widgetId", GoType: "int64", Required: true, PathStyle: "matrix"},
+				},
+			},
+		},
+	}
+	if err := NewControllerGenerator(cfg).Generate(crds, nil, nil); err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+
+	wants := map[string][]string{
+		"widget_controller.go": {
+			`builder.WithPathParamStyle("widgetId", "label")`,
+		},
+		"widget_controller_test.go": {
+			`expectedPath = strings.Replace(expectedPath, "{widgetId}", "."+testResourceID, 1)`,
+		},
+		"widgetreboot_controller.go": {
+			`builder.WithPathParamStyle("widgetId", "matrix")`,
+			`builder.WithPathParamStyle("mode", "label")`,
+		},
+		"widgetreboot_controller_test.go": {
+			`expectedPath = strings.Replace(expectedPath, "{widgetId}", ";widgetId="+testResourceID, 1)`,
+			`expectedPath = strings.Replace(expectedPath, "{mode}", "."+testResourceID, 1)`,
+		},
+		"widgetparts_controller.go": {
+			`builder.WithPathParamStyle("widgetId", "matrix")`,
+		},
+		"widgetparts_controller_test.go": {
+			`expectedPath = strings.Replace(expectedPath, "{widgetId}", ";widgetId="+fmt.Sprintf("%d", testResourceIDNumeric), 1)`,
+		},
+	}
+	for file, fileWants := range wants {
+		content, err := os.ReadFile(filepath.Join(tmpDir, "internal", "controller", file))
+		if err != nil {
+			t.Fatalf("failed to read %s: %v", file, err)
+		}
+		for _, want := range fileWants {
+			if !strings.Contains(string(content), want) {
+				t.Errorf("%s missing %q", file, want)
+			}
+		}
+	}
+
+	// The default simple style substitutes the value as is
+	crds[0].Spec.Fields[0].PathStyle = ""
+	if err := NewControllerGenerator(cfg).Generate(crds[:1], nil, nil); err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+	content, err := os.ReadFile(filepath.Join(tmpDir, "internal", "controller", "widget_controller.go"))
+	if err != nil {
+		t.Fatalf("failed to read controller: %v", err)
+	}
+	if strings.Contains(string(content), "WithPathParamStyle") {
+		t.Error("expected no path param style for the simple style")
+	}
+}
+
+func TestControllerGenerator_BundleAdopt(t *testing.T) {
+	bundle := &mapper.BundleDefinition{
+		APIGroup: "test.example.com", APIVersion: "v1alpha1", Kind: "WidgetBundle", Plural: "widgetbundles",
+		ResourceKinds: []string{"Widget"}, AllKinds: []string{"Widget"},
+	}
+
+	for _, adopt := range []bool{false, true} {
+		tmpDir := t.TempDir()
+		cfg := &config.Config{
+			OutputDir:   tmpDir,
+			APIGroup:    "test.example.com",
+			APIVersion:  "v1alpha1",
+			ModuleName:  "github.com/example/widget-operator",
+			BundleAdopt: adopt,
+		}
+		if err := NewControllerGenerator(cfg).GenerateBundleController(bundle); err != nil {
+			t.Fatalf("GenerateBundleController failed: %v", err)
+		}
+		content, err := os.ReadFile(filepath.Join(tmpDir, "internal", "controller", "widgetbundle_controller.go"))
+		if err != nil {
+			t.Fatalf("failed to read controller: %v", err)
+		}
+		contentStr := string(content)
+		for _, want := range []string{
+			"widgetbundleAdoptDefault = " + strconv.FormatBool(adopt),
+			"adopted, err := r.adoptChild(ctx, bundle, existing)",
+			"if adopted || !r.specsEqual(existing.Spec, child.Spec) {",
+		} {
+			if !strings.Contains(contentStr, want) {
+				t.Errorf("adopt=%v: controller missing %q", adopt, want)
+			}
+		}
+
+		testContent, err := os.ReadFile(filepath.Join(tmpDir, "internal", "controller", "widgetbundle_controller_test.go"))
+		if err != nil {
+			t.Fatalf("failed to read bundle controller test: %v", err)
+		}
+		if !strings.Contains(string(testContent), "r.syncWidget(context.Background(), bundle,") {
+			t.Error("bundle controller test should sync the first resource kind")
+		}
+	}
+}
+
+func TestKubectlPluginGenerator_KrewManifest(t *testing.T) {
+	crds := []*mapper.CRDDefinition{
+		{APIGroup: "test.example.com", APIVersion: "v1alpha1", Kind: "Widget", Plural: "widgets", BasePath: "/widgets"},
+	}
+
+	for _, enable := range []bool{false, true} {
+		tmpDir := t.TempDir()
+		cfg := &config.Config{
+			OutputDir:             tmpDir,
+			APIGroup:              "test.example.com",
+			APIVersion:            "v1alpha1",
+			ModuleName:            "github.com/example/widget-operator",
+			GenerateKubectlPlugin: true,
+			GenerateKrewManifest:  enable,
+			SpecVersion:           "2.1",
+		}
+		if err := NewKubectlPluginGenerator(cfg).Generate(crds, nil, nil); err != nil {
+			t.Fatalf("Generate failed: %v", err)
+		}
+		content, err := os.ReadFile(filepath.Join(tmpDir, "kubectl-plugin", "plugin.yaml"))
+		if !enable {
+			if err == nil {
+				t.Error("plugin.yaml should not be generated without GenerateKrewManifest")
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("failed to read plugin.yaml: %v", err)
+		}
+		contentStr := string(content)
+		for _, want := range []string{
+			"version: v2.1.0",
+			"homepage: https://github.com/example/widget-operator",
+			"https://github.com/example/widget-operator/releases/download/v2.1.0/kubectl-test_windows_amd64.zip",
+			"sha256: SHA256_LINUX_ARM64",
+			"bin: kubectl-test.exe",
+		} {
+			if !strings.Contains(contentStr, want) {
+				t.Errorf("plugin.yaml missing %q", want)
+			}
+		}
+	}
+}
+
+func TestGenerators_SampleNamespace(t *testing.T) {
+	crds := []*mapper.CRDDefinition{
+		{
+			APIGroup: "test.example.com", APIVersion: "v1alpha1", Kind: "Widget", Plural: "widgets", Scope: "Namespaced", BasePath: "/widgets",
+			Spec: &mapper.FieldDefinition{Fields: []*mapper.FieldDefinition{{Name: "Name", JSONName: "name", GoType: "string"}}},
+		},
+	}
+
+	tmpDir := t.TempDir()
+	cfg := &config.Config{
+		OutputDir:             tmpDir,
+		APIGroup:              "test.example.com",
+		APIVersion:            "v1alpha1",
+		ModuleName:            "github.com/example/widget-operator",
+		GenerateKubectlPlugin: true,
+		SampleNamespace:       "team-a",
+	}
+	if err := NewSamplesGenerator(cfg).Generate(crds, nil, nil); err != nil {
+		t.Fatalf("samples Generate failed: %v", err)
+	}
+	if err := NewKubectlPluginGenerator(cfg).Generate(crds, nil, nil); err != nil {
+		t.Fatalf("kubectl plugin Generate failed: %v", err)
+	}
+
+	wants := map[string]string{
+		"config/samples/v1alpha1_widget.yaml": "  namespace: team-a\n",
+		"kubectl-plugin/pkg/client/client.go": `namespace:  "team-a",`,
+		"kubectl-plugin/cmd/root.go":          `ns = "team-a"`,
+	}
+	for file, want := range wants {
+		content, err := os.ReadFile(filepath.Join(tmpDir, filepath.FromSlash(file)))
+		if err != nil {
+			t.Fatalf("failed to read %s: %v", file, err)
+		}
+		if !strings.Contains(string(content), want) {
+			t.Errorf("expected %s to contain %q", file, want)
+		}
+		for _, unwanted := range []string{"namespace: default", `namespace:  "default"`, `ns = "default"`} {
+			if strings.Contains(string(content), unwanted) {
+				t.Errorf("expected %s not to contain %q", file, unwanted)
+			}
+		}
+	}
+}
+
+func TestKubectlPluginGenerator_CmdTests(t *testing.T) {
+	crds := []*mapper.CRDDefinition{
+		{
+			APIGroup: "test.example.com", APIVersion: "v1alpha1", Kind: "Widget", Plural: "widgets", Scope: "Namespaced", BasePath: "/widgets",
+			Spec: &mapper.FieldDefinition{Fields: []*mapper.FieldDefinition{{Name: "Name", JSONName: "name", GoType: "string"}}},
+		},
+		{
+			APIGroup: "test.example.com", APIVersion: "v1alpha1", Kind: "WidgetSearchQuery", Plural: "widgetsearchqueries", Scope: "Namespaced", IsQuery: true,
+		},
+	}
+
+	t.Run("emits tests against a fake dynamic client", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		cfg := &config.Config{
+			OutputDir:             tmpDir,
+			APIGroup:              "test.example.com",
+			APIVersion:            "v1alpha1",
+			ModuleName:            "github.com/example/widget-operator",
+			GenerateKubectlPlugin: true,
+		}
+		if err := NewKubectlPluginGenerator(cfg).Generate(crds, nil, nil); err != nil {
+			t.Fatalf("Generate failed: %v", err)
+		}
+
+		content, err := os.ReadFile(filepath.Join(tmpDir, "kubectl-plugin", "cmd", "cmd_test.go"))
+		if err != nil {
+			t.Fatalf("failed to read cmd_test.go: %v", err)
+		}
+		for _, want := range []string{
+			`dynamicfake "k8s.io/client-go/dynamic/fake"`,
+			`"github.com/example/widget-operator/kubectl-plugin/pkg/client"`,
+			`{Group: "test.example.com", Version: "v1alpha1", Resource: "widgets"}: "WidgetList",`,
+			`{Group: "test.example.com", Version: "v1alpha1", Resource: "widgetsearchqueries"}: "WidgetSearchQueryList",`,
+			`"kind":       "Widget",`,
+			`client.NewClientFromDynamic(dynamicClient, "test.example.com", "v1alpha1")`,
+			"func TestGet(t *testing.T)",
+			"func TestDescribe(t *testing.T)",
+			"func TestDiagnose(t *testing.T)",
+			"func TestGraph(t *testing.T)",
+		} {
+			if !strings.Contains(string(content), want) {
+				t.Errorf("expected cmd_test.go to contain %q", want)
+			}
+		}
+
+		clientContent, err := os.ReadFile(filepath.Join(tmpDir, "kubectl-plugin", "pkg", "client", "client.go"))
+		if err != nil {
+			t.Fatalf("failed to read client.go: %v", err)
+		}
+		if !strings.Contains(string(clientContent), "func NewClientFromDynamic(dynamicClient dynamic.Interface, apiGroup, apiVersion string) *Client {") {
+			t.Error("expected client.go to define NewClientFromDynamic")
+		}
+	})
+
+	t.Run("skipped without kinds", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		cfg := &config.Config{
+			OutputDir:             tmpDir,
+			APIGroup:              "test.example.com",
+			APIVersion:            "v1alpha1",
+			ModuleName:            "github.com/example/widget-operator",
+			GenerateKubectlPlugin: true,
+		}
+		if err := NewKubectlPluginGenerator(cfg).Generate(nil, nil, nil); err != nil {
+			t.Fatalf("Generate failed: %v", err)
+		}
+		if _, err := os.Stat(filepath.Join(tmpDir, "kubectl-plugin", "cmd", "cmd_test.go")); !os.IsNotExist(err) {
+			t.Errorf("expected no cmd_test.go without kinds, got %v", err)
+		}
+	})
+}
+
+func TestKubectlPluginGenerator_Graph(t *testing.T) {
+	crds := []*mapper.CRDDefinition{
+		{APIGroup: "test.example.com", APIVersion: "v1alpha1", Kind: "Pet", Plural: "pets", Scope: "Namespaced", BasePath: "/pet"},
+		{
+			APIGroup: "test.example.com", APIVersion: "v1alpha1", Kind: "PetUploadImageAction", Plural: "petuploadimageactions", Scope: "Namespaced",
+			IsAction: true, ParentResource: "Pet", ParentIDParam: "petId",
+		},
+	}
+	tmpDir := t.TempDir()
+	cfg := &config.Config{
+		OutputDir:             tmpDir,
+		APIGroup:              "test.example.com",
+		APIVersion:            "v1alpha1",
+		ModuleName:            "github.com/example/pet-operator",
+		GenerateKubectlPlugin: true,
+	}
+	aggregate := &mapper.AggregateDefinition{Kind: "PetstoreAggregate", Plural: "petstoreaggregates"}
+	bundle := &mapper.BundleDefinition{Kind: "PetstoreBundle", Plural: "petstorebundles"}
+	if err := NewKubectlPluginGenerator(cfg).Generate(crds, aggregate, bundle); err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+
+	content, err := os.ReadFile(filepath.Join(tmpDir, "kubectl-plugin", "cmd", "graph.go"))
+	if err != nil {
+		t.Fatalf("failed to read graph.go: %v", err)
+	}
+	for _, want := range []string{
+		`{Kind: "Pet", Plural: "pets"},`,
+		`{Kind: "PetUploadImageAction", Plural: "petuploadimageactions", ParentKind: "Pet", ParentIDField: "petId"},`,
+		`{Kind: "PetstoreAggregate", Plural: "petstoreaggregates"},`,
+		`case "PetstoreBundle":`,
+		`linkMembers(obj, node, "contains", link)`,
+		`linkMembers(obj, node, "aggregates", link)`,
+	} {
+		if !strings.Contains(string(content), want) {
+			t.Errorf("expected graph.go to contain %q", want)
+		}
+	}
+
+	root, err := os.ReadFile(filepath.Join(tmpDir, "kubectl-plugin", "cmd", "root.go"))
+	if err != nil {
+		t.Fatalf("failed to read root.go: %v", err)
+	}
+	if !strings.Contains(string(root), "rootCmd.AddCommand(graphCmd)") {
+		t.Error("expected root.go to register the graph command")
+	}
+}
+
+func TestKrewVersion(t *testing.T) {
+	tests := map[string]string{
+		"":           "v0.1.0",
+		"1.0":        "v1.0.0",
+		"v2":         "v2.0.0",
+		"1.2.3":      "v1.2.3",
+		"1.2-beta.1": "v1.2.0-beta.1",
+		"not-semver": "v0.1.0",
+	}
+	for in, want := range tests {
+		if got := krewVersion(in); got != want {
+			t.Errorf("krewVersion(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestGenerate_NoStatusSubresource(t *testing.T) {
+	crds := []*mapper.CRDDefinition{
+		{
+			APIGroup: "test.example.com", APIVersion: "v1alpha1", Kind: "Widget", Plural: "widgets", Scope: "Namespaced", BasePath: "/widgets",
+			Spec: &mapper.FieldDefinition{Fields: []*mapper.FieldDefinition{{Name: "Name", JSONName: "name", GoType: "string"}}},
+		},
+		{
+			APIGroup: "test.example.com", APIVersion: "v1alpha1", Kind: "WidgetSearch", Plural: "widgetsearches", Scope: "Namespaced", IsQuery: true, QueryPath: "/widgets/search",
+			Spec: &mapper.FieldDefinition{},
+		},
+	}
+
+	for _, noStatus := range []bool{false, true} {
+		tmpDir := t.TempDir()
+		cfg := &config.Config{
+			OutputDir:           tmpDir,
+			APIGroup:            "test.example.com",
+			APIVersion:          "v1alpha1",
+			ModuleName:          "github.com/example/widget-operator",
+			GenerateCRDs:        true,
+			NoStatusSubresource: noStatus,
+		}
+		if err := NewTypesGenerator(cfg).Generate(crds); err != nil {
+			t.Fatalf("types Generate failed: %v", err)
+		}
+		if err := NewCRDGenerator(cfg).Generate(crds); err != nil {
+			t.Fatalf("CRD Generate failed: %v", err)
+		}
+		if err := NewControllerGenerator(cfg).Generate(crds, nil, nil); err != nil {
+			t.Fatalf("controller Generate failed: %v", err)
+		}
+
+		files := map[string]string{
+			"api/v1alpha1/types.go":                               "// +kubebuilder:subresource:status",
+			"config/crd/bases/test.example.com_widgets.yaml":      "subresources:",
+			"internal/controller/widget_controller.go":            "r.Status().Update(ctx, latest)",
+			"internal/controller/widgetsearch_controller.go":      "r.Status().Update(ctx, instance)",
+			"internal/controller/widget_controller_test.go":       "WithStatusSubresource(obj)",
+			"internal/controller/widgetsearch_controller_test.go": "WithStatusSubresource(obj)",
+		}
+		for file, marker := range files {
+			content, err := os.ReadFile(filepath.Join(tmpDir, file))
+			if err != nil {
+				t.Fatalf("failed to read %s: %v", file, err)
+			}
+			if strings.Contains(string(content), marker) == noStatus {
+				t.Errorf("NoStatusSubresource=%v: %s contains %q = %v", noStatus, file, marker, noStatus)
+			}
+		}
+
+		if noStatus {
+			for file, want := range map[string]string{
+				"internal/controller/widget_controller.go":       "return r.Update(ctx, latest)",
+				"internal/controller/widgetsearch_controller.go": "if err := r.Update(ctx, instance); err != nil {",
+			} {
+				content, err := os.ReadFile(filepath.Join(tmpDir, file))
+				if err != nil {
+					t.Fatalf("failed to read %s: %v", file, err)
+				}
+				if !strings.Contains(string(content), want) {
+					t.Errorf("%s missing full object update %q", file, want)
+				}
+			}
+		}
+
+		// Status writes bump the generation without the subresource, so the predicate
+		// compares specs and the observed generation anticipates the bump
+		for file, markers := range map[string][]string{
+			"internal/controller/widget_controller.go": {
+				"latest.Status.ObservedGeneration++",
+				"!equality.Semantic.DeepEqual(oldObj.Spec, newObj.Spec)",
+			},
+			"internal/controller/widgetsearch_controller.go": {"instance.Status.ObservedGeneration++"},
+			"internal/controller/widget_controller_test.go":  {"func TestWidgetPredicate_NoStatusSubresource("},
+		} {
+			content, err := os.ReadFile(filepath.Join(tmpDir, file))
+			if err != nil {
+				t.Fatalf("failed to read %s: %v", file, err)
+			}
+			for _, marker := range markers {
+				if strings.Contains(string(content), marker) != noStatus {
+					t.Errorf("NoStatusSubresource=%v: %s contains %q = %v", noStatus, file, marker, !noStatus)
+				}
+			}
+		}
+		content, err := os.ReadFile(filepath.Join(tmpDir, "internal/controller/widget_controller.go"))
+		if err != nil {
+			t.Fatalf("failed to read widget controller: %v", err)
+		}
+		if strings.Contains(string(content), "predicate.GenerationChangedPredicate{}") == noStatus {
+			t.Errorf("NoStatusSubresource=%v: widget controller filters on generation = %v", noStatus, noStatus)
+		}
+	}
+}
+
+func TestControllerGenerator_GenerationPredicate(t *testing.T) {
+	crds := []*mapper.CRDDefinition{
+		{
+			APIGroup: "test.example.com", APIVersion: "v1alpha1", Kind: "Widget", Plural: "widgets", Scope: "Namespaced", BasePath: "/widgets",
+			Spec: &mapper.FieldDefinition{Fields: []*mapper.FieldDefinition{{Name: "Name", JSONName: "name", GoType: "string"}}},
+		},
+		{
+			APIGroup: "test.example.com", APIVersion: "v1alpha1", Kind: "WidgetSearch", Plural: "widgetsearches", Scope: "Namespaced", IsQuery: true, QueryPath: "/widgets/search",
+			Spec: &mapper.FieldDefinition{},
+		},
+	}
+
+	for _, noPredicate := range []bool{false, true} {
+		tmpDir := t.TempDir()
+		cfg := &config.Config{
+			OutputDir:             tmpDir,
+			APIGroup:              "test.example.com",
+			APIVersion:            "v1alpha1",
+			ModuleName:            "github.com/example/widget-operator",
+			NoGenerationPredicate: noPredicate,
+		}
+		if err := NewControllerGenerator(cfg).Generate(crds, nil, nil); err != nil {
+			t.Fatalf("Generate failed: %v", err)
+		}
+
+		content, err := os.ReadFile(filepath.Join(tmpDir, "internal/controller/widget_controller.go"))
+		if err != nil {
+			t.Fatalf("failed to read controller: %v", err)
+		}
+		for _, want := range []string{
+			`"sigs.k8s.io/controller-runtime/pkg/predicate"`,
+			"builder.WithPredicates(widgetPredicate())",
+			"predicate.GenerationChangedPredicate{},",
+			"predicate.AnnotationChangedPredicate{},",
+		} {
+			if strings.Contains(string(content), want) == noPredicate {
+				t.Errorf("NoGenerationPredicate=%v: widget controller contains %q = %v", noPredicate, want, noPredicate)
+			}
+		}
+
+		// Queries keep reconciling on every update
+		content, err = os.ReadFile(filepath.Join(tmpDir, "internal/controller/widgetsearch_controller.go"))
+		if err != nil {
+			t.Fatalf("failed to read query controller: %v", err)
+		}
+		if strings.Contains(string(content), "GenerationChangedPredicate") {
+			t.Errorf("NoGenerationPredicate=%v: query controller should not filter on generation", noPredicate)
+		}
+	}
+}
+
+func TestGenerators_StringFormats(t *testing.T) {
+	tmpDir := t.TempDir()
+	cfg := &config.Config{
+		OutputDir:  tmpDir,
+		APIGroup:   "test.example.com",
+		APIVersion: "v1alpha1",
+		ModuleName: "github.com/example/test-operator",
+	}
+	crds := []*mapper.CRDDefinition{
+		{
+			APIGroup: "test.example.com", APIVersion: "v1alpha1", Kind: "Contact", Plural: "contacts", Scope: "Namespaced", BasePath: "/contacts",
+			Spec: &mapper.FieldDefinition{Fields: []*mapper.FieldDefinition{
+				{Name: "Address", JSONName: "address", GoType: "string", Required: true, Format: "email",
+					Validation: &mapper.ValidationRules{Format: "email"}},
+				{Name: "Host", JSONName: "host", GoType: "string", Format: "ipv4",
+					Validation: &mapper.ValidationRules{Format: "ipv4"}},
+			}},
+		},
+	}
+
+	if err := NewTypesGenerator(cfg).Generate(crds); err != nil {
+		t.Fatalf("types Generate failed: %v", err)
+	}
+	if err := NewSamplesGenerator(cfg).Generate(crds, nil, nil); err != nil {
+		t.Fatalf("samples Generate failed: %v", err)
+	}
+
+	types, err := os.ReadFile(filepath.Join(tmpDir, "api", "v1alpha1", "types.go"))
+	if err != nil {
+		t.Fatalf("failed to read types.go: %v", err)
+	}
+	for _, want := range []string{"+kubebuilder:validation:Format=email", "+kubebuilder:validation:Format=ipv4"} {
+		if !strings.Contains(string(types), want) {
+			t.Errorf("types.go missing %q", want)
+		}
+	}
+
+	sample, err := os.ReadFile(filepath.Join(tmpDir, "config", "samples", "v1alpha1_contact.yaml"))
+	if err != nil {
+		t.Fatalf("failed to read sample: %v", err)
+	}
+	for _, want := range []string{`"user@example.com"`, `"192.0.2.10"`} {
+		if !strings.Contains(string(sample), want) {
+			t.Errorf("sample missing %q:\n%s", want, sample)
+		}
+	}
+}
+
+func TestTypesGenerator_TargetDefault(t *testing.T) {
+	tmpDir := t.TempDir()
+	cfg := &config.Config{
+		OutputDir:    tmpDir,
+		APIGroup:     "test.example.com",
+		APIVersion:   "v1alpha1",
+		GenerateCRDs: true,
+	}
+	crds := []*mapper.CRDDefinition{
+		{
+			APIGroup: "test.example.com", APIVersion: "v1alpha1", Kind: "Widget", Plural: "widgets", Scope: "Namespaced",
+			Spec:          &mapper.FieldDefinition{Fields: []*mapper.FieldDefinition{{Name: "Name", JSONName: "name", GoType: "string"}}},
+			TargetDefault: &config.TargetDefault{Deployment: "widget-api", Namespace: "backend"},
+		},
+	}
+	if err := NewTypesGenerator(cfg).Generate(crds); err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+	content, err := os.ReadFile(filepath.Join(tmpDir, "api", "v1alpha1", "types.go"))
+	if err != nil {
+		t.Fatalf("failed to read types.go: %v", err)
+	}
+	for _, want := range []string{
+		`// +kubebuilder:default={deployment: "widget-api", namespace: "backend"}`,
+		"// If not specified, defaults to deployment=widget-api,namespace=backend.",
+	} {
+		if !strings.Contains(string(content), want) {
+			t.Errorf("types.go missing %q", want)
+		}
+	}
+
+	if err := NewCRDGenerator(cfg).Generate(crds); err != nil {
+		t.Fatalf("CRD Generate failed: %v", err)
+	}
+	crdYAML, err := os.ReadFile(filepath.Join(tmpDir, "config", "crd", "bases", "test.example.com_widgets.yaml"))
+	if err != nil {
+		t.Fatalf("failed to read CRD: %v", err)
+	}
+	if !strings.Contains(string(crdYAML), `deployment: "widget-api"`) {
+		t.Errorf("CRD YAML missing target default:\n%s", crdYAML)
+	}
+}
+
+func TestTypesGenerator_StateEnum(t *testing.T) {
+	tmpDir := t.TempDir()
+	cfg := &config.Config{
+		OutputDir:    tmpDir,
+		APIGroup:     "test.example.com",
+		APIVersion:   "v1alpha1",
+		GenerateCRDs: true,
+	}
+	spec := &mapper.FieldDefinition{Fields: []*mapper.FieldDefinition{{Name: "Name", JSONName: "name", GoType: "string"}}}
+	crds := []*mapper.CRDDefinition{
+		{APIGroup: "test.example.com", APIVersion: "v1alpha1", Kind: "Widget", Plural: "widgets", Scope: "Namespaced", Spec: spec},
+		{APIGroup: "test.example.com", APIVersion: "v1alpha1", Kind: "WidgetSearchQuery", Plural: "widgetsearchqueries", Scope: "Namespaced", Spec: spec, IsQuery: true},
+		{APIGroup: "test.example.com", APIVersion: "v1alpha1", Kind: "WidgetResetAction", Plural: "widgetresetactions", Scope: "Namespaced", Spec: spec, IsAction: true},
+	}
+	if err := NewTypesGenerator(cfg).Generate(crds); err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+	content, err := os.ReadFile(filepath.Join(tmpDir, "api", "v1alpha1", "types.go"))
+	if err != nil {
+		t.Fatalf("failed to read types.go: %v", err)
+	}
+	for _, want := range []string{
+		"// +kubebuilder:validation:Enum=Pending;Syncing;Synced;Failed;Observed;NotFound;Paused",
+		"// +kubebuilder:validation:Enum=Pending;Querying;Queried;Failed;Paused",
+		"// +kubebuilder:validation:Enum=Pending;Executing;Completed;Failed;Paused",
+	} {
+		if !strings.Contains(string(content), want) {
+			t.Errorf("types.go missing %q", want)
+		}
+	}
+
+	if err := NewCRDGenerator(cfg).Generate(crds); err != nil {
+		t.Fatalf("CRD Generate failed: %v", err)
+	}
+	crdYAML, err := os.ReadFile(filepath.Join(tmpDir, "config", "crd", "bases", "test.example.com_widgetsearchqueries.yaml"))
+	if err != nil {
+		t.Fatalf("failed to read CRD: %v", err)
+	}
+	want := "description: Current state of the query\n                type: string\n                enum:\n" +
+		"                - Pending\n                - Querying\n                - Queried\n                - Failed\n                - Paused\n"
+	if !strings.Contains(string(crdYAML), want) {
+		t.Errorf("CRD YAML missing query state enum:\n%s", crdYAML)
+	}
+}
+
+func TestTypesGenerator_ImmutableFields(t *testing.T) {
+	tmpDir := t.TempDir()
+	cfg := &config.Config{
+		OutputDir:    tmpDir,
+		APIGroup:     "test.example.com",
+		APIVersion:   "v1alpha1",
+		GenerateCRDs: true,
+	}
+
+	crds := []*mapper.CRDDefinition{
+		{
+			APIGroup:   "test.example.com",
+			APIVersion: "v1alpha1",
+			Kind:       "Widget",
+			Plural:     "widgets",
+			Scope:      "Namespaced",
+			Spec: &mapper.FieldDefinition{
+				Fields: []*mapper.FieldDefinition{
+					{Name: "Region", JSONName: "region", GoType: "string", Immutable: true},
+					{Name: "Name", JSONName: "name", GoType: "string"},
+				},
+			},
+		},
+	}
+
+	if err := NewTypesGenerator(cfg).Generate(crds); err != nil {
+		t.Fatalf("types Generate failed: %v", err)
+	}
+	types, err := os.ReadFile(filepath.Join(tmpDir, "api", "v1alpha1", "types.go"))
+	if err != nil {
+		t.Fatalf("failed to read types.go: %v", err)
+	}
+	rule := `// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="region is immutable"`
+	if strings.Count(string(types), rule) != 1 {
+		t.Errorf("expected one immutability rule for region in types.go")
+	}
+	if strings.Contains(string(types), "name is immutable") {
+		t.Error("expected no immutability rule for name")
+	}
+
+	if err := NewCRDGenerator(cfg).Generate(crds); err != nil {
+		t.Fatalf("CRD Generate failed: %v", err)
+	}
+	crdYAML, err := os.ReadFile(filepath.Join(tmpDir, "config", "crd", "bases", "test.example.com_widgets.yaml"))
+	if err != nil {
+		t.Fatalf("failed to read CRD: %v", err)
+	}
+	if !strings.Contains(string(crdYAML), "message: region is immutable") || !strings.Contains(string(crdYAML), "rule: self == oldSelf") {
+		t.Error("expected x-kubernetes-validations immutability rule in CRD YAML")
+	}
+}
+
+func TestTypesGenerator_PatternPropertiesMaps(t *testing.T) {
+	tmpDir := t.TempDir()
+	cfg := &config.Config{
+		OutputDir:    tmpDir,
+		APIGroup:     "test.example.com",
+		APIVersion:   "v1alpha1",
+		GenerateCRDs: true,
+	}
+
+	crds := []*mapper.CRDDefinition{
+		{
+			APIGroup:   "test.example.com",
+			APIVersion: "v1alpha1",
+			Kind:       "Widget",
+			Plural:     "widgets",
+			Scope:      "Namespaced",
+			Spec: &mapper.FieldDefinition{
+				Fields: []*mapper.FieldDefinition{
+					{Name: "Labels", JSONName: "labels", GoType: "map[string]string", KeyPatterns: []string{`^[a-z]+\.io$`}},
+					{
+						Name: "Limits", JSONName: "limits", GoType: "map[string]struct", KeyPatterns: []string{"^x-"},
+						ItemType: &mapper.FieldDefinition{
+							Name: "Value", GoType: "struct",
+							Fields: []*mapper.FieldDefinition{{Name: "Max", JSONName: "max", GoType: "int"}},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	if err := NewTypesGenerator(cfg).Generate(crds); err != nil {
+		t.Fatalf("types Generate failed: %v", err)
+	}
+	types, err := os.ReadFile(filepath.Join(tmpDir, "api", "v1alpha1", "types.go"))
+	if err != nil {
+		t.Fatalf("failed to read types.go: %v", err)
+	}
+	for _, want := range []string{
+		"Labels map[string]string `json:\"labels,omitempty\"`",
+		`// +kubebuilder:validation:XValidation:rule="self.all(k, k.matches('^[a-z]+\\\\.io$'))",message="labels keys must match ^[a-z]+\\.io$"`,
+		"type WidgetLimitsValue struct",
+		"Limits map[string]WidgetLimitsValue `json:\"limits,omitempty\"`",
+	} {
+		if !strings.Contains(string(types), want) {
+			t.Errorf("expected types.go to contain %s", want)
+		}
+	}
+
+	if err := NewCRDGenerator(cfg).Generate(crds); err != nil {
+		t.Fatalf("CRD Generate failed: %v", err)
+	}
+	crdYAML, err := os.ReadFile(filepath.Join(tmpDir, "config", "crd", "bases", "test.example.com_widgets.yaml"))
+	if err != nil {
+		t.Fatalf("failed to read CRD: %v", err)
+	}
+	for _, want := range []string{
+		"additionalProperties:\n                  type: string",
+		"additionalProperties:\n                  x-kubernetes-preserve-unknown-fields: true",
+		`rule: "self.all(k, k.matches('^x-'))"`,
+	} {
+		if !strings.Contains(string(crdYAML), want) {
+			t.Errorf("expected CRD YAML to contain %q", want)
+		}
+	}
+}
+
+func TestTypesGenerator_ItemAndValueValidation(t *testing.T) {
+	tmpDir := t.TempDir()
+	cfg := &config.Config{
+		OutputDir:    tmpDir,
+		APIGroup:     "test.example.com",
+		APIVersion:   "v1alpha1",
+		GenerateCRDs: true,
+	}
+	maxLength := int64(20)
+	minimum, maximum := 0.0, 100.0
+
+	crds := []*mapper.CRDDefinition{
+		{
+			APIGroup:   "test.example.com",
+			APIVersion: "v1alpha1",
+			Kind:       "Widget",
+			Plural:     "widgets",
+			Scope:      "Namespaced",
+			Spec: &mapper.FieldDefinition{
+				Fields: []*mapper.FieldDefinition{
+					{
+						Name: "Nicknames", JSONName: "nicknames", GoType: "[]string",
+						ItemType: &mapper.FieldDefinition{
+							GoType:     "string",
+							Validation: &mapper.ValidationRules{MaxLength: &maxLength, Pattern: "^[a-z]+$", Enum: []string{"ab", "cd"}},
+						},
+					},
+					{
+						Name: "Labels", JSONName: "labels", GoType: "map[string]string",
+						ValueValidation: &mapper.ValidationRules{MaxLength: &maxLength, Pattern: "^it's$"},
+					},
+					{
+						Name: "Scores", JSONName: "scores", GoType: "map[string]int",
+						ValueValidation: &mapper.ValidationRules{Minimum: &minimum, Maximum: &maximum, ExclusiveMaximum: true},
+					},
+					{
+						Name: "Weights", JSONName: "weights", GoType: "map[string]float64",
+						ValueValidation: &mapper.ValidationRules{Minimum: &minimum},
+					},
+				},
+			},
+		},
+	}
+
+	if err := NewTypesGenerator(cfg).Generate(crds); err != nil {
+		t.Fatalf("types Generate failed: %v", err)
+	}
+	types, err := os.ReadFile(filepath.Join(tmpDir, "api", "v1alpha1", "types.go"))
+	if err != nil {
+		t.Fatalf("failed to read types.go: %v", err)
+	}
+	for _, want := range []string{
+		"// +kubebuilder:validation:items:MaxLength=20\n",
+		`// +kubebuilder:validation:items:Pattern="^[a-z]+$"`,
+		"// +kubebuilder:validation:items:Enum=ab;cd\n",
+		`// +kubebuilder:validation:XValidation:rule="self.all(k, size(self[k]) <= 20 && self[k].matches('^it\\'s$'))",message="labels values must be at most 20 characters and match ^it's$"`,
+		`// +kubebuilder:validation:XValidation:rule="self.all(k, self[k] >= 0 && self[k] < 100)",message="scores values must be at least 0 and be less than 100"`,
+		`// +kubebuilder:validation:XValidation:rule="self.all(k, self[k] >= 0.0)",message="weights values must be at least 0"`,
+	} {
+		if !strings.Contains(string(types), want) {
+			t.Errorf("expected types.go to contain %s", want)
+		}
+	}
+	if strings.Contains(string(types), "validation:MaxLength=20") {
+		t.Error("expected the item maxLength not to apply to the array itself")
+	}
+
+	if err := NewCRDGenerator(cfg).Generate(crds); err != nil {
+		t.Fatalf("CRD Generate failed: %v", err)
+	}
+	crdYAML, err := os.ReadFile(filepath.Join(tmpDir, "config", "crd", "bases", "test.example.com_widgets.yaml"))
+	if err != nil {
+		t.Fatalf("failed to read CRD: %v", err)
+	}
+	if want := `rule: "self.all(k, self[k] >= 0 && self[k] < 100)"`; !strings.Contains(string(crdYAML), want) {
+		t.Errorf("expected CRD YAML to contain %q", want)
+	}
+}
+
+func TestTypesGenerator_NotRules(t *testing.T) {
+	tmpDir := t.TempDir()
+	cfg := &config.Config{
+		OutputDir:    tmpDir,
+		APIGroup:     "test.example.com",
+		APIVersion:   "v1alpha1",
+		GenerateCRDs: true,
+	}
+	minimum, maximum := 100.0, 200.0
+
+	crds := []*mapper.CRDDefinition{
+		{
+			APIGroup:   "test.example.com",
+			APIVersion: "v1alpha1",
+			Kind:       "Account",
+			Plural:     "accounts",
+			Scope:      "Namespaced",
+			Spec: &mapper.FieldDefinition{
+				Fields: []*mapper.FieldDefinition{
+					{Name: "Username", JSONName: "username", GoType: "string", NotValidation: &mapper.ValidationRules{Pattern: "^admin"}},
+					{Name: "Plan", JSONName: "plan", GoType: "*string", NotValidation: &mapper.ValidationRules{Enum: []string{"legacy", "trial"}}},
+					{Name: "Quota", JSONName: "quota", GoType: "int64", NotValidation: &mapper.ValidationRules{Minimum: &minimum, Maximum: &maximum}},
+					{Name: "Ratio", JSONName: "ratio", GoType: "float64", NotValidation: &mapper.ValidationRules{Minimum: &minimum, ExclusiveMinimum: true}},
+				},
+			},
+		},
+	}
+
+	if err := NewTypesGenerator(cfg).Generate(crds); err != nil {
+		t.Fatalf("types Generate failed: %v", err)
+	}
+	types, err := os.ReadFile(filepath.Join(tmpDir, "api", "v1alpha1", "types.go"))
+	if err != nil {
+		t.Fatalf("failed to read types.go: %v", err)
+	}
+	for _, want := range []string{
+		`// +kubebuilder:validation:XValidation:rule="!(self.matches('^admin'))",message="username must not match ^admin"`,
+		`// +kubebuilder:validation:XValidation:rule="!(self in ['legacy', 'trial'])",message="plan must not be one of legacy, trial"`,
+		`// +kubebuilder:validation:XValidation:rule="!(self >= 100 && self <= 200)",message="quota must not be at least 100 and be at most 200"`,
+		`// +kubebuilder:validation:XValidation:rule="!(self > 100.0)",message="ratio must not be greater than 100"`,
+	} {
+		if !strings.Contains(string(types), want) {
+			t.Errorf("expected types.go to contain %s", want)
+		}
+	}
+
+	if err := NewCRDGenerator(cfg).Generate(crds); err != nil {
+		t.Fatalf("CRD Generate failed: %v", err)
+	}
+	crdYAML, err := os.ReadFile(filepath.Join(tmpDir, "config", "crd", "bases", "test.example.com_accounts.yaml"))
+	if err != nil {
+		t.Fatalf("failed to read CRD: %v", err)
+	}
+	if want := `rule: "!(self.matches('^admin'))"`; !strings.Contains(string(crdYAML), want) {
+		t.Errorf("expected CRD YAML to contain %q", want)
+	}
+}
+
+func TestGenerate_CustomPlural(t *testing.T) {
+	tmpDir := t.TempDir()
+	cfg := &config.Config{
+		OutputDir:  tmpDir,
+		APIGroup:   "test.example.com",
+		APIVersion: "v1alpha1",
+		ModuleName: "github.com/example/test-operator",
+	}
+
+	crds := []*mapper.CRDDefinition{
+		{
+			APIGroup: "test.example.com", APIVersion: "v1alpha1",
+			Kind: "Datum", Plural: "data", CustomPlural: true, Scope: "Namespaced",
+			Spec: &mapper.FieldDefinition{},
+		},
+		{
+			APIGroup: "test.example.com", APIVersion: "v1alpha1",
+			Kind: "Pet", Plural: "pets", Scope: "Namespaced",
+			Spec: &mapper.FieldDefinition{},
+		},
+	}
+
+	if err := NewTypesGenerator(cfg).Generate(crds); err != nil {
+		t.Fatalf("types Generate failed: %v", err)
+	}
+	types, err := os.ReadFile(filepath.Join(tmpDir, "api", "v1alpha1", "types.go"))
+	if err != nil {
+		t.Fatalf("failed to read types.go: %v", err)
+	}
+	if strings.Count(string(types), "// +kubebuilder:resource:path=") != 1 ||
+		!strings.Contains(string(types), "// +kubebuilder:resource:path=data") {
+		t.Error("expected a resource:path marker for the explicit plural only")
+	}
+
+	aggregate := mapper.NewMapper(cfg).CreateAggregateDefinition(crds)
+	if err := NewControllerGenerator(cfg).GenerateAggregateController(aggregate); err != nil {
+		t.Fatalf("GenerateAggregateController failed: %v", err)
+	}
+	controller, err := os.ReadFile(filepath.Join(tmpDir, "internal", "controller", "testaggregate_controller.go"))
+	if err != nil {
+		t.Fatalf("failed to read aggregate controller: %v", err)
+	}
+	for _, want := range []string{
+		`aggregate.RegisterResourceNames(map[string]string{`,
+		`"Datum": "data",`,
+		`// +kubebuilder:rbac:groups=test.example.com,resources=data,verbs=get;list;watch`,
+		`// +kubebuilder:rbac:groups=test.example.com,resources=pets,verbs=get;list;watch`,
+	} {
+		if !strings.Contains(string(controller), want) {
+			t.Errorf("expected %q in the aggregate controller", want)
+		}
+	}
+}
+
+func TestExportKindInfo(t *testing.T) {
+	crd := &mapper.CRDDefinition{
+		Kind:         "Order",
+		Plural:       "orders",
+		ResourcePath: "/stores/{storeId}/order/{orderId}",
+		Operations: []mapper.OperationMapping{
+			{CRDAction: "Create", HTTPMethod: "POST", Path: "/stores/{storeId}/order", PathParams: []string{"storeId"}, QueryParams: []string{"dry_run"}},
+			{CRDAction: "Get", HTTPMethod: "GET", Path: "/stores/{storeId}/order/{orderId}", PathParams: []string{"storeId", "orderId"}},
+		},
+		IDFieldMappings: []mapper.IDFieldMapping{{PathParam: "orderId", BodyField: "id"}},
+	}
+
+	info := NewKubectlPluginGenerator(&config.Config{}).exportKindInfo(crd)
+
+	wantParams := []ExportPathParam{{Name: "storeId", Field: "storeId"}, {Name: "orderId", Field: "id"}}
+	if len(info.PathParams) != len(wantParams) {
+		t.Fatalf("expected %d path params, got %v", len(wantParams), info.PathParams)
+	}
+	for i, want := range wantParams {
+		if info.PathParams[i] != want {
+			t.Errorf("PathParams[%d] = %+v, want %+v", i, info.PathParams[i], want)
+		}
+	}
+
+	wantDrop := []string{"storeId", "dryRun"}
+	if strings.Join(info.DropFields, ",") != strings.Join(wantDrop, ",") {
+		t.Errorf("DropFields = %v, want %v (merged id must stay in the body)", info.DropFields, wantDrop)
+	}
+}
+
+func TestImportKindInfo(t *testing.T) {
+	crd := &mapper.CRDDefinition{
+		Kind:         "Variable",
+		Plural:       "variables",
+		ResourcePath: "/classes/{classId}/variables/{name}",
+		Operations: []mapper.OperationMapping{
+			{CRDAction: "Create", HTTPMethod: "POST", Path: "/classes/{classId}/variables", PathParams: []string{"classId"}},
+			{CRDAction: "Get", HTTPMethod: "GET", Path: "/classes/{classId}/variables", PathParams: []string{"classId"}},
+			{CRDAction: "Get", HTTPMethod: "GET", Path: "/classes/{classId}/variables/{name}", PathParams: []string{"classId", "name"}},
+		},
+		Spec: &mapper.FieldDefinition{Fields: []*mapper.FieldDefinition{
+			{JSONName: "classId", GoType: "int64"},
+			{JSONName: "name", GoType: "string"},
+			{JSONName: "value", GoType: "string"},
+		}},
+	}
+
+	g := NewKubectlPluginGenerator(&config.Config{})
+	info := g.importKindInfo(crd)
+
+	if info.ListPath != "/classes/{classId}/variables" {
+		t.Errorf("ListPath = %q, want the collection GET", info.ListPath)
+	}
+	if strings.Join(info.SpecFields, ",") != "classId,name,value" {
+		t.Errorf("SpecFields = %v", info.SpecFields)
+	}
+	wantParams := []ImportPathParam{{Name: "classId", Field: "classId", Numeric: true}, {Name: "name", Field: "name"}}
+	if len(info.PathParams) != len(wantParams) {
+		t.Fatalf("expected %d path params, got %v", len(wantParams), info.PathParams)
+	}
+	for i, want := range wantParams {
+		if info.PathParams[i] != want {
+			t.Errorf("PathParams[%d] = %+v, want %+v", i, info.PathParams[i], want)
+		}
+	}
+	if info.IDParam != "name" {
+		t.Errorf("IDParam = %q, want name", info.IDParam)
+	}
+
+	// A path param merged into a body field is read from the object as is, and a
+	// resource without a collection GET has no list endpoint
+	crd = &mapper.CRDDefinition{
+		Kind:         "Order",
+		Plural:       "orders",
+		ResourcePath: "/store/order/{orderId}",
+		Operations: []mapper.OperationMapping{
+			{CRDAction: "Get", HTTPMethod: "GET", Path: "/store/order/{orderId}", PathParams: []string{"orderId"}},
+		},
+		IDFieldMappings: []mapper.IDFieldMapping{{PathParam: "orderId", BodyField: "id"}},
+	}
+	info = g.importKindInfo(crd)
+	if info.ListPath != "" || info.IDParam != "" {
+		t.Errorf("expected no list path and no ID param, got %q and %q", info.ListPath, info.IDParam)
+	}
+	if len(info.PathParams) != 1 || info.PathParams[0].Field != "id" {
+		t.Errorf("PathParams = %+v, want orderId read from id", info.PathParams)
+	}
+}
+
+func TestGenerators_UseETag(t *testing.T) {
+	tmpDir := t.TempDir()
+	cfg := &config.Config{
+		OutputDir:  tmpDir,
+		APIGroup:   "test.example.com",
+		APIVersion: "v1alpha1",
+		ModuleName: "github.com/example/widget-operator",
+	}
+
+	crds := []*mapper.CRDDefinition{
+		{
+			APIGroup:     "test.example.com",
+			APIVersion:   "v1alpha1",
+			Kind:         "Widget",
+			Plural:       "widgets",
+			BasePath:     "/widgets",
+			ResourcePath: "/widgets/{id}",
+			HasPut:       true,
+			HasPatch:     true,
+			UseETag:      true,
+			Spec: &mapper.FieldDefinition{
+				Fields: []*mapper.FieldDefinition{{Name: "Name", JSONName: "name", GoType: "string"}},
+			},
+		},
+		{
+			APIGroup:     "test.example.com",
+			APIVersion:   "v1alpha1",
+			Kind:         "Gadget",
+			Plural:       "gadgets",
+			BasePath:     "/gadgets",
+			ResourcePath: "/gadgets/{id}",
+			HasPut:       true,
+			Spec: &mapper.FieldDefinition{
+				Fields: []*mapper.FieldDefinition{{Name: "Name", JSONName: "name", GoType: "string"}},
+			},
+		},
+	}
+
+	if err := NewTypesGenerator(cfg).Generate(crds); err != nil {
+		t.Fatalf("types Generate failed: %v", err)
+	}
+	if err := NewControllerGenerator(cfg).Generate(crds, nil, nil); err != nil {
+		t.Fatalf("controller Generate failed: %v", err)
+	}
+
+	types, err := os.ReadFile(filepath.Join(tmpDir, "api", "v1alpha1", "types.go"))
+	if err != nil {
+		t.Fatalf("failed to read types.go: %v", err)
+	}
+	if got := strings.Count(string(types), "ETag string `json:\"etag,omitempty\"`"); got != 1 {
+		t.Errorf("expected status.etag only on Widget, found %d", got)
+	}
+
+	widget, err := os.ReadFile(filepath.Join(tmpDir, "internal", "controller", "widget_controller.go"))
+	if err != nil {
+		t.Fatalf("failed to read widget controller: %v", err)
+	}
+	for _, want := range []string{
+		`req.Header.Set("If-Match", instance.Status.ETag)`,
+		`instance.Status.ETag = resp.Header.Get("ETag")`,
+		"http.StatusPreconditionFailed",
+	} {
+		if !strings.Contains(string(widget), want) {
+			t.Errorf("expected widget controller to contain %q", want)
+		}
+	}
+
+	gadget, err := os.ReadFile(filepath.Join(tmpDir, "internal", "controller", "gadget_controller.go"))
+	if err != nil {
+		t.Fatalf("failed to read gadget controller: %v", err)
+	}
+	if strings.Contains(string(gadget), "If-Match") {
+		t.Error("expected no If-Match handling without UseETag")
+	}
+}
+
+func TestGenerators_StatusFields(t *testing.T) {
+	tmpDir := t.TempDir()
+	cfg := &config.Config{
+		OutputDir:  tmpDir,
+		APIGroup:   "test.example.com",
+		APIVersion: "v1alpha1",
+		ModuleName: "github.com/example/vm-operator",
+	}
+
+	crds := []*mapper.CRDDefinition{
+		{
+			APIGroup:     "test.example.com",
+			APIVersion:   "v1alpha1",
+			Kind:         "VM",
+			Plural:       "vms",
+			Scope:        "Namespaced",
+			BasePath:     "/vms",
+			ResourcePath: "/vms/{id}",
+			HasPut:       true,
+			StatusFields: []mapper.StatusField{
+				{Name: "Cores", JSONName: "cores", Path: []string{"properties", "cores"}, GoType: "int64", SchemaType: "integer",
+					Description: "Number of virtual cores"},
+				{Name: "ProvisioningState", JSONName: "provisioningState", Path: []string{"properties", "provisioningState"},
+					GoType: "string", SchemaType: "string", Description: "Provisioning state of the VM"},
+			},
+			Spec: &mapper.FieldDefinition{
+				Fields: []*mapper.FieldDefinition{{Name: "Name", JSONName: "name", GoType: "string"}},
+			},
+		},
+	}
+
+	if err := NewTypesGenerator(cfg).Generate(crds); err != nil {
+		t.Fatalf("types Generate failed: %v", err)
+	}
+	if err := NewCRDGenerator(cfg).Generate(crds); err != nil {
+		t.Fatalf("CRD Generate failed: %v", err)
+	}
+	if err := NewControllerGenerator(cfg).Generate(crds, nil, nil); err != nil {
+		t.Fatalf("controller Generate failed: %v", err)
+	}
+
+	files := map[string][]string{
+		"api/v1alpha1/types.go": {
+			"ProvisioningState string `json:\"provisioningState,omitempty\"`",
+			"Cores int64 `json:\"cores,omitempty\"`",
+			"// +kubebuilder:printcolumn:name=\"ProvisioningState\",type=string,JSONPath=`.status.provisioningState`",
+			"// +kubebuilder:printcolumn:name=\"Cores\",type=integer,JSONPath=`.status.cores`",
+		},
+		"config/crd/bases/test.example.com_vms.yaml": {
+			"- jsonPath: .status.provisioningState\n      name: ProvisioningState\n      type: string",
+			"provisioningState:\n                description: Provisioning state of the VM\n                type: string",
+		},
+		"internal/controller/vm_controller.go": {
+			"setResponseStatusFields(statusSnapshot)",
+			`controllerutil2.ExtractJSONPath(raw, []string{"properties", "provisioningState"}, &status.ProvisioningState)`,
+			`controllerutil2.ExtractJSONPath(raw, []string{"properties", "cores"}, &status.Cores)`,
+		},
+	}
+	for file, wants := range files {
+		content, err := os.ReadFile(filepath.Join(tmpDir, filepath.FromSlash(file)))
+		if err != nil {
+			t.Fatalf("failed to read %s: %v", file, err)
+		}
+		for _, want := range wants {
+			if !strings.Contains(string(content), want) {
+				t.Errorf("expected %s to contain %q", file, want)
+			}
+		}
+	}
+}
+
+func TestGenerators_ValidateOnly(t *testing.T) {
+	outputDir := filepath.Join(t.TempDir(), "generated")
+	cfg := &config.Config{
+		OutputDir:    outputDir,
+		APIGroup:     "pets.example.com",
+		APIVersion:   "v1alpha1",
+		ModuleName:   "github.com/example/pet-operator",
+		ValidateOnly: true,
+	}
+
+	crds := []*mapper.CRDDefinition{
+		{
+			APIGroup:   "pets.example.com",
+			APIVersion: "v1alpha1",
+			Kind:       "Pet",
+			Plural:     "pets",
+			BasePath:   "/pet",
+			Spec: &mapper.FieldDefinition{
+				Fields: []*mapper.FieldDefinition{
+					{Name: "Name", JSONName: "name", GoType: "string", Required: true},
+				},
+			},
+		},
+	}
+
+	if err := NewTypesGenerator(cfg).Generate(crds); err != nil {
+		t.Fatalf("types Generate failed: %v", err)
+	}
+	if err := NewSamplesGenerator(cfg).Generate(crds, nil, nil); err != nil {
+		t.Fatalf("samples Generate failed: %v", err)
+	}
+	controllerGen := NewControllerGenerator(cfg)
+	if err := controllerGen.Generate(crds, nil, nil); err != nil {
+		t.Fatalf("controller Generate failed: %v", err)
+	}
+
+	if _, err := os.Stat(outputDir); !os.IsNotExist(err) {
+		t.Errorf("expected no output directory in validate-only mode, got err=%v", err)
+	}
+
+	sink, ok := controllerGen.files.(*MemorySink)
+	if !ok {
+		t.Fatalf("expected MemorySink in validate-only mode, got %T", controllerGen.files)
+	}
+	content, err := sink.ReadFile(filepath.Join(outputDir, "internal", "controller", "pet_controller.go"))
+	if err != nil {
+		t.Fatalf("expected rendered controller in memory: %v", err)
+	}
+	if !strings.Contains(string(content), "func (r *PetReconciler) Reconcile") {
+		t.Error("expected rendered controller content")
+	}
+}
+
+func TestMemorySink(t *testing.T) {
+	sink := NewMemorySink()
+
+	f, err := sink.Create("out/b.txt")
+	if err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+	if _, err := f.Write([]byte("hello")); err != nil {
+		t.Fatalf("Write failed: %v", err)
+	}
+	if err := f.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+	if err := sink.WriteFile("out/a.txt", []byte("world"), 0644); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+
+	data, err := sink.ReadFile("out/b.txt")
+	if err != nil || string(data) != "hello" {
+		t.Errorf("ReadFile(out/b.txt) = %q, %v; want \"hello\"", data, err)
+	}
+	if _, err := sink.ReadFile("out/missing.txt"); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("expected ErrNotExist for missing file, got %v", err)
+	}
+	if paths := sink.Paths(); len(paths) != 2 || paths[0] != filepath.Clean("out/a.txt") {
+		t.Errorf("Paths() = %v, want sorted [out/a.txt out/b.txt]", paths)
+	}
+}
+
+func TestOSSink_RecordsManifest(t *testing.T) {
+	outputDir := t.TempDir()
+	cfg := &config.Config{OutputDir: outputDir}
+	sink := newFileSink(cfg)
+
+	f, err := sink.Create(filepath.Join(outputDir, "b.txt"))
+	if err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+	if _, err := f.Write([]byte("hello")); err != nil {
+		t.Fatalf("Write failed: %v", err)
+	}
+	if err := f.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+	if err := sink.MkdirAll(filepath.Join(outputDir, "sub"), 0755); err != nil {
+		t.Fatalf("MkdirAll failed: %v", err)
+	}
+	if err := sink.WriteFile(filepath.Join(outputDir, "sub", "a.txt"), []byte("world"), 0644); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+	if err := sink.WriteFile(filepath.Join(outputDir, ".openapi-operator-gen.yaml"), []byte("{}"), 0644); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+	if err := sink.WriteFile(filepath.Join(t.TempDir(), "outside.txt"), []byte("x"), 0644); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+
+	want := map[string]string{
+		"b.txt":     config.HashSpecBytes([]byte("hello")),
+		"sub/a.txt": config.HashSpecBytes([]byte("world")),
+	}
+	if len(cfg.GeneratedFiles) != len(want) {
+		t.Fatalf("GeneratedFiles = %v, want %v", cfg.GeneratedFiles, want)
+	}
+	for rel, hash := range want {
+		if cfg.GeneratedFiles[rel] != hash {
+			t.Errorf("GeneratedFiles[%s] = %q, want %q", rel, cfg.GeneratedFiles[rel], hash)
+		}
+	}
+
+	if err := SaveManifest(cfg); err != nil {
+		t.Fatalf("SaveManifest failed: %v", err)
+	}
+	file, err := config.LoadConfigFile(filepath.Join(outputDir, ".openapi-operator-gen.yaml"))
+	if err != nil {
+		t.Fatalf("LoadConfigFile failed: %v", err)
+	}
+	if file.GeneratedFiles["sub/a.txt"] != want["sub/a.txt"] {
+		t.Errorf("expected the saved manifest to record sub/a.txt, got %v", file.GeneratedFiles)
+	}
+}
+
+// =============================================================================
+// Edge Cases and Error Handling
+// =============================================================================
+
+func TestControllerGenerator_GenerateCELValidationTest(t *testing.T) {
+	tmpDir := t.TempDir()
+	cfg := &config.Config{
+		OutputDir:  tmpDir,
+		APIGroup:   "test.example.com",
+		APIVersion: "v1alpha1",
+		ModuleName: "github.com/example/test-operator",
+	}
+	g := NewControllerGenerator(cfg)
+
+	crd := &mapper.CRDDefinition{
+		APIGroup:   "petstore.example.com",
+		APIVersion: "v1alpha1",
+		Kind:       "Pet",
+		Plural:     "pets",
+		Spec: &mapper.FieldDefinition{
+			Fields: []*mapper.FieldDefinition{
+				{Name: "PetId", JSONName: "petId", GoType: "int64", PathParamName: "petId"},
+				{Name: "Name", JSONName: "name", GoType: "string", OpenAPIRequired: true},
+				{Name: "Status", JSONName: "status", GoType: "string", Enum: []string{"available", "sold"}, OpenAPIRequired: true},
+				{Name: "Tag", JSONName: "tag", GoType: "string"},
+			},
+		},
+		CELValidationRules: []mapper.CELValidationRule{
+			{
+				Rule:            "has(self.petId) || has(self.name)",
+				Message:         "name is required when creating a new resource",
+				Field:           "name",
+				ReferenceFields: []string{"petId"},
+			},
+			{
+				Rule:            "has(self.petId) || has(self.status)",
+				Message:         "status is required when creating a new resource",
+				Field:           "status",
+				ReferenceFields: []string{"petId"},
+			},
+		},
+	}
+
+	if err := g.generateCELValidationTest(tmpDir, crd); err != nil {
+		t.Fatalf("generateCELValidationTest failed: %v", err)
+	}
+
+	content, err := os.ReadFile(filepath.Join(tmpDir, "pet_cel_validation_test.go"))
+	if err != nil {
+		t.Fatalf("failed to read pet_cel_validation_test.go: %v", err)
+	}
+	contentStr := string(content)
+
+	expected := []string{
+		`"apiVersion": "petstore.example.com/v1alpha1"`,
+		`"name": "test-value"`,
+		`"status": "available"`,
+		`ContainSubstring("name is required when creating a new resource")`,
+		`ContainSubstring("status is required when creating a new resource")`,
+		`spec["petId"] = int64(1)`,
+	}
+	for _, exp := range expected {
+		if !strings.Contains(contentStr, exp) {
+			t.Errorf("expected CEL validation test to contain %q", exp)
+		}
+	}
+	if strings.Contains(contentStr, `"tag":`) {
+		t.Error("expected optional fields to be omitted from the valid spec")
+	}
+
+	// CRDs without CEL rules don't get a validation test
+	crd.Kind = "Store"
+	crd.CELValidationRules = nil
+	if err := g.generateCELValidationTest(tmpDir, crd); err != nil {
+		t.Fatalf("generateCELValidationTest failed: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(tmpDir, "store_cel_validation_test.go")); !os.IsNotExist(err) {
+		t.Error("expected no CEL validation test for a CRD without rules")
+	}
+}
+
+func TestCELSampleValue_Bounds(t *testing.T) {
+	i64 := func(v int64) *int64 { return &v }
+	f64 := func(v float64) *float64 { return &v }
+	tests := []struct {
+		name       string
+		goType     string
+		validation *mapper.ValidationRules
+		want       string
+		wantOK     bool
+	}{
+		{name: "string", goType: "string", want: `"test-value"`, wantOK: true},
+		{name: "string maxLength", goType: "string", validation: &mapper.ValidationRules{MaxLength: i64(4)}, want: `"test"`, wantOK: true},
+		{name: "string minLength", goType: "string", validation: &mapper.ValidationRules{MinLength: i64(12)}, want: `"test-valuexx"`, wantOK: true},
+		{name: "string impossible length", goType: "string", validation: &mapper.ValidationRules{MinLength: i64(5), MaxLength: i64(3)}},
+		{name: "integer", goType: "int64", want: "int64(1)", wantOK: true},
+		{name: "integer minimum", goType: "int64", validation: &mapper.ValidationRules{Minimum: f64(5)}, want: "int64(5)", wantOK: true},
+		{name: "integer exclusive minimum", goType: "int32", validation: &mapper.ValidationRules{Minimum: f64(5), ExclusiveMinimum: true}, want: "int64(6)", wantOK: true},
+		{name: "integer maximum", goType: "int64", validation: &mapper.ValidationRules{Maximum: f64(0)}, want: "int64(0)", wantOK: true},
+		{name: "integer exclusive maximum", goType: "int64", validation: &mapper.ValidationRules{Maximum: f64(0), ExclusiveMaximum: true}, want: "int64(-1)", wantOK: true},
+		{name: "integer range", goType: "int64", validation: &mapper.ValidationRules{Minimum: f64(10), Maximum: f64(20)}, want: "int64(10)", wantOK: true},
+		{name: "integer empty range", goType: "int64", validation: &mapper.ValidationRules{Minimum: f64(1.2), Maximum: f64(1.8)}},
+		{name: "float maximum", goType: "float64", validation: &mapper.ValidationRules{Maximum: f64(0.5)}, want: "float64(-0.5)", wantOK: true},
+		{name: "float range", goType: "float64", validation: &mapper.ValidationRules{Minimum: f64(2), Maximum: f64(2.5)}, want: "float64(2.25)", wantOK: true},
+		{name: "float empty range", goType: "float64", validation: &mapper.ValidationRules{Minimum: f64(2), Maximum: f64(2), ExclusiveMaximum: true}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := celSampleValue(&mapper.FieldDefinition{GoType: tt.goType, Validation: tt.validation})
+			if ok != tt.wantOK || got != tt.want {
+				t.Errorf("celSampleValue() = %q, %v; want %q, %v", got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestCRDGenerator_Generate_NilSpec(t *testing.T) {
+	tmpDir := t.TempDir()
+	cfg := &config.Config{
+		OutputDir:  tmpDir,
+		APIGroup:   "test.example.com",
+		APIVersion: "v1",
+	}
+	g := NewCRDGenerator(cfg)
+
+	crds := []*mapper.CRDDefinition{
+		{
+			APIGroup:   "test.example.com",
+			APIVersion: "v1",
+			Kind:       "Widget",
+			Plural:     "widgets",
+			Scope:      "Namespaced",
+			Spec:       nil, // nil spec
+		},
+	}
+
+	// CRD template requires Spec, so this should return an error
+	err := g.Generate(crds)
+	if err == nil {
+		t.Error("expected error for nil spec")
+	}
+}
+
+func TestCRDGenerator_Generate_EmptySpecFields(t *testing.T) {
+	tmpDir := t.TempDir()
+	cfg := &config.Config{
+		OutputDir:  tmpDir,
+		APIGroup:   "test.example.com",
+		APIVersion: "v1",
+	}
+	g := NewCRDGenerator(cfg)
+
+	crds := []*mapper.CRDDefinition{
+		{
+			APIGroup:   "test.example.com",
+			APIVersion: "v1",
+			Kind:       "Widget",
+			Plural:     "widgets",
+			Scope:      "Namespaced",
+			Spec: &mapper.FieldDefinition{
+				Fields: []*mapper.FieldDefinition{}, // empty fields
+			},
+		},
+	}
+
+	err := g.Generate(crds)
+	if err != nil {
+		t.Fatalf("Generate should handle empty spec fields: %v", err)
+	}
+}
+
+func TestTypesGenerator_Generate_EmptyCRDs(t *testing.T) {
+	tmpDir := t.TempDir()
+	cfg := &config.Config{
+		OutputDir:  tmpDir,
+		APIGroup:   "test.example.com",
+		APIVersion: "v1",
+		ModuleName: "github.com/example/test",
+	}
+	g := NewTypesGenerator(cfg)
+
+	err := g.Generate([]*mapper.CRDDefinition{})
+	if err != nil {
+		t.Fatalf("Generate should handle empty CRDs: %v", err)
+	}
+
+	// Files should still be created
+	typesPath := filepath.Join(tmpDir, "api", "v1", "types.go")
+	if _, err := os.Stat(typesPath); os.IsNotExist(err) {
+		t.Error("expected types.go to exist even with no CRDs")
+	}
+}
+
+func TestControllerGenerator_Generate_EmptyCRDs(t *testing.T) {
+	tmpDir := t.TempDir()
+	cfg := &config.Config{
+		OutputDir:  tmpDir,
+		APIGroup:   "test.example.com",
+		APIVersion: "v1",
+		ModuleName: "github.com/example/test",
+	}
+	g := NewControllerGenerator(cfg)
+
+	err := g.Generate([]*mapper.CRDDefinition{}, nil, nil)
+	if err != nil {
+		t.Fatalf("Generate should handle empty CRDs: %v", err)
+	}
+
+	// main.go should still be created
+	mainPath := filepath.Join(tmpDir, "cmd", "manager", "main.go")
+	if _, err := os.Stat(mainPath); os.IsNotExist(err) {
+		t.Error("expected main.go to exist even with no CRDs")
+	}
+}
+
+func TestCRDGenerator_ConvertFields_EmptyFields(t *testing.T) {
+	g := &CRDGenerator{config: &config.Config{}}
+	result := g.convertFields(nil)
+	if len(result) != 0 {
+		t.Errorf("expected empty result for nil fields, got %d", len(result))
+	}
+
+	result = g.convertFields([]*mapper.FieldDefinition{})
+	if len(result) != 0 {
+		t.Errorf("expected empty result for empty fields, got %d", len(result))
+	}
+}
+
+func TestTypesGenerator_ConvertFieldsWithNestedTypes_NoNestedTypes(t *testing.T) {
+	g := &TypesGenerator{config: &config.Config{}}
+	nestedTypes := make(map[string]NestedTypeData)
+
+	fields := []*mapper.FieldDefinition{
+		{Name: "Name", JSONName: "name", GoType: "string"},
+		{Name: "Age", JSONName: "age", GoType: "int32"},
+	}
+
+	result := g.convertFieldsWithNestedTypes(fields, "User", nestedTypes)
+
+	if len(result) != 2 {
+		t.Fatalf("expected 2 fields, got %d", len(result))
+	}
+	if len(nestedTypes) != 0 {
+		t.Errorf("expected no nested types, got %d", len(nestedTypes))
+	}
+}
+
+// =============================================================================
+// Integration Test
+// =============================================================================
+
+// =============================================================================
+// Version Handling Tests
+// =============================================================================
+
+func TestIsValidSemver(t *testing.T) {
+	tests := []struct {
+		version  string
+		expected bool
+	}{
+		{"v1.0.0", true},
+		{"v0.0.1", true},
+		{"v10.20.30", true},
+		{"v0.0.0", true},
+		{"v1.2.3-dirty", false},
+		{"v0.0.7-10-gd5024c8-dirty", false},
+		{"v1.0.0-rc1", false},
+		{"v1.0", false},
+		{"v1", false},
+		{"1.0.0", false},
+		{"", false},
+		{"dev", false},
+		{"latest", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.version, func(t *testing.T) {
+			result := isValidSemver(tt.version)
+			if result != tt.expected {
+				t.Errorf("isValidSemver(%q) = %v, expected %v", tt.version, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestExtractBaseSemver(t *testing.T) {
+	tests := []struct {
+		version  string
+		expected string
+	}{
+		// Clean versions pass through
+		{"v1.0.0", "v1.0.0"},
+		{"v0.0.7", "v0.0.7"},
+		// Dirty/commit versions extract base version only
+		{"v0.0.7-dirty", "v0.0.7"},
+		{"v0.0.7-10-gd5024c8-dirty", "v0.0.7"},
+		{"v1.2.3-5-gabcdef", "v1.2.3"},
+		{"v10.20.30-1-g1234567-dirty", "v10.20.30"},
+		{"v0.0.7-10-gd5024c8", "v0.0.7"},
+		// Invalid versions fall back to v0.0.0
+		{"", "v0.0.0"},
+		{"dev", "v0.0.0"},
+		{"latest", "v0.0.0"},
+		{"1.0.0", "v1.0.0"},  // no 'v' prefix - still extracts valid semver
+		{"v1.0", "v0.0.0"},   // only 2 parts
+		{"v1", "v0.0.0"},     // only 1 part
+		{"vX.Y.Z", "v0.0.0"}, // non-numeric
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.version, func(t *testing.T) {
+			result := extractBaseSemver(tt.version)
+			if result != tt.expected {
+				t.Errorf("extractBaseSemver(%q) = %q, expected %q", tt.version, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestIncrementPatchVersion(t *testing.T) {
+	tests := []struct {
+		version  string
+		expected string
+	}{
+		{"v0.0.0", "v0.0.1"},
+		{"v0.0.7", "v0.0.8"},
+		{"v1.2.3", "v1.2.4"},
+		{"v10.20.30", "v10.20.31"},
+		{"v0.0.99", "v0.0.100"},
+		// Invalid versions return as-is
+		{"invalid", "invalid"},
+		{"v1.2", "v1.2"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.version, func(t *testing.T) {
+			result := incrementPatchVersion(tt.version)
+			if result != tt.expected {
+				t.Errorf("incrementPatchVersion(%q) = %q, expected %q", tt.version, result, tt.expected)
+			}
+		})
+	}
+}
+
+// =============================================================================
+// Integration Test
+// =============================================================================
+
+func TestFullGeneration(t *testing.T) {
+	tmpDir := t.TempDir()
+	cfg := &config.Config{
+		OutputDir:  tmpDir,
+		APIGroup:   "petstore.example.com",
+		APIVersion: "v1alpha1",
+		ModuleName: "github.com/example/petstore-operator",
+	}
+
+	crds := []*mapper.CRDDefinition{
+		{
+			APIGroup:   "petstore.example.com",
+			APIVersion: "v1alpha1",
+			Kind:       "Pet",
+			Plural:     "pets",
+			ShortNames: []string{"pe", "pet"},
+			Scope:      "Namespaced",
+			BasePath:   "/pet",
+			Spec: &mapper.FieldDefinition{
+				Fields: []*mapper.FieldDefinition{
+					{Name: "Name", JSONName: "name", GoType: "string", Required: true},
+					{Name: "Status", JSONName: "status", GoType: "string", Enum: []string{"available", "pending", "sold"}},
+					{
+						Name:     "Category",
+						JSONName: "category",
+						GoType:   "struct",
+						Fields: []*mapper.FieldDefinition{
+							{Name: "Id", JSONName: "id", GoType: "int64"},
+							{Name: "Name", JSONName: "name", GoType: "string"},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	// Generate all files
+	typesGen := NewTypesGenerator(cfg)
+	if err := typesGen.Generate(crds); err != nil {
+		t.Fatalf("TypesGenerator.Generate failed: %v", err)
+	}
+
+	crdGen := NewCRDGenerator(cfg)
+	if err := crdGen.Generate(crds); err != nil {
+		t.Fatalf("CRDGenerator.Generate failed: %v", err)
+	}
+
+	controllerGen := NewControllerGenerator(cfg)
+	if err := controllerGen.Generate(crds, nil, nil); err != nil {
+		t.Fatalf("ControllerGenerator.Generate failed: %v", err)
+	}
+
+	// Verify all expected files exist
+	expectedFiles := []string{
+		"api/v1alpha1/types.go",
+		"api/v1alpha1/groupversion_info.go",
+		"config/crd/bases/petstore.example.com_pets.yaml",
+		"internal/controller/pet_controller.go",
+		"cmd/manager/main.go",
+		"go.mod",
+		"Dockerfile",
+		"Makefile",
+		".golangci.yml",
+		"hack/boilerplate.go.txt",
+		"hack/rbac-audit.sh",
+	}
+
+	for _, file := range expectedFiles {
+		path := filepath.Join(tmpDir, file)
+		if _, err := os.Stat(path); os.IsNotExist(err) {
+			t.Errorf("expected file %s to exist", file)
+		}
+	}
+
+	// Verify types.go has nested Category type
+	content, _ := os.ReadFile(filepath.Join(tmpDir, "api", "v1alpha1", "types.go"))
+	if !strings.Contains(string(content), "PetCategory") {
+		t.Error("expected PetCategory nested type in types.go")
+	}
+}
+
+func TestInitGitRepo(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "openapi.yaml"), []byte("openapi: 3.0.0\n"), 0644); err != nil {
+		t.Fatalf("failed to write spec: %v", err)
+	}
+
+	created, err := InitGitRepo(dir, "Generate operator from openapi.yaml")
+	if err != nil {
+		t.Fatalf("InitGitRepo failed: %v", err)
+	}
+	if !created {
+		t.Fatal("expected a repository to be created")
+	}
+	files, err := runGit(dir, "ls-tree", "--name-only", "HEAD")
+	if err != nil {
+		t.Fatalf("expected an initial commit: %v", err)
+	}
+	if files != "openapi.yaml" {
+		t.Errorf("expected the spec to be committed, got %q", files)
+	}
+
+	// A directory that is already a repository is left alone
+	created, err = InitGitRepo(dir, "second")
+	if err != nil {
+		t.Fatalf("InitGitRepo failed on existing repo: %v", err)
+	}
+	if created {
+		t.Error("expected git init to be skipped for an existing repository")
+	}
+	if count, _ := runGit(dir, "rev-list", "--count", "HEAD"); count != "1" {
+		t.Errorf("expected a single commit, got %s", count)
+	}
+}
+
+func TestGenerators_AnyOfRules(t *testing.T) {
+	tmpDir := t.TempDir()
+	cfg := &config.Config{
+		OutputDir:  tmpDir,
+		APIGroup:   "test.example.com",
+		APIVersion: "v1alpha1",
+		ModuleName: "github.com/example/notify-operator",
+	}
+
+	channel := &mapper.FieldDefinition{
+		Name: "Channel", JSONName: "channel", GoType: "SubscriberChannel",
+		Fields: []*mapper.FieldDefinition{
+			{Name: "Slack", JSONName: "slack", GoType: "string"},
+			{Name: "Webhook", JSONName: "webhook", GoType: "string"},
+		},
+		AnyOfRequired: [][]string{{"slack"}, {"webhook"}},
+	}
+	targetItem := &mapper.FieldDefinition{
+		Name: "TargetsItem", JSONName: "targetsItem", GoType: "SubscriberTargetsItem",
+		Fields: []*mapper.FieldDefinition{
+			{Name: "Host", JSONName: "host", GoType: "string"},
+			{Name: "Ip", JSONName: "ip", GoType: "string"},
+		},
+		AnyOfRequired: [][]string{{"host"}, {"ip"}},
+	}
+	crds := []*mapper.CRDDefinition{
+		{
+			APIGroup:     "test.example.com",
+			APIVersion:   "v1alpha1",
+			Kind:         "Subscriber",
+			Plural:       "subscribers",
+			Scope:        "Namespaced",
+			BasePath:     "/subscribers",
+			ResourcePath: "/subscribers/{id}",
+			HasPost:      true,
+			Spec: &mapper.FieldDefinition{
+				Fields: []*mapper.FieldDefinition{
+					{Name: "Email", JSONName: "email", GoType: "string"},
+					channel,
+					{Name: "Targets", JSONName: "targets", GoType: "[]SubscriberTargetsItem", ItemType: targetItem},
+				},
+			},
+			SpecAnyOfRule: &mapper.CELValidationRule{
+				Rule:    "has(self.externalIDRef) || has(self.email)",
+				Message: "spec must set email",
+			},
+		},
+	}
+
+	if err := NewTypesGenerator(cfg).Generate(crds); err != nil {
+		t.Fatalf("types Generate failed: %v", err)
+	}
+	if err := NewCRDGenerator(cfg).Generate(crds); err != nil {
+		t.Fatalf("CRD Generate failed: %v", err)
+	}
+
+	files := map[string][]string{
+		"api/v1alpha1/types.go": {
+			`// +kubebuilder:validation:XValidation:rule="has(self.externalIDRef) || has(self.email)",message="spec must set email"`,
+			`// +kubebuilder:validation:XValidation:rule="!(has(self.slack) || has(self.webhook)) || has(self.slack) || has(self.webhook)",message="channel must set slack, or webhook"`,
+			`// +kubebuilder:validation:XValidation:rule="self.all(item, has(item.host) || has(item.ip))",message="targets items must set host, or ip"`,
+		},
+		"config/crd/bases/test.example.com_subscribers.yaml": {
+			`rule: "!(has(self.slack) || has(self.webhook)) || has(self.slack) || has(self.webhook)"`,
+			`rule: "self.all(item, has(item.host) || has(item.ip))"`,
+		},
+	}
+	for file, wants := range files {
+		content, err := os.ReadFile(filepath.Join(tmpDir, filepath.FromSlash(file)))
+		if err != nil {
+			t.Fatalf("failed to read %s: %v", file, err)
+		}
+		for _, want := range wants {
+			if !strings.Contains(string(content), want) {
+				t.Errorf("expected %s to contain %q", file, want)
+			}
+		}
+	}
+}
+
+func TestGenerators_ExtraHeaders(t *testing.T) {
+	tmpDir := t.TempDir()
+	cfg := &config.Config{
+		OutputDir:    tmpDir,
+		APIGroup:     "test.example.com",
+		APIVersion:   "v1alpha1",
+		ModuleName:   "github.com/example/pet-operator",
+		GenerateCRDs: true,
+	}
+	crds := []*mapper.CRDDefinition{
+		{
+			APIGroup:        "test.example.com",
+			APIVersion:      "v1alpha1",
+			Kind:            "Pet",
+			Plural:          "pets",
+			Scope:           "Namespaced",
+			BasePath:        "/pets",
+			ResourcePath:    "/pets/{id}",
+			HasPost:         true,
+			HasDelete:       true,
+			HasExtraHeaders: true,
+			Spec: &mapper.FieldDefinition{
+				Fields: []*mapper.FieldDefinition{{Name: "Name", JSONName: "name", GoType: "string"}},
+			},
+		},
+		{
+			APIGroup:        "test.example.com",
+			APIVersion:      "v1alpha1",
+			Kind:            "PetFindByStatusQuery",
+			Plural:          "petfindbystatusqueries",
+			Scope:           "Namespaced",
+			IsQuery:         true,
+			QueryPath:       "/pets/findByStatus",
+			HasExtraHeaders: true,
+			Spec:            &mapper.FieldDefinition{},
+		},
+	}
+
+	if err := NewTypesGenerator(cfg).Generate(crds); err != nil {
+		t.Fatalf("types Generate failed: %v", err)
+	}
+	if err := NewCRDGenerator(cfg).Generate(crds); err != nil {
+		t.Fatalf("CRD Generate failed: %v", err)
+	}
+	if err := NewControllerGenerator(cfg).Generate(crds, nil, nil); err != nil {
+		t.Fatalf("controller Generate failed: %v", err)
+	}
+
+	files := map[string][]string{
+		"api/v1alpha1/types.go": {
+			"ExtraHeaders map[string]string `json:\"extraHeaders,omitempty\"`",
+			"// +kubebuilder:validation:MaxProperties=32",
+		},
+		"config/crd/bases/test.example.com_pets.yaml": {
+			"extraHeaders:\n                description: HTTP headers sent with every REST API request for this CR",
+		},
+		"internal/controller/pet_controller.go": {
+			"r.applyExtraHeaders(ctx, req, instance)\n\tresp, err := r.HTTPClient.Do(req)",
+			"controllerutil2.RedactHeaders(instance.Spec.ExtraHeaders)",
+			`delete(specMap, "extraHeaders")`,
+		},
+		"internal/controller/pet_controller_test.go": {
+			"func TestPetReconciler_ExtraHeadersNotSent(t *testing.T) {",
+		},
+		"internal/controller/petfindbystatusquery_controller.go": {
+			"r.applyExtraHeaders(ctx, req, instance)",
+			`controllerutil2 "github.com/bluecontainer/openapi-operator-gen/pkg/controller"`,
+		},
+	}
+	for file, wants := range files {
+		content, err := os.ReadFile(filepath.Join(tmpDir, filepath.FromSlash(file)))
+		if err != nil {
+			t.Fatalf("failed to read %s: %v", file, err)
+		}
+		for _, want := range wants {
+			if !strings.Contains(string(content), want) {
+				t.Errorf("expected %s to contain %q", file, want)
+			}
+		}
+	}
+}
+
+func TestGenerators_Security(t *testing.T) {
+	tmpDir := t.TempDir()
+	cfg := &config.Config{
+		OutputDir:    tmpDir,
+		APIGroup:     "test.example.com",
+		APIVersion:   "v1alpha1",
+		ModuleName:   "github.com/example/pet-operator",
+		GenerateCRDs: true,
+	}
+	apiKey := mapper.SecurityScheme{Name: "api_key", Type: "apiKey", In: "header", ParamName: "X-API-Key", EnvVar: "API_AUTH_API_KEY"}
+	bearer := mapper.SecurityScheme{Name: "bearerAuth", Type: "http", Scheme: "bearer", EnvVar: "API_AUTH_BEARERAUTH"}
+	both := []mapper.SecurityRequirement{{Schemes: []mapper.SecurityScheme{apiKey, bearer}}}
+	crds := []*mapper.CRDDefinition{
+		{
+			APIGroup:     "test.example.com",
+			APIVersion:   "v1alpha1",
+			Kind:         "Pet",
+			Plural:       "pets",
+			Scope:        "Namespaced",
+			BasePath:     "/pets",
+			ResourcePath: "/pets/{id}",
+			HasPost:      true,
+			HasDelete:    true,
+			Operations: []mapper.OperationMapping{
+				{CRDAction: "Create", HTTPMethod: "POST", Path: "/pets", Security: both},
+				{CRDAction: "Get", HTTPMethod: "GET", Path: "/pets/{id}", Security: []mapper.SecurityRequirement{{Schemes: []mapper.SecurityScheme{apiKey}}, {}}},
+				{CRDAction: "Delete", HTTPMethod: "DELETE", Path: "/pets/{id}"},
+			},
+			SecuritySchemes: []mapper.SecurityScheme{apiKey, bearer},
+			Spec: &mapper.FieldDefinition{
+				Fields: []*mapper.FieldDefinition{{Name: "Name", JSONName: "name", GoType: "string"}},
+			},
+		},
+		{
+			APIGroup:        "test.example.com",
+			APIVersion:      "v1alpha1",
+			Kind:            "PetFindByStatusQuery",
+			Plural:          "petfindbystatusqueries",
+			Scope:           "Namespaced",
+			IsQuery:         true,
+			QueryPath:       "/pets/findByStatus",
+			Operations:      []mapper.OperationMapping{{CRDAction: "Get", HTTPMethod: "GET", Path: "/pets/findByStatus", Security: both}},
+			SecuritySchemes: []mapper.SecurityScheme{apiKey, bearer},
+			Spec:            &mapper.FieldDefinition{},
+		},
+	}
+
+	if err := NewTypesGenerator(cfg).Generate(crds); err != nil {
+		t.Fatalf("types Generate failed: %v", err)
+	}
+	if err := NewControllerGenerator(cfg).Generate(crds, nil, nil); err != nil {
+		t.Fatalf("controller Generate failed: %v", err)
+	}
+
+	files := map[string][]string{
+		"internal/controller/pet_controller.go": {
+			"func (r *PetReconciler) securityRequirements(method string) []controllerutil2.SecurityRequirement {",
+			`	case "POST":
+		return []controllerutil2.SecurityRequirement{
+			{{Type: "apiKey", In: "header", ParamName: "X-API-Key", Scheme: "", EnvVar: "API_AUTH_API_KEY"}, {Type: "http", In: "", ParamName: "", Scheme: "bearer", EnvVar: "API_AUTH_BEARERAUTH"}},
+		}`,
+			`	case "GET":
+		return []controllerutil2.SecurityRequirement{
+			{{Type: "apiKey", In: "header", ParamName: "X-API-Key", Scheme: "", EnvVar: "API_AUTH_API_KEY"}},
+			{},
+		}`,
+			`controllerutil2.ApplySecurity(req, r.securityRequirements("POST"))`,
+			`controllerutil2.ApplySecurity(req, r.securityRequirements("GET"))`,
+			`controllerutil2.ApplySecurity(req, r.securityRequirements("DELETE"))`,
+		},
+		"internal/controller/petfindbystatusquery_controller.go": {
+			`controllerutil2.ApplySecurity(req, r.securityRequirements("GET"))`,
+			`controllerutil2 "github.com/bluecontainer/openapi-operator-gen/pkg/controller"`,
+		},
+		"config/manager/manager.yaml": {
+			"        # - name: API_AUTH_API_KEY\n        #   valueFrom:\n        #     secretKeyRef:\n        #       name: test-api-credentials\n        #       key: api_key\n",
+			"        # - name: API_AUTH_BEARERAUTH\n",
+		},
+	}
+	for file, wants := range files {
+		content, err := os.ReadFile(filepath.Join(tmpDir, filepath.FromSlash(file)))
+		if err != nil {
+			t.Fatalf("failed to read %s: %v", file, err)
+		}
+		for _, want := range wants {
+			if !strings.Contains(string(content), want) {
+				t.Errorf("expected %s to contain %q", file, want)
+			}
+		}
+	}
+
+	// Without security schemes, the controllers send no credentials
+	crds[0].Operations = nil
+	crds[0].SecuritySchemes = nil
+	if err := NewControllerGenerator(cfg).Generate(crds[:1], nil, nil); err != nil {
+		t.Fatalf("controller Generate failed: %v", err)
+	}
+	content, err := os.ReadFile(filepath.Join(tmpDir, "internal", "controller", "pet_controller.go"))
+	if err != nil {
+		t.Fatalf("failed to read pet_controller.go: %v", err)
+	}
+	if strings.Contains(string(content), "securityRequirements") {
+		t.Error("expected no securityRequirements without security schemes")
+	}
+}
+
+func TestGenerators_ExcludeStatusFields(t *testing.T) {
+	tmpDir := t.TempDir()
+	cfg := &config.Config{
+		OutputDir:    tmpDir,
+		APIGroup:     "test.example.com",
+		APIVersion:   "v1alpha1",
+		ModuleName:   "github.com/example/pet-operator",
+		GenerateCRDs: true,
+	}
+	crds := []*mapper.CRDDefinition{
+		{
+			APIGroup:             "test.example.com",
+			APIVersion:           "v1alpha1",
+			Kind:                 "Pet",
+			Plural:               "pets",
+			Scope:                "Namespaced",
+			HasPost:              true,
+			ExcludedStatusFields: []string{"externalResourceURL", "response", "responses"},
+			Spec: &mapper.FieldDefinition{
+				Fields: []*mapper.FieldDefinition{{Name: "Name", JSONName: "name", GoType: "string"}},
+			},
+		},
+		{
+			APIGroup:             "test.example.com",
+			APIVersion:           "v1alpha1",
+			Kind:                 "PetFindByStatusQuery",
+			Plural:               "petfindbystatusqueries",
+			Scope:                "Namespaced",
+			IsQuery:              true,
+			QueryPath:            "/pets/findByStatus",
+			ExcludedStatusFields: []string{"resultCount"},
+			Spec:                 &mapper.FieldDefinition{},
+		},
+	}
+
+	if err := NewTypesGenerator(cfg).Generate(crds); err != nil {
+		t.Fatalf("types Generate failed: %v", err)
+	}
+	if err := NewCRDGenerator(cfg).Generate(crds); err != nil {
+		t.Fatalf("CRD Generate failed: %v", err)
+	}
+
+	types, err := os.ReadFile(filepath.Join(tmpDir, "api", "v1alpha1", "types.go"))
+	if err != nil {
+		t.Fatalf("failed to read types.go: %v", err)
+	}
+	crdYAML, err := os.ReadFile(filepath.Join(tmpDir, "config", "crd", "bases", "test.example.com_pets.yaml"))
+	if err != nil {
+		t.Fatalf("failed to read the Pet CRD: %v", err)
+	}
+
+	// Excluded fields stay in the Go types, for the controllers, but are never serialized
+	for _, want := range []string{
+		"Response *PetEndpointResponse `json:\"-\"`",
+		"Responses map[string]PetEndpointResponse `json:\"-\"`",
+		"ExternalResourceURL string `json:\"-\"`",
+		"ResultCount int `json:\"-\"`",
+		"LastSyncTime *metav1.Time `json:\"lastSyncTime,omitempty\"`",
+		"Conditions []metav1.Condition `json:\"conditions,omitempty\"",
+	} {
+		if !strings.Contains(string(types), want) {
+			t.Errorf("expected types.go to contain %q", want)
+		}
+	}
+	for _, notWant := range []string{"JSONPath=`.status.externalResourceURL`", "JSONPath=`.status.resultCount`"} {
+		if strings.Contains(string(types), notWant) {
+			t.Errorf("expected no printer column for %s", notWant)
+		}
+	}
+	for _, notWant := range []string{"externalResourceURL", "response:"} {
+		if strings.Contains(string(crdYAML), notWant) {
+			t.Errorf("expected the Pet CRD not to contain %q", notWant)
+		}
+	}
+	if !strings.Contains(string(crdYAML), "lastSyncTime:") {
+		t.Error("expected the Pet CRD to keep status.lastSyncTime")
+	}
+}
+
+func TestControllerGenerator_PollCondition(t *testing.T) {
+	tmpDir := t.TempDir()
+	cfg := &config.Config{
+		OutputDir:  tmpDir,
+		APIGroup:   "test.example.com",
+		APIVersion: "v1alpha1",
+		ModuleName: "github.com/example/db-operator",
+	}
+	crds := []*mapper.CRDDefinition{{
+		APIGroup:     "test.example.com",
+		APIVersion:   "v1alpha1",
+		Kind:         "Database",
+		Plural:       "databases",
+		Scope:        "Namespaced",
+		BasePath:     "/databases",
+		ResourcePath: "/databases/{id}",
+		HasPost:      true,
+		HasDelete:    true,
+		PollCondition: &mapper.PollCondition{
+			Path:       []string{"properties", "status"},
+			Values:     []string{"available", "active"},
+			Interval:   15 * time.Second,
+			Expression: `properties.status == "available" || properties.status == "active"`,
+		},
+		Spec: &mapper.FieldDefinition{
+			Fields: []*mapper.FieldDefinition{{Name: "Name", JSONName: "name", GoType: "string"}},
+		},
+	}}
+
+	if err := NewControllerGenerator(cfg).Generate(crds, nil, nil); err != nil {
+		t.Fatalf("controller Generate failed: %v", err)
+	}
+	content, err := os.ReadFile(filepath.Join(tmpDir, "internal", "controller", "database_controller.go"))
+	if err != nil {
+		t.Fatalf("failed to read database_controller.go: %v", err)
+	}
+	for _, want := range []string{
+		`var databasePollCondition = controllerutil2.PollCondition{
+	Path:       []string{"properties", "status"},
+	Values:     []string{"available", "active"},
+	Interval:   15000 * time.Millisecond,
+	Expression: "properties.status == \"available\" || properties.status == \"active\"",
+}`,
+		"pollPending := errors.Is(syncErr, errDatabaseNotReady)",
+		`		r.updateStatus(ctx, instance, "Syncing", ReasonAwaitingReadiness, message)
+		return errDatabaseNotReady`,
+		"return ctrl.Result{RequeueAfter: databasePollCondition.Interval}, nil",
+		"func (r *DatabaseReconciler) checkPollCondition(instance *v1alpha1.Database) (bool, string) {",
+	} {
+		if !strings.Contains(string(content), want) {
+			t.Errorf("expected database_controller.go to contain %q", want)
+		}
+	}
+
+	// Without a poll condition, a synced resource is ready right away
+	crds[0].PollCondition = nil
+	if err := NewControllerGenerator(cfg).Generate(crds, nil, nil); err != nil {
+		t.Fatalf("controller Generate failed: %v", err)
+	}
+	content, err = os.ReadFile(filepath.Join(tmpDir, "internal", "controller", "database_controller.go"))
+	if err != nil {
+		t.Fatalf("failed to read database_controller.go: %v", err)
+	}
+	if strings.Contains(string(content), "PollCondition") || strings.Contains(string(content), "pollPending") {
+		t.Error("expected no poll condition handling without x-k8s-poll-condition")
+	}
+}
+
+func TestGenerators_SpecVersionMetadata(t *testing.T) {
+	crds := []*mapper.CRDDefinition{
+		{
+			APIGroup:     "test.example.com",
+			APIVersion:   "v1alpha1",
+			Kind:         "Pet",
+			Plural:       "pets",
+			Scope:        "Namespaced",
+			BasePath:     "/pets",
+			ResourcePath: "/pets/{id}",
+			HasPost:      true,
+			Spec: &mapper.FieldDefinition{
+				Fields: []*mapper.FieldDefinition{{Name: "Name", JSONName: "name", GoType: "string"}},
+			},
+		},
+	}
+
+	tests := []struct {
+		name        string
+		specVersion string
+		wants       map[string][]string
+		unwanted    []string
+	}{
+		{
+			name:        "label and annotation",
+			specVersion: "1.0.27",
+			wants: map[string][]string{
+				"api/v1alpha1/types.go": {
+					`// +kubebuilder:metadata:annotations="openapi-operator-gen/spec-version=1.0.27",labels="app.kubernetes.io/version=1.0.27"`,
+				},
+				"config/crd/bases/test.example.com_pets.yaml": {
+					`openapi-operator-gen/spec-version: "1.0.27"`,
+					"  labels:\n    app.kubernetes.io/version: \"1.0.27\"",
+				},
+				"config/samples/v1alpha1_pet.yaml": {
+					"  namespace: default\n  labels:\n    app.kubernetes.io/version: \"1.0.27\"\n  annotations:\n    openapi-operator-gen/spec-version: \"1.0.27\"",
+				},
+			},
+		},
+		{
+			name:        "invalid label value keeps the annotation only",
+			specVersion: "2.0 beta",
+			wants: map[string][]string{
+				"api/v1alpha1/types.go": {
+					`// +kubebuilder:metadata:annotations="openapi-operator-gen/spec-version=2.0 beta"` + "\n",
+				},
+				"config/samples/v1alpha1_pet.yaml": {
+					`openapi-operator-gen/spec-version: "2.0 beta"`,
+				},
+			},
+			unwanted: []string{"app.kubernetes.io/version"},
+		},
+		{
+			name:     "no spec version",
+			unwanted: []string{"app.kubernetes.io/version", "openapi-operator-gen/spec-version"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir := t.TempDir()
+			cfg := &config.Config{
+				OutputDir:    tmpDir,
+				APIGroup:     "test.example.com",
+				APIVersion:   "v1alpha1",
+				ModuleName:   "github.com/example/pet-operator",
+				GenerateCRDs: true,
+				SpecVersion:  tt.specVersion,
+			}
+			if err := NewTypesGenerator(cfg).Generate(crds); err != nil {
+				t.Fatalf("types Generate failed: %v", err)
+			}
+			if err := NewCRDGenerator(cfg).Generate(crds); err != nil {
+				t.Fatalf("CRD Generate failed: %v", err)
+			}
+			if err := NewSamplesGenerator(cfg).Generate(crds, nil, nil); err != nil {
+				t.Fatalf("samples Generate failed: %v", err)
+			}
+
+			for _, file := range []string{"api/v1alpha1/types.go", "config/crd/bases/test.example.com_pets.yaml", "config/samples/v1alpha1_pet.yaml"} {
+				content, err := os.ReadFile(filepath.Join(tmpDir, filepath.FromSlash(file)))
+				if err != nil {
+					t.Fatalf("failed to read %s: %v", file, err)
+				}
+				for _, want := range tt.wants[file] {
+					if !strings.Contains(string(content), want) {
+						t.Errorf("expected %s to contain %q", file, want)
+					}
+				}
+				for _, unwanted := range tt.unwanted {
+					if strings.Contains(string(content), unwanted) {
+						t.Errorf("expected %s not to contain %q", file, unwanted)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestMigrationGenerator(t *testing.T) {
+	pet := func(fields ...*mapper.FieldDefinition) *mapper.CRDDefinition {
+		return &mapper.CRDDefinition{
+			Kind:   "Pet",
+			Plural: "pets",
+			Spec:   &mapper.FieldDefinition{Fields: fields},
+			Operations: []mapper.OperationMapping{
+				{CRDAction: "Create", HTTPMethod: "POST", Path: "/pets"},
+			},
+		}
+	}
+	oldCRDs := []*mapper.CRDDefinition{
+		pet(
+			&mapper.FieldDefinition{JSONName: "name", GoType: "string", Required: true},
+			&mapper.FieldDefinition{JSONName: "tag", GoType: "string"},
+			&mapper.FieldDefinition{JSONName: "age", GoType: "string"},
+			&mapper.FieldDefinition{JSONName: "weight", GoType: "int32"},
+			&mapper.FieldDefinition{JSONName: "owner", GoType: "string"},
+		),
+		{Kind: "Store", Plural: "stores"},
+	}
+	newCRDs := []*mapper.CRDDefinition{
+		pet(
+			&mapper.FieldDefinition{JSONName: "name", GoType: "string"},
+			&mapper.FieldDefinition{JSONName: "label", GoType: "string"},
+			&mapper.FieldDefinition{JSONName: "age", GoType: "int64"},
+			&mapper.FieldDefinition{JSONName: "weight", GoType: "int64"},
+			&mapper.FieldDefinition{JSONName: "owner", GoType: "string", Required: true},
+		),
+		{Kind: "Order", Plural: "orders"},
+	}
+
+	tmpDir := t.TempDir()
+	cfg := &config.Config{
+		OutputDir:        tmpDir,
+		APIGroup:         "petstore.example.com",
+		APIVersion:       "v1alpha1",
+		GeneratorVersion: "v1.2.3",
+		SpecVersion:      "2.0.0",
+	}
+	if err := NewMigrationGenerator(cfg).Generate(oldCRDs, newCRDs, "1.0.0"); err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+	content, err := os.ReadFile(filepath.Join(tmpDir, "MIGRATION.md"))
+	if err != nil {
+		t.Fatalf("failed to read MIGRATION.md: %v", err)
+	}
+	got := string(content)
+
+	for _, want := range []string{
+		"(spec version 1.0.0 -> 2.0.0)",
+		"## Added Kinds",
+		"- Order",
+		"kubectl delete crd stores.petstore.example.com",
+		"#### Renamed field: tag -> label (string)",
+		".items[] | select(.spec.tag != null)",
+		"> pet-tag.jsonl",
+		"({spec: {label: (.value)}} | tojson | @sh)",
+		"#### Changed field type: age string -> int64",
+		"({spec: {age: (.value | tonumber | floor)}} | tojson | @sh)",
+		"#### Field now required: owner",
+		`kubectl patch pets.petstore.example.com <name> -n <namespace> --type=merge -p '{"spec":{"owner":"<value>"}}'`,
+		"- Changed field type: weight int32 -> int64 (widened; existing values stay valid)",
+		"- Field now optional: name",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("expected MIGRATION.md to contain %q", want)
+		}
+	}
+	if strings.Contains(got, "Removed field: tag") {
+		t.Error("expected the removed and added field of the same type to be treated as a rename")
+	}
+
+	if err := NewMigrationGenerator(cfg).Generate(newCRDs, newCRDs, ""); err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+	content, err = os.ReadFile(filepath.Join(tmpDir, "MIGRATION.md"))
+	if err != nil {
+		t.Fatalf("failed to read MIGRATION.md: %v", err)
+	}
+	if !strings.Contains(string(content), "No CRD changes were detected") {
+		t.Errorf("expected an unchanged generation to report no changes, got:\n%s", content)
+	}
+}
+
+func TestMigrationGenerator_ClusterScoped(t *testing.T) {
+	widget := func(fields ...*mapper.FieldDefinition) *mapper.CRDDefinition {
+		return &mapper.CRDDefinition{Kind: "Widget", Plural: "widgets", Scope: "Cluster", Spec: &mapper.FieldDefinition{Fields: fields}}
+	}
+	oldCRDs := []*mapper.CRDDefinition{widget(
+		&mapper.FieldDefinition{JSONName: "tag", GoType: "string"},
+		&mapper.FieldDefinition{JSONName: "owner", GoType: "string"},
+	)}
+	newCRDs := []*mapper.CRDDefinition{widget(
+		&mapper.FieldDefinition{JSONName: "label", GoType: "string"},
+		&mapper.FieldDefinition{JSONName: "owner", GoType: "string", Required: true},
+	)}
+
+	tmpDir := t.TempDir()
+	cfg := &config.Config{OutputDir: tmpDir, APIGroup: "example.com", APIVersion: "v1alpha1"}
+	if err := NewMigrationGenerator(cfg).Generate(oldCRDs, newCRDs, ""); err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+	content, err := os.ReadFile(filepath.Join(tmpDir, "MIGRATION.md"))
+	if err != nil {
+		t.Fatalf("failed to read MIGRATION.md: %v", err)
+	}
+	got := string(content)
+
+	for _, want := range []string{
+		`jq -r '"kubectl patch widgets.example.com \(.name) --type=merge -p "`,
+		`select(.spec.owner == null) | "\(.metadata.name)"'`,
+		`kubectl patch widgets.example.com <name> --type=merge -p '{"spec":{"owner":"<value>"}}'`,
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("expected MIGRATION.md to contain %q", want)
+		}
+	}
+	if strings.Contains(got, "-n ") {
+		t.Errorf("expected no -n for a cluster-scoped Kind, got:\n%s", got)
+	}
+}
+
+func TestPreviousCRDs(t *testing.T) {
+	tmpDir := t.TempDir()
+	if _, _, err := PreviousCRDs(tmpDir); err == nil {
+		t.Fatal("expected an error without a saved config")
+	}
+
+	spec := `openapi: "3.0.0"
+info:
+  title: Pets
+  version: "1.0.0"
+paths:
+  /pets:
+    post:
+      requestBody:
+        content:
+          application/json:
+            schema:
+              $ref: '#/components/schemas/Pet'
+      responses:
+        "201":
+          description: created
+  /pets/{id}:
+    get:
+      parameters:
+        - name: id
+          in: path
+          required: true
+          schema:
+            type: integer
+      responses:
+        "200":
+          description: ok
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/Pet'
+components:
+  schemas:
+    Pet:
+      type: object
+      properties:
+        id:
+          type: integer
+        name:
+          type: string
+`
+	if err := os.WriteFile(filepath.Join(tmpDir, "pets.yaml"), []byte(spec), 0644); err != nil {
+		t.Fatal(err)
+	}
+	saved := "spec: /elsewhere/pets.yaml\ngroup: pets.example.com\nmodule: github.com/example/pets-operator\n"
+	if err := os.WriteFile(filepath.Join(tmpDir, ".openapi-operator-gen.yaml"), []byte(saved), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	crds, specVersion, err := PreviousCRDs(tmpDir)
+	if err != nil {
+		t.Fatalf("PreviousCRDs failed: %v", err)
+	}
+	if specVersion != "1.0.0" {
+		t.Errorf("spec version = %q, want 1.0.0", specVersion)
+	}
+	var pet *mapper.CRDDefinition
+	for _, crd := range crds {
+		if crd.Kind == "Pet" {
+			pet = crd
+		}
+	}
+	if pet == nil {
+		t.Fatalf("expected the Pet CRD from the spec copy, got %d CRDs", len(crds))
+	}
+	if pet.APIGroup != "pets.example.com" {
+		t.Errorf("expected the saved API group, got %q", pet.APIGroup)
+	}
+}
+
+func TestPlanKindRegeneration(t *testing.T) {
+	tmpDir := t.TempDir()
+	spec := `openapi: "3.0.0"
+info:
+  title: Pets
+  version: "1.0.0"
+paths:
+  /pets:
+    post:
+      requestBody:
+        content:
+          application/json:
+            schema:
+              $ref: '#/components/schemas/Pet'
+      responses:
+        "201":
+          description: created
+  /pets/{id}:
+    get:
+      parameters:
+        - name: id
+          in: path
+          required: true
+          schema:
+            type: integer
+      responses:
+        "200":
+          description: ok
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/Pet'
+components:
+  schemas:
+    Pet:
+      type: object
+      properties:
+        id:
+          type: integer
+        name:
+          type: string
+`
+	if err := os.WriteFile(filepath.Join(tmpDir, "pets.yaml"), []byte(spec), 0644); err != nil {
+		t.Fatal(err)
+	}
+	saved := "spec: /elsewhere/pets.yaml\ngroup: pets.example.com\nmodule: github.com/example/pets-operator\n"
+	if err := os.WriteFile(filepath.Join(tmpDir, ".openapi-operator-gen.yaml"), []byte(saved), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	pet := &mapper.CRDDefinition{APIGroup: "pets.example.com", Kind: "Pet", Plural: "pets"}
+	owner := &mapper.CRDDefinition{APIGroup: "pets.example.com", Kind: "Owner", Plural: "owners"}
+	crds := []*mapper.CRDDefinition{pet, owner}
+
+	plan, err := PlanKindRegeneration(tmpDir, crds, "Pet")
+	if err != nil {
+		t.Fatalf("PlanKindRegeneration failed: %v", err)
+	}
+	if plan.CRD != pet {
+		t.Errorf("expected the new Pet definition, got %+v", plan.CRD)
+	}
+	replaced := false
+	for _, crd := range plan.TypesCRDs {
+		switch crd.Kind {
+		case "Pet":
+			replaced = crd == pet
+		case "Owner":
+			t.Error("expected Owner, which wasn't generated last time, to be left out of the types")
+		}
+	}
+	if !replaced {
+		t.Error("expected the previous Pet definition to be replaced by the new one")
+	}
+
+	if _, err := PlanKindRegeneration(tmpDir, crds, "Owner"); err == nil || !strings.Contains(err.Error(), "wasn't in the last generation") {
+		t.Errorf("expected an error for a Kind added since the last generation, got %v", err)
+	}
+	if _, err := PlanKindRegeneration(tmpDir, crds, "Toy"); err == nil || !strings.Contains(err.Error(), "is not in the spec") {
+		t.Errorf("expected an error for a Kind missing from the spec, got %v", err)
+	}
+}
+
+func TestPlanKindRegeneration_Consecutive(t *testing.T) {
+	outputDir := t.TempDir()
+	srcSpec := filepath.Join(t.TempDir(), "pets.yaml")
+	writeSpec := func(path string, petFields, ownerFields []string) {
+		t.Helper()
+		var b strings.Builder
+		b.WriteString("openapi: \"3.0.0\"\ninfo:\n  title: Pets\n  version: \"1.0.0\"\npaths:\n")
+		for _, r := range []string{"pets", "owners"} {
+			schema := map[string]string{"pets": "Pet", "owners": "Owner"}[r]
+			fmt.Fprintf(&b, `  /%[1]s:
+    post:
+      requestBody:
+        content:
+          application/json:
+            schema:
+              $ref: '#/components/schemas/%[2]s'
+      responses:
+        "201":
+          description: created
+  /%[1]s/{id}:
+    get:
+      parameters:
+        - name: id
+          in: path
+          required: true
+          schema:
+            type: integer
+      responses:
+        "200":
+          description: ok
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/%[2]s'
+`, r, schema)
+		}
+		b.WriteString("components:\n  schemas:\n")
+		for schema, fields := range map[string][]string{"Pet": petFields, "Owner": ownerFields} {
+			fmt.Fprintf(&b, "    %s:\n      type: object\n      properties:\n        id:\n          type: integer\n", schema)
+			for _, f := range fields {
+				fmt.Fprintf(&b, "        %s:\n          type: string\n", f)
+			}
+		}
+		if err := os.WriteFile(path, []byte(b.String()), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	hasField := func(crds []*mapper.CRDDefinition, kind, field string) bool {
+		for _, crd := range crds {
+			if crd.Kind != kind {
+				continue
+			}
+			for _, f := range crd.Spec.Fields {
+				if f.JSONName == field {
+					return true
+				}
+			}
+		}
+		return false
+	}
+	// loadConfig loads the saved config as the MCP generate tool's only_kind does
+	loadConfig := func() *config.Config {
+		t.Helper()
+		file, err := config.LoadConfigFile(filepath.Join(outputDir, manifestFile))
+		if err != nil || file == nil {
+			t.Fatalf("failed to load the saved config: %v", err)
+		}
+		cfg := config.ConfigFromFile(file)
+		cfg.OutputDir = outputDir
+		cfg.GeneratedFiles = file.GeneratedFiles
+		cfg.KindSpecHashes = file.KindSpecHashes
+		return cfg
+	}
+	regenerate := func(kind string) *KindRegeneration {
+		t.Helper()
+		cfg := loadConfig()
+		crds, _, err := mapSpecCopy(cfg, srcSpec)
+		if err != nil {
+			t.Fatalf("failed to map the current spec: %v", err)
+		}
+		plan, err := PlanKindRegeneration(outputDir, crds, kind)
+		if err != nil {
+			t.Fatalf("PlanKindRegeneration(%s) failed: %v", kind, err)
+		}
+		if err := SaveKindSpec(cfg, kind); err != nil {
+			t.Fatalf("SaveKindSpec(%s) failed: %v", kind, err)
+		}
+		if err := SaveManifest(cfg); err != nil {
+			t.Fatalf("SaveManifest failed: %v", err)
+		}
+		return plan
+	}
+
+	// The last full generation
+	writeSpec(filepath.Join(outputDir, "pets.yaml"), []string{"name"}, []string{"name"})
+	saved := "spec: " + srcSpec + "\ngroup: pets.example.com\nmodule: github.com/example/pets-operator\n"
+	if err := os.WriteFile(filepath.Join(outputDir, manifestFile), []byte(saved), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	writeSpec(srcSpec, []string{"name", "age"}, []string{"name"})
+	plan := regenerate("Pet")
+	if !hasField(plan.TypesCRDs, "Pet", "age") {
+		t.Error("expected the Pet types to pick up the new age field")
+	}
+
+	// Regenerating Owner from a newer spec keeps Pet as it was regenerated
+	writeSpec(srcSpec, []string{"name", "age", "color"}, []string{"name", "email"})
+	plan = regenerate("Owner")
+	if !hasField(plan.TypesCRDs, "Pet", "age") {
+		t.Error("expected the Pet types to keep the age field from its own regeneration")
+	}
+	if hasField(plan.TypesCRDs, "Pet", "color") {
+		t.Error("expected the Pet types not to pick up a field Pet's controller wasn't regenerated for")
+	}
+	if !hasField(plan.TypesCRDs, "Owner", "email") {
+		t.Error("expected the Owner types to pick up the new email field")
+	}
+
+	cfg := loadConfig()
+	if cfg.KindSpecHashes["Pet"] == "" || cfg.KindSpecHashes["Owner"] == "" || cfg.KindSpecHashes["Pet"] == cfg.KindSpecHashes["Owner"] {
+		t.Errorf("expected Pet and Owner to record the hashes of different specs, got %v", cfg.KindSpecHashes)
+	}
+
+	// A full generation renders every Kind from one spec and drops the per-Kind copies
+	cfg.KindSpecHashes = nil
+	if err := SaveManifest(cfg); err != nil {
+		t.Fatalf("SaveManifest failed: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(outputDir, kindSpecsDir)); !os.IsNotExist(err) {
+		t.Errorf("expected %s to be removed by a full generation, got %v", kindSpecsDir, err)
+	}
+}
+
+func TestControllerGenerator_GenerateKind(t *testing.T) {
+	tmpDir := t.TempDir()
+	cfg := &config.Config{
+		OutputDir:        tmpDir,
+		APIGroup:         "test.example.com",
+		APIVersion:       "v1alpha1",
+		ModuleName:       "github.com/example/widget-operator",
+		ControllerHashes: map[string]string{"internal/controller/gadget_controller.go": "sha256:abc"},
+	}
+	crd := &mapper.CRDDefinition{APIGroup: "test.example.com", APIVersion: "v1alpha1", Kind: "Widget", Plural: "widgets", BasePath: "/widgets", HasPost: true}
+	if err := NewControllerGenerator(cfg).GenerateKind(crd); err != nil {
+		t.Fatalf("GenerateKind failed: %v", err)
+	}
+
+	for _, file := range []string{"widget_controller.go", "widget_controller_test.go", "widget_integration_test.go"} {
+		if _, err := os.Stat(filepath.Join(tmpDir, "internal", "controller", file)); err != nil {
+			t.Errorf("expected %s: %v", file, err)
+		}
+	}
+	for _, file := range []string{filepath.Join("internal", "controller", "suite_test.go"), filepath.Join("cmd", "manager", "main.go"), "Makefile", ".openapi-operator-gen.yaml"} {
+		if _, err := os.Stat(filepath.Join(tmpDir, file)); err == nil {
+			t.Errorf("expected %s to be left alone", file)
+		}
+	}
+	if cfg.ControllerHashes["internal/controller/gadget_controller.go"] != "sha256:abc" || cfg.ControllerHashes["internal/controller/widget_controller.go"] == "" {
+		t.Errorf("expected the other controllers' hashes kept and the Widget's added, got %v", cfg.ControllerHashes)
+	}
+}
+
+func TestControllerGenerator_GenerateAppend(t *testing.T) {
+	tmpDir := t.TempDir()
+	existing := &config.Config{
+		OutputDir:  tmpDir,
+		APIGroup:   "test.example.com",
+		APIVersion: "v1alpha1",
+		ModuleName: "github.com/example/widget-operator",
+	}
+	widgets := []*mapper.CRDDefinition{
+		{APIGroup: "test.example.com", APIVersion: "v1alpha1", Kind: "Widget", Plural: "widgets", BasePath: "/widgets", Spec: &mapper.FieldDefinition{}},
+	}
+	if err := NewTypesGenerator(existing).Generate(widgets); err != nil {
+		t.Fatalf("Generate types failed: %v", err)
+	}
+	if err := NewControllerGenerator(existing).Generate(widgets, nil, nil); err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+
+	target, err := LoadAppendTarget(tmpDir)
+	if err != nil {
+		t.Fatalf("LoadAppendTarget failed: %v", err)
+	}
+	if target.ModulePath != "github.com/example/widget-operator" || len(target.Kinds["test.example.com"]) != 1 {
+		t.Fatalf("unexpected append target: %+v", target)
+	}
+
+	cfg := &config.Config{
+		OutputDir:  tmpDir,
+		AppendTo:   tmpDir,
+		APIGroup:   "gadgets.example.com",
+		APIVersion: "v1",
+		ModuleName: target.ModulePath,
+	}
+	gadgets := []*mapper.CRDDefinition{
+		{APIGroup: "gadgets.example.com", APIVersion: "v1", Kind: "Gadget", Plural: "gadgets", BasePath: "/gadgets", Spec: &mapper.FieldDefinition{}},
+	}
+	if err := target.CheckCollisions(cfg, gadgets); err != nil {
+		t.Fatalf("CheckCollisions failed: %v", err)
+	}
+	if err := target.CheckCollisions(cfg, widgets); err == nil || !strings.Contains(err.Error(), "Widget") {
+		t.Errorf("expected a Kind collision error, got %v", err)
+	}
+	sameGroup := *cfg
+	sameGroup.APIGroup = "test.example.com"
+	if err := target.CheckCollisions(&sameGroup, gadgets); err == nil {
+		t.Error("expected an API group collision error")
+	}
+
+	if err := NewTypesGenerator(cfg).Generate(gadgets); err != nil {
+		t.Fatalf("Generate types failed: %v", err)
+	}
+	if err := NewControllerGenerator(cfg).GenerateAppend(gadgets); err != nil {
+		t.Fatalf("GenerateAppend failed: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(tmpDir, "api", "gadgets", "v1", "types.go")); err != nil {
+		t.Errorf("expected types in api/gadgets/v1: %v", err)
+	}
+	controller, err := os.ReadFile(filepath.Join(tmpDir, "internal", "controller", "gadget_controller.go"))
+	if err != nil {
+		t.Fatalf("failed to read gadget controller: %v", err)
+	}
+	if !strings.Contains(string(controller), `v1 "github.com/example/widget-operator/api/gadgets/v1"`) {
+		t.Error("gadget controller doesn't import api/gadgets/v1")
+	}
+
+	main, err := os.ReadFile(filepath.Join(tmpDir, "cmd", "manager", "main.go"))
+	if err != nil {
+		t.Fatalf("failed to read main.go: %v", err)
+	}
+	for _, want := range []string{
+		`gadgetsv1 "github.com/example/widget-operator/api/gadgets/v1"`,
+		"utilruntime.Must(gadgetsv1.AddToScheme(scheme))",
+		"&gadgetsv1.Gadget{}: {",
+		"(&controller.GadgetReconciler{",
+		"(&controller.WidgetReconciler{",
+	} {
+		if !strings.Contains(string(main), want) {
+			t.Errorf("main.go missing %q", want)
+		}
 	}
 }
@@ -0,0 +1,53 @@
+package generator
+
+import (
+	"bytes"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// fallbackGitIdentity is used for the initial commit when git has no user configured,
+// so a fresh CI machine can still create the baseline
+var fallbackGitIdentity = []string{
+	"-c", "user.name=openapi-operator-gen",
+	"-c", "user.email=openapi-operator-gen@localhost",
+}
+
+// InitGitRepo initializes dir as a git repository and commits everything in it, giving the
+// diff tool a baseline of the generated files and embedded spec. It returns false without
+// changing anything when dir is already inside a git work tree.
+func InitGitRepo(dir, message string) (bool, error) {
+	if _, err := runGit(dir, "rev-parse", "--is-inside-work-tree"); err == nil {
+		return false, nil
+	}
+
+	if _, err := runGit(dir, "init", "--quiet"); err != nil {
+		return false, err
+	}
+	if _, err := runGit(dir, "add", "--all"); err != nil {
+		return false, err
+	}
+
+	args := []string{"commit", "--quiet", "--message", message}
+	if email, _ := runGit(dir, "config", "user.email"); email == "" {
+		args = append(append([]string{}, fallbackGitIdentity...), args...)
+	}
+	if _, err := runGit(dir, args...); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// runGit runs git in dir and returns its trimmed stdout
+func runGit(dir string, args ...string) (string, error) {
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("git %s: %w: %s", args[0], err, strings.TrimSpace(stderr.String()))
+	}
+	return strings.TrimSpace(stdout.String()), nil
+}
@@ -2,6 +2,7 @@ package generator
 
 import (
 	"fmt"
+	"io"
 	"os"
 	"os/exec"
 	"path/filepath"
@@ -259,6 +260,62 @@ func TestGeneratedCodeFromPetstoreSpec(t *testing.T) {
 	t.Logf("Generated code from petstore.yaml compiles successfully (%d CRDs)", len(crds))
 }
 
+// TestGeneratedCodePassesVet generates the default operator for petstore.yaml and runs
+// go vet on it, so templates that produce vet-unclean code fail here
+func TestGeneratedCodePassesVet(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping vet test in short mode")
+	}
+
+	specPath := filepath.Join("..", "..", "examples", "petstore.1.0.27.yaml")
+	if _, err := os.Stat(specPath); os.IsNotExist(err) {
+		t.Skipf("petstore.yaml not found at %s", specPath)
+	}
+
+	tmpDir := t.TempDir()
+	cfg := &config.Config{
+		SpecPath:    specPath,
+		OutputDir:   tmpDir,
+		APIGroup:    "petstore.example.com",
+		APIVersion:  "v1alpha1",
+		ModuleName:  "github.com/example/petstore-operator",
+		MappingMode: config.PerResource,
+	}
+
+	p := parser.NewParser()
+	p.LogWriter = io.Discard
+	spec, err := p.Parse(specPath)
+	if err != nil {
+		t.Fatalf("failed to parse spec: %v", err)
+	}
+	crds, err := mapper.NewMapper(cfg).MapResources(spec)
+	if err != nil {
+		t.Fatalf("failed to map resources: %v", err)
+	}
+
+	if err := NewTypesGenerator(cfg).Generate(crds); err != nil {
+		t.Fatalf("TypesGenerator.Generate failed: %v", err)
+	}
+	if err := NewControllerGenerator(cfg).Generate(crds, nil, nil); err != nil {
+		t.Fatalf("ControllerGenerator.Generate failed: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(tmpDir, ".golangci.yml")); err != nil {
+		t.Errorf("expected .golangci.yml for make lint: %v", err)
+	}
+
+	if err := runCompilationSteps(t, tmpDir); err != nil {
+		t.Fatalf("Compilation failed: %v", err)
+	}
+
+	t.Log("Running go vet...")
+	vetCmd := exec.Command("go", "vet", "./...")
+	vetCmd.Dir = tmpDir
+	vetCmd.Env = append(os.Environ(), "GO111MODULE=on")
+	if output, err := vetCmd.CombinedOutput(); err != nil {
+		t.Fatalf("go vet failed: %v\nOutput: %s", err, output)
+	}
+}
+
 // runCompilationSteps runs go mod tidy, controller-gen, and go build
 func runCompilationSteps(t *testing.T, dir string) error {
 	t.Helper()
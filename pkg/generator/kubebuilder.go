@@ -0,0 +1,97 @@
+package generator
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+
+	"github.com/bluecontainer/openapi-operator-gen/pkg/mapper"
+	"github.com/bluecontainer/openapi-operator-gen/pkg/templates"
+)
+
+// ProjectData holds data for the kubebuilder PROJECT file written with --kubebuilder
+type ProjectData struct {
+	GeneratorVersion string
+	// Group and Domain split the API group at its first label (e.g., petstore and example.com)
+	Group       string
+	Domain      string
+	Version     string
+	ProjectName string
+	Repo        string
+	// Path is the import path of the API types package
+	Path      string
+	Resources []ProjectResource
+}
+
+// ProjectResource is a Kind listed in the PROJECT file, with its controller
+type ProjectResource struct {
+	Kind       string
+	Namespaced bool
+}
+
+// projectData returns the PROJECT file data of the given resources
+func (g *ControllerGenerator) projectData(resources []ProjectResource) ProjectData {
+	group, domain, _ := strings.Cut(g.config.APIGroup, ".")
+	return ProjectData{
+		GeneratorVersion: g.config.GeneratorVersion,
+		Group:            group,
+		Domain:           domain,
+		Version:          g.config.APIVersion,
+		ProjectName:      group,
+		Repo:             g.config.ResolvedImportPrefix(),
+		Path:             g.config.APIImportPath(),
+		Resources:        resources,
+	}
+}
+
+// projectResources lists the CRDs, and the aggregate and bundle when generated, as PROJECT
+// file resources
+func projectResources(crds []*mapper.CRDDefinition, aggregate *mapper.AggregateDefinition, bundle *mapper.BundleDefinition) []ProjectResource {
+	resources := make([]ProjectResource, 0, len(crds)+2)
+	for _, crd := range crds {
+		resources = append(resources, ProjectResource{Kind: crd.Kind, Namespaced: crd.Scope != "Cluster"})
+	}
+	if aggregate != nil {
+		resources = append(resources, ProjectResource{Kind: aggregate.Kind, Namespaced: true})
+	}
+	if bundle != nil {
+		resources = append(resources, ProjectResource{Kind: bundle.Kind, Namespaced: true})
+	}
+	return resources
+}
+
+// generateProjectFile writes the kubebuilder PROJECT file, which records the operator's
+// resources so kubebuilder create api and create webhook can scaffold alongside them
+func (g *ControllerGenerator) generateProjectFile(crds []*mapper.CRDDefinition, aggregate *mapper.AggregateDefinition, bundle *mapper.BundleDefinition) error {
+	data := g.projectData(projectResources(crds, aggregate, bundle))
+	return g.executeTemplate(templates.ProjectTemplate, data, filepath.Join(g.config.OutputDir, "PROJECT"))
+}
+
+// appendToProject adds the resources of crds to the operator's PROJECT file, before its
+// trailing version field
+func (g *ControllerGenerator) appendToProject(crds []*mapper.CRDDefinition) error {
+	projectPath := filepath.Join(g.config.OutputDir, "PROJECT")
+	content, err := os.ReadFile(projectPath)
+	if err != nil {
+		return err
+	}
+	tmpl, err := template.New("project").Parse(templates.ProjectTemplate)
+	if err != nil {
+		return fmt.Errorf("failed to parse template: %w", err)
+	}
+	var resources bytes.Buffer
+	if err := tmpl.ExecuteTemplate(&resources, "projectResources", g.projectData(projectResources(crds, nil, nil))); err != nil {
+		return fmt.Errorf("failed to execute template: %w", err)
+	}
+
+	src := string(content)
+	i := strings.Index(src, "\nversion:")
+	if i < 0 {
+		return fmt.Errorf("%s has no version field", projectPath)
+	}
+	src = src[:i] + resources.String() + src[i:]
+	return g.files.WriteFile(projectPath, []byte(src), 0644)
+}
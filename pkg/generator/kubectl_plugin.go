@@ -3,8 +3,8 @@ package generator
 import (
 	"bytes"
 	"fmt"
-	"os"
 	"path/filepath"
+	"regexp"
 	"strings"
 	"text/template"
 	"time"
@@ -17,11 +17,12 @@ import (
 // KubectlPluginGenerator generates kubectl plugin code
 type KubectlPluginGenerator struct {
 	config *config.Config
+	files  FileSink
 }
 
 // NewKubectlPluginGenerator creates a new kubectl plugin generator
 func NewKubectlPluginGenerator(cfg *config.Config) *KubectlPluginGenerator {
-	return &KubectlPluginGenerator{config: cfg}
+	return &KubectlPluginGenerator{config: cfg, files: newFileSink(cfg)}
 }
 
 // KindInfo holds information about a CRD kind for the kubectl plugin
@@ -30,6 +31,58 @@ type KindInfo struct {
 	KindLower  string   // e.g., "pet"
 	Plural     string   // e.g., "pets"
 	ShortNames []string // e.g., ["pet"]
+	// Action kinds run against a parent resource only: the parent kind and the spec field
+	// holding its ID
+	ParentKind    string // e.g., "Pet"
+	ParentIDField string // e.g., "petId"
+}
+
+// ExportKindInfo holds what the export command needs to turn a resource CR back into an API payload
+type ExportKindInfo struct {
+	Kind         string
+	KindLower    string
+	Plural       string
+	ResourcePath string            // e.g., "/store/order/{orderId}"
+	PathParams   []ExportPathParam // Path parameters and the spec fields holding their values
+	DropFields   []string          // Spec fields sent in the URL, not the body
+}
+
+// ExportPathParam maps a URL path parameter to the spec field holding its value
+type ExportPathParam struct {
+	Name  string // e.g., "orderId"
+	Field string // e.g., "id" when merged via IDFieldMappings, otherwise "orderId"
+}
+
+// ImportKindInfo holds what the import command needs to turn API objects into resource CRs
+type ImportKindInfo struct {
+	Kind       string
+	KindLower  string
+	Plural     string
+	ListPath   string            // GET path listing the objects, e.g., "/users"; empty when the API has none
+	SpecFields []string          // Spec fields copied from the matching keys of each object
+	PathParams []ImportPathParam // Resource path parameters and the spec fields holding their values
+	// IDParam is the path parameter identifying the object itself when it isn't merged into a
+	// body field; its spec field falls back to the object's id
+	IDParam       string
+	ExternalIDRef bool // The CR references the object through spec.externalIDRef
+}
+
+// ImportPathParam maps a URL path parameter to the spec field the import command fills
+type ImportPathParam struct {
+	Name    string // e.g., "orderId"
+	Field   string // e.g., "id" when merged via IDFieldMappings, otherwise "orderId"
+	Numeric bool   // The spec field is an integer or number
+}
+
+// KrewPlatform describes one release archive listed in the krew manifest.
+// Archive and Binary match the names produced by the plugin Makefile's release targets.
+type KrewPlatform struct {
+	OS                string // e.g., "linux"
+	Arch              string // e.g., "amd64"
+	Archive           string // e.g., "kubectl-petstore_linux_amd64.tar.gz"
+	Binary            string // Binary name inside the archive, e.g., "kubectl-petstore_linux_amd64"
+	Bin               string // Installed binary name, e.g., "kubectl-petstore"
+	SHA256Placeholder string // Replaced by "make krew-manifest", e.g., "SHA256_LINUX_AMD64"
 }
 
 // KubectlPluginTemplateData holds data for kubectl plugin templates
@@ -42,14 +95,25 @@ type KubectlPluginTemplateData struct {
 	PluginName       string // e.g., "petstore"
 	BinaryName       string // e.g., "kubectl-petstore"
 	ModuleName       string // Go module name for the plugin
+	Namespace        string // Default namespace, matching the generated samples
 	ResourceKinds    []KindInfo
 	QueryKinds       []KindInfo
 	ActionKinds      []KindInfo
 	AllKinds         []KindInfo
+	ExportKinds      []ExportKindInfo
+	ImportKinds      []ImportKindInfo
 	HasAggregate     bool
 	AggregateKind    string
 	HasBundle        bool
 	BundleKind       string
+	// ReadyCondition is the type of the controllers' Ready condition (--emit-conditions)
+	ReadyCondition string
+	// krew manifest (only with --krew-manifest)
+	KrewManifest  bool
+	KrewVersion   string // Semantic version with "v" prefix, from the spec's info.version
+	Homepage      string
+	ReleaseURL    string // Base URL the release archives are downloaded from
+	KrewPlatforms []KrewPlatform
 }
 
 // Generate generates the kubectl plugin code
@@ -63,7 +127,7 @@ func (g *KubectlPluginGenerator) Generate(crds []*mapper.CRDDefinition, aggregat
 		filepath.Join(pluginDir, "pkg", "output"),
 	}
 	for _, dir := range dirs {
-		if err := os.MkdirAll(dir, 0755); err != nil {
+		if err := g.files.MkdirAll(dir, 0755); err != nil {
 			return fmt.Errorf("failed to create directory %s: %w", dir, err)
 		}
 	}
@@ -87,6 +151,9 @@ func (g *KubectlPluginGenerator) Generate(crds []*mapper.CRDDefinition, aggregat
 		{templates.KubectlPluginCompareCmdTemplate, filepath.Join(pluginDir, "cmd", "compare.go")},
 		{templates.KubectlPluginDiagnoseCmdTemplate, filepath.Join(pluginDir, "cmd", "diagnose.go")},
 		{templates.KubectlPluginDriftCmdTemplate, filepath.Join(pluginDir, "cmd", "drift.go")},
+		{templates.KubectlPluginExportCmdTemplate, filepath.Join(pluginDir, "cmd", "export.go")},
+		{templates.KubectlPluginImportCmdTemplate, filepath.Join(pluginDir, "cmd", "import.go")},
+		{templates.KubectlPluginGraphCmdTemplate, filepath.Join(pluginDir, "cmd", "graph.go")},
 		// Phase 3: Interactive/Management Commands
 		{templates.KubectlPluginCreateCmdTemplate, filepath.Join(pluginDir, "cmd", "create.go")},
 		{templates.KubectlPluginQueryCmdTemplate, filepath.Join(pluginDir, "cmd", "query.go")},
@@ -101,9 +168,27 @@ func (g *KubectlPluginGenerator) Generate(crds []*mapper.CRDDefinition, aggregat
 		{templates.KubectlPluginClientTemplate, filepath.Join(pluginDir, "pkg", "client", "client.go")},
 		{templates.KubectlPluginOutputTemplate, filepath.Join(pluginDir, "pkg", "output", "output.go")},
 		// Build files
-		{templates.KubectlPluginGoModTemplate, filepath.Join(pluginDir, "go.mod")},
 		{templates.KubectlPluginMakefileTemplate, filepath.Join(pluginDir, "Makefile")},
 	}
+	// The command tests seed CRs of the first kind, so need at least one
+	if len(data.AllKinds) > 0 {
+		templateFiles = append(templateFiles, struct {
+			tmplContent string
+			outputPath  string
+		}{templates.KubectlPluginCmdTestTemplate, filepath.Join(pluginDir, "cmd", "cmd_test.go")})
+	}
+	if !g.config.SkipGoMod {
+		templateFiles = append(templateFiles, struct {
+			tmplContent string
+			outputPath  string
+		}{templates.KubectlPluginGoModTemplate, filepath.Join(pluginDir, "go.mod")})
+	}
+	if data.KrewManifest {
+		templateFiles = append(templateFiles, struct {
+			tmplContent string
+			outputPath  string
+		}{templates.KubectlPluginKrewManifestTemplate, filepath.Join(pluginDir, "plugin.yaml")})
+	}
 
 	funcMap := template.FuncMap{
 		"lower":     strings.ToLower,
@@ -127,7 +212,7 @@ func (g *KubectlPluginGenerator) prepareTemplateData(crds []*mapper.CRDDefinitio
 	apiName := strings.Split(g.config.APIGroup, ".")[0]
 
 	// Build module name for the plugin
-	pluginModuleName := fmt.Sprintf("%s/kubectl-plugin", g.config.ModuleName)
+	pluginModuleName := fmt.Sprintf("%s/kubectl-plugin", g.config.ResolvedImportPrefix())
 
 	data := KubectlPluginTemplateData{
 		Year:             time.Now().Year(),
@@ -138,6 +223,8 @@ func (g *KubectlPluginGenerator) prepareTemplateData(crds []*mapper.CRDDefinitio
 		PluginName:       apiName,
 		BinaryName:       "kubectl-" + apiName,
 		ModuleName:       pluginModuleName,
+		Namespace:        g.config.ResolvedSampleNamespace(),
+		ReadyCondition:   g.config.ConditionType(config.ConditionReady),
 		ResourceKinds:    make([]KindInfo, 0),
 		QueryKinds:       make([]KindInfo, 0),
 		ActionKinds:      make([]KindInfo, 0),
@@ -152,6 +239,10 @@ func (g *KubectlPluginGenerator) prepareTemplateData(crds []*mapper.CRDDefinitio
 			Plural:     crd.Plural,
 			ShortNames: []string{strings.ToLower(crd.Kind)},
 		}
+		if crd.IsAction && crd.ParentResource != "" && crd.ParentIDParam != "" {
+			kindInfo.ParentKind = crd.ParentResource
+			kindInfo.ParentIDField = g.config.JSONFieldName(crd.ParentIDParam)
+		}
 
 		data.AllKinds = append(data.AllKinds, kindInfo)
 
@@ -161,6 +252,8 @@ func (g *KubectlPluginGenerator) prepareTemplateData(crds []*mapper.CRDDefinitio
 			data.ActionKinds = append(data.ActionKinds, kindInfo)
 		} else {
 			data.ResourceKinds = append(data.ResourceKinds, kindInfo)
+			data.ExportKinds = append(data.ExportKinds, g.exportKindInfo(crd))
+			data.ImportKinds = append(data.ImportKinds, g.importKindInfo(crd))
 		}
 	}
 
@@ -190,9 +283,198 @@ func (g *KubectlPluginGenerator) prepareTemplateData(crds []*mapper.CRDDefinitio
 		data.AllKinds = append(data.AllKinds, bundleInfo)
 	}
 
+	if g.config.GenerateKrewManifest {
+		data.KrewManifest = true
+		data.KrewVersion = krewVersion(g.config.SpecVersion)
+		data.Homepage = g.config.SpecHomepage
+		if data.Homepage == "" {
+			data.Homepage = "https://" + g.config.ModuleName
+		}
+		data.ReleaseURL = releaseURL(g.config.ModuleName, data.KrewVersion)
+		data.KrewPlatforms = krewPlatforms(data.BinaryName)
+	}
+
 	return data
 }
 
+var semverPattern = regexp.MustCompile(`^v\d+\.\d+\.\d+(-[0-9A-Za-z.-]+)?(\+[0-9A-Za-z.-]+)?$`)
+
+// krewVersion turns the spec's info.version into the "vMAJOR.MINOR.PATCH" form krew
+// requires, padding missing components (e.g., "1.0" -> "v1.0.0"). Versions that still
+// aren't semantic versions fall back to v0.1.0.
+func krewVersion(specVersion string) string {
+	v := strings.TrimSpace(specVersion)
+	if v == "" {
+		return "v0.1.0"
+	}
+	if !strings.HasPrefix(v, "v") {
+		v = "v" + v
+	}
+	core, suffix := v, ""
+	if i := strings.IndexAny(v, "-+"); i >= 0 {
+		core, suffix = v[:i], v[i:]
+	}
+	for strings.Count(core, ".") < 2 {
+		core += ".0"
+	}
+	v = core + suffix
+	if !semverPattern.MatchString(v) {
+		return "v0.1.0"
+	}
+	return v
+}
+
+// releaseURL returns the download location of the release archives. GitHub modules
+// point at the repository's release assets; anything else gets a placeholder to edit.
+func releaseURL(moduleName, version string) string {
+	parts := strings.Split(moduleName, "/")
+	if len(parts) >= 3 && parts[0] == "github.com" {
+		return fmt.Sprintf("https://github.com/%s/%s/releases/download/%s", parts[1], parts[2], version)
+	}
+	return "https://example.com/releases/" + version
+}
+
+// krewPlatforms lists the archives built by the plugin Makefile's release target
+func krewPlatforms(binaryName string) []KrewPlatform {
+	targets := []struct{ os, arch string }{
+		{"darwin", "amd64"},
+		{"darwin", "arm64"},
+		{"linux", "amd64"},
+		{"linux", "arm64"},
+		{"windows", "amd64"},
+	}
+	platforms := make([]KrewPlatform, 0, len(targets))
+	for _, t := range targets {
+		name := fmt.Sprintf("%s_%s_%s", binaryName, t.os, t.arch)
+		p := KrewPlatform{
+			OS:                t.os,
+			Arch:              t.arch,
+			Archive:           name + ".tar.gz",
+			Binary:            name,
+			Bin:               binaryName,
+			SHA256Placeholder: fmt.Sprintf("SHA256_%s_%s", strings.ToUpper(t.os), strings.ToUpper(t.arch)),
+		}
+		if t.os == "windows" {
+			p.Archive = name + ".zip"
+			p.Binary = name + ".exe"
+			p.Bin = binaryName + ".exe"
+		}
+		platforms = append(platforms, p)
+	}
+	return platforms
+}
+
+// exportKindInfo applies the inverse of ID field merging: merged path params are read from
+// (and stay in) their body field, while other path and query params are dropped from the body.
+func (g *KubectlPluginGenerator) exportKindInfo(crd *mapper.CRDDefinition) ExportKindInfo {
+	info := ExportKindInfo{
+		Kind:         crd.Kind,
+		KindLower:    strings.ToLower(crd.Kind),
+		Plural:       crd.Plural,
+		ResourcePath: crd.ResourcePath,
+	}
+
+	merged := make(map[string]string)
+	for _, m := range crd.IDFieldMappings {
+		merged[m.PathParam] = m.BodyField
+	}
+
+	seen := make(map[string]bool)
+	drop := func(field string) {
+		if !seen[field] {
+			seen[field] = true
+			info.DropFields = append(info.DropFields, field)
+		}
+	}
+
+	for _, name := range pathParamNames(crd.ResourcePath) {
+		if bodyField, ok := merged[name]; ok {
+			info.PathParams = append(info.PathParams, ExportPathParam{Name: name, Field: bodyField})
+			continue
+		}
+		field := g.config.JSONFieldName(name)
+		info.PathParams = append(info.PathParams, ExportPathParam{Name: name, Field: field})
+		drop(field)
+	}
+	for _, op := range crd.Operations {
+		for _, name := range op.PathParams {
+			if _, ok := merged[name]; !ok {
+				drop(g.config.JSONFieldName(name))
+			}
+		}
+		// Mirrors the controller, which only sends Create query params in the URL
+		if op.CRDAction == "Create" {
+			for _, name := range op.QueryParams {
+				drop(g.config.JSONFieldName(name))
+			}
+		}
+	}
+	return info
+}
+
+// importKindInfo is the reverse of exportKindInfo: API object keys that are spec fields are
+// copied into the spec, and the resource path parameters are filled so the controller adopts
+// the existing object instead of creating a new one.
+func (g *KubectlPluginGenerator) importKindInfo(crd *mapper.CRDDefinition) ImportKindInfo {
+	info := ImportKindInfo{
+		Kind:          crd.Kind,
+		KindLower:     strings.ToLower(crd.Kind),
+		Plural:        crd.Plural,
+		ExternalIDRef: crd.NeedsExternalIDRef,
+	}
+
+	// The list endpoint is a GET on the resource path without its last {id} segment
+	for _, op := range crd.Operations {
+		if op.HTTPMethod != "GET" || !strings.HasPrefix(crd.ResourcePath, op.Path+"/") {
+			continue
+		}
+		rest := strings.TrimPrefix(crd.ResourcePath, op.Path+"/")
+		if strings.HasPrefix(rest, "{") && strings.HasSuffix(rest, "}") && !strings.Contains(rest, "/") {
+			info.ListPath = op.Path
+			break
+		}
+	}
+
+	numeric := make(map[string]bool)
+	if crd.Spec != nil {
+		for _, f := range crd.Spec.Fields {
+			info.SpecFields = append(info.SpecFields, f.JSONName)
+			switch strings.TrimPrefix(f.GoType, "*") {
+			case "int", "int32", "int64", "float32", "float64":
+				numeric[f.JSONName] = true
+			}
+		}
+	}
+
+	for _, p := range g.exportKindInfo(crd).PathParams {
+		info.PathParams = append(info.PathParams, ImportPathParam{Name: p.Name, Field: p.Field, Numeric: numeric[p.Field]})
+	}
+	if n := len(info.PathParams); n > 0 {
+		last := info.PathParams[n-1]
+		if last.Field == g.config.JSONFieldName(last.Name) {
+			info.IDParam = last.Name
+		}
+	}
+	return info
+}
+
+// pathParamNames returns the {placeholder} names in a path template, in order
+func pathParamNames(path string) []string {
+	var names []string
+	for {
+		start := strings.Index(path, "{")
+		if start < 0 {
+			return names
+		}
+		end := strings.Index(path[start:], "}")
+		if end < 0 {
+			return names
+		}
+		names = append(names, path[start+1:start+end])
+		path = path[start+end+1:]
+	}
+}
+
 // executePluginTemplate executes a template and writes to output file
 func (g *KubectlPluginGenerator) executePluginTemplate(tmplContent string, data interface{}, outputPath string, funcMap template.FuncMap) error {
 	tmpl, err := template.New("kubectl-plugin").Funcs(funcMap).Parse(tmplContent)
@@ -205,5 +487,5 @@ func (g *KubectlPluginGenerator) executePluginTemplate(tmplContent string, data
 		return fmt.Errorf("failed to execute template: %w", err)
 	}
 
-	return os.WriteFile(outputPath, buf.Bytes(), 0644)
+	return g.files.WriteFile(outputPath, buf.Bytes(), 0644)
 }
@@ -0,0 +1,328 @@
+package generator
+
+import (
+	"fmt"
+	"io"
+	"net/url"
+	"path"
+	"path/filepath"
+	"strings"
+	"text/template"
+
+	"github.com/bluecontainer/openapi-operator-gen/internal/config"
+	"github.com/bluecontainer/openapi-operator-gen/pkg/mapper"
+	"github.com/bluecontainer/openapi-operator-gen/pkg/parser"
+	"github.com/bluecontainer/openapi-operator-gen/pkg/templates"
+)
+
+// MigrationTemplateData holds the data for MIGRATION.md
+type MigrationTemplateData struct {
+	GeneratorVersion    string
+	APIGroup            string
+	APIVersion          string
+	SpecVersion         string
+	PreviousSpecVersion string
+	AddedKinds          []string
+	RemovedKinds        []MigrationKindData
+	ChangedKinds        []MigrationKindData
+}
+
+// MigrationKindData holds the migration steps for one CRD Kind
+type MigrationKindData struct {
+	Kind string
+	// Resource is the CRD name (<plural>.<group>), as used with kubectl
+	Resource string
+	// ClusterScoped is set for cluster-scoped Kinds, whose CRs are patched without -n
+	ClusterScoped bool
+	Steps         []MigrationStepData
+	// Notes describe changes that need no migration
+	Notes []string
+}
+
+// MigrationStepData is one change that may need existing CRs to be migrated
+type MigrationStepData struct {
+	Change string
+	Advice string
+	// Before holds commands to run while the previous CRDs are installed
+	Before string
+	// After holds commands to run once the new CRDs are applied
+	After string
+}
+
+// MigrationGenerator generates MIGRATION.md from the differences between the CRDs of the
+// previous generation and the current one
+type MigrationGenerator struct {
+	config *config.Config
+	files  FileSink
+}
+
+// NewMigrationGenerator creates a new migration guide generator
+func NewMigrationGenerator(cfg *config.Config) *MigrationGenerator {
+	return &MigrationGenerator{config: cfg, files: newFileSink(cfg)}
+}
+
+// PreviousCRDs maps the spec copy saved in outputDir by the previous generation, using the
+// configuration saved alongside it. It returns the CRDs and the spec's info.version.
+// Call it before generating, which overwrites the spec copy.
+func PreviousCRDs(outputDir string) ([]*mapper.CRDDefinition, string, error) {
+	file, err := config.LoadConfigFile(filepath.Join(outputDir, manifestFile))
+	if err != nil {
+		return nil, "", err
+	}
+	if file == nil {
+		return nil, "", fmt.Errorf("no %s found in %s; generate the operator first", manifestFile, outputDir)
+	}
+	prev := config.ConfigFromFile(file)
+	if prev.SpecPath == "" {
+		return nil, "", fmt.Errorf("%s does not record the spec", manifestFile)
+	}
+	return mapSpecCopy(prev, filepath.Join(outputDir, specCopyName(prev.SpecPath)))
+}
+
+// mapSpecCopy maps a saved copy of the spec with prev's settings, returning the CRDs and
+// the spec's info.version
+func mapSpecCopy(prev *config.Config, specCopy string) ([]*mapper.CRDDefinition, string, error) {
+	p := parser.NewParserWithFilter(prev.RootKind, config.NewPathFilter(prev))
+	p.SpecRootFile = prev.SpecRootFile
+	p.SpecFormat = prev.SpecFormat
+	p.ConstantPathParams = prev.ConstantPathParams
+	p.SpecCacheDir = prev.SpecCacheDir
+	p.LogWriter = io.Discard
+	spec, err := p.Parse(specCopy)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to parse previous spec copy: %w", err)
+	}
+	crds, err := mapper.NewMapper(prev).MapResources(spec)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to map previous spec: %w", err)
+	}
+	return crds, spec.Version, nil
+}
+
+// specCopyName returns the name copySpecFile gives the copy of specPath in the output directory
+func specCopyName(specPath string) string {
+	if strings.HasPrefix(specPath, "http://") || strings.HasPrefix(specPath, "https://") {
+		if parsedURL, err := url.Parse(specPath); err == nil {
+			if name := path.Base(parsedURL.Path); name != "" && name != "/" && name != "." {
+				return name
+			}
+		}
+		return "openapi-spec.yaml"
+	}
+	return filepath.Base(filepath.Clean(specPath))
+}
+
+// Generate writes MIGRATION.md to the output directory
+func (g *MigrationGenerator) Generate(oldCRDs, newCRDs []*mapper.CRDDefinition, previousSpecVersion string) error {
+	data := g.buildData(oldCRDs, newCRDs)
+	data.PreviousSpecVersion = previousSpecVersion
+
+	tmpl, err := template.New("migration").Parse(templates.MigrationTemplate)
+	if err != nil {
+		return fmt.Errorf("failed to parse template: %w", err)
+	}
+
+	file, err := g.files.Create(filepath.Join(g.config.OutputDir, "MIGRATION.md"))
+	if err != nil {
+		return fmt.Errorf("failed to create file: %w", err)
+	}
+	defer file.Close()
+
+	if err := tmpl.Execute(file, data); err != nil {
+		return fmt.Errorf("failed to execute template: %w", err)
+	}
+	return nil
+}
+
+func (g *MigrationGenerator) buildData(oldCRDs, newCRDs []*mapper.CRDDefinition) MigrationTemplateData {
+	data := MigrationTemplateData{
+		GeneratorVersion: g.config.GeneratorVersion,
+		APIGroup:         g.config.APIGroup,
+		APIVersion:       g.config.APIVersion,
+		SpecVersion:      g.config.SpecVersion,
+	}
+
+	oldByKind := make(map[string]*mapper.CRDDefinition)
+	for _, crd := range oldCRDs {
+		oldByKind[crd.Kind] = crd
+	}
+	newKinds := make(map[string]bool)
+	for _, crd := range newCRDs {
+		newKinds[crd.Kind] = true
+		old, ok := oldByKind[crd.Kind]
+		if !ok {
+			data.AddedKinds = append(data.AddedKinds, crd.Kind)
+			continue
+		}
+		if kind := g.migrateKind(old, crd); len(kind.Steps) > 0 || len(kind.Notes) > 0 {
+			data.ChangedKinds = append(data.ChangedKinds, kind)
+		}
+	}
+	for _, crd := range oldCRDs {
+		if !newKinds[crd.Kind] {
+			data.RemovedKinds = append(data.RemovedKinds, MigrationKindData{
+				Kind:     crd.Kind,
+				Resource: g.resourceName(crd),
+			})
+		}
+	}
+	return data
+}
+
+// resourceName returns the CRD name of crd in the new API group
+func (g *MigrationGenerator) resourceName(crd *mapper.CRDDefinition) string {
+	return crd.Plural + "." + g.config.APIGroup
+}
+
+// migrateKind turns the changes to one Kind into migration steps
+func (g *MigrationGenerator) migrateKind(old, new *mapper.CRDDefinition) MigrationKindData {
+	kind := MigrationKindData{Kind: new.Kind, Resource: g.resourceName(new), ClusterScoped: new.Scope == "Cluster"}
+	changes := mapper.CompareCRDs(old, new)
+
+	// A single removed field with a single added field of the same type is most likely a rename
+	var added, removed []mapper.CRDChange
+	for _, c := range changes {
+		switch c.Type {
+		case mapper.ChangeFieldAdded:
+			added = append(added, c)
+		case mapper.ChangeFieldRemoved:
+			removed = append(removed, c)
+		}
+	}
+	rename := len(added) == 1 && len(removed) == 1 && added[0].New == removed[0].Old
+
+	for _, c := range changes {
+		switch c.Type {
+		case mapper.ChangeFieldAdded:
+			if rename {
+				kind.Steps = append(kind.Steps, g.renameStep(kind, removed[0], c))
+			} else if c.Required {
+				kind.Steps = append(kind.Steps, g.requiredStep(kind, c))
+			} else {
+				kind.Notes = append(kind.Notes, c.String())
+			}
+		case mapper.ChangeFieldRemoved:
+			if !rename {
+				kind.Steps = append(kind.Steps, g.removedStep(kind, c))
+			}
+		case mapper.ChangeFieldRetyped:
+			if c.IsWidening() {
+				kind.Notes = append(kind.Notes, c.String()+" (widened; existing values stay valid)")
+			} else {
+				kind.Steps = append(kind.Steps, g.retypedStep(kind, c))
+			}
+		case mapper.ChangeFieldRequired:
+			kind.Steps = append(kind.Steps, g.requiredStep(kind, c))
+		case mapper.ChangeQueryParamAdded, mapper.ChangeQueryParamRemoved, mapper.ChangeQueryParamRetyped:
+			// Query parameters are also spec fields, so the field changes cover them
+		default:
+			kind.Notes = append(kind.Notes, c.String())
+		}
+	}
+	return kind
+}
+
+func (g *MigrationGenerator) removedStep(kind MigrationKindData, c mapper.CRDChange) MigrationStepData {
+	return MigrationStepData{
+		Change: c.String(),
+		Advice: fmt.Sprintf("`spec.%s` is no longer in the schema and is dropped from existing %s resources "+
+			"once the new CRD is applied. Save the values first if they need to be carried over.", c.Name, kind.Kind),
+		Before: exportCommand(kind, c.Name),
+	}
+}
+
+func (g *MigrationGenerator) renameStep(kind MigrationKindData, removed, added mapper.CRDChange) MigrationStepData {
+	return MigrationStepData{
+		Change: fmt.Sprintf("Renamed field: %s -> %s (%s)", removed.Name, added.Name, added.New),
+		Advice: fmt.Sprintf("`spec.%s` was removed and `spec.%s` of the same type added, which looks like a rename. "+
+			"Save the old values, then copy them to the new field.", removed.Name, added.Name),
+		Before: exportCommand(kind, removed.Name),
+		After:  patchCommand(kind, removed.Name, added.Name, ".value"),
+	}
+}
+
+func (g *MigrationGenerator) retypedStep(kind MigrationKindData, c mapper.CRDChange) MigrationStepData {
+	step := MigrationStepData{
+		Change: c.String(),
+		Before: exportCommand(kind, c.Name),
+	}
+	if conversion := jqConversion(c.New); conversion != "" {
+		step.Advice = fmt.Sprintf("Existing values of `spec.%s` no longer match the schema and are rejected on "+
+			"the next update. Save them, then write them back converted to %s.", c.Name, c.New)
+		step.After = patchCommand(kind, c.Name, c.Name, ".value | "+conversion)
+	} else {
+		step.Advice = fmt.Sprintf("Existing values of `spec.%s` no longer match the schema and are rejected on "+
+			"the next update. Save them and rewrite each as %s by hand.", c.Name, c.New)
+	}
+	return step
+}
+
+func (g *MigrationGenerator) requiredStep(kind MigrationKindData, c mapper.CRDChange) MigrationStepData {
+	name, namespace := `\(.metadata.namespace)/\(.metadata.name)`, " -n <namespace>"
+	if kind.ClusterScoped {
+		name, namespace = `\(.metadata.name)`, ""
+	}
+	return MigrationStepData{
+		Change: c.String(),
+		Advice: fmt.Sprintf("Existing %s resources without `spec.%s` are rejected on their next update. "+
+			"List them and set a value on each.", kind.Kind, c.Name),
+		After: fmt.Sprintf("kubectl get %s -A -o json \\\n"+
+			"  | jq -r '.items[] | select(.spec.%s == null) | \"%s\"'\n"+
+			"kubectl patch %s <name>%s --type=merge -p '{\"spec\":{\"%s\":%s}}'",
+			kind.Resource, c.Name, name, kind.Resource, namespace, c.Name, placeholderValue(c.New)),
+	}
+}
+
+// exportCommand saves the namespace, name and value of a spec field for every CR that sets it
+func exportCommand(kind MigrationKindData, field string) string {
+	return fmt.Sprintf("kubectl get %s -A -o json \\\n"+
+		"  | jq -c '.items[] | select(.spec.%s != null) | {namespace: .metadata.namespace, name: .metadata.name, value: .spec.%s}' \\\n"+
+		"  > %s",
+		kind.Resource, field, field, exportFile(kind, field))
+}
+
+// patchCommand writes the saved values of a field back to the CRs, through a jq expression
+func patchCommand(kind MigrationKindData, from, to, value string) string {
+	namespace := ` -n \(.namespace)`
+	if kind.ClusterScoped {
+		namespace = ""
+	}
+	return fmt.Sprintf("jq -r '\"kubectl patch %s \\(.name)%s --type=merge -p \" + ({spec: {%s: (%s)}} | tojson | @sh)' \\\n"+
+		"  %s | sh",
+		kind.Resource, namespace, to, value, exportFile(kind, from))
+}
+
+func exportFile(kind MigrationKindData, field string) string {
+	return strings.ToLower(kind.Kind) + "-" + field + ".jsonl"
+}
+
+// jqConversion returns the jq filter converting a saved value to goType, or "" when there
+// is no mechanical conversion
+func jqConversion(goType string) string {
+	switch strings.TrimPrefix(goType, "*") {
+	case "string":
+		return "tostring"
+	case "int", "int32", "int64":
+		return "tonumber | floor"
+	case "float32", "float64":
+		return "tonumber"
+	case "bool":
+		return `(. == true or . == "true")`
+	}
+	return ""
+}
+
+// placeholderValue returns a JSON placeholder for a new value of goType
+func placeholderValue(goType string) string {
+	switch t := strings.TrimPrefix(goType, "*"); {
+	case t == "string":
+		return `"<value>"`
+	case t == "bool":
+		return "false"
+	case strings.HasPrefix(t, "int") || strings.HasPrefix(t, "float"):
+		return "0"
+	case strings.HasPrefix(t, "[]"):
+		return "[]"
+	}
+	return "{}"
+}
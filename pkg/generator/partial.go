@@ -0,0 +1,134 @@
+package generator
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/bluecontainer/openapi-operator-gen/internal/config"
+	"github.com/bluecontainer/openapi-operator-gen/pkg/mapper"
+)
+
+// kindSpecsDir holds, for each Kind regenerated on its own, a copy of the spec it was
+// generated from (in a directory named after the Kind)
+const kindSpecsDir = ".kind-specs"
+
+// KindRegeneration holds what a partial regeneration of one Kind writes
+type KindRegeneration struct {
+	// CRD is the Kind's new definition, from the current spec
+	CRD *mapper.CRDDefinition
+	// TypesCRDs are the CRDs to render the shared types.go from: the previous
+	// generation's, with the Kind's replaced by its new definition, so the other
+	// Kinds' types don't change. Kinds regenerated on their own since keep the
+	// definition from the spec they were regenerated from.
+	TypesCRDs []*mapper.CRDDefinition
+}
+
+// PlanKindRegeneration finds kind in crds (mapped from the current spec) and in the
+// previous generation recorded in outputDir. It fails if the Kind is no longer in the
+// spec, or wasn't generated last time: adding a Kind also changes main.go, the RBAC
+// rules and the kustomizations, which a partial regeneration leaves alone.
+func PlanKindRegeneration(outputDir string, crds []*mapper.CRDDefinition, kind string) (*KindRegeneration, error) {
+	var crd *mapper.CRDDefinition
+	kinds := make([]string, 0, len(crds))
+	for _, c := range crds {
+		if c.Kind == kind {
+			crd = c
+		}
+		kinds = append(kinds, c.Kind)
+	}
+	if crd == nil {
+		return nil, fmt.Errorf("kind %q is not in the spec (it has %s); regenerate everything to remove it", kind, strings.Join(kinds, ", "))
+	}
+
+	previous, _, err := PreviousCRDs(outputDir)
+	if err != nil {
+		return nil, err
+	}
+	regenerated, err := regeneratedKindCRDs(outputDir, kind)
+	if err != nil {
+		return nil, err
+	}
+	plan := &KindRegeneration{CRD: crd, TypesCRDs: make([]*mapper.CRDDefinition, 0, len(previous))}
+	found := false
+	for _, c := range previous {
+		if c.Kind == kind {
+			c = crd
+			found = true
+		} else if r, ok := regenerated[c.Kind]; ok {
+			c = r
+		}
+		plan.TypesCRDs = append(plan.TypesCRDs, c)
+	}
+	if !found {
+		return nil, fmt.Errorf("kind %q wasn't in the last generation; regenerate everything to add it", kind)
+	}
+	return plan, nil
+}
+
+// regeneratedKindCRDs maps the Kinds other than kind that were regenerated on their own
+// since the last full generation to their definitions in the spec copy saved for them
+func regeneratedKindCRDs(outputDir, kind string) (map[string]*mapper.CRDDefinition, error) {
+	file, err := config.LoadConfigFile(filepath.Join(outputDir, manifestFile))
+	if err != nil || file == nil {
+		return nil, err
+	}
+	prev := config.ConfigFromFile(file)
+	crds := make(map[string]*mapper.CRDDefinition)
+	for other := range file.KindSpecHashes {
+		if other == kind {
+			continue
+		}
+		dir := filepath.Join(outputDir, kindSpecsDir, other)
+		entries, err := os.ReadDir(dir)
+		if err != nil || len(entries) != 1 {
+			return nil, fmt.Errorf("the spec %s was last regenerated from is missing from %s; regenerate everything", other, dir)
+		}
+		mapped, _, err := mapSpecCopy(prev, filepath.Join(dir, entries[0].Name()))
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", other, err)
+		}
+		for _, c := range mapped {
+			if c.Kind == other {
+				crds[other] = c
+			}
+		}
+		if crds[other] == nil {
+			return nil, fmt.Errorf("kind %q is not in the spec it was last regenerated from; regenerate everything", other)
+		}
+	}
+	return crds, nil
+}
+
+// SaveKindSpec copies the spec a partial regeneration of kind ran from to the Kind's
+// directory under .kind-specs and records its hash in cfg.KindSpecHashes, so later partial
+// regenerations of other Kinds keep rendering kind's types from it. cfg.SpecHash is left
+// alone: the other Kinds are still generated from the spec it describes.
+func SaveKindSpec(cfg *config.Config, kind string) error {
+	if cfg.ValidateOnly || cfg.SpecPath == "" {
+		return nil
+	}
+	dir := filepath.Join(cfg.OutputDir, kindSpecsDir, kind)
+	if err := os.RemoveAll(dir); err != nil {
+		return fmt.Errorf("failed to remove the previous %s spec copy: %w", kind, err)
+	}
+	prefix := path.Join(kindSpecsDir, kind) + "/"
+	for rel := range cfg.GeneratedFiles {
+		if strings.HasPrefix(rel, prefix) {
+			delete(cfg.GeneratedFiles, rel)
+		}
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("failed to create %s: %w", dir, err)
+	}
+	if err := NewControllerGenerator(cfg).copySpecFile(dir); err != nil {
+		return fmt.Errorf("failed to copy the %s spec: %w", kind, err)
+	}
+	if cfg.KindSpecHashes == nil {
+		cfg.KindSpecHashes = make(map[string]string)
+	}
+	cfg.KindSpecHashes[kind] = specHash(cfg)
+	return nil
+}
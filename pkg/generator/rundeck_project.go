@@ -18,11 +18,12 @@ import (
 // RundeckProjectGenerator generates Rundeck project files with job definitions
 type RundeckProjectGenerator struct {
 	config *config.Config
+	files  FileSink
 }
 
 // NewRundeckProjectGenerator creates a new Rundeck project generator
 func NewRundeckProjectGenerator(cfg *config.Config) *RundeckProjectGenerator {
-	return &RundeckProjectGenerator{config: cfg}
+	return &RundeckProjectGenerator{config: cfg, files: newFileSink(cfg)}
 }
 
 // RundeckTemplateData is the top-level data for the project template
@@ -263,12 +264,12 @@ func (g *RundeckProjectGenerator) GenerateNodeSourcePlugin() error {
 
 	// Create plugin ZIP file
 	pluginDir := filepath.Join(g.config.OutputDir, "rundeck-plugin")
-	if err := os.MkdirAll(pluginDir, 0755); err != nil {
+	if err := g.files.MkdirAll(pluginDir, 0755); err != nil {
 		return fmt.Errorf("failed to create plugin directory: %w", err)
 	}
 
 	zipPath := filepath.Join(pluginDir, apiName+"-node-source.zip")
-	zipFile, err := os.Create(zipPath)
+	zipFile, err := g.files.Create(zipPath)
 	if err != nil {
 		return fmt.Errorf("failed to create plugin ZIP: %w", err)
 	}
@@ -304,14 +305,14 @@ func (g *RundeckProjectGenerator) GenerateNodeSourcePlugin() error {
 	}
 
 	// Also write standalone files for debugging/inspection
-	if err := os.WriteFile(filepath.Join(pluginDir, "plugin.yaml"), pluginYAML, 0644); err != nil {
+	if err := g.files.WriteFile(filepath.Join(pluginDir, "plugin.yaml"), pluginYAML, 0644); err != nil {
 		return fmt.Errorf("failed to write plugin.yaml: %w", err)
 	}
 	contentsDir := filepath.Join(pluginDir, "contents")
-	if err := os.MkdirAll(contentsDir, 0755); err != nil {
+	if err := g.files.MkdirAll(contentsDir, 0755); err != nil {
 		return fmt.Errorf("failed to create contents directory: %w", err)
 	}
-	if err := os.WriteFile(filepath.Join(contentsDir, "nodes.sh"), nodesScript, 0755); err != nil {
+	if err := g.files.WriteFile(filepath.Join(contentsDir, "nodes.sh"), nodesScript, 0755); err != nil {
 		return fmt.Errorf("failed to write nodes.sh: %w", err)
 	}
 
@@ -332,7 +333,7 @@ func (g *RundeckProjectGenerator) generateProject(crds []*mapper.CRDDefinition,
 		filepath.Join(rundeckDir, "jobs", "workflows", "managed"),
 	}
 	for _, dir := range dirs {
-		if err := os.MkdirAll(dir, 0755); err != nil {
+		if err := g.files.MkdirAll(dir, 0755); err != nil {
 			return fmt.Errorf("failed to create directory %s: %w", dir, err)
 		}
 	}
@@ -367,7 +368,7 @@ func (g *RundeckProjectGenerator) generateProject(crds []*mapper.CRDDefinition,
 	// Generate tokens.properties for Rundeck API authentication
 	// Format: username: token, role1, role2
 	tokensContent := fmt.Sprintf("# Generated by openapi-operator-gen %s\n# Static API token for automated setup (admin group required for project/job management)\nadmin: letmein99, admin, user\n", g.config.GeneratorVersion)
-	if err := os.WriteFile(filepath.Join(rundeckDir, "tokens.properties"), []byte(tokensContent), 0644); err != nil {
+	if err := g.files.WriteFile(filepath.Join(rundeckDir, "tokens.properties"), []byte(tokensContent), 0644); err != nil {
 		return fmt.Errorf("failed to generate tokens.properties: %w", err)
 	}
 
@@ -434,7 +435,7 @@ for:
 by:
   urn: 'project:%[2]s-operator-k8s'
 `, g.config.GeneratorVersion, baseData.APIName)
-	if err := os.WriteFile(filepath.Join(rundeckDir, "storage-access.aclpolicy"), []byte(aclContent), 0644); err != nil {
+	if err := g.files.WriteFile(filepath.Join(rundeckDir, "storage-access.aclpolicy"), []byte(aclContent), 0644); err != nil {
 		return fmt.Errorf("failed to generate storage-access.aclpolicy: %w", err)
 	}
 
@@ -691,7 +692,7 @@ func (g *RundeckProjectGenerator) GenerateManagedJobs(managedCRsDir string) erro
 	for _, mode := range modes {
 		for _, cr := range managedCRs {
 			crDir := filepath.Join(g.config.OutputDir, mode.dirName, "jobs", "managed", cr.KindLower+"-"+cr.CRName)
-			if err := os.MkdirAll(crDir, 0755); err != nil {
+			if err := g.files.MkdirAll(crDir, 0755); err != nil {
 				return fmt.Errorf("failed to create managed jobs directory %s: %w", crDir, err)
 			}
 
@@ -713,7 +714,7 @@ func (g *RundeckProjectGenerator) GenerateManagedJobs(managedCRsDir string) erro
 
 			// Generate managed deploy workflow
 			workflowManagedDir := filepath.Join(g.config.OutputDir, mode.dirName, "jobs", "workflows", "managed")
-			if err := os.MkdirAll(workflowManagedDir, 0755); err != nil {
+			if err := g.files.MkdirAll(workflowManagedDir, 0755); err != nil {
 				return fmt.Errorf("failed to create workflow managed directory %s: %w", workflowManagedDir, err)
 			}
 			if err := g.executeTemplate(
@@ -849,7 +850,7 @@ func indentString(s string, spaces int) string {
 // The JSON format is {"name": "...", "config": {"key": "value", ...}}.
 // The project name is derived from the project.name property in the file.
 func (g *RundeckProjectGenerator) generateProjectJSON(propsPath string, outputPath string) error {
-	data, err := os.ReadFile(propsPath)
+	data, err := g.files.ReadFile(propsPath)
 	if err != nil {
 		return fmt.Errorf("failed to read %s: %w", propsPath, err)
 	}
@@ -884,7 +885,7 @@ func (g *RundeckProjectGenerator) generateProjectJSON(propsPath string, outputPa
 		return fmt.Errorf("failed to marshal project JSON: %w", err)
 	}
 
-	return os.WriteFile(outputPath, jsonBytes, 0644)
+	return g.files.WriteFile(outputPath, jsonBytes, 0644)
 }
 
 // executeTemplate parses and executes a template, writing the result to outputPath.
@@ -907,7 +908,7 @@ func (g *RundeckProjectGenerator) executeTemplate(tmplContent string, data inter
 		return fmt.Errorf("failed to execute template: %w", err)
 	}
 
-	return os.WriteFile(outputPath, buf.Bytes(), 0644)
+	return g.files.WriteFile(outputPath, buf.Bytes(), 0644)
 }
 
 // renderTemplate parses and executes a template, returning the result as bytes.
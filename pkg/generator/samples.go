@@ -2,7 +2,6 @@ package generator
 
 import (
 	"fmt"
-	"os"
 	"path/filepath"
 	"strings"
 	"text/template"
@@ -16,16 +15,20 @@ import (
 // SamplesGenerator generates example CR YAML files
 type SamplesGenerator struct {
 	config *config.Config
+	files  FileSink
 }
 
 // NewSamplesGenerator creates a new samples generator
 func NewSamplesGenerator(cfg *config.Config) *SamplesGenerator {
-	return &SamplesGenerator{config: cfg}
+	return &SamplesGenerator{config: cfg, files: newFileSink(cfg)}
 }
 
 // ExampleCRData holds data for example CR template
 type ExampleCRData struct {
 	GeneratorVersion string
+	SpecVersion      string // Spec info.version, stamped as the spec-version annotation
+	SpecVersionLabel string // SpecVersion as an app.kubernetes.io/version label ("" if invalid)
+	Namespace        string // metadata.namespace of the sample
 	APIGroup         string
 	APIVersion       string
 	Kind             string
@@ -48,6 +51,9 @@ type ExampleFieldData struct {
 // ExampleAggregateCRData holds data for example aggregate CR template
 type ExampleAggregateCRData struct {
 	GeneratorVersion string
+	SpecVersion      string // Spec info.version, stamped as the spec-version annotation
+	SpecVersionLabel string // SpecVersion as an app.kubernetes.io/version label ("" if invalid)
+	Namespace        string // metadata.namespace of the sample
 	APIGroup         string
 	APIVersion       string
 	Kind             string
@@ -61,6 +67,9 @@ type ExampleAggregateCRData struct {
 // ExampleBundleCRData holds data for example bundle CR template
 type ExampleBundleCRData struct {
 	GeneratorVersion string
+	SpecVersion      string // Spec info.version, stamped as the spec-version annotation
+	SpecVersionLabel string // SpecVersion as an app.kubernetes.io/version label ("" if invalid)
+	Namespace        string // metadata.namespace of the sample
 	APIGroup         string
 	APIVersion       string
 	Kind             string
@@ -77,37 +86,18 @@ type ExampleBundleCRData struct {
 func (g *SamplesGenerator) Generate(crds []*mapper.CRDDefinition, aggregate *mapper.AggregateDefinition, bundle *mapper.BundleDefinition) error {
 	// Create samples directory
 	samplesDir := filepath.Join(g.config.OutputDir, "config", "samples")
-	if err := os.MkdirAll(samplesDir, 0755); err != nil {
+	if err := g.files.MkdirAll(samplesDir, 0755); err != nil {
 		return fmt.Errorf("failed to create samples directory: %w", err)
 	}
 
 	sampleFiles := make([]string, 0, len(crds)*2)
 
 	for _, crd := range crds {
-		// Generate basic example CR
-		if err := g.generateExampleCR(samplesDir, crd); err != nil {
-			return fmt.Errorf("failed to generate example CR for %s: %w", crd.Kind, err)
-		}
-		sampleFiles = append(sampleFiles, fmt.Sprintf("%s_%s.yaml", g.config.APIVersion, strings.ToLower(crd.Kind)))
-
-		// Generate example CR with externalIDRef (only for resource CRDs that need it)
-		// ExternalIDRef is only needed when there are no path parameters to identify the resource
-		if !crd.IsQuery && !crd.IsAction && crd.NeedsExternalIDRef {
-			if err := g.generateExampleCRRef(samplesDir, crd); err != nil {
-				return fmt.Errorf("failed to generate example CR ref for %s: %w", crd.Kind, err)
-			}
-			sampleFiles = append(sampleFiles, fmt.Sprintf("%s_%s_ref.yaml", g.config.APIVersion, strings.ToLower(crd.Kind)))
-		}
-
-		// Generate adopt-and-modify example CR (only for resource CRDs that can update)
-		// This demonstrates adopting an existing resource and modifying a field
-		canUpdate := crd.HasPut || crd.HasPatch || crd.UpdateWithPost
-		if !crd.IsQuery && !crd.IsAction && canUpdate {
-			if err := g.generateExampleCRAdopt(samplesDir, crd); err != nil {
-				return fmt.Errorf("failed to generate example CR adopt for %s: %w", crd.Kind, err)
-			}
-			sampleFiles = append(sampleFiles, fmt.Sprintf("%s_%s_adopt.yaml", g.config.APIVersion, strings.ToLower(crd.Kind)))
+		files, err := g.generateKindSamples(samplesDir, crd)
+		if err != nil {
+			return err
 		}
+		sampleFiles = append(sampleFiles, files...)
 	}
 
 	// Generate aggregate sample if aggregate CRD is enabled
@@ -134,9 +124,54 @@ func (g *SamplesGenerator) Generate(crds []*mapper.CRDDefinition, aggregate *map
 	return nil
 }
 
+// GenerateKind regenerates only one CRD's samples. The samples kustomization.yaml is
+// left alone, so the Kind must already have been generated.
+func (g *SamplesGenerator) GenerateKind(crd *mapper.CRDDefinition) error {
+	samplesDir := filepath.Join(g.config.OutputDir, "config", "samples")
+	if err := g.files.MkdirAll(samplesDir, 0755); err != nil {
+		return fmt.Errorf("failed to create samples directory: %w", err)
+	}
+	_, err := g.generateKindSamples(samplesDir, crd)
+	return err
+}
+
+// generateKindSamples writes a CRD's example CRs and returns their file names
+func (g *SamplesGenerator) generateKindSamples(samplesDir string, crd *mapper.CRDDefinition) ([]string, error) {
+	var files []string
+
+	// Generate basic example CR
+	if err := g.generateExampleCR(samplesDir, crd); err != nil {
+		return nil, fmt.Errorf("failed to generate example CR for %s: %w", crd.Kind, err)
+	}
+	files = append(files, fmt.Sprintf("%s_%s.yaml", g.config.APIVersion, strings.ToLower(crd.Kind)))
+
+	// Generate example CR with externalIDRef (only for resource CRDs that need it)
+	// ExternalIDRef is only needed when there are no path parameters to identify the resource
+	if !crd.IsQuery && !crd.IsAction && crd.NeedsExternalIDRef {
+		if err := g.generateExampleCRRef(samplesDir, crd); err != nil {
+			return nil, fmt.Errorf("failed to generate example CR ref for %s: %w", crd.Kind, err)
+		}
+		files = append(files, fmt.Sprintf("%s_%s_ref.yaml", g.config.APIVersion, strings.ToLower(crd.Kind)))
+	}
+
+	// Generate adopt-and-modify example CR (only for resource CRDs that can update)
+	// This demonstrates adopting an existing resource and modifying a field
+	canUpdate := crd.HasPut || crd.HasPatch || crd.UpdateWithPost
+	if !crd.IsQuery && !crd.IsAction && canUpdate {
+		if err := g.generateExampleCRAdopt(samplesDir, crd); err != nil {
+			return nil, fmt.Errorf("failed to generate example CR adopt for %s: %w", crd.Kind, err)
+		}
+		files = append(files, fmt.Sprintf("%s_%s_adopt.yaml", g.config.APIVersion, strings.ToLower(crd.Kind)))
+	}
+	return files, nil
+}
+
 func (g *SamplesGenerator) generateExampleCR(samplesDir string, crd *mapper.CRDDefinition) error {
 	data := ExampleCRData{
 		GeneratorVersion: g.config.GeneratorVersion,
+		SpecVersion:      g.config.SpecVersion,
+		SpecVersionLabel: specVersionLabel(g.config.SpecVersion),
+		Namespace:        g.config.ResolvedSampleNamespace(),
 		APIGroup:         crd.APIGroup,
 		APIVersion:       crd.APIVersion,
 		Kind:             crd.Kind,
@@ -154,7 +189,7 @@ func (g *SamplesGenerator) generateExampleCR(samplesDir string, crd *mapper.CRDD
 	filename := fmt.Sprintf("%s_%s.yaml", g.config.APIVersion, strings.ToLower(crd.Kind))
 	examplePath := filepath.Join(samplesDir, filename)
 
-	file, err := os.Create(examplePath)
+	file, err := g.files.Create(examplePath)
 	if err != nil {
 		return fmt.Errorf("failed to create file: %w", err)
 	}
@@ -170,6 +205,9 @@ func (g *SamplesGenerator) generateExampleCR(samplesDir string, crd *mapper.CRDD
 func (g *SamplesGenerator) generateExampleCRRef(samplesDir string, crd *mapper.CRDDefinition) error {
 	data := ExampleCRData{
 		GeneratorVersion: g.config.GeneratorVersion,
+		SpecVersion:      g.config.SpecVersion,
+		SpecVersionLabel: specVersionLabel(g.config.SpecVersion),
+		Namespace:        g.config.ResolvedSampleNamespace(),
 		APIGroup:         crd.APIGroup,
 		APIVersion:       crd.APIVersion,
 		Kind:             crd.Kind,
@@ -186,7 +224,7 @@ func (g *SamplesGenerator) generateExampleCRRef(samplesDir string, crd *mapper.C
 	filename := fmt.Sprintf("%s_%s_ref.yaml", g.config.APIVersion, strings.ToLower(crd.Kind))
 	examplePath := filepath.Join(samplesDir, filename)
 
-	file, err := os.Create(examplePath)
+	file, err := g.files.Create(examplePath)
 	if err != nil {
 		return fmt.Errorf("failed to create file: %w", err)
 	}
@@ -206,6 +244,9 @@ func (g *SamplesGenerator) generateExampleCRRef(samplesDir string, crd *mapper.C
 func (g *SamplesGenerator) generateExampleCRAdopt(samplesDir string, crd *mapper.CRDDefinition) error {
 	data := ExampleCRData{
 		GeneratorVersion: g.config.GeneratorVersion,
+		SpecVersion:      g.config.SpecVersion,
+		SpecVersionLabel: specVersionLabel(g.config.SpecVersion),
+		Namespace:        g.config.ResolvedSampleNamespace(),
 		APIGroup:         crd.APIGroup,
 		APIVersion:       crd.APIVersion,
 		Kind:             crd.Kind,
@@ -223,7 +264,7 @@ func (g *SamplesGenerator) generateExampleCRAdopt(samplesDir string, crd *mapper
 	filename := fmt.Sprintf("%s_%s_adopt.yaml", g.config.APIVersion, strings.ToLower(crd.Kind))
 	examplePath := filepath.Join(samplesDir, filename)
 
-	file, err := os.Create(examplePath)
+	file, err := g.files.Create(examplePath)
 	if err != nil {
 		return fmt.Errorf("failed to create file: %w", err)
 	}
@@ -251,7 +292,7 @@ func (g *SamplesGenerator) generateSamplesKustomization(samplesDir string, sampl
 	}
 
 	kustomizationPath := filepath.Join(samplesDir, "kustomization.yaml")
-	file, err := os.Create(kustomizationPath)
+	file, err := g.files.Create(kustomizationPath)
 	if err != nil {
 		return fmt.Errorf("failed to create file: %w", err)
 	}
@@ -352,6 +393,10 @@ func (g *SamplesGenerator) selectFieldToModify(spec *mapper.FieldDefinition) str
 		if strings.HasSuffix(strings.ToLower(f.JSONName), "id") {
 			continue
 		}
+		// A suffixed value would no longer match the field's format
+		if f.Format != "" {
+			continue
+		}
 		goType := strings.TrimPrefix(f.GoType, "*")
 		if goType == "string" || goType == "bool" || goType == "int" || goType == "int32" || goType == "int64" {
 			return f.JSONName
@@ -518,6 +563,10 @@ func (g *SamplesGenerator) generateExampleValue(f *mapper.FieldDefinition) strin
 
 	switch goType {
 	case "string":
+		// Use a value valid for the field's format, if the CRD validates it
+		if example, ok := mapper.FormatExample(f.Format); ok {
+			return fmt.Sprintf("%q", example)
+		}
 		// Generate more meaningful example values based on field name patterns
 		return g.generateStringExampleValue(f.JSONName)
 	case "int", "int32", "int64":
@@ -699,6 +748,9 @@ func (g *SamplesGenerator) sortKindsByDependency(kinds []string, resourceSpecs m
 func (g *SamplesGenerator) generateExampleAggregateCR(samplesDir string, aggregate *mapper.AggregateDefinition) error {
 	data := ExampleAggregateCRData{
 		GeneratorVersion: g.config.GeneratorVersion,
+		SpecVersion:      g.config.SpecVersion,
+		SpecVersionLabel: specVersionLabel(g.config.SpecVersion),
+		Namespace:        g.config.ResolvedSampleNamespace(),
 		APIGroup:         aggregate.APIGroup,
 		APIVersion:       aggregate.APIVersion,
 		Kind:             aggregate.Kind,
@@ -719,7 +771,7 @@ func (g *SamplesGenerator) generateExampleAggregateCR(samplesDir string, aggrega
 	filename := fmt.Sprintf("%s_%s.yaml", g.config.APIVersion, strings.ToLower(aggregate.Kind))
 	examplePath := filepath.Join(samplesDir, filename)
 
-	file, err := os.Create(examplePath)
+	file, err := g.files.Create(examplePath)
 	if err != nil {
 		return fmt.Errorf("failed to create file: %w", err)
 	}
@@ -745,6 +797,9 @@ func (g *SamplesGenerator) generateExampleBundleCR(samplesDir string, bundle *ma
 
 	data := ExampleBundleCRData{
 		GeneratorVersion: g.config.GeneratorVersion,
+		SpecVersion:      g.config.SpecVersion,
+		SpecVersionLabel: specVersionLabel(g.config.SpecVersion),
+		Namespace:        g.config.ResolvedSampleNamespace(),
 		APIGroup:         bundle.APIGroup,
 		APIVersion:       bundle.APIVersion,
 		Kind:             bundle.Kind,
@@ -778,7 +833,7 @@ func (g *SamplesGenerator) generateExampleBundleCR(samplesDir string, bundle *ma
 	filename := fmt.Sprintf("%s_%s.yaml", g.config.APIVersion, strings.ToLower(bundle.Kind))
 	examplePath := filepath.Join(samplesDir, filename)
 
-	file, err := os.Create(examplePath)
+	file, err := g.files.Create(examplePath)
 	if err != nil {
 		return fmt.Errorf("failed to create file: %w", err)
 	}
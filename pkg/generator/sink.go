@@ -0,0 +1,221 @@
+package generator
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/bluecontainer/openapi-operator-gen/internal/config"
+	"github.com/bluecontainer/openapi-operator-gen/pkg/parser"
+)
+
+// FileSink receives the files produced by the generators.
+// Normal runs write to disk; validate-only runs render into memory and discard the output.
+type FileSink interface {
+	MkdirAll(path string, perm os.FileMode) error
+	Create(path string) (io.WriteCloser, error)
+	WriteFile(path string, data []byte, perm os.FileMode) error
+	// ReadFile reads back a file previously written to the sink
+	ReadFile(path string) ([]byte, error)
+}
+
+// manifestFile is the saved config, which holds the generation manifest and so isn't
+// recorded in it
+const manifestFile = ".openapi-operator-gen.yaml"
+
+// newFileSink returns the sink to use for the given configuration
+func newFileSink(cfg *config.Config) FileSink {
+	if cfg.ValidateOnly {
+		return NewMemorySink()
+	}
+	return osSink{cfg: cfg}
+}
+
+// osSink writes generated files to disk, recording their hashes in cfg.GeneratedFiles
+type osSink struct {
+	cfg *config.Config
+}
+
+func (osSink) MkdirAll(path string, perm os.FileMode) error { return os.MkdirAll(path, perm) }
+
+func (s osSink) Create(path string) (io.WriteCloser, error) {
+	f, err := os.Create(path)
+	if err != nil {
+		return nil, err
+	}
+	return &recordedFile{File: f, sink: s, path: path}, nil
+}
+
+func (s osSink) WriteFile(path string, data []byte, perm os.FileMode) error {
+	if err := os.WriteFile(path, data, perm); err != nil {
+		return err
+	}
+	s.record(path, data)
+	return nil
+}
+
+func (osSink) ReadFile(path string) ([]byte, error) { return os.ReadFile(path) }
+
+// record adds a written file to the manifest. Files outside the output directory
+// (e.g., a spec copied elsewhere) aren't part of the operator and are left out.
+func (s osSink) record(path string, data []byte) {
+	if s.cfg == nil {
+		return
+	}
+	rel, err := filepath.Rel(s.cfg.OutputDir, path)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return
+	}
+	rel = filepath.ToSlash(rel)
+	if rel == manifestFile {
+		return
+	}
+	if s.cfg.GeneratedFiles == nil {
+		s.cfg.GeneratedFiles = make(map[string]string)
+	}
+	s.cfg.GeneratedFiles[rel] = config.HashSpecBytes(data)
+}
+
+// recordedFile hashes what is written to a file created by osSink and records it
+// when the file is closed
+type recordedFile struct {
+	*os.File
+	sink osSink
+	path string
+	buf  bytes.Buffer
+}
+
+func (f *recordedFile) Write(p []byte) (int, error) {
+	n, err := f.File.Write(p)
+	f.buf.Write(p[:n])
+	return n, err
+}
+
+func (f *recordedFile) Close() error {
+	if err := f.File.Close(); err != nil {
+		return err
+	}
+	f.sink.record(f.path, f.buf.Bytes())
+	return nil
+}
+
+// SaveManifest rewrites the saved .openapi-operator-gen.yaml with the generation
+// manifest (cfg.GeneratedFiles). The controller generator saves the config before the
+// later generators run, so this is called once all of them are done; in types-only
+// runs, which skip the controller generator, it writes the config for the first time.
+// It is a no-op for validate-only runs.
+func SaveManifest(cfg *config.Config) error {
+	if cfg.ValidateOnly || len(cfg.GeneratedFiles) == 0 {
+		return nil
+	}
+	if cfg.SpecHash == "" {
+		recordSpecHash(cfg)
+	}
+	// A full generation renders every Kind from the same spec, so the per-Kind copies go
+	if len(cfg.KindSpecHashes) == 0 {
+		if err := os.RemoveAll(filepath.Join(cfg.OutputDir, kindSpecsDir)); err != nil {
+			return fmt.Errorf("failed to remove the per-Kind spec copies: %w", err)
+		}
+	}
+	data, err := config.MarshalConfigFile(cfg)
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(filepath.Join(cfg.OutputDir, manifestFile), data, 0644); err != nil {
+		return fmt.Errorf("failed to write config file: %w", err)
+	}
+	return nil
+}
+
+// MemorySink keeps generated files in memory without touching the filesystem.
+type MemorySink struct {
+	mu    sync.Mutex
+	files map[string][]byte
+}
+
+// NewMemorySink creates an empty in-memory sink
+func NewMemorySink() *MemorySink {
+	return &MemorySink{files: make(map[string][]byte)}
+}
+
+// MkdirAll is a no-op; directories are implied by file paths
+func (s *MemorySink) MkdirAll(path string, perm os.FileMode) error { return nil }
+
+// Create returns a writer whose content is stored when it is closed
+func (s *MemorySink) Create(path string) (io.WriteCloser, error) {
+	return &memoryFile{sink: s, path: filepath.Clean(path)}, nil
+}
+
+// WriteFile stores data under path
+func (s *MemorySink) WriteFile(path string, data []byte, perm os.FileMode) error {
+	s.store(filepath.Clean(path), append([]byte(nil), data...))
+	return nil
+}
+
+// ReadFile returns the content stored under path
+func (s *MemorySink) ReadFile(path string) ([]byte, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	data, ok := s.files[filepath.Clean(path)]
+	if !ok {
+		return nil, fmt.Errorf("open %s: %w", path, os.ErrNotExist)
+	}
+	return append([]byte(nil), data...), nil
+}
+
+// Paths returns the paths of all stored files in sorted order
+func (s *MemorySink) Paths() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	paths := make([]string, 0, len(s.files))
+	for p := range s.files {
+		paths = append(paths, p)
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+func (s *MemorySink) store(path string, data []byte) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.files[path] = data
+}
+
+// memoryFile buffers writes for a MemorySink file
+type memoryFile struct {
+	sink *MemorySink
+	path string
+	buf  bytes.Buffer
+}
+
+func (f *memoryFile) Write(p []byte) (int, error) { return f.buf.Write(p) }
+
+func (f *memoryFile) Close() error {
+	f.sink.store(f.path, f.buf.Bytes())
+	return nil
+}
+
+// recordSpecHash sets cfg.SpecHash for change detection. A URL is read through the
+// spec cache so the hash is still recorded offline
+func recordSpecHash(cfg *config.Config) {
+	if hash := specHash(cfg); hash != "" {
+		cfg.SpecHash = hash
+	}
+}
+
+// specHash returns the hash of cfg's spec, or "" if it can't be read
+func specHash(cfg *config.Config) string {
+	if strings.HasPrefix(cfg.SpecPath, "http://") || strings.HasPrefix(cfg.SpecPath, "https://") {
+		if data, err := parser.ReadSpec(cfg.SpecPath, cfg.SpecCacheDir); err == nil {
+			return config.HashSpecBytes(data)
+		}
+	} else if hash, err := config.HashSpecFile(cfg.SpecPath); err == nil {
+		return hash
+	}
+	return ""
+}
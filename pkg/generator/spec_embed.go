@@ -0,0 +1,93 @@
+package generator
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/bluecontainer/openapi-operator-gen/internal/config"
+	"github.com/bluecontainer/openapi-operator-gen/pkg/parser"
+	"github.com/bluecontainer/openapi-operator-gen/pkg/templates"
+)
+
+// EmbeddedSpec describes the spec copy a generated operator embeds with --embed-spec
+type EmbeddedSpec struct {
+	// PackageName is the package of spec_embed.go, at the root of the generated module
+	PackageName string
+	// Pattern is the go:embed pattern: the copied spec file, or the copied split spec directory
+	Pattern string
+	// File is the path of the spec's root document within the embedded files
+	File string
+	// IsDir is true for a split spec directory
+	IsDir bool
+	// ServePath is where the manager serves the root document on the metrics server
+	ServePath string
+	// ContentType is the Content-Type the root document is served with
+	ContentType string
+}
+
+// embeddedSpec returns the spec embedded in the generated operator, or nil when
+// EmbedSpec is off or no spec path is configured
+func embeddedSpec(cfg *config.Config) (*EmbeddedSpec, error) {
+	if !cfg.EmbedSpec || cfg.SpecPath == "" {
+		return nil, nil
+	}
+	spec := &EmbeddedSpec{
+		PackageName: embedPackageName(cfg.ResolvedImportPrefix()),
+		Pattern:     specCopyName(cfg.SpecPath),
+	}
+	spec.File = spec.Pattern
+	if info, err := os.Stat(cfg.SpecPath); err == nil && info.IsDir() {
+		root, err := parser.ResolveSpecRoot(cfg.SpecPath, cfg.SpecRootFile)
+		if err != nil {
+			return nil, err
+		}
+		rel, err := filepath.Rel(cfg.SpecPath, root)
+		if err != nil {
+			return nil, fmt.Errorf("failed to locate spec root file: %w", err)
+		}
+		spec.IsDir = true
+		spec.File = path.Join(spec.Pattern, filepath.ToSlash(rel))
+	}
+	if strings.EqualFold(path.Ext(spec.File), ".json") {
+		spec.ServePath, spec.ContentType = "/openapi.json", "application/json"
+	} else {
+		spec.ServePath, spec.ContentType = "/openapi.yaml", "application/yaml"
+	}
+	return spec, nil
+}
+
+// embedPackageName derives the package name of spec_embed.go from the last element of
+// the module path (e.g., "petstoreoperator" for github.com/example/petstore-operator)
+func embedPackageName(modulePath string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(path.Base(modulePath)) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			b.WriteRune(r)
+		}
+	}
+	name := b.String()
+	if name == "" || (name[0] >= '0' && name[0] <= '9') {
+		name = "operator" + name
+	}
+	return name
+}
+
+// generateSpecEmbed writes spec_embed.go, which embeds the copy of the spec written by
+// copySpecFile in the operator binary
+func (g *ControllerGenerator) generateSpecEmbed(spec *EmbeddedSpec) error {
+	data := struct {
+		*EmbeddedSpec
+		Year             int
+		GeneratorVersion string
+	}{
+		EmbeddedSpec:     spec,
+		Year:             time.Now().Year(),
+		GeneratorVersion: g.config.GeneratorVersion,
+	}
+	outputPath := filepath.Join(g.config.OutputDir, "spec_embed.go")
+	return g.executeTemplate(templates.SpecEmbedTemplate, data, outputPath)
+}
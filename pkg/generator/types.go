@@ -2,13 +2,18 @@ package generator
 
 import (
 	"fmt"
-	"os"
 	"path/filepath"
+	"reflect"
+	"regexp"
 	"sort"
+	"strconv"
 	"strings"
 	"text/template"
 	"time"
 
+	"github.com/iancoleman/strcase"
+	"k8s.io/apimachinery/pkg/util/validation"
+
 	"github.com/bluecontainer/openapi-operator-gen/internal/config"
 	"github.com/bluecontainer/openapi-operator-gen/pkg/mapper"
 	"github.com/bluecontainer/openapi-operator-gen/pkg/templates"
@@ -17,11 +22,15 @@ import (
 // TypesGenerator generates Go type definitions for CRDs
 type TypesGenerator struct {
 	config *config.Config
+	files  FileSink
+	// reserved holds the type names types.go declares besides nested types; a schema
+	// title matching one of them is not used as a nested type name
+	reserved map[string]bool
 }
 
 // NewTypesGenerator creates a new types generator
 func NewTypesGenerator(cfg *config.Config) *TypesGenerator {
-	return &TypesGenerator{config: cfg}
+	return &TypesGenerator{config: cfg, files: newFileSink(cfg)}
 }
 
 // TypesTemplateData holds data for the types template
@@ -34,12 +43,23 @@ type TypesTemplateData struct {
 	CRDs             []CRDTypeData
 	NestedTypes      []NestedTypeData // Nested types to generate (for Category, Tag, etc.)
 	HasBinaryActions bool             // True if any action CRD has binary body support
+	// SharedResultTypes are result types used by several queries returning the same $ref schema
+	SharedResultTypes []SharedResultTypeData
+	// StatusSubresource emits the +kubebuilder:subresource:status marker (off with --no-status-subresource)
+	StatusSubresource bool
+	// SpecVersion is the spec's info.version, stamped on the CRDs as the
+	// openapi-operator-gen/spec-version annotation
+	SpecVersion string
+	// SpecVersionLabel is SpecVersion as an app.kubernetes.io/version label value
+	// ("" when it isn't a valid one)
+	SpecVersionLabel string
 }
 
 // CRDTypeData holds CRD-specific data for template
 type CRDTypeData struct {
 	Kind               string
 	Plural             string
+	CustomPlural       bool // Emit a resource:path marker so controller-gen keeps the explicit plural
 	ShortNames         []string
 	Spec               *SpecData
 	IsQuery            bool                     // True if this is a query CRD
@@ -52,6 +72,7 @@ type CRDTypeData struct {
 	UsesSharedType     bool                     // True if ResultItemType is a shared type from another CRD
 	IsPrimitiveArray   bool                     // True if response is a primitive array ([]string, []int, etc.)
 	PrimitiveArrayType string                   // Base type for primitive arrays (e.g., "string", "int64")
+	StateEnum          string                   // Allowed status.state values, ";"-separated for the Enum marker
 
 	// Action endpoint fields
 	IsAction          bool   // True if this is an action CRD
@@ -63,6 +84,7 @@ type CRDTypeData struct {
 	ActionName        string // Action name (e.g., "uploadImage")
 	HasBinaryBody     bool   // True if the action accepts binary data uploads
 	BinaryContentType string // Content type for binary data
+	WatchDataFrom     bool   // True if status.dataFromVersion tracks the spec.dataFrom ConfigMap/Secret
 
 	// HTTP method availability (for Resource CRDs)
 	HasDelete bool // True if DELETE method is available
@@ -70,11 +92,32 @@ type CRDTypeData struct {
 	HasPatch  bool // True if PATCH method is available
 	HasPut    bool // True if PUT method is available
 
+	// UseETag adds status.etag for optimistic concurrency
+	UseETag bool
+	// HasWriteOnlyFields adds status.writeOnlyGeneration, the generation whose writeOnly
+	// spec fields were last sent
+	HasWriteOnlyFields bool
+
+	// StatusFields are response fields copied into the status (x-k8s-status-field)
+	StatusFields []mapper.StatusField
+	// ExcludedStatus are the status fields --exclude-status-fields drops, by JSON name
+	ExcludedStatus map[string]bool
+
+	// Default spec.target (x-k8s-target-default or --default-target)
+	TargetDefault        string // kubebuilder:default marker value, e.g. {deployment: "api", namespace: "backend"}
+	TargetDefaultSummary string // e.g., "deployment=api,namespace=backend"
+
 	// ExternalIDRef handling
 	NeedsExternalIDRef bool // True if externalIDRef field is needed (no path params to identify resource)
+	// ReadOnly omits the spec fields that only apply to writes (readOnly, mergeOnUpdate)
+	ReadOnly bool
+	// HasExtraHeaders adds spec.extraHeaders
+	HasExtraHeaders bool
 
 	// CEL validation rules for conditional field requirements
 	CELValidationRules []mapper.CELValidationRule
+	// SpecAnyOfRule requires one member of a resource schema merged from a loose anyOf
+	SpecAnyOfRule *mapper.CELValidationRule
 }
 
 // SpecData holds spec field data
@@ -91,8 +134,33 @@ type FieldData struct {
 	Required    bool
 	Validation  *mapper.ValidationRules
 	Enum        []string
+	Immutable   bool        // adds a self == oldSelf transition rule
+	Deprecated  bool        // adds a Deprecated: comment for deprecated parameters
 	Fields      []FieldData // nested fields for struct types
 	ItemType    *FieldData  // item type for array types
+	// ItemValidation holds the constraints of each item of a scalar array field, emitted
+	// as +kubebuilder:validation:items: markers
+	ItemValidation *mapper.ValidationRules
+	// KeyRule is the CEL rule requiring map keys to match the field's patternProperties
+	KeyRule        string
+	KeyRuleMessage string
+	// ValueRule is the CEL rule applying a scalar map field's value constraints
+	ValueRule        string
+	ValueRuleMessage string
+	// NotRule is the CEL rule rejecting values that satisfy a scalar field's not schema
+	NotRule        string
+	NotRuleMessage string
+	// AnyOfRule is the CEL rule requiring one anyOf member of a merged struct field
+	AnyOfRule        string
+	AnyOfRuleMessage string
+}
+
+// SharedResultTypeData holds a query result type shared by several query CRDs
+type SharedResultTypeData struct {
+	Name   string
+	Schema string   // Component schema the queries' responses reference
+	Kinds  []string // Query kinds using the type
+	Fields []FieldData
 }
 
 // NestedTypeData holds information about a nested type to generate
@@ -103,13 +171,16 @@ type NestedTypeData struct {
 
 // Generate generates the types.go file
 func (g *TypesGenerator) Generate(crds []*mapper.CRDDefinition) error {
-	outputDir := filepath.Join(g.config.OutputDir, "api", g.config.APIVersion)
-	if err := os.MkdirAll(outputDir, 0755); err != nil {
+	outputDir := filepath.Join(g.config.OutputDir, filepath.FromSlash(g.config.APIDir()))
+	if err := g.files.MkdirAll(outputDir, 0755); err != nil {
 		return fmt.Errorf("failed to create output directory: %w", err)
 	}
 
 	// Track nested types for generation
 	nestedTypes := make(map[string]NestedTypeData)
+	sharedResults := make(map[string]*SharedResultTypeData)
+	var sharedResultNames []string
+	g.reserved = reservedTypeNames(crds)
 
 	// Prepare template data
 	data := TypesTemplateData{
@@ -117,15 +188,21 @@ func (g *TypesGenerator) Generate(crds []*mapper.CRDDefinition) error {
 		GeneratorVersion: g.config.GeneratorVersion,
 		APIVersion:       g.config.APIVersion,
 		APIGroup:         g.config.APIGroup,
-		ModuleName:       g.config.ModuleName,
+		ModuleName:       g.config.ResolvedImportPrefix(),
 		CRDs:             make([]CRDTypeData, 0, len(crds)),
+
+		StatusSubresource: !g.config.NoStatusSubresource,
+		SpecVersion:       g.config.SpecVersion,
+		SpecVersionLabel:  specVersionLabel(g.config.SpecVersion),
 	}
 
 	for _, crd := range crds {
 		crdData := CRDTypeData{
 			Kind:               crd.Kind,
 			Plural:             crd.Plural,
+			CustomPlural:       crd.CustomPlural,
 			ShortNames:         crd.ShortNames,
+			StateEnum:          strings.Join(crd.States(), ";"),
 			IsQuery:            crd.IsQuery,
 			QueryPath:          crd.QueryPath,
 			QueryParams:        crd.QueryParams,
@@ -145,27 +222,53 @@ func (g *TypesGenerator) Generate(crds []*mapper.CRDDefinition) error {
 			ActionName:        crd.ActionName,
 			HasBinaryBody:     crd.HasBinaryBody,
 			BinaryContentType: crd.BinaryContentType,
+			WatchDataFrom:     crd.WatchDataFrom,
 			// HTTP method availability
 			HasDelete: crd.HasDelete,
 			HasPost:   crd.HasPost,
 			HasPatch:  crd.HasPatch,
 			HasPut:    crd.HasPut,
+			UseETag:   crd.UseETag,
+			// Response fields surfaced in the status
+			StatusFields:   crd.StatusFields,
+			ExcludedStatus: excludedStatus(crd),
 			// ExternalIDRef handling
 			NeedsExternalIDRef: crd.NeedsExternalIDRef,
+			ReadOnly:           crd.ReadOnly,
+			HasExtraHeaders:    crd.HasExtraHeaders,
 			// CEL validation rules
 			CELValidationRules: crd.CELValidationRules,
+			SpecAnyOfRule:      crd.SpecAnyOfRule,
+		}
+		if !crd.TargetDefault.IsEmpty() {
+			crdData.TargetDefault = targetDefaultMarker(crd.TargetDefault)
+			crdData.TargetDefaultSummary = crd.TargetDefault.String()
 		}
 
 		if crd.Spec != nil {
 			crdData.Spec = &SpecData{
 				Fields: g.convertFieldsWithNestedTypes(crd.Spec.Fields, crd.Kind, nestedTypes),
 			}
+			crdData.HasWriteOnlyFields = hasWriteOnlyGeneration(crd)
 		}
 
 		// Convert result fields for query/action CRDs with typed responses (skip if using shared type)
 		if (crd.IsQuery || crd.IsAction) && len(crd.ResultFields) > 0 && !crd.UsesSharedType {
 			crdData.ResultFields = g.convertFieldsWithNestedTypes(crd.ResultFields, crd.ResultItemType, nestedTypes)
 		}
+		if crd.IsQuery && crd.UsesSharedType && len(crd.ResultFields) > 0 {
+			shared, ok := sharedResults[crd.ResultItemType]
+			if !ok {
+				shared = &SharedResultTypeData{
+					Name:   crd.ResultItemType,
+					Schema: crd.ResultSchemaRef,
+					Fields: g.convertFieldsWithNestedTypes(crd.ResultFields, crd.ResultItemType, nestedTypes),
+				}
+				sharedResults[crd.ResultItemType] = shared
+				sharedResultNames = append(sharedResultNames, crd.ResultItemType)
+			}
+			shared.Kinds = append(shared.Kinds, crd.Kind)
+		}
 
 		data.CRDs = append(data.CRDs, crdData)
 
@@ -175,6 +278,11 @@ func (g *TypesGenerator) Generate(crds []*mapper.CRDDefinition) error {
 		}
 	}
 
+	sort.Strings(sharedResultNames)
+	for _, name := range sharedResultNames {
+		data.SharedResultTypes = append(data.SharedResultTypes, *sharedResults[name])
+	}
+
 	// Convert nested types map to sorted slice for deterministic output
 	nestedTypeNames := make([]string, 0, len(nestedTypes))
 	for name := range nestedTypes {
@@ -233,12 +341,24 @@ func (g *TypesGenerator) convertFieldsWithNestedTypes(fields []*mapper.FieldDefi
 			Required:    f.Required,
 			Validation:  f.Validation,
 			Enum:        f.Enum,
+			Immutable:   f.Immutable,
+			Deprecated:  f.Deprecated,
+		}
+		if len(f.KeyPatterns) > 0 {
+			fd.KeyRule = mapKeyRule(f.KeyPatterns)
+			fd.KeyRuleMessage = mapKeyRuleMessage(f.JSONName, f.KeyPatterns)
+		}
+		fd.ValueRule, fd.ValueRuleMessage = mapValueRule(f)
+		fd.NotRule, fd.NotRuleMessage = fieldNotRule(f)
+		fd.AnyOfRule, fd.AnyOfRuleMessage = fieldAnyOfRule(f)
+		if strings.HasPrefix(f.GoType, "[]") && f.ItemType != nil && len(f.ItemType.Fields) == 0 {
+			fd.ItemValidation = f.ItemType.Validation
 		}
 
 		// Handle nested struct types - create named types instead of inline structs
 		if f.GoType == "struct" && len(f.Fields) > 0 {
 			// Create a named type for this nested struct
-			typeName := prefix + f.Name
+			typeName := g.nestedTypeName(f.Title, prefix+f.Name, f.Fields, nestedTypes)
 			if _, exists := nestedTypes[typeName]; !exists {
 				nestedTypes[typeName] = NestedTypeData{
 					Name:   typeName,
@@ -248,7 +368,7 @@ func (g *TypesGenerator) convertFieldsWithNestedTypes(fields []*mapper.FieldDefi
 			fd.GoType = typeName
 		} else if f.GoType == "[]struct" && f.ItemType != nil && len(f.ItemType.Fields) > 0 {
 			// Create a named type for array item type
-			typeName := prefix + f.Name + "Item"
+			typeName := g.nestedTypeName(f.ItemType.Title, prefix+f.Name+"Item", f.ItemType.Fields, nestedTypes)
 			if _, exists := nestedTypes[typeName]; !exists {
 				nestedTypes[typeName] = NestedTypeData{
 					Name:   typeName,
@@ -256,6 +376,16 @@ func (g *TypesGenerator) convertFieldsWithNestedTypes(fields []*mapper.FieldDefi
 				}
 			}
 			fd.GoType = "[]" + typeName
+		} else if f.GoType == "map[string]struct" && f.ItemType != nil && len(f.ItemType.Fields) > 0 {
+			// Create a named type for map values
+			typeName := g.nestedTypeName(f.ItemType.Title, prefix+f.Name+"Value", f.ItemType.Fields, nestedTypes)
+			if _, exists := nestedTypes[typeName]; !exists {
+				nestedTypes[typeName] = NestedTypeData{
+					Name:   typeName,
+					Fields: g.convertFieldsWithNestedTypes(f.ItemType.Fields, typeName, nestedTypes),
+				}
+			}
+			fd.GoType = "map[string]" + typeName
 		} else {
 			fd.GoType = g.resolveGoType(f)
 		}
@@ -266,6 +396,182 @@ func (g *TypesGenerator) convertFieldsWithNestedTypes(fields []*mapper.FieldDefi
 	return result
 }
 
+// nestedTypeName returns the Go type name of a nested struct: the schema title when it
+// names a valid identifier that is free or already holds the same fields, otherwise the
+// path name built from the parent type and property
+func (g *TypesGenerator) nestedTypeName(title, pathName string, fields []*mapper.FieldDefinition, nestedTypes map[string]NestedTypeData) string {
+	name := strcase.ToCamel(title)
+	if !goTypeNameRe.MatchString(name) || g.reserved[name] {
+		return pathName
+	}
+	existing, exists := nestedTypes[name]
+	if !exists {
+		return name
+	}
+	// Same title with the same shape (e.g., a $ref reused across kinds) shares the type
+	scratch := make(map[string]NestedTypeData)
+	if reflect.DeepEqual(existing.Fields, g.convertFieldsWithNestedTypes(fields, name, scratch)) {
+		return name
+	}
+	return pathName
+}
+
+// goTypeNameRe matches an exported Go identifier
+var goTypeNameRe = regexp.MustCompile(`^[A-Z][A-Za-z0-9]*$`)
+
+// reservedTypeNames returns the type names types.go declares for the CRDs themselves
+func reservedTypeNames(crds []*mapper.CRDDefinition) map[string]bool {
+	reserved := map[string]bool{
+		"BinaryDataSource":     true,
+		"ConfigMapKeySelector": true,
+		"SecretKeySelector":    true,
+		"FileDataSource":       true,
+		"TargetSpec":           true,
+	}
+	for _, crd := range crds {
+		for _, suffix := range []string{"", "Spec", "Status", "EndpointResponse", "List"} {
+			reserved[crd.Kind+suffix] = true
+		}
+		if crd.ResultItemType != "" {
+			reserved[crd.ResultItemType] = true
+		}
+	}
+	return reserved
+}
+
+// mapKeyRule builds a CEL rule that requires every key of a map to match one of the
+// given patterns, e.g. self.all(k, k.matches('^x-'))
+func mapKeyRule(patterns []string) string {
+	matches := make([]string, 0, len(patterns))
+	for _, pattern := range patterns {
+		quoted := strings.NewReplacer(`\`, `\\`, "'", `\'`).Replace(pattern)
+		matches = append(matches, fmt.Sprintf("k.matches('%s')", quoted))
+	}
+	return fmt.Sprintf("self.all(k, %s)", strings.Join(matches, " || "))
+}
+
+// mapKeyRuleMessage is the validation message for mapKeyRule
+func mapKeyRuleMessage(jsonName string, patterns []string) string {
+	return fmt.Sprintf("%s keys must match %s", jsonName, strings.Join(patterns, " or "))
+}
+
+// mapValueRule builds a CEL rule applying the value constraints of a scalar map field to
+// every value, e.g. self.all(k, size(self[k]) <= 63), as CRDs have no marker for them
+func mapValueRule(f *mapper.FieldDefinition) (string, string) {
+	if f.ValueValidation == nil {
+		return "", ""
+	}
+	checks, messages := validationChecks("self[k]", f.ValueValidation, strings.TrimPrefix(f.GoType, "map[string]"))
+	if len(checks) == 0 {
+		return "", ""
+	}
+	return fmt.Sprintf("self.all(k, %s)", strings.Join(checks, " && ")),
+		fmt.Sprintf("%s values must %s", f.JSONName, strings.Join(messages, " and "))
+}
+
+// fieldNotRule builds the CEL rule for a scalar field's not schema, e.g.
+// !(self.matches('^admin')), rejecting values that satisfy all of its constraints
+func fieldNotRule(f *mapper.FieldDefinition) (string, string) {
+	if f.NotValidation == nil {
+		return "", ""
+	}
+	checks, messages := validationChecks("self", f.NotValidation, strings.TrimPrefix(f.GoType, "*"))
+	if len(checks) == 0 {
+		return "", ""
+	}
+	return fmt.Sprintf("!(%s)", strings.Join(checks, " && ")),
+		fmt.Sprintf("%s must not %s", f.JSONName, strings.Join(messages, " and "))
+}
+
+// validationChecks turns the constraints of a scalar value into CEL checks on expr, with
+// the matching message fragments (e.g., "be at most 63 characters")
+func validationChecks(expr string, v *mapper.ValidationRules, valueType string) ([]string, []string) {
+	integer := valueType == "int" || valueType == "int32" || valueType == "int64"
+	// CEL compares a double only with a double literal
+	literal := func(n float64) string {
+		s := strconv.FormatFloat(n, 'f', -1, 64)
+		if !integer && !strings.Contains(s, ".") {
+			s += ".0"
+		}
+		return s
+	}
+	text := func(n float64) string { return strconv.FormatFloat(n, 'f', -1, 64) }
+
+	celQuote := strings.NewReplacer(`\`, `\\`, "'", `\'`)
+	var checks, messages []string
+	if v.MinLength != nil {
+		checks = append(checks, fmt.Sprintf("size(%s) >= %d", expr, *v.MinLength))
+		messages = append(messages, fmt.Sprintf("be at least %d characters", *v.MinLength))
+	}
+	if v.MaxLength != nil {
+		checks = append(checks, fmt.Sprintf("size(%s) <= %d", expr, *v.MaxLength))
+		messages = append(messages, fmt.Sprintf("be at most %d characters", *v.MaxLength))
+	}
+	if v.Pattern != "" {
+		checks = append(checks, fmt.Sprintf("%s.matches('%s')", expr, celQuote.Replace(v.Pattern)))
+		messages = append(messages, "match "+v.Pattern)
+	}
+	if v.Minimum != nil {
+		if v.ExclusiveMinimum {
+			checks = append(checks, expr+" > "+literal(*v.Minimum))
+			messages = append(messages, "be greater than "+text(*v.Minimum))
+		} else {
+			checks = append(checks, expr+" >= "+literal(*v.Minimum))
+			messages = append(messages, "be at least "+text(*v.Minimum))
+		}
+	}
+	if v.Maximum != nil {
+		if v.ExclusiveMaximum {
+			checks = append(checks, expr+" < "+literal(*v.Maximum))
+			messages = append(messages, "be less than "+text(*v.Maximum))
+		} else {
+			checks = append(checks, expr+" <= "+literal(*v.Maximum))
+			messages = append(messages, "be at most "+text(*v.Maximum))
+		}
+	}
+	if len(v.Enum) > 0 && valueType == "string" {
+		quoted := make([]string, 0, len(v.Enum))
+		for _, e := range v.Enum {
+			quoted = append(quoted, "'"+celQuote.Replace(e)+"'")
+		}
+		checks = append(checks, fmt.Sprintf("%s in [%s]", expr, strings.Join(quoted, ", ")))
+		messages = append(messages, "be one of "+strings.Join(v.Enum, ", "))
+	}
+	return checks, messages
+}
+
+// fieldAnyOfRule builds the CEL rule for a struct field merged from a loose anyOf, or for
+// each item of an array of them. Go clients send an unset optional struct as {}, so the
+// rule of an optional field only applies once one of its properties is set.
+func fieldAnyOfRule(f *mapper.FieldDefinition) (string, string) {
+	if len(f.AnyOfRequired) > 0 {
+		rule := mapper.AnyOfRule("self", f.AnyOfRequired)
+		if !f.Required && len(f.Fields) > 0 {
+			checks := make([]string, 0, len(f.Fields))
+			for _, nested := range f.Fields {
+				checks = append(checks, fmt.Sprintf("has(self.%s)", nested.JSONName))
+			}
+			rule = fmt.Sprintf("!(%s) || %s", strings.Join(checks, " || "), rule)
+		}
+		return rule, mapper.AnyOfRuleMessage(f.JSONName, f.AnyOfRequired)
+	}
+	if f.ItemType != nil && len(f.ItemType.AnyOfRequired) > 0 && strings.HasPrefix(f.GoType, "[]") {
+		rule := fmt.Sprintf("self.all(item, %s)", mapper.AnyOfRule("item", f.ItemType.AnyOfRequired))
+		return rule, mapper.AnyOfRuleMessage(f.JSONName+" items", f.ItemType.AnyOfRequired)
+	}
+	return "", ""
+}
+
+// specVersionLabel returns the spec's info.version as an app.kubernetes.io/version label
+// value, or "" when it isn't a valid one (e.g., "2.0 beta"); the spec-version annotation
+// still records it as-is
+func specVersionLabel(version string) string {
+	if len(validation.IsValidLabelValue(version)) > 0 {
+		return ""
+	}
+	return version
+}
+
 func (g *TypesGenerator) resolveGoType(f *mapper.FieldDefinition) string {
 	goType := f.GoType
 
@@ -283,7 +589,14 @@ func (g *TypesGenerator) resolveGoType(f *mapper.FieldDefinition) string {
 	if !f.Required {
 		switch goType {
 		case "string", "bool":
-			// Keep as-is, will use omitempty
+			// Keep as-is, will use omitempty; nullable ones become pointers so null is
+			// distinct from the zero value
+			if f.Nullable {
+				goType = "*" + goType
+			} else if f.AllowEmptyValue && goType == "string" {
+				// An empty value must still be sent, so unset and "" need to differ
+				goType = "*" + goType
+			}
 		case "int", "int32", "int64", "float32", "float64":
 			goType = "*" + goType
 		}
@@ -292,13 +605,36 @@ func (g *TypesGenerator) resolveGoType(f *mapper.FieldDefinition) string {
 	return goType
 }
 
+// excludedStatus indexes a CRD's ExcludedStatusFields by JSON name, for the templates
+func excludedStatus(crd *mapper.CRDDefinition) map[string]bool {
+	if len(crd.ExcludedStatusFields) == 0 {
+		return nil
+	}
+	excluded := make(map[string]bool, len(crd.ExcludedStatusFields))
+	for _, field := range crd.ExcludedStatusFields {
+		excluded[field] = true
+	}
+	return excluded
+}
+
+// targetDefaultMarker renders a default target as the value of a kubebuilder:default
+// marker, e.g. {deployment: "petstore-api", namespace: "backend"}
+func targetDefaultMarker(t *config.TargetDefault) string {
+	entries := t.Entries()
+	parts := make([]string, 0, len(entries))
+	for _, e := range entries {
+		parts = append(parts, e.Key+": "+strconv.Quote(e.Value))
+	}
+	return "{" + strings.Join(parts, ", ") + "}"
+}
+
 func (g *TypesGenerator) generateFile(path, tmplContent string, data interface{}) error {
 	tmpl, err := template.New("template").Parse(tmplContent)
 	if err != nil {
 		return fmt.Errorf("failed to parse template: %w", err)
 	}
 
-	file, err := os.Create(path)
+	file, err := g.files.Create(path)
 	if err != nil {
 		return fmt.Errorf("failed to create file: %w", err)
 	}
@@ -313,18 +649,21 @@ func (g *TypesGenerator) generateFile(path, tmplContent string, data interface{}
 
 // AggregateTypesTemplateData holds data for the aggregate types template
 type AggregateTypesTemplateData struct {
-	Year             int
-	GeneratorVersion string
-	APIVersion       string
-	Kind             string
-	Plural           string
-	ResourceKinds    []string
+	Year              int
+	GeneratorVersion  string
+	APIVersion        string
+	Kind              string
+	Plural            string
+	ResourceKinds     []string
+	StatusSubresource bool
+	SpecVersion       string
+	SpecVersionLabel  string
 }
 
 // GenerateAggregateTypes generates the aggregate CRD types
 func (g *TypesGenerator) GenerateAggregateTypes(aggregate *mapper.AggregateDefinition) error {
-	outputDir := filepath.Join(g.config.OutputDir, "api", g.config.APIVersion)
-	if err := os.MkdirAll(outputDir, 0755); err != nil {
+	outputDir := filepath.Join(g.config.OutputDir, filepath.FromSlash(g.config.APIDir()))
+	if err := g.files.MkdirAll(outputDir, 0755); err != nil {
 		return fmt.Errorf("failed to create output directory: %w", err)
 	}
 
@@ -335,6 +674,10 @@ func (g *TypesGenerator) GenerateAggregateTypes(aggregate *mapper.AggregateDefin
 		Kind:             aggregate.Kind,
 		Plural:           aggregate.Plural,
 		ResourceKinds:    aggregate.ResourceKinds,
+
+		StatusSubresource: !g.config.NoStatusSubresource,
+		SpecVersion:       g.config.SpecVersion,
+		SpecVersionLabel:  specVersionLabel(g.config.SpecVersion),
 	}
 
 	outputPath := filepath.Join(outputDir, "aggregate_types.go")
@@ -347,21 +690,24 @@ func (g *TypesGenerator) GenerateAggregateTypes(aggregate *mapper.AggregateDefin
 
 // BundleTypesTemplateData holds data for the bundle types template
 type BundleTypesTemplateData struct {
-	Year             int
-	GeneratorVersion string
-	APIVersion       string
-	Kind             string
-	Plural           string
-	ResourceKinds    []string
-	QueryKinds       []string
-	ActionKinds      []string
-	AllKinds         []string
+	Year              int
+	GeneratorVersion  string
+	APIVersion        string
+	Kind              string
+	Plural            string
+	ResourceKinds     []string
+	QueryKinds        []string
+	ActionKinds       []string
+	AllKinds          []string
+	StatusSubresource bool
+	SpecVersion       string
+	SpecVersionLabel  string
 }
 
 // GenerateBundleTypes generates the bundle CRD types
 func (g *TypesGenerator) GenerateBundleTypes(bundle *mapper.BundleDefinition) error {
-	outputDir := filepath.Join(g.config.OutputDir, "api", g.config.APIVersion)
-	if err := os.MkdirAll(outputDir, 0755); err != nil {
+	outputDir := filepath.Join(g.config.OutputDir, filepath.FromSlash(g.config.APIDir()))
+	if err := g.files.MkdirAll(outputDir, 0755); err != nil {
 		return fmt.Errorf("failed to create output directory: %w", err)
 	}
 
@@ -375,6 +721,10 @@ func (g *TypesGenerator) GenerateBundleTypes(bundle *mapper.BundleDefinition) er
 		QueryKinds:       bundle.QueryKinds,
 		ActionKinds:      bundle.ActionKinds,
 		AllKinds:         bundle.AllKinds,
+
+		StatusSubresource: !g.config.NoStatusSubresource,
+		SpecVersion:       g.config.SpecVersion,
+		SpecVersionLabel:  specVersionLabel(g.config.SpecVersion),
 	}
 
 	outputPath := filepath.Join(outputDir, "bundle_types.go")
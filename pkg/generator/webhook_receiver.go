@@ -0,0 +1,152 @@
+package generator
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/iancoleman/strcase"
+
+	"github.com/bluecontainer/openapi-operator-gen/pkg/mapper"
+	"github.com/bluecontainer/openapi-operator-gen/pkg/templates"
+)
+
+// WebhookReceiverTemplateData holds data for internal/webhookreceiver/receiver.go
+type WebhookReceiverTemplateData struct {
+	Year             int
+	GeneratorVersion string
+	APIVersion       string
+	ModuleName       string
+	Webhooks         []WebhookHandlerData
+	// HasKinds is true when some handler creates or updates CRs, so the API types are imported
+	HasKinds bool
+}
+
+// WebhookHandlerData holds the handler of one webhook operation
+type WebhookHandlerData struct {
+	Name        string // Key in the spec's webhooks object, e.g. "newPet"
+	Method      string // e.g. POST
+	OperationID string
+	Summary     string // Single line, without a trailing period
+	Path        string // Route of the handler, e.g. /webhooks/newPet; unsafe characters become "-"
+	Handler     string // Receiver method, e.g. handleNewPet
+	// Kind is the resource Kind whose CRs the payload creates or updates; empty for a stub
+	Kind       string
+	KindLower  string
+	Namespaced bool
+}
+
+// webhookPathUnsafe matches the runs of characters a webhook name can't carry into its
+// route or handler name, e.g. spaces or the braces of a ServeMux wildcard
+var webhookPathUnsafe = regexp.MustCompile(`[^A-Za-z0-9._-]+`)
+
+// webhookReceiverEnabled reports whether the receiver of the spec's webhooks is generated:
+// GenerateWebhookReceiver is set and the spec has webhooks
+func (g *ControllerGenerator) webhookReceiverEnabled() bool {
+	return g.config.GenerateWebhookReceiver && len(g.config.SpecWebhooks) > 0
+}
+
+// webhookHandlers builds a handler per webhook operation. A webhook's Kind is its
+// x-k8s-kind extension, else the resource Kind named like its payload schema; it must be a
+// resource the controllers write, since the payload becomes the CR's spec.
+func (g *ControllerGenerator) webhookHandlers(crds []*mapper.CRDDefinition) ([]WebhookHandlerData, error) {
+	resources := make(map[string]*mapper.CRDDefinition)
+	for _, crd := range crds {
+		if !crd.IsQuery && !crd.IsAction && !crd.ReadOnly {
+			resources[crd.Kind] = crd
+		}
+	}
+
+	methods := make(map[string]int)
+	for _, w := range g.config.SpecWebhooks {
+		methods[w.Name]++
+	}
+
+	handlers := make([]WebhookHandlerData, 0, len(g.config.SpecWebhooks))
+	handlerWebhooks := make(map[string]string)
+	for _, w := range g.config.SpecWebhooks {
+		segment := strings.Trim(webhookPathUnsafe.ReplaceAllString(w.Name, "-"), "-.")
+		if segment == "" {
+			return nil, fmt.Errorf("webhook %q: the name has no characters usable in a URL path", w.Name)
+		}
+		handler := WebhookHandlerData{
+			Name:        w.Name,
+			Method:      w.Method,
+			OperationID: w.OperationID,
+			Summary:     strings.TrimSuffix(strings.Join(strings.Fields(w.Summary), " "), "."),
+			Path:        "/webhooks/" + segment,
+			Handler:     "handle" + strcase.ToCamel(segment),
+		}
+		if methods[w.Name] > 1 {
+			handler.Handler += strcase.ToCamel(strings.ToLower(w.Method))
+		}
+		// Names differing only in case or separators (new-pet, newPet) share a handler
+		if other, ok := handlerWebhooks[handler.Handler]; ok {
+			return nil, fmt.Errorf("webhooks %q and %q both map to the receiver method %s; rename one of them", other, w.Name, handler.Handler)
+		}
+		handlerWebhooks[handler.Handler] = w.Name
+
+		var crd *mapper.CRDDefinition
+		if w.Kind != "" {
+			if crd = resources[w.Kind]; crd == nil {
+				return nil, fmt.Errorf("webhook %q: x-k8s-kind %q is not a generated resource Kind", w.Name, w.Kind)
+			}
+		} else if w.PayloadSchemaRef != "" {
+			crd = resources[strcase.ToCamel(w.PayloadSchemaRef)]
+		}
+		if crd != nil {
+			handler.Kind = crd.Kind
+			handler.KindLower = strings.ToLower(crd.Kind)
+			handler.Namespaced = crd.Scope != "Cluster"
+		}
+		handlers = append(handlers, handler)
+	}
+	return handlers, nil
+}
+
+// generateWebhookReceiver writes internal/webhookreceiver/receiver.go, the HTTP server the
+// manager runs for the spec's webhooks
+func (g *ControllerGenerator) generateWebhookReceiver(crds []*mapper.CRDDefinition) error {
+	handlers, err := g.webhookHandlers(crds)
+	if err != nil {
+		return err
+	}
+	data := WebhookReceiverTemplateData{
+		Year:             time.Now().Year(),
+		GeneratorVersion: g.config.GeneratorVersion,
+		APIVersion:       g.config.APIVersion,
+		ModuleName:       g.config.ResolvedImportPrefix(),
+		Webhooks:         handlers,
+	}
+	for _, h := range handlers {
+		if h.Kind != "" {
+			data.HasKinds = true
+		}
+	}
+
+	receiverDir := filepath.Join(g.config.OutputDir, "internal", "webhookreceiver")
+	if err := g.files.MkdirAll(receiverDir, 0755); err != nil {
+		return fmt.Errorf("failed to create webhookreceiver directory: %w", err)
+	}
+	return g.executeTemplate(templates.WebhookReceiverTemplate, data, filepath.Join(receiverDir, "receiver.go"))
+}
+
+// generateWebhookReceiverService writes config/webhook-receiver, the Service in front of
+// the manager's webhook receiver
+func (g *ControllerGenerator) generateWebhookReceiverService(data DeploymentManifestData) error {
+	receiverDir := filepath.Join(g.config.OutputDir, "config", "webhook-receiver")
+	if err := g.files.MkdirAll(receiverDir, 0755); err != nil {
+		return fmt.Errorf("failed to create webhook-receiver directory: %w", err)
+	}
+	if err := g.executeTemplate(templates.WebhookReceiverServiceTemplate, data,
+		filepath.Join(receiverDir, "service.yaml")); err != nil {
+		return fmt.Errorf("failed to generate webhook-receiver service.yaml: %w", err)
+	}
+	if err := g.executeTemplate(templates.KustomizationWebhookTemplate, data,
+		filepath.Join(receiverDir, "kustomization.yaml")); err != nil {
+		return fmt.Errorf("failed to generate webhook-receiver kustomization.yaml: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,343 @@
+/*
+Copyright 2024 openapi-operator-gen authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+*/
+
+// Package lint checks an OpenAPI spec for problems that make the generated operator
+// worse, such as operations without an operationId or response schema, before an
+// operator is generated from it. The rules are built in, in the style of Spectral's.
+package lint
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+
+	"github.com/bluecontainer/openapi-operator-gen/pkg/parser"
+)
+
+// Severity orders findings; lower values are more urgent
+type Severity int
+
+const (
+	// SeverityError makes the spec fail to parse, so nothing can be generated
+	SeverityError Severity = iota
+	// SeverityWarning degrades the generated operator
+	SeverityWarning
+	// SeverityInfo is worth cleaning up but doesn't affect generation
+	SeverityInfo
+)
+
+func (s Severity) String() string {
+	switch s {
+	case SeverityError:
+		return "error"
+	case SeverityWarning:
+		return "warning"
+	default:
+		return "info"
+	}
+}
+
+// Rule names, as shown in findings
+const (
+	RuleValid           = "valid-spec"
+	RuleOperationID     = "operation-operationId"
+	RuleResponseSchema  = "operation-success-response-schema"
+	RulePathCasing      = "path-casing"
+	RuleParameterType   = "parameter-type"
+	RuleUnusedComponent = "unused-component"
+)
+
+// Finding is a problem reported by one of the rules
+type Finding struct {
+	Severity Severity
+	Rule     string
+	// Location is where the problem is, e.g. "GET /pets" or "components.schemas.Pet"
+	Location string
+	Message  string
+}
+
+// Report is the result of Run
+type Report struct {
+	Spec string
+	// Findings are sorted by severity, then location
+	Findings []Finding
+}
+
+// Count returns the number of findings with the given severity
+func (r *Report) Count(severity Severity) int {
+	n := 0
+	for _, f := range r.Findings {
+		if f.Severity == severity {
+			n++
+		}
+	}
+	return n
+}
+
+// String renders the report grouped by severity
+func (r *Report) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Lint report for %s\n\n", r.Spec)
+	if len(r.Findings) == 0 {
+		b.WriteString("No problems found.\n")
+		return b.String()
+	}
+	fmt.Fprintf(&b, "%d errors, %d warnings, %d info\n", r.Count(SeverityError), r.Count(SeverityWarning), r.Count(SeverityInfo))
+	current := Severity(-1)
+	for _, f := range r.Findings {
+		if f.Severity != current {
+			current = f.Severity
+			fmt.Fprintf(&b, "\n%s:\n", strings.ToUpper(current.String()))
+		}
+		fmt.Fprintf(&b, "  [%s] %s: %s\n", f.Rule, f.Location, f.Message)
+	}
+	return b.String()
+}
+
+// Run loads the spec at specPath with p and checks it against every rule. It fails only
+// when the spec can't be read or loaded; a spec that loads but doesn't validate is
+// reported as a valid-spec error, and the other rules still run.
+func Run(p *parser.Parser, specPath string) (*Report, error) {
+	doc, err := p.Load(specPath)
+	if err != nil {
+		return nil, err
+	}
+
+	r := &Report{Spec: specPath}
+	if err := doc.Validate(); err != nil {
+		r.add(SeverityError, RuleValid, "spec", err.Error())
+	}
+	if doc.Doc.Paths != nil {
+		r.checkOperations(doc.Doc)
+		r.checkPathCasing(doc.Doc)
+	}
+	r.checkUnusedComponents(doc)
+
+	sort.SliceStable(r.Findings, func(i, j int) bool {
+		if r.Findings[i].Severity != r.Findings[j].Severity {
+			return r.Findings[i].Severity < r.Findings[j].Severity
+		}
+		return r.Findings[i].Location < r.Findings[j].Location
+	})
+	return r, nil
+}
+
+func (r *Report) add(severity Severity, rule, location, message string) {
+	r.Findings = append(r.Findings, Finding{Severity: severity, Rule: rule, Location: location, Message: message})
+}
+
+// sortedPaths returns the spec's paths in order, so findings are stable
+func sortedPaths(doc *openapi3.T) []string {
+	paths := make([]string, 0, len(doc.Paths.Map()))
+	for path := range doc.Paths.Map() {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+// checkOperations applies the per-operation rules: operationIds, success response
+// schemas and parameter types
+func (r *Report) checkOperations(doc *openapi3.T) {
+	for _, path := range sortedPaths(doc) {
+		item := doc.Paths.Value(path)
+		operations := item.Operations()
+		methods := make([]string, 0, len(operations))
+		for method := range operations {
+			methods = append(methods, method)
+		}
+		sort.Strings(methods)
+
+		for _, method := range methods {
+			op := operations[method]
+			location := method + " " + path
+
+			if op.OperationID == "" {
+				r.add(SeverityWarning, RuleOperationID, location,
+					"no operationId, so --include-operations and --exclude-operations can't select it")
+			}
+
+			if needsResponseSchema(method) && !hasSuccessSchema(op) {
+				r.add(SeverityWarning, RuleResponseSchema, location,
+					"no schema on a 2xx response, so the CRD status can't be derived from the response")
+			}
+
+			// Path-level parameters apply to every operation unless overridden
+			params := make(map[string]*openapi3.Parameter)
+			for _, ref := range item.Parameters {
+				if ref != nil && ref.Value != nil {
+					params[ref.Value.In+":"+ref.Value.Name] = ref.Value
+				}
+			}
+			for _, ref := range op.Parameters {
+				if ref != nil && ref.Value != nil {
+					params[ref.Value.In+":"+ref.Value.Name] = ref.Value
+				}
+			}
+			keys := make([]string, 0, len(params))
+			for key := range params {
+				keys = append(keys, key)
+			}
+			sort.Strings(keys)
+			for _, key := range keys {
+				param := params[key]
+				if param.Content == nil && !hasType(param.Schema) {
+					r.add(SeverityWarning, RuleParameterType, location,
+						fmt.Sprintf("%s parameter %q has no type, so it becomes a string field", param.In, param.Name))
+				}
+			}
+		}
+	}
+}
+
+// needsResponseSchema reports whether the generator reads the response of an
+// operation with this method
+func needsResponseSchema(method string) bool {
+	switch method {
+	case "GET", "POST", "PUT", "PATCH":
+		return true
+	}
+	return false
+}
+
+// hasSuccessSchema reports whether an operation has a schema on a 2xx response. An
+// operation whose only success response is 204 No Content has nothing to describe.
+func hasSuccessSchema(op *openapi3.Operation) bool {
+	if op.Responses == nil {
+		return false
+	}
+	successes := 0
+	for code, ref := range op.Responses.Map() {
+		if !strings.HasPrefix(code, "2") || ref == nil || ref.Value == nil {
+			continue
+		}
+		if code == "204" {
+			continue
+		}
+		successes++
+		for _, media := range ref.Value.Content {
+			if media != nil && media.Schema != nil {
+				return true
+			}
+		}
+	}
+	return successes == 0
+}
+
+// hasType reports whether a parameter schema declares a type, directly or through a
+// composition
+func hasType(ref *openapi3.SchemaRef) bool {
+	if ref == nil || ref.Value == nil {
+		return false
+	}
+	s := ref.Value
+	return (s.Type != nil && len(s.Type.Slice()) > 0) || len(s.OneOf) > 0 || len(s.AnyOf) > 0 || len(s.AllOf) > 0
+}
+
+// segmentCasing names the casing style of a literal path segment, or "" when the
+// segment is a single lowercase word that fits any style
+func segmentCasing(segment string) string {
+	switch {
+	case strings.Contains(segment, "-"):
+		return "kebab-case"
+	case strings.Contains(segment, "_"):
+		return "snake_case"
+	case strings.ToLower(segment) != segment:
+		return "camelCase"
+	}
+	return ""
+}
+
+// checkPathCasing reports paths whose literal segments use a different casing style
+// from most of the spec's paths
+func (r *Report) checkPathCasing(doc *openapi3.T) {
+	styles := make(map[string][]string) // path -> styles used
+	counts := make(map[string]int)
+	for _, path := range sortedPaths(doc) {
+		seen := make(map[string]bool)
+		for _, segment := range strings.Split(path, "/") {
+			if segment == "" || strings.HasPrefix(segment, "{") {
+				continue
+			}
+			if style := segmentCasing(segment); style != "" && !seen[style] {
+				seen[style] = true
+				styles[path] = append(styles[path], style)
+				counts[style]++
+			}
+		}
+	}
+	if len(counts) < 2 {
+		return
+	}
+
+	// The most used style wins; ties go to the first name so findings are stable
+	dominant := ""
+	for style, n := range counts {
+		if dominant == "" || n > counts[dominant] || (n == counts[dominant] && style < dominant) {
+			dominant = style
+		}
+	}
+	for _, path := range sortedPaths(doc) {
+		for _, style := range styles[path] {
+			if style != dominant {
+				r.add(SeverityInfo, RulePathCasing, path,
+					fmt.Sprintf("uses %s segments while most paths use %s", style, dominant))
+			}
+		}
+	}
+}
+
+// componentRef matches a reference to a named component, in OpenAPI 3 or Swagger 2.0 form
+var componentRef = regexp.MustCompile(`#/(?:components/)?(schemas|definitions|parameters|responses|requestBodies)/([^"'\s/#}\]]+)`)
+
+// checkUnusedComponents reports component schemas, parameters, responses and request
+// bodies that no $ref in the spec names. Swagger 2.0 definitions are converted to
+// component schemas.
+func (r *Report) checkUnusedComponents(doc *parser.Document) {
+	components := doc.Doc.Components
+	if components == nil {
+		return
+	}
+
+	used := make(map[string]bool)
+	for _, match := range componentRef.FindAllSubmatch(doc.Raw, -1) {
+		kind := string(match[1])
+		if kind == "definitions" {
+			kind = "schemas"
+		}
+		used[kind+"."+string(match[2])] = true
+	}
+
+	names := func(kind string, m map[string]bool) {
+		sorted := make([]string, 0, len(m))
+		for name := range m {
+			sorted = append(sorted, name)
+		}
+		sort.Strings(sorted)
+		for _, name := range sorted {
+			if !used[kind+"."+name] {
+				r.add(SeverityInfo, RuleUnusedComponent, "components."+kind+"."+name, "not referenced by any $ref")
+			}
+		}
+	}
+	names("schemas", componentNames(components.Schemas))
+	names("parameters", componentNames(components.Parameters))
+	names("responses", componentNames(components.Responses))
+	names("requestBodies", componentNames(components.RequestBodies))
+}
+
+// componentNames returns the names of a component map as a set
+func componentNames[V any](m map[string]V) map[string]bool {
+	set := make(map[string]bool, len(m))
+	for name := range m {
+		set[name] = true
+	}
+	return set
+}
@@ -0,0 +1,146 @@
+/*
+Copyright 2024 openapi-operator-gen authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+*/
+
+package lint
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/bluecontainer/openapi-operator-gen/pkg/parser"
+)
+
+const cleanSpec = `openapi: 3.0.3
+info: {title: Widgets, version: 1.0.0}
+paths:
+  /widgets:
+    post:
+      operationId: createWidget
+      requestBody: {content: {application/json: {schema: {$ref: '#/components/schemas/Widget'}}}}
+      responses: {"201": {description: created, content: {application/json: {schema: {$ref: '#/components/schemas/Widget'}}}}}
+  /widgets/{id}:
+    parameters: [{name: id, in: path, required: true, schema: {type: string}}]
+    get:
+      operationId: getWidget
+      responses: {"200": {description: ok, content: {application/json: {schema: {$ref: '#/components/schemas/Widget'}}}}}
+    delete:
+      operationId: deleteWidget
+      responses: {"204": {description: gone}}
+components:
+  schemas:
+    Widget:
+      type: object
+      properties:
+        id: {type: string}
+`
+
+const messySpec = `openapi: 3.0.3
+info: {title: Widgets, version: 1.0.0}
+paths:
+  /widget-groups:
+    get:
+      operationId: listWidgetGroups
+      responses: {"200": {description: ok, content: {application/json: {schema: {type: array, items: {type: string}}}}}}
+  /widget-parts:
+    get:
+      operationId: listWidgetParts
+      responses: {"200": {description: ok, content: {application/json: {schema: {type: array, items: {type: string}}}}}}
+  /widget_owners/{ownerId}:
+    get:
+      parameters:
+        - {name: ownerId, in: path, required: true, schema: {description: owner}}
+        - {name: limit, in: query, schema: {type: integer}}
+      responses: {"200": {description: ok}}
+components:
+  schemas:
+    Widget:
+      type: object
+      properties:
+        id: {type: string}
+  parameters:
+    Limit: {name: limit, in: query, schema: {type: integer}}
+`
+
+func runLint(t *testing.T, spec string) *Report {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "spec.yaml")
+	if err := os.WriteFile(path, []byte(spec), 0644); err != nil {
+		t.Fatal(err)
+	}
+	p := parser.NewParser()
+	p.LogWriter = io.Discard
+	report, err := Run(p, path)
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	return report
+}
+
+func TestRun_Clean(t *testing.T) {
+	report := runLint(t, cleanSpec)
+	if len(report.Findings) != 0 {
+		t.Errorf("expected no findings, got %+v", report.Findings)
+	}
+	if !strings.Contains(report.String(), "No problems found.") {
+		t.Errorf("String() = %q, want the no-problems line", report.String())
+	}
+}
+
+func TestRun_Rules(t *testing.T) {
+	report := runLint(t, messySpec)
+
+	want := []Finding{
+		{SeverityWarning, RuleOperationID, "GET /widget_owners/{ownerId}", ""},
+		{SeverityWarning, RuleResponseSchema, "GET /widget_owners/{ownerId}", ""},
+		{SeverityWarning, RuleParameterType, "GET /widget_owners/{ownerId}", `path parameter "ownerId"`},
+		{SeverityInfo, RulePathCasing, "/widget_owners/{ownerId}", "uses snake_case segments while most paths use kebab-case"},
+		{SeverityInfo, RuleUnusedComponent, "components.schemas.Widget", ""},
+		{SeverityInfo, RuleUnusedComponent, "components.parameters.Limit", ""},
+	}
+	for _, w := range want {
+		found := false
+		for _, f := range report.Findings {
+			if f.Severity == w.Severity && f.Rule == w.Rule && f.Location == w.Location && strings.Contains(f.Message, w.Message) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			t.Errorf("missing %s finding %s at %s (%q), got %+v", w.Severity, w.Rule, w.Location, w.Message, report.Findings)
+		}
+	}
+	if len(report.Findings) != len(want) {
+		t.Errorf("got %d findings, want %d: %+v", len(report.Findings), len(want), report.Findings)
+	}
+
+	for i := 1; i < len(report.Findings); i++ {
+		if report.Findings[i].Severity < report.Findings[i-1].Severity {
+			t.Errorf("findings not sorted by severity: %+v", report.Findings)
+			break
+		}
+	}
+	if out := report.String(); !strings.Contains(out, "0 errors, 3 warnings, 3 info") || !strings.Contains(out, "WARNING:") {
+		t.Errorf("String() = %q, want the summary line and severity headings", out)
+	}
+}
+
+func TestRun_InvalidSpec(t *testing.T) {
+	report := runLint(t, `openapi: 3.0.3
+info: {title: Widgets, version: 1.0.0}
+paths:
+  /widgets:
+    get:
+      operationId: listWidgets
+      responses: {}
+`)
+	if report.Count(SeverityError) != 1 || report.Findings[0].Rule != RuleValid {
+		t.Errorf("expected a valid-spec error, got %+v", report.Findings)
+	}
+}
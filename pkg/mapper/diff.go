@@ -0,0 +1,193 @@
+package mapper
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// ChangeType classifies a difference between two mappings of the same CRD Kind
+type ChangeType string
+
+const (
+	ChangeOperationAdded    ChangeType = "OperationAdded"
+	ChangeOperationRemoved  ChangeType = "OperationRemoved"
+	ChangeFieldAdded        ChangeType = "FieldAdded"
+	ChangeFieldRemoved      ChangeType = "FieldRemoved"
+	ChangeFieldRetyped      ChangeType = "FieldRetyped"
+	ChangeFieldRequired     ChangeType = "FieldRequired"
+	ChangeFieldOptional     ChangeType = "FieldOptional"
+	ChangeQueryParamAdded   ChangeType = "QueryParamAdded"
+	ChangeQueryParamRemoved ChangeType = "QueryParamRemoved"
+	ChangeQueryParamRetyped ChangeType = "QueryParamRetyped"
+	ChangeResponseType      ChangeType = "ResponseType"
+)
+
+// CRDChange is a difference between two mappings of the same CRD Kind
+type CRDChange struct {
+	Type ChangeType
+	// Name is the operation's CRD action, or the JSON name of the spec field or query parameter
+	Name string
+	// Old and New are the Go types of a field or query parameter, the response types,
+	// or the "METHOD path" of an added operation
+	Old string
+	New string
+	// Required is set for an added field that is required
+	Required bool
+}
+
+// String describes the change in one line
+func (c CRDChange) String() string {
+	switch c.Type {
+	case ChangeOperationAdded:
+		return fmt.Sprintf("Added operation: %s (%s)", c.Name, c.New)
+	case ChangeOperationRemoved:
+		return fmt.Sprintf("Removed operation: %s", c.Name)
+	case ChangeFieldAdded:
+		req := ""
+		if c.Required {
+			req = " (required)"
+		}
+		return fmt.Sprintf("Added field: %s (%s)%s", c.Name, c.New, req)
+	case ChangeFieldRemoved:
+		return fmt.Sprintf("Removed field: %s", c.Name)
+	case ChangeFieldRetyped:
+		return fmt.Sprintf("Changed field type: %s %s -> %s", c.Name, c.Old, c.New)
+	case ChangeFieldRequired:
+		return fmt.Sprintf("Field now required: %s", c.Name)
+	case ChangeFieldOptional:
+		return fmt.Sprintf("Field now optional: %s", c.Name)
+	case ChangeQueryParamAdded:
+		return fmt.Sprintf("Added query param: %s (%s)", c.Name, c.New)
+	case ChangeQueryParamRemoved:
+		return fmt.Sprintf("Removed query param: %s", c.Name)
+	case ChangeQueryParamRetyped:
+		return fmt.Sprintf("Changed query param type: %s %s -> %s", c.Name, c.Old, c.New)
+	case ChangeResponseType:
+		return fmt.Sprintf("Changed response type: %s -> %s", c.Old, c.New)
+	}
+	return string(c.Type) + ": " + c.Name
+}
+
+// IsWidening reports whether a retyped field's new type accepts every value of the
+// old one (e.g., int32 -> int64), so existing CRs stay valid
+func (c CRDChange) IsWidening() bool {
+	oldType := strings.TrimPrefix(c.Old, "*")
+	newType := strings.TrimPrefix(c.New, "*")
+	if oldType == newType {
+		return true
+	}
+	switch oldType {
+	case "int32":
+		return newType == "int64" || newType == "float64"
+	case "int64", "float32":
+		return newType == "float64"
+	}
+	return false
+}
+
+// CompareCRDs returns the changes between the old and new mapping of a CRD Kind:
+// operations, spec fields, and the query parameters and response type of query CRDs.
+// Changes are grouped by type, in name order within a group.
+func CompareCRDs(old, new *CRDDefinition) []CRDChange {
+	var changes []CRDChange
+
+	// Compare operations
+	oldOps := make(map[string]string)
+	for _, op := range old.Operations {
+		oldOps[op.CRDAction] = op.HTTPMethod + " " + op.Path
+	}
+	newOps := make(map[string]string)
+	for _, op := range new.Operations {
+		newOps[op.CRDAction] = op.HTTPMethod + " " + op.Path
+	}
+	for _, action := range sortedKeys(newOps) {
+		if _, ok := oldOps[action]; !ok {
+			changes = append(changes, CRDChange{Type: ChangeOperationAdded, Name: action, New: newOps[action]})
+		}
+	}
+	for _, action := range sortedKeys(oldOps) {
+		if _, ok := newOps[action]; !ok {
+			changes = append(changes, CRDChange{Type: ChangeOperationRemoved, Name: action})
+		}
+	}
+
+	// Compare spec fields
+	oldFields := make(map[string]*FieldDefinition)
+	if old.Spec != nil {
+		for _, f := range old.Spec.Fields {
+			oldFields[f.JSONName] = f
+		}
+	}
+	newFields := make(map[string]*FieldDefinition)
+	if new.Spec != nil {
+		for _, f := range new.Spec.Fields {
+			newFields[f.JSONName] = f
+		}
+	}
+	for _, name := range sortedKeys(newFields) {
+		newF := newFields[name]
+		oldF, ok := oldFields[name]
+		if !ok {
+			changes = append(changes, CRDChange{Type: ChangeFieldAdded, Name: name, New: newF.GoType, Required: newF.Required})
+			continue
+		}
+		if oldF.GoType != newF.GoType {
+			changes = append(changes, CRDChange{Type: ChangeFieldRetyped, Name: name, Old: oldF.GoType, New: newF.GoType})
+		}
+		if oldF.Required != newF.Required {
+			if newF.Required {
+				changes = append(changes, CRDChange{Type: ChangeFieldRequired, Name: name, New: newF.GoType})
+			} else {
+				changes = append(changes, CRDChange{Type: ChangeFieldOptional, Name: name, New: newF.GoType})
+			}
+		}
+	}
+	for _, name := range sortedKeys(oldFields) {
+		if _, ok := newFields[name]; !ok {
+			changes = append(changes, CRDChange{Type: ChangeFieldRemoved, Name: name, Old: oldFields[name].GoType})
+		}
+	}
+
+	// Compare query parameters (for query endpoints)
+	if old.IsQuery && new.IsQuery {
+		oldQP := make(map[string]string)
+		for _, qp := range old.QueryParams {
+			oldQP[qp.JSONName] = qp.GoType
+		}
+		newQP := make(map[string]string)
+		for _, qp := range new.QueryParams {
+			newQP[qp.JSONName] = qp.GoType
+		}
+		for _, name := range sortedKeys(newQP) {
+			oldType, ok := oldQP[name]
+			if !ok {
+				changes = append(changes, CRDChange{Type: ChangeQueryParamAdded, Name: name, New: newQP[name]})
+			} else if oldType != newQP[name] {
+				changes = append(changes, CRDChange{Type: ChangeQueryParamRetyped, Name: name, Old: oldType, New: newQP[name]})
+			}
+		}
+		for _, name := range sortedKeys(oldQP) {
+			if _, ok := newQP[name]; !ok {
+				changes = append(changes, CRDChange{Type: ChangeQueryParamRemoved, Name: name, Old: oldQP[name]})
+			}
+		}
+	}
+
+	// Compare response type (for query endpoints)
+	if old.IsQuery && new.IsQuery && old.ResponseType != new.ResponseType {
+		changes = append(changes, CRDChange{Type: ChangeResponseType, Old: old.ResponseType, New: new.ResponseType})
+	}
+
+	return changes
+}
+
+// sortedKeys returns the keys of m in order
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -1,8 +1,13 @@
 package mapper
 
 import (
+	"errors"
+	"fmt"
+	"io"
+	"slices"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/bluecontainer/openapi-operator-gen/internal/config"
 	"github.com/bluecontainer/openapi-operator-gen/pkg/parser"
@@ -37,6 +42,7 @@ type CRDDefinition struct {
 	APIVersion   string
 	Kind         string
 	Plural       string
+	CustomPlural bool // Plural came from config.PluralOverrides or x-k8s-plural, not the heuristic
 	ShortNames   []string
 	Scope        string // Namespaced or Cluster
 	Description  string
@@ -61,9 +67,47 @@ type CRDDefinition struct {
 	// This is set when --update-with-post flag is used AND HasPut is false AND HasPost is true.
 	UpdateWithPost bool
 
+	// UseETag enables optimistic concurrency: the controller stores the ETag returned by GET
+	// and sends it as If-Match on updates. Set when --use-etag is used AND the GET response
+	// declares an ETag header.
+	UseETag bool
+
+	// TargetDefault presets spec.target when a CR doesn't set it. Taken from the
+	// x-k8s-target-default extension of the CRD's operations, else config.DefaultTarget.
+	TargetDefault *config.TargetDefault
+
+	// StatusFields are response fields surfaced as first-class status fields and
+	// printer columns (x-k8s-status-field)
+	StatusFields []StatusField
+
+	// PollCondition keeps a resource the controller writes in the Syncing state, polling
+	// the REST API, until a response satisfies it (x-k8s-poll-condition)
+	PollCondition *PollCondition
+
+	// ExcludedStatusFields are the status fields config.ExcludeStatusFields drops from the
+	// CRD, sorted. The generated types keep built-in ones the controllers set as json:"-"
+	// fields, so they are never stored.
+	ExcludedStatusFields []string
+
 	// ExternalIDRef handling
 	NeedsExternalIDRef bool // True if externalIDRef field is needed (no path params to identify resource)
 
+	// ReadOnly marks an observe-only resource: the spec holds only its identifier and the
+	// controller GETs the object and mirrors it into status, never writing to the API
+	ReadOnly bool
+
+	// Owner is the resource Kind whose CRs own this Kind's CRs, from x-k8s-owner or
+	// derived from the resource path with config.DeriveOwners; the controller sets an
+	// owner reference to the CR of the owning REST API resource
+	Owner *OwnerDefinition
+
+	// HasExtraHeaders adds spec.extraHeaders, HTTP headers sent on each REST API request
+	HasExtraHeaders bool
+
+	// FormEncoded sends the create and update bodies (or the action body) as
+	// application/x-www-form-urlencoded instead of JSON
+	FormEncoded bool
+
 	// Query endpoint fields
 	IsQuery            bool               // True if this is a query/action CRD
 	QueryPath          string             // Full query path (e.g., /pet/findByTags)
@@ -74,6 +118,7 @@ type CRDDefinition struct {
 	ResultItemType     string             // Item type if ResponseIsArray (e.g., "Pet")
 	ResultFields       []*FieldDefinition // Fields for the result type (used to generate result struct)
 	UsesSharedType     bool               // True if ResultItemType is a shared type from another CRD
+	ResultSchemaRef    string             // Component schema the response references (e.g., "Pet"); keys shared result types
 	IsPrimitiveArray   bool               // True if response is a simple array of primitives ([]string, []int, etc.)
 	PrimitiveArrayType string             // The Go type for primitive arrays (e.g., "string", "int64")
 
@@ -90,6 +135,14 @@ type CRDDefinition struct {
 	// Binary upload fields
 	HasBinaryBody     bool   // True if the action accepts binary data uploads
 	BinaryContentType string // Content type for binary data (e.g., "application/octet-stream")
+	// WatchDataFrom re-executes the action when the ConfigMap or Secret referenced by
+	// spec.dataFrom changes (--reconcile-on-configmap-change)
+	WatchDataFrom bool
+
+	// Multipart form parts (request body is multipart/form-data)
+	IsMultipart bool     // True if the request body is sent as multipart/form-data
+	FormFields  []string // Text part names; each is a spec field (e.g., "description")
+	FileField   string   // Part carrying the binary upload from the data sources (e.g., "file")
 
 	// IDFieldMappings stores mappings from path parameters to body fields.
 	// This is used when a path param like {orderId} maps to the body's "id" field.
@@ -98,12 +151,106 @@ type CRDDefinition struct {
 	// 2. Optionally rename the field in the JSON body when sending to the API
 	IDFieldMappings []IDFieldMapping
 
+	// SpecAnyOfRule requires one of the spec's anyOf member schemas to be satisfied when
+	// the resource schema is a loose anyOf; referencing an existing resource also satisfies it
+	SpecAnyOfRule *CELValidationRule
+
 	// CELValidationRules contains CEL validation rules for conditional field requirements.
 	// These rules make OpenAPI-required fields optional when referencing existing resources
 	// via path parameters or externalIDRef.
 	CELValidationRules []CELValidationRule
+
+	// SecuritySchemes are the security schemes the CRD's operations authenticate with,
+	// sorted by name; each operation's Security picks among them
+	SecuritySchemes []SecurityScheme
+}
+
+// SecurityScheme is an OpenAPI security scheme the controller authenticates REST API
+// requests with. Its credential is read from the manager's EnvVar environment variable.
+type SecurityScheme struct {
+	Name      string // Key in components.securitySchemes, e.g. "api_key"
+	Type      string // apiKey, http, oauth2 or openIdConnect
+	In        string // apiKey only: header, query or cookie
+	ParamName string // apiKey only: the header, query parameter or cookie name
+	Scheme    string // http only: the Authorization scheme, e.g. "bearer" or "basic"
+	EnvVar    string // e.g. "API_AUTH_API_KEY"
 }
 
+// SecurityRequirement is one security requirement object of an operation: all of its
+// schemes are applied to the request together. An empty requirement makes
+// authentication optional.
+type SecurityRequirement struct {
+	Schemes []SecurityScheme
+}
+
+// KindType classifies the CRD as "resource" (CRUD), "query" (GET-only) or "action"
+// (POST/PUT-only)
+func (c *CRDDefinition) KindType() string {
+	switch {
+	case c.IsQuery:
+		return "query"
+	case c.IsAction:
+		return "action"
+	}
+	return "resource"
+}
+
+// Values the generated controllers set in status.state, emitted as the field's enum
+var (
+	ResourceStates = []string{"Pending", "Syncing", "Synced", "Failed", "Observed", "NotFound", "Paused"}
+	ReadOnlyStates = []string{"Pending", "Observed", "NotFound", "Failed", "Paused"}
+	QueryStates    = []string{"Pending", "Querying", "Queried", "Failed", "Paused"}
+	ActionStates   = []string{"Pending", "Executing", "Completed", "Failed", "Paused"}
+)
+
+// States returns the allowed values of the CRD's status.state
+func (c *CRDDefinition) States() []string {
+	switch {
+	case c.IsQuery:
+		return QueryStates
+	case c.IsAction:
+		return ActionStates
+	case c.ReadOnly:
+		return ReadOnlyStates
+	}
+	return ResourceStates
+}
+
+// WriteKindList writes one Kind<TAB>type<TAB>plural line per CRD, a minimal listing
+// for scripts
+func WriteKindList(w io.Writer, crds []*CRDDefinition) error {
+	for _, crd := range crds {
+		if _, err := fmt.Fprintf(w, "%s\t%s\t%s\n", crd.Kind, crd.KindType(), crd.Plural); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// StatusField is a field of the REST API response that the controller copies into
+// the CR status (x-k8s-status-field)
+type StatusField struct {
+	Name        string   // Go field name (e.g., "ProvisioningState")
+	JSONName    string   // Status field name (e.g., "provisioningState")
+	Path        []string // Keys leading to the value in the response body
+	GoType      string   // string, int64, float64 or bool
+	SchemaType  string   // OpenAPI type, also used for the printer column (e.g., "string")
+	Description string
+}
+
+// PollCondition is a resource's x-k8s-poll-condition: it is ready once the response
+// value at Path is one of Values
+type PollCondition struct {
+	Path       []string      // Keys leading to the value in the response body
+	Values     []string      // Ready values, compared as text
+	Interval   time.Duration // How often to poll until the condition is met
+	Expression string        // The condition as written in messages, e.g. status == "available"
+}
+
+// DefaultPollInterval is how often a resource is polled for its x-k8s-poll-condition
+// when the extension gives no interval
+const DefaultPollInterval = 10 * time.Second
+
 // QueryParamField represents a query parameter as a spec field
 type QueryParamField struct {
 	Name        string
@@ -115,6 +262,10 @@ type QueryParamField struct {
 	ItemType    string // Type of array items if IsArray is true
 	IsPointer   bool   // True if this is a pointer type (for optional numeric types)
 	BaseType    string // Base type without pointer (e.g., "int64" for "*int64")
+	Style       string // Serialization style of path params (simple, label or matrix); set for scalars only when not simple
+	Explode     bool   // Serialization explode flag of array path params
+	// AllowEmptyValue is true when an empty value is sent (?flag=) rather than dropped
+	AllowEmptyValue bool
 }
 
 // OperationMapping maps a CRD operation to a REST API call
@@ -122,8 +273,25 @@ type OperationMapping struct {
 	CRDAction   string // Create, Update, Delete, Get
 	HTTPMethod  string
 	Path        string
+	OperationID string // operationId from the OpenAPI spec, if any
 	PathParams  []string
 	QueryParams []string
+	// TargetDefault holds the operation's x-k8s-target-default extension, if any
+	TargetDefault map[string]string
+	// SuccessCodes are the response codes the controller treats as success, from the
+	// x-k8s-success-codes extension or config.SuccessCodes; nil accepts any 2xx
+	SuccessCodes []int
+	// Accept is the Accept header sent with the request, from config.AcceptHeader or the
+	// media types of the operation's responses
+	Accept string
+	// ContentType is the media type of the operation's request body (e.g.,
+	// "application/merge-patch+json"); empty when it has none or it is binary
+	ContentType string
+	// Security lists the requirements any one of which authenticates the request; nil
+	// when the operation needs no authentication
+	Security []SecurityRequirement
+	// RequestExample is the spec's example request body, used to seed generated tests
+	RequestExample interface{}
 }
 
 // FieldDefinition represents a field in the CRD spec or status
@@ -146,6 +314,60 @@ type FieldDefinition struct {
 	// This is used to generate CEL validation rules that make the field conditionally required
 	// (required when creating a new resource, optional when referencing an existing one).
 	OpenAPIRequired bool
+	// Immutable marks a create-only field (x-k8s-immutable); the CRD rejects changes with a
+	// self == oldSelf transition rule because the controller cannot update it in the API.
+	Immutable bool
+	// WriteOnly marks a field the API accepts but never returns (OpenAPI writeOnly). The
+	// controller leaves it out of drift detection so it isn't re-sent on every reconcile.
+	WriteOnly bool
+	// Nullable marks a field the API may return as null (nullable: true, or a 3.1 type array
+	// including "null"). It is generated as a pointer so null and the zero value stay distinct.
+	Nullable bool
+	// PathStyle and PathExplode are the serialization of an array path parameter field
+	// (e.g., simple/false joins the values with commas: /items/1,2,3). For a scalar path
+	// parameter PathStyle is only set for the label (.value) and matrix (;name=value) styles.
+	PathStyle   string
+	PathExplode bool
+	// Deprecated marks a field generated from a parameter the API has deprecated
+	Deprecated bool
+	// Title is the schema's title; the types generator prefers it over the property path
+	// as the Go type name of a nested struct
+	Title string
+	// AllowEmptyValue marks a query parameter field whose empty value is sent rather than
+	// dropped; optional ones are generated as *string so unset and "" stay distinct
+	AllowEmptyValue bool
+	// KeyPatterns are the patternProperties regexes of a map field; every key must match
+	// one of them. For "map[string]struct" fields, ItemType holds the value's fields.
+	KeyPatterns []string
+	// ValueValidation holds the constraints of the values of a scalar map field (e.g.,
+	// map[string]string), from its patternProperties schema
+	ValueValidation *ValidationRules
+	// Format is the OpenAPI format of a string field (e.g., "email"), kept for samples
+	Format string
+	// AnyOfRequired are the JSON names of each anyOf member's required properties, for a
+	// struct merged from a loose anyOf; at least one set must be present (see AnyOfRule)
+	AnyOfRequired [][]string
+	// NotValidation holds the constraints of a scalar field's not schema, which its value
+	// must not satisfy
+	NotValidation *ValidationRules
+	// APIName is the request body property a spec field is sent as when x-k8s-spec-field
+	// gives it a different name in the CR spec (e.g., "type" for spec.petType). Empty
+	// means the field isn't renamed.
+	APIName string
+}
+
+// OwnerDefinition describes the resource Kind whose CRs own another Kind's CRs
+type OwnerDefinition struct {
+	Kind   string
+	Plural string
+	// IDParam is the owned Kind's path parameter holding the owner's REST API ID (e.g., "orderId")
+	IDParam string
+	// IDField is the Go name of the owner's spec field holding its ID (e.g., "Id"), or ""
+	// when it has none
+	IDField string
+	// HasExternalID is true when the owner records the ID the REST API assigned it in
+	// status.externalID
+	HasExternalID bool
 }
 
 // IDFieldMapping represents a mapping from a path parameter to a body field.
@@ -160,6 +382,12 @@ type IDFieldMapping struct {
 type CELValidationRule struct {
 	Rule    string // The CEL expression (e.g., "has(self.petId) || has(self.name)")
 	Message string // The validation error message
+
+	// Field is the JSON name of the field the rule requires (e.g., "name")
+	Field string
+	// ReferenceFields are the JSON names of fields that satisfy the rule by
+	// referencing an existing resource (e.g., ["petId", "externalIDRef"])
+	ReferenceFields []string
 }
 
 // generateCELValidationRules creates CEL validation rules for conditional field requirements.
@@ -174,6 +402,12 @@ func generateCELValidationRules(crd *CRDDefinition) {
 
 	// Skip Query and Action CRDs - they don't need conditional validation
 	if crd.IsQuery || crd.IsAction {
+		if len(crd.Spec.AnyOfRequired) > 0 {
+			crd.SpecAnyOfRule = &CELValidationRule{
+				Rule:    AnyOfRule("self", crd.Spec.AnyOfRequired),
+				Message: AnyOfRuleMessage("spec", crd.Spec.AnyOfRequired),
+			}
+		}
 		return
 	}
 
@@ -186,24 +420,27 @@ func generateCELValidationRules(crd *CRDDefinition) {
 		}
 	}
 
-	// Check if we have any path params or need externalIDRef
-	hasPathParams := len(pathParamFields) > 0
-	needsExternalIDRef := crd.NeedsExternalIDRef
-
-	// If there's no way to reference existing resources, no CEL rules needed
-	if !hasPathParams && !needsExternalIDRef {
-		return
-	}
-
 	// Build the condition prefix for referencing existing resources
 	// e.g., "has(self.petId)" or "has(self.externalIDRef)"
 	var conditions []string
+	referenceFields := append([]string{}, pathParamFields...)
 	for _, pathParam := range pathParamFields {
 		conditions = append(conditions, "has(self."+pathParam+")")
 	}
-	if needsExternalIDRef && crd.HasPost {
+	if crd.NeedsExternalIDRef && crd.HasPost {
 		// Only add externalIDRef condition if POST is available (optional externalIDRef case)
 		conditions = append(conditions, "has(self.externalIDRef)")
+		referenceFields = append(referenceFields, "externalIDRef")
+	}
+
+	// A resource schema merged from a loose anyOf needs one member's required properties,
+	// unless the CR references an existing resource
+	if len(crd.Spec.AnyOfRequired) > 0 {
+		crd.SpecAnyOfRule = &CELValidationRule{
+			Rule:            strings.Join(append(append([]string{}, conditions...), AnyOfRule("self", crd.Spec.AnyOfRequired)), " || "),
+			Message:         AnyOfRuleMessage("spec", crd.Spec.AnyOfRequired),
+			ReferenceFields: referenceFields,
+		}
 	}
 
 	// If no conditions to check, no rules needed
@@ -236,8 +473,10 @@ func generateCELValidationRules(crd *CRDDefinition) {
 		message := field.JSONName + " is required when creating a new resource"
 
 		crd.CELValidationRules = append(crd.CELValidationRules, CELValidationRule{
-			Rule:    rule,
-			Message: message,
+			Rule:            rule,
+			Message:         message,
+			Field:           field.JSONName,
+			ReferenceFields: referenceFields,
 		})
 
 		// Mark the field as no longer strictly required since CEL handles it
@@ -245,6 +484,33 @@ func generateCELValidationRules(crd *CRDDefinition) {
 	}
 }
 
+// AnyOfRule builds a CEL expression that holds when all required properties of at least
+// one anyOf member are set on variable, e.g. (has(self.email) && has(self.name)) || has(self.phone)
+func AnyOfRule(variable string, sets [][]string) string {
+	alternatives := make([]string, 0, len(sets))
+	for _, set := range sets {
+		checks := make([]string, 0, len(set))
+		for _, name := range set {
+			checks = append(checks, fmt.Sprintf("has(%s.%s)", variable, name))
+		}
+		if len(checks) == 1 || len(sets) == 1 {
+			alternatives = append(alternatives, strings.Join(checks, " && "))
+		} else {
+			alternatives = append(alternatives, "("+strings.Join(checks, " && ")+")")
+		}
+	}
+	return strings.Join(alternatives, " || ")
+}
+
+// AnyOfRuleMessage is the validation message for AnyOfRule
+func AnyOfRuleMessage(name string, sets [][]string) string {
+	alternatives := make([]string, 0, len(sets))
+	for _, set := range sets {
+		alternatives = append(alternatives, strings.Join(set, " and "))
+	}
+	return fmt.Sprintf("%s must set %s", name, strings.Join(alternatives, ", or "))
+}
+
 // ValidationRules contains kubebuilder validation markers
 type ValidationRules struct {
 	MinLength *int64
@@ -255,11 +521,50 @@ type ValidationRules struct {
 	Enum      []string
 	MinItems  *int64
 	MaxItems  *int64
+	// ExclusiveMinimum/ExclusiveMaximum make the Minimum/Maximum bounds exclusive
+	ExclusiveMinimum bool
+	ExclusiveMaximum bool
+	// Format is a string format the API server validates natively (e.g., "email")
+	Format string
+}
+
+// stringFormat describes how an OpenAPI string format is validated in the CRD
+type stringFormat struct {
+	// Format is set for formats the Kubernetes API server validates natively
+	Format string
+	// Pattern is a regex for formats the API server doesn't know
+	Pattern string
+	// Example is a valid value used in generated samples
+	Example string
+}
+
+// stringFormats maps OpenAPI string formats to CRD validations. Formats not listed
+// here are left unvalidated.
+var stringFormats = map[string]stringFormat{
+	"uri":      {Format: "uri", Example: "https://example.com/resource"},
+	"url":      {Pattern: `^[a-zA-Z][a-zA-Z0-9+.-]*://[^\s]+$`, Example: "https://example.com/resource"},
+	"email":    {Format: "email", Example: "user@example.com"},
+	"hostname": {Format: "hostname", Example: "host.example.com"},
+	"ipv4":     {Format: "ipv4", Example: "192.0.2.10"},
+	"ipv6":     {Format: "ipv6", Example: "2001:db8::10"},
+	"cidr":     {Format: "cidr", Example: "192.0.2.0/24"},
+	"mac":      {Format: "mac", Example: "00:1a:2b:3c:4d:5e"},
+	"uuid":     {Format: "uuid", Example: "3fa85f64-5717-4562-b3fc-2c963f66afa6"},
+	"date":     {Format: "date", Example: "2024-01-15"},
+}
+
+// FormatExample returns a valid example value for a string format, or false when the
+// format isn't one the mapper validates
+func FormatExample(format string) (string, bool) {
+	f, ok := stringFormats[format]
+	return f.Example, ok
 }
 
 // Mapper maps REST resources to Kubernetes CRD definitions
 type Mapper struct {
 	config *config.Config
+	// securitySchemes are the spec's security schemes, set by MapResources
+	securitySchemes map[string]parser.SecurityScheme
 }
 
 // NewMapper creates a new resource mapper
@@ -270,12 +575,21 @@ func NewMapper(cfg *config.Config) *Mapper {
 // MapResources converts parsed OpenAPI resources to CRD definitions
 func (m *Mapper) MapResources(spec *parser.ParsedSpec) ([]*CRDDefinition, error) {
 	var crds []*CRDDefinition
+	var err error
+
+	if err := validateSuccessCodes(spec); err != nil {
+		return nil, err
+	}
+	m.securitySchemes = spec.SecuritySchemes
 
 	switch m.config.MappingMode {
 	case config.SingleCRD:
-		crds, _ = m.mapSingleCRD(spec)
+		crds, err = m.mapSingleCRD(spec)
 	default:
-		crds, _ = m.mapPerResource(spec)
+		crds, err = m.mapPerResource(spec)
+	}
+	if err != nil {
+		return nil, err
 	}
 
 	// Collect known resource kinds for type reuse in query endpoints
@@ -292,14 +606,307 @@ func (m *Mapper) MapResources(spec *parser.ParsedSpec) ([]*CRDDefinition, error)
 	actionCRDs := m.mapActionEndpoints(spec.ActionEndpoints, knownKinds)
 	crds = append(crds, actionCRDs...)
 
+	if err := checkPlurals(crds); err != nil {
+		return nil, err
+	}
+	if err := checkSpecFieldRenames(crds); err != nil {
+		return nil, err
+	}
+	if err := m.resolveOwners(crds); err != nil {
+		return nil, err
+	}
+	if m.config.GroupKindPluralCheck {
+		if err := checkNames(crds, m.config.APIGroup); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, crd := range crds {
+		crd.SecuritySchemes = operationSecuritySchemes(crd.Operations)
+	}
+
 	// Generate CEL validation rules for conditional field requirements
 	for _, crd := range crds {
 		generateCELValidationRules(crd)
 	}
 
+	// Resolve default endpoint targets
+	for _, crd := range crds {
+		if err := m.resolveTargetDefault(crd); err != nil {
+			return nil, err
+		}
+	}
+
+	if m.config.AllowExtraHeaders {
+		for _, crd := range crds {
+			if err := addExtraHeaders(crd); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if len(m.config.ExcludeStatusFields) > 0 {
+		if err := excludeStatusFields(crds, m.config.ExcludeStatusFields); err != nil {
+			return nil, err
+		}
+	}
+
+	if m.config.MaxCRDs > 0 && len(crds) > m.config.MaxCRDs {
+		return nil, fmt.Errorf("spec maps to %d CRDs, more than the limit of %d; narrow it with path, tag or operation filters (--include-paths, --exclude-paths, --include-tags, --exclude-tags, --include-operations, --exclude-operations) or raise --max-crds",
+			len(crds), m.config.MaxCRDs)
+	}
+
 	return crds, nil
 }
 
+// addExtraHeaders adds spec.extraHeaders to a resource, query or action CRD, unless the
+// API schema already has a field with that name
+func addExtraHeaders(crd *CRDDefinition) error {
+	if crd.Spec != nil {
+		for _, field := range crd.Spec.Fields {
+			if field.JSONName == "extraHeaders" {
+				return fmt.Errorf("%s: spec field extraHeaders from the API schema conflicts with --allow-extra-headers", crd.Kind)
+			}
+		}
+	}
+	crd.HasExtraHeaders = true
+	return nil
+}
+
+// resolvePlural returns the CRD plural for kind and whether it was set explicitly:
+// config.PluralOverrides first, then the first non-empty x-k8s-plural extension,
+// falling back to the pluralization heuristic.
+func (m *Mapper) resolvePlural(kind, heuristic string, extensions ...string) (string, bool) {
+	if plural, ok := m.config.PluralOverrides[kind]; ok {
+		return plural, true
+	}
+	for _, plural := range extensions {
+		if plural != "" {
+			return plural, true
+		}
+	}
+	return heuristic, false
+}
+
+// operationsPlural returns the x-k8s-plural extension of the first operation declaring one
+func operationsPlural(ops []parser.Operation) string {
+	for _, op := range ops {
+		if op.Plural != "" {
+			return op.Plural
+		}
+	}
+	return ""
+}
+
+// checkPlurals rejects invalid explicit plurals and Kinds that would share a plural,
+// which would make their CRD names collide
+func checkPlurals(crds []*CRDDefinition) error {
+	kindsByPlural := make(map[string]string, len(crds))
+	for _, crd := range crds {
+		if crd.CustomPlural {
+			if err := config.ValidatePlural(crd.Plural); err != nil {
+				return fmt.Errorf("%s: %w", crd.Kind, err)
+			}
+		}
+		if other, ok := kindsByPlural[crd.Plural]; ok {
+			return fmt.Errorf("kinds %s and %s both use the plural %q; set x-k8s-plural or --plural-overrides to tell them apart",
+				other, crd.Kind, crd.Plural)
+		}
+		kindsByPlural[crd.Plural] = crd.Kind
+	}
+	return nil
+}
+
+// checkNames validates every CRD's Kind, plural, CRD name and short names against the
+// Kubernetes naming rules, and rejects short names another CRD already uses, reporting
+// all the problems at once
+func checkNames(crds []*CRDDefinition, group string) error {
+	owners := make(map[string]string, len(crds))
+	for _, crd := range crds {
+		owners[crd.Plural] = crd.Kind
+	}
+
+	var errs []error
+	for _, crd := range crds {
+		if err := config.ValidateKind(crd.Kind); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w; rename its path or operationId, or leave it out with --exclude-paths or --exclude-operations", crd.Kind, err))
+		}
+		if err := config.ValidatePlural(crd.Plural); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w; set x-k8s-plural or --plural-overrides", crd.Kind, err))
+		} else if err := config.ValidateCRDName(crd.Plural, group); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w; shorten the plural or the API group", crd.Kind, err))
+		}
+		for _, shortName := range crd.ShortNames {
+			if err := config.ValidateShortName(shortName); err != nil {
+				errs = append(errs, fmt.Errorf("%s: %w", crd.Kind, err))
+				continue
+			}
+			if other, ok := owners[shortName]; ok && other != crd.Kind {
+				errs = append(errs, fmt.Errorf("%s: short name %q is also used by %s, so kubectl can't tell them apart", crd.Kind, shortName, other))
+				continue
+			}
+			owners[shortName] = crd.Kind
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// targetDefaultMethodOrder decides which operation's x-k8s-target-default wins when a
+// resource declares it on several operations
+var targetDefaultMethodOrder = []string{"GET", "POST", "PUT", "PATCH", "DELETE"}
+
+// resolveTargetDefault sets crd.TargetDefault from the x-k8s-target-default extension of
+// its operations, falling back to config.DefaultTarget.
+func (m *Mapper) resolveTargetDefault(crd *CRDDefinition) error {
+	for _, method := range targetDefaultMethodOrder {
+		for _, op := range crd.Operations {
+			if op.HTTPMethod != method || op.TargetDefault == nil {
+				continue
+			}
+			target, err := config.TargetDefaultFromMap(op.TargetDefault)
+			if err != nil {
+				return fmt.Errorf("x-k8s-target-default on %s %s: %w", op.HTTPMethod, op.Path, err)
+			}
+			crd.TargetDefault = target
+			return nil
+		}
+	}
+	crd.TargetDefault = m.config.DefaultTarget
+	return nil
+}
+
+// validateSuccessCodes checks the x-k8s-success-codes extensions of the spec's operations
+func validateSuccessCodes(spec *parser.ParsedSpec) error {
+	check := func(method, path string, codes []string) error {
+		if codes == nil {
+			return nil
+		}
+		if _, err := config.ParseStatusCodes(codes); err != nil {
+			return fmt.Errorf("x-k8s-success-codes on %s %s: %w", method, path, err)
+		}
+		return nil
+	}
+	for _, resource := range spec.Resources {
+		for _, op := range resource.Operations {
+			if err := check(op.Method, op.Path, op.SuccessCodes); err != nil {
+				return err
+			}
+		}
+	}
+	for _, qe := range spec.QueryEndpoints {
+		if err := check("GET", qe.Path, qe.SuccessCodes); err != nil {
+			return err
+		}
+	}
+	for _, ae := range spec.ActionEndpoints {
+		if err := check(ae.HTTPMethod, ae.Path, ae.SuccessCodes); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// successCodes returns an operation's success codes: its x-k8s-success-codes extension
+// (validated by validateSuccessCodes), else config.SuccessCodes for its method
+func (m *Mapper) successCodes(method string, extension []string) []int {
+	if extension != nil {
+		codes, _ := config.ParseStatusCodes(extension)
+		return codes
+	}
+	return m.config.SuccessCodes[method]
+}
+
+// accept returns the Accept header of an operation's requests: config.AcceptHeader, else
+// application/json when the operation's responses declare it or no media types, else
+// their first JSON media type (e.g., application/vnd.x+json), else their first media type
+func (m *Mapper) accept(contentTypes []string) string {
+	if m.config.AcceptHeader != "" {
+		return m.config.AcceptHeader
+	}
+	if len(contentTypes) == 0 {
+		return "application/json"
+	}
+	for _, contentType := range contentTypes {
+		if mediaTypeBase(contentType) == "application/json" {
+			return contentType
+		}
+	}
+	for _, contentType := range contentTypes {
+		if base := mediaTypeBase(contentType); strings.HasSuffix(base, "+json") || strings.HasSuffix(base, "/json") {
+			return contentType
+		}
+	}
+	return contentTypes[0]
+}
+
+// security resolves an operation's security requirements against the spec's security
+// schemes. Requirements naming an unknown scheme, or a mutualTLS one the controller can't
+// present, are dropped; nil is returned when none remain.
+func (m *Mapper) security(requirements []parser.SecurityRequirement) []SecurityRequirement {
+	var result []SecurityRequirement
+requirements:
+	for _, requirement := range requirements {
+		schemes := make([]SecurityScheme, 0, len(requirement.Schemes))
+		for _, name := range requirement.Schemes {
+			scheme, ok := m.securitySchemes[name]
+			if !ok || scheme.Type == "mutualTLS" {
+				continue requirements
+			}
+			schemes = append(schemes, SecurityScheme{
+				Name:      scheme.Name,
+				Type:      scheme.Type,
+				In:        scheme.In,
+				ParamName: scheme.ParamName,
+				Scheme:    scheme.Scheme,
+				EnvVar:    securityEnvVar(scheme.Name),
+			})
+		}
+		result = append(result, SecurityRequirement{Schemes: schemes})
+	}
+	return result
+}
+
+// securityEnvVar returns the manager environment variable holding a security scheme's
+// credential: API_AUTH_ and the scheme name, uppercased, with other characters as
+// underscores (e.g., API_AUTH_PETSTORE_AUTH for petstore_auth)
+func securityEnvVar(name string) string {
+	var b strings.Builder
+	b.WriteString("API_AUTH_")
+	for _, r := range strings.ToUpper(name) {
+		if (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') {
+			b.WriteRune(r)
+		} else {
+			b.WriteByte('_')
+		}
+	}
+	return b.String()
+}
+
+// operationSecuritySchemes returns the distinct security schemes of operations, sorted by name
+func operationSecuritySchemes(ops []OperationMapping) []SecurityScheme {
+	seen := make(map[string]bool)
+	var schemes []SecurityScheme
+	for _, op := range ops {
+		for _, requirement := range op.Security {
+			for _, scheme := range requirement.Schemes {
+				if !seen[scheme.Name] {
+					seen[scheme.Name] = true
+					schemes = append(schemes, scheme)
+				}
+			}
+		}
+	}
+	sort.Slice(schemes, func(i, j int) bool { return schemes[i].Name < schemes[j].Name })
+	return schemes
+}
+
+// mediaTypeBase returns a media type without its parameters, lowercased
+// (e.g., "application/json" for "application/json; charset=utf-8")
+func mediaTypeBase(mediaType string) string {
+	base, _, _ := strings.Cut(mediaType, ";")
+	return strings.ToLower(strings.TrimSpace(base))
+}
+
 // mapQueryEndpoints converts query endpoints to CRD definitions
 func (m *Mapper) mapQueryEndpoints(queryEndpoints []*parser.QueryEndpoint, knownKinds map[string]bool) []*CRDDefinition {
 	crds := make([]*CRDDefinition, 0, len(queryEndpoints))
@@ -316,7 +923,6 @@ func (m *Mapper) mapQueryEndpoints(queryEndpoints []*parser.QueryEndpoint, known
 			APIGroup:        m.config.APIGroup,
 			APIVersion:      m.config.APIVersion,
 			Kind:            qe.Name,
-			Plural:          pluralize(qe.Name),
 			ShortNames:      []string{}, // Query CRDs don't get short names to avoid conflicts
 			Scope:           "Namespaced",
 			Description:     qe.Summary,
@@ -327,11 +933,14 @@ func (m *Mapper) mapQueryEndpoints(queryEndpoints []*parser.QueryEndpoint, known
 			QueryParams:     m.mapQueryParams(qe.QueryParams),
 		}
 
+		crd.Plural, crd.CustomPlural = m.resolvePlural(qe.Name, pluralize(qe.Name), qe.Plural)
+
 		// Generate spec fields from query parameters
 		crd.Spec = m.createQuerySpec(qe)
 
 		// Map response schema to typed result fields
 		m.mapResponseSchema(crd, qe, knownKinds)
+		crd.ResultSchemaRef = qe.ResponseSchemaRef
 
 		// Generate status fields (includes results)
 		crd.Status = m.createQueryStatusDefinition()
@@ -339,18 +948,64 @@ func (m *Mapper) mapQueryEndpoints(queryEndpoints []*parser.QueryEndpoint, known
 		// Add a single GET operation
 		crd.Operations = []OperationMapping{
 			{
-				CRDAction:  "Query",
-				HTTPMethod: "GET",
-				Path:       qe.Path,
+				CRDAction:     "Query",
+				HTTPMethod:    "GET",
+				Path:          qe.Path,
+				OperationID:   qe.OperationID,
+				TargetDefault: qe.TargetDefault,
+				SuccessCodes:  m.successCodes("GET", qe.SuccessCodes),
+				Accept:        m.accept(qe.ResponseContentTypes),
+				Security:      m.security(qe.Security),
 			},
 		}
 
 		crds = append(crds, crd)
 	}
 
+	shareResultTypes(crds, knownKinds)
+
 	return crds
 }
 
+// shareResultTypes points queries whose responses $ref the same component schema at one
+// <Schema>Result type, instead of generating an identical <Kind>Result type per query
+func shareResultTypes(crds []*CRDDefinition, knownKinds map[string]bool) {
+	taken := make(map[string]bool)
+	for kind := range knownKinds {
+		taken[kind] = true
+	}
+	byRef := make(map[string][]*CRDDefinition)
+	var refs []string
+	for _, crd := range crds {
+		taken[crd.Kind] = true
+		taken[crd.Kind+"Result"] = true
+		if crd.ResultSchemaRef == "" || len(crd.ResultFields) == 0 {
+			continue
+		}
+		if _, ok := byRef[crd.ResultSchemaRef]; !ok {
+			refs = append(refs, crd.ResultSchemaRef)
+		}
+		byRef[crd.ResultSchemaRef] = append(byRef[crd.ResultSchemaRef], crd)
+	}
+
+	for _, ref := range refs {
+		users := byRef[ref]
+		typeName := strcase.ToCamel(ref) + "Result"
+		if len(users) < 2 || taken[typeName] {
+			continue
+		}
+		for _, crd := range users {
+			crd.ResultItemType = typeName
+			crd.UsesSharedType = true
+			if strings.HasPrefix(crd.ResponseType, "[]") {
+				crd.ResponseType = "[]" + typeName
+			} else {
+				crd.ResponseType = "*" + typeName
+			}
+		}
+	}
+}
+
 // mapActionEndpoints converts action endpoints to CRD definitions
 func (m *Mapper) mapActionEndpoints(actionEndpoints []*parser.ActionEndpoint, knownKinds map[string]bool) []*CRDDefinition {
 	crds := make([]*CRDDefinition, 0, len(actionEndpoints))
@@ -367,7 +1022,6 @@ func (m *Mapper) mapActionEndpoints(actionEndpoints []*parser.ActionEndpoint, kn
 			APIGroup:          m.config.APIGroup,
 			APIVersion:        m.config.APIVersion,
 			Kind:              ae.Name,
-			Plural:            pluralize(ae.Name),
 			ShortNames:        []string{}, // Action CRDs don't get short names to avoid conflicts
 			Scope:             "Namespaced",
 			Description:       ae.Summary,
@@ -377,11 +1031,19 @@ func (m *Mapper) mapActionEndpoints(actionEndpoints []*parser.ActionEndpoint, kn
 			ParentResource:    ae.ParentResource,
 			ParentIDParam:     ae.ParentIDParam,
 			ParentIDType:      ae.ParentIDType,
-			ParentIDGoType:    m.mapParamType(ae.ParentIDType),
+			ParentIDGoType:    m.mapParamType(ae.ParentIDType, ae.ParentIDFormat),
 			ActionName:        ae.ActionName,
 			HasBinaryBody:     ae.HasBinaryBody,
 			BinaryContentType: ae.BinaryContentType,
+			WatchDataFrom:     ae.HasBinaryBody && m.config.ReconcileOnConfigMapChange,
+			IsMultipart:       ae.IsMultipart(),
+			FileField:         ae.FileField,
+			FormEncoded:       ae.FormEncoded,
+		}
+		for _, formField := range ae.FormFields {
+			crd.FormFields = append(crd.FormFields, formField.Name)
 		}
+		crd.Plural, crd.CustomPlural = m.resolvePlural(ae.Name, pluralize(ae.Name), ae.Plural)
 
 		// Generate spec fields from request schema and path params
 		crd.Spec = m.createActionSpec(ae)
@@ -395,9 +1057,16 @@ func (m *Mapper) mapActionEndpoints(actionEndpoints []*parser.ActionEndpoint, kn
 		// Add single operation for the action
 		crd.Operations = []OperationMapping{
 			{
-				CRDAction:  "Execute",
-				HTTPMethod: ae.HTTPMethod,
-				Path:       ae.Path,
+				CRDAction:      "Execute",
+				HTTPMethod:     ae.HTTPMethod,
+				Path:           ae.Path,
+				OperationID:    ae.OperationID,
+				TargetDefault:  ae.TargetDefault,
+				SuccessCodes:   m.successCodes(ae.HTTPMethod, ae.SuccessCodes),
+				Accept:         m.accept(ae.ResponseContentTypes),
+				ContentType:    ae.RequestContentType,
+				Security:       m.security(ae.Security),
+				RequestExample: ae.RequestBodyExample,
 			},
 		}
 
@@ -419,16 +1088,17 @@ func (m *Mapper) createActionSpec(ae *parser.ActionEndpoint) *FieldDefinition {
 	// Add parent resource ID field (required) - only if the action has a parent ID
 	if ae.ParentIDParam != "" {
 		// Use the actual type from the OpenAPI spec for the parent ID field
-		parentIDGoType := m.mapParamType(ae.ParentIDType)
+		parentIDGoType := m.mapParamType(ae.ParentIDType, ae.ParentIDFormat)
 		if parentIDGoType == "" {
 			parentIDGoType = "string" // fallback for unspecified types
 		}
 		parentIDField := &FieldDefinition{
 			Name:        strcase.ToCamel(ae.ParentIDParam),
-			JSONName:    strcase.ToLowerCamel(ae.ParentIDParam),
+			JSONName:    m.config.JSONFieldName(ae.ParentIDParam),
 			GoType:      parentIDGoType,
 			Description: "ID of the parent " + ae.ParentResource + " resource",
 			Required:    true,
+			PathStyle:   scalarPathStyle(ae.ParentIDStyle),
 		}
 		spec.Fields = append(spec.Fields, parentIDField)
 	}
@@ -438,38 +1108,33 @@ func (m *Mapper) createActionSpec(ae *parser.ActionEndpoint) *FieldDefinition {
 		if param.Name == ae.ParentIDParam {
 			continue
 		}
-		field := &FieldDefinition{
-			Name:        strcase.ToCamel(param.Name),
-			JSONName:    strcase.ToLowerCamel(param.Name),
-			GoType:      m.mapParamType(param.Type),
-			Description: param.Description,
-			Required:    param.Required,
-		}
-		spec.Fields = append(spec.Fields, field)
+		spec.Fields = append(spec.Fields, m.pathParamField(param))
 	}
 
 	// Add query parameters
 	for _, param := range ae.QueryParams {
-		goType := m.mapParamType(param.Type)
+		goType := m.mapParamType(param.Type, param.Format)
 		isArray := false
 
 		if strings.HasPrefix(param.Type, "array:") {
 			isArray = true
 			itemType := strings.TrimPrefix(param.Type, "array:")
-			goType = "[]" + m.mapParamType(itemType)
+			goType = "[]" + m.mapParamType(itemType, param.Format)
 		}
 
 		field := &FieldDefinition{
-			Name:        strcase.ToCamel(param.Name),
-			JSONName:    strcase.ToLowerCamel(param.Name),
-			GoType:      goType,
-			Description: param.Description,
-			Required:    param.Required,
+			Name:            strcase.ToCamel(param.Name),
+			JSONName:        m.config.JSONFieldName(param.Name),
+			GoType:          goType,
+			Description:     param.Description,
+			Required:        param.Required,
+			Deprecated:      param.Deprecated,
+			AllowEmptyValue: param.AllowEmptyValue && goType == "string",
 		}
 
 		if isArray {
 			field.ItemType = &FieldDefinition{
-				GoType: m.mapParamType(strings.TrimPrefix(param.Type, "array:")),
+				GoType: m.mapParamType(strings.TrimPrefix(param.Type, "array:"), param.Format),
 			}
 		}
 
@@ -486,8 +1151,18 @@ func (m *Mapper) createActionSpec(ae *parser.ActionEndpoint) *FieldDefinition {
 		sort.Strings(propNames)
 
 		for _, propName := range propNames {
+			// The multipart file part is filled from the binary upload fields below
+			if propName == ae.FileField {
+				continue
+			}
 			propSchema := ae.RequestSchema.Properties[propName]
 			propField := m.schemaToFieldDefinition(propName, propSchema, false)
+			renameSpecField(propField, propName, propSchema)
+			// Properties of an optional request body are never required
+			if ae.RequestBodyOptional {
+				spec.Fields = append(spec.Fields, propField)
+				continue
+			}
 			// Check if property is required in OpenAPI spec
 			for _, req := range ae.RequestSchema.Required {
 				if req == propName {
@@ -508,6 +1183,20 @@ func (m *Mapper) createActionSpec(ae *parser.ActionEndpoint) *FieldDefinition {
 	return spec
 }
 
+// relaxRequiredFields marks the top-level fields of a spec as optional, both for the
+// kubebuilder Required marker and for CEL conditional-required rule generation.
+// Used when the request body the spec was derived from is not required.
+func relaxRequiredFields(spec *FieldDefinition) {
+	if spec == nil {
+		return
+	}
+	spec.AnyOfRequired = nil
+	for _, field := range spec.Fields {
+		field.Required = false
+		field.OpenAPIRequired = false
+	}
+}
+
 // createBinaryUploadFields creates the fields for binary upload support
 // Supports multiple data sources: inline base64, ConfigMap/Secret reference, URL, and PVC
 func (m *Mapper) createBinaryUploadFields() []*FieldDefinition {
@@ -603,7 +1292,8 @@ func (m *Mapper) createActionStatusDefinition() *FieldDefinition {
 				Name:        "State",
 				JSONName:    "state",
 				GoType:      "string",
-				Description: "Current state of the action (Pending, Executing, Completed, Failed)",
+				Description: "Current state of the action",
+				Enum:        ActionStates,
 			},
 			{
 				Name:        "ExecutedAt",
@@ -687,7 +1377,8 @@ func (m *Mapper) mapResponseSchema(crd *CRDDefinition, qe *parser.QueryEndpoint,
 	// validation errors like "unknown field" and "expected map, got string".
 	//
 	// Instead, we always generate a dedicated result type from the response schema, which only
-	// contains the actual API response fields.
+	// contains the actual API response fields. Queries returning the same $ref schema share
+	// one such type (see shareResultTypes).
 
 	// Check if response is an array
 	if schema.Type == "array" && schema.Items != nil {
@@ -790,23 +1481,25 @@ func (m *Mapper) mapQueryParams(params []parser.Parameter) []QueryParamField {
 
 	for _, p := range params {
 		field := QueryParamField{
-			Name:        strcase.ToCamel(p.Name),
-			JSONName:    strcase.ToLowerCamel(p.Name),
-			Description: p.Description,
-			Required:    p.Required,
+			Name:            strcase.ToCamel(p.Name),
+			JSONName:        m.config.JSONFieldName(p.Name),
+			Description:     p.Description,
+			Required:        p.Required,
+			AllowEmptyValue: p.AllowEmptyValue && p.Type == "string",
 		}
 
 		// Handle array types (e.g., "array:string")
 		if strings.HasPrefix(p.Type, "array:") {
 			field.IsArray = true
 			field.ItemType = strings.TrimPrefix(p.Type, "array:")
-			field.GoType = "[]" + m.mapParamType(field.ItemType)
+			field.GoType = "[]" + m.mapParamType(field.ItemType, p.Format)
 			field.BaseType = field.GoType
 		} else {
-			baseType := m.mapParamType(p.Type)
+			baseType := m.mapParamType(p.Type, p.Format)
 			field.BaseType = baseType
-			// Add pointer for optional numeric types (matches resolveGoType in types.go)
-			if !p.Required && m.isNumericType(baseType) {
+			// Add pointer for optional numeric types and strings allowing empty values
+			// (matches resolveGoType in types.go)
+			if !p.Required && (m.isNumericType(baseType) || field.AllowEmptyValue) {
 				field.GoType = "*" + baseType
 				field.IsPointer = true
 			} else {
@@ -825,13 +1518,31 @@ func (m *Mapper) mapQueryPathParams(params []parser.Parameter) []QueryParamField
 	fields := make([]QueryParamField, 0, len(params))
 
 	for _, p := range params {
-		baseType := m.mapParamType(p.Type)
+		if strings.HasPrefix(p.Type, "array:") {
+			// Array path params (e.g., /items/{ids} with ids=1,2,3) are joined per style/explode
+			field := QueryParamField{
+				Name:        strcase.ToCamel(p.Name),
+				JSONName:    m.config.JSONFieldName(p.Name),
+				Description: p.Description,
+				Required:    p.Required,
+				IsArray:     true,
+				ItemType:    strings.TrimPrefix(p.Type, "array:"),
+				Style:       p.Style,
+				Explode:     p.Explode,
+			}
+			field.GoType = "[]" + m.mapParamType(field.ItemType, p.Format)
+			field.BaseType = field.GoType
+			fields = append(fields, field)
+			continue
+		}
+		baseType := m.mapParamType(p.Type, p.Format)
 		field := QueryParamField{
 			Name:        strcase.ToCamel(p.Name),
-			JSONName:    strcase.ToLowerCamel(p.Name),
+			JSONName:    m.config.JSONFieldName(p.Name),
 			Description: p.Description,
 			Required:    p.Required,
 			BaseType:    baseType,
+			Style:       scalarPathStyle(p.Style),
 		}
 		// Add pointer for optional numeric types (matches resolveGoType in types.go)
 		if !p.Required && m.isNumericType(baseType) {
@@ -846,12 +1557,48 @@ func (m *Mapper) mapQueryPathParams(params []parser.Parameter) []QueryParamField
 	return fields
 }
 
-// mapParamType maps OpenAPI parameter types to Go types
-func (m *Mapper) mapParamType(t string) string {
+// pathParamField converts a path parameter to a spec field. Array path params
+// ("array:<itemType>") become slices that the controller joins per the param's style/explode.
+func (m *Mapper) pathParamField(param parser.Parameter) *FieldDefinition {
+	field := &FieldDefinition{
+		Name:        strcase.ToCamel(param.Name),
+		JSONName:    m.config.JSONFieldName(param.Name),
+		GoType:      m.mapParamType(param.Type, param.Format),
+		Description: param.Description,
+		Required:    param.Required,
+		Deprecated:  param.Deprecated,
+	}
+	if strings.HasPrefix(param.Type, "array:") {
+		itemType := m.mapParamType(strings.TrimPrefix(param.Type, "array:"), param.Format)
+		field.GoType = "[]" + itemType
+		field.ItemType = &FieldDefinition{GoType: itemType}
+		field.PathStyle = param.Style
+		field.PathExplode = param.Explode
+	} else {
+		field.PathStyle = scalarPathStyle(param.Style)
+	}
+	return field
+}
+
+// scalarPathStyle returns the label or matrix style of a scalar path parameter, or ""
+// for the default simple style, which substitutes the value as is
+func scalarPathStyle(style string) string {
+	if style == "label" || style == "matrix" {
+		return style
+	}
+	return ""
+}
+
+// mapParamType maps OpenAPI parameter types to Go types. Integers are int64 unless their
+// format is int32, so a path param agrees with the int32 body field it is merged with.
+func (m *Mapper) mapParamType(t, format string) string {
 	switch t {
 	case "string":
 		return "string"
 	case "integer":
+		if format == "int32" {
+			return "int32"
+		}
 		return "int64"
 	case "number":
 		return "float64"
@@ -893,40 +1640,35 @@ func (m *Mapper) createQuerySpec(qe *parser.QueryEndpoint) *FieldDefinition {
 
 	// Add path parameters as spec fields
 	for _, param := range qe.PathParams {
-		field := &FieldDefinition{
-			Name:        strcase.ToCamel(param.Name),
-			JSONName:    strcase.ToLowerCamel(param.Name),
-			GoType:      m.mapParamType(param.Type),
-			Description: param.Description,
-			Required:    param.Required,
-		}
-		spec.Fields = append(spec.Fields, field)
+		spec.Fields = append(spec.Fields, m.pathParamField(param))
 	}
 
 	// Add query parameters as spec fields
 	for _, param := range qe.QueryParams {
-		goType := m.mapParamType(param.Type)
+		goType := m.mapParamType(param.Type, param.Format)
 		isArray := false
 
 		// Handle array types
 		if strings.HasPrefix(param.Type, "array:") {
 			isArray = true
 			itemType := strings.TrimPrefix(param.Type, "array:")
-			goType = "[]" + m.mapParamType(itemType)
+			goType = "[]" + m.mapParamType(itemType, param.Format)
 		}
 
 		field := &FieldDefinition{
-			Name:        strcase.ToCamel(param.Name),
-			JSONName:    strcase.ToLowerCamel(param.Name),
-			GoType:      goType,
-			Description: param.Description,
-			Required:    param.Required,
+			Name:            strcase.ToCamel(param.Name),
+			JSONName:        m.config.JSONFieldName(param.Name),
+			GoType:          goType,
+			Description:     param.Description,
+			Required:        param.Required,
+			Deprecated:      param.Deprecated,
+			AllowEmptyValue: param.AllowEmptyValue && goType == "string",
 		}
 
 		// Add item type info for arrays
 		if isArray {
 			field.ItemType = &FieldDefinition{
-				GoType: m.mapParamType(strings.TrimPrefix(param.Type, "array:")),
+				GoType: m.mapParamType(strings.TrimPrefix(param.Type, "array:"), param.Format),
 			}
 		}
 
@@ -947,7 +1689,8 @@ func (m *Mapper) createQueryStatusDefinition() *FieldDefinition {
 				Name:        "State",
 				JSONName:    "state",
 				GoType:      "string",
-				Description: "Current state of the query (Pending, Queried, Failed)",
+				Description: "Current state of the query",
+				Enum:        QueryStates,
 			},
 			{
 				Name:        "LastQueryTime",
@@ -997,12 +1740,21 @@ func (m *Mapper) mapPerResource(spec *parser.ParsedSpec) ([]*CRDDefinition, erro
 			APIGroup:    m.config.APIGroup,
 			APIVersion:  m.config.APIVersion,
 			Kind:        resource.Name,
-			Plural:      strings.ToLower(resource.PluralName),
 			ShortNames:  m.generateShortNames(resource.Name),
 			Scope:       "Namespaced",
 			Description: resource.Description,
 			BasePath:    resource.Path,
 			Operations:  m.mapOperations(resource.Operations),
+			ReadOnly:    resource.ReadOnly,
+		}
+		var schemaPlural string
+		if resource.Schema != nil {
+			schemaPlural = resource.Schema.Plural
+		}
+		crd.Plural, crd.CustomPlural = m.resolvePlural(resource.Name, strings.ToLower(resource.PluralName),
+			operationsPlural(resource.Operations), schemaPlural)
+		if resource.Owner != "" {
+			crd.Owner = &OwnerDefinition{Kind: resource.Owner}
 		}
 
 		// Check method availability and collect per-method paths
@@ -1015,17 +1767,25 @@ func (m *Mapper) mapPerResource(spec *parser.ParsedSpec) ([]*CRDDefinition, erro
 				}
 			case "POST":
 				crd.HasPost = true
+				// Only the create POST carries the resource body; POSTs on an item are updates or actions
+				if len(op.PathParams) == 0 {
+					crd.FormEncoded = crd.FormEncoded || op.FormEncoded
+				}
 			case "PUT":
 				crd.HasPut = true
 				if crd.PutPath == "" {
 					crd.PutPath = op.Path
 				}
+				crd.FormEncoded = crd.FormEncoded || op.FormEncoded
 			case "PATCH":
 				crd.HasPatch = true
 			case "GET":
 				if crd.GetPath == "" {
 					crd.GetPath = op.Path
 				}
+				if m.config.UseETag && op.HasResponseHeader("ETag") {
+					crd.UseETag = true
+				}
 			}
 		}
 
@@ -1059,9 +1819,16 @@ func (m *Mapper) mapPerResource(spec *parser.ParsedSpec) ([]*CRDDefinition, erro
 		// If path params exist (e.g., /pet/{petId}), those fields serve as the identifier
 		crd.NeedsExternalIDRef = !strings.Contains(crd.ResourcePath, "{")
 
-		// Generate spec fields from resource schema
-		if resource.Schema != nil {
+		// Generate spec fields from resource schema; a read-only resource's spec only
+		// identifies the object, with the path parameters added below
+		if crd.ReadOnly {
+			crd.Spec = &FieldDefinition{Name: "Spec", JSONName: "spec", GoType: "struct"}
+		} else if resource.Schema != nil {
 			crd.Spec = m.schemaToFieldDefinition("Spec", resource.Schema, true)
+			// An optional request body means none of its properties are required
+			if resource.SchemaOptional {
+				relaxRequiredFields(crd.Spec)
+			}
 		} else {
 			// Create a generic spec if no schema found
 			crd.Spec = m.createGenericSpec()
@@ -1073,6 +1840,28 @@ func (m *Mapper) mapPerResource(spec *parser.ParsedSpec) ([]*CRDDefinition, erro
 
 		// Generate status fields
 		crd.Status = m.createStatusDefinition()
+		if crd.UseETag {
+			crd.Status.Fields = append(crd.Status.Fields, &FieldDefinition{
+				Name:        "ETag",
+				JSONName:    "etag",
+				GoType:      "string",
+				Description: "Entity tag last returned by the REST API, sent as If-Match on updates",
+			})
+		}
+		if err := resolveStatusFields(crd, *resource); err != nil {
+			return nil, err
+		}
+		if err := resolvePollCondition(crd, *resource); err != nil {
+			return nil, err
+		}
+		for _, f := range crd.StatusFields {
+			crd.Status.Fields = append(crd.Status.Fields, &FieldDefinition{
+				Name:        f.Name,
+				JSONName:    f.JSONName,
+				GoType:      f.GoType,
+				Description: f.Description,
+			})
+		}
 
 		crds = append(crds, crd)
 	}
@@ -1080,6 +1869,267 @@ func (m *Mapper) mapPerResource(spec *parser.ParsedSpec) ([]*CRDDefinition, erro
 	return crds, nil
 }
 
+// reservedStatusFields are the built-in resource status fields an x-k8s-status-field
+// must not shadow
+var reservedStatusFields = map[string]bool{
+	"state": true, "lastSyncTime": true, "syncDurationSeconds": true, "externalID": true,
+	"externalResourceURL": true, "etag": true, "message": true, "conditions": true,
+	"observedGeneration": true, "response": true, "responses": true, "driftDetected": true,
+	"driftDetectedCount": true, "lastGetTime": true, "createdByController": true,
+	"originalState": true, "adoptedAt": true,
+}
+
+// queryStatusFields and actionStatusFields are the built-in query and action status fields
+var (
+	queryStatusFields = map[string]bool{
+		"state": true, "lastQueryTime": true, "lastExecutionTime": true, "nextExecutionTime": true,
+		"executionCount": true, "syncDurationSeconds": true, "resultCount": true, "truncated": true,
+		"message": true, "conditions": true, "observedGeneration": true, "results": true,
+		"responses": true,
+	}
+	actionStatusFields = map[string]bool{
+		"state": true, "executedAt": true, "completedAt": true, "lastExecutionTime": true,
+		"nextExecutionTime": true, "executionCount": true, "syncDurationSeconds": true,
+		"dataFromVersion": true, "httpStatusCode": true, "message": true, "conditions": true,
+		"observedGeneration": true, "result": true, "responses": true, "successCount": true,
+		"totalEndpoints": true,
+	}
+)
+
+// hasStatusField reports whether a CRD's status has a field, built in or from
+// x-k8s-status-field
+func hasStatusField(crd *CRDDefinition, jsonName string) bool {
+	switch {
+	case crd.IsQuery:
+		return queryStatusFields[jsonName]
+	case crd.IsAction:
+		return actionStatusFields[jsonName]
+	}
+	if reservedStatusFields[jsonName] {
+		return true
+	}
+	for _, f := range crd.StatusFields {
+		if f.JSONName == jsonName {
+			return true
+		}
+	}
+	return false
+}
+
+// excludeStatusFields drops the status fields named by config.ExcludeStatusFields from the
+// CRDs' status definitions, recording them in ExcludedStatusFields. A global entry applies
+// to every CRD with the field and a "Kind.field" entry to that Kind; an entry matching no
+// CRD is an error, so a typo doesn't silently keep the field.
+func excludeStatusFields(crds []*CRDDefinition, entries []string) error {
+	excluded := make(map[*CRDDefinition]map[string]bool)
+	for _, entry := range entries {
+		kind, field := config.ParseExcludedStatusField(entry)
+		matched, kindFound := false, false
+		for _, crd := range crds {
+			if kind != "" && crd.Kind != kind {
+				continue
+			}
+			kindFound = true
+			if !hasStatusField(crd, field) {
+				continue
+			}
+			if excluded[crd] == nil {
+				excluded[crd] = make(map[string]bool)
+			}
+			excluded[crd][field] = true
+			matched = true
+		}
+		switch {
+		case matched:
+		case kind != "" && !kindFound:
+			return fmt.Errorf("--exclude-status-fields %q: no CRD Kind %s", entry, kind)
+		case kind != "":
+			return fmt.Errorf("--exclude-status-fields %q: %s has no status field %s", entry, kind, field)
+		default:
+			return fmt.Errorf("--exclude-status-fields %q: no CRD has status field %s", entry, field)
+		}
+	}
+
+	for crd, fields := range excluded {
+		for field := range fields {
+			crd.ExcludedStatusFields = append(crd.ExcludedStatusFields, field)
+		}
+		sort.Strings(crd.ExcludedStatusFields)
+
+		statusFields := crd.StatusFields[:0]
+		for _, f := range crd.StatusFields {
+			if !fields[f.JSONName] {
+				statusFields = append(statusFields, f)
+			}
+		}
+		crd.StatusFields = statusFields
+		if crd.Status != nil {
+			kept := crd.Status.Fields[:0]
+			for _, f := range crd.Status.Fields {
+				if !fields[f.JSONName] {
+					kept = append(kept, f)
+				}
+			}
+			crd.Status.Fields = kept
+		}
+	}
+	return nil
+}
+
+// resolveStatusFields sets crd.StatusFields from the x-k8s-status-field extension of the
+// resource's GET operation, else its schema. Each path must lead to a string, integer,
+// number or boolean property of the GET response schema (or the resource schema when
+// GET declares none).
+func resolveStatusFields(crd *CRDDefinition, resource parser.Resource) error {
+	var fields map[string]string
+	var responseSchema *parser.Schema
+	for _, op := range resource.Operations {
+		if op.Method != "GET" {
+			continue
+		}
+		if fields == nil {
+			fields = op.StatusFields
+		}
+		if responseSchema == nil {
+			responseSchema = op.ResponseBody
+		}
+	}
+	if fields == nil && resource.Schema != nil {
+		fields = resource.Schema.StatusFields
+	}
+	if responseSchema == nil {
+		responseSchema = resource.Schema
+	}
+	if len(fields) == 0 {
+		return nil
+	}
+
+	names := make([]string, 0, len(fields))
+	for name := range fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		path := strings.TrimPrefix(strings.TrimPrefix(fields[name], "$"), ".")
+		jsonName := strcase.ToLowerCamel(name)
+		if reservedStatusFields[jsonName] {
+			return fmt.Errorf("x-k8s-status-field %q on %s: status.%s is a built-in status field", name, crd.Kind, jsonName)
+		}
+
+		schema := responseSchema
+		segments := strings.Split(path, ".")
+		for _, segment := range segments {
+			if schema == nil || schema.Properties[segment] == nil {
+				return fmt.Errorf("x-k8s-status-field %q on %s: path %q is not in the response schema", name, crd.Kind, path)
+			}
+			schema = schema.Properties[segment]
+		}
+
+		field := StatusField{
+			Name:        strcase.ToCamel(jsonName),
+			JSONName:    jsonName,
+			Path:        segments,
+			SchemaType:  schema.Type,
+			Description: schema.Description,
+		}
+		switch schema.Type {
+		case "string":
+			field.GoType = "string"
+		case "integer":
+			field.GoType = "int64"
+		case "number":
+			field.GoType = "float64"
+		case "boolean":
+			field.GoType = "bool"
+		default:
+			return fmt.Errorf("x-k8s-status-field %q on %s: %q has type %s, only strings, numbers and booleans can be status fields",
+				name, crd.Kind, path, schema.Type)
+		}
+		if field.Description == "" {
+			field.Description = fmt.Sprintf("Value of %s in the REST API response", path)
+		}
+		crd.StatusFields = append(crd.StatusFields, field)
+	}
+	return nil
+}
+
+// resolvePollCondition sets crd.PollCondition from the x-k8s-poll-condition extension of
+// the resource's GET operation, else its schema. The field must be a string, integer,
+// number or boolean property of the GET response schema (or the resource schema when GET
+// declares none), and the ready values must be among its enum values when it has any.
+// Read-only resources are never written, so they have nothing to wait for.
+func resolvePollCondition(crd *CRDDefinition, resource parser.Resource) error {
+	var condition *parser.PollCondition
+	var responseSchema *parser.Schema
+	for _, op := range resource.Operations {
+		if op.Method != "GET" {
+			continue
+		}
+		if condition == nil {
+			condition = op.PollCondition
+		}
+		if responseSchema == nil {
+			responseSchema = op.ResponseBody
+		}
+	}
+	if condition == nil && resource.Schema != nil {
+		condition = resource.Schema.PollCondition
+	}
+	if responseSchema == nil {
+		responseSchema = resource.Schema
+	}
+	if condition == nil || crd.ReadOnly {
+		return nil
+	}
+
+	path := strings.TrimPrefix(strings.TrimPrefix(condition.Field, "$"), ".")
+	if path == "" || len(condition.Values) == 0 {
+		return fmt.Errorf("x-k8s-poll-condition on %s: expected a field and its ready value, e.g. status == \"available\"", crd.Kind)
+	}
+	schema := responseSchema
+	segments := strings.Split(path, ".")
+	for _, segment := range segments {
+		if schema == nil || schema.Properties[segment] == nil {
+			return fmt.Errorf("x-k8s-poll-condition on %s: path %q is not in the response schema", crd.Kind, path)
+		}
+		schema = schema.Properties[segment]
+	}
+	switch schema.Type {
+	case "string", "integer", "number", "boolean":
+	default:
+		return fmt.Errorf("x-k8s-poll-condition on %s: %q has type %s, only strings, numbers and booleans can be compared",
+			crd.Kind, path, schema.Type)
+	}
+	if len(schema.Enum) > 0 {
+		for _, value := range condition.Values {
+			if !slices.ContainsFunc(schema.Enum, func(e interface{}) bool { return fmt.Sprint(e) == value }) {
+				return fmt.Errorf("x-k8s-poll-condition on %s: %q is not an allowed value of %s", crd.Kind, value, path)
+			}
+		}
+	}
+
+	interval := DefaultPollInterval
+	if condition.Interval != "" {
+		d, err := time.ParseDuration(condition.Interval)
+		if err != nil || d <= 0 {
+			return fmt.Errorf("x-k8s-poll-condition on %s: invalid interval %q", crd.Kind, condition.Interval)
+		}
+		interval = d
+	}
+
+	quoted := make([]string, len(condition.Values))
+	for i, value := range condition.Values {
+		quoted[i] = fmt.Sprintf("%s == %q", path, value)
+	}
+	crd.PollCondition = &PollCondition{
+		Path:       segments,
+		Values:     condition.Values,
+		Interval:   interval,
+		Expression: strings.Join(quoted, " || "),
+	}
+	return nil
+}
+
 // addOperationParamsToSpec adds path and query parameters from operations to the spec.
 // It also handles ID field merging: when a path parameter like {orderId} maps to a body field "id",
 // the path param is not added as a separate field; instead, the body field is annotated with PathParamName.
@@ -1116,11 +2166,11 @@ func (m *Mapper) addOperationParamsToSpecWithMerge(spec *FieldDefinition, operat
 	pathParamsSeen := make(map[string]bool)
 	for _, op := range sortedOps {
 		for _, param := range op.PathParams {
-			paramKey := strings.ToLower(param.Name)
-			if pathParamsSeen[paramKey] {
+			if pathParamsSeen[strings.ToLower(param.Name)] {
 				continue
 			}
-			pathParamsSeen[paramKey] = true
+			pathParamsSeen[strings.ToLower(param.Name)] = true
+			paramKey := strings.ToLower(m.config.JSONFieldName(param.Name))
 
 			// Check if this path param should be merged with an existing body field
 			// Priority: 1) x-k8s-id-field extension, 2) --id-field-map flag, 3) auto-detection
@@ -1128,10 +2178,11 @@ func (m *Mapper) addOperationParamsToSpecWithMerge(spec *FieldDefinition, operat
 
 			if bodyFieldName != "" {
 				// Check if the body field exists in the spec
-				bodyFieldKey := strings.ToLower(bodyFieldName)
+				bodyFieldKey := strings.ToLower(m.config.JSONFieldName(bodyFieldName))
 				if bodyField, ok := fieldByJSONName[bodyFieldKey]; ok {
 					// Merge: annotate the body field with the path param name
 					bodyField.PathParamName = param.Name
+					bodyField.PathStyle = scalarPathStyle(param.Style)
 					// Store the mapping in the CRD for controller use
 					if crd != nil {
 						crd.IDFieldMappings = append(crd.IDFieldMappings, IDFieldMapping{
@@ -1150,14 +2201,7 @@ func (m *Mapper) addOperationParamsToSpecWithMerge(spec *FieldDefinition, operat
 			}
 
 			// Add the path param as a new field
-			field := &FieldDefinition{
-				Name:        strcase.ToCamel(param.Name),
-				JSONName:    strcase.ToLowerCamel(param.Name),
-				GoType:      m.mapParamType(param.Type),
-				Description: param.Description,
-				Required:    param.Required,
-			}
-			spec.Fields = append(spec.Fields, field)
+			spec.Fields = append(spec.Fields, m.pathParamField(param))
 			existingFields[paramKey] = true
 		}
 	}
@@ -1166,32 +2210,34 @@ func (m *Mapper) addOperationParamsToSpecWithMerge(spec *FieldDefinition, operat
 	queryParamsSeen := make(map[string]bool)
 	for _, op := range sortedOps {
 		for _, param := range op.QueryParams {
-			paramKey := strings.ToLower(param.Name)
+			paramKey := strings.ToLower(m.config.JSONFieldName(param.Name))
 			if queryParamsSeen[paramKey] || existingFields[paramKey] {
 				continue
 			}
 			queryParamsSeen[paramKey] = true
 
-			goType := m.mapParamType(param.Type)
+			goType := m.mapParamType(param.Type, param.Format)
 			isArray := false
 
 			if strings.HasPrefix(param.Type, "array:") {
 				isArray = true
 				itemType := strings.TrimPrefix(param.Type, "array:")
-				goType = "[]" + m.mapParamType(itemType)
+				goType = "[]" + m.mapParamType(itemType, param.Format)
 			}
 
 			field := &FieldDefinition{
-				Name:        strcase.ToCamel(param.Name),
-				JSONName:    strcase.ToLowerCamel(param.Name),
-				GoType:      goType,
-				Description: param.Description,
-				Required:    param.Required,
+				Name:            strcase.ToCamel(param.Name),
+				JSONName:        m.config.JSONFieldName(param.Name),
+				GoType:          goType,
+				Description:     param.Description,
+				Required:        param.Required,
+				Deprecated:      param.Deprecated,
+				AllowEmptyValue: param.AllowEmptyValue && goType == "string",
 			}
 
 			if isArray {
 				field.ItemType = &FieldDefinition{
-					GoType: m.mapParamType(strings.TrimPrefix(param.Type, "array:")),
+					GoType: m.mapParamType(strings.TrimPrefix(param.Type, "array:"), param.Format),
 				}
 			}
 
@@ -1289,10 +2335,17 @@ func (m *Mapper) mapOperations(ops []parser.Operation) []OperationMapping {
 
 	for _, op := range ops {
 		mapping := OperationMapping{
-			HTTPMethod:  op.Method,
-			Path:        op.Path,
-			PathParams:  make([]string, 0),
-			QueryParams: make([]string, 0),
+			HTTPMethod:     op.Method,
+			Path:           op.Path,
+			OperationID:    op.OperationID,
+			PathParams:     make([]string, 0),
+			QueryParams:    make([]string, 0),
+			TargetDefault:  op.TargetDefault,
+			SuccessCodes:   m.successCodes(op.Method, op.SuccessCodes),
+			Accept:         m.accept(op.ResponseContentTypes),
+			ContentType:    op.RequestContentType,
+			Security:       m.security(op.Security),
+			RequestExample: op.RequestBodyExample,
 		}
 
 		// Collect path params first so we can use them for action classification
@@ -1334,8 +2387,9 @@ func (m *Mapper) schemaToFieldDefinition(name string, schema *parser.Schema, isR
 
 	field := &FieldDefinition{
 		Name:        strcase.ToCamel(name),
-		JSONName:    strcase.ToLowerCamel(name),
+		JSONName:    m.config.JSONFieldName(name),
 		Description: schema.Description,
+		Title:       schema.Title,
 	}
 
 	// Set required if in parent's required list (from OpenAPI spec)
@@ -1362,6 +2416,9 @@ func (m *Mapper) schemaToFieldDefinition(name string, schema *parser.Schema, isR
 			Pattern:   schema.Pattern,
 			MinItems:  schema.MinItems,
 			MaxItems:  schema.MaxItems,
+
+			ExclusiveMinimum: schema.ExclusiveMinimum,
+			ExclusiveMaximum: schema.ExclusiveMaximum,
 		}
 		for _, e := range schema.Enum {
 			if s, ok := e.(string); ok {
@@ -1370,6 +2427,20 @@ func (m *Mapper) schemaToFieldDefinition(name string, schema *parser.Schema, isR
 		}
 	}
 
+	// Translate known string formats into a Format marker or a Pattern; an explicit
+	// pattern in the spec wins
+	if f, ok := stringFormats[schema.Format]; ok && field.GoType == "string" {
+		field.Format = schema.Format
+		if field.Validation == nil {
+			field.Validation = &ValidationRules{}
+		}
+		if f.Format != "" {
+			field.Validation.Format = f.Format
+		} else if field.Validation.Pattern == "" {
+			field.Validation.Pattern = f.Pattern
+		}
+	}
+
 	// Handle nested properties
 	if schema.Type == "object" && len(schema.Properties) > 0 {
 		field.Fields = make([]*FieldDefinition, 0, len(schema.Properties))
@@ -1384,6 +2455,9 @@ func (m *Mapper) schemaToFieldDefinition(name string, schema *parser.Schema, isR
 		for _, propName := range propNames {
 			propSchema := schema.Properties[propName]
 			propField := m.schemaToFieldDefinition(propName, propSchema, false)
+			if isRoot {
+				renameSpecField(propField, propName, propSchema)
+			}
 			// Check if property is required in OpenAPI spec
 			for _, req := range schema.Required {
 				if req == propName {
@@ -1396,9 +2470,38 @@ func (m *Mapper) schemaToFieldDefinition(name string, schema *parser.Schema, isR
 		}
 	}
 
+	// The parser only keeps not schemas with constraints a CEL rule can negate
+	if schema.Not != nil {
+		field.NotValidation = m.schemaToFieldDefinition(name, schema.Not, false).Validation
+	}
+
+	// Keep the required sets of a loose anyOf, by JSON name, for its CEL rule
+	for _, required := range schema.AnyOfRequired {
+		names := make([]string, 0, len(required))
+		for _, name := range required {
+			names = append(names, m.config.JSONFieldName(name))
+		}
+		field.AnyOfRequired = append(field.AnyOfRequired, names)
+	}
+
+	// Handle maps from patternProperties
+	if strings.HasPrefix(field.GoType, "map[string]") {
+		for pattern := range schema.PatternProperties {
+			field.KeyPatterns = append(field.KeyPatterns, pattern)
+		}
+		sort.Strings(field.KeyPatterns)
+		if field.GoType == "map[string]struct" {
+			field.ItemType = m.schemaToFieldDefinition("Value", patternValueSchema(schema), false)
+		} else if value := patternValueSchema(schema); value != nil {
+			field.ValueValidation = m.schemaToFieldDefinition("Value", value, false).Validation
+		}
+	}
+
 	// Handle arrays
 	if schema.Type == "array" && schema.Items != nil {
 		field.ItemType = m.schemaToFieldDefinition("Item", schema.Items, false)
+		// Transition rules are not allowed inside list items (no oldSelf to correlate with)
+		clearImmutable(field.ItemType)
 	}
 
 	// Handle enums
@@ -1416,9 +2519,171 @@ func (m *Mapper) schemaToFieldDefinition(name string, schema *parser.Schema, isR
 		field.Example = schema.Example
 	}
 
+	field.Immutable = schema.Immutable
+	field.WriteOnly = schema.WriteOnly
+	field.Nullable = schema.Nullable
+
 	return field
 }
 
+// renameSpecField applies the x-k8s-spec-field extension of a top-level request body
+// property: the field takes the extension's name in the CR spec and remembers the
+// property name it is sent as
+func renameSpecField(field *FieldDefinition, propName string, schema *parser.Schema) {
+	if schema == nil || schema.SpecField == "" {
+		return
+	}
+	field.APIName = propName
+	field.Name = strcase.ToCamel(schema.SpecField)
+	field.JSONName = strcase.ToLowerCamel(schema.SpecField)
+}
+
+// operatorSpecFields are the spec fields the generated CRDs add for the operator itself;
+// an x-k8s-spec-field rename must not take one of their names
+var operatorSpecFields = map[string]bool{
+	"target": true, "externalIDRef": true, "readOnly": true, "mergeOnUpdate": true,
+	"paused": true, "executionInterval": true, "onDelete": true, "extraHeaders": true,
+}
+
+// checkSpecFieldRenames rejects x-k8s-spec-field renames that collide with another spec
+// field or an operator-owned one
+func checkSpecFieldRenames(crds []*CRDDefinition) error {
+	for _, crd := range crds {
+		if crd.Spec == nil {
+			continue
+		}
+		seen := make(map[string]string, len(crd.Spec.Fields))
+		for _, field := range crd.Spec.Fields {
+			if field.APIName == "" {
+				continue
+			}
+			if operatorSpecFields[field.JSONName] {
+				return fmt.Errorf("%s: x-k8s-spec-field renames %q to spec.%s, which the operator uses", crd.Kind, field.APIName, field.JSONName)
+			}
+			seen[field.JSONName] = field.APIName
+		}
+		for _, field := range crd.Spec.Fields {
+			if apiName, ok := seen[field.JSONName]; ok && field.APIName != apiName {
+				return fmt.Errorf("%s: x-k8s-spec-field renames %q to spec.%s, which another field already uses", crd.Kind, apiName, field.JSONName)
+			}
+		}
+	}
+	return nil
+}
+
+// resolveOwners checks the owners set with x-k8s-owner and, with DeriveOwners, makes the
+// closest resource a resource's path is nested under its owner. The owner's ID is the
+// child's path parameter in the place of the owner's last one, e.g. orderId for
+// /orders/{orderId}/items/{itemId} under /orders/{orderId}. A cluster-scoped resource
+// can't reference a namespaced owner, so it is never given one.
+func (m *Mapper) resolveOwners(crds []*CRDDefinition) error {
+	var resources []*CRDDefinition
+	for _, crd := range crds {
+		if !crd.IsQuery && !crd.IsAction {
+			resources = append(resources, crd)
+		}
+	}
+
+	for _, crd := range resources {
+		var owner *CRDDefinition
+		idParam := ""
+		if crd.Owner != nil {
+			for _, r := range resources {
+				if r.Kind == crd.Owner.Kind && r != crd {
+					owner = r
+				}
+			}
+			if owner == nil {
+				return fmt.Errorf("%s: x-k8s-owner %q is not another resource Kind", crd.Kind, crd.Owner.Kind)
+			}
+			if idParam = ownerIDParam(owner.ResourcePath, crd.ResourcePath); idParam == "" {
+				return fmt.Errorf("%s: x-k8s-owner %q: %s isn't nested under %s, so the owner's ID is unknown",
+					crd.Kind, crd.Owner.Kind, crd.ResourcePath, owner.ResourcePath)
+			}
+			if !canOwn(owner, crd) {
+				return fmt.Errorf("%s: x-k8s-owner %q: a cluster-scoped resource can't be owned by a namespaced one",
+					crd.Kind, crd.Owner.Kind)
+			}
+		} else if m.config.DeriveOwners {
+			depth := 0
+			for _, r := range resources {
+				if r == crd || !canOwn(r, crd) {
+					continue
+				}
+				param := ownerIDParam(r.ResourcePath, crd.ResourcePath)
+				if n := strings.Count(r.ResourcePath, "/"); param != "" && n > depth {
+					owner, idParam, depth = r, param, n
+				}
+			}
+		}
+		if owner == nil {
+			continue
+		}
+
+		crd.Owner = &OwnerDefinition{
+			Kind:          owner.Kind,
+			Plural:        owner.Plural,
+			IDParam:       idParam,
+			HasExternalID: owner.HasPost,
+		}
+		// The owner's ID is its last path parameter, or the body field it is merged into
+		target := strings.Trim(owner.ResourcePath[strings.LastIndex(owner.ResourcePath, "/")+1:], "{}")
+		for _, mapping := range owner.IDFieldMappings {
+			if mapping.PathParam == target {
+				target = mapping.BodyField
+			}
+		}
+		if owner.Spec != nil {
+			for _, field := range owner.Spec.Fields {
+				if strings.EqualFold(field.JSONName, m.config.JSONFieldName(target)) && field.ItemType == nil {
+					crd.Owner.IDField = field.Name
+					break
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// canOwn reports whether owner's CRs can be set as the owner of child's: a namespaced
+// owner reference from a cluster-scoped object is invalid
+func canOwn(owner, child *CRDDefinition) bool {
+	return child.Scope != "Cluster" || owner.Scope == "Cluster"
+}
+
+// ownerIDParam returns the path parameter of childPath holding the ID of the resource at
+// ownerPath, or "" when childPath isn't nested under ownerPath or ownerPath has no ID
+// parameter. Parameter names may differ: /orders/{id} owns /orders/{orderId}/items.
+func ownerIDParam(ownerPath, childPath string) string {
+	owner := strings.Split(strings.Trim(ownerPath, "/"), "/")
+	child := strings.Split(strings.Trim(childPath, "/"), "/")
+	if len(owner) >= len(child) {
+		return ""
+	}
+	for i, segment := range owner {
+		isParam := strings.HasPrefix(segment, "{")
+		if isParam != strings.HasPrefix(child[i], "{") || (!isParam && segment != child[i]) {
+			return ""
+		}
+	}
+	if last := child[len(owner)-1]; strings.HasPrefix(last, "{") {
+		return strings.Trim(last, "{}")
+	}
+	return ""
+}
+
+// clearImmutable removes the immutable flag from a field and everything nested under it
+func clearImmutable(field *FieldDefinition) {
+	if field == nil {
+		return
+	}
+	field.Immutable = false
+	for _, f := range field.Fields {
+		clearImmutable(f)
+	}
+	clearImmutable(field.ItemType)
+}
+
 func (m *Mapper) mapType(schema *parser.Schema) string {
 	switch schema.Type {
 	case "string":
@@ -1458,6 +2723,10 @@ func (m *Mapper) mapType(schema *parser.Schema) string {
 		// Arrays without item type use RawExtension for arbitrary JSON
 		return "[]runtime.RawExtension"
 	case "object":
+		if len(schema.Properties) == 0 && len(schema.PatternProperties) > 0 {
+			// Objects keyed by patternProperties become typed maps
+			return "map[string]" + m.mapPatternValueType(schema)
+		}
 		if len(schema.Properties) == 0 {
 			// Objects without properties use RawExtension for arbitrary JSON
 			// This is compatible with controller-gen (interface{} is not)
@@ -1470,6 +2739,44 @@ func (m *Mapper) mapType(schema *parser.Schema) string {
 	}
 }
 
+// mapPatternValueType returns the Go type of the values of a patternProperties map. When the
+// patterns disagree on the value type, values fall back to RawExtension.
+func (m *Mapper) mapPatternValueType(schema *parser.Schema) string {
+	value := patternValueSchema(schema)
+	if value == nil {
+		return "runtime.RawExtension"
+	}
+	return strings.TrimPrefix(m.mapType(value), "*")
+}
+
+// patternValueSchema returns the value schema shared by all patternProperties of a schema,
+// or nil when there are several patterns and they don't map to the same scalar Go type
+func patternValueSchema(schema *parser.Schema) *parser.Schema {
+	patterns := make([]string, 0, len(schema.PatternProperties))
+	for pattern := range schema.PatternProperties {
+		patterns = append(patterns, pattern)
+	}
+	sort.Strings(patterns)
+
+	var value *parser.Schema
+	for _, pattern := range patterns {
+		candidate := schema.PatternProperties[pattern]
+		if candidate == nil {
+			return nil
+		}
+		if value == nil {
+			value = candidate
+			continue
+		}
+		// Structs and arrays from different patterns can't be merged into one value type
+		if candidate.Type == "object" || candidate.Type == "array" ||
+			candidate.Type != value.Type || candidate.Format != value.Format {
+			return nil
+		}
+	}
+	return value
+}
+
 func (m *Mapper) createGenericSpec() *FieldDefinition {
 	return &FieldDefinition{
 		Name:     "Spec",
@@ -1497,7 +2804,8 @@ func (m *Mapper) createStatusDefinition() *FieldDefinition {
 				Name:        "State",
 				JSONName:    "state",
 				GoType:      "string",
-				Description: "Current state of the resource (Pending, Synced, Failed)",
+				Description: "Current state of the resource",
+				Enum:        ResourceStates,
 			},
 			{
 				Name:        "LastSyncTime",
@@ -1511,6 +2819,12 @@ func (m *Mapper) createStatusDefinition() *FieldDefinition {
 				GoType:      "string",
 				Description: "ID of the resource in the external REST API",
 			},
+			{
+				Name:        "ExternalResourceURL",
+				JSONName:    "externalResourceURL",
+				GoType:      "string",
+				Description: "Direct link to the resource in the external REST API",
+			},
 			{
 				Name:        "Message",
 				JSONName:    "message",
@@ -1570,6 +2884,8 @@ type AggregateDefinition struct {
 	ActionKinds []string
 	// AllKinds is the combined list of all kinds (for iteration convenience)
 	AllKinds []string
+	// ResourceNames maps Kinds with an explicit plural to it (see CustomPlurals)
+	ResourceNames map[string]string
 }
 
 // ResourceSelector defines how to select resources to aggregate
@@ -1639,7 +2955,24 @@ func (m *Mapper) CreateAggregateDefinition(crds []*CRDDefinition) *AggregateDefi
 		QueryKinds:    queryKinds,
 		ActionKinds:   actionKinds,
 		AllKinds:      allKinds,
+		ResourceNames: CustomPlurals(crds),
+	}
+}
+
+// CustomPlurals returns the plurals of the CRDs whose plural was set explicitly, keyed by
+// Kind, or nil when every plural follows the heuristic
+func CustomPlurals(crds []*CRDDefinition) map[string]string {
+	var plurals map[string]string
+	for _, crd := range crds {
+		if !crd.CustomPlural {
+			continue
+		}
+		if plurals == nil {
+			plurals = make(map[string]string)
+		}
+		plurals[crd.Kind] = crd.Plural
 	}
+	return plurals
 }
 
 // BundleDefinition represents an Inline Composition CRD definition (Option 2)
@@ -1657,6 +2990,8 @@ type BundleDefinition struct {
 	ActionKinds []string
 	// AllKinds is the combined list of all kinds (for iteration convenience)
 	AllKinds []string
+	// ResourceNames maps Kinds with an explicit plural to it (see CustomPlurals)
+	ResourceNames map[string]string
 }
 
 // CreateBundleDefinition creates a bundle CRD definition from existing CRDs
@@ -1692,5 +3027,6 @@ func (m *Mapper) CreateBundleDefinition(crds []*CRDDefinition) *BundleDefinition
 		QueryKinds:    queryKinds,
 		ActionKinds:   actionKinds,
 		AllKinds:      allKinds,
+		ResourceNames: CustomPlurals(crds),
 	}
 }
@@ -1,7 +1,11 @@
 package mapper
 
 import (
+	"reflect"
+	"regexp"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/bluecontainer/openapi-operator-gen/internal/config"
 	"github.com/bluecontainer/openapi-operator-gen/pkg/parser"
@@ -389,6 +393,44 @@ func TestSchemaToFieldDefinition_Description(t *testing.T) {
 	}
 }
 
+func TestSchemaToFieldDefinition_Nullable(t *testing.T) {
+	m := &Mapper{config: &config.Config{}}
+	result := m.schemaToFieldDefinition("nickname", &parser.Schema{Type: "string", Nullable: true}, false)
+	if result.GoType != "string" || !result.Nullable {
+		t.Errorf("expected nullable string field, got GoType %q nullable %v", result.GoType, result.Nullable)
+	}
+}
+
+func TestPathParamField_Array(t *testing.T) {
+	m := &Mapper{config: &config.Config{}}
+	field := m.pathParamField(parser.Parameter{Name: "ids", In: "path", Type: "array:integer", Required: true, Style: "simple"})
+	if field.GoType != "[]int64" || field.ItemType == nil || field.ItemType.GoType != "int64" {
+		t.Errorf("expected []int64 field with int64 items, got GoType %q", field.GoType)
+	}
+	if field.PathStyle != "simple" || field.PathExplode {
+		t.Errorf("expected simple non-exploded serialization, got %q explode %v", field.PathStyle, field.PathExplode)
+	}
+
+	params := m.mapQueryPathParams([]parser.Parameter{{Name: "tags", In: "path", Type: "array:string", Style: "label", Explode: true}})
+	if len(params) != 1 || !params[0].IsArray || params[0].GoType != "[]string" || params[0].Style != "label" || !params[0].Explode {
+		t.Errorf("expected exploded label []string query path param, got %+v", params)
+	}
+}
+
+func TestPathParamField_ScalarStyle(t *testing.T) {
+	m := &Mapper{config: &config.Config{}}
+	for style, want := range map[string]string{"simple": "", "label": "label", "matrix": "matrix"} {
+		field := m.pathParamField(parser.Parameter{Name: "petId", In: "path", Type: "integer", Required: true, Style: style})
+		if field.PathStyle != want || field.ItemType != nil {
+			t.Errorf("%s: expected scalar path style %q, got %q", style, want, field.PathStyle)
+		}
+		params := m.mapQueryPathParams([]parser.Parameter{{Name: "petId", In: "path", Type: "integer", Required: true, Style: style}})
+		if len(params) != 1 || params[0].IsArray || params[0].Style != want {
+			t.Errorf("%s: expected scalar query path param style %q, got %+v", style, want, params)
+		}
+	}
+}
+
 func TestSchemaToFieldDefinition_ValidationRules(t *testing.T) {
 	m := &Mapper{config: &config.Config{}}
 
@@ -438,6 +480,99 @@ func TestSchemaToFieldDefinition_ValidationRules(t *testing.T) {
 	}
 }
 
+func TestSchemaToFieldDefinition_StringFormats(t *testing.T) {
+	m := &Mapper{config: &config.Config{}}
+
+	tests := []struct {
+		name        string
+		schema      *parser.Schema
+		wantFormat  string
+		wantPattern string
+		wantNil     bool
+	}{
+		{name: "native format", schema: &parser.Schema{Type: "string", Format: "email"}, wantFormat: "email"},
+		{name: "pattern format", schema: &parser.Schema{Type: "string", Format: "url"}, wantPattern: stringFormats["url"].Pattern},
+		{name: "explicit pattern wins", schema: &parser.Schema{Type: "string", Format: "url", Pattern: "^https://"}, wantPattern: "^https://"},
+		{name: "unknown format", schema: &parser.Schema{Type: "string", Format: "x-custom"}, wantNil: true},
+		{name: "non-string", schema: &parser.Schema{Type: "integer", Format: "uuid"}, wantNil: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := m.schemaToFieldDefinition("test", tt.schema, false)
+			if tt.wantNil {
+				if result.Validation != nil || result.Format != "" {
+					t.Errorf("expected no format validation, got %+v (format %q)", result.Validation, result.Format)
+				}
+				return
+			}
+			if result.Validation == nil {
+				t.Fatal("expected validation rules to be set")
+			}
+			if result.Validation.Format != tt.wantFormat {
+				t.Errorf("Format = %q, want %q", result.Validation.Format, tt.wantFormat)
+			}
+			if result.Validation.Pattern != tt.wantPattern {
+				t.Errorf("Pattern = %q, want %q", result.Validation.Pattern, tt.wantPattern)
+			}
+			if result.Format != tt.schema.Format {
+				t.Errorf("field Format = %q, want %q", result.Format, tt.schema.Format)
+			}
+		})
+	}
+}
+
+func TestFormatExample(t *testing.T) {
+	for format, f := range stringFormats {
+		example, ok := FormatExample(format)
+		if !ok || example == "" {
+			t.Errorf("FormatExample(%q) = %q, %v; want an example", format, example, ok)
+		}
+		if f.Pattern != "" && !regexp.MustCompile(f.Pattern).MatchString(example) {
+			t.Errorf("example %q for %q doesn't match its pattern %q", example, format, f.Pattern)
+		}
+	}
+	if _, ok := FormatExample("x-custom"); ok {
+		t.Error("FormatExample should not know x-custom")
+	}
+}
+
+func TestSchemaToFieldDefinition_ExclusiveBounds(t *testing.T) {
+	m := &Mapper{config: &config.Config{}}
+
+	min := float64(0)
+	max := float64(1)
+
+	inclusive := m.schemaToFieldDefinition("ratio", &parser.Schema{
+		Type:    "number",
+		Minimum: &min,
+		Maximum: &max,
+	}, false)
+	if inclusive.Validation == nil {
+		t.Fatal("expected validation rules to be set")
+	}
+	if inclusive.Validation.ExclusiveMinimum || inclusive.Validation.ExclusiveMaximum {
+		t.Error("expected inclusive bounds not to be exclusive")
+	}
+
+	exclusive := m.schemaToFieldDefinition("ratio", &parser.Schema{
+		Type:             "number",
+		Minimum:          &min,
+		Maximum:          &max,
+		ExclusiveMinimum: true,
+		ExclusiveMaximum: true,
+	}, false)
+	if exclusive.Validation == nil {
+		t.Fatal("expected validation rules to be set")
+	}
+	if !exclusive.Validation.ExclusiveMinimum {
+		t.Error("expected ExclusiveMinimum to be true")
+	}
+	if !exclusive.Validation.ExclusiveMaximum {
+		t.Error("expected ExclusiveMaximum to be true")
+	}
+}
+
 func TestSchemaToFieldDefinition_NoValidation(t *testing.T) {
 	m := &Mapper{config: &config.Config{}}
 	schema := &parser.Schema{Type: "string"}
@@ -547,6 +682,179 @@ func TestSchemaToFieldDefinition_ArrayItems(t *testing.T) {
 	}
 }
 
+func TestSchemaToFieldDefinition_Immutable(t *testing.T) {
+	m := &Mapper{config: &config.Config{}}
+	schema := &parser.Schema{
+		Type: "object",
+		Properties: map[string]*parser.Schema{
+			"region": {Type: "string", Immutable: true},
+			"tags": {
+				Type: "array",
+				Items: &parser.Schema{
+					Type: "object",
+					Properties: map[string]*parser.Schema{
+						"key": {Type: "string", Immutable: true},
+					},
+				},
+			},
+		},
+	}
+
+	result := m.schemaToFieldDefinition("spec", schema, true)
+
+	fields := make(map[string]*FieldDefinition)
+	for _, f := range result.Fields {
+		fields[f.JSONName] = f
+	}
+	if !fields["region"].Immutable {
+		t.Error("expected region to be immutable")
+	}
+	// Transition rules cannot be used inside list items
+	if fields["tags"].ItemType.Fields[0].Immutable {
+		t.Error("expected immutable flag to be dropped inside array items")
+	}
+}
+
+func TestSchemaToFieldDefinition_PatternProperties(t *testing.T) {
+	m := &Mapper{config: &config.Config{}}
+	schema := &parser.Schema{
+		Type: "object",
+		Properties: map[string]*parser.Schema{
+			"labels": {
+				Type: "object",
+				PatternProperties: map[string]*parser.Schema{
+					"^[a-z]+$": {Type: "string"},
+				},
+			},
+			"limits": {
+				Type: "object",
+				PatternProperties: map[string]*parser.Schema{
+					"^x-": {
+						Type: "object",
+						Properties: map[string]*parser.Schema{
+							"max": {Type: "integer"},
+						},
+					},
+				},
+			},
+			"mixed": {
+				Type: "object",
+				PatternProperties: map[string]*parser.Schema{
+					"^s_": {Type: "string"},
+					"^n_": {Type: "integer"},
+				},
+			},
+		},
+	}
+
+	result := m.schemaToFieldDefinition("spec", schema, true)
+
+	fields := make(map[string]*FieldDefinition)
+	for _, f := range result.Fields {
+		fields[f.JSONName] = f
+	}
+
+	if got := fields["labels"].GoType; got != "map[string]string" {
+		t.Errorf("labels: expected map[string]string, got %s", got)
+	}
+	if got := fields["labels"].KeyPatterns; len(got) != 1 || got[0] != "^[a-z]+$" {
+		t.Errorf("labels: unexpected key patterns %v", got)
+	}
+
+	limits := fields["limits"]
+	if limits.GoType != "map[string]struct" {
+		t.Errorf("limits: expected map[string]struct, got %s", limits.GoType)
+	}
+	if limits.ItemType == nil || len(limits.ItemType.Fields) != 1 || limits.ItemType.Fields[0].JSONName != "max" {
+		t.Errorf("limits: expected value fields [max], got %+v", limits.ItemType)
+	}
+
+	// Patterns with different value types fall back to RawExtension values
+	mixed := fields["mixed"]
+	if mixed.GoType != "map[string]runtime.RawExtension" {
+		t.Errorf("mixed: expected map[string]runtime.RawExtension, got %s", mixed.GoType)
+	}
+	if strings.Join(mixed.KeyPatterns, ",") != "^n_,^s_" {
+		t.Errorf("mixed: expected sorted key patterns, got %v", mixed.KeyPatterns)
+	}
+}
+
+func TestSchemaToFieldDefinition_ItemAndValueValidation(t *testing.T) {
+	m := &Mapper{config: &config.Config{}}
+	maxLength := int64(20)
+	maximum := 100.0
+	schema := &parser.Schema{
+		Type: "object",
+		Properties: map[string]*parser.Schema{
+			"nicknames": {
+				Type:  "array",
+				Items: &parser.Schema{Type: "string", MaxLength: &maxLength, Pattern: "^[a-z]+$"},
+			},
+			"scores": {
+				Type: "object",
+				PatternProperties: map[string]*parser.Schema{
+					"^s": {Type: "integer", Maximum: &maximum},
+				},
+			},
+			"mixed": {
+				Type: "object",
+				PatternProperties: map[string]*parser.Schema{
+					"^s_": {Type: "string", MaxLength: &maxLength},
+					"^n_": {Type: "integer"},
+				},
+			},
+		},
+	}
+
+	result := m.schemaToFieldDefinition("spec", schema, true)
+	fields := make(map[string]*FieldDefinition)
+	for _, f := range result.Fields {
+		fields[f.JSONName] = f
+	}
+
+	items := fields["nicknames"].ItemType.Validation
+	if items == nil || items.MaxLength == nil || *items.MaxLength != 20 || items.Pattern != "^[a-z]+$" {
+		t.Errorf("nicknames: expected item maxLength 20 and pattern, got %+v", items)
+	}
+	values := fields["scores"].ValueValidation
+	if values == nil || values.Maximum == nil || *values.Maximum != 100 {
+		t.Errorf("scores: expected value maximum 100, got %+v", values)
+	}
+	// Values of disagreeing patterns are RawExtensions without constraints
+	if fields["mixed"].ValueValidation != nil {
+		t.Errorf("mixed: expected no value validation, got %+v", fields["mixed"].ValueValidation)
+	}
+}
+
+func TestSchemaToFieldDefinition_Not(t *testing.T) {
+	m := &Mapper{config: &config.Config{}}
+	minimum := 100.0
+	schema := &parser.Schema{
+		Type: "object",
+		Properties: map[string]*parser.Schema{
+			"username": {Type: "string", Pattern: "^[a-z]+$", Not: &parser.Schema{Type: "string", Pattern: "^admin"}},
+			"quota":    {Type: "integer", Not: &parser.Schema{Type: "integer", Minimum: &minimum}},
+			"name":     {Type: "string"},
+		},
+	}
+
+	result := m.schemaToFieldDefinition("spec", schema, true)
+	fields := make(map[string]*FieldDefinition)
+	for _, f := range result.Fields {
+		fields[f.JSONName] = f
+	}
+
+	if v := fields["username"]; v.NotValidation == nil || v.NotValidation.Pattern != "^admin" || v.Validation.Pattern != "^[a-z]+$" {
+		t.Errorf("username: expected the not pattern apart from the field's own, got %+v, %+v", v.NotValidation, v.Validation)
+	}
+	if v := fields["quota"].NotValidation; v == nil || v.Minimum == nil || *v.Minimum != 100 {
+		t.Errorf("quota: expected a not minimum of 100, got %+v", v)
+	}
+	if v := fields["name"].NotValidation; v != nil {
+		t.Errorf("name: expected no not validation, got %+v", v)
+	}
+}
+
 // =============================================================================
 // generateShortNames Tests
 // =============================================================================
@@ -582,78 +890,1209 @@ func TestGenerateShortNames(t *testing.T) {
 	}
 }
 
-// =============================================================================
-// createGenericSpec Tests
-// =============================================================================
+// =============================================================================
+// createGenericSpec Tests
+// =============================================================================
+
+func TestCreateGenericSpec(t *testing.T) {
+	m := &Mapper{config: &config.Config{}}
+	result := m.createGenericSpec()
+
+	if result.Name != "Spec" {
+		t.Errorf("expected Name 'Spec', got %q", result.Name)
+	}
+	if result.JSONName != "spec" {
+		t.Errorf("expected JSONName 'spec', got %q", result.JSONName)
+	}
+	if result.GoType != "struct" {
+		t.Errorf("expected GoType 'struct', got %q", result.GoType)
+	}
+	if len(result.Fields) != 1 {
+		t.Fatalf("expected 1 field, got %d", len(result.Fields))
+	}
+	if result.Fields[0].Name != "Data" {
+		t.Errorf("expected field name 'Data', got %q", result.Fields[0].Name)
+	}
+	if result.Fields[0].GoType != "runtime.RawExtension" {
+		t.Errorf("expected GoType 'runtime.RawExtension', got %q", result.Fields[0].GoType)
+	}
+}
+
+// =============================================================================
+// createStatusDefinition Tests
+// =============================================================================
+
+func TestCreateStatusDefinition(t *testing.T) {
+	m := &Mapper{config: &config.Config{}}
+	result := m.createStatusDefinition()
+
+	if result.Name != "Status" {
+		t.Errorf("expected Name 'Status', got %q", result.Name)
+	}
+	if result.JSONName != "status" {
+		t.Errorf("expected JSONName 'status', got %q", result.JSONName)
+	}
+
+	expectedFields := map[string]string{
+		"State":               "string",
+		"LastSyncTime":        "metav1.Time",
+		"ExternalID":          "string",
+		"ExternalResourceURL": "string",
+		"Message":             "string",
+		"Conditions":          "[]metav1.Condition",
+		"ObservedGeneration":  "int64",
+		"Response":            "runtime.RawExtension",
+	}
+
+	if len(result.Fields) != len(expectedFields) {
+		t.Fatalf("expected %d fields, got %d", len(expectedFields), len(result.Fields))
+	}
+
+	for _, f := range result.Fields {
+		expected, ok := expectedFields[f.Name]
+		if !ok {
+			t.Errorf("unexpected field %q", f.Name)
+			continue
+		}
+		if f.GoType != expected {
+			t.Errorf("expected %s.GoType to be %q, got %q", f.Name, expected, f.GoType)
+		}
+		if f.Name == "State" && !reflect.DeepEqual(f.Enum, ResourceStates) {
+			t.Errorf("expected State enum %v, got %v", ResourceStates, f.Enum)
+		}
+	}
+}
+
+// =============================================================================
+// MapResources Integration Tests
+// =============================================================================
+
+func TestMapResources_OptionalRequestBody(t *testing.T) {
+	cfg := &config.Config{
+		APIGroup:    "test.example.com",
+		APIVersion:  "v1alpha1",
+		MappingMode: config.PerResource,
+	}
+	m := NewMapper(cfg)
+
+	widgetSchema := &parser.Schema{
+		Type:     "object",
+		Required: []string{"name"},
+		Properties: map[string]*parser.Schema{
+			"name": {Type: "string"},
+			"size": {Type: "integer"},
+		},
+	}
+
+	spec := &parser.ParsedSpec{
+		Resources: []*parser.Resource{
+			{
+				Name:           "Widget",
+				PluralName:     "Widgets",
+				Path:           "/widgets",
+				Schema:         widgetSchema,
+				SchemaOptional: true,
+				Operations: []parser.Operation{
+					{Method: "POST", Path: "/widgets", RequestBodyOptional: true},
+				},
+			},
+			{
+				Name:       "Gadget",
+				PluralName: "Gadgets",
+				Path:       "/gadgets",
+				Schema:     widgetSchema,
+				Operations: []parser.Operation{
+					{Method: "POST", Path: "/gadgets"},
+				},
+			},
+		},
+		ActionEndpoints: []*parser.ActionEndpoint{
+			{
+				Name:                "WidgetReset",
+				Path:                "/widgets/reset",
+				ActionName:          "reset",
+				HTTPMethod:          "POST",
+				RequestSchema:       widgetSchema,
+				RequestBodyOptional: true,
+			},
+		},
+	}
+
+	crds, err := m.MapResources(spec)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	nameRequired := func(crd *CRDDefinition) bool {
+		for _, f := range crd.Spec.Fields {
+			if f.JSONName == "name" {
+				return f.Required || f.OpenAPIRequired
+			}
+		}
+		t.Fatalf("%s: name field not found", crd.Kind)
+		return false
+	}
+
+	for _, crd := range crds {
+		switch crd.Kind {
+		case "Widget", "WidgetReset":
+			if nameRequired(crd) {
+				t.Errorf("%s: expected name to be optional for an optional request body", crd.Kind)
+			}
+			if len(crd.CELValidationRules) != 0 {
+				t.Errorf("%s: expected no CEL rules, got %d", crd.Kind, len(crd.CELValidationRules))
+			}
+		case "Gadget":
+			if !nameRequired(crd) {
+				t.Error("Gadget: expected name to stay required")
+			}
+		}
+	}
+}
+
+func TestMapResources_MultipartAction(t *testing.T) {
+	cfg := &config.Config{
+		APIGroup:    "test.example.com",
+		APIVersion:  "v1alpha1",
+		MappingMode: config.PerResource,
+	}
+	m := NewMapper(cfg)
+	spec := &parser.ParsedSpec{
+		ActionEndpoints: []*parser.ActionEndpoint{
+			{
+				Name:       "DocumentUpload",
+				Path:       "/documents/upload",
+				ActionName: "upload",
+				HTTPMethod: "POST",
+				RequestSchema: &parser.Schema{
+					Type: "object",
+					Properties: map[string]*parser.Schema{
+						"description": {Type: "string"},
+						"file":        {Type: "string", Format: "binary"},
+					},
+				},
+				HasBinaryBody:     true,
+				BinaryContentType: "multipart/form-data",
+				FormFields:        []parser.FormField{{Name: "description", Type: "string"}},
+				FileField:         "file",
+			},
+		},
+	}
+
+	crds, err := m.MapResources(spec)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(crds) != 1 {
+		t.Fatalf("expected 1 CRD, got %d", len(crds))
+	}
+
+	crd := crds[0]
+	if !crd.IsMultipart || crd.FileField != "file" || len(crd.FormFields) != 1 || crd.FormFields[0] != "description" {
+		t.Errorf("expected multipart with file 'file' and form field 'description', got %v %q %v", crd.IsMultipart, crd.FileField, crd.FormFields)
+	}
+
+	fields := make(map[string]bool)
+	for _, f := range crd.Spec.Fields {
+		fields[f.JSONName] = true
+	}
+	if !fields["description"] {
+		t.Error("expected the text part to be a spec field")
+	}
+	if fields["file"] {
+		t.Error("expected the file part to come from the binary data fields, not a spec field")
+	}
+	for _, name := range []string{"data", "dataFrom", "dataURL", "dataFromFile"} {
+		if !fields[name] {
+			t.Errorf("expected binary source field %q", name)
+		}
+	}
+}
+
+func TestMapResources_Int32PathParams(t *testing.T) {
+	widgetID := parser.Parameter{Name: "widgetId", In: "path", Type: "integer", Format: "int32", Required: true, IDFieldRef: "id"}
+	spec := &parser.ParsedSpec{
+		Resources: []*parser.Resource{
+			{
+				Name:       "Widget",
+				PluralName: "Widgets",
+				Path:       "/widgets",
+				Schema: &parser.Schema{
+					Type: "object",
+					Properties: map[string]*parser.Schema{
+						"id":   {Type: "integer", Format: "int32"},
+						"name": {Type: "string"},
+					},
+				},
+				Operations: []parser.Operation{
+					{Method: "GET", Path: "/widgets/{widgetId}", PathParams: []parser.Parameter{widgetID}},
+					{Method: "PUT", Path: "/widgets/{widgetId}", PathParams: []parser.Parameter{widgetID}},
+				},
+			},
+		},
+		ActionEndpoints: []*parser.ActionEndpoint{
+			{
+				Name:           "WidgetRestartAction",
+				Path:           "/widgets/{widgetId}/restart",
+				ParentResource: "Widget",
+				ParentIDParam:  "widgetId",
+				ParentIDType:   "integer",
+				ParentIDFormat: "int32",
+				ActionName:     "restart",
+				HTTPMethod:     "POST",
+			},
+		},
+	}
+	cfg := &config.Config{APIGroup: "test.example.com", APIVersion: "v1alpha1", MappingMode: config.PerResource}
+	crds, err := NewMapper(cfg).MapResources(spec)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(crds) != 2 {
+		t.Fatalf("expected 2 CRDs, got %d", len(crds))
+	}
+
+	for _, crd := range crds {
+		fields := make(map[string]string)
+		for _, f := range crd.Spec.Fields {
+			fields[f.JSONName] = f.GoType
+		}
+		switch crd.Kind {
+		case "Widget":
+			if len(crd.IDFieldMappings) != 1 || crd.IDFieldMappings[0] != (IDFieldMapping{PathParam: "widgetId", BodyField: "id"}) {
+				t.Errorf("expected widgetId to be merged into id, got %+v", crd.IDFieldMappings)
+			}
+			if fields["id"] != "int32" {
+				t.Errorf("expected the merged id field to be int32, got %q", fields["id"])
+			}
+			if _, ok := fields["widgetId"]; ok {
+				t.Error("expected no separate widgetId field")
+			}
+		case "WidgetRestartAction":
+			if crd.ParentIDGoType != "int32" || fields["widgetId"] != "int32" {
+				t.Errorf("expected an int32 parent ID like the Widget id, got %q (spec field %q)", crd.ParentIDGoType, fields["widgetId"])
+			}
+		default:
+			t.Errorf("unexpected CRD %s", crd.Kind)
+		}
+	}
+
+	m := &Mapper{config: cfg}
+	if got := m.pathParamField(widgetID).GoType; got != "int32" {
+		t.Errorf("expected an int32 path param field, got %q", got)
+	}
+	if got := m.pathParamField(parser.Parameter{Name: "widgetId", In: "path", Type: "integer"}).GoType; got != "int64" {
+		t.Errorf("expected an integer path param without a format to stay int64, got %q", got)
+	}
+}
+
+func TestMapResources_FieldNameCase(t *testing.T) {
+	spec := &parser.ParsedSpec{
+		Resources: []*parser.Resource{
+			{
+				Name:       "Widget",
+				PluralName: "Widgets",
+				Path:       "/widgets",
+				Schema: &parser.Schema{
+					Type: "object",
+					Properties: map[string]*parser.Schema{
+						"widgetName": {Type: "string"},
+						"max_size":   {Type: "integer"},
+					},
+				},
+				Operations: []parser.Operation{
+					{Method: "POST", Path: "/widgets", QueryParams: []parser.Parameter{{Name: "dryRun", In: "query", Type: "boolean"}}},
+					{Method: "GET", Path: "/widgets/{widget_id}", PathParams: []parser.Parameter{{Name: "widget_id", In: "path", Type: "string", Required: true}}},
+				},
+			},
+		},
+	}
+
+	tests := []struct {
+		fieldNameCase config.FieldNameCase
+		want          []string
+	}{
+		{config.FieldNameCaseCamel, []string{"widgetName", "maxSize", "dryRun", "widgetId"}},
+		{config.FieldNameCaseOriginal, []string{"widgetName", "max_size", "dryRun", "widget_id"}},
+		{config.FieldNameCaseSnake, []string{"widget_name", "max_size", "dry_run", "widget_id"}},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.fieldNameCase), func(t *testing.T) {
+			cfg := &config.Config{APIGroup: "test.example.com", APIVersion: "v1alpha1", MappingMode: config.PerResource, FieldNameCase: tt.fieldNameCase}
+			crds, err := NewMapper(cfg).MapResources(spec)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			fields := make(map[string]bool)
+			for _, f := range crds[0].Spec.Fields {
+				fields[f.JSONName] = true
+			}
+			for _, name := range tt.want {
+				if !fields[name] {
+					t.Errorf("expected spec field %q, got %v", name, fields)
+				}
+			}
+		})
+	}
+}
+
+func TestMapResources_Owners(t *testing.T) {
+	orderID := parser.Parameter{Name: "orderId", In: "path", Type: "string", Required: true}
+	newSpec := func(itemOwner string) *parser.ParsedSpec {
+		return &parser.ParsedSpec{
+			Resources: []*parser.Resource{
+				{
+					Name: "Item", PluralName: "Items", Path: "/orders/{orderId}/items", Owner: itemOwner,
+					Operations: []parser.Operation{{Method: "GET", Path: "/orders/{orderId}/items/{itemId}",
+						PathParams: []parser.Parameter{orderID, {Name: "itemId", In: "path", Type: "string", Required: true}}}},
+				},
+				{
+					Name: "Note", PluralName: "Notes", Path: "/orders/{orderId}/notes",
+					Operations: []parser.Operation{{Method: "GET", Path: "/orders/{orderId}/notes/{noteId}",
+						PathParams: []parser.Parameter{orderID, {Name: "noteId", In: "path", Type: "string", Required: true}}}},
+				},
+				{
+					Name: "Order", PluralName: "Orders", Path: "/orders",
+					Operations: []parser.Operation{{Method: "GET", Path: "/orders/{id}",
+						PathParams: []parser.Parameter{{Name: "id", In: "path", Type: "string", Required: true}}}},
+				},
+			},
+		}
+	}
+	owners := func(crds []*CRDDefinition) map[string]string {
+		result := make(map[string]string)
+		for _, crd := range crds {
+			if crd.Owner != nil {
+				result[crd.Kind] = crd.Owner.Kind + "/" + crd.Owner.IDParam + "/" + crd.Owner.IDField
+			}
+		}
+		return result
+	}
+
+	cfg := &config.Config{APIGroup: "test.example.com", APIVersion: "v1alpha1", MappingMode: config.PerResource}
+	crds, err := NewMapper(cfg).MapResources(newSpec("Order"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	got := owners(crds)
+	if got["Item"] != "Order/orderId/Id" || got["Note"] != "" || got["Order"] != "" {
+		t.Errorf("expected only Item to be owned by Order, got %v", got)
+	}
+
+	cfg.DeriveOwners = true
+	crds, err = NewMapper(cfg).MapResources(newSpec(""))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	got = owners(crds)
+	if got["Item"] != "Order/orderId/Id" || got["Note"] != "Order/orderId/Id" || got["Order"] != "" {
+		t.Errorf("expected Item and Note to be owned by Order, got %v", got)
+	}
+
+	for _, owner := range []string{"Customer", "Note", "Item"} {
+		if _, err := NewMapper(cfg).MapResources(newSpec(owner)); err == nil {
+			t.Errorf("expected an error for x-k8s-owner %q", owner)
+		}
+	}
+}
+
+func TestResolveOwners_ClusterScopedChild(t *testing.T) {
+	newCRDs := func(itemOwner *OwnerDefinition) []*CRDDefinition {
+		return []*CRDDefinition{
+			{Kind: "Item", Scope: "Cluster", ResourcePath: "/orders/{orderId}/items/{itemId}", Owner: itemOwner},
+			{Kind: "Order", Scope: "Namespaced", ResourcePath: "/orders/{id}"},
+		}
+	}
+
+	m := NewMapper(&config.Config{APIGroup: "test.example.com", DeriveOwners: true})
+	crds := newCRDs(nil)
+	if err := m.resolveOwners(crds); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if crds[0].Owner != nil {
+		t.Errorf("expected a cluster-scoped Item not to be owned by a namespaced Order, got %+v", crds[0].Owner)
+	}
+
+	crds[1].Scope = "Cluster"
+	if err := m.resolveOwners(crds); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if crds[0].Owner == nil || crds[0].Owner.Kind != "Order" {
+		t.Errorf("expected a cluster-scoped Item to be owned by a cluster-scoped Order, got %+v", crds[0].Owner)
+	}
+
+	if err := m.resolveOwners(newCRDs(&OwnerDefinition{Kind: "Order"})); err == nil {
+		t.Error("expected an error for x-k8s-owner on a cluster-scoped Item with a namespaced Order")
+	}
+}
+
+func TestOwnerIDParam(t *testing.T) {
+	tests := []struct {
+		ownerPath, childPath, want string
+	}{
+		{"/orders/{orderId}", "/orders/{orderId}/items/{itemId}", "orderId"},
+		{"/orders/{id}", "/orders/{orderId}/items/{itemId}", "orderId"},
+		{"/orders/{orderId}", "/orders/{orderId}", ""},
+		{"/orders/{orderId}", "/customers/{customerId}/items/{itemId}", ""},
+		{"/orders", "/orders/items/{itemId}", ""},
+	}
+	for _, tt := range tests {
+		if got := ownerIDParam(tt.ownerPath, tt.childPath); got != tt.want {
+			t.Errorf("ownerIDParam(%q, %q) = %q, want %q", tt.ownerPath, tt.childPath, got, tt.want)
+		}
+	}
+}
+
+func TestMapResources_SpecFieldRename(t *testing.T) {
+	newSpec := func(specField string) *parser.ParsedSpec {
+		return &parser.ParsedSpec{
+			Resources: []*parser.Resource{
+				{
+					Name:       "Pet",
+					PluralName: "Pets",
+					Path:       "/pets",
+					Schema: &parser.Schema{
+						Type: "object",
+						Properties: map[string]*parser.Schema{
+							"type": {Type: "string", SpecField: specField},
+							"name": {Type: "string"},
+							"owner": {Type: "object", Properties: map[string]*parser.Schema{
+								"type": {Type: "string"},
+							}},
+						},
+					},
+					Operations: []parser.Operation{{Method: "POST", Path: "/pets"}},
+				},
+			},
+		}
+	}
+	cfg := &config.Config{APIGroup: "test.example.com", APIVersion: "v1alpha1", MappingMode: config.PerResource}
+
+	crds, err := NewMapper(cfg).MapResources(newSpec("petType"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	fields := make(map[string]*FieldDefinition)
+	for _, f := range crds[0].Spec.Fields {
+		fields[f.JSONName] = f
+	}
+	petType, ok := fields["petType"]
+	if !ok {
+		t.Fatalf("expected a petType spec field, got %v", fields)
+	}
+	if petType.Name != "PetType" || petType.APIName != "type" {
+		t.Errorf("petType = %s (API name %q), want PetType sent as type", petType.Name, petType.APIName)
+	}
+	if _, ok := fields["type"]; ok {
+		t.Error("expected no type spec field")
+	}
+	if fields["name"].APIName != "" {
+		t.Errorf("expected name not to be renamed, got API name %q", fields["name"].APIName)
+	}
+	if nested := fields["owner"].Fields[0]; nested.JSONName != "type" || nested.APIName != "" {
+		t.Errorf("expected the nested owner.type to keep its name, got %s (API name %q)", nested.JSONName, nested.APIName)
+	}
+
+	for _, name := range []string{"paused", "name"} {
+		if _, err := NewMapper(cfg).MapResources(newSpec(name)); err == nil {
+			t.Errorf("expected renaming type to %s to fail", name)
+		}
+	}
+}
+
+func TestMapResources_RequestContentType(t *testing.T) {
+	cfg := &config.Config{
+		APIGroup:    "test.example.com",
+		APIVersion:  "v1alpha1",
+		MappingMode: config.PerResource,
+	}
+	schema := &parser.Schema{
+		Type:       "object",
+		Properties: map[string]*parser.Schema{"name": {Type: "string"}},
+	}
+	spec := &parser.ParsedSpec{
+		Resources: []*parser.Resource{
+			{
+				Name:       "Widget",
+				PluralName: "Widgets",
+				Path:       "/widgets",
+				Schema:     schema,
+				Operations: []parser.Operation{
+					{Method: "POST", Path: "/widgets", RequestBody: schema, RequestContentType: "application/vnd.example+json"},
+					{Method: "GET", Path: "/widgets/{widgetId}", PathParams: []parser.Parameter{{Name: "widgetId", In: "path", Type: "string"}}},
+				},
+			},
+		},
+		ActionEndpoints: []*parser.ActionEndpoint{
+			{
+				Name:               "WidgetRename",
+				Path:               "/rename",
+				ActionName:         "rename",
+				HTTPMethod:         "POST",
+				RequestSchema:      schema,
+				RequestContentType: "application/merge-patch+json",
+			},
+		},
+	}
+
+	crds, err := NewMapper(cfg).MapResources(spec)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := map[string]string{"POST": "application/vnd.example+json", "GET": ""}
+	for _, crd := range crds {
+		for _, op := range crd.Operations {
+			expected := want[op.HTTPMethod]
+			if crd.IsAction {
+				expected = "application/merge-patch+json"
+			}
+			if op.ContentType != expected {
+				t.Errorf("%s %s: ContentType = %q, want %q", crd.Kind, op.HTTPMethod, op.ContentType, expected)
+			}
+		}
+	}
+}
+
+func TestMapResources_FormEncoded(t *testing.T) {
+	cfg := &config.Config{
+		APIGroup:    "test.example.com",
+		APIVersion:  "v1alpha1",
+		MappingMode: config.PerResource,
+	}
+	schema := &parser.Schema{
+		Type:       "object",
+		Properties: map[string]*parser.Schema{"name": {Type: "string"}},
+	}
+	spec := &parser.ParsedSpec{
+		Resources: []*parser.Resource{
+			{
+				Name:       "Account",
+				PluralName: "Accounts",
+				Path:       "/accounts",
+				Schema:     schema,
+				Operations: []parser.Operation{
+					{Method: "POST", Path: "/accounts", RequestBody: schema, FormEncoded: true},
+					{Method: "GET", Path: "/accounts/{accountId}", PathParams: []parser.Parameter{{Name: "accountId", In: "path", Type: "string"}}},
+				},
+			},
+			{
+				Name:       "Note",
+				PluralName: "Notes",
+				Path:       "/notes",
+				Schema:     schema,
+				Operations: []parser.Operation{
+					{Method: "POST", Path: "/notes", RequestBody: schema},
+					// A form POST on an item is an update or action, not the resource body
+					{Method: "POST", Path: "/notes/{noteId}", FormEncoded: true, PathParams: []parser.Parameter{{Name: "noteId", In: "path", Type: "string"}}},
+				},
+			},
+		},
+		ActionEndpoints: []*parser.ActionEndpoint{
+			{
+				Name:          "Login",
+				Path:          "/login",
+				ActionName:    "login",
+				HTTPMethod:    "POST",
+				RequestSchema: schema,
+				FormEncoded:   true,
+			},
+		},
+	}
+
+	crds, err := NewMapper(cfg).MapResources(spec)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := map[string]bool{"Account": true, "Note": false, "Login": true}
+	for _, crd := range crds {
+		if crd.FormEncoded != want[crd.Kind] {
+			t.Errorf("%s: FormEncoded = %v, want %v", crd.Kind, crd.FormEncoded, want[crd.Kind])
+		}
+	}
+	if len(crds) != len(want) {
+		t.Errorf("expected %d CRDs, got %d", len(want), len(crds))
+	}
+}
+
+func TestMapResources_SharedQueryResultType(t *testing.T) {
+	cfg := &config.Config{
+		APIGroup:    "test.example.com",
+		APIVersion:  "v1alpha1",
+		MappingMode: config.PerResource,
+	}
+	m := NewMapper(cfg)
+	petSchema := &parser.Schema{
+		Type: "object",
+		Properties: map[string]*parser.Schema{
+			"id":   {Type: "integer", Format: "int64"},
+			"name": {Type: "string"},
+		},
+	}
+	petList := &parser.Schema{Type: "array", Items: petSchema}
+	orderSchema := &parser.Schema{
+		Type: "object",
+		Properties: map[string]*parser.Schema{
+			"quantity": {Type: "integer"},
+		},
+	}
+	spec := &parser.ParsedSpec{
+		QueryEndpoints: []*parser.QueryEndpoint{
+			{Name: "PetFindByStatus", Path: "/pet/findByStatus", ResponseSchema: petList, ResponseSchemaRef: "Pet", ResponseIsArray: true},
+			{Name: "PetFindByTags", Path: "/pet/findByTags", ResponseSchema: petList, ResponseSchemaRef: "Pet", ResponseIsArray: true},
+			{Name: "OrderLookup", Path: "/store/lookup", ResponseSchema: orderSchema, ResponseSchemaRef: "Order"},
+		},
+	}
+
+	crds, err := m.MapResources(spec)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	byKind := make(map[string]*CRDDefinition)
+	for _, crd := range crds {
+		byKind[crd.Kind] = crd
+	}
+	for _, kind := range []string{"PetFindByStatus", "PetFindByTags"} {
+		crd := byKind[kind]
+		if crd == nil {
+			t.Fatalf("expected query CRD %s", kind)
+		}
+		if crd.ResultItemType != "PetResult" || !crd.UsesSharedType || crd.ResponseType != "[]PetResult" {
+			t.Errorf("%s: expected shared []PetResult, got %q (shared=%v, response %q)", kind, crd.ResultItemType, crd.UsesSharedType, crd.ResponseType)
+		}
+	}
+
+	order := byKind["OrderLookup"]
+	if order == nil {
+		t.Fatal("expected query CRD OrderLookup")
+	}
+	if order.ResultItemType != "OrderLookupResult" || order.UsesSharedType {
+		t.Errorf("expected a single-use ref to keep its own result type, got %q (shared=%v)", order.ResultItemType, order.UsesSharedType)
+	}
+}
+
+func TestMapResources_PluralOverrides(t *testing.T) {
+	spec := &parser.ParsedSpec{
+		Resources: []*parser.Resource{
+			{
+				Name:       "Datum",
+				PluralName: "Datums",
+				Path:       "/data",
+				Schema:     &parser.Schema{Type: "object", Plural: "data"},
+				Operations: []parser.Operation{{Method: "POST", Path: "/data"}},
+			},
+			{
+				Name:       "Person",
+				PluralName: "Persons",
+				Path:       "/people",
+				Operations: []parser.Operation{{Method: "POST", Path: "/people", Plural: "humans"}},
+			},
+		},
+		QueryEndpoints: []*parser.QueryEndpoint{
+			{Name: "StatsQuery", Path: "/stats", Plural: "statistics"},
+		},
+	}
+	cfg := &config.Config{
+		APIGroup:        "test.example.com",
+		APIVersion:      "v1alpha1",
+		MappingMode:     config.PerResource,
+		PluralOverrides: map[string]string{"Person": "people"},
+	}
+
+	crds, err := NewMapper(cfg).MapResources(spec)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	plurals := make(map[string]string)
+	for _, crd := range crds {
+		plurals[crd.Kind] = crd.Plural
+		if !crd.CustomPlural {
+			t.Errorf("expected %s to be marked as having an explicit plural", crd.Kind)
+		}
+	}
+	// Schema extension, config (over the operation extension), operation extension
+	want := map[string]string{"Datum": "data", "Person": "people", "StatsQuery": "statistics"}
+	for kind, plural := range want {
+		if plurals[kind] != plural {
+			t.Errorf("expected %s plural %q, got %q", kind, plural, plurals[kind])
+		}
+	}
+	if got := CustomPlurals(crds); len(got) != 3 || got["Datum"] != "data" {
+		t.Errorf("expected CustomPlurals to list the explicit plurals, got %v", got)
+	}
+}
+
+func TestMapResources_PluralErrors(t *testing.T) {
+	cfg := &config.Config{
+		APIGroup:    "test.example.com",
+		APIVersion:  "v1alpha1",
+		MappingMode: config.PerResource,
+	}
+
+	collision := &parser.ParsedSpec{
+		QueryEndpoints: []*parser.QueryEndpoint{
+			{Name: "StatsQuery", Path: "/stats"},
+			{Name: "MetricsQuery", Path: "/metrics", Plural: "statsqueries"},
+		},
+	}
+	_, err := NewMapper(cfg).MapResources(collision)
+	if err == nil || !strings.Contains(err.Error(), `both use the plural "statsqueries"`) {
+		t.Errorf("expected a plural collision error, got %v", err)
+	}
+
+	invalid := &parser.ParsedSpec{
+		QueryEndpoints: []*parser.QueryEndpoint{
+			{Name: "StatsQuery", Path: "/stats", Plural: "Stats_Queries"},
+		},
+	}
+	_, err = NewMapper(cfg).MapResources(invalid)
+	if err == nil || !strings.Contains(err.Error(), "StatsQuery") {
+		t.Errorf("expected an invalid plural error naming the Kind, got %v", err)
+	}
+}
+
+func TestMapResources_GroupKindPluralCheck(t *testing.T) {
+	cfg := &config.Config{
+		APIGroup:             "test.example.com",
+		APIVersion:           "v1alpha1",
+		MappingMode:          config.PerResource,
+		GroupKindPluralCheck: true,
+	}
+
+	valid := &parser.ParsedSpec{
+		Resources: []*parser.Resource{
+			{Name: "Pet", PluralName: "Pets", Path: "/pets", Operations: []parser.Operation{{Method: "POST", Path: "/pets"}}},
+			{Name: "Order", PluralName: "Orders", Path: "/orders", Operations: []parser.Operation{{Method: "POST", Path: "/orders"}}},
+		},
+		QueryEndpoints: []*parser.QueryEndpoint{{Name: "StatsQuery", Path: "/stats"}},
+	}
+	if _, err := NewMapper(cfg).MapResources(valid); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	invalid := &parser.ParsedSpec{
+		Resources: []*parser.Resource{
+			{Name: "Widget", PluralName: "Widgets", Path: "/widgets", Operations: []parser.Operation{{Method: "POST", Path: "/widgets"}}},
+			{Name: "Wizard", PluralName: "Wizards", Path: "/wizards", Operations: []parser.Operation{{Method: "POST", Path: "/wizards"}}},
+		},
+		QueryEndpoints: []*parser.QueryEndpoint{
+			{Name: "Stats_Query", Path: "/stats"},
+		},
+	}
+	_, err := NewMapper(cfg).MapResources(invalid)
+	if err == nil {
+		t.Fatal("expected naming errors")
+	}
+	for _, want := range []string{
+		`Wizard: short name "wi" is also used by Widget`,
+		`Stats_Query: invalid Kind "Stats_Query": must contain only letters and digits`,
+		`Stats_Query: invalid plural "stats_queries"`,
+	} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("expected error to contain %q, got: %v", want, err)
+		}
+	}
+
+	// Without the check, only explicit plurals are validated
+	cfg.GroupKindPluralCheck = false
+	if _, err := NewMapper(cfg).MapResources(invalid); err != nil {
+		t.Errorf("unexpected error without the check: %v", err)
+	}
+}
+
+func TestMapResources_UseETag(t *testing.T) {
+	widgetSchema := &parser.Schema{
+		Type:       "object",
+		Properties: map[string]*parser.Schema{"name": {Type: "string"}},
+	}
+	spec := &parser.ParsedSpec{
+		Resources: []*parser.Resource{
+			{
+				Name:       "Widget",
+				PluralName: "Widgets",
+				Path:       "/widgets",
+				Schema:     widgetSchema,
+				Operations: []parser.Operation{
+					{Method: "GET", Path: "/widgets/{id}", ResponseHeaders: []string{"etag"}},
+					{Method: "PUT", Path: "/widgets/{id}"},
+				},
+			},
+			{
+				Name:       "Gadget",
+				PluralName: "Gadgets",
+				Path:       "/gadgets",
+				Schema:     widgetSchema,
+				Operations: []parser.Operation{
+					{Method: "GET", Path: "/gadgets/{id}"},
+					{Method: "PUT", Path: "/gadgets/{id}"},
+				},
+			},
+		},
+	}
+
+	for _, useETag := range []bool{false, true} {
+		cfg := &config.Config{
+			APIGroup:    "test.example.com",
+			APIVersion:  "v1alpha1",
+			MappingMode: config.PerResource,
+			UseETag:     useETag,
+		}
+		crds, err := NewMapper(cfg).MapResources(spec)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		for _, crd := range crds {
+			want := useETag && crd.Kind == "Widget"
+			if crd.UseETag != want {
+				t.Errorf("useETag=%v %s: expected UseETag %v, got %v", useETag, crd.Kind, want, crd.UseETag)
+			}
+			hasStatusField := false
+			for _, f := range crd.Status.Fields {
+				if f.JSONName == "etag" {
+					hasStatusField = true
+				}
+			}
+			if hasStatusField != want {
+				t.Errorf("useETag=%v %s: expected etag status field %v, got %v", useETag, crd.Kind, want, hasStatusField)
+			}
+		}
+	}
+}
+
+func TestMapResources_StatusFields(t *testing.T) {
+	vmSchema := &parser.Schema{
+		Type: "object",
+		Properties: map[string]*parser.Schema{
+			"name": {Type: "string"},
+			"properties": {
+				Type: "object",
+				Properties: map[string]*parser.Schema{
+					"provisioningState": {Type: "string", Description: "Provisioning state of the VM"},
+					"cores":             {Type: "integer"},
+					"disks":             {Type: "array", Items: &parser.Schema{Type: "string"}},
+				},
+			},
+		},
+	}
+	newSpec := func(fields map[string]string) *parser.ParsedSpec {
+		return &parser.ParsedSpec{
+			Resources: []*parser.Resource{{
+				Name:       "VM",
+				PluralName: "VMs",
+				Path:       "/vms",
+				Schema:     vmSchema,
+				Operations: []parser.Operation{
+					{Method: "GET", Path: "/vms/{id}", ResponseBody: vmSchema, StatusFields: fields},
+					{Method: "PUT", Path: "/vms/{id}"},
+				},
+			}},
+		}
+	}
+	cfg := &config.Config{
+		APIGroup:    "test.example.com",
+		APIVersion:  "v1alpha1",
+		MappingMode: config.PerResource,
+	}
+
+	crds, err := NewMapper(cfg).MapResources(newSpec(map[string]string{
+		"provisioningState": "properties.provisioningState",
+		"cpuCount":          "$.properties.cores",
+	}))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := []StatusField{
+		{Name: "CpuCount", JSONName: "cpuCount", Path: []string{"properties", "cores"}, GoType: "int64", SchemaType: "integer",
+			Description: "Value of properties.cores in the REST API response"},
+		{Name: "ProvisioningState", JSONName: "provisioningState", Path: []string{"properties", "provisioningState"}, GoType: "string", SchemaType: "string",
+			Description: "Provisioning state of the VM"},
+	}
+	if !reflect.DeepEqual(crds[0].StatusFields, want) {
+		t.Errorf("StatusFields = %+v, want %+v", crds[0].StatusFields, want)
+	}
+	statusNames := map[string]bool{}
+	for _, f := range crds[0].Status.Fields {
+		statusNames[f.JSONName] = true
+	}
+	if !statusNames["cpuCount"] || !statusNames["provisioningState"] {
+		t.Errorf("expected the status fields in the status definition, got %v", statusNames)
+	}
+
+	for fields, wantErr := range map[string]string{
+		"properties.missing": "is not in the response schema",
+		"properties.disks":   "has type array",
+		"properties":         "has type object",
+	} {
+		_, err := NewMapper(cfg).MapResources(newSpec(map[string]string{"value": fields}))
+		if err == nil || !strings.Contains(err.Error(), wantErr) {
+			t.Errorf("path %q: expected error containing %q, got %v", fields, wantErr, err)
+		}
+	}
+	_, err = NewMapper(cfg).MapResources(newSpec(map[string]string{"state": "name"}))
+	if err == nil || !strings.Contains(err.Error(), "built-in status field") {
+		t.Errorf("expected a built-in status field error, got %v", err)
+	}
+}
+
+func TestMapResources_ExcludeStatusFields(t *testing.T) {
+	vmSchema := &parser.Schema{
+		Type: "object",
+		Properties: map[string]*parser.Schema{
+			"state": {Type: "string"},
+			"cores": {Type: "integer"},
+		},
+	}
+	spec := &parser.ParsedSpec{
+		Resources: []*parser.Resource{
+			{Name: "VM", PluralName: "VMs", Path: "/vms", Schema: vmSchema, Operations: []parser.Operation{
+				{Method: "GET", Path: "/vms/{id}", ResponseBody: vmSchema, StatusFields: map[string]string{"vmState": "state", "cpuCount": "cores"}},
+				{Method: "PUT", Path: "/vms/{id}"},
+			}},
+			{Name: "Disk", PluralName: "Disks", Path: "/disks", Operations: []parser.Operation{
+				{Method: "GET", Path: "/disks/{id}"},
+			}},
+		},
+	}
+	mapWith := func(entries ...string) ([]*CRDDefinition, error) {
+		return NewMapper(&config.Config{
+			APIGroup:            "test.example.com",
+			APIVersion:          "v1alpha1",
+			MappingMode:         config.PerResource,
+			ExcludeStatusFields: entries,
+		}).MapResources(spec)
+	}
 
-func TestCreateGenericSpec(t *testing.T) {
-	m := &Mapper{config: &config.Config{}}
-	result := m.createGenericSpec()
+	crds, err := mapWith("response", "VM.cpuCount", "Disk.lastGetTime")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	excluded := map[string][]string{}
+	for _, crd := range crds {
+		excluded[crd.Kind] = crd.ExcludedStatusFields
+	}
+	if want := []string{"cpuCount", "response"}; !reflect.DeepEqual(excluded["VM"], want) {
+		t.Errorf("VM ExcludedStatusFields = %v, want %v", excluded["VM"], want)
+	}
+	if want := []string{"lastGetTime", "response"}; !reflect.DeepEqual(excluded["Disk"], want) {
+		t.Errorf("Disk ExcludedStatusFields = %v, want %v", excluded["Disk"], want)
+	}
+	vm := crds[0]
+	if len(vm.StatusFields) != 1 || vm.StatusFields[0].JSONName != "vmState" {
+		t.Errorf("expected only vmState to remain in StatusFields, got %+v", vm.StatusFields)
+	}
+	for _, f := range vm.Status.Fields {
+		if f.JSONName == "response" || f.JSONName == "cpuCount" {
+			t.Errorf("expected status.%s to be dropped from the status definition", f.JSONName)
+		}
+	}
 
-	if result.Name != "Spec" {
-		t.Errorf("expected Name 'Spec', got %q", result.Name)
+	for entry, wantErr := range map[string]string{
+		"VM.results":     "VM has no status field results",
+		"Widget.message": "no CRD Kind Widget",
+		"lastSynctime":   "no CRD has status field lastSynctime",
+	} {
+		if _, err := mapWith(entry); err == nil || !strings.Contains(err.Error(), wantErr) {
+			t.Errorf("%q: expected error containing %q, got %v", entry, wantErr, err)
+		}
 	}
-	if result.JSONName != "spec" {
-		t.Errorf("expected JSONName 'spec', got %q", result.JSONName)
+}
+
+func TestMapResources_PollCondition(t *testing.T) {
+	dbSchema := &parser.Schema{
+		Type: "object",
+		Properties: map[string]*parser.Schema{
+			"name":   {Type: "string"},
+			"status": {Type: "string", Enum: []interface{}{"creating", "available", "failed"}},
+			"config": {Type: "object", Properties: map[string]*parser.Schema{"ready": {Type: "boolean"}}},
+		},
 	}
-	if result.GoType != "struct" {
-		t.Errorf("expected GoType 'struct', got %q", result.GoType)
+	newSpec := func(condition *parser.PollCondition) *parser.ParsedSpec {
+		return &parser.ParsedSpec{
+			Resources: []*parser.Resource{{
+				Name:       "Database",
+				PluralName: "Databases",
+				Path:       "/databases",
+				Schema:     dbSchema,
+				Operations: []parser.Operation{
+					{Method: "POST", Path: "/databases"},
+					{Method: "GET", Path: "/databases/{id}", ResponseBody: dbSchema, PollCondition: condition},
+				},
+			}},
+		}
 	}
-	if len(result.Fields) != 1 {
-		t.Fatalf("expected 1 field, got %d", len(result.Fields))
+	cfg := &config.Config{APIGroup: "test.example.com", APIVersion: "v1alpha1", MappingMode: config.PerResource}
+
+	crds, err := NewMapper(cfg).MapResources(newSpec(&parser.PollCondition{Field: "status", Values: []string{"available"}}))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
 	}
-	if result.Fields[0].Name != "Data" {
-		t.Errorf("expected field name 'Data', got %q", result.Fields[0].Name)
+	want := &PollCondition{Path: []string{"status"}, Values: []string{"available"}, Interval: DefaultPollInterval, Expression: `status == "available"`}
+	if !reflect.DeepEqual(crds[0].PollCondition, want) {
+		t.Errorf("PollCondition = %+v, want %+v", crds[0].PollCondition, want)
 	}
-	if result.Fields[0].GoType != "runtime.RawExtension" {
-		t.Errorf("expected GoType 'runtime.RawExtension', got %q", result.Fields[0].GoType)
+
+	crds, err = NewMapper(cfg).MapResources(newSpec(&parser.PollCondition{Field: "$.config.ready", Values: []string{"true"}, Interval: "30s"}))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want = &PollCondition{Path: []string{"config", "ready"}, Values: []string{"true"}, Interval: 30 * time.Second, Expression: `config.ready == "true"`}
+	if !reflect.DeepEqual(crds[0].PollCondition, want) {
+		t.Errorf("PollCondition = %+v, want %+v", crds[0].PollCondition, want)
+	}
+
+	for wantErr, condition := range map[string]*parser.PollCondition{
+		"expected a field and its ready value": {Field: "status"},
+		"is not in the response schema":        {Field: "state", Values: []string{"ready"}},
+		"has type object":                      {Field: "config", Values: []string{"ready"}},
+		"is not an allowed value of status":    {Field: "status", Values: []string{"ready"}},
+		"invalid interval":                     {Field: "status", Values: []string{"available"}, Interval: "soon"},
+	} {
+		_, err := NewMapper(cfg).MapResources(newSpec(condition))
+		if err == nil || !strings.Contains(err.Error(), wantErr) {
+			t.Errorf("%+v: expected error containing %q, got %v", condition, wantErr, err)
+		}
 	}
 }
 
-// =============================================================================
-// createStatusDefinition Tests
-// =============================================================================
+func TestMapResources_AnyOf(t *testing.T) {
+	subscriberSchema := &parser.Schema{
+		Type: "object",
+		Properties: map[string]*parser.Schema{
+			"email":        {Type: "string"},
+			"phone_number": {Type: "string"},
+			"channel": {
+				Type: "object",
+				Properties: map[string]*parser.Schema{
+					"slack":   {Type: "string"},
+					"webhook": {Type: "string"},
+				},
+				AnyOfRequired: [][]string{{"slack"}, {"webhook"}},
+			},
+		},
+		AnyOfRequired: [][]string{{"email"}, {"phone_number"}},
+	}
+	spec := &parser.ParsedSpec{
+		Resources: []*parser.Resource{{
+			Name:       "Subscriber",
+			PluralName: "Subscribers",
+			Path:       "/subscribers",
+			Schema:     subscriberSchema,
+			Operations: []parser.Operation{
+				{Method: "POST", Path: "/subscribers", RequestBody: subscriberSchema},
+				{Method: "GET", Path: "/subscribers/{id}"},
+			},
+		}},
+	}
+	cfg := &config.Config{
+		APIGroup:    "test.example.com",
+		APIVersion:  "v1alpha1",
+		MappingMode: config.PerResource,
+	}
 
-func TestCreateStatusDefinition(t *testing.T) {
-	m := &Mapper{config: &config.Config{}}
-	result := m.createStatusDefinition()
+	crds, err := NewMapper(cfg).MapResources(spec)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	crd := crds[0]
+	if want := [][]string{{"email"}, {"phoneNumber"}}; !reflect.DeepEqual(crd.Spec.AnyOfRequired, want) {
+		t.Errorf("spec AnyOfRequired = %v, want %v", crd.Spec.AnyOfRequired, want)
+	}
+	if crd.SpecAnyOfRule == nil {
+		t.Fatal("expected a spec anyOf rule")
+	}
+	if !strings.HasSuffix(crd.SpecAnyOfRule.Rule, "has(self.email) || has(self.phoneNumber)") {
+		t.Errorf("unexpected spec anyOf rule %q", crd.SpecAnyOfRule.Rule)
+	}
+	if crd.SpecAnyOfRule.Message != "spec must set email, or phoneNumber" {
+		t.Errorf("unexpected spec anyOf message %q", crd.SpecAnyOfRule.Message)
+	}
+	for _, ref := range crd.SpecAnyOfRule.ReferenceFields {
+		if !strings.Contains(crd.SpecAnyOfRule.Rule, "has(self."+ref+") || ") {
+			t.Errorf("expected the rule %q to be skipped when %s is set", crd.SpecAnyOfRule.Rule, ref)
+		}
+	}
 
-	if result.Name != "Status" {
-		t.Errorf("expected Name 'Status', got %q", result.Name)
+	var channel *FieldDefinition
+	for _, f := range crd.Spec.Fields {
+		if f.JSONName == "channel" {
+			channel = f
+		}
 	}
-	if result.JSONName != "status" {
-		t.Errorf("expected JSONName 'status', got %q", result.JSONName)
+	if channel == nil || !reflect.DeepEqual(channel.AnyOfRequired, [][]string{{"slack"}, {"webhook"}}) {
+		t.Errorf("expected the channel field to keep its anyOf required sets, got %+v", channel)
 	}
+}
 
-	expectedFields := map[string]string{
-		"State":              "string",
-		"LastSyncTime":       "metav1.Time",
-		"ExternalID":         "string",
-		"Message":            "string",
-		"Conditions":         "[]metav1.Condition",
-		"ObservedGeneration": "int64",
-		"Response":           "runtime.RawExtension",
+func TestAnyOfRule(t *testing.T) {
+	sets := [][]string{{"email"}, {"phone", "country"}}
+	if got, want := AnyOfRule("self", sets), "has(self.email) || (has(self.phone) && has(self.country))"; got != want {
+		t.Errorf("AnyOfRule = %q, want %q", got, want)
+	}
+	if got, want := AnyOfRule("item", [][]string{{"a", "b"}}), "has(item.a) && has(item.b)"; got != want {
+		t.Errorf("AnyOfRule = %q, want %q", got, want)
+	}
+	if got, want := AnyOfRuleMessage("spec", sets), "spec must set email, or phone and country"; got != want {
+		t.Errorf("AnyOfRuleMessage = %q, want %q", got, want)
 	}
+}
 
-	if len(result.Fields) != len(expectedFields) {
-		t.Fatalf("expected %d fields, got %d", len(expectedFields), len(result.Fields))
+func TestMapResources_AllowExtraHeaders(t *testing.T) {
+	newSpec := func(field string) *parser.ParsedSpec {
+		schema := &parser.Schema{
+			Type:       "object",
+			Properties: map[string]*parser.Schema{field: {Type: "string"}},
+		}
+		return &parser.ParsedSpec{
+			Resources: []*parser.Resource{{
+				Name:       "Pet",
+				PluralName: "Pets",
+				Path:       "/pets",
+				Schema:     schema,
+				Operations: []parser.Operation{
+					{Method: "POST", Path: "/pets", RequestBody: schema},
+					{Method: "GET", Path: "/pets/{id}"},
+				},
+			}},
+			QueryEndpoints: []*parser.QueryEndpoint{
+				{Name: "PetFindByStatus", Path: "/pets/findByStatus"},
+			},
+		}
+	}
+	cfg := &config.Config{
+		APIGroup:    "test.example.com",
+		APIVersion:  "v1alpha1",
+		MappingMode: config.PerResource,
 	}
 
-	for _, f := range result.Fields {
-		expected, ok := expectedFields[f.Name]
-		if !ok {
-			t.Errorf("unexpected field %q", f.Name)
-			continue
+	crds, err := NewMapper(cfg).MapResources(newSpec("name"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, crd := range crds {
+		if crd.HasExtraHeaders {
+			t.Errorf("%s: expected no extraHeaders without AllowExtraHeaders", crd.Kind)
 		}
-		if f.GoType != expected {
-			t.Errorf("expected %s.GoType to be %q, got %q", f.Name, expected, f.GoType)
+	}
+
+	cfg.AllowExtraHeaders = true
+	crds, err = NewMapper(cfg).MapResources(newSpec("name"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(crds) != 2 {
+		t.Fatalf("expected a resource and a query CRD, got %d", len(crds))
+	}
+	for _, crd := range crds {
+		if !crd.HasExtraHeaders {
+			t.Errorf("%s: expected extraHeaders", crd.Kind)
 		}
 	}
-}
 
-// =============================================================================
-// MapResources Integration Tests
-// =============================================================================
+	_, err = NewMapper(cfg).MapResources(newSpec("extraHeaders"))
+	if err == nil || !strings.Contains(err.Error(), "conflicts with --allow-extra-headers") {
+		t.Errorf("expected a conflict with the API's extraHeaders field, got %v", err)
+	}
+}
 
 func TestMapResources_PerResourceMode(t *testing.T) {
 	cfg := &config.Config{
@@ -911,6 +2350,39 @@ func TestMapResources_EmptySpec(t *testing.T) {
 	}
 }
 
+func TestMapResources_MaxCRDs(t *testing.T) {
+	spec := &parser.ParsedSpec{
+		QueryEndpoints: []*parser.QueryEndpoint{
+			{Name: "PetFindByStatus", Path: "/pet/findByStatus"},
+			{Name: "PetFindByTags", Path: "/pet/findByTags"},
+			{Name: "StoreInventory", Path: "/store/inventory"},
+		},
+	}
+
+	cfg := &config.Config{
+		APIGroup:    "test.example.com",
+		APIVersion:  "v1",
+		MappingMode: config.PerResource,
+		MaxCRDs:     2,
+	}
+	_, err := NewMapper(cfg).MapResources(spec)
+	if err == nil {
+		t.Fatal("expected an error when the spec maps to more CRDs than MaxCRDs")
+	}
+	if !strings.Contains(err.Error(), "3 CRDs") || !strings.Contains(err.Error(), "--include-paths") {
+		t.Errorf("expected the error to give the count and suggest filters, got %v", err)
+	}
+
+	cfg.MaxCRDs = 3
+	crds, err := NewMapper(cfg).MapResources(spec)
+	if err != nil {
+		t.Fatalf("unexpected error at the limit: %v", err)
+	}
+	if len(crds) != 3 {
+		t.Errorf("expected 3 CRDs, got %d", len(crds))
+	}
+}
+
 // =============================================================================
 // Edge Cases and Complex Scenarios
 // =============================================================================
@@ -1015,3 +2487,281 @@ func TestMapOperations_MultipleOperationsSamePath(t *testing.T) {
 		t.Errorf("expected Get, Update, Delete actions, got %v", actions)
 	}
 }
+
+func TestMapResources_TargetDefault(t *testing.T) {
+	cfg := &config.Config{
+		APIGroup:      "test.example.com",
+		APIVersion:    "v1",
+		DefaultTarget: &config.TargetDefault{Deployment: "fallback-api"},
+	}
+	spec := &parser.ParsedSpec{
+		Resources: []*parser.Resource{
+			{Name: "Foo", PluralName: "Foos", Path: "/foo", Operations: []parser.Operation{
+				{Method: "POST", Path: "/foo", TargetDefault: map[string]string{"deployment": "post-api"}},
+				{Method: "GET", Path: "/foo/{id}", TargetDefault: map[string]string{"baseURL": "http://foo.backend.svc:8080"}},
+			}},
+			{Name: "Bar", PluralName: "Bars", Path: "/bar", Operations: []parser.Operation{
+				{Method: "GET", Path: "/bar/{id}"},
+			}},
+		},
+	}
+
+	crds, err := NewMapper(cfg).MapResources(spec)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	targets := make(map[string]*config.TargetDefault)
+	for _, crd := range crds {
+		targets[crd.Kind] = crd.TargetDefault
+	}
+	if got := targets["Foo"]; got == nil || got.BaseURL != "http://foo.backend.svc:8080" {
+		t.Errorf("Foo: expected the GET operation's target, got %+v", got)
+	}
+	if got := targets["Bar"]; got == nil || got.Deployment != "fallback-api" {
+		t.Errorf("Bar: expected config.DefaultTarget, got %+v", got)
+	}
+
+	spec.Resources[1].Operations[0].TargetDefault = map[string]string{"service": "bar"}
+	if _, err := NewMapper(cfg).MapResources(spec); err == nil || !strings.Contains(err.Error(), "x-k8s-target-default on GET /bar/{id}") {
+		t.Errorf("expected error for unknown target key, got %v", err)
+	}
+}
+
+func TestMapResources_ReadOnly(t *testing.T) {
+	cfg := &config.Config{APIGroup: "test.example.com", APIVersion: "v1"}
+	spec := &parser.ParsedSpec{
+		Resources: []*parser.Resource{
+			{Name: "Region", PluralName: "Regions", Path: "/regions", ReadOnly: true,
+				Schema: &parser.Schema{
+					Type:       "object",
+					Properties: map[string]*parser.Schema{"name": {Type: "string"}},
+				},
+				Operations: []parser.Operation{
+					{Method: "GET", Path: "/regions/{regionId}", PathParams: []parser.Parameter{
+						{Name: "regionId", In: "path", Required: true, Type: "string"},
+					}},
+				}},
+		},
+	}
+
+	crds, err := NewMapper(cfg).MapResources(spec)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(crds) != 1 {
+		t.Fatalf("expected 1 CRD, got %d", len(crds))
+	}
+	crd := crds[0]
+	if !crd.ReadOnly {
+		t.Error("expected ReadOnly")
+	}
+	if !reflect.DeepEqual(crd.States(), ReadOnlyStates) {
+		t.Errorf("expected states %v, got %v", ReadOnlyStates, crd.States())
+	}
+	// The spec only identifies the object: the schema's properties are left out
+	var fields []string
+	for _, f := range crd.Spec.Fields {
+		fields = append(fields, f.JSONName)
+	}
+	if !reflect.DeepEqual(fields, []string{"regionId"}) {
+		t.Errorf("expected only the path parameter in the spec, got %v", fields)
+	}
+}
+
+func TestMapResources_SuccessCodes(t *testing.T) {
+	cfg := &config.Config{
+		APIGroup:     "test.example.com",
+		APIVersion:   "v1",
+		SuccessCodes: map[string][]int{"DELETE": {204}, "POST": {200}},
+	}
+	spec := &parser.ParsedSpec{
+		Resources: []*parser.Resource{
+			{Name: "Foo", PluralName: "Foos", Path: "/foo", Operations: []parser.Operation{
+				{Method: "POST", Path: "/foo", SuccessCodes: []string{"202", "201"}},
+				{Method: "GET", Path: "/foo/{id}"},
+				{Method: "DELETE", Path: "/foo/{id}"},
+			}},
+		},
+	}
+
+	crds, err := NewMapper(cfg).MapResources(spec)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	codes := make(map[string][]int)
+	for _, op := range crds[0].Operations {
+		codes[op.HTTPMethod] = op.SuccessCodes
+	}
+	if got := codes["POST"]; !reflect.DeepEqual(got, []int{201, 202}) {
+		t.Errorf("POST: expected the extension's codes, got %v", got)
+	}
+	if got := codes["DELETE"]; !reflect.DeepEqual(got, []int{204}) {
+		t.Errorf("DELETE: expected config.SuccessCodes, got %v", got)
+	}
+	if got := codes["GET"]; got != nil {
+		t.Errorf("GET: expected no success codes, got %v", got)
+	}
+
+	spec.Resources[0].Operations[0].SuccessCodes = []string{"abc"}
+	if _, err := NewMapper(cfg).MapResources(spec); err == nil || !strings.Contains(err.Error(), "x-k8s-success-codes on POST /foo") {
+		t.Errorf("expected error for invalid success code, got %v", err)
+	}
+}
+
+func TestMapResources_Security(t *testing.T) {
+	cfg := &config.Config{APIGroup: "test.example.com", APIVersion: "v1"}
+	spec := &parser.ParsedSpec{
+		SecuritySchemes: map[string]parser.SecurityScheme{
+			"api_key":    {Name: "api_key", Type: "apiKey", In: "header", ParamName: "X-API-Key"},
+			"bearerAuth": {Name: "bearerAuth", Type: "http", Scheme: "bearer"},
+			"clientCert": {Name: "clientCert", Type: "mutualTLS"},
+		},
+		Resources: []*parser.Resource{
+			{Name: "Foo", PluralName: "Foos", Path: "/foo", Operations: []parser.Operation{
+				{Method: "POST", Path: "/foo", Security: []parser.SecurityRequirement{
+					{Schemes: []string{"api_key", "bearerAuth"}},
+					{Schemes: []string{"clientCert"}},
+					{Schemes: []string{"unknown"}},
+				}},
+				{Method: "GET", Path: "/foo/{id}", Security: []parser.SecurityRequirement{{Schemes: []string{"api_key"}}, {}}},
+				{Method: "DELETE", Path: "/foo/{id}"},
+			}},
+		},
+	}
+
+	crds, err := NewMapper(cfg).MapResources(spec)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	apiKey := SecurityScheme{Name: "api_key", Type: "apiKey", In: "header", ParamName: "X-API-Key", EnvVar: "API_AUTH_API_KEY"}
+	bearer := SecurityScheme{Name: "bearerAuth", Type: "http", Scheme: "bearer", EnvVar: "API_AUTH_BEARERAUTH"}
+
+	security := make(map[string][]SecurityRequirement)
+	for _, op := range crds[0].Operations {
+		security[op.HTTPMethod] = op.Security
+	}
+	// Both schemes apply together; the mutualTLS and unknown requirements are dropped
+	if got, want := security["POST"], []SecurityRequirement{{Schemes: []SecurityScheme{apiKey, bearer}}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("POST security = %+v, want %+v", got, want)
+	}
+	if got, want := security["GET"], []SecurityRequirement{{Schemes: []SecurityScheme{apiKey}}, {Schemes: []SecurityScheme{}}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("GET security = %+v, want %+v", got, want)
+	}
+	if got := security["DELETE"]; got != nil {
+		t.Errorf("DELETE security = %+v, want nil", got)
+	}
+	if got, want := crds[0].SecuritySchemes, []SecurityScheme{apiKey, bearer}; !reflect.DeepEqual(got, want) {
+		t.Errorf("SecuritySchemes = %+v, want %+v", got, want)
+	}
+}
+
+func TestSecurityEnvVar(t *testing.T) {
+	tests := map[string]string{
+		"api_key":       "API_AUTH_API_KEY",
+		"petstore-auth": "API_AUTH_PETSTORE_AUTH",
+		"bearerAuth":    "API_AUTH_BEARERAUTH",
+	}
+	for name, want := range tests {
+		if got := securityEnvVar(name); got != want {
+			t.Errorf("securityEnvVar(%q) = %q, want %q", name, got, want)
+		}
+	}
+}
+
+func TestMapper_Accept(t *testing.T) {
+	tests := []struct {
+		name         string
+		acceptHeader string
+		contentTypes []string
+		want         string
+	}{
+		{"no media types", "", nil, "application/json"},
+		{"prefers application/json", "", []string{"application/vnd.widget+json", "application/json; charset=utf-8", "application/xml"}, "application/json; charset=utf-8"},
+		{"vendor JSON media type", "", []string{"application/vnd.widget+json", "application/xml"}, "application/vnd.widget+json"},
+		{"first media type", "", []string{"text/csv", "text/plain"}, "text/csv"},
+		{"config override", "application/hal+json", []string{"application/json"}, "application/hal+json"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := NewMapper(&config.Config{APIGroup: "test.example.com", APIVersion: "v1", AcceptHeader: tt.acceptHeader})
+			if got := m.accept(tt.contentTypes); got != tt.want {
+				t.Errorf("accept(%v) = %q, want %q", tt.contentTypes, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWriteKindList(t *testing.T) {
+	crds := []*CRDDefinition{
+		{Kind: "Pet", Plural: "pets"},
+		{Kind: "PetFindbystatusQuery", Plural: "petfindbystatusqueries", IsQuery: true},
+		{Kind: "PetUploadimageAction", Plural: "petuploadimageactions", IsAction: true},
+	}
+
+	var b strings.Builder
+	if err := WriteKindList(&b, crds); err != nil {
+		t.Fatalf("WriteKindList failed: %v", err)
+	}
+	want := "Pet\tresource\tpets\n" +
+		"PetFindbystatusQuery\tquery\tpetfindbystatusqueries\n" +
+		"PetUploadimageAction\taction\tpetuploadimageactions\n"
+	if b.String() != want {
+		t.Errorf("WriteKindList =\n%s\nwant\n%s", b.String(), want)
+	}
+}
+
+func TestCompareCRDs(t *testing.T) {
+	old := &CRDDefinition{
+		Kind: "Pet",
+		Operations: []OperationMapping{
+			{CRDAction: "Create", HTTPMethod: "POST", Path: "/pets"},
+			{CRDAction: "Delete", HTTPMethod: "DELETE", Path: "/pets/{id}"},
+		},
+		Spec: &FieldDefinition{Fields: []*FieldDefinition{
+			{JSONName: "name", GoType: "string", Required: true},
+			{JSONName: "tag", GoType: "string"},
+			{JSONName: "weight", GoType: "int32"},
+		}},
+	}
+	new := &CRDDefinition{
+		Kind: "Pet",
+		Operations: []OperationMapping{
+			{CRDAction: "Create", HTTPMethod: "POST", Path: "/pets"},
+			{CRDAction: "Update", HTTPMethod: "PUT", Path: "/pets/{id}"},
+		},
+		Spec: &FieldDefinition{Fields: []*FieldDefinition{
+			{JSONName: "name", GoType: "string"},
+			{JSONName: "weight", GoType: "int64"},
+			{JSONName: "status", GoType: "string", Required: true},
+		}},
+	}
+
+	var got []string
+	for _, c := range CompareCRDs(old, new) {
+		got = append(got, c.String())
+	}
+	want := []string{
+		"Added operation: Update (PUT /pets/{id})",
+		"Removed operation: Delete",
+		"Field now optional: name",
+		"Added field: status (string) (required)",
+		"Changed field type: weight int32 -> int64",
+		"Removed field: tag",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("CompareCRDs =\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+
+	widening := map[[2]string]bool{
+		{"int32", "int64"}:     true,
+		{"*int32", "*float64"}: true,
+		{"int64", "int32"}:     false,
+		{"string", "int64"}:    false,
+	}
+	for types, want := range widening {
+		c := CRDChange{Type: ChangeFieldRetyped, Old: types[0], New: types[1]}
+		if got := c.IsWidening(); got != want {
+			t.Errorf("IsWidening(%s -> %s) = %v, want %v", types[0], types[1], got, want)
+		}
+	}
+}
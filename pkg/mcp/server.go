@@ -3,16 +3,21 @@ package mcp
 import (
 	"context"
 	"fmt"
+	"io"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"slices"
 	"strings"
+	"time"
 
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
 
 	"github.com/bluecontainer/openapi-operator-gen/internal/config"
+	"github.com/bluecontainer/openapi-operator-gen/pkg/doctor"
 	"github.com/bluecontainer/openapi-operator-gen/pkg/generator"
+	"github.com/bluecontainer/openapi-operator-gen/pkg/lint"
 	"github.com/bluecontainer/openapi-operator-gen/pkg/mapper"
 	"github.com/bluecontainer/openapi-operator-gen/pkg/parser"
 )
@@ -38,6 +43,8 @@ func NewServer(version, commit, date string) *server.MCPServer {
 	s.AddTool(diffTool, h.handleDiff)
 	s.AddTool(explainTool, h.handleExplain)
 	s.AddTool(sampleTool, h.handleSample)
+	s.AddTool(doctorTool, h.handleDoctor)
+	s.AddTool(lintSpecTool, h.handleLintSpec)
 
 	s.AddPrompt(generateOperatorPrompt, h.handleGenerateOperatorPrompt)
 	s.AddPrompt(previewAPIPrompt, h.handlePreviewAPIPrompt)
@@ -54,7 +61,10 @@ var validateTool = mcp.NewTool("validate",
 	mcp.WithDestructiveHintAnnotation(false),
 	mcp.WithString("spec",
 		mcp.Required(),
-		mcp.Description("Path or URL to the OpenAPI specification file"),
+		mcp.Description("Path or URL to the OpenAPI specification file, or a directory of split spec files"),
+	),
+	mcp.WithString("spec_root_file",
+		mcp.Description("Root document when 'spec' is a directory of split files, relative to it (default: auto-detect)"),
 	),
 )
 
@@ -64,11 +74,20 @@ var previewTool = mcp.NewTool("preview",
 	mcp.WithDestructiveHintAnnotation(false),
 	mcp.WithString("spec",
 		mcp.Required(),
-		mcp.Description("Path or URL to the OpenAPI specification file"),
+		mcp.Description("Path or URL to the OpenAPI specification file, or a directory of split spec files"),
+	),
+	mcp.WithString("spec_root_file",
+		mcp.Description("Root document when 'spec' is a directory of split files, relative to it (default: auto-detect)"),
+	),
+	mcp.WithString("spec_cache",
+		mcp.Description("Directory caching specs fetched from a URL; cached copies are revalidated with conditional GETs and reused when the server is unreachable"),
 	),
 	mcp.WithString("group",
 		mcp.Description("Kubernetes API group (e.g., myapp.example.com). Used for Kind name derivation."),
 	),
+	mcp.WithBoolean("derive_owners",
+		mcp.Description("Make resources nested under another resource's path (/orders/{orderId}/items) owned by its CRs, as the x-k8s-owner extension does"),
+	),
 	mcp.WithString("include_paths",
 		mcp.Description("Only include paths matching these patterns (comma-separated, glob supported: /users,/pets/*)"),
 	),
@@ -87,6 +106,15 @@ var previewTool = mcp.NewTool("preview",
 	mcp.WithString("exclude_operations",
 		mcp.Description("Exclude operations with these operationIds (comma-separated, glob supported: *Deprecated,deletePet)"),
 	),
+	mcp.WithString("exclude_path_params",
+		mcp.Description("Path params with a fixed value, baked into the URLs instead of becoming spec fields (comma-separated: version=v1,tenant=acme)"),
+	),
+	mcp.WithString("plural_overrides",
+		mcp.Description("Exact CRD plurals per Kind, overriding x-k8s-plural and the pluralization heuristic (comma-separated: Datum=data,Person=people)"),
+	),
+	mcp.WithBoolean("list_kinds",
+		mcp.Description("Return only one Kind<TAB>type<TAB>plural line per CRD (type is resource, query or action) instead of the full preview, for scripts"),
+	),
 )
 
 var generateTool = mcp.NewTool("generate",
@@ -94,7 +122,16 @@ var generateTool = mcp.NewTool("generate",
 	// Required parameters
 	mcp.WithString("spec",
 		mcp.Required(),
-		mcp.Description("Path or URL to the OpenAPI specification file"),
+		mcp.Description("Path or URL to the OpenAPI specification file, or a directory of split spec files"),
+	),
+	mcp.WithString("spec_root_file",
+		mcp.Description("Root document when 'spec' is a directory of split files, relative to it (default: auto-detect)"),
+	),
+	mcp.WithString("spec_format",
+		mcp.Description("Force how the spec is decoded: auto (default), yaml or json. Use json for a JSON spec served as text/plain"),
+	),
+	mcp.WithString("spec_cache",
+		mcp.Description("Directory caching specs fetched from a URL; cached copies are revalidated with conditional GETs and reused when the server is unreachable"),
 	),
 	mcp.WithString("output",
 		mcp.Required(),
@@ -108,6 +145,12 @@ var generateTool = mcp.NewTool("generate",
 		mcp.Required(),
 		mcp.Description("Go module name for the generated operator (e.g., github.com/myorg/myapp-operator)"),
 	),
+	mcp.WithString("output_module_path",
+		mcp.Description("Import path of the output directory when generating into a subdirectory of an existing module (default: module)"),
+	),
+	mcp.WithBoolean("skip_go_mod",
+		mcp.Description("Skip generating go.mod files, for output nested inside an existing module"),
+	),
 	// Optional parameters
 	mcp.WithString("version",
 		mcp.Description("Kubernetes API version (default: v1alpha1)"),
@@ -121,21 +164,39 @@ var generateTool = mcp.NewTool("generate",
 	mcp.WithBoolean("bundle",
 		mcp.Description("Generate an Inline Composition Bundle CRD for creating multiple resources as a unit"),
 	),
+	mcp.WithBoolean("bundle_adopt",
+		mcp.Description("Make the bundle controller adopt pre-existing, unowned child CRs so they are garbage-collected with the bundle"),
+	),
 	mcp.WithBoolean("kubectl_plugin",
 		mcp.Description("Generate a kubectl plugin for managing and diagnosing operator resources"),
 	),
 	mcp.WithBoolean("rundeck_project",
 		mcp.Description("Generate Rundeck projects with jobs for operating the API (requires kubectl_plugin=true)"),
 	),
+	mcp.WithBoolean("krew_manifest",
+		mcp.Description("Generate a krew manifest (kubectl-plugin/plugin.yaml) for distributing the kubectl plugin (requires kubectl_plugin=true)"),
+	),
+	mcp.WithBoolean("webhook_patches",
+		mcp.Description("Generate kustomize patches for a conversion webhook with cert-manager CA injection (config/crd/patches, config/certmanager, config/webhook)"),
+	),
 	mcp.WithBoolean("standalone_node_source",
 		mcp.Description("Use standalone kubectl-rundeck-nodes plugin instead of generating a per-API node source plugin"),
 	),
 	mcp.WithBoolean("generate_crds",
 		mcp.Description("Generate CRD YAML manifests directly (default: use controller-gen via 'make generate')"),
 	),
+	mcp.WithBoolean("types_only",
+		mcp.Description("Generate only the API types, CRD YAML and samples, without controllers, main.go, go.mod, Dockerfile or Makefile, for importing into a larger operator"),
+	),
 	mcp.WithString("root_kind",
 		mcp.Description("Kind name for root '/' endpoint (default: derived from spec filename)"),
 	),
+	mcp.WithString("controller_file_naming",
+		mcp.Description("Controller file naming: 'kind' (default) or 'operation-id'"),
+	),
+	mcp.WithString("field_name_case",
+		mcp.Description("JSON names of CR fields: 'camel' (default, petName), 'original' (the API's names) or 'snake' (pet_name)"),
+	),
 	mcp.WithString("include_paths",
 		mcp.Description("Only include paths matching these patterns (comma-separated, glob supported)"),
 	),
@@ -154,21 +215,144 @@ var generateTool = mcp.NewTool("generate",
 	mcp.WithString("exclude_operations",
 		mcp.Description("Exclude operations with these operationIds (comma-separated, glob supported)"),
 	),
+	mcp.WithNumber("max_crds",
+		mcp.Description("Fail if the spec maps to more than this many CRDs; use the filters to narrow it (default: 0, unlimited)"),
+	),
 	mcp.WithString("update_with_post",
 		mcp.Description("Use POST for updates when PUT is not available. Value: '*' for all, or comma-separated paths"),
 	),
+	mcp.WithString("finalizer_name",
+		mcp.Description("Finalizer added by the generated controllers (default: <group>/finalizer)"),
+	),
+	mcp.WithString("sample_namespace",
+		mcp.Description("Namespace of the sample CRs and the kubectl plugin's default namespace (default: the spec's x-k8s-namespace, else default)"),
+	),
+	mcp.WithBoolean("use_etag",
+		mcp.Description("Send If-Match with the stored ETag on updates when the GET response declares an ETag header"),
+	),
+	mcp.WithString("accept_header",
+		mcp.Description("Accept header the controllers send on every request (default: derived from each operation's response media types, preferring application/json)"),
+	),
+	mcp.WithString("exclude_status_fields",
+		mcp.Description("Status fields to drop from the CRDs, as field or Kind.field (comma-separated: response,responses,Pet.lastGetTime)"),
+	),
+	mcp.WithBoolean("group_kind_plural_check",
+		mcp.Description("Validate the API group and version and every generated Kind, plural and short name against Kubernetes naming rules before generating"),
+	),
+	mcp.WithString("idempotency_header",
+		mcp.Description("Header (e.g., Idempotency-Key) carrying a key derived from the CR's UID and generation on create and action requests"),
+	),
+	mcp.WithBoolean("no_generation_predicate",
+		mcp.Description("Reconcile resource CRs on every update, including status writes, instead of only on spec and annotation changes"),
+	),
+	mcp.WithBoolean("no_status_subresource",
+		mcp.Description("Generate CRDs without the status subresource; controllers write status with a full object update"),
+	),
 	mcp.WithBoolean("no_id_merge",
 		mcp.Description("Disable automatic merging of path ID parameters with body 'id' fields"),
 	),
 	mcp.WithString("id_field_map",
 		mcp.Description("Explicit path param to body field mappings (comma-separated: orderId=id,petId=id)"),
 	),
+	mcp.WithString("exclude_path_params",
+		mcp.Description("Path params with a fixed value, baked into the URLs instead of becoming spec fields (comma-separated: version=v1,tenant=acme)"),
+	),
+	mcp.WithString("plural_overrides",
+		mcp.Description("Exact CRD plurals per Kind, overriding x-k8s-plural and the pluralization heuristic (comma-separated: Datum=data,Person=people)"),
+	),
+	mcp.WithString("server_selector",
+		mcp.Description("Server from the spec's servers list (x-name, x-environment, description or URL) the generated operator targets by default; its --server flag overrides it"),
+	),
+	mcp.WithString("environment",
+		mcp.Description("Environment (e.g., prod or staging) whose spec server, matched by its x-environment extension, is the spec base URL and the operator's default server"),
+	),
 	mcp.WithString("target_api_image",
 		mcp.Description("Container image for target REST API (generates a Deployment+Service manifest for local testing)"),
 	),
 	mcp.WithNumber("target_api_port",
 		mcp.Description("Container port for target REST API (overrides port from spec URL, default: 8080)"),
 	),
+	mcp.WithString("default_target",
+		mcp.Description("Default spec.target for generated CRs as key=value pairs, e.g. 'deployment=petstore-api,namespace=backend' (x-k8s-target-default on an operation takes precedence)"),
+	),
+	mcp.WithString("success_codes",
+		mcp.Description("Response codes treated as success per HTTP method as METHOD=CODE[|CODE...] pairs, e.g. 'DELETE=204,POST=201|202' (x-k8s-success-codes on an operation takes precedence, default: any 2xx)"),
+	),
+	mcp.WithNumber("status_result_limit",
+		mcp.Description("Max results a query controller stores in status; resultCount keeps the full count (default: 0, unlimited)"),
+	),
+	mcp.WithNumber("client_qps",
+		mcp.Description("Queries per second the generated operator's Kubernetes client may send, the default of its --kube-api-qps flag (default: 20)"),
+	),
+	mcp.WithNumber("client_burst",
+		mcp.Description("Request burst the generated operator's Kubernetes client may send above client_qps, the default of its --kube-api-burst flag (default: 30)"),
+	),
+	mcp.WithString("slow_reconcile_threshold",
+		mcp.Description("Reconcile duration above which the generated controllers emit a Warning event, e.g. '10s' (default: 10s)"),
+	),
+	mcp.WithString("delete_timeout",
+		mcp.Description("Retry a failed DELETE of the external resource, keeping the CR's finalizer and setting DeletionBlocked, until this long after deletion was requested, e.g. '10m' (default: remove the finalizer after one attempt)"),
+	),
+	mcp.WithBoolean("orphan_on_delete_timeout",
+		mcp.Description("Remove the finalizer once delete_timeout has passed, orphaning the external resource (default: false)"),
+	),
+	mcp.WithString("emit_conditions",
+		mcp.Description("Condition types the controllers set instead of Ready, Reconciling and Stalled (comma-separated: ready=Available,reconciling=Progressing,stalled=Degraded)"),
+	),
+	mcp.WithString("pause_configmap",
+		mcp.Description("ConfigMap (namespace/name or name) the generated controllers check for an operator-wide per-Kind pause switch (default: disabled)"),
+	),
+	mcp.WithBoolean("allow_extra_headers",
+		mcp.Description("Add spec.extraHeaders to resource, query and action CRDs, sent as HTTP headers on each REST API request"),
+	),
+	mcp.WithBoolean("reconcile_on_configmap_change",
+		mcp.Description("Watch the ConfigMaps and Secrets CRs reference (action spec.dataFrom) and re-reconcile those CRs when they change"),
+	),
+	mcp.WithBoolean("support_dry_run",
+		mcp.Description("Add a --dry-run-external flag to the manager that logs REST API writes (POST/PUT/PATCH/DELETE) instead of sending them, for safe first rollouts"),
+	),
+	mcp.WithBoolean("ha",
+		mcp.Description("Run 2 manager replicas with leader election, a PodDisruptionBudget and a PriorityClass"),
+	),
+	mcp.WithString("priority_class",
+		mcp.Description("Existing PriorityClass for the manager pods (default with 'ha': a generated one)"),
+	),
+	mcp.WithString("watch_namespaces",
+		mcp.Description("Comma-separated namespaces the operator watches by default; its --watch-namespaces flag overrides them (default: all namespaces)"),
+	),
+	mcp.WithBoolean("tracing",
+		mcp.Description("Export OpenTelemetry spans for reconciles and REST API calls from the generated manager"),
+	),
+	mcp.WithBoolean("profile",
+		mcp.Description("Expose /debug/pprof in the generated manager"),
+	),
+	mcp.WithString("pprof_addr",
+		mcp.Description("Default bind address of the generated manager's pprof handler (default: 127.0.0.1:6060)"),
+	),
+	mcp.WithBoolean("embed_spec",
+		mcp.Description("Embed the OpenAPI spec in the operator binary (spec_embed.go) and serve it on the metrics server"),
+	),
+	mcp.WithBoolean("kubebuilder",
+		mcp.Description("Write a kubebuilder PROJECT file and the manager entrypoint to cmd/main.go, so kubebuilder create api/webhook work on the operator"),
+	),
+	mcp.WithString("controller_base_image",
+		mcp.Description("Builder stage image of the generated Dockerfile (default: golang:1.25)"),
+	),
+	mcp.WithString("runtime_image",
+		mcp.Description("Runtime stage image of the generated Dockerfile; the manager runs as UID 65532, so the image must not need root (default: gcr.io/distroless/static:nonroot)"),
+	),
+	mcp.WithBoolean("dashboard",
+		mcp.Description("Generate a Grafana dashboard (dashboards/<app>.json) for the operator's reconcile, external API and custom resource metrics"),
+	),
+	mcp.WithBoolean("tilt",
+		mcp.Description("Generate a Tiltfile for a live-reload development loop"),
+	),
+	mcp.WithBoolean("e2e",
+		mcp.Description("Generate an end-to-end test (test/e2e, make test-e2e) that deploys the operator, and the target API when target_api_image is set, to a kind cluster and waits for a sample CR to become Ready"),
+	),
+	mcp.WithBoolean("webhook_receiver",
+		mcp.Description("Generate a receiver for the spec's OpenAPI 3.1 webhooks (internal/webhookreceiver) that creates or updates CRs when the REST API calls back"),
+	),
 	mcp.WithString("managed_crs",
 		mcp.Description("Directory containing CR YAML files for managed Rundeck lifecycle jobs"),
 	),
@@ -182,6 +366,9 @@ var describeTool = mcp.NewTool("describe",
 		mcp.Required(),
 		mcp.Description("Path to the generated operator directory (must contain .openapi-operator-gen.yaml)"),
 	),
+	mcp.WithBoolean("files",
+		mcp.Description("List the actual files in the directory instead of the general ownership guidance, each marked regenerated, modified (hand-edited since generation), user-owned, orphaned, derived (written by make generate) or missing. Useful before regenerating."),
+	),
 )
 
 var regenerateTool = mcp.NewTool("regenerate",
@@ -193,6 +380,18 @@ var regenerateTool = mcp.NewTool("regenerate",
 	mcp.WithString("spec",
 		mcp.Description("Override the OpenAPI spec path or URL"),
 	),
+	mcp.WithString("spec_root_file",
+		mcp.Description("Override the root document inside a spec directory"),
+	),
+	mcp.WithBoolean("merge",
+		mcp.Description("Preserve hand-edited controllers: overwrite a controller only if it is unchanged since generation, otherwise write the new version as <file>.new for manual merging"),
+	),
+	mcp.WithBoolean("patch_existing_crds",
+		mcp.Description("Write MIGRATION.md with suggested kubectl commands for migrating existing CRs across the CRD changes since the previous generation (renamed, removed and retyped fields, newly required fields)"),
+	),
+	mcp.WithString("only_kind",
+		mcp.Description("Regenerate only this Kind's types, CRD YAML, samples, controller and controller tests from the current spec, leaving every other file untouched. The Kind must be in both the spec and the last generation; add or remove Kinds with a full regenerate"),
+	),
 	mcp.WithString("group",
 		mcp.Description("Override Kubernetes API group"),
 	),
@@ -223,6 +422,10 @@ var regenerateTool = mcp.NewTool("regenerate",
 	mcp.WithString("exclude_paths",
 		mcp.Description("Override: path exclude patterns (comma-separated)"),
 	),
+	mcp.WithArray("set",
+		mcp.WithStringItems(),
+		mcp.Description("Override any saved setting as key=value, where key is its .openapi-operator-gen.yaml key (dotted for nested ones) and value is YAML, e.g. [\"useETag=true\", \"httpTransport.idleConnTimeout=30s\", \"filters.excludeTags=[internal]\"]. Applied before the dedicated override parameters"),
+	),
 )
 
 var diffTool = mcp.NewTool("diff",
@@ -236,10 +439,13 @@ var diffTool = mcp.NewTool("diff",
 	mcp.WithString("spec",
 		mcp.Description("Override the new spec path to compare against (default: uses spec path from saved config)"),
 	),
+	mcp.WithString("since",
+		mcp.Description("Git ref or tag (e.g., v1.0.0) to compare against instead of the last generation, showing the cumulative CRD changes since that release. Reads the spec copy committed at the ref in the operator directory's repository."),
+	),
 )
 
 var explainTool = mcp.NewTool("explain",
-	mcp.WithDescription("Explain the reconciliation logic for a CRD kind in plain language. Shows what HTTP calls it makes, how create/update/delete work, finalizer behavior, drift detection, and status conditions. Works from the spec — does not require reading generated source code."),
+	mcp.WithDescription("Explain the reconciliation logic for a CRD kind in plain language. Shows what HTTP calls it makes, how create/update/delete work, finalizer behavior, drift detection, and status conditions. Also covers the aggregate and bundle CRDs (CEL evaluation, child ordering, status rollup). Works from the spec — does not require reading generated source code."),
 	mcp.WithReadOnlyHintAnnotation(true),
 	mcp.WithDestructiveHintAnnotation(false),
 	mcp.WithString("directory",
@@ -248,7 +454,7 @@ var explainTool = mcp.NewTool("explain",
 	),
 	mcp.WithString("kind",
 		mcp.Required(),
-		mcp.Description("CRD Kind name to explain (e.g., Pet, FindPetsByStatusQuery)"),
+		mcp.Description("CRD Kind name to explain (e.g., Pet, FindPetsByStatusQuery, PetstoreAggregate, PetstoreBundle)"),
 	),
 )
 
@@ -266,6 +472,29 @@ var sampleTool = mcp.NewTool("sample",
 	),
 )
 
+var doctorTool = mcp.NewTool("doctor",
+	mcp.WithDescription("Check a previously generated operator for common problems and return a prioritized checklist: spec drift since the last generation, generator version mismatch, go.mod not tidied, CRD manifests close to or over the kubectl apply size limit, missing, edited or orphaned controllers, and CRDs not registered in cmd/manager/main.go. Each problem comes with a suggested fix."),
+	mcp.WithReadOnlyHintAnnotation(true),
+	mcp.WithDestructiveHintAnnotation(false),
+	mcp.WithString("directory",
+		mcp.Required(),
+		mcp.Description("Path to the generated operator directory (must contain .openapi-operator-gen.yaml)"),
+	),
+)
+
+var lintSpecTool = mcp.NewTool("lint-spec",
+	mcp.WithDescription("Check an OpenAPI spec for quality problems before generating an operator from it, with built-in rules: spec validity, operations without an operationId or a 2xx response schema, parameters without a type, inconsistent path segment casing and unused components. Returns the findings grouped by severity (error, warning, info)."),
+	mcp.WithReadOnlyHintAnnotation(true),
+	mcp.WithDestructiveHintAnnotation(false),
+	mcp.WithString("spec",
+		mcp.Required(),
+		mcp.Description("Path or URL to the OpenAPI specification file, or a directory of split spec files"),
+	),
+	mcp.WithString("spec_root_file",
+		mcp.Description("Root document when 'spec' is a directory of split files, relative to it (default: auto-detect)"),
+	),
+)
+
 // Prompt definitions
 
 var generateOperatorPrompt = mcp.NewPrompt("generate-operator",
@@ -328,6 +557,8 @@ func (h *handlers) handleValidate(_ context.Context, req mcp.CallToolRequest) (*
 	}
 
 	p := parser.NewParser()
+	p.SpecRootFile = mcp.ParseString(req, "spec_root_file", "")
+	p.LogWriter = io.Discard
 	spec, err := p.Parse(specPath)
 	if err != nil {
 		return mcp.NewToolResultError(fmt.Sprintf("Failed to parse OpenAPI spec: %v", err)), nil
@@ -363,10 +594,12 @@ func (h *handlers) handlePreview(_ context.Context, req mcp.CallToolRequest) (*m
 	}
 
 	cfg := &config.Config{
-		SpecPath:    specPath,
-		APIGroup:    mcp.ParseString(req, "group", "example.com"),
-		APIVersion:  "v1alpha1",
-		MappingMode: config.PerResource,
+		SpecPath:     specPath,
+		SpecRootFile: mcp.ParseString(req, "spec_root_file", ""),
+		SpecCacheDir: mcp.ParseString(req, "spec_cache", ""),
+		APIGroup:     mcp.ParseString(req, "group", "example.com"),
+		APIVersion:   "v1alpha1",
+		MappingMode:  config.PerResource,
 	}
 	cfg.IncludePaths = parseCommaSeparated(mcp.ParseString(req, "include_paths", ""))
 	cfg.ExcludePaths = parseCommaSeparated(mcp.ParseString(req, "exclude_paths", ""))
@@ -374,9 +607,16 @@ func (h *handlers) handlePreview(_ context.Context, req mcp.CallToolRequest) (*m
 	cfg.ExcludeTags = parseCommaSeparated(mcp.ParseString(req, "exclude_tags", ""))
 	cfg.IncludeOperations = parseCommaSeparated(mcp.ParseString(req, "include_operations", ""))
 	cfg.ExcludeOperations = parseCommaSeparated(mcp.ParseString(req, "exclude_operations", ""))
+	cfg.PluralOverrides = parseIDFieldMap(mcp.ParseString(req, "plural_overrides", ""))
+	cfg.ConstantPathParams = parseIDFieldMap(mcp.ParseString(req, "exclude_path_params", ""))
 
 	filter := config.NewPathFilter(cfg)
 	p := parser.NewParserWithFilter(cfg.RootKind, filter)
+	p.SpecRootFile = cfg.SpecRootFile
+	p.SpecFormat = cfg.SpecFormat
+	p.ConstantPathParams = cfg.ConstantPathParams
+	p.SpecCacheDir = cfg.SpecCacheDir
+	p.LogWriter = io.Discard
 	spec, err := p.Parse(specPath)
 	if err != nil {
 		return mcp.NewToolResultError(fmt.Sprintf("Failed to parse OpenAPI spec: %v", err)), nil
@@ -389,6 +629,10 @@ func (h *handlers) handlePreview(_ context.Context, req mcp.CallToolRequest) (*m
 	}
 
 	var b strings.Builder
+	if mcp.ParseBoolean(req, "list_kinds", false) {
+		_ = mapper.WriteKindList(&b, crds)
+		return mcp.NewToolResultText(b.String()), nil
+	}
 
 	// Header with spec metadata
 	if spec.Title != "" {
@@ -404,6 +648,7 @@ func (h *handlers) handlePreview(_ context.Context, req mcp.CallToolRequest) (*m
 	if spec.BaseURL != "" {
 		fmt.Fprintf(&b, "Base URL: %s\n", spec.BaseURL)
 	}
+	writeServers(&b, spec.Servers, "", "")
 	b.WriteString("\n")
 
 	formatCRDs(&b, crds)
@@ -433,14 +678,10 @@ func (h *handlers) runGeneration(cfg *config.Config) (*mcp.CallToolResult, error
 		cfg.SpecHash = hash
 	}
 
-	// Parse spec
-	filter := config.NewPathFilter(cfg)
-	p := parser.NewParserWithFilter(cfg.RootKind, filter)
-	spec, err := p.Parse(cfg.SpecPath)
-	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to parse OpenAPI spec: %v", err)), nil
+	spec, errResult := parseSpecInto(cfg)
+	if errResult != nil {
+		return errResult, nil
 	}
-	cfg.SpecBaseURL = spec.BaseURL
 
 	// Map resources to CRDs
 	m := mapper.NewMapper(cfg)
@@ -454,6 +695,18 @@ func (h *handlers) runGeneration(cfg *config.Config) (*mcp.CallToolResult, error
 		len(spec.Resources), len(spec.QueryEndpoints), len(spec.ActionEndpoints)))
 	messages = append(messages, fmt.Sprintf("Mapped to %d CRD definitions", len(crds)))
 
+	// Migration guide from the previous generation, before its spec copy is replaced
+	if cfg.PatchExistingCRDs {
+		oldCRDs, oldSpecVersion, err := generator.PreviousCRDs(cfg.OutputDir)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("patch_existing_crds: %v", err)), nil
+		}
+		if err := generator.NewMigrationGenerator(cfg).Generate(oldCRDs, crds, oldSpecVersion); err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to generate MIGRATION.md: %v", err)), nil
+		}
+		messages = append(messages, "Generated MIGRATION.md")
+	}
+
 	// Generate types
 	typesGen := generator.NewTypesGenerator(cfg)
 	if err := typesGen.Generate(crds); err != nil {
@@ -461,13 +714,19 @@ func (h *handlers) runGeneration(cfg *config.Config) (*mcp.CallToolResult, error
 	}
 	messages = append(messages, "Generated api/<version>/types.go")
 
-	// Generate CRD YAML (optional)
-	if cfg.GenerateCRDs {
+	// Generate CRD YAML (optional; always written in types-only mode)
+	if cfg.GenerateCRDs || cfg.TypesOnly {
 		crdGen := generator.NewCRDGenerator(cfg)
 		if err := crdGen.Generate(crds); err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to generate CRD YAML: %v", err)), nil
 		}
 		messages = append(messages, "Generated config/crd/bases/*.yaml")
+		for _, warning := range crdGen.SizeWarnings {
+			messages = append(messages, "Warning: "+warning)
+		}
+		if len(crdGen.SizeWarnings) > 0 {
+			messages = append(messages, "To shrink them: "+generator.CRDSizeFix)
+		}
 	}
 
 	// Aggregate CRD
@@ -497,12 +756,27 @@ func (h *handlers) runGeneration(cfg *config.Config) (*mcp.CallToolResult, error
 	}
 	messages = append(messages, "Generated config/samples/*.yaml")
 
+	if cfg.TypesOnly {
+		messages = append(messages, "Types only: skipped controllers, main.go, go.mod, Dockerfile and Makefile")
+		return generationResult(cfg, crds, messages)
+	}
+
 	// Controllers
 	controllerGen := generator.NewControllerGenerator(cfg)
 	if err := controllerGen.Generate(crds, aggregate, bundle); err != nil {
 		return mcp.NewToolResultError(fmt.Sprintf("Failed to generate controllers: %v", err)), nil
 	}
 	messages = append(messages, "Generated controllers, main.go, Dockerfile, Makefile")
+	for _, f := range controllerGen.PendingMerges() {
+		messages = append(messages, fmt.Sprintf("Kept hand-edited %s; wrote %s for manual merge", strings.TrimSuffix(f, ".new"), f))
+	}
+	if cfg.GenerateWebhookReceiver {
+		if len(cfg.SpecWebhooks) > 0 {
+			messages = append(messages, "Generated internal/webhookreceiver/receiver.go and config/webhook-receiver")
+		} else {
+			messages = append(messages, "Skipped the webhook receiver: the spec declares no webhooks")
+		}
+	}
 
 	if cfg.TargetAPIImage != "" {
 		if err := controllerGen.GenerateTargetAPIDeployment(); err != nil {
@@ -515,6 +789,28 @@ func (h *handlers) runGeneration(cfg *config.Config) (*mcp.CallToolResult, error
 		return mcp.NewToolResultError(fmt.Sprintf("Failed to generate docker-compose.yaml: %v", err)), nil
 	}
 
+	if cfg.GenerateTilt {
+		if err := controllerGen.GenerateTilt(); err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to generate Tiltfile: %v", err)), nil
+		}
+		messages = append(messages, "Generated Tiltfile")
+	}
+
+	if cfg.GenerateE2E {
+		if err := controllerGen.GenerateE2E(crds); err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to generate e2e test: %v", err)), nil
+		}
+		messages = append(messages, "Generated test/e2e/e2e_test.go")
+	}
+
+	if cfg.GenerateDashboard {
+		path, err := controllerGen.GenerateDashboard(crds, aggregate, bundle)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to generate Grafana dashboard: %v", err)), nil
+		}
+		messages = append(messages, "Generated "+path)
+	}
+
 	// Aggregate controller
 	if aggregate != nil {
 		if err := controllerGen.GenerateAggregateController(aggregate); err != nil {
@@ -600,6 +896,117 @@ func (h *handlers) runGeneration(cfg *config.Config) (*mcp.CallToolResult, error
 		messages = append(messages, "Generated Rundeck projects")
 	}
 
+	return generationResult(cfg, crds, messages)
+}
+
+// parseSpecInto parses cfg's spec and records what the generators need from it (base
+// URL, servers, version, namespace) in cfg. On failure it returns the tool error.
+func parseSpecInto(cfg *config.Config) (*parser.ParsedSpec, *mcp.CallToolResult) {
+	filter := config.NewPathFilter(cfg)
+	p := parser.NewParserWithFilter(cfg.RootKind, filter)
+	p.SpecRootFile = cfg.SpecRootFile
+	p.SpecFormat = cfg.SpecFormat
+	p.ConstantPathParams = cfg.ConstantPathParams
+	p.SpecCacheDir = cfg.SpecCacheDir
+	p.LogWriter = io.Discard
+	spec, err := p.Parse(cfg.SpecPath)
+	if err != nil {
+		return nil, mcp.NewToolResultError(fmt.Sprintf("Failed to parse OpenAPI spec: %v", err))
+	}
+	cfg.SpecBaseURL = spec.BaseURL
+	cfg.SpecServers = specServers(spec.Servers)
+	cfg.SpecWebhooks = specWebhooks(spec.Webhooks)
+	if err := cfg.ApplyEnvironment(); err != nil {
+		return nil, mcp.NewToolResultError(fmt.Sprintf("Invalid environment: %v", err))
+	}
+	cfg.SpecVersion = spec.Version
+	cfg.SpecHomepage = spec.Homepage
+	if err := cfg.ApplySpecNamespace(spec.Namespace); err != nil {
+		return nil, mcp.NewToolResultError(fmt.Sprintf("Invalid OpenAPI spec: %v", err))
+	}
+	return spec, nil
+}
+
+// runKindGeneration regenerates one Kind's types, CRD YAML, samples, controller and
+// controller tests, leaving every other file alone. The saved spec hash is kept, so
+// diff still reports the spec changes the other Kinds haven't picked up; the spec the
+// Kind was regenerated from is saved for it, so later partial runs keep its types.
+func (h *handlers) runKindGeneration(cfg *config.Config, kind string) (*mcp.CallToolResult, error) {
+	if err := cfg.Validate(); err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid configuration: %v", err)), nil
+	}
+	spec, errResult := parseSpecInto(cfg)
+	if errResult != nil {
+		return errResult, nil
+	}
+	crds, err := mapper.NewMapper(cfg).MapResources(spec)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to map resources: %v", err)), nil
+	}
+	plan, err := generator.PlanKindRegeneration(cfg.OutputDir, crds, kind)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("only_kind: %v", err)), nil
+	}
+
+	var messages []string
+	if err := generator.NewTypesGenerator(cfg).Generate(plan.TypesCRDs); err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to generate types: %v", err)), nil
+	}
+	messages = append(messages, fmt.Sprintf("Regenerated the %s types in api/%s/types.go", kind, cfg.APIVersion))
+
+	if cfg.GenerateCRDs || cfg.TypesOnly {
+		if err := generator.NewCRDGenerator(cfg).GenerateKind(plan.CRD); err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to generate CRD YAML: %v", err)), nil
+		}
+		messages = append(messages, fmt.Sprintf("Regenerated config/crd/bases/%s_%s.yaml", cfg.APIGroup, plan.CRD.Plural))
+	}
+
+	if err := generator.NewSamplesGenerator(cfg).GenerateKind(plan.CRD); err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to generate samples: %v", err)), nil
+	}
+	messages = append(messages, fmt.Sprintf("Regenerated the %s samples", kind))
+
+	if !cfg.TypesOnly {
+		controllerGen := generator.NewControllerGenerator(cfg)
+		if err := controllerGen.GenerateKind(plan.CRD); err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to generate controller: %v", err)), nil
+		}
+		messages = append(messages, fmt.Sprintf("Regenerated the %s controller and its tests", kind))
+		for _, f := range controllerGen.PendingMerges() {
+			messages = append(messages, fmt.Sprintf("Kept hand-edited %s; wrote %s for manual merge", strings.TrimSuffix(f, ".new"), f))
+		}
+	}
+
+	if err := generator.SaveKindSpec(cfg, kind); err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to save the %s spec copy: %v", kind, err)), nil
+	}
+	messages = append(messages, fmt.Sprintf("Saved the spec %s was regenerated from in .kind-specs/%s", kind, kind))
+
+	if err := generator.SaveManifest(cfg); err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to save generation manifest: %v", err)), nil
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "Regenerated %s only; all other files were left untouched.\n\n", kind)
+	for _, msg := range messages {
+		fmt.Fprintf(&b, "- %s\n", msg)
+	}
+	b.WriteString("\nThe saved spec hash is unchanged, so diff still shows spec changes for the other Kinds.\n")
+	if !cfg.TypesOnly {
+		b.WriteString("\nNext steps:\n")
+		fmt.Fprintf(&b, "  1. cd %s\n", cfg.OutputDir)
+		b.WriteString("  2. make generate  # Regenerate deep copy methods and CRD manifests\n")
+		b.WriteString("  3. make build && make test\n")
+	}
+	return mcp.NewToolResultText(b.String()), nil
+}
+
+// generationResult saves the generation manifest and summarizes a generation run
+func generationResult(cfg *config.Config, crds []*mapper.CRDDefinition, messages []string) (*mcp.CallToolResult, error) {
+	if err := generator.SaveManifest(cfg); err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to save generation manifest: %v", err)), nil
+	}
+
 	// Build result summary
 	messages = append(messages, "Saved .openapi-operator-gen.yaml config")
 	var b strings.Builder
@@ -612,6 +1019,12 @@ func (h *handlers) runGeneration(cfg *config.Config) (*mcp.CallToolResult, error
 		fmt.Fprintf(&b, "- %s (%s)\n", crd.Kind, crd.Plural)
 	}
 	b.WriteString("\nNext steps:\n")
+	if cfg.TypesOnly {
+		fmt.Fprintf(&b, "  1. Copy %s/api/%s into your operator module\n", cfg.OutputDir, cfg.APIVersion)
+		b.WriteString("  2. controller-gen object paths=./api/...  # Generate deep copy methods\n")
+		fmt.Fprintf(&b, "  3. kubectl apply -f %s/config/crd/bases  # Install CRDs to cluster\n", cfg.OutputDir)
+		return mcp.NewToolResultText(b.String()), nil
+	}
 	fmt.Fprintf(&b, "  1. cd %s\n", cfg.OutputDir)
 	b.WriteString("  2. go mod tidy\n")
 	b.WriteString("  3. make generate  # Generate deep copy methods and CRD manifests\n")
@@ -765,6 +1178,11 @@ func (h *handlers) handleDescribe(_ context.Context, req mcp.CallToolRequest) (*
 	// Parse spec and map to CRDs
 	filter := config.NewPathFilter(cfg)
 	p := parser.NewParserWithFilter(cfg.RootKind, filter)
+	p.SpecRootFile = cfg.SpecRootFile
+	p.SpecFormat = cfg.SpecFormat
+	p.ConstantPathParams = cfg.ConstantPathParams
+	p.SpecCacheDir = cfg.SpecCacheDir
+	p.LogWriter = io.Discard
 	spec, err := p.Parse(cfg.SpecPath)
 	if err != nil {
 		return mcp.NewToolResultError(fmt.Sprintf("Failed to parse OpenAPI spec at %s: %v", cfg.SpecPath, err)), nil
@@ -804,6 +1222,21 @@ func (h *handlers) handleDescribe(_ context.Context, req mcp.CallToolRequest) (*
 	if spec.BaseURL != "" {
 		fmt.Fprintf(&b, "  Base URL:    %s\n", spec.BaseURL)
 	}
+	selector := cfg.ServerSelector
+	if cfg.Environment != "" {
+		environment := "no server has this x-environment"
+		for _, s := range spec.Servers {
+			if strings.EqualFold(s.Environment, cfg.Environment) {
+				environment = s.URL
+				break
+			}
+		}
+		fmt.Fprintf(&b, "  Environment: %s (%s)\n", cfg.Environment, environment)
+		if selector == "" {
+			selector = cfg.Environment
+		}
+	}
+	writeServers(&b, spec.Servers, "  ", selector)
 
 	// Spec status with hash comparison
 	fmt.Fprintf(&b, "  Spec:        %s", cfg.SpecPath)
@@ -849,6 +1282,9 @@ func (h *handlers) handleDescribe(_ context.Context, req mcp.CallToolRequest) (*
 	if cfg.GenerateCRDs {
 		b.WriteString("  CRD YAML gen:       enabled\n")
 	}
+	if cfg.TypesOnly {
+		b.WriteString("  Types only:         enabled (no operator scaffold)\n")
+	}
 	if len(cfg.UpdateWithPost) > 0 {
 		fmt.Fprintf(&b, "  Update with POST:   %s\n", strings.Join(cfg.UpdateWithPost, ", "))
 	}
@@ -883,10 +1319,24 @@ func (h *handlers) handleDescribe(_ context.Context, req mcp.CallToolRequest) (*
 	formatCRDs(&b, crds)
 	b.WriteString("\n")
 
+	if mcp.ParseBoolean(req, "files", false) {
+		cfg.GeneratedFiles = file.GeneratedFiles
+		cfg.ControllerHashes = file.ControllerHashes
+		writeFileInventory(&b, cfg)
+		return mcp.NewToolResultText(b.String()), nil
+	}
+
 	// File ownership
 	b.WriteString("FILE OWNERSHIP:\n\n")
 	b.WriteString("  Regenerated (overwritten on re-generation — do not hand-edit):\n")
 	fmt.Fprintf(&b, "    api/%s/              CRD Go types with kubebuilder markers\n", cfg.APIVersion)
+	if cfg.TypesOnly {
+		b.WriteString("    config/crd/            CRD YAML manifests\n\n")
+		b.WriteString("  Safe to customize:\n")
+		b.WriteString("    config/samples/        Example CR YAML files\n\n")
+		b.WriteString("  Types-only output: no controllers, main.go, go.mod, Dockerfile or Makefile are generated.\n")
+		return mcp.NewToolResultText(b.String()), nil
+	}
 	b.WriteString("    internal/controller/   Reconciliation logic for each CRD\n")
 	b.WriteString("    config/crd/            CRD YAML manifests\n")
 	b.WriteString("    main.go                Controller manager entrypoint\n")
@@ -900,6 +1350,66 @@ func (h *handlers) handleDescribe(_ context.Context, req mcp.CallToolRequest) (*
 	return mcp.NewToolResultText(b.String()), nil
 }
 
+// writeFileInventory lists the files of a generated operator with their ownership,
+// grouping the ones that need attention before regeneration first
+func writeFileInventory(b *strings.Builder, cfg *config.Config) {
+	b.WriteString("FILES:\n\n")
+	entries, err := doctor.Inventory(cfg)
+	if err != nil {
+		fmt.Fprintf(b, "  %v\n", err)
+		return
+	}
+
+	counts := make(map[doctor.FileStatus]int)
+	for _, e := range entries {
+		counts[e.Status]++
+		fmt.Fprintf(b, "  %-12s %s\n", e.Status, e.Path)
+	}
+
+	b.WriteString("\n ")
+	for _, status := range []doctor.FileStatus{doctor.FileRegenerated, doctor.FileModified, doctor.FileMissing, doctor.FileOrphaned, doctor.FileDerived, doctor.FileUserOwned} {
+		fmt.Fprintf(b, " %d %s", counts[status], status)
+	}
+	b.WriteString("\n")
+	if counts[doctor.FileModified] > 0 {
+		b.WriteString("\n  Modified files are overwritten on regeneration; controllers can be kept with merge: true, other edits should move to user-owned files.\n")
+	}
+	if counts[doctor.FileOrphaned] > 0 {
+		b.WriteString("\n  Orphaned files weren't written by the last generation; delete them if their CRD was removed from the spec.\n")
+	}
+}
+
+// handleDoctor checks a generated operator directory for common problems.
+func (h *handlers) handleDoctor(_ context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	directory := mcp.ParseString(req, "directory", "")
+	if directory == "" {
+		return mcp.NewToolResultError("'directory' parameter is required"), nil
+	}
+
+	report, err := doctor.Run(directory, h.version)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	return mcp.NewToolResultText(report.String()), nil
+}
+
+// handleLintSpec checks an OpenAPI spec against the built-in lint rules.
+func (h *handlers) handleLintSpec(_ context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	specPath := mcp.ParseString(req, "spec", "")
+	if specPath == "" {
+		return mcp.NewToolResultError("'spec' parameter is required"), nil
+	}
+
+	p := parser.NewParser()
+	p.SpecRootFile = mcp.ParseString(req, "spec_root_file", "")
+	p.LogWriter = io.Discard
+	report, err := lint.Run(p, specPath)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to load OpenAPI spec: %v", err)), nil
+	}
+	return mcp.NewToolResultText(report.String()), nil
+}
+
 // handleRegenerate re-runs generation using saved config with optional overrides.
 func (h *handlers) handleRegenerate(_ context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 	directory := mcp.ParseString(req, "directory", "")
@@ -917,6 +1427,12 @@ func (h *handlers) handleRegenerate(_ context.Context, req mcp.CallToolRequest)
 		return mcp.NewToolResultError(fmt.Sprintf("No .openapi-operator-gen.yaml found in %s", directory)), nil
 	}
 
+	for _, pair := range req.GetStringSlice("set", nil) {
+		if err := file.Set(pair); err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Invalid 'set' override: %v", err)), nil
+		}
+	}
+
 	cfg := config.ConfigFromFile(file)
 	cfg.OutputDir = directory
 	cfg.GeneratorVersion = h.version
@@ -927,6 +1443,11 @@ func (h *handlers) handleRegenerate(_ context.Context, req mcp.CallToolRequest)
 	if v := mcp.ParseString(req, "spec", ""); v != "" {
 		cfg.SpecPath = v
 	}
+	if v := mcp.ParseString(req, "spec_root_file", ""); v != "" {
+		cfg.SpecRootFile = v
+	}
+	cfg.MergeControllers = mcp.ParseBoolean(req, "merge", false)
+	cfg.PatchExistingCRDs = mcp.ParseBoolean(req, "patch_existing_crds", false)
 	if v := mcp.ParseString(req, "group", ""); v != "" {
 		cfg.APIGroup = v
 	}
@@ -958,6 +1479,17 @@ func (h *handlers) handleRegenerate(_ context.Context, req mcp.CallToolRequest)
 		cfg.ExcludePaths = v
 	}
 
+	if kind := mcp.ParseString(req, "only_kind", ""); kind != "" {
+		if cfg.PatchExistingCRDs {
+			return mcp.NewToolResultError("only_kind can't be combined with patch_existing_crds"), nil
+		}
+		// Keep the manifest entries of the files this run doesn't write
+		cfg.GeneratedFiles = file.GeneratedFiles
+		cfg.ControllerHashes = file.ControllerHashes
+		cfg.KindSpecHashes = file.KindSpecHashes
+		return h.runKindGeneration(cfg, kind)
+	}
+
 	return h.runGeneration(cfg)
 }
 
@@ -986,9 +1518,13 @@ func (h *handlers) handleDiff(_ context.Context, req mcp.CallToolRequest) (*mcp.
 	if newSpecPath == "" {
 		newSpecPath = cfg.SpecPath
 	}
+	since := mcp.ParseString(req, "since", "")
+	if strings.HasPrefix(since, "-") {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid 'since' ref %q", since)), nil
+	}
 
-	// Fast path: check spec hash
-	if cfg.SpecHash != "" {
+	// Fast path: check spec hash (it only describes the last generation, not an older ref)
+	if cfg.SpecHash != "" && since == "" {
 		currentHash, hashErr := config.HashSpecFile(newSpecPath)
 		if hashErr == nil && currentHash == cfg.SpecHash {
 			msg := fmt.Sprintf(
@@ -1006,11 +1542,27 @@ func (h *handlers) handleDiff(_ context.Context, req mcp.CallToolRequest) (*mcp.
 	specBasename := filepath.Base(cfg.SpecPath)
 	embeddedSpecPath := filepath.Join(directory, specBasename)
 
-	// Try git first to get the committed version
+	// Try git first to get the committed version. git runs in the operator directory so
+	// a repository created there (e.g., by generate --git-init) is found.
 	var oldSpecPath string
-	gitRef := fmt.Sprintf("HEAD:%s", embeddedSpecPath)
+	ref := "HEAD"
+	if since != "" {
+		ref = since
+	}
+	gitRef := fmt.Sprintf("%s:./%s", ref, specBasename)
 	gitCmd := exec.Command("git", "show", gitRef)
+	gitCmd.Dir = directory
 	gitOutput, gitErr := gitCmd.Output()
+	if since != "" && (gitErr != nil || len(gitOutput) == 0) {
+		// An explicit ref has no fallback: the embedded copy is the last generation, not the ref
+		detail := "empty file"
+		if exitErr, ok := gitErr.(*exec.ExitError); ok {
+			detail = strings.TrimSpace(string(exitErr.Stderr))
+		} else if gitErr != nil {
+			detail = gitErr.Error()
+		}
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to read %s at %s: %s", specBasename, since, detail)), nil
+	}
 	if gitErr == nil && len(gitOutput) > 0 {
 		// Write git content to a temp file for parsing
 		tmpFile := filepath.Join(directory, ".openapi-operator-gen-diff-old-spec.tmp")
@@ -1040,6 +1592,11 @@ func (h *handlers) handleDiff(_ context.Context, req mcp.CallToolRequest) (*mcp.
 	// Parse old spec
 	oldFilter := config.NewPathFilter(cfg)
 	oldParser := parser.NewParserWithFilter(cfg.RootKind, oldFilter)
+	oldParser.SpecRootFile = cfg.SpecRootFile
+	oldParser.SpecFormat = cfg.SpecFormat
+	oldParser.ConstantPathParams = cfg.ConstantPathParams
+	oldParser.SpecCacheDir = cfg.SpecCacheDir
+	oldParser.LogWriter = io.Discard
 	oldSpec, err := oldParser.Parse(oldSpecPath)
 	if err != nil {
 		return mcp.NewToolResultError(fmt.Sprintf("Failed to parse old spec: %v", err)), nil
@@ -1053,6 +1610,11 @@ func (h *handlers) handleDiff(_ context.Context, req mcp.CallToolRequest) (*mcp.
 	// Parse new spec
 	newFilter := config.NewPathFilter(cfg)
 	newParser := parser.NewParserWithFilter(cfg.RootKind, newFilter)
+	newParser.SpecRootFile = cfg.SpecRootFile
+	newParser.SpecFormat = cfg.SpecFormat
+	newParser.ConstantPathParams = cfg.ConstantPathParams
+	newParser.SpecCacheDir = cfg.SpecCacheDir
+	newParser.LogWriter = io.Discard
 	newSpec, err := newParser.Parse(newSpecPath)
 	if err != nil {
 		return mcp.NewToolResultError(fmt.Sprintf("Failed to parse new spec: %v", err)), nil
@@ -1100,18 +1662,26 @@ func (h *handlers) handleDiff(_ context.Context, req mcp.CallToolRequest) (*mcp.
 
 	// Format output
 	var b strings.Builder
-	fmt.Fprintf(&b, "Spec Diff: %s\n", filepath.Base(newSpecPath))
+	if since != "" {
+		fmt.Fprintf(&b, "Spec Diff: %s (since %s)\n", filepath.Base(newSpecPath), since)
+	} else {
+		fmt.Fprintf(&b, "Spec Diff: %s\n", filepath.Base(newSpecPath))
+	}
 	fmt.Fprintf(&b, "Summary: %d added, %d removed, %d changed, %d unchanged\n",
 		len(added), len(removed), len(changed), len(unchanged))
 
-	if cfg.GeneratorVersion != "" && cfg.GeneratorVersion != h.version {
+	if since == "" && cfg.GeneratorVersion != "" && cfg.GeneratorVersion != h.version {
 		fmt.Fprintf(&b, "\nNote: Generator version has also changed (%s → %s). Regeneration will update both spec changes and generated code templates.\n",
 			cfg.GeneratorVersion, h.version)
 	}
 	b.WriteString("\n")
 
 	if len(added) == 0 && len(removed) == 0 && len(changed) == 0 {
-		b.WriteString("No changes detected. The spec matches the last generation.\n")
+		if since != "" {
+			fmt.Fprintf(&b, "No changes detected. The spec maps to the same CRDs as at %s.\n", since)
+		} else {
+			b.WriteString("No changes detected. The spec matches the last generation.\n")
+		}
 		return mcp.NewToolResultText(b.String()), nil
 	}
 
@@ -1153,9 +1723,17 @@ func (h *handlers) handleDiff(_ context.Context, req mcp.CallToolRequest) (*mcp.
 	return mcp.NewToolResultText(b.String()), nil
 }
 
+// loadedKind is a kind resolved by loadCRD. Exactly one of CRD, Aggregate or Bundle is set.
+type loadedKind struct {
+	CRD       *mapper.CRDDefinition
+	Aggregate *mapper.AggregateDefinition
+	Bundle    *mapper.BundleDefinition
+}
+
 // loadCRD loads the config from a generated operator directory, parses the spec,
-// maps to CRDs, and returns the CRD matching the given kind name.
-func (h *handlers) loadCRD(directory, kind string) (*config.Config, *mapper.CRDDefinition, error) {
+// maps to CRDs, and returns the definition matching the given kind name. The
+// aggregate and bundle kinds are considered when the operator generates them.
+func (h *handlers) loadCRD(directory, kind string) (*config.Config, *loadedKind, error) {
 	configPath := filepath.Join(directory, ".openapi-operator-gen.yaml")
 	file, err := config.LoadConfigFile(configPath)
 	if err != nil {
@@ -1170,10 +1748,18 @@ func (h *handlers) loadCRD(directory, kind string) (*config.Config, *mapper.CRDD
 
 	filter := config.NewPathFilter(cfg)
 	p := parser.NewParserWithFilter(cfg.RootKind, filter)
+	p.SpecRootFile = cfg.SpecRootFile
+	p.SpecFormat = cfg.SpecFormat
+	p.ConstantPathParams = cfg.ConstantPathParams
+	p.SpecCacheDir = cfg.SpecCacheDir
+	p.LogWriter = io.Discard
 	spec, err := p.Parse(cfg.SpecPath)
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to parse spec at %s: %w", cfg.SpecPath, err)
 	}
+	if err := cfg.ApplySpecNamespace(spec.Namespace); err != nil {
+		return nil, nil, err
+	}
 
 	m := mapper.NewMapper(cfg)
 	crds, err := m.MapResources(spec)
@@ -1187,7 +1773,23 @@ func (h *handlers) loadCRD(directory, kind string) (*config.Config, *mapper.CRDD
 	for _, crd := range crds {
 		available = append(available, crd.Kind)
 		if strings.ToLower(crd.Kind) == lowerKind {
-			return cfg, crd, nil
+			return cfg, &loadedKind{CRD: crd}, nil
+		}
+	}
+
+	if cfg.GenerateAggregate {
+		aggregate := m.CreateAggregateDefinition(crds)
+		available = append(available, aggregate.Kind)
+		if strings.ToLower(aggregate.Kind) == lowerKind {
+			return cfg, &loadedKind{Aggregate: aggregate}, nil
+		}
+	}
+
+	if cfg.GenerateBundle {
+		bundle := m.CreateBundleDefinition(crds)
+		available = append(available, bundle.Kind)
+		if strings.ToLower(bundle.Kind) == lowerKind {
+			return cfg, &loadedKind{Bundle: bundle}, nil
 		}
 	}
 
@@ -1205,7 +1807,7 @@ func (h *handlers) handleExplain(_ context.Context, req mcp.CallToolRequest) (*m
 		return mcp.NewToolResultError("'kind' parameter is required"), nil
 	}
 
-	cfg, crd, err := h.loadCRD(directory, kind)
+	cfg, def, err := h.loadCRD(directory, kind)
 	if err != nil {
 		return mcp.NewToolResultError(err.Error()), nil
 	}
@@ -1213,12 +1815,18 @@ func (h *handlers) handleExplain(_ context.Context, req mcp.CallToolRequest) (*m
 	var b strings.Builder
 
 	switch {
-	case crd.IsQuery:
-		h.explainQuery(&b, cfg, crd)
-	case crd.IsAction:
-		h.explainAction(&b, cfg, crd)
+	case def.Aggregate != nil:
+		h.explainAggregate(&b, cfg, def.Aggregate)
+	case def.Bundle != nil:
+		h.explainBundle(&b, cfg, def.Bundle)
+	case def.CRD.IsQuery:
+		h.explainQuery(&b, cfg, def.CRD)
+	case def.CRD.IsAction:
+		h.explainAction(&b, cfg, def.CRD)
+	case def.CRD.ReadOnly:
+		h.explainReadOnlyResource(&b, cfg, def.CRD)
 	default:
-		h.explainResource(&b, cfg, crd)
+		h.explainResource(&b, cfg, def.CRD)
 	}
 
 	return mcp.NewToolResultText(b.String()), nil
@@ -1249,17 +1857,30 @@ func (h *handlers) explainResource(b *strings.Builder, cfg *config.Config, crd *
 	if crd.HasDelete {
 		fmt.Fprintf(b, "  %d. If the CR is being deleted:\n", step)
 		fmt.Fprintf(b, "     - Send DELETE %s to the REST API to remove the external resource.\n", crd.DeletePath)
+		if cfg.DeleteTimeout > 0 {
+			fmt.Fprintf(b, "     - If the DELETE fails, set DeletionBlocked and retry for up to %s (--delete-timeout).\n", cfg.DeleteTimeout)
+			if cfg.OrphanOnDeleteTimeout {
+				b.WriteString("     - Once the timeout has passed, orphan the external resource (--orphan-on-delete-timeout).\n")
+			} else {
+				b.WriteString("     - Once the timeout has passed, keep retrying until the DELETE succeeds or spec.onDelete is Orphan.\n")
+			}
+		} else {
+			b.WriteString("     - If the DELETE fails, log the error and orphan the external resource.\n")
+		}
 		fmt.Fprintf(b, "     - Remove the finalizer so Kubernetes can complete deletion.\n")
 		step++
 	}
 
-	fmt.Fprintf(b, "  %d. Add a finalizer (if not already present) to ensure cleanup on deletion.\n", step)
+	fmt.Fprintf(b, "  %d. Add the finalizer %q (if not already present) to ensure cleanup on deletion.\n", step, cfg.ResolvedFinalizerName())
 	step++
 
 	fmt.Fprintf(b, "  %d. If spec.paused is true, skip reconciliation and requeue.\n", step)
 	step++
 
 	fmt.Fprintf(b, "  %d. Resolve the target endpoint (static URL, StatefulSet, Deployment, or Helm release).\n", step)
+	if crd.TargetDefault != nil {
+		fmt.Fprintf(b, "     spec.target defaults to %s when the CR doesn't set it.\n", crd.TargetDefault)
+	}
 	step++
 
 	if crd.GetPath != "" {
@@ -1301,6 +1922,44 @@ func (h *handlers) explainResource(b *strings.Builder, cfg *config.Config, crd *
 		b.WriteString("\n")
 	}
 
+	if crd.Spec != nil {
+		if immutable := immutableFieldPaths(crd.Spec.Fields, ""); len(immutable) > 0 {
+			b.WriteString("IMMUTABLE FIELDS:\n")
+			for _, path := range immutable {
+				fmt.Fprintf(b, "  spec.%s\n", path)
+			}
+			b.WriteString("  These fields are marked x-k8s-immutable and can only be set at creation.\n")
+			b.WriteString("  The API server rejects changes to them (CEL rule: self == oldSelf).\n\n")
+		}
+	}
+
+	if crd.HasPost && cfg.IdempotencyHeader != "" {
+		b.WriteString("IDEMPOTENCY:\n")
+		fmt.Fprintf(b, "  Create requests send %s: <CR UID>-<generation> (--idempotency-header).\n", cfg.IdempotencyHeader)
+		b.WriteString("  If the POST succeeds but the status update fails, the retried POST carries the same key,\n")
+		b.WriteString("  so an API that honors the header returns the first result instead of creating a duplicate.\n")
+		b.WriteString("  Editing the spec changes the generation and thus the key.\n\n")
+	}
+
+	if crd.HasDelete {
+		b.WriteString("DELETE FAILURES:\n")
+		switch {
+		case cfg.DeleteTimeout == 0:
+			b.WriteString("  A failed DELETE doesn't hold up the CR: the finalizer is removed anyway, so the CR and\n")
+			b.WriteString("  its namespace always go away, but the external resource may be left behind.\n")
+			b.WriteString("  Set --delete-timeout to retry failed deletes instead.\n\n")
+		case cfg.OrphanOnDeleteTimeout:
+			fmt.Fprintf(b, "  A failed DELETE is retried for %s with the CR marked DeletionBlocked. After that the\n", cfg.DeleteTimeout)
+			b.WriteString("  finalizer is removed and an OrphanedOnDeleteTimeout event recorded: the namespace can't get\n")
+			b.WriteString("  stuck terminating, but an external resource that never deleted is left behind.\n\n")
+		default:
+			fmt.Fprintf(b, "  A failed DELETE is retried with the CR marked DeletionBlocked (reason DeleteTimeout after %s).\n", cfg.DeleteTimeout)
+			b.WriteString("  The external resource is never left behind silently, but the CR (and a namespace being\n")
+			b.WriteString("  deleted) stays until the DELETE succeeds. Set spec.onDelete: Orphan to let it go, or\n")
+			b.WriteString("  regenerate with --orphan-on-delete-timeout to do that automatically.\n\n")
+		}
+	}
+
 	if crd.UpdateWithPost {
 		b.WriteString("UPDATE WITH POST:\n")
 		b.WriteString("  This resource uses POST for updates because the API does not provide PUT.\n")
@@ -1309,16 +1968,87 @@ func (h *handlers) explainResource(b *strings.Builder, cfg *config.Config, crd *
 
 	// Status conditions
 	b.WriteString("STATUS CONDITIONS:\n")
-	b.WriteString("  Ready        — The external resource exists and matches the CR spec (no drift).\n")
-	b.WriteString("  Reconciling  — The controller is actively creating or updating the resource.\n")
-	b.WriteString("  Stalled      — An error occurred (API returned an error, endpoint unreachable).\n\n")
+	fmt.Fprintf(b, "  %-12s — The external resource exists and matches the CR spec (no drift).\n", cfg.ConditionType(config.ConditionReady))
+	fmt.Fprintf(b, "  %-12s — The controller is actively creating or updating the resource.\n", cfg.ConditionType(config.ConditionReconciling))
+	fmt.Fprintf(b, "  %-12s — An error occurred (API returned an error, endpoint unreachable).\n", cfg.ConditionType(config.ConditionStalled))
+	if crd.HasDelete && cfg.DeleteTimeout > 0 {
+		b.WriteString("  DeletionBlocked — The CR is being deleted but the DELETE of the external resource keeps failing.\n")
+	}
+	b.WriteString("\n")
 
-	b.WriteString("STATUS FIELDS:\n")
-	b.WriteString("  state              — Current state: Creating, Active, Updating, Deleting, Failed, Paused\n")
-	b.WriteString("  externalID         — The ID of the resource in the external REST API\n")
-	b.WriteString("  lastSyncTime       — When the controller last successfully synced with the API\n")
-	b.WriteString("  observedGeneration — The CR generation that was last reconciled\n")
-	b.WriteString("  conditions         — Standard Kubernetes conditions (Ready, Reconciling, Stalled)\n")
+	writeStatusFields(b, crd, []statusFieldDoc{
+		{"state", "Current state: Creating, Active, Updating, Deleting, Failed, Paused"},
+		{"externalID", "The ID of the resource in the external REST API"},
+		{"lastSyncTime", "When the controller last successfully synced with the API"},
+		{"observedGeneration", "The CR generation that was last reconciled"},
+		{"conditions", "Standard Kubernetes conditions (" + conditionTypeList(cfg) + ")"},
+	})
+}
+
+func (h *handlers) explainReadOnlyResource(b *strings.Builder, cfg *config.Config, crd *mapper.CRDDefinition) {
+	fmt.Fprintf(b, "%s (Read-Only Resource)\n\n", crd.Kind)
+	fmt.Fprintf(b, "API: %s/%s\n", cfg.APIGroup, cfg.APIVersion)
+	fmt.Fprintf(b, "Endpoint: GET %s\n", crd.GetPath)
+	if crd.Description != "" {
+		fmt.Fprintf(b, "Description: %s\n", crd.Description)
+	}
+	b.WriteString("\n")
+
+	b.WriteString("OBSERVE-ONLY:\n")
+	b.WriteString("  The API offers no create, update or delete for this resource (detected from its\n")
+	b.WriteString("  GET-only ID path, or set with x-k8s-readonly). Each CR names an existing object\n")
+	b.WriteString("  and mirrors it into its status; the controller never writes to the REST API.\n\n")
+
+	b.WriteString("RECONCILIATION FLOW:\n\n")
+	fmt.Fprintf(b, "  1. Fetch the %s CR from Kubernetes.\n", crd.Kind)
+	b.WriteString("  2. If spec.paused is true, set state Paused and stop.\n")
+	b.WriteString("  3. Resolve the target endpoint (static URL, StatefulSet, Deployment, or Helm release).\n")
+	if crd.TargetDefault != nil {
+		fmt.Fprintf(b, "     spec.target defaults to %s when the CR doesn't set it.\n", crd.TargetDefault)
+	}
+	fmt.Fprintf(b, "  4. GET %s with the identifier from the spec.\n", crd.GetPath)
+	b.WriteString("  5. Copy the response into status.response and set state Observed (NotFound on 404).\n")
+	b.WriteString("  6. Requeue after spec.executionInterval to pick up changes in the API.\n\n")
+
+	b.WriteString("SPEC:\n")
+	b.WriteString("  The spec holds only the identifier (the path parameters) plus target, executionInterval\n")
+	b.WriteString("  and paused. There are no resource body fields, no drift detection and no finalizer:\n")
+	b.WriteString("  deleting the CR leaves the external object untouched.\n\n")
+
+	fields := []statusFieldDoc{
+		{"state", "Current state: Pending, Observed, NotFound, Failed, Paused"},
+		{"response", "The object last returned by the GET"},
+	}
+	for _, f := range crd.StatusFields {
+		fields = append(fields, statusFieldDoc{f.JSONName, f.Description})
+	}
+	fields = append(fields,
+		statusFieldDoc{"lastGetTime", "When the controller last fetched the object"},
+		statusFieldDoc{"conditions", "Standard Kubernetes conditions"},
+	)
+	writeStatusFields(b, crd, fields)
+}
+
+// immutableFieldPaths returns the dotted JSON paths of fields marked x-k8s-immutable
+func immutableFieldPaths(fields []*mapper.FieldDefinition, prefix string) []string {
+	var paths []string
+	for _, f := range fields {
+		path := prefix + f.JSONName
+		if f.Immutable {
+			paths = append(paths, path)
+		}
+		paths = append(paths, immutableFieldPaths(f.Fields, path+".")...)
+	}
+	return paths
+}
+
+// writeTargetDefault explains the preset spec.target of query and action CRDs, if any
+func writeTargetDefault(b *strings.Builder, crd *mapper.CRDDefinition) {
+	if crd.TargetDefault == nil {
+		return
+	}
+	b.WriteString("DEFAULT TARGET:\n")
+	fmt.Fprintf(b, "  spec.target defaults to %s; set spec.target on the CR to override it.\n\n", crd.TargetDefault)
 }
 
 func (h *handlers) explainQuery(b *strings.Builder, cfg *config.Config, crd *mapper.CRDDefinition) {
@@ -1334,6 +2064,8 @@ func (h *handlers) explainQuery(b *strings.Builder, cfg *config.Config, crd *map
 	b.WriteString("  Executes a GET query against the REST API and stores the results in the CR status.\n")
 	b.WriteString("  This is a read-only operation — it never creates or modifies external resources.\n\n")
 
+	writeTargetDefault(b, crd)
+
 	b.WriteString("EXECUTION FLOW:\n\n")
 	b.WriteString("  1. First execution happens immediately when the CR is created.\n")
 	b.WriteString("  2. If spec.executionInterval is set (e.g., \"5m\"), the query re-executes periodically.\n")
@@ -1390,14 +2122,15 @@ func (h *handlers) explainQuery(b *strings.Builder, cfg *config.Config, crd *map
 	b.WriteString("  When targeting multiple endpoints (fan-out), the query is sent to all endpoints.\n")
 	b.WriteString("  Results from each endpoint are stored separately in status.results.\n\n")
 
-	b.WriteString("STATUS FIELDS:\n")
-	b.WriteString("  state              — Current state: Pending, Queried, Failed, Paused\n")
-	b.WriteString("  results            — Query results per endpoint (typed if response schema is defined)\n")
-	b.WriteString("  lastQueryTime      — When the last query was executed\n")
-	b.WriteString("  nextExecutionTime  — When the next periodic execution is scheduled\n")
-	b.WriteString("  executionCount     — Total number of times the query has executed\n")
-	b.WriteString("  observedGeneration — The CR generation that was last processed\n")
-	b.WriteString("  conditions         — Ready, Reconciling, Stalled\n")
+	writeStatusFields(b, crd, []statusFieldDoc{
+		{"state", "Current state: Pending, Queried, Failed, Paused"},
+		{"results", "Query results per endpoint (typed if response schema is defined)"},
+		{"lastQueryTime", "When the last query was executed"},
+		{"nextExecutionTime", "When the next periodic execution is scheduled"},
+		{"executionCount", "Total number of times the query has executed"},
+		{"observedGeneration", "The CR generation that was last processed"},
+		{"conditions", conditionTypeList(cfg)},
+	})
 }
 
 func (h *handlers) explainAction(b *strings.Builder, cfg *config.Config, crd *mapper.CRDDefinition) {
@@ -1420,6 +2153,8 @@ func (h *handlers) explainAction(b *strings.Builder, cfg *config.Config, crd *ma
 		b.WriteString("\n")
 	}
 
+	writeTargetDefault(b, crd)
+
 	b.WriteString("EXECUTION FLOW:\n\n")
 	b.WriteString("  1. First execution happens immediately when the CR is created.\n")
 	b.WriteString("  2. If spec.executionInterval is set, the action re-executes periodically.\n")
@@ -1437,6 +2172,13 @@ func (h *handlers) explainAction(b *strings.Builder, cfg *config.Config, crd *ma
 		b.WriteString("    spec.dataFromFile — Local file path on the operator pod\n\n")
 	}
 
+	if cfg.IdempotencyHeader != "" {
+		b.WriteString("IDEMPOTENCY:\n")
+		fmt.Fprintf(b, "  Requests send %s: <CR UID>-<generation>-<status.executionCount> (--idempotency-header).\n", cfg.IdempotencyHeader)
+		b.WriteString("  A retry of the same execution (e.g., after a failed status update) reuses the key;\n")
+		b.WriteString("  each periodic execution and each spec change gets a new one.\n\n")
+	}
+
 	// Request fields
 	if crd.Spec != nil && len(crd.Spec.Fields) > 0 {
 		b.WriteString("REQUEST FIELDS:\n")
@@ -1450,15 +2192,194 @@ func (h *handlers) explainAction(b *strings.Builder, cfg *config.Config, crd *ma
 		b.WriteString("\n")
 	}
 
+	writeStatusFields(b, crd, []statusFieldDoc{
+		{"state", "Current state: Pending, Executing, Completed, Failed"},
+		{"result", "Action response per endpoint"},
+		{"executedAt", "When the action was last executed"},
+		{"completedAt", "When the action last completed"},
+		{"httpStatusCode", "HTTP status code from the API response"},
+		{"executionCount", "Total number of times the action has executed"},
+		{"observedGeneration", "The CR generation that was last processed"},
+		{"conditions", conditionTypeList(cfg)},
+	})
+}
+
+// writeAggregationStrategies explains the health strategies shared by the aggregate and bundle CRDs
+func writeAggregationStrategies(b *strings.Builder) {
+	b.WriteString("AGGREGATION STRATEGIES (spec.aggregationStrategy):\n")
+	b.WriteString("  AllHealthy (default) — Healthy when every resource is synced; Degraded if any failed.\n")
+	b.WriteString("  AnyHealthy           — Healthy when at least one resource is synced.\n")
+	b.WriteString("  Quorum               — Healthy when a majority (total/2 + 1) of resources are synced.\n")
+	b.WriteString("  Synced states are Synced (resources), Observed, Queried (queries) and Completed (actions).\n\n")
+}
+
+// writeDerivedValues explains the CEL variables available to spec.derivedValues
+func writeDerivedValues(b *strings.Builder, kinds []string) {
+	b.WriteString("CEL DERIVED VALUES (spec.derivedValues):\n")
+	b.WriteString("  Each expression is evaluated with CEL after the status rollup, and the result is\n")
+	b.WriteString("  stored in computedValues. Evaluation errors are recorded per value and do not fail\n")
+	b.WriteString("  the reconcile. Available variables:\n")
+	b.WriteString("    resources — list of all resource objects (kind, metadata, spec, status)\n")
+	b.WriteString("    summary   — map with total, synced, failed and pending counts\n")
+	if len(kinds) > 0 {
+		var vars []string
+		for _, kind := range kinds {
+			vars = append(vars, strings.ToLower(kind)+"s")
+		}
+		fmt.Fprintf(b, "    %s — per-kind lists\n", strings.Join(vars, ", "))
+	}
+	b.WriteString("  Aggregate functions: sum(), max(), min(), avg()\n")
+	b.WriteString("  Example: \"summary.synced * 100 / summary.total\" (percentage synced)\n\n")
+}
+
+// statusFieldDoc is a status field and its description in an explanation
+type statusFieldDoc struct {
+	name, description string
+}
+
+// writeStatusFields writes the STATUS FIELDS section of an explanation, leaving out the
+// fields --exclude-status-fields dropped from the CRD
+// conditionTypeList returns the condition types the controllers set, as configured with
+// --emit-conditions
+func conditionTypeList(cfg *config.Config) string {
+	return strings.Join([]string{
+		cfg.ConditionType(config.ConditionReady),
+		cfg.ConditionType(config.ConditionReconciling),
+		cfg.ConditionType(config.ConditionStalled),
+	}, ", ")
+}
+
+func writeStatusFields(b *strings.Builder, crd *mapper.CRDDefinition, fields []statusFieldDoc) {
 	b.WriteString("STATUS FIELDS:\n")
-	b.WriteString("  state              — Current state: Pending, Executing, Completed, Failed\n")
-	b.WriteString("  result             — Action response per endpoint\n")
-	b.WriteString("  executedAt         — When the action was last executed\n")
-	b.WriteString("  completedAt        — When the action last completed\n")
-	b.WriteString("  httpStatusCode     — HTTP status code from the API response\n")
-	b.WriteString("  executionCount     — Total number of times the action has executed\n")
-	b.WriteString("  observedGeneration — The CR generation that was last processed\n")
-	b.WriteString("  conditions         — Ready, Reconciling, Stalled\n")
+	for _, f := range fields {
+		if !slices.Contains(crd.ExcludedStatusFields, f.name) {
+			fmt.Fprintf(b, "  %-18s — %s\n", f.name, f.description)
+		}
+	}
+	if len(crd.ExcludedStatusFields) > 0 {
+		fmt.Fprintf(b, "  Excluded (not stored): %s\n", strings.Join(crd.ExcludedStatusFields, ", "))
+	}
+}
+
+func (h *handlers) explainAggregate(b *strings.Builder, cfg *config.Config, agg *mapper.AggregateDefinition) {
+	fmt.Fprintf(b, "%s (Aggregate)\n\n", agg.Kind)
+	fmt.Fprintf(b, "API: %s/%s\n\n", cfg.APIGroup, cfg.APIVersion)
+
+	b.WriteString("WHAT THIS DOES:\n")
+	b.WriteString("  Observes existing CRs of this operator and rolls their status up into a single health\n")
+	b.WriteString("  state. It is read-only — it never creates, updates or deletes the resources it observes,\n")
+	b.WriteString("  and it makes no REST API calls of its own.\n\n")
+
+	b.WriteString("OBSERVABLE KINDS:\n")
+	if len(agg.ResourceKinds) > 0 {
+		fmt.Fprintf(b, "  Resources: %s\n", strings.Join(agg.ResourceKinds, ", "))
+	}
+	if len(agg.QueryKinds) > 0 {
+		fmt.Fprintf(b, "  Queries:   %s\n", strings.Join(agg.QueryKinds, ", "))
+	}
+	if len(agg.ActionKinds) > 0 {
+		fmt.Fprintf(b, "  Actions:   %s\n", strings.Join(agg.ActionKinds, ", "))
+	}
+	b.WriteString("\n")
+
+	b.WriteString("RECONCILIATION FLOW:\n\n")
+	fmt.Fprintf(b, "  1. Fetch the %s CR from Kubernetes.\n", agg.Kind)
+	b.WriteString("  2. If spec.paused is true, skip reconciliation.\n")
+	b.WriteString("  3. Collect resources named in spec.resources (kind, name, optional namespace).\n")
+	b.WriteString("     Missing resources are reported with state NotFound.\n")
+	b.WriteString("  4. Collect resources matching spec.resourceSelectors (kind, matchLabels, namePattern regex).\n")
+	b.WriteString("     At least one of spec.resources or spec.resourceSelectors must be set.\n")
+	b.WriteString("  5. Count the collected resources as synced, failed or pending and record each one in\n")
+	b.WriteString("     status.resources.\n")
+	b.WriteString("  6. Evaluate spec.derivedValues with CEL.\n")
+	b.WriteString("  7. Apply spec.aggregationStrategy to decide the overall state and update conditions.\n\n")
+
+	b.WriteString("TRIGGERS:\n")
+	b.WriteString("  There is no periodic requeue. The aggregate reconciles when its own spec changes and\n")
+	b.WriteString("  whenever any observable CR in the same namespace changes.\n\n")
+
+	writeAggregationStrategies(b)
+	writeDerivedValues(b, agg.AllKinds)
+
+	b.WriteString("STATUS FIELDS:\n")
+	b.WriteString("  state               — Overall health: Pending, Healthy, Degraded, Unknown, Paused\n")
+	b.WriteString("  message             — Explanation of the state (e.g., \"2 of 3 resources synced\")\n")
+	b.WriteString("  summary             — Counts: total, synced, failed, pending\n")
+	b.WriteString("  resources           — Per-resource kind, name, state, externalID and message\n")
+	b.WriteString("  computedValues      — Results of spec.derivedValues (name, value or error)\n")
+	b.WriteString("  lastAggregationTime — When the status was last rolled up\n")
+	b.WriteString("  observedGeneration  — The CR generation that was last reconciled\n")
+	fmt.Fprintf(b, "  conditions          — %s, AllHealthy\n", conditionTypeList(cfg))
+}
+
+func (h *handlers) explainBundle(b *strings.Builder, cfg *config.Config, bundle *mapper.BundleDefinition) {
+	fmt.Fprintf(b, "%s (Bundle)\n\n", bundle.Kind)
+	fmt.Fprintf(b, "API: %s/%s\n\n", cfg.APIGroup, cfg.APIVersion)
+
+	b.WriteString("WHAT THIS DOES:\n")
+	b.WriteString("  Creates and manages a set of child CRs declared inline in spec.resources, in dependency\n")
+	b.WriteString("  order, and rolls their status up into the bundle. The children make the REST API calls;\n")
+	b.WriteString("  the bundle only creates, updates and orders them.\n\n")
+
+	b.WriteString("CHILD KINDS:\n")
+	if len(bundle.ResourceKinds) > 0 {
+		fmt.Fprintf(b, "  Resources: %s\n", strings.Join(bundle.ResourceKinds, ", "))
+	}
+	if len(bundle.QueryKinds) > 0 {
+		fmt.Fprintf(b, "  Queries:   %s\n", strings.Join(bundle.QueryKinds, ", "))
+	}
+	if len(bundle.ActionKinds) > 0 {
+		fmt.Fprintf(b, "  Actions:   %s\n", strings.Join(bundle.ActionKinds, ", "))
+	}
+	b.WriteString("\n")
+
+	b.WriteString("RECONCILIATION FLOW:\n\n")
+	fmt.Fprintf(b, "  1. Fetch the %s CR from Kubernetes.\n", bundle.Kind)
+	b.WriteString("  2. If the CR is being deleted, remove the finalizer. Children are deleted by\n")
+	b.WriteString("     Kubernetes garbage collection through their owner references.\n")
+	b.WriteString("  3. Add the finalizer (if not already present).\n")
+	b.WriteString("  4. If spec.paused is true, skip reconciliation.\n")
+	b.WriteString("  5. Build the execution order: a topological sort of spec.resources using explicit\n")
+	b.WriteString("     dependsOn lists and implicit ${resources.<id>...} references in child specs.\n")
+	b.WriteString("     Unknown IDs and dependency cycles fail the bundle.\n")
+	b.WriteString("  6. For each resource, in order:\n")
+	b.WriteString("     - If any skipWhen CEL condition is true, mark it Skipped and move on.\n")
+	b.WriteString("     - If a dependency is not yet synced, mark it Pending (\"Waiting for dependencies\").\n")
+	b.WriteString("     - Resolve ${...} expressions in its spec against the status of earlier resources.\n")
+	b.WriteString("     - Create or update the child CR <bundle-name>-<id>, owned by the bundle. spec.target\n")
+	b.WriteString("       is copied to every child.\n")
+	b.WriteString("     - Once synced, evaluate readyWhen CEL conditions to set the child's ready flag.\n")
+	b.WriteString("  7. Roll child states up into the bundle status and conditions.\n")
+	b.WriteString("  8. Requeue while any child is not yet synced.\n\n")
+
+	b.WriteString("CEL EXPRESSIONS IN CHILD SPECS:\n")
+	b.WriteString("  ${resources.<id>.status.<field>} — value from an earlier resource (e.g., its externalID)\n")
+	b.WriteString("  ${now()}, ${nowUnix()}, ${addDuration(now(), \"24h\")}, ${formatTime(...)} — time helpers;\n")
+	b.WriteString("  a ${now()} value already accepted by the REST API is preserved across reconciles.\n")
+	b.WriteString("  skipWhen and readyWhen conditions see resources.<id> and the bundle spec; all\n")
+	b.WriteString("  conditions in a list must be true (AND).\n\n")
+
+	b.WriteString("ADOPTION:\n")
+	fmt.Fprintf(b, "  spec.adopt (default %t) takes ownership of a pre-existing child with the expected name\n", cfg.BundleAdopt)
+	b.WriteString("  that has no controller owner, so it is deleted with the bundle.\n\n")
+
+	b.WriteString("STATUS ROLLUP:\n")
+	b.WriteString("  Bundle state is Failed if any child failed, Synced when every child is synced or\n")
+	b.WriteString("  skipped, and Syncing otherwise. Query children count as synced once Queried and\n")
+	b.WriteString("  action children once Completed. status.aggregatedHealth reports the same children\n")
+	b.WriteString("  using the aggregate CRD's health model.\n\n")
+
+	writeAggregationStrategies(b)
+	writeDerivedValues(b, bundle.AllKinds)
+
+	b.WriteString("STATUS FIELDS:\n")
+	b.WriteString("  state              — Current state: Pending, Syncing, Synced, Failed, Paused\n")
+	b.WriteString("  resources          — Per-child id, kind, name, state, ready, skipped, externalID\n")
+	b.WriteString("  summary            — Counts: total, synced, failed, pending, skipped\n")
+	b.WriteString("  operationState     — Phase (Pending, Running, Succeeded, Failed) and timestamps\n")
+	b.WriteString("  aggregatedHealth   — Health state, summary and computedValues from spec.derivedValues\n")
+	b.WriteString("  observedGeneration — The CR generation that was last reconciled\n")
+	fmt.Fprintf(b, "  conditions         — %s\n", conditionTypeList(cfg))
 }
 
 // handleSample generates example CR YAML for a CRD kind.
@@ -1472,19 +2393,27 @@ func (h *handlers) handleSample(_ context.Context, req mcp.CallToolRequest) (*mc
 		return mcp.NewToolResultError("'kind' parameter is required"), nil
 	}
 
-	cfg, crd, err := h.loadCRD(directory, kind)
+	cfg, def, err := h.loadCRD(directory, kind)
 	if err != nil {
 		return mcp.NewToolResultError(err.Error()), nil
 	}
+	if def.CRD == nil {
+		return mcp.NewToolResultError(fmt.Sprintf("sample does not support %s; see the generated example in config/samples/", kind)), nil
+	}
+	crd := def.CRD
 
 	var b strings.Builder
 
+	if len(crd.ExcludedStatusFields) > 0 {
+		fmt.Fprintf(&b, "# status excludes %s (--exclude-status-fields)\n", strings.Join(crd.ExcludedStatusFields, ", "))
+	}
+
 	// YAML header
 	fmt.Fprintf(&b, "apiVersion: %s/%s\n", cfg.APIGroup, cfg.APIVersion)
 	fmt.Fprintf(&b, "kind: %s\n", crd.Kind)
 	b.WriteString("metadata:\n")
 	fmt.Fprintf(&b, "  name: %s-sample\n", strings.ToLower(crd.Kind))
-	b.WriteString("  namespace: default\n")
+	fmt.Fprintf(&b, "  namespace: %s\n", cfg.ResolvedSampleNamespace())
 	b.WriteString("spec:\n")
 
 	// Spec fields
@@ -1534,6 +2463,14 @@ func (h *handlers) handleSample(_ context.Context, req mcp.CallToolRequest) (*mc
 	}
 
 	// Endpoint targeting (commented)
+	if crd.TargetDefault != nil {
+		b.WriteString("\n  # Endpoint targeting (optional; these are the defaults)\n")
+		b.WriteString("  # target:\n")
+		for _, e := range crd.TargetDefault.Entries() {
+			fmt.Fprintf(&b, "  #   %s: %q\n", e.Key, e.Value)
+		}
+		return mcp.NewToolResultText(b.String()), nil
+	}
 	b.WriteString("\n  # Endpoint targeting (optional)\n")
 	b.WriteString("  # target:\n")
 	b.WriteString("  #   namespace: target-namespace\n")
@@ -1564,6 +2501,9 @@ func sampleValue(f *mapper.FieldDefinition) string {
 
 	switch goType {
 	case "string":
+		if example, ok := mapper.FormatExample(f.Format); ok {
+			return fmt.Sprintf("%q", example)
+		}
 		return fmt.Sprintf("%q", "example-"+f.JSONName)
 	case "int", "int32", "int64":
 		return "1"
@@ -1719,97 +2659,9 @@ func (h *handlers) handleEvolveSpecPrompt(_ context.Context, req mcp.GetPromptRe
 // compareCRDs compares two CRD definitions and returns a list of human-readable changes.
 func compareCRDs(old, new *mapper.CRDDefinition) []string {
 	var changes []string
-
-	// Compare operations
-	oldOps := make(map[string]string)
-	for _, op := range old.Operations {
-		oldOps[op.CRDAction] = op.HTTPMethod + " " + op.Path
-	}
-	newOps := make(map[string]string)
-	for _, op := range new.Operations {
-		newOps[op.CRDAction] = op.HTTPMethod + " " + op.Path
-	}
-	for action, detail := range newOps {
-		if _, ok := oldOps[action]; !ok {
-			changes = append(changes, fmt.Sprintf("Added operation: %s (%s)", action, detail))
-		}
+	for _, change := range mapper.CompareCRDs(old, new) {
+		changes = append(changes, change.String())
 	}
-	for action := range oldOps {
-		if _, ok := newOps[action]; !ok {
-			changes = append(changes, fmt.Sprintf("Removed operation: %s", action))
-		}
-	}
-
-	// Compare spec fields
-	oldFields := make(map[string]*mapper.FieldDefinition)
-	if old.Spec != nil {
-		for _, f := range old.Spec.Fields {
-			oldFields[f.JSONName] = f
-		}
-	}
-	newFields := make(map[string]*mapper.FieldDefinition)
-	if new.Spec != nil {
-		for _, f := range new.Spec.Fields {
-			newFields[f.JSONName] = f
-		}
-	}
-	for name, newF := range newFields {
-		oldF, ok := oldFields[name]
-		if !ok {
-			req := ""
-			if newF.Required {
-				req = " (required)"
-			}
-			changes = append(changes, fmt.Sprintf("Added field: %s (%s)%s", name, newF.GoType, req))
-			continue
-		}
-		if oldF.GoType != newF.GoType {
-			changes = append(changes, fmt.Sprintf("Changed field type: %s %s -> %s", name, oldF.GoType, newF.GoType))
-		}
-		if oldF.Required != newF.Required {
-			if newF.Required {
-				changes = append(changes, fmt.Sprintf("Field now required: %s", name))
-			} else {
-				changes = append(changes, fmt.Sprintf("Field now optional: %s", name))
-			}
-		}
-	}
-	for name := range oldFields {
-		if _, ok := newFields[name]; !ok {
-			changes = append(changes, fmt.Sprintf("Removed field: %s", name))
-		}
-	}
-
-	// Compare query parameters (for query endpoints)
-	if old.IsQuery && new.IsQuery {
-		oldQP := make(map[string]string)
-		for _, qp := range old.QueryParams {
-			oldQP[qp.JSONName] = qp.GoType
-		}
-		newQP := make(map[string]string)
-		for _, qp := range new.QueryParams {
-			newQP[qp.JSONName] = qp.GoType
-		}
-		for name, newType := range newQP {
-			oldType, ok := oldQP[name]
-			if !ok {
-				changes = append(changes, fmt.Sprintf("Added query param: %s (%s)", name, newType))
-			} else if oldType != newType {
-				changes = append(changes, fmt.Sprintf("Changed query param type: %s %s -> %s", name, oldType, newType))
-			}
-		}
-		for name := range oldQP {
-			if _, ok := newQP[name]; !ok {
-				changes = append(changes, fmt.Sprintf("Removed query param: %s", name))
-			}
-		}
-	}
-
-	// Compare response type (for query endpoints)
-	if old.IsQuery && new.IsQuery && old.ResponseType != new.ResponseType {
-		changes = append(changes, fmt.Sprintf("Changed response type: %s -> %s", old.ResponseType, new.ResponseType))
-	}
-
 	return changes
 }
 
@@ -1852,26 +2704,64 @@ func (h *handlers) configFromRequest(req mcp.CallToolRequest) (*config.Config, e
 	mappingMode := config.MappingMode(mcp.ParseString(req, "mapping", "per-resource"))
 
 	cfg := &config.Config{
-		SpecPath:               specPath,
-		OutputDir:              outputDir,
-		APIGroup:               group,
-		APIVersion:             apiVersion,
-		MappingMode:            mappingMode,
-		ModuleName:             module,
-		GeneratorVersion:       h.version,
-		CommitHash:             h.commit,
-		CommitTimestamp:        h.date,
-		GenerateCRDs:           mcp.ParseBoolean(req, "generate_crds", false),
-		RootKind:               mcp.ParseString(req, "root_kind", ""),
-		GenerateAggregate:      mcp.ParseBoolean(req, "aggregate", false),
-		GenerateBundle:         mcp.ParseBoolean(req, "bundle", false),
-		GenerateKubectlPlugin:  mcp.ParseBoolean(req, "kubectl_plugin", false),
-		GenerateRundeckProject: mcp.ParseBoolean(req, "rundeck_project", false),
-		StandaloneNodeSource:   mcp.ParseBoolean(req, "standalone_node_source", false),
-		NoIDMerge:              mcp.ParseBoolean(req, "no_id_merge", false),
-		TargetAPIImage:         mcp.ParseString(req, "target_api_image", ""),
-		TargetAPIPort:          mcp.ParseInt(req, "target_api_port", 0),
-		ManagedCRsDir:          mcp.ParseString(req, "managed_crs", ""),
+		SpecPath:                specPath,
+		SpecRootFile:            mcp.ParseString(req, "spec_root_file", ""),
+		SpecFormat:              mcp.ParseString(req, "spec_format", config.SpecFormatAuto),
+		SpecCacheDir:            mcp.ParseString(req, "spec_cache", ""),
+		OutputDir:               outputDir,
+		APIGroup:                group,
+		APIVersion:              apiVersion,
+		MappingMode:             mappingMode,
+		ModuleName:              module,
+		ImportPrefix:            mcp.ParseString(req, "output_module_path", ""),
+		SkipGoMod:               mcp.ParseBoolean(req, "skip_go_mod", false),
+		GeneratorVersion:        h.version,
+		CommitHash:              h.commit,
+		CommitTimestamp:         h.date,
+		GenerateCRDs:            mcp.ParseBoolean(req, "generate_crds", false),
+		TypesOnly:               mcp.ParseBoolean(req, "types_only", false),
+		RootKind:                mcp.ParseString(req, "root_kind", ""),
+		ControllerFileNaming:    config.ControllerFileNaming(mcp.ParseString(req, "controller_file_naming", "")),
+		FieldNameCase:           config.FieldNameCase(mcp.ParseString(req, "field_name_case", "")),
+		DeriveOwners:            mcp.ParseBoolean(req, "derive_owners", false),
+		GenerateAggregate:       mcp.ParseBoolean(req, "aggregate", false),
+		GenerateBundle:          mcp.ParseBoolean(req, "bundle", false),
+		BundleAdopt:             mcp.ParseBoolean(req, "bundle_adopt", false),
+		PauseConfigMapRef:       mcp.ParseString(req, "pause_configmap", ""),
+		MaxQueryResults:         mcp.ParseInt(req, "status_result_limit", 0),
+		GenerateKubectlPlugin:   mcp.ParseBoolean(req, "kubectl_plugin", false),
+		GenerateRundeckProject:  mcp.ParseBoolean(req, "rundeck_project", false),
+		GenerateKrewManifest:    mcp.ParseBoolean(req, "krew_manifest", false),
+		GenerateWebhookPatches:  mcp.ParseBoolean(req, "webhook_patches", false),
+		StandaloneNodeSource:    mcp.ParseBoolean(req, "standalone_node_source", false),
+		NoIDMerge:               mcp.ParseBoolean(req, "no_id_merge", false),
+		FinalizerName:           mcp.ParseString(req, "finalizer_name", ""),
+		SampleNamespace:         mcp.ParseString(req, "sample_namespace", ""),
+		UseETag:                 mcp.ParseBoolean(req, "use_etag", false),
+		IdempotencyHeader:       mcp.ParseString(req, "idempotency_header", ""),
+		AcceptHeader:            mcp.ParseString(req, "accept_header", ""),
+		NoStatusSubresource:     mcp.ParseBoolean(req, "no_status_subresource", false),
+		NoGenerationPredicate:   mcp.ParseBoolean(req, "no_generation_predicate", false),
+		ServerSelector:          mcp.ParseString(req, "server_selector", ""),
+		Environment:             mcp.ParseString(req, "environment", ""),
+		TargetAPIImage:          mcp.ParseString(req, "target_api_image", ""),
+		TargetAPIPort:           mcp.ParseInt(req, "target_api_port", 0),
+		GenerateTilt:            mcp.ParseBoolean(req, "tilt", false),
+		GenerateE2E:             mcp.ParseBoolean(req, "e2e", false),
+		GenerateWebhookReceiver: mcp.ParseBoolean(req, "webhook_receiver", false),
+		GenerateDashboard:       mcp.ParseBoolean(req, "dashboard", false),
+		AllowExtraHeaders:       mcp.ParseBoolean(req, "allow_extra_headers", false),
+		SupportDryRun:           mcp.ParseBoolean(req, "support_dry_run", false),
+		HighAvailability:        mcp.ParseBoolean(req, "ha", false),
+		PriorityClassName:       mcp.ParseString(req, "priority_class", ""),
+		EnableTracing:           mcp.ParseBoolean(req, "tracing", false),
+		EnablePprof:             mcp.ParseBoolean(req, "profile", false),
+		PprofAddr:               mcp.ParseString(req, "pprof_addr", ""),
+		EmbedSpec:               mcp.ParseBoolean(req, "embed_spec", false),
+		KubebuilderLayout:       mcp.ParseBoolean(req, "kubebuilder", false),
+		BaseImage:               mcp.ParseString(req, "controller_base_image", ""),
+		RuntimeImage:            mcp.ParseString(req, "runtime_image", ""),
+		ManagedCRsDir:           mcp.ParseString(req, "managed_crs", ""),
 	}
 
 	cfg.IncludePaths = parseCommaSeparated(mcp.ParseString(req, "include_paths", ""))
@@ -1880,12 +2770,105 @@ func (h *handlers) configFromRequest(req mcp.CallToolRequest) (*config.Config, e
 	cfg.ExcludeTags = parseCommaSeparated(mcp.ParseString(req, "exclude_tags", ""))
 	cfg.IncludeOperations = parseCommaSeparated(mcp.ParseString(req, "include_operations", ""))
 	cfg.ExcludeOperations = parseCommaSeparated(mcp.ParseString(req, "exclude_operations", ""))
+	cfg.MaxCRDs = mcp.ParseInt(req, "max_crds", 0)
 	cfg.UpdateWithPost = parseCommaSeparated(mcp.ParseString(req, "update_with_post", ""))
+	cfg.ExcludeStatusFields = parseCommaSeparated(mcp.ParseString(req, "exclude_status_fields", ""))
+	cfg.WatchNamespaces = parseCommaSeparated(mcp.ParseString(req, "watch_namespaces", ""))
+	cfg.GroupKindPluralCheck = mcp.ParseBoolean(req, "group_kind_plural_check", false)
 	cfg.IDFieldMap = parseIDFieldMap(mcp.ParseString(req, "id_field_map", ""))
+	cfg.PluralOverrides = parseIDFieldMap(mcp.ParseString(req, "plural_overrides", ""))
+	cfg.ConstantPathParams = parseIDFieldMap(mcp.ParseString(req, "exclude_path_params", ""))
+	cfg.ReconcileOnConfigMapChange = mcp.ParseBoolean(req, "reconcile_on_configmap_change", false)
+
+	cfg.KubeClientQPS = mcp.ParseFloat32(req, "client_qps", 0)
+	cfg.KubeClientBurst = mcp.ParseInt(req, "client_burst", 0)
+
+	if v := mcp.ParseString(req, "slow_reconcile_threshold", ""); v != "" {
+		d, err := time.ParseDuration(v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid 'slow_reconcile_threshold': %w", err)
+		}
+		cfg.SlowReconcileThreshold = d
+	}
+	if v := mcp.ParseString(req, "delete_timeout", ""); v != "" {
+		d, err := time.ParseDuration(v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid 'delete_timeout': %w", err)
+		}
+		cfg.DeleteTimeout = d
+	}
+	cfg.OrphanOnDeleteTimeout = mcp.ParseBoolean(req, "orphan_on_delete_timeout", false)
+	cfg.ConditionTypes = parseIDFieldMap(mcp.ParseString(req, "emit_conditions", ""))
+	if v := mcp.ParseString(req, "default_target", ""); v != "" {
+		target, err := config.ParseTargetDefault(v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid 'default_target': %w", err)
+		}
+		cfg.DefaultTarget = target
+	}
+	if v := mcp.ParseString(req, "success_codes", ""); v != "" {
+		codes, err := config.ParseSuccessCodes(v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid 'success_codes': %w", err)
+		}
+		cfg.SuccessCodes = codes
+	}
 
 	return cfg, nil
 }
 
+// writeServers lists the spec's servers when there is a choice of more than one,
+// marking the one selected with --server-selector
+func writeServers(b *strings.Builder, servers []parser.Server, indent, selector string) {
+	if len(servers) < 2 {
+		return
+	}
+	fmt.Fprintf(b, "%sServers (select with the operator's --server flag):\n", indent)
+	for _, s := range servers {
+		label := s.Name
+		if label == "" {
+			label = s.Description
+		}
+		line := s.URL
+		if label != "" {
+			line = label + ": " + s.URL
+		}
+		if s.Environment != "" {
+			line += fmt.Sprintf(" [%s]", s.Environment)
+		}
+		if selector != "" && (strings.EqualFold(selector, s.Name) || strings.EqualFold(selector, s.Environment) || strings.EqualFold(selector, s.Description) || strings.EqualFold(selector, s.URL)) {
+			line += " (selected)"
+		}
+		fmt.Fprintf(b, "%s  - %s\n", indent, line)
+	}
+}
+
+// specServers converts the parsed spec's servers list for the generator config
+func specServers(servers []parser.Server) []config.SpecServer {
+	var result []config.SpecServer
+	for _, s := range servers {
+		result = append(result, config.SpecServer{Name: s.Name, Environment: s.Environment, URL: s.URL, Description: s.Description})
+	}
+	return result
+}
+
+// specWebhooks converts the parsed spec's webhooks for the generator config
+func specWebhooks(webhooks []parser.Webhook) []config.SpecWebhook {
+	var result []config.SpecWebhook
+	for _, w := range webhooks {
+		result = append(result, config.SpecWebhook{Name: w.Name, Method: w.Method, OperationID: w.OperationID, Summary: w.Summary, PayloadSchemaRef: w.PayloadSchemaRef, Kind: w.Kind})
+	}
+	return result
+}
+
+// pluralLabel returns the CRD's plural, noting when it was set explicitly rather than derived
+func pluralLabel(crd *mapper.CRDDefinition) string {
+	if crd.CustomPlural {
+		return crd.Plural + ", explicit plural"
+	}
+	return crd.Plural
+}
+
 // formatCRDs writes rich markdown output for a list of CRD definitions.
 // Used by handlePreview and handleDescribe.
 func formatCRDs(b *strings.Builder, crds []*mapper.CRDDefinition) {
@@ -1910,7 +2893,7 @@ func formatCRDs(b *strings.Builder, crds []*mapper.CRDDefinition) {
 	if len(resources) > 0 {
 		fmt.Fprintf(b, "RESOURCES (CRUD) — %d:\n\n", len(resources))
 		for _, crd := range resources {
-			fmt.Fprintf(b, "  %s (%s)  scope=%s\n", crd.Kind, crd.Plural, crd.Scope)
+			fmt.Fprintf(b, "  %s (%s)  scope=%s\n", crd.Kind, pluralLabel(crd), crd.Scope)
 			if crd.Description != "" {
 				fmt.Fprintf(b, "    %s\n", crd.Description)
 			}
@@ -1962,7 +2945,7 @@ func formatCRDs(b *strings.Builder, crds []*mapper.CRDDefinition) {
 	if len(queries) > 0 {
 		fmt.Fprintf(b, "QUERY ENDPOINTS (GET-only) — %d:\n\n", len(queries))
 		for _, crd := range queries {
-			fmt.Fprintf(b, "  %s (%s)  GET %s\n", crd.Kind, crd.Plural, crd.QueryPath)
+			fmt.Fprintf(b, "  %s (%s)  GET %s\n", crd.Kind, pluralLabel(crd), crd.QueryPath)
 			if crd.Description != "" {
 				fmt.Fprintf(b, "    %s\n", crd.Description)
 			}
@@ -2021,7 +3004,7 @@ func formatCRDs(b *strings.Builder, crds []*mapper.CRDDefinition) {
 	if len(actions) > 0 {
 		fmt.Fprintf(b, "ACTION ENDPOINTS (POST/PUT-only) — %d:\n\n", len(actions))
 		for _, crd := range actions {
-			fmt.Fprintf(b, "  %s (%s)  %s %s\n", crd.Kind, crd.Plural, crd.ActionMethod, crd.ActionPath)
+			fmt.Fprintf(b, "  %s (%s)  %s %s\n", crd.Kind, pluralLabel(crd), crd.ActionMethod, crd.ActionPath)
 			if crd.Description != "" {
 				fmt.Fprintf(b, "    %s\n", crd.Description)
 			}
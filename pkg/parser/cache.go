@@ -0,0 +1,143 @@
+package parser
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// specCacheEntry is the metadata stored next to a cached spec, used to revalidate it
+// with a conditional GET
+type specCacheEntry struct {
+	URL          string `json:"url"`
+	ETag         string `json:"etag,omitempty"`
+	LastModified string `json:"lastModified,omitempty"`
+}
+
+// errSpecNotModified is returned by fetchSpec when the server answers 304 Not Modified
+var errSpecNotModified = errors.New("spec not modified")
+
+// ReadSpec reads the spec content from a file or URL. A URL is cached in cacheDir when
+// it is set: a cached copy is revalidated with a conditional GET (If-None-Match /
+// If-Modified-Since) and reused when the server answers 304 Not Modified or can't be
+// reached, so re-runs work offline.
+func ReadSpec(specPath, cacheDir string) ([]byte, error) {
+	return readSpec(specPath, cacheDir, func(string, ...interface{}) {})
+}
+
+// readSpec is ReadSpec with a logger for the warning printed when a cached copy stands
+// in for a failed fetch
+func readSpec(specPath, cacheDir string, logf func(format string, args ...interface{})) ([]byte, error) {
+	if !isURL(specPath) {
+		return os.ReadFile(specPath)
+	}
+	if cacheDir == "" {
+		data, _, err := fetchSpec(specPath, nil)
+		return data, err
+	}
+
+	dir, err := expandHome(cacheDir)
+	if err != nil {
+		return nil, err
+	}
+	key := fmt.Sprintf("%x", sha256.Sum256([]byte(specPath)))
+	bodyPath := filepath.Join(dir, key+".spec")
+	metaPath := filepath.Join(dir, key+".json")
+
+	var cached []byte
+	var entry *specCacheEntry
+	if body, err := os.ReadFile(bodyPath); err == nil {
+		if meta, err := os.ReadFile(metaPath); err == nil {
+			var e specCacheEntry
+			if json.Unmarshal(meta, &e) == nil && e.URL == specPath {
+				cached, entry = body, &e
+			}
+		}
+	}
+
+	data, fetched, err := fetchSpec(specPath, entry)
+	if errors.Is(err, errSpecNotModified) {
+		return cached, nil
+	}
+	if err != nil {
+		if entry == nil {
+			return nil, err
+		}
+		logf("Warning: using cached copy of %s: %v\n", specPath, err)
+		return cached, nil
+	}
+
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create spec cache directory: %w", err)
+	}
+	meta, err := json.MarshalIndent(fetched, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	if err := os.WriteFile(bodyPath, data, 0644); err != nil {
+		return nil, fmt.Errorf("failed to write spec cache: %w", err)
+	}
+	if err := os.WriteFile(metaPath, meta, 0644); err != nil {
+		return nil, fmt.Errorf("failed to write spec cache: %w", err)
+	}
+	return data, nil
+}
+
+// fetchSpec downloads a spec, sending the validators of a cached copy when there is one.
+// It returns the validators of the downloaded content for the next revalidation.
+func fetchSpec(specURL string, cached *specCacheEntry) ([]byte, *specCacheEntry, error) {
+	req, err := http.NewRequest(http.MethodGet, specURL, nil)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to fetch spec from URL: %w", err)
+	}
+	if cached != nil {
+		if cached.ETag != "" {
+			req.Header.Set("If-None-Match", cached.ETag)
+		}
+		if cached.LastModified != "" {
+			req.Header.Set("If-Modified-Since", cached.LastModified)
+		}
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to fetch spec from URL: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotModified && cached != nil {
+		return nil, nil, errSpecNotModified
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, nil, fmt.Errorf("failed to fetch spec: HTTP %d", resp.StatusCode)
+	}
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, nil, err
+	}
+	return data, &specCacheEntry{
+		URL:          specURL,
+		ETag:         resp.Header.Get("ETag"),
+		LastModified: resp.Header.Get("Last-Modified"),
+	}, nil
+}
+
+// expandHome expands a leading ~ to the user's home directory, for cache directories
+// given in a config file where the shell doesn't expand it
+func expandHome(path string) (string, error) {
+	if path != "~" && !strings.HasPrefix(path, "~/") {
+		return path, nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to expand %s: %w", path, err)
+	}
+	return filepath.Join(home, strings.TrimPrefix(path, "~")), nil
+}
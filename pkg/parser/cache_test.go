@@ -0,0 +1,143 @@
+package parser
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestReadSpec_Cache(t *testing.T) {
+	spec := "openapi: 3.0.0\n"
+	var requests, notModified int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			notModified++
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `"v1"`)
+		_, _ = w.Write([]byte(spec))
+	}))
+
+	cacheDir := filepath.Join(t.TempDir(), "cache")
+	specURL := server.URL + "/openapi.yaml"
+
+	data, err := ReadSpec(specURL, cacheDir)
+	if err != nil {
+		t.Fatalf("ReadSpec failed: %v", err)
+	}
+	if string(data) != spec {
+		t.Errorf("expected the fetched spec, got %q", data)
+	}
+	entries, err := os.ReadDir(cacheDir)
+	if err != nil || len(entries) != 2 {
+		t.Fatalf("expected the spec and its metadata in the cache, got %v (%v)", entries, err)
+	}
+
+	// A cached copy is revalidated and reused on 304
+	data, err = ReadSpec(specURL, cacheDir)
+	if err != nil {
+		t.Fatalf("ReadSpec failed: %v", err)
+	}
+	if string(data) != spec || notModified != 1 {
+		t.Errorf("expected the cached spec after a 304, got %q (%d not modified)", data, notModified)
+	}
+
+	// The cached copy stands in when the server is gone
+	server.Close()
+	var log strings.Builder
+	data, err = readSpec(specURL, cacheDir, func(format string, args ...interface{}) {
+		log.WriteString(format)
+	})
+	if err != nil {
+		t.Fatalf("expected the cached spec offline, got %v", err)
+	}
+	if string(data) != spec {
+		t.Errorf("expected the cached spec offline, got %q", data)
+	}
+	if !strings.Contains(log.String(), "using cached copy") {
+		t.Errorf("expected a warning about the cached copy, got %q", log.String())
+	}
+
+	// Without a cached copy an unreachable server is still an error
+	if _, err := ReadSpec(server.URL+"/other.yaml", cacheDir); err == nil {
+		t.Error("expected an error for an uncached URL offline")
+	}
+	if requests != 2 {
+		t.Errorf("expected 2 requests to the live server, got %d", requests)
+	}
+}
+
+func TestReadSpec_CacheRefreshesChangedSpec(t *testing.T) {
+	version := "v1"
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("If-Modified-Since") != "" && version == "v1" {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("Last-Modified", "Mon, 02 Jan 2006 15:04:05 GMT")
+		_, _ = w.Write([]byte("title: " + version))
+	}))
+	defer server.Close()
+
+	cacheDir := t.TempDir()
+	if _, err := ReadSpec(server.URL, cacheDir); err != nil {
+		t.Fatalf("ReadSpec failed: %v", err)
+	}
+	version = "v2"
+	data, err := ReadSpec(server.URL, cacheDir)
+	if err != nil {
+		t.Fatalf("ReadSpec failed: %v", err)
+	}
+	if string(data) != "title: v2" {
+		t.Errorf("expected the changed spec, got %q", data)
+	}
+	version = "v1"
+	data, err = ReadSpec(server.URL, cacheDir)
+	if err != nil {
+		t.Fatalf("ReadSpec failed: %v", err)
+	}
+	if string(data) != "title: v2" {
+		t.Errorf("expected the refreshed cache entry, got %q", data)
+	}
+}
+
+func TestParse_SpecCacheDir(t *testing.T) {
+	specContent := `
+openapi: "3.0.0"
+info:
+  title: "Cached API"
+  version: "1.0.0"
+paths:
+  /pets:
+    get:
+      responses:
+        "200":
+          description: OK
+`
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		_, _ = w.Write([]byte(specContent))
+	}))
+	specURL := server.URL + "/cached.yaml"
+
+	p := NewParser()
+	p.LogWriter = &strings.Builder{}
+	p.SpecCacheDir = t.TempDir()
+	if _, err := p.Parse(specURL); err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	if requests != 1 {
+		t.Errorf("expected the spec to be fetched once, got %d requests", requests)
+	}
+
+	server.Close()
+	if _, err := p.Parse(specURL); err != nil {
+		t.Fatalf("expected Parse to use the cached spec offline, got %v", err)
+	}
+}
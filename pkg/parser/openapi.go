@@ -6,10 +6,13 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"mime"
 	"net/http"
 	"net/url"
 	"os"
+	"path/filepath"
 	"sort"
+	"strconv"
 	"strings"
 
 	"github.com/getkin/kin-openapi/openapi2"
@@ -23,22 +26,79 @@ func isURL(path string) bool {
 	return strings.HasPrefix(path, "http://") || strings.HasPrefix(path, "https://")
 }
 
-// readSpec reads the spec content from a file or URL
-func readSpec(specPath string) ([]byte, error) {
+// ResolveSpecRoot returns the root document of a spec given as a directory of split
+// files. The root is rootFile (relative to the directory) when set, otherwise the one
+// file with a top-level "openapi" or "swagger" key. URLs and plain files are returned
+// unchanged.
+func ResolveSpecRoot(specPath, rootFile string) (string, error) {
 	if isURL(specPath) {
-		resp, err := http.Get(specPath)
-		if err != nil {
-			return nil, fmt.Errorf("failed to fetch spec from URL: %w", err)
+		return specPath, nil
+	}
+	info, err := os.Stat(specPath)
+	if err != nil || !info.IsDir() {
+		return specPath, nil
+	}
+
+	if rootFile != "" {
+		root := filepath.Join(specPath, rootFile)
+		if _, err := os.Stat(root); err != nil {
+			return "", fmt.Errorf("spec root file %s not found in %s", rootFile, specPath)
 		}
-		defer resp.Body.Close()
+		return root, nil
+	}
 
-		if resp.StatusCode != http.StatusOK {
-			return nil, fmt.Errorf("failed to fetch spec: HTTP %d", resp.StatusCode)
+	var candidates []string
+	err = filepath.WalkDir(specPath, func(path string, d os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || !isSpecFileExt(path) {
+			return nil
 		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		if isRootDocument(data) {
+			candidates = append(candidates, path)
+		}
+		return nil
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to scan spec directory: %w", err)
+	}
 
-		return io.ReadAll(resp.Body)
+	switch len(candidates) {
+	case 0:
+		return "", fmt.Errorf("no OpenAPI root document (openapi: or swagger: key) found in %s", specPath)
+	case 1:
+		return candidates[0], nil
+	default:
+		sort.Strings(candidates)
+		return "", fmt.Errorf("multiple OpenAPI root documents found in %s (%s); set --spec-root-file to choose one",
+			specPath, strings.Join(candidates, ", "))
 	}
-	return os.ReadFile(specPath)
+}
+
+// isSpecFileExt reports whether path has a YAML or JSON extension
+func isSpecFileExt(path string) bool {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml", ".json":
+		return true
+	}
+	return false
+}
+
+// isRootDocument reports whether data is a spec document with a top-level
+// "openapi" or "swagger" key, as opposed to a fragment referenced from the root
+func isRootDocument(data []byte) bool {
+	var doc map[string]interface{}
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return false
+	}
+	_, hasOpenAPI := doc["openapi"]
+	_, hasSwagger := doc["swagger"]
+	return hasOpenAPI || hasSwagger
 }
 
 // detectSpecVersion detects whether the spec is Swagger 2.0 or OpenAPI 3.x
@@ -52,12 +112,58 @@ func detectSpecVersion(data []byte) string {
 	return "3.x"
 }
 
-// parseSwagger2 parses a Swagger 2.0 spec and converts it to OpenAPI 3.0
-func parseSwagger2(data []byte) (*openapi3.T, error) {
+// Spec formats accepted by Parser.SpecFormat
+const (
+	// SpecFormatAuto lets the decoder try JSON first and fall back to YAML
+	SpecFormatAuto = "auto"
+	// SpecFormatYAML decodes the spec as YAML only
+	SpecFormatYAML = "yaml"
+	// SpecFormatJSON decodes the spec as JSON only
+	SpecFormatJSON = "json"
+)
+
+// decodeSpecFormat checks data against a forced spec format and returns it ready for
+// the loader. JSON input must be valid JSON; YAML input is converted to JSON so the
+// loader never has to guess. Auto returns the data unchanged.
+func decodeSpecFormat(data []byte, format string) ([]byte, error) {
+	switch format {
+	case "", SpecFormatAuto:
+		return data, nil
+	case SpecFormatJSON:
+		var v interface{}
+		if err := json.Unmarshal(data, &v); err != nil {
+			return nil, fmt.Errorf("failed to parse spec as JSON: %w", err)
+		}
+		return data, nil
+	case SpecFormatYAML:
+		var yamlData interface{}
+		if err := yaml.Unmarshal(data, &yamlData); err != nil {
+			return nil, fmt.Errorf("failed to parse spec as YAML: %w", err)
+		}
+		jsonData, err := json.Marshal(convertYAMLMapKeys(yamlData))
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert YAML to JSON: %w", err)
+		}
+		return jsonData, nil
+	default:
+		return nil, fmt.Errorf("unknown spec format %q (want auto, yaml or json)", format)
+	}
+}
+
+// parseSwagger2 parses a Swagger 2.0 spec and converts it to OpenAPI 3.0.
+// format forces the decode path; auto tries JSON first and falls back to YAML.
+func parseSwagger2(data []byte, format string) (*openapi3.T, error) {
 	var swagger openapi2.T
 
-	// Try JSON first
-	if err := json.Unmarshal(data, &swagger); err != nil {
+	// Try JSON first unless YAML is forced
+	var jsonErr error
+	if format != SpecFormatYAML {
+		jsonErr = json.Unmarshal(data, &swagger)
+		if jsonErr != nil && format == SpecFormatJSON {
+			return nil, fmt.Errorf("failed to parse Swagger 2.0 spec as JSON: %w", jsonErr)
+		}
+	}
+	if format == SpecFormatYAML || jsonErr != nil {
 		// If JSON fails, convert YAML to JSON first then parse
 		// This avoids YAML unmarshalling issues with kin-openapi types
 		var yamlData interface{}
@@ -115,6 +221,211 @@ func normalizeTypeFields(v interface{}) {
 	}
 }
 
+// findOptionalRequestBodies scans the raw spec for request bodies that are explicitly
+// marked optional: "requestBody.required: false" (OpenAPI 3.x, including $ref'd
+// components.requestBodies) or a body parameter with "required: false" (Swagger 2.0).
+// The result is keyed by the corresponding operation in the loaded document.
+func findOptionalRequestBodies(data []byte, doc *openapi3.T) map[*openapi3.Operation]bool {
+	result := make(map[*openapi3.Operation]bool)
+	if doc == nil || doc.Paths == nil {
+		return result
+	}
+
+	var raw interface{}
+	if err := yaml.Unmarshal(data, &raw); err != nil {
+		return result
+	}
+	root, ok := convertYAMLMapKeys(raw).(map[string]interface{})
+	if !ok {
+		return result
+	}
+	paths, _ := root["paths"].(map[string]interface{})
+
+	// Named request bodies from components (OpenAPI 3.x)
+	var namedBodies map[string]interface{}
+	if components, ok := root["components"].(map[string]interface{}); ok {
+		namedBodies, _ = components["requestBodies"].(map[string]interface{})
+	}
+
+	for path, rawItem := range paths {
+		item, ok := rawItem.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		pathItem := doc.Paths.Value(path)
+		if pathItem == nil {
+			continue
+		}
+		for method, rawOp := range item {
+			opMap, ok := rawOp.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			op := pathItem.GetOperation(strings.ToUpper(method))
+			if op == nil {
+				continue
+			}
+			if isExplicitlyOptionalBody(opMap, namedBodies) {
+				result[op] = true
+			}
+		}
+	}
+	return result
+}
+
+// isExplicitlyOptionalBody reports whether a raw operation's request body has "required: false"
+func isExplicitlyOptionalBody(op map[string]interface{}, namedBodies map[string]interface{}) bool {
+	if body, ok := op["requestBody"].(map[string]interface{}); ok {
+		if ref, ok := body["$ref"].(string); ok {
+			name := ref[strings.LastIndex(ref, "/")+1:]
+			body, _ = namedBodies[name].(map[string]interface{})
+		}
+		required, ok := body["required"].(bool)
+		return ok && !required
+	}
+
+	// Swagger 2.0 body parameter
+	params, _ := op["parameters"].([]interface{})
+	for _, rawParam := range params {
+		param, ok := rawParam.(map[string]interface{})
+		if !ok || param["in"] != "body" {
+			continue
+		}
+		required, ok := param["required"].(bool)
+		return ok && !required
+	}
+	return false
+}
+
+// normalizeOpenAPI31 rewrites OpenAPI 3.1 constructs kin-openapi doesn't understand into their
+// 3.0 equivalents: numeric exclusiveMinimum/exclusiveMaximum (e.g. "exclusiveMinimum: 0") become
+// the boolean form ("minimum: 0, exclusiveMinimum: true"), and nullable type arrays
+// (type: [string, "null"]) become "type: string, nullable: true". Returns nil when the spec
+// contains neither.
+func normalizeOpenAPI31(data []byte) []byte {
+	if !bytes.Contains(data, []byte("exclusiveMinimum")) && !bytes.Contains(data, []byte("exclusiveMaximum")) &&
+		!bytes.Contains(data, []byte("null")) {
+		return nil
+	}
+
+	var raw interface{}
+	if err := yaml.Unmarshal(data, &raw); err != nil {
+		return nil
+	}
+	converted := convertYAMLMapKeys(raw)
+	boundsChanged := rewriteExclusiveBounds(converted)
+	typesChanged := rewriteNullableTypes(converted)
+	if !boundsChanged && !typesChanged {
+		return nil
+	}
+
+	jsonData, err := json.Marshal(converted)
+	if err != nil {
+		return nil
+	}
+	return jsonData
+}
+
+// rewriteExclusiveBounds recursively converts numeric exclusive bounds to minimum/maximum plus a
+// boolean exclusivity flag. A schema with both keeps the tighter bound: an inclusive minimum
+// above the exclusive one (or maximum below it) wins and drops the exclusivity. Returns true
+// if anything was rewritten.
+func rewriteExclusiveBounds(v interface{}) bool {
+	changed := false
+	switch x := v.(type) {
+	case map[string]interface{}:
+		if bound, ok := toFloat64(x["exclusiveMinimum"]); ok {
+			if inclusive, ok := toFloat64(x["minimum"]); ok && inclusive > bound {
+				delete(x, "exclusiveMinimum")
+			} else {
+				x["minimum"] = bound
+				x["exclusiveMinimum"] = true
+			}
+			changed = true
+		}
+		if bound, ok := toFloat64(x["exclusiveMaximum"]); ok {
+			if inclusive, ok := toFloat64(x["maximum"]); ok && inclusive < bound {
+				delete(x, "exclusiveMaximum")
+			} else {
+				x["maximum"] = bound
+				x["exclusiveMaximum"] = true
+			}
+			changed = true
+		}
+		for _, val := range x {
+			if rewriteExclusiveBounds(val) {
+				changed = true
+			}
+		}
+	case []interface{}:
+		for _, item := range x {
+			if rewriteExclusiveBounds(item) {
+				changed = true
+			}
+		}
+	}
+	return changed
+}
+
+// rewriteNullableTypes recursively converts OpenAPI 3.1 type arrays containing "null" into a
+// single type plus "nullable: true". Returns true if anything was rewritten.
+func rewriteNullableTypes(v interface{}) bool {
+	changed := false
+	switch x := v.(type) {
+	case map[string]interface{}:
+		if types, ok := x["type"].([]interface{}); ok {
+			var rest []interface{}
+			hasNull := false
+			for _, t := range types {
+				if t == "null" {
+					hasNull = true
+				} else {
+					rest = append(rest, t)
+				}
+			}
+			if hasNull {
+				x["nullable"] = true
+				switch len(rest) {
+				case 0:
+					delete(x, "type")
+				case 1:
+					x["type"] = rest[0]
+				default:
+					x["type"] = rest
+				}
+				changed = true
+			}
+		}
+		for _, val := range x {
+			if rewriteNullableTypes(val) {
+				changed = true
+			}
+		}
+	case []interface{}:
+		for _, item := range x {
+			if rewriteNullableTypes(item) {
+				changed = true
+			}
+		}
+	}
+	return changed
+}
+
+// toFloat64 converts a decoded YAML/JSON number to float64
+func toFloat64(v interface{}) (float64, bool) {
+	switch n := v.(type) {
+	case int:
+		return float64(n), true
+	case int64:
+		return float64(n), true
+	case uint64:
+		return float64(n), true
+	case float64:
+		return n, true
+	}
+	return 0, false
+}
+
 // convertYAMLMapKeys recursively converts map[interface{}]interface{} to map[string]interface{}
 // This is needed because YAML unmarshalling creates interface{} keys which JSON can't handle
 func convertYAMLMapKeys(v interface{}) interface{} {
@@ -149,6 +460,15 @@ type Resource struct {
 	Operations  []Operation
 	Schema      *Schema
 	Description string
+	// SchemaOptional is true when the request body Schema was taken from is
+	// explicitly optional (requestBody.required: false)
+	SchemaOptional bool
+	// ReadOnly marks an observe-only resource that can only be fetched by its path
+	// parameters (no create, update or delete); detected or set with x-k8s-readonly
+	ReadOnly bool
+	// Owner is the x-k8s-owner extension: the Kind whose CRs own this resource's CRs
+	// (e.g., "Order" for /orders/{orderId}/items); validated by the mapper
+	Owner string
 }
 
 // Operation represents an HTTP operation on a resource
@@ -161,6 +481,51 @@ type Operation struct {
 	ResponseBody *Schema
 	PathParams   []Parameter
 	QueryParams  []Parameter
+	// RequestBodyOptional is true when the spec explicitly marks the request body
+	// as not required (requestBody.required: false)
+	RequestBodyOptional bool
+	// FormEncoded is true when the request body is only accepted as
+	// application/x-www-form-urlencoded
+	FormEncoded bool
+	// RequestContentType is the media type the request body is sent as (e.g.,
+	// "application/json" or "application/vnd.example+json"); empty without a body
+	RequestContentType string
+	// ResponseHeaders lists the header names declared on the 200/201 response
+	ResponseHeaders []string
+	// TargetDefault is the x-k8s-target-default extension: preset spec.target fields
+	// (e.g., {"baseURL": "http://petstore.backend.svc:8080"})
+	TargetDefault map[string]string
+	// SuccessCodes is the x-k8s-success-codes extension: the response codes treated as
+	// success (e.g., ["204"]); validated by the mapper
+	SuccessCodes []string
+	// ResponseContentTypes are the media types of the 2xx responses, sorted (e.g.,
+	// ["application/json", "application/xml"])
+	ResponseContentTypes []string
+	// Security is the operation's security list, or the spec-level one when the operation
+	// has none; nil when neither requires authentication
+	Security []SecurityRequirement
+	// RequestBodyExample is the example request body (the media type's example, its first
+	// named example, or the body schema's example), if the spec gives one
+	RequestBodyExample interface{}
+	// Plural is the x-k8s-plural extension: the exact plural of the resource's CRD
+	Plural string
+	// StatusFields is the x-k8s-status-field extension: status field names mapped to
+	// dot-separated paths in the response body (e.g., "provisioningState" -> "properties.state")
+	StatusFields map[string]string
+	// PollCondition is the x-k8s-poll-condition extension: the response field value that
+	// marks an asynchronously created resource ready
+	PollCondition *PollCondition
+}
+
+// HasResponseHeader reports whether the operation declares the named response header.
+// Header names are compared case-insensitively.
+func (o Operation) HasResponseHeader(name string) bool {
+	for _, h := range o.ResponseHeaders {
+		if strings.EqualFold(h, name) {
+			return true
+		}
+	}
+	return false
 }
 
 // Parameter represents an API parameter
@@ -169,10 +534,20 @@ type Parameter struct {
 	In          string // path, query, header
 	Required    bool
 	Type        string
+	Format      string // e.g., "int32"; the items' format for array params
 	Description string
 	// IDFieldRef is the value of x-k8s-id-field extension, indicating which body field
 	// this path parameter should be merged with (e.g., "id" for orderId -> id mapping)
 	IDFieldRef string
+	// Style and Explode are the parameter's serialization (with the OpenAPI defaults applied,
+	// e.g., simple/false for path params). They decide how array values are joined.
+	Style   string
+	Explode bool
+	// Deprecated marks a parameter the API has deprecated
+	Deprecated bool
+	// AllowEmptyValue marks a query parameter that may be sent with an empty value
+	// (e.g., flag-style ?verbose=)
+	AllowEmptyValue bool
 }
 
 // Schema represents a data schema
@@ -196,6 +571,37 @@ type Schema struct {
 	Pattern     string
 	MinItems    *int64
 	MaxItems    *int64
+	// ExclusiveMinimum/ExclusiveMaximum mark Minimum/Maximum as exclusive bounds
+	ExclusiveMinimum bool
+	ExclusiveMaximum bool
+	// Immutable is set by the x-k8s-immutable extension for fields that cannot change after creation
+	Immutable bool
+	// SpecField is the x-k8s-spec-field extension: the name a top-level request body
+	// property gets in the CR spec, while the API keeps receiving the property's own name
+	SpecField string
+	// WriteOnly marks fields that are sent to the API but never returned (e.g., passwords)
+	WriteOnly bool
+	// PatternProperties maps key regexes to the schema of the values stored under
+	// matching keys (JSON Schema patternProperties), for map-shaped objects
+	PatternProperties map[string]*Schema
+	// Plural is the x-k8s-plural extension: the exact CRD plural for the Kind built from
+	// this schema, overriding the pluralization heuristic
+	Plural string
+	// StatusFields is the x-k8s-status-field extension of a resource schema (see
+	// Operation.StatusFields)
+	StatusFields map[string]string
+	// PollCondition is the x-k8s-poll-condition extension of a resource schema (see
+	// Operation.PollCondition)
+	PollCondition *PollCondition
+	// AnyOfRequired holds the required properties of each member of an anyOf without a
+	// discriminator whose properties were merged into this schema. At least one set must
+	// be satisfied; it is nil when some member requires nothing.
+	AnyOfRequired [][]string
+	// Title is the schema's title, preferred as the Go type name of a nested struct
+	Title string
+	// Not is the schema's not subschema, kept only when it is a pattern, string enum,
+	// length or range the mapper can negate in a CEL rule; others are dropped with a warning
+	Not *Schema
 }
 
 // QueryEndpoint represents a query/search endpoint (GET-only with query params)
@@ -212,6 +618,16 @@ type QueryEndpoint struct {
 	ResponseSchema    *Schema     // Response schema for status
 	ResponseSchemaRef string      // Reference name if response uses $ref (e.g., "Pet")
 	ResponseIsArray   bool        // True if response is an array
+	// TargetDefault is the operation's x-k8s-target-default extension
+	TargetDefault map[string]string
+	// SuccessCodes is the operation's x-k8s-success-codes extension
+	SuccessCodes []string
+	// ResponseContentTypes are the media types of the operation's 2xx responses
+	ResponseContentTypes []string
+	// Security is the operation's effective security list (see Operation.Security)
+	Security []SecurityRequirement
+	// Plural is the operation's x-k8s-plural extension
+	Plural string
 }
 
 // ActionEndpoint represents an action endpoint (POST/PUT on /{resource}/{id}/{action})
@@ -222,6 +638,8 @@ type ActionEndpoint struct {
 	ParentResource string // e.g., "Pet"
 	ParentIDParam  string // e.g., "petId"
 	ParentIDType   string // e.g., "integer" - OpenAPI type of parent ID param
+	ParentIDFormat string // e.g., "int32" - OpenAPI format of parent ID param
+	ParentIDStyle  string // Serialization style of the parent ID param (simple, label or matrix)
 	ActionName     string // e.g., "uploadImage"
 	HTTPMethod     string // POST or PUT
 	Summary        string
@@ -230,9 +648,92 @@ type ActionEndpoint struct {
 	QueryParams    []Parameter // Query parameters
 	RequestSchema  *Schema     // Request body schema
 	ResponseSchema *Schema     // Response schema
+	// RequestBodyOptional is true when the request body is explicitly not required
+	RequestBodyOptional bool
+	// FormEncoded is true when the request body is sent as application/x-www-form-urlencoded
+	FormEncoded bool
+	// RequestContentType is the media type the request body is sent as (see
+	// Operation.RequestContentType); empty for binary and multipart bodies
+	RequestContentType string
 	// Binary upload fields
 	HasBinaryBody     bool   // True if request body is binary (application/octet-stream or multipart/form-data with binary)
 	BinaryContentType string // Content type for binary data (e.g., "application/octet-stream", "multipart/form-data")
+	// Multipart form parts, set when the request body is multipart/form-data
+	FormFields []FormField // Non-binary parts, sent as text form fields
+	FileField  string      // Binary part carrying the upload (e.g., "file"); empty without one
+	// TargetDefault is the operation's x-k8s-target-default extension
+	TargetDefault map[string]string
+	// SuccessCodes is the operation's x-k8s-success-codes extension
+	SuccessCodes []string
+	// ResponseContentTypes are the media types of the operation's 2xx responses
+	ResponseContentTypes []string
+	// Security is the operation's effective security list (see Operation.Security)
+	Security []SecurityRequirement
+	// RequestBodyExample is the example request body, if the spec gives one
+	RequestBodyExample interface{}
+	// Plural is the operation's x-k8s-plural extension
+	Plural string
+}
+
+// FormField is a non-binary part of a multipart/form-data request body
+type FormField struct {
+	Name     string // Part name (e.g., "description")
+	Type     string // OpenAPI type (e.g., "string", "integer")
+	Required bool
+}
+
+// IsMultipart reports whether the action's request body is sent as multipart/form-data
+func (a *ActionEndpoint) IsMultipart() bool {
+	return a.FileField != "" || len(a.FormFields) > 0
+}
+
+// Server is an entry of the spec's servers list, e.g. a production or staging deployment
+type Server struct {
+	Name        string // x-name extension, if set
+	Environment string // x-environment extension (e.g., prod or staging), if set
+	URL         string // Server URL with its variables set to their defaults
+	Description string
+}
+
+// Webhook is an operation of the spec's top-level webhooks object (OpenAPI 3.1): a request
+// the REST API sends to a receiver when something happens on its side
+type Webhook struct {
+	Name        string // Key in the webhooks object, e.g. "newPet"
+	Method      string // e.g. POST
+	OperationID string
+	Summary     string
+	// ContentType is the media type of the payload, preferring JSON (see requestBodyContent)
+	ContentType string
+	// PayloadSchemaRef is the component schema of a JSON payload, if it uses $ref (e.g. "Pet")
+	PayloadSchemaRef string
+	// Kind is the operation's x-k8s-kind extension: the Kind whose CRs the payload
+	// creates or updates
+	Kind string
+}
+
+// SecurityScheme is an entry of components.securitySchemes
+type SecurityScheme struct {
+	Name      string // Key in components.securitySchemes, e.g. "api_key"
+	Type      string // apiKey, http, oauth2, openIdConnect or mutualTLS
+	In        string // apiKey only: header, query or cookie
+	ParamName string // apiKey only: the header, query parameter or cookie name
+	Scheme    string // http only: the Authorization scheme, e.g. "bearer" or "basic"
+}
+
+// PollCondition is an x-k8s-poll-condition: the resource is ready once the response
+// field at Field, a dot-separated path, has one of Values. Interval is how often to poll
+// until then ("" for the default). It is validated by the mapper.
+type PollCondition struct {
+	Field    string
+	Values   []string
+	Interval string
+}
+
+// SecurityRequirement is one security requirement object of a security list. Its schemes
+// all apply to a request together (AND), while any one requirement of the list
+// authenticates it (OR). An empty requirement makes authentication optional.
+type SecurityRequirement struct {
+	Schemes []string // Scheme names, sorted
 }
 
 // ParsedSpec contains the parsed OpenAPI specification
@@ -241,10 +742,17 @@ type ParsedSpec struct {
 	Version         string
 	Description     string
 	BaseURL         string
+	Servers         []Server // All entries of the servers list; BaseURL is the first one's URL
+	Homepage        string   // info.contact.url, or externalDocs.url when there is no contact URL
+	Namespace       string   // x-k8s-namespace extension of info or the document: namespace of the sample CRs
 	Resources       []*Resource
 	QueryEndpoints  []*QueryEndpoint
 	ActionEndpoints []*ActionEndpoint
 	Schemas         map[string]*Schema
+	// SecuritySchemes are the spec's components.securitySchemes, by name
+	SecuritySchemes map[string]SecurityScheme
+	// Webhooks are the operations of the spec's webhooks object, sorted by name and method
+	Webhooks []Webhook
 }
 
 // PathFilter interface for filtering paths, tags, and operationIds
@@ -271,6 +779,44 @@ type Parser struct {
 	RootKind string
 	// Filter is an optional filter for paths and tags
 	Filter PathFilter
+	// SpecRootFile names the root document when Parse is given a directory of
+	// split spec files (relative to that directory). Empty means auto-detect.
+	SpecRootFile string
+	// LogWriter receives diagnostics such as the Swagger 2.0 conversion notice and the
+	// endpoint classification table. Nil means os.Stdout; use io.Discard to silence them.
+	LogWriter io.Writer
+	// SpecFormat forces how the spec is decoded: SpecFormatYAML, SpecFormatJSON or
+	// SpecFormatAuto. Empty means auto. Forcing a format helps with extension-less
+	// files and URLs served with a misleading content type.
+	SpecFormat string
+	// ConstantPathParams maps path parameters with a fixed value to that value. They are
+	// substituted into the paths before endpoints are classified and never become fields.
+	ConstantPathParams map[string]string
+	// SpecCacheDir caches specs fetched from a URL, revalidated with conditional GETs and
+	// reused when the server can't be reached. Empty disables the cache.
+	SpecCacheDir string
+
+	// optionalBodies holds operations whose request body is explicitly optional
+	optionalBodies map[*openapi3.Operation]bool
+	// componentSchemas resolves $refs inside patternProperties, which kin-openapi
+	// leaves as raw extension data
+	componentSchemas openapi3.Schemas
+	// resolvingRefs guards against recursive patternProperties $refs
+	resolvingRefs map[string]bool
+	// specSecurity is the spec-level security list, inherited by operations without one
+	specSecurity openapi3.SecurityRequirements
+	// skippedNots are the not subschemas already warned about, as components are
+	// converted once per endpoint using them
+	skippedNots map[*openapi3.Schema]bool
+}
+
+// logf writes a diagnostic message to LogWriter
+func (p *Parser) logf(format string, args ...interface{}) {
+	w := p.LogWriter
+	if w == nil {
+		w = os.Stdout
+	}
+	fmt.Fprintf(w, format, args...)
 }
 
 // NewParser creates a new OpenAPI parser
@@ -288,13 +834,130 @@ func NewParserWithFilter(rootKind string, filter PathFilter) *Parser {
 	return &Parser{RootKind: rootKind, Filter: filter}
 }
 
-// Parse parses an OpenAPI specification file or URL
+// Parse parses an OpenAPI specification file, URL, or directory of split files
 // Supports both Swagger 2.0 and OpenAPI 3.0/3.1 specifications
 func (p *Parser) Parse(specPath string) (*ParsedSpec, error) {
+	doc, data, isSwagger2, err := p.loadDocument(specPath)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateDocument(doc, isSwagger2); err != nil {
+		return nil, err
+	}
+
+	// kin-openapi can't distinguish an omitted requestBody.required from an explicit
+	// "required: false", so find the explicitly optional bodies in the raw spec
+	p.optionalBodies = findOptionalRequestBodies(data, doc)
+
+	if err := p.applyConstantPathParams(doc); err != nil {
+		return nil, err
+	}
+
+	spec := &ParsedSpec{
+		Title:           doc.Info.Title,
+		Version:         doc.Info.Version,
+		Schemas:         make(map[string]*Schema),
+		Resources:       make([]*Resource, 0),
+		QueryEndpoints:  make([]*QueryEndpoint, 0),
+		ActionEndpoints: make([]*ActionEndpoint, 0),
+	}
+
+	if doc.Info.Description != "" {
+		spec.Description = doc.Info.Description
+	}
+
+	if doc.Info.Contact != nil && doc.Info.Contact.URL != "" {
+		spec.Homepage = doc.Info.Contact.URL
+	} else if doc.ExternalDocs != nil {
+		spec.Homepage = doc.ExternalDocs.URL
+	}
+
+	spec.Namespace, _ = doc.Info.Extensions["x-k8s-namespace"].(string)
+	if spec.Namespace == "" {
+		spec.Namespace, _ = doc.Extensions["x-k8s-namespace"].(string)
+	}
+
+	// Extract base URL from servers
+	if len(doc.Servers) > 0 {
+		spec.BaseURL = doc.Servers[0].URL
+	}
+	spec.Servers = specServers(doc.Servers)
+
+	p.specSecurity = doc.Security
+	if doc.Components != nil {
+		spec.SecuritySchemes = securitySchemes(doc.Components.SecuritySchemes)
+	}
+
+	// Parse component schemas
+	if doc.Components != nil && doc.Components.Schemas != nil {
+		p.componentSchemas = doc.Components.Schemas
+		for name, schemaRef := range doc.Components.Schemas {
+			spec.Schemas[name] = p.convertSchema(name, schemaRef.Value)
+		}
+	}
+
+	// Parse paths and extract resources, query endpoints, and action endpoints
+	resources, queryEndpoints, actionEndpoints := p.extractResourcesQueriesAndActions(doc)
+	spec.Resources = resources
+	spec.QueryEndpoints = queryEndpoints
+	spec.ActionEndpoints = actionEndpoints
+
+	webhooks, err := p.extractWebhooks(doc)
+	if err != nil {
+		return nil, err
+	}
+	spec.Webhooks = webhooks
+
+	return spec, nil
+}
+
+// Document is a loaded spec before it is validated and parsed, for tools that inspect
+// the OpenAPI document itself, such as the spec linter
+type Document struct {
+	// Doc is the loaded document; Swagger 2.0 specs are converted to OpenAPI 3.0
+	Doc *openapi3.T
+	// Raw is the spec as read (the root document for a directory of split files)
+	Raw []byte
+	// Swagger2 reports whether the spec was Swagger 2.0
+	Swagger2 bool
+}
+
+// Load reads and loads a spec like Parse, without validating or parsing it
+func (p *Parser) Load(specPath string) (*Document, error) {
+	doc, data, isSwagger2, err := p.loadDocument(specPath)
+	if err != nil {
+		return nil, err
+	}
+	return &Document{Doc: doc, Raw: data, Swagger2: isSwagger2}, nil
+}
+
+// Validate runs the validation Parse applies before parsing
+func (d *Document) Validate() error {
+	return validateDocument(d.Doc, d.Swagger2)
+}
+
+// loadDocument reads and loads a spec without validating it, converting Swagger 2.0 to
+// OpenAPI 3.0. It also returns the raw spec and whether it was Swagger 2.0.
+func (p *Parser) loadDocument(specPath string) (*openapi3.T, []byte, bool, error) {
+	// A directory is loaded through its root document; refs between the files
+	// are resolved relative to it like any other external ref
+	specPath, err := ResolveSpecRoot(specPath, p.SpecRootFile)
+	if err != nil {
+		return nil, nil, false, err
+	}
+
 	// Read the raw spec first to detect version
-	data, err := readSpec(specPath)
+	data, err := readSpec(specPath, p.SpecCacheDir, p.logf)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read spec: %w", err)
+		return nil, nil, false, fmt.Errorf("failed to read spec: %w", err)
+	}
+
+	// A forced format is checked before anything else so a malformed spec fails
+	// with the decoder the caller asked for rather than the loader's fallback
+	forceFormat := p.SpecFormat != "" && p.SpecFormat != SpecFormatAuto
+	decoded, err := decodeSpecFormat(data, p.SpecFormat)
+	if err != nil {
+		return nil, nil, false, err
 	}
 
 	version := detectSpecVersion(data)
@@ -304,87 +967,83 @@ func (p *Parser) Parse(specPath string) (*ParsedSpec, error) {
 
 	if version == "2.0" {
 		// Parse as Swagger 2.0 and convert to OpenAPI 3.0
-		fmt.Println("Detected Swagger 2.0 specification, converting to OpenAPI 3.0...")
-		doc, err = parseSwagger2(data)
+		p.logf("Detected Swagger 2.0 specification, converting to OpenAPI 3.0...\n")
+		doc, err = parseSwagger2(data, p.SpecFormat)
 		if err != nil {
-			return nil, err
+			return nil, nil, false, err
 		}
 		isSwagger2 = true
 	} else {
 		// Parse as OpenAPI 3.x
 		loader := openapi3.NewLoader()
 		loader.IsExternalRefsAllowed = true
+		// Cache the files read per load rather than in kin-openapi's process-wide cache, so a
+		// long-running MCP server sees a spec edited between two generations
+		loader.ReadFromURIFunc = openapi3.URIMapCache(openapi3.ReadFromURIs(openapi3.ReadFromHTTP(http.DefaultClient), openapi3.ReadFromFile))
+
+		// OpenAPI 3.1 numeric exclusive bounds and nullable type arrays must be rewritten
+		// before loading, since kin-openapi only understands the 3.0 forms
+		normalized := normalizeOpenAPI31(decoded)
+		if normalized == nil && forceFormat {
+			// Load from the decoded bytes so the loader never re-sniffs the format
+			normalized = decoded
+		}
 
 		if isURL(specPath) {
 			// Load from URL
 			specURL, parseErr := url.Parse(specPath)
 			if parseErr != nil {
-				return nil, fmt.Errorf("failed to parse spec URL: %w", parseErr)
+				return nil, nil, false, fmt.Errorf("failed to parse spec URL: %w", parseErr)
+			}
+			// Load from the bytes already read so the spec is fetched (or served from
+			// the spec cache) only once
+			if normalized == nil {
+				normalized = data
 			}
-			doc, err = loader.LoadFromURI(specURL)
+			doc, err = loader.LoadFromDataWithPath(normalized, specURL)
 			if err != nil {
-				return nil, fmt.Errorf("failed to load OpenAPI spec from URL: %w", err)
+				return nil, nil, false, fmt.Errorf("failed to load OpenAPI spec from URL: %w", err)
 			}
 		} else {
 			// Load from file
-			doc, err = loader.LoadFromFile(specPath)
+			if normalized != nil {
+				doc, err = loader.LoadFromDataWithPath(normalized, &url.URL{Path: filepath.ToSlash(specPath)})
+			} else {
+				doc, err = loader.LoadFromFile(specPath)
+			}
 			if err != nil {
-				return nil, fmt.Errorf("failed to load OpenAPI spec from file: %w", err)
+				return nil, nil, false, fmt.Errorf("failed to load OpenAPI spec from file: %w", err)
 			}
 		}
 	}
 
-	// Validate the spec - use lenient validation for converted Swagger 2.0 specs
+	return doc, data, isSwagger2, nil
+}
+
+// validateDocument checks a loaded spec. Converted Swagger 2.0 specs only need paths.
+func validateDocument(doc *openapi3.T, isSwagger2 bool) error {
+	// Use lenient validation for converted Swagger 2.0 specs
 	// since they may have incomplete response definitions
 	if isSwagger2 {
 		// Skip strict validation for Swagger 2.0 specs - just do minimal checks
 		if doc.Paths == nil {
-			return nil, fmt.Errorf("invalid OpenAPI spec: no paths defined")
+			return fmt.Errorf("invalid OpenAPI spec: no paths defined")
 		}
 	} else {
 		// Disable example validation - example values don't affect code generation
 		// and many real-world specs have type mismatches in examples (e.g. numeric zip codes
-		// declared as string type)
+		// declared as string type). patternProperties is JSON Schema rather than OpenAPI 3.0,
+		// but it is read for keyed maps, so it is allowed next to the OpenAPI keywords, as
+		// is the OpenAPI 3.1 webhooks object, which kin-openapi leaves to extractWebhooks.
 		ctx := openapi3.WithValidationOptions(context.Background(),
 			openapi3.DisableExamplesValidation(),
+			openapi3.AllowExtraSiblingFields("patternProperties", "webhooks"),
 		)
 		if err := doc.Validate(ctx); err != nil {
-			return nil, fmt.Errorf("invalid OpenAPI spec: %w", err)
+			return fmt.Errorf("invalid OpenAPI spec: %w", err)
 		}
 	}
-
-	spec := &ParsedSpec{
-		Title:           doc.Info.Title,
-		Version:         doc.Info.Version,
-		Schemas:         make(map[string]*Schema),
-		Resources:       make([]*Resource, 0),
-		QueryEndpoints:  make([]*QueryEndpoint, 0),
-		ActionEndpoints: make([]*ActionEndpoint, 0),
-	}
-
-	if doc.Info.Description != "" {
-		spec.Description = doc.Info.Description
-	}
-
-	// Extract base URL from servers
-	if len(doc.Servers) > 0 {
-		spec.BaseURL = doc.Servers[0].URL
-	}
-
-	// Parse component schemas
-	if doc.Components != nil && doc.Components.Schemas != nil {
-		for name, schemaRef := range doc.Components.Schemas {
-			spec.Schemas[name] = p.convertSchema(name, schemaRef.Value)
-		}
-	}
-
-	// Parse paths and extract resources, query endpoints, and action endpoints
-	resources, queryEndpoints, actionEndpoints := p.extractResourcesQueriesAndActions(doc)
-	spec.Resources = resources
-	spec.QueryEndpoints = queryEndpoints
-	spec.ActionEndpoints = actionEndpoints
-
-	return spec, nil
+	return nil
 }
 
 // getPathTagsAndOperationIDs extracts all unique tags and operationIds from operations on a path
@@ -564,16 +1223,16 @@ func (p *Parser) extractResourcesQueriesAndActions(doc *openapi3.T) ([]*Resource
 			}
 		}
 		if filteredCount > 0 {
-			fmt.Printf("Filtering: %d of %d paths excluded by filter\n", filteredCount, len(paths))
+			p.logf("Filtering: %d of %d paths excluded by filter\n", filteredCount, len(paths))
 		}
 	}
 
 	// Log endpoint classification header
-	fmt.Println("\n┌────────────────────────────────────────────────────────────────────────────────────────────────────────────────────┐")
-	fmt.Println("│                                        Endpoint Classification                                                    │")
-	fmt.Println("├────────────────────────────────────┬──────────────┬────────────────────┬─────────────────────┬─────────────────────┤")
-	fmt.Println("│ Endpoint                           │ Method       │ Classification     │ Kind                │ Parent ID Param     │")
-	fmt.Println("├────────────────────────────────────┼──────────────┼────────────────────┼─────────────────────┼─────────────────────┤")
+	p.logf("\n┌────────────────────────────────────────────────────────────────────────────────────────────────────────────────────┐\n")
+	p.logf("│                                        Endpoint Classification                                                    │\n")
+	p.logf("├────────────────────────────────────┬──────────────┬────────────────────┬─────────────────────┬─────────────────────┤\n")
+	p.logf("│ Endpoint                           │ Method       │ Classification     │ Kind                │ Parent ID Param     │\n")
+	p.logf("├────────────────────────────────────┼──────────────┼────────────────────┼─────────────────────┼─────────────────────┤\n")
 
 	for _, path := range paths {
 		pathItem := doc.Paths.Map()[path]
@@ -606,12 +1265,13 @@ func (p *Parser) extractResourcesQueriesAndActions(doc *openapi3.T) ([]*Resource
 				classification = "Filtered (op)"
 			}
 
-			printWrappedTableRow(path, methodDisplay, classification, "-", "-")
+			p.printWrappedTableRow(path, methodDisplay, classification, "-", "-")
 			continue
 		}
 
 		// Check if this path is a base path with POST that has a corresponding resource ID path
 		// e.g., /pet with POST + /pet/{petId} with GET/PUT/DELETE = combined resource
+		readOnly := false
 		if p.hasCorrespondingResourceIDPath(path, doc, resourceIDPaths) && pathItem.Post != nil {
 			// This is a base path that should be combined with its ID path
 			// Mark it as combined and process as a resource
@@ -626,21 +1286,27 @@ func (p *Parser) extractResourcesQueriesAndActions(doc *openapi3.T) ([]*Resource
 				if parentIDDisplay == "" {
 					parentIDDisplay = "-"
 				}
-				printWrappedTableRow(path, actionEndpoint.HTTPMethod, "ActionEndpoint", actionEndpoint.Name, parentIDDisplay)
+				p.printWrappedTableRow(path, actionEndpoint.HTTPMethod, "ActionEndpoint", actionEndpoint.Name, parentIDDisplay)
 				continue
 			}
 
+			// Check if this is a read-only resource before query endpoints: a GET of a
+			// single object by ID is observed, not queried
+			readOnly = p.isReadOnlyResource(path, pathItem, doc)
+
 			// Check if this is a query endpoint
-			if queryEndpoint := p.extractQueryEndpoint(path, pathItem, doc); queryEndpoint != nil {
-				queryEndpoints = append(queryEndpoints, queryEndpoint)
-				printWrappedTableRow(path, "GET", "QueryEndpoint", queryEndpoint.Name, "-")
-				continue
+			if !readOnly {
+				if queryEndpoint := p.extractQueryEndpoint(path, pathItem, doc); queryEndpoint != nil {
+					queryEndpoints = append(queryEndpoints, queryEndpoint)
+					p.printWrappedTableRow(path, "GET", "QueryEndpoint", queryEndpoint.Name, "-")
+					continue
+				}
 			}
 		}
 
 		resourceName := p.extractResourceName(path)
 		if resourceName == "" {
-			printWrappedTableRow(path, methods, "Skipped", "-", "-")
+			p.printWrappedTableRow(path, methods, "Skipped", "-", "-")
 			continue
 		}
 
@@ -651,13 +1317,17 @@ func (p *Parser) extractResourcesQueriesAndActions(doc *openapi3.T) ([]*Resource
 				PluralName: p.pluralize(resourceName),
 				Path:       p.getBasePath(path),
 				Operations: make([]Operation, 0),
+				ReadOnly:   readOnly,
 			}
 			resourceMap[resourceName] = resource
 		}
+		resource.ReadOnly = resource.ReadOnly && readOnly
 
 		// Check if this is a combined resource (base path that was combined with ID path)
 		classification := "Resource"
-		if combinedBasePaths[path] {
+		if readOnly {
+			classification = "ReadOnlyResource"
+		} else if combinedBasePaths[path] {
 			classification = "Resource (POST)"
 		} else if p.isResourceIDPath(path) {
 			// Check if this ID path has a corresponding base path with POST
@@ -676,20 +1346,27 @@ func (p *Parser) extractResourcesQueriesAndActions(doc *openapi3.T) ([]*Resource
 			methodDisplay = fmt.Sprintf("%s ~%s~", passed, filtered)
 		}
 
-		printWrappedTableRow(path, methodDisplay, classification, resourceName, "-")
+		p.printWrappedTableRow(path, methodDisplay, classification, resourceName, "-")
 
-		// Extract operations
-		ops := p.extractOperations(path, pathItem)
+		// Extract operations (only the GET of a read-only resource)
+		opsItem := pathItem
+		if readOnly {
+			opsItem = &openapi3.PathItem{Get: pathItem.Get, Parameters: pathItem.Parameters}
+		}
+		ops := p.extractOperations(path, opsItem)
 		resource.Operations = append(resource.Operations, ops...)
+		if resource.Owner == "" {
+			resource.Owner = ownerExtension(opsItem)
+		}
 
 		// Try to extract schema from POST/PUT request body
-		if resource.Schema == nil {
-			resource.Schema = p.extractResourceSchema(pathItem, doc)
+		if resource.Schema == nil && !readOnly {
+			resource.Schema, resource.SchemaOptional = p.extractResourceSchema(pathItem, doc)
 		}
 	}
 
-	fmt.Println("└────────────────────────────────────┴──────────────┴────────────────────┴─────────────────────┴─────────────────────┘")
-	fmt.Println()
+	p.logf("└────────────────────────────────────┴──────────────┴────────────────────┴─────────────────────┴─────────────────────┘\n")
+	p.logf("\n")
 
 	// Convert map to slice
 	resources := make([]*Resource, 0, len(resourceMap))
@@ -874,6 +1551,12 @@ func (p *Parser) extractActionEndpoint(path string, pathItem *openapi3.PathItem,
 		Description:    op.Description,
 		PathParams:     make([]Parameter, 0),
 		QueryParams:    make([]Parameter, 0),
+		TargetDefault:  targetDefaultExtension(op.Extensions),
+		SuccessCodes:   successCodesExtension(op.Extensions),
+		Plural:         pluralExtension(op.Extensions),
+
+		ResponseContentTypes: responseContentTypes(op),
+		Security:             p.operationSecurity(op),
 	}
 
 	// Extract parameters
@@ -881,17 +1564,7 @@ func (p *Parser) extractActionEndpoint(path string, pathItem *openapi3.PathItem,
 		if paramRef.Value == nil {
 			continue
 		}
-		param := Parameter{
-			Name:        paramRef.Value.Name,
-			In:          paramRef.Value.In,
-			Required:    paramRef.Value.Required,
-			Description: paramRef.Value.Description,
-		}
-		if paramRef.Value.Schema != nil && paramRef.Value.Schema.Value != nil {
-			if len(paramRef.Value.Schema.Value.Type.Slice()) > 0 {
-				param.Type = paramRef.Value.Schema.Value.Type.Slice()[0]
-			}
-		}
+		param := newParameter(paramRef.Value)
 
 		// Extract x-k8s-id-field extension if present
 		if paramRef.Value.Extensions != nil {
@@ -905,6 +1578,8 @@ func (p *Parser) extractActionEndpoint(path string, pathItem *openapi3.PathItem,
 		// Capture the parent ID param's type, then skip it (handled separately)
 		if param.Name == parentIDParam {
 			actionEndpoint.ParentIDType = param.Type
+			actionEndpoint.ParentIDFormat = param.Format
+			actionEndpoint.ParentIDStyle = param.Style
 			continue
 		}
 
@@ -918,25 +1593,50 @@ func (p *Parser) extractActionEndpoint(path string, pathItem *openapi3.PathItem,
 
 	// Extract request body schema
 	if op.RequestBody != nil && op.RequestBody.Value != nil {
-		if content, ok := op.RequestBody.Value.Content["application/json"]; ok {
+		actionEndpoint.RequestBodyOptional = p.optionalBodies[op]
+		if content, mediaType := requestBodyContent(op.RequestBody.Value); content != nil {
 			if content.Schema != nil && content.Schema.Value != nil {
 				actionEndpoint.RequestSchema = p.convertSchema("RequestBody", content.Schema.Value)
+				actionEndpoint.FormEncoded = mediaType == formMediaType
+				actionEndpoint.RequestContentType = mediaType
 			}
+			actionEndpoint.RequestBodyExample = requestBodyExample(content)
 		}
 		// Check for multipart/form-data (common for file uploads)
 		if content, ok := op.RequestBody.Value.Content["multipart/form-data"]; ok {
 			if content.Schema != nil && content.Schema.Value != nil {
 				schema := content.Schema.Value
 				actionEndpoint.RequestSchema = p.convertSchema("RequestBody", schema)
-				// Check if any property has binary format
-				if schema.Properties != nil {
-					for _, prop := range schema.Properties {
-						if prop.Value != nil && prop.Value.Format == "binary" {
+				actionEndpoint.FormEncoded = false
+				actionEndpoint.RequestContentType = ""
+				// The first binary property carries the upload; the others are text parts
+				propNames := make([]string, 0, len(schema.Properties))
+				for propName := range schema.Properties {
+					propNames = append(propNames, propName)
+				}
+				sort.Strings(propNames)
+				for _, propName := range propNames {
+					prop := schema.Properties[propName]
+					if prop.Value == nil {
+						continue
+					}
+					if prop.Value.Format == "binary" {
+						if actionEndpoint.FileField == "" {
 							actionEndpoint.HasBinaryBody = true
 							actionEndpoint.BinaryContentType = "multipart/form-data"
+							actionEndpoint.FileField = propName
+						}
+						continue
+					}
+					formField := FormField{Name: propName}
+					formField.Type, _ = schemaType(prop.Value)
+					for _, req := range schema.Required {
+						if req == propName {
+							formField.Required = true
 							break
 						}
 					}
+					actionEndpoint.FormFields = append(actionEndpoint.FormFields, formField)
 				}
 			}
 		}
@@ -946,14 +1646,16 @@ func (p *Parser) extractActionEndpoint(path string, pathItem *openapi3.PathItem,
 				schema := content.Schema.Value
 				actionEndpoint.RequestSchema = p.convertSchema("RequestBody", schema)
 				// application/octet-stream with binary format is a binary upload
-				schemaType := ""
-				if len(schema.Type.Slice()) > 0 {
-					schemaType = schema.Type.Slice()[0]
-				}
-				if schema.Format == "binary" || schemaType == "string" {
+				bodyType, _ := schemaType(schema)
+				if schema.Format == "binary" || bodyType == "string" {
 					actionEndpoint.HasBinaryBody = true
 					actionEndpoint.BinaryContentType = "application/octet-stream"
 				}
+				// The raw body replaces any form declared alongside it
+				actionEndpoint.FormFields = nil
+				actionEndpoint.FileField = ""
+				actionEndpoint.FormEncoded = false
+				actionEndpoint.RequestContentType = ""
 			}
 		}
 	}
@@ -984,6 +1686,51 @@ func (p *Parser) isQueryEndpoint(path string, pathItem *openapi3.PathItem) bool
 		pathItem.Delete == nil
 }
 
+// isReadOnlyResource checks if a path is an observe-only resource: a GET of a single
+// object by ID (e.g., /regions/{regionId}) with no way to create, update or delete it.
+// The x-k8s-readonly extension of the GET operation or the path overrides the detection;
+// either way the path must have path parameters to identify the object.
+func (p *Parser) isReadOnlyResource(path string, pathItem *openapi3.PathItem, doc *openapi3.T) bool {
+	if pathItem.Get == nil || !strings.Contains(path, "{") {
+		return false
+	}
+	if readOnly, ok := readOnlyExtension(pathItem); ok {
+		return readOnly
+	}
+	if !p.isQueryEndpoint(path, pathItem) || !p.isResourceIDPath(path) {
+		return false
+	}
+	// Only a listable collection that can't be created into is read-only; a lone GET-only
+	// ID path stays a query endpoint
+	base := doc.Paths.Map()[p.getBasePathForIDPath(path)]
+	return base != nil && base.Get != nil && base.Post == nil && base.Put == nil
+}
+
+// readOnlyExtension reads the x-k8s-readonly extension of a path's GET operation, else
+// of the path item
+func readOnlyExtension(pathItem *openapi3.PathItem) (readOnly, ok bool) {
+	if readOnly, ok = pathItem.Get.Extensions["x-k8s-readonly"].(bool); ok {
+		return readOnly, true
+	}
+	readOnly, ok = pathItem.Extensions["x-k8s-readonly"].(bool)
+	return readOnly, ok
+}
+
+// ownerExtension reads the x-k8s-owner extension of a path's operations, else of the
+// path item
+func ownerExtension(pathItem *openapi3.PathItem) string {
+	for _, op := range []*openapi3.Operation{pathItem.Get, pathItem.Post, pathItem.Put, pathItem.Patch, pathItem.Delete} {
+		if op == nil {
+			continue
+		}
+		if owner, _ := op.Extensions["x-k8s-owner"].(string); owner != "" {
+			return owner
+		}
+	}
+	owner, _ := pathItem.Extensions["x-k8s-owner"].(string)
+	return owner
+}
+
 // extractQueryEndpoint extracts a query endpoint definition
 func (p *Parser) extractQueryEndpoint(path string, pathItem *openapi3.PathItem, doc *openapi3.T) *QueryEndpoint {
 	if !p.isQueryEndpoint(path, pathItem) {
@@ -1018,15 +1765,21 @@ func (p *Parser) extractQueryEndpoint(path string, pathItem *openapi3.PathItem,
 	operation := parts[len(parts)-1]
 
 	queryEndpoint := &QueryEndpoint{
-		Name:        name,
-		OperationID: op.OperationID,
-		Path:        path,
-		BasePath:    basePath,
-		Operation:   operation,
-		Summary:     op.Summary,
-		Description: op.Description,
-		PathParams:  make([]Parameter, 0),
-		QueryParams: make([]Parameter, 0),
+		Name:          name,
+		OperationID:   op.OperationID,
+		Path:          path,
+		BasePath:      basePath,
+		Operation:     operation,
+		Summary:       op.Summary,
+		Description:   op.Description,
+		PathParams:    make([]Parameter, 0),
+		QueryParams:   make([]Parameter, 0),
+		TargetDefault: targetDefaultExtension(op.Extensions),
+		SuccessCodes:  successCodesExtension(op.Extensions),
+		Plural:        pluralExtension(op.Extensions),
+
+		ResponseContentTypes: responseContentTypes(op),
+		Security:             p.operationSecurity(op),
 	}
 
 	// Extract path and query parameters
@@ -1035,17 +1788,7 @@ func (p *Parser) extractQueryEndpoint(path string, pathItem *openapi3.PathItem,
 			continue
 		}
 		if paramRef.Value.In == "path" {
-			param := Parameter{
-				Name:        paramRef.Value.Name,
-				In:          paramRef.Value.In,
-				Required:    paramRef.Value.Required,
-				Description: paramRef.Value.Description,
-			}
-			if paramRef.Value.Schema != nil && paramRef.Value.Schema.Value != nil {
-				if len(paramRef.Value.Schema.Value.Type.Slice()) > 0 {
-					param.Type = paramRef.Value.Schema.Value.Type.Slice()[0]
-				}
-			}
+			param := newParameter(paramRef.Value)
 			// Extract x-k8s-id-field extension if present
 			if paramRef.Value.Extensions != nil {
 				if idFieldRef, ok := paramRef.Value.Extensions["x-k8s-id-field"]; ok {
@@ -1056,24 +1799,8 @@ func (p *Parser) extractQueryEndpoint(path string, pathItem *openapi3.PathItem,
 			}
 			queryEndpoint.PathParams = append(queryEndpoint.PathParams, param)
 		} else if paramRef.Value.In == "query" {
-			param := Parameter{
-				Name:        paramRef.Value.Name,
-				In:          paramRef.Value.In,
-				Required:    paramRef.Value.Required,
-				Description: paramRef.Value.Description,
-			}
-			if paramRef.Value.Schema != nil && paramRef.Value.Schema.Value != nil {
-				schemaVal := paramRef.Value.Schema.Value
-				if len(schemaVal.Type.Slice()) > 0 {
-					param.Type = schemaVal.Type.Slice()[0]
-				}
-				// Handle array query params (e.g., tags[])
-				if param.Type == "array" && schemaVal.Items != nil && schemaVal.Items.Value != nil {
-					if len(schemaVal.Items.Value.Type.Slice()) > 0 {
-						param.Type = "array:" + schemaVal.Items.Value.Type.Slice()[0]
-					}
-				}
-			}
+			// Array query params (e.g., tags[]) are typed "array:<itemType>"
+			param := newParameter(paramRef.Value)
 			queryEndpoint.QueryParams = append(queryEndpoint.QueryParams, param)
 		}
 	}
@@ -1288,12 +2015,20 @@ func (p *Parser) extractOperations(path string, pathItem *openapi3.PathItem) []O
 		}
 
 		operation := Operation{
-			Method:      method,
-			Path:        path,
-			OperationID: op.OperationID,
-			Summary:     op.Summary,
-			PathParams:  make([]Parameter, 0),
-			QueryParams: make([]Parameter, 0),
+			Method:        method,
+			Path:          path,
+			OperationID:   op.OperationID,
+			Summary:       op.Summary,
+			PathParams:    make([]Parameter, 0),
+			QueryParams:   make([]Parameter, 0),
+			TargetDefault: targetDefaultExtension(op.Extensions),
+			SuccessCodes:  successCodesExtension(op.Extensions),
+			Plural:        pluralExtension(op.Extensions),
+			StatusFields:  statusFieldExtension(op.Extensions),
+			PollCondition: pollConditionExtension(op.Extensions),
+
+			ResponseContentTypes: responseContentTypes(op),
+			Security:             p.operationSecurity(op),
 		}
 
 		// Extract parameters
@@ -1301,15 +2036,7 @@ func (p *Parser) extractOperations(path string, pathItem *openapi3.PathItem) []O
 			if paramRef.Value == nil {
 				continue
 			}
-			param := Parameter{
-				Name:        paramRef.Value.Name,
-				In:          paramRef.Value.In,
-				Required:    paramRef.Value.Required,
-				Description: paramRef.Value.Description,
-			}
-			if paramRef.Value.Schema != nil && paramRef.Value.Schema.Value != nil {
-				param.Type = paramRef.Value.Schema.Value.Type.Slice()[0]
-			}
+			param := newParameter(paramRef.Value)
 
 			// Extract x-k8s-id-field extension if present
 			if paramRef.Value.Extensions != nil {
@@ -1330,16 +2057,23 @@ func (p *Parser) extractOperations(path string, pathItem *openapi3.PathItem) []O
 
 		// Extract request body schema
 		if op.RequestBody != nil && op.RequestBody.Value != nil {
-			if content, ok := op.RequestBody.Value.Content["application/json"]; ok {
+			if content, mediaType := requestBodyContent(op.RequestBody.Value); content != nil {
 				if content.Schema != nil && content.Schema.Value != nil {
 					operation.RequestBody = p.convertSchema("RequestBody", content.Schema.Value)
+					operation.FormEncoded = mediaType == formMediaType
+					operation.RequestContentType = mediaType
 				}
+				operation.RequestBodyExample = requestBodyExample(content)
 			}
+			operation.RequestBodyOptional = p.optionalBodies[op]
 		}
 
 		// Extract response body schema (from 200 or 201 response)
 		for _, code := range []string{"200", "201"} {
 			if resp := op.Responses.Status(p.parseStatusCode(code)); resp != nil && resp.Value != nil {
+				if operation.ResponseHeaders == nil {
+					operation.ResponseHeaders = responseHeaderNames(resp.Value)
+				}
 				if content, ok := resp.Value.Content["application/json"]; ok {
 					if content.Schema != nil && content.Schema.Value != nil {
 						operation.ResponseBody = p.convertSchema("ResponseBody", content.Schema.Value)
@@ -1355,6 +2089,317 @@ func (p *Parser) extractOperations(path string, pathItem *openapi3.PathItem) []O
 	return ops
 }
 
+// targetDefaultExtension reads the x-k8s-target-default extension, an object of
+// spec.target fields. Values are converted to strings; keys are validated by the mapper.
+func targetDefaultExtension(extensions map[string]interface{}) map[string]string {
+	raw, ok := extensions["x-k8s-target-default"].(map[string]interface{})
+	if !ok || len(raw) == 0 {
+		return nil
+	}
+	target := make(map[string]string, len(raw))
+	for k, v := range raw {
+		target[k] = fmt.Sprint(v)
+	}
+	return target
+}
+
+// successCodesExtension reads the x-k8s-success-codes extension, a list of status codes
+// or a comma-separated string of them. The codes are validated by the mapper.
+func successCodesExtension(extensions map[string]interface{}) []string {
+	var codes []string
+	switch raw := extensions["x-k8s-success-codes"].(type) {
+	case []interface{}:
+		for _, code := range raw {
+			codes = append(codes, fmt.Sprint(code))
+		}
+	case string:
+		codes = strings.Split(raw, ",")
+	case float64:
+		codes = []string{fmt.Sprint(raw)}
+	}
+	return codes
+}
+
+// responseContentTypes returns the media types of an operation's 2xx responses (including
+// 2XX ranges), sorted and de-duplicated
+func responseContentTypes(op *openapi3.Operation) []string {
+	if op.Responses == nil {
+		return nil
+	}
+	seen := make(map[string]bool)
+	var types []string
+	for code, resp := range op.Responses.Map() {
+		if !strings.HasPrefix(code, "2") || resp == nil || resp.Value == nil {
+			continue
+		}
+		for mediaType := range resp.Value.Content {
+			if !seen[mediaType] {
+				seen[mediaType] = true
+				types = append(types, mediaType)
+			}
+		}
+	}
+	sort.Strings(types)
+	return types
+}
+
+// securitySchemes converts components.securitySchemes, skipping unresolved entries
+func securitySchemes(schemes openapi3.SecuritySchemes) map[string]SecurityScheme {
+	if len(schemes) == 0 {
+		return nil
+	}
+	result := make(map[string]SecurityScheme, len(schemes))
+	for name, ref := range schemes {
+		if ref == nil || ref.Value == nil {
+			continue
+		}
+		result[name] = SecurityScheme{
+			Name:      name,
+			Type:      ref.Value.Type,
+			In:        ref.Value.In,
+			ParamName: ref.Value.Name,
+			Scheme:    strings.ToLower(ref.Value.Scheme),
+		}
+	}
+	return result
+}
+
+// operationSecurity returns an operation's security list, falling back to the spec-level
+// one when the operation doesn't set it. An explicitly empty list ("security: []") turns
+// authentication off for the operation.
+func (p *Parser) operationSecurity(op *openapi3.Operation) []SecurityRequirement {
+	requirements := p.specSecurity
+	if op.Security != nil {
+		requirements = *op.Security
+	}
+	if len(requirements) == 0 {
+		return nil
+	}
+	result := make([]SecurityRequirement, 0, len(requirements))
+	for _, requirement := range requirements {
+		names := make([]string, 0, len(requirement))
+		for name := range requirement {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		result = append(result, SecurityRequirement{Schemes: names})
+	}
+	return result
+}
+
+// pluralExtension reads the x-k8s-plural extension, the exact CRD plural for a Kind.
+// The value is validated by the mapper.
+func pluralExtension(extensions map[string]interface{}) string {
+	plural, _ := extensions["x-k8s-plural"].(string)
+	return plural
+}
+
+// applyConstantPathParams substitutes the ConstantPathParams values into the spec's path
+// templates and drops those parameters, so /api/{version}/pets with version=v1 is
+// classified and named exactly like a literal /api/v1/pets
+func (p *Parser) applyConstantPathParams(doc *openapi3.T) error {
+	if len(p.ConstantPathParams) == 0 || doc.Paths == nil {
+		return nil
+	}
+	withoutConstants := func(params openapi3.Parameters) openapi3.Parameters {
+		var kept openapi3.Parameters
+		for _, ref := range params {
+			if ref != nil && ref.Value != nil && ref.Value.In == openapi3.ParameterInPath {
+				if _, ok := p.ConstantPathParams[ref.Value.Name]; ok {
+					continue
+				}
+			}
+			kept = append(kept, ref)
+		}
+		return kept
+	}
+
+	paths := openapi3.NewPaths()
+	original := make(map[string]string)
+	for path, item := range doc.Paths.Map() {
+		literal := path
+		for name, value := range p.ConstantPathParams {
+			literal = strings.ReplaceAll(literal, "{"+name+"}", url.PathEscape(value))
+		}
+		if other, ok := original[literal]; ok {
+			return fmt.Errorf("constant path params turn both %s and %s into %s", other, path, literal)
+		}
+		original[literal] = path
+
+		item.Parameters = withoutConstants(item.Parameters)
+		for _, op := range item.Operations() {
+			op.Parameters = withoutConstants(op.Parameters)
+		}
+		paths.Set(literal, item)
+	}
+	doc.Paths = paths
+	return nil
+}
+
+// statusFieldExtension reads the x-k8s-status-field extension: either an object of
+// status field names to response paths, or a list of response paths whose last segment
+// names the status field. Paths are validated against the response schema by the mapper.
+func statusFieldExtension(extensions map[string]interface{}) map[string]string {
+	fields := map[string]string{}
+	switch raw := extensions["x-k8s-status-field"].(type) {
+	case map[string]interface{}:
+		for name, path := range raw {
+			fields[name] = fmt.Sprint(path)
+		}
+	case []interface{}:
+		for _, path := range raw {
+			p := strings.TrimPrefix(fmt.Sprint(path), ".")
+			fields[p[strings.LastIndex(p, ".")+1:]] = p
+		}
+	case string:
+		p := strings.TrimPrefix(raw, ".")
+		fields[p[strings.LastIndex(p, ".")+1:]] = p
+	}
+	if len(fields) == 0 {
+		return nil
+	}
+	return fields
+}
+
+// pollConditionExtension reads the x-k8s-poll-condition extension: either an expression
+// such as `status == "available"`, or an object with a field, its ready value (or values)
+// and an optional polling interval. A malformed expression yields a condition without
+// values, which the mapper rejects.
+func pollConditionExtension(extensions map[string]interface{}) *PollCondition {
+	switch raw := extensions["x-k8s-poll-condition"].(type) {
+	case string:
+		field, value, ok := strings.Cut(raw, "==")
+		if !ok {
+			return &PollCondition{Field: strings.TrimSpace(raw)}
+		}
+		value = strings.TrimSpace(value)
+		if unquoted, err := strconv.Unquote(value); err == nil {
+			value = unquoted
+		}
+		return &PollCondition{Field: strings.TrimSpace(field), Values: []string{value}}
+	case map[string]interface{}:
+		condition := &PollCondition{}
+		condition.Field, _ = raw["field"].(string)
+		if value, ok := raw["value"]; ok {
+			condition.Values = append(condition.Values, fmt.Sprint(value))
+		}
+		if values, ok := raw["values"].([]interface{}); ok {
+			for _, value := range values {
+				condition.Values = append(condition.Values, fmt.Sprint(value))
+			}
+		}
+		condition.Interval, _ = raw["interval"].(string)
+		return condition
+	}
+	return nil
+}
+
+// specServers converts the spec's servers list, substituting each {variable} in a
+// server URL with the variable's default
+func specServers(servers openapi3.Servers) []Server {
+	var result []Server
+	for _, srv := range servers {
+		if srv == nil || srv.URL == "" {
+			continue
+		}
+		serverURL := srv.URL
+		for name, v := range srv.Variables {
+			if v != nil {
+				serverURL = strings.ReplaceAll(serverURL, "{"+name+"}", v.Default)
+			}
+		}
+		name, _ := srv.Extensions["x-name"].(string)
+		environment, _ := srv.Extensions["x-environment"].(string)
+		result = append(result, Server{Name: name, Environment: environment, URL: serverURL, Description: srv.Description})
+	}
+	return result
+}
+
+// extractWebhooks reads the OpenAPI 3.1 webhooks object, which kin-openapi keeps as a raw
+// extension of the document. Each entry is a path item whose operations describe the
+// requests the REST API sends; the operation and tag filters apply to them.
+func (p *Parser) extractWebhooks(doc *openapi3.T) ([]Webhook, error) {
+	raw, ok := doc.Extensions["webhooks"]
+	if !ok {
+		return nil, nil
+	}
+	data, err := json.Marshal(raw)
+	if err != nil {
+		return nil, fmt.Errorf("invalid webhooks: %w", err)
+	}
+	var items map[string]*openapi3.PathItem
+	if err := json.Unmarshal(data, &items); err != nil {
+		return nil, fmt.Errorf("invalid webhooks: %w", err)
+	}
+
+	names := make([]string, 0, len(items))
+	for name := range items {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var webhooks []Webhook
+	for _, name := range names {
+		item := items[name]
+		if item == nil {
+			continue
+		}
+		methods := make([]string, 0, len(item.Operations()))
+		for method := range item.Operations() {
+			methods = append(methods, method)
+		}
+		sort.Strings(methods)
+		for _, method := range methods {
+			op := item.Operations()[method]
+			if p.Filter != nil && (!p.Filter.ShouldIncludeTags(op.Tags) || !p.Filter.ShouldIncludeOperation(op.OperationID)) {
+				continue
+			}
+			webhook := Webhook{Name: name, Method: method, OperationID: op.OperationID, Summary: op.Summary}
+			webhook.Kind, _ = op.Extensions["x-k8s-kind"].(string)
+			if body := p.webhookRequestBody(doc, op.RequestBody); body != nil {
+				content, mediaType := requestBodyContent(body)
+				webhook.ContentType = mediaType
+				if content != nil && content.Schema != nil && isJSONMediaType(mediaType) {
+					webhook.PayloadSchemaRef = p.extractRefName(content.Schema.Ref)
+				}
+			}
+			webhooks = append(webhooks, webhook)
+		}
+	}
+	return webhooks, nil
+}
+
+// webhookRequestBody resolves a webhook operation's request body. The webhooks object
+// isn't loaded by kin-openapi, so a $ref to components.requestBodies is looked up here.
+func (p *Parser) webhookRequestBody(doc *openapi3.T, ref *openapi3.RequestBodyRef) *openapi3.RequestBody {
+	if ref == nil {
+		return nil
+	}
+	if ref.Value != nil {
+		return ref.Value
+	}
+	if doc.Components == nil {
+		return nil
+	}
+	if body := doc.Components.RequestBodies[p.extractRefName(ref.Ref)]; body != nil {
+		return body.Value
+	}
+	return nil
+}
+
+// responseHeaderNames returns the sorted header names declared on a response
+func responseHeaderNames(resp *openapi3.Response) []string {
+	if len(resp.Headers) == 0 {
+		return nil
+	}
+	names := make([]string, 0, len(resp.Headers))
+	for name := range resp.Headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
 func (p *Parser) parseStatusCode(code string) int {
 	switch code {
 	case "200":
@@ -1366,27 +2411,88 @@ func (p *Parser) parseStatusCode(code string) int {
 	}
 }
 
-func (p *Parser) extractResourceSchema(pathItem *openapi3.PathItem, doc *openapi3.T) *Schema {
+// extractResourceSchema returns the resource schema from the POST or PUT request body,
+// and whether that request body is explicitly optional
+func (p *Parser) extractResourceSchema(pathItem *openapi3.PathItem, doc *openapi3.T) (*Schema, bool) {
 	// Try POST first, then PUT
 	for _, op := range []*openapi3.Operation{pathItem.Post, pathItem.Put} {
 		if op == nil || op.RequestBody == nil || op.RequestBody.Value == nil {
 			continue
 		}
-		if content, ok := op.RequestBody.Value.Content["application/json"]; ok {
+		if content, _ := requestBodyContent(op.RequestBody.Value); content != nil {
 			if content.Schema != nil {
-				if content.Schema.Ref != "" {
+				if content.Schema.Ref != "" && doc.Components != nil {
 					// Resolve reference
 					refName := p.extractRefName(content.Schema.Ref)
 					if schema, ok := doc.Components.Schemas[refName]; ok {
-						return p.convertSchema(refName, schema.Value)
+						return p.convertSchema(refName, schema.Value), p.optionalBodies[op]
 					}
 				}
 				if content.Schema.Value != nil {
-					return p.convertSchema("Resource", content.Schema.Value)
+					return p.convertSchema("Resource", content.Schema.Value), p.optionalBodies[op]
 				}
 			}
 		}
 	}
+	return nil, false
+}
+
+// formMediaType is the media type of form-encoded request bodies
+const formMediaType = "application/x-www-form-urlencoded"
+
+// requestBodyContent returns the content of a request body and its media type. It prefers
+// application/json, then other JSON media types (application/merge-patch+json, vendor
+// types like application/vnd.example+json, or application/json with parameters) in
+// name order, then application/x-www-form-urlencoded.
+func requestBodyContent(body *openapi3.RequestBody) (content *openapi3.MediaType, mediaType string) {
+	if content, ok := body.Content["application/json"]; ok {
+		return content, "application/json"
+	}
+	mediaTypes := make([]string, 0, len(body.Content))
+	for mediaType := range body.Content {
+		mediaTypes = append(mediaTypes, mediaType)
+	}
+	sort.Strings(mediaTypes)
+	for _, mediaType := range mediaTypes {
+		if isJSONMediaType(mediaType) {
+			return body.Content[mediaType], mediaType
+		}
+	}
+	if content, ok := body.Content[formMediaType]; ok {
+		return content, formMediaType
+	}
+	return nil, ""
+}
+
+// isJSONMediaType reports whether a media type carries JSON: application/json or a
+// +json structured syntax suffix, with or without parameters
+func isJSONMediaType(mediaType string) bool {
+	base, _, err := mime.ParseMediaType(mediaType)
+	if err != nil {
+		return false
+	}
+	return base == "application/json" || strings.HasSuffix(base, "+json")
+}
+
+// requestBodyExample returns the example of a request body's media type: its example,
+// else its first named example (by name), else the example of its schema
+func requestBodyExample(content *openapi3.MediaType) interface{} {
+	if content.Example != nil {
+		return content.Example
+	}
+	names := make([]string, 0, len(content.Examples))
+	for name := range content.Examples {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if example := content.Examples[name]; example != nil && example.Value != nil && example.Value.Value != nil {
+			return example.Value.Value
+		}
+	}
+	if content.Schema != nil && content.Schema.Value != nil {
+		return content.Schema.Value.Example
+	}
 	return nil
 }
 
@@ -1403,6 +2509,54 @@ func (p *Parser) extractRefName(ref string) string {
 	return ""
 }
 
+// newParameter converts an OpenAPI parameter. Array parameters get the type "array:<itemType>"
+// (e.g., "array:string"), and the style/explode defaults for the parameter location are applied.
+func newParameter(p *openapi3.Parameter) Parameter {
+	param := Parameter{
+		Name:            p.Name,
+		In:              p.In,
+		Required:        p.Required,
+		Description:     p.Description,
+		Deprecated:      p.Deprecated,
+		AllowEmptyValue: p.AllowEmptyValue,
+	}
+	if p.Schema != nil && p.Schema.Value != nil {
+		schemaVal := p.Schema.Value
+		param.Type, _ = schemaType(schemaVal)
+		param.Format = schemaVal.Format
+		if param.Type == "array" && schemaVal.Items != nil && schemaVal.Items.Value != nil {
+			if itemType, _ := schemaType(schemaVal.Items.Value); itemType != "" {
+				param.Type = "array:" + itemType
+				param.Format = schemaVal.Items.Value.Format
+			}
+		}
+	}
+	if sm, err := p.SerializationMethod(); err == nil {
+		param.Style = sm.Style
+		param.Explode = sm.Explode
+	}
+	return param
+}
+
+// schemaType returns the schema's type and whether it is an OpenAPI 3.1 nullable union.
+// In 3.1, type may be an array such as ["string", "null"]; "null" is skipped and reported
+// as nullable so the union maps to the same type as `type: string, nullable: true`.
+func schemaType(schema *openapi3.Schema) (string, bool) {
+	if schema == nil || schema.Type == nil {
+		return "", false
+	}
+	typ := ""
+	nullable := false
+	for _, t := range schema.Type.Slice() {
+		if t == openapi3.TypeNull {
+			nullable = true
+		} else if typ == "" {
+			typ = t
+		}
+	}
+	return typ, nullable
+}
+
 func (p *Parser) convertSchema(name string, schema *openapi3.Schema) *Schema {
 	if schema == nil {
 		return nil
@@ -1410,6 +2564,7 @@ func (p *Parser) convertSchema(name string, schema *openapi3.Schema) *Schema {
 
 	s := &Schema{
 		Name:        name,
+		Title:       schema.Title,
 		Description: schema.Description,
 		Required:    schema.Required,
 		Properties:  make(map[string]*Schema),
@@ -1417,15 +2572,19 @@ func (p *Parser) convertSchema(name string, schema *openapi3.Schema) *Schema {
 		Pattern:     schema.Pattern,
 	}
 
-	// Handle type - it can be a slice in OpenAPI 3.1
-	if len(schema.Type.Slice()) > 0 {
-		s.Type = schema.Type.Slice()[0]
+	// Handle type - in OpenAPI 3.1 it can be an array, with "null" marking the field nullable
+	var nullUnion bool
+	s.Type, nullUnion = schemaType(schema)
+	if nullUnion {
+		s.Nullable = true
 	}
 	s.Format = schema.Format
 
+	s.PatternProperties = p.convertPatternProperties(schema)
+
 	// Infer type from structure if not explicitly set
 	if s.Type == "" {
-		if len(schema.Properties) > 0 {
+		if len(schema.Properties) > 0 || len(s.PatternProperties) > 0 {
 			s.Type = "object"
 		} else if schema.Items != nil {
 			s.Type = "array"
@@ -1443,9 +2602,11 @@ func (p *Parser) convertSchema(name string, schema *openapi3.Schema) *Schema {
 	}
 	if schema.Min != nil {
 		s.Minimum = schema.Min
+		s.ExclusiveMinimum = schema.ExclusiveMin
 	}
 	if schema.Max != nil {
 		s.Maximum = schema.Max
+		s.ExclusiveMaximum = schema.ExclusiveMax
 	}
 	if schema.MinItems != 0 {
 		v := int64(schema.MinItems)
@@ -1456,6 +2617,16 @@ func (p *Parser) convertSchema(name string, schema *openapi3.Schema) *Schema {
 		s.MaxItems = &v
 	}
 
+	// Extract x-k8s-immutable extension if present
+	if immutable, ok := schema.Extensions["x-k8s-immutable"].(bool); ok {
+		s.Immutable = immutable
+	}
+	s.SpecField, _ = schema.Extensions["x-k8s-spec-field"].(string)
+	s.Plural = pluralExtension(schema.Extensions)
+	s.StatusFields = statusFieldExtension(schema.Extensions)
+	s.PollCondition = pollConditionExtension(schema.Extensions)
+	s.WriteOnly = schema.WriteOnly
+
 	// Handle enum
 	s.Enum = schema.Enum
 	s.Default = schema.Default
@@ -1475,9 +2646,152 @@ func (p *Parser) convertSchema(name string, schema *openapi3.Schema) *Schema {
 		s.Items = p.convertSchema("Items", schema.Items.Value)
 	}
 
+	if len(schema.AnyOf) > 0 && schema.Discriminator == nil {
+		p.mergeAnyOf(s, schema.AnyOf)
+	}
+
+	if schema.Not != nil && schema.Not.Value != nil {
+		if simpleNot(s.Type, schema.Not.Value) {
+			s.Not = p.convertSchema(name, schema.Not.Value)
+			s.Not.Type = s.Type
+		} else if !p.skippedNots[schema.Not.Value] {
+			if p.skippedNots == nil {
+				p.skippedNots = make(map[*openapi3.Schema]bool)
+			}
+			p.skippedNots[schema.Not.Value] = true
+			p.logf("Warning: skipping the not schema of %s: only a pattern, string enum, length or range on a scalar can be enforced\n", name)
+		}
+	}
+
 	return s
 }
 
+// simpleNot reports whether a not subschema of a typ schema only holds constraints the
+// mapper can negate in a CEL rule: a pattern, enum or length on a string, or a range on
+// a number
+func simpleNot(typ string, not *openapi3.Schema) bool {
+	if notType, _ := schemaType(not); notType != "" && notType != typ {
+		return false
+	}
+	if len(not.Properties) > 0 || not.Items != nil || len(not.Required) > 0 || not.Not != nil ||
+		len(not.AllOf) > 0 || len(not.AnyOf) > 0 || len(not.OneOf) > 0 || not.Format != "" {
+		return false
+	}
+	switch typ {
+	case "string":
+		if not.Min != nil || not.Max != nil {
+			return false
+		}
+		return not.Pattern != "" || len(not.Enum) > 0 || not.MinLength != 0 || not.MaxLength != nil
+	case "integer", "number":
+		if not.Pattern != "" || len(not.Enum) > 0 || not.MinLength != 0 || not.MaxLength != nil {
+			return false
+		}
+		return not.Min != nil || not.Max != nil
+	}
+	return false
+}
+
+// mergeAnyOf folds the members of a loose anyOf into s. Object members are unioned into
+// one set of optional properties, with their required sets kept in AnyOfRequired for a
+// CEL rule. Primitive members of a single type give s that type. Anything else (mixed or
+// incompatible types) leaves s untyped, so it maps to a RawExtension.
+func (p *Parser) mergeAnyOf(s *Schema, members openapi3.SchemaRefs) {
+	var objects, primitives []*Schema
+	for i, ref := range members {
+		if ref == nil || ref.Value == nil {
+			continue
+		}
+		member := p.convertSchema(fmt.Sprintf("%s%d", s.Name, i), ref.Value)
+		if member.Type == "object" {
+			objects = append(objects, member)
+		} else {
+			primitives = append(primitives, member)
+		}
+	}
+
+	switch {
+	case len(objects) > 0 && len(primitives) == 0:
+		if s.Type != "" && s.Type != "object" {
+			return
+		}
+		s.Type = "object"
+		anyOfRequired := make([][]string, 0, len(objects))
+		for _, member := range objects {
+			for name, prop := range member.Properties {
+				if _, exists := s.Properties[name]; !exists {
+					s.Properties[name] = prop
+				}
+			}
+			anyOfRequired = append(anyOfRequired, member.Required)
+		}
+		for _, required := range anyOfRequired {
+			if len(required) == 0 {
+				// A member without required properties matches any object
+				return
+			}
+		}
+		s.AnyOfRequired = anyOfRequired
+	case len(primitives) > 0 && len(objects) == 0 && s.Type == "":
+		for _, member := range primitives[1:] {
+			if member.Type != primitives[0].Type {
+				return
+			}
+		}
+		if primitives[0].Type != "array" {
+			s.Type = primitives[0].Type
+		}
+	}
+}
+
+// convertPatternProperties converts the patternProperties of a schema. OpenAPI 3.0 has no
+// patternProperties keyword, so kin-openapi keeps it as raw extension data; each value is
+// decoded as a schema, resolving a local $ref against the component schemas.
+func (p *Parser) convertPatternProperties(schema *openapi3.Schema) map[string]*Schema {
+	raw, ok := schema.Extensions["patternProperties"].(map[string]interface{})
+	if !ok || len(raw) == 0 {
+		return nil
+	}
+
+	result := make(map[string]*Schema, len(raw))
+	for pattern, rawValue := range raw {
+		data, err := json.Marshal(rawValue)
+		if err != nil {
+			continue
+		}
+		var value openapi3.Schema
+		if err := json.Unmarshal(data, &value); err != nil {
+			continue
+		}
+
+		var ref struct {
+			Ref string `json:"$ref"`
+		}
+		_ = json.Unmarshal(data, &ref)
+		if ref.Ref == "" {
+			result[pattern] = p.convertSchema(pattern, &value)
+			continue
+		}
+
+		name := strings.TrimPrefix(ref.Ref, "#/components/schemas/")
+		target, ok := p.componentSchemas[name]
+		if !ok || target.Value == nil || p.resolvingRefs[name] {
+			continue
+		}
+		if p.resolvingRefs == nil {
+			p.resolvingRefs = make(map[string]bool)
+		}
+		p.resolvingRefs[name] = true
+		result[pattern] = p.convertSchema(name, target.Value)
+		delete(p.resolvingRefs, name)
+	}
+
+	if len(result) == 0 {
+		return nil
+	}
+	return result
+}
+
 func (p *Parser) toPascalCase(s string) string {
 	// Simple conversion - split by common separators and capitalize
 	s = strings.ReplaceAll(s, "-", " ")
@@ -1579,7 +2893,7 @@ func wrapText(s string, maxLen int) []string {
 
 // printWrappedTableRow prints a table row with text wrapping for cells that exceed column width
 // Column widths: Endpoint=34, Method=12, Classification=18, Kind=19, ParentID=19
-func printWrappedTableRow(endpoint, method, classification, kind, parentID string) {
+func (p *Parser) printWrappedTableRow(endpoint, method, classification, kind, parentID string) {
 	// Wrap each cell
 	endpointLines := wrapText(endpoint, 34)
 	methodLines := wrapText(method, 12)
@@ -1620,12 +2934,12 @@ func printWrappedTableRow(endpoint, method, classification, kind, parentID strin
 		if i < len(kindLines) {
 			k = kindLines[i]
 		}
-		p := ""
+		pid := ""
 		if i < len(parentIDLines) {
-			p = parentIDLines[i]
+			pid = parentIDLines[i]
 		}
 
-		fmt.Printf("│ %-34s │ %-12s │ %-18s │ %-19s │ %-19s │\n", e, m, c, k, p)
+		p.logf("│ %-34s │ %-12s │ %-18s │ %-19s │ %-19s │\n", e, m, c, k, pid)
 	}
 }
 
@@ -1,8 +1,14 @@
 package parser
 
 import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"path/filepath"
+	"reflect"
+	"strings"
 	"testing"
 )
 
@@ -652,6 +658,407 @@ components:
 	}
 }
 
+func TestParse_ExclusiveBounds(t *testing.T) {
+	tests := []struct {
+		name       string
+		version    string
+		properties string
+	}{
+		{
+			name:    "OpenAPI 3.0 boolean form",
+			version: "3.0.0",
+			properties: `
+        inclusive:
+          type: number
+          minimum: 0
+          maximum: 100
+        exclusive:
+          type: number
+          minimum: 0
+          exclusiveMinimum: true
+          maximum: 100
+          exclusiveMaximum: true`,
+		},
+		{
+			name:    "OpenAPI 3.1 numeric form",
+			version: "3.1.0",
+			properties: `
+        inclusive:
+          type: number
+          minimum: 0
+          maximum: 100
+        exclusive:
+          type: number
+          exclusiveMinimum: 0
+          exclusiveMaximum: 100`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			specContent := `
+openapi: "` + tt.version + `"
+info:
+  title: "Bounds API"
+  version: "1.0.0"
+paths:
+  /items:
+    get:
+      operationId: listItems
+      responses:
+        "200":
+          description: OK
+components:
+  schemas:
+    Item:
+      type: object
+      properties:` + tt.properties + "\n"
+
+			tmpDir := t.TempDir()
+			specPath := filepath.Join(tmpDir, "openapi.yaml")
+			if err := os.WriteFile(specPath, []byte(specContent), 0644); err != nil {
+				t.Fatalf("failed to write spec file: %v", err)
+			}
+
+			spec, err := NewParser().Parse(specPath)
+			if err != nil {
+				t.Fatalf("Parse failed: %v", err)
+			}
+
+			itemSchema, ok := spec.Schemas["Item"]
+			if !ok {
+				t.Fatal("Item schema not found")
+			}
+
+			inclusive := itemSchema.Properties["inclusive"]
+			if inclusive == nil {
+				t.Fatal("inclusive property not found")
+			}
+			if inclusive.Minimum == nil || *inclusive.Minimum != 0 {
+				t.Error("expected inclusive Minimum 0")
+			}
+			if inclusive.Maximum == nil || *inclusive.Maximum != 100 {
+				t.Error("expected inclusive Maximum 100")
+			}
+			if inclusive.ExclusiveMinimum || inclusive.ExclusiveMaximum {
+				t.Error("expected inclusive bounds not to be exclusive")
+			}
+
+			exclusive := itemSchema.Properties["exclusive"]
+			if exclusive == nil {
+				t.Fatal("exclusive property not found")
+			}
+			if exclusive.Minimum == nil || *exclusive.Minimum != 0 {
+				t.Error("expected exclusive Minimum 0")
+			}
+			if exclusive.Maximum == nil || *exclusive.Maximum != 100 {
+				t.Error("expected exclusive Maximum 100")
+			}
+			if !exclusive.ExclusiveMinimum {
+				t.Error("expected ExclusiveMinimum to be true")
+			}
+			if !exclusive.ExclusiveMaximum {
+				t.Error("expected ExclusiveMaximum to be true")
+			}
+		})
+	}
+}
+
+func TestParse_ExclusiveBoundsWithInclusive(t *testing.T) {
+	specContent := `
+openapi: "3.1.0"
+info:
+  title: "Bounds API"
+  version: "1.0.0"
+paths:
+  /items:
+    get:
+      operationId: listItems
+      responses:
+        "200":
+          description: OK
+components:
+  schemas:
+    Item:
+      type: object
+      properties:
+        inclusiveTighter:
+          type: number
+          minimum: 10
+          exclusiveMinimum: 0
+          maximum: 50
+          exclusiveMaximum: 100
+        exclusiveTighter:
+          type: number
+          minimum: 0
+          exclusiveMinimum: 10
+          maximum: 100
+          exclusiveMaximum: 50
+        equal:
+          type: number
+          minimum: 5
+          exclusiveMinimum: 5
+          maximum: 20
+          exclusiveMaximum: 20
+`
+	tmpDir := t.TempDir()
+	specPath := filepath.Join(tmpDir, "openapi.yaml")
+	if err := os.WriteFile(specPath, []byte(specContent), 0644); err != nil {
+		t.Fatalf("failed to write spec file: %v", err)
+	}
+
+	spec, err := NewParser().Parse(specPath)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	itemSchema, ok := spec.Schemas["Item"]
+	if !ok {
+		t.Fatal("Item schema not found")
+	}
+
+	tests := []struct {
+		property     string
+		minimum      float64
+		exclusiveMin bool
+		maximum      float64
+		exclusiveMax bool
+	}{
+		{property: "inclusiveTighter", minimum: 10, maximum: 50},
+		{property: "exclusiveTighter", minimum: 10, exclusiveMin: true, maximum: 50, exclusiveMax: true},
+		{property: "equal", minimum: 5, exclusiveMin: true, maximum: 20, exclusiveMax: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.property, func(t *testing.T) {
+			schema := itemSchema.Properties[tt.property]
+			if schema == nil {
+				t.Fatalf("%s property not found", tt.property)
+			}
+			if schema.Minimum == nil || *schema.Minimum != tt.minimum || schema.ExclusiveMinimum != tt.exclusiveMin {
+				t.Errorf("expected minimum %v (exclusive %v), got %v (exclusive %v)", tt.minimum, tt.exclusiveMin, schema.Minimum, schema.ExclusiveMinimum)
+			}
+			if schema.Maximum == nil || *schema.Maximum != tt.maximum || schema.ExclusiveMaximum != tt.exclusiveMax {
+				t.Errorf("expected maximum %v (exclusive %v), got %v (exclusive %v)", tt.maximum, tt.exclusiveMax, schema.Maximum, schema.ExclusiveMaximum)
+			}
+		})
+	}
+}
+
+func TestParse_RereadsEditedSpec(t *testing.T) {
+	specPath := filepath.Join(t.TempDir(), "openapi.yaml")
+	write := func(title string) {
+		t.Helper()
+		content := "openapi: \"3.0.0\"\ninfo:\n  title: " + title + "\n  version: \"1.0.0\"\npaths: {}\n"
+		if err := os.WriteFile(specPath, []byte(content), 0644); err != nil {
+			t.Fatalf("failed to write spec file: %v", err)
+		}
+	}
+
+	// A long-running process (the MCP server) parses the same path again after an edit
+	for _, title := range []string{"First", "Second"} {
+		write(title)
+		doc, err := NewParser().Load(specPath)
+		if err != nil {
+			t.Fatalf("Load failed: %v", err)
+		}
+		if doc.Doc.Info.Title != title {
+			t.Errorf("expected title %q, got %q", title, doc.Doc.Info.Title)
+		}
+	}
+}
+
+func TestParse_OptionalRequestBody(t *testing.T) {
+	specContent := `
+openapi: "3.0.0"
+info:
+  title: "Optional Body API"
+  version: "1.0.0"
+paths:
+  /widgets:
+    get:
+      operationId: listWidgets
+      responses:
+        "200":
+          description: OK
+    post:
+      operationId: createWidget
+      requestBody:
+        required: false
+        content:
+          application/json:
+            schema:
+              $ref: '#/components/schemas/Widget'
+      responses:
+        "201":
+          description: Created
+  /gadgets:
+    get:
+      operationId: listGadgets
+      responses:
+        "200":
+          description: OK
+    post:
+      operationId: createGadget
+      requestBody:
+        content:
+          application/json:
+            schema:
+              $ref: '#/components/schemas/Widget'
+      responses:
+        "201":
+          description: Created
+  /gizmos:
+    get:
+      operationId: listGizmos
+      responses:
+        "200":
+          description: OK
+    post:
+      operationId: createGizmo
+      requestBody:
+        $ref: '#/components/requestBodies/OptionalWidget'
+      responses:
+        "201":
+          description: Created
+components:
+  requestBodies:
+    OptionalWidget:
+      required: false
+      content:
+        application/json:
+          schema:
+            $ref: '#/components/schemas/Widget'
+  schemas:
+    Widget:
+      type: object
+      required:
+        - name
+      properties:
+        name:
+          type: string
+`
+
+	tmpDir := t.TempDir()
+	specPath := filepath.Join(tmpDir, "openapi.yaml")
+	if err := os.WriteFile(specPath, []byte(specContent), 0644); err != nil {
+		t.Fatalf("failed to write spec file: %v", err)
+	}
+
+	spec, err := NewParser().Parse(specPath)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	expected := map[string]bool{
+		"Widget": true,  // explicit required: false
+		"Gadget": false, // required omitted
+		"Gizmo":  true,  // required: false via components.requestBodies
+	}
+	found := 0
+	for _, res := range spec.Resources {
+		want, ok := expected[res.Name]
+		if !ok {
+			continue
+		}
+		found++
+		if res.SchemaOptional != want {
+			t.Errorf("%s: expected SchemaOptional %v, got %v", res.Name, want, res.SchemaOptional)
+		}
+		for _, op := range res.Operations {
+			if op.Method == "POST" && op.RequestBodyOptional != want {
+				t.Errorf("%s: expected POST RequestBodyOptional %v, got %v", res.Name, want, op.RequestBodyOptional)
+			}
+		}
+	}
+	if found != len(expected) {
+		t.Errorf("expected %d resources, found %d", len(expected), found)
+	}
+}
+
+func TestParse_ResponseHeaders(t *testing.T) {
+	specContent := `
+openapi: "3.0.0"
+info:
+  title: "Headers API"
+  version: "1.0.0"
+paths:
+  /widgets/{id}:
+    parameters:
+      - name: id
+        in: path
+        required: true
+        schema:
+          type: string
+    get:
+      operationId: getWidget
+      responses:
+        "200":
+          description: OK
+          headers:
+            ETag:
+              schema:
+                type: string
+            X-Request-Id:
+              schema:
+                type: string
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/Widget'
+    put:
+      operationId: updateWidget
+      requestBody:
+        content:
+          application/json:
+            schema:
+              $ref: '#/components/schemas/Widget'
+      responses:
+        "200":
+          description: OK
+components:
+  schemas:
+    Widget:
+      type: object
+      properties:
+        name:
+          type: string
+`
+
+	tmpDir := t.TempDir()
+	specPath := filepath.Join(tmpDir, "openapi.yaml")
+	if err := os.WriteFile(specPath, []byte(specContent), 0644); err != nil {
+		t.Fatalf("failed to write spec file: %v", err)
+	}
+
+	spec, err := NewParser().Parse(specPath)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	var checked int
+	for _, res := range spec.Resources {
+		for _, op := range res.Operations {
+			switch op.Method {
+			case "GET":
+				checked++
+				if len(op.ResponseHeaders) != 2 || op.ResponseHeaders[0] != "ETag" || op.ResponseHeaders[1] != "X-Request-Id" {
+					t.Errorf("expected GET headers [ETag X-Request-Id], got %v", op.ResponseHeaders)
+				}
+				if !op.HasResponseHeader("etag") {
+					t.Error("expected HasResponseHeader to match case-insensitively")
+				}
+			case "PUT":
+				checked++
+				if op.HasResponseHeader("ETag") {
+					t.Errorf("expected no PUT headers, got %v", op.ResponseHeaders)
+				}
+			}
+		}
+	}
+	if checked != 2 {
+		t.Errorf("expected GET and PUT operations, checked %d", checked)
+	}
+}
+
 func TestParse_NestedObjects(t *testing.T) {
 	specContent := `
 openapi: "3.0.0"
@@ -673,6 +1080,7 @@ components:
           type: string
         address:
           type: object
+          title: PostalAddress
           properties:
             street:
               type: string
@@ -711,6 +1119,9 @@ components:
 	if addressSchema.Type != "object" {
 		t.Errorf("expected address type 'object', got %q", addressSchema.Type)
 	}
+	if addressSchema.Title != "PostalAddress" {
+		t.Errorf("expected address title 'PostalAddress', got %q", addressSchema.Title)
+	}
 
 	locationSchema := addressSchema.Properties["location"]
 	if locationSchema == nil {
@@ -1226,32 +1637,39 @@ components:
 	}
 }
 
-func TestParse_DefaultValues(t *testing.T) {
+func TestParse_NullableTypeArray(t *testing.T) {
 	specContent := `
-openapi: "3.0.0"
+openapi: "3.1.0"
 info:
-  title: "Default Values API"
+  title: "Nullable 3.1 API"
   version: "1.0.0"
 paths:
-  /test:
+  /items:
     get:
+      parameters:
+        - name: cursor
+          in: query
+          schema:
+            type: ["null", "string"]
       responses:
         "200":
           description: Success
 components:
   schemas:
-    DefaultsTest:
+    NullableTest:
       type: object
       properties:
-        count:
-          type: integer
-          default: 10
-        enabled:
-          type: boolean
-          default: true
-        name:
+        normalField:
           type: string
-          default: "default_name"
+        nullableField:
+          type: ["string", "null"]
+        nullableCount:
+          type: ["null", "integer"]
+          format: int64
+        tags:
+          type: ["array", "null"]
+          items:
+            type: string
 `
 
 	tmpDir := t.TempDir()
@@ -1261,177 +1679,2019 @@ components:
 	}
 
 	p := NewParser()
+	p.LogWriter = io.Discard
 	spec, err := p.Parse(specPath)
 	if err != nil {
 		t.Fatalf("Parse failed: %v", err)
 	}
 
-	schema := spec.Schemas["DefaultsTest"]
+	schema := spec.Schemas["NullableTest"]
 	if schema == nil {
-		t.Fatal("DefaultsTest schema not found")
+		t.Fatal("NullableTest schema not found")
 	}
 
-	countField := schema.Properties["count"]
-	if countField == nil {
-		t.Fatal("count field not found")
-	}
-	if countField.Default == nil {
-		t.Error("expected count to have default value")
+	tests := []struct {
+		field    string
+		typ      string
+		nullable bool
+	}{
+		{"normalField", "string", false},
+		{"nullableField", "string", true},
+		{"nullableCount", "integer", true},
+		{"tags", "array", true},
 	}
+	for _, tt := range tests {
+		f := schema.Properties[tt.field]
+		if f == nil {
+			t.Errorf("%s not found", tt.field)
+			continue
+		}
+		if f.Type != tt.typ || f.Nullable != tt.nullable {
+			t.Errorf("%s: got type %q nullable %v, expected %q nullable %v", tt.field, f.Type, f.Nullable, tt.typ, tt.nullable)
+		}
+	}
+
+	if len(spec.QueryEndpoints) != 1 || len(spec.QueryEndpoints[0].QueryParams) != 1 {
+		t.Fatalf("expected 1 query endpoint with 1 query param, got %+v", spec.QueryEndpoints)
+	}
+	if got := spec.QueryEndpoints[0].QueryParams[0].Type; got != "string" {
+		t.Errorf("expected cursor param type 'string', got %q", got)
+	}
+}
+
+func TestParse_ArrayPathParam(t *testing.T) {
+	specContent := `
+openapi: "3.0.3"
+info:
+  title: "Array Path API"
+  version: "1.0.0"
+paths:
+  /items/{ids}:
+    get:
+      parameters:
+        - name: ids
+          in: path
+          required: true
+          style: simple
+          explode: false
+          schema:
+            type: array
+            items:
+              type: integer
+              format: int32
+      responses:
+        "200":
+          description: Success
+  /labels/{names}:
+    get:
+      parameters:
+        - name: names
+          in: path
+          required: true
+          style: matrix
+          explode: true
+          schema:
+            type: array
+            items:
+              type: string
+      responses:
+        "200":
+          description: Success
+  /owners/{ownerId}:
+    get:
+      parameters:
+        - name: ownerId
+          in: path
+          required: true
+          style: label
+          schema:
+            type: string
+      responses:
+        "200":
+          description: Success
+`
+
+	tmpDir := t.TempDir()
+	specPath := filepath.Join(tmpDir, "openapi.yaml")
+	if err := os.WriteFile(specPath, []byte(specContent), 0644); err != nil {
+		t.Fatalf("failed to write spec file: %v", err)
+	}
+
+	p := NewParser()
+	p.LogWriter = io.Discard
+	spec, err := p.Parse(specPath)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	expected := map[string]Parameter{
+		"ids":     {Type: "array:integer", Format: "int32", Style: "simple", Explode: false},
+		"names":   {Type: "array:string", Style: "matrix", Explode: true},
+		"ownerId": {Type: "string", Style: "label", Explode: false},
+	}
+	found := 0
+	for _, qe := range spec.QueryEndpoints {
+		for _, param := range qe.PathParams {
+			want, ok := expected[param.Name]
+			if !ok {
+				continue
+			}
+			found++
+			if param.Type != want.Type || param.Format != want.Format || param.Style != want.Style || param.Explode != want.Explode {
+				t.Errorf("%s: got type %q format %q style %q explode %v, expected %q %q %q %v",
+					param.Name, param.Type, param.Format, param.Style, param.Explode, want.Type, want.Format, want.Style, want.Explode)
+			}
+		}
+	}
+	if found != len(expected) {
+		t.Errorf("expected %d styled path params, found %d", len(expected), found)
+	}
+}
+
+func TestParse_ParameterDeprecatedAndAllowEmptyValue(t *testing.T) {
+	specContent := `
+openapi: "3.0.3"
+info:
+  title: "Flags API"
+  version: "1.0.0"
+paths:
+  /reports:
+    get:
+      parameters:
+        - name: verbose
+          in: query
+          allowEmptyValue: true
+          schema:
+            type: string
+        - name: legacyFormat
+          in: query
+          deprecated: true
+          schema:
+            type: string
+      responses:
+        "200":
+          description: Success
+`
+
+	tmpDir := t.TempDir()
+	specPath := filepath.Join(tmpDir, "openapi.yaml")
+	if err := os.WriteFile(specPath, []byte(specContent), 0644); err != nil {
+		t.Fatalf("failed to write spec file: %v", err)
+	}
+
+	p := NewParser()
+	p.LogWriter = io.Discard
+	spec, err := p.Parse(specPath)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	if len(spec.QueryEndpoints) != 1 || len(spec.QueryEndpoints[0].QueryParams) != 2 {
+		t.Fatalf("expected 1 query endpoint with 2 query params, got %+v", spec.QueryEndpoints)
+	}
+	for _, param := range spec.QueryEndpoints[0].QueryParams {
+		switch param.Name {
+		case "verbose":
+			if !param.AllowEmptyValue || param.Deprecated {
+				t.Errorf("verbose: expected allowEmptyValue only, got %+v", param)
+			}
+		case "legacyFormat":
+			if !param.Deprecated || param.AllowEmptyValue {
+				t.Errorf("legacyFormat: expected deprecated only, got %+v", param)
+			}
+		}
+	}
+}
+
+func TestParse_ImmutableExtension(t *testing.T) {
+	specContent := `
+openapi: "3.0.0"
+info:
+  title: "Immutable API"
+  version: "1.0.0"
+paths:
+  /test:
+    get:
+      responses:
+        "200":
+          description: Success
+components:
+  schemas:
+    ImmutableTest:
+      type: object
+      properties:
+        region:
+          type: string
+          x-k8s-immutable: true
+        name:
+          type: string
+        size:
+          type: integer
+          x-k8s-immutable: false
+        password:
+          type: string
+          writeOnly: true
+        type:
+          type: string
+          x-k8s-spec-field: petType
+`
+
+	tmpDir := t.TempDir()
+	specPath := filepath.Join(tmpDir, "openapi.yaml")
+	if err := os.WriteFile(specPath, []byte(specContent), 0644); err != nil {
+		t.Fatalf("failed to write spec file: %v", err)
+	}
+
+	spec, err := NewParser().Parse(specPath)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	schema := spec.Schemas["ImmutableTest"]
+	if schema == nil {
+		t.Fatal("ImmutableTest schema not found")
+	}
+	expected := map[string]bool{"region": true, "name": false, "size": false}
+	for name, want := range expected {
+		prop := schema.Properties[name]
+		if prop == nil {
+			t.Fatalf("%s not found", name)
+		}
+		if prop.Immutable != want {
+			t.Errorf("%s: expected Immutable %v, got %v", name, want, prop.Immutable)
+		}
+	}
+	if !schema.Properties["password"].WriteOnly || schema.Properties["name"].WriteOnly {
+		t.Error("expected only password to be marked WriteOnly")
+	}
+	if got := schema.Properties["type"].SpecField; got != "petType" {
+		t.Errorf("expected type to have SpecField petType, got %q", got)
+	}
+}
+
+func TestParse_PatternProperties(t *testing.T) {
+	specContent := `
+openapi: "3.0.0"
+info:
+  title: "Map API"
+  version: "1.0.0"
+paths:
+  /test:
+    get:
+      responses:
+        "200":
+          description: Success
+components:
+  schemas:
+    Limit:
+      type: object
+      properties:
+        max:
+          type: integer
+    Settings:
+      type: object
+      properties:
+        labels:
+          patternProperties:
+            "^[a-z]+$":
+              type: string
+        limits:
+          type: object
+          patternProperties:
+            "^x-":
+              $ref: '#/components/schemas/Limit'
+`
+
+	tmpDir := t.TempDir()
+	specPath := filepath.Join(tmpDir, "openapi.yaml")
+	if err := os.WriteFile(specPath, []byte(specContent), 0644); err != nil {
+		t.Fatalf("failed to write spec file: %v", err)
+	}
+
+	spec, err := NewParser().Parse(specPath)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	settings := spec.Schemas["Settings"]
+	if settings == nil {
+		t.Fatal("Settings schema not found")
+	}
+
+	labels := settings.Properties["labels"]
+	if labels.Type != "object" {
+		t.Errorf("expected labels type to be inferred as object, got %q", labels.Type)
+	}
+	if value := labels.PatternProperties["^[a-z]+$"]; value == nil || value.Type != "string" {
+		t.Errorf("expected string values for ^[a-z]+$, got %+v", labels.PatternProperties)
+	}
+
+	value := settings.Properties["limits"].PatternProperties["^x-"]
+	if value == nil {
+		t.Fatal("expected the ^x- pattern of limits to be captured")
+	}
+	if value.Properties["max"] == nil {
+		t.Error("expected the $ref value schema to be resolved to Limit")
+	}
+}
+
+func TestParse_TargetDefaultExtension(t *testing.T) {
+	specContent := `
+openapi: "3.0.0"
+info:
+  title: "Target API"
+  version: "1.0.0"
+paths:
+  /widgets/{id}:
+    get:
+      x-k8s-target-default:
+        deployment: widget-api
+        namespace: backend
+      parameters:
+        - name: id
+          in: path
+          required: true
+          schema:
+            type: string
+      responses:
+        "200":
+          description: Success
+`
+
+	tmpDir := t.TempDir()
+	specPath := filepath.Join(tmpDir, "openapi.yaml")
+	if err := os.WriteFile(specPath, []byte(specContent), 0644); err != nil {
+		t.Fatalf("failed to write spec file: %v", err)
+	}
+
+	spec, err := NewParser().Parse(specPath)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	// A GET-only path is classified as a query endpoint
+	if len(spec.QueryEndpoints) != 1 {
+		t.Fatalf("expected 1 query endpoint, got %d", len(spec.QueryEndpoints))
+	}
+	target := spec.QueryEndpoints[0].TargetDefault
+	if target["deployment"] != "widget-api" || target["namespace"] != "backend" {
+		t.Errorf("expected x-k8s-target-default on the query endpoint, got %v", target)
+	}
+}
+
+func TestParse_ResponseContentTypes(t *testing.T) {
+	specContent := `
+openapi: "3.0.0"
+info:
+  title: "Media Types API"
+  version: "1.0.0"
+paths:
+  /widgets/{id}:
+    get:
+      parameters:
+        - name: id
+          in: path
+          required: true
+          schema:
+            type: string
+      responses:
+        "200":
+          description: Success
+          content:
+            application/xml: {}
+            application/vnd.widget+json: {}
+        "2XX":
+          description: Other success
+          content:
+            application/json: {}
+        "404":
+          description: Not found
+          content:
+            application/problem+json: {}
+  /reports:
+    get:
+      responses:
+        "200":
+          description: Success
+          content:
+            text/csv: {}
+`
+
+	tmpDir := t.TempDir()
+	specPath := filepath.Join(tmpDir, "openapi.yaml")
+	if err := os.WriteFile(specPath, []byte(specContent), 0644); err != nil {
+		t.Fatalf("failed to write spec file: %v", err)
+	}
+
+	spec, err := NewParser().Parse(specPath)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	types := make(map[string][]string)
+	for _, qe := range spec.QueryEndpoints {
+		types[qe.Path] = qe.ResponseContentTypes
+	}
+	// Only the success responses count, sorted
+	if got, want := types["/widgets/{id}"], []string{"application/json", "application/vnd.widget+json", "application/xml"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("/widgets/{id} response content types = %v, want %v", got, want)
+	}
+	if got := types["/reports"]; !reflect.DeepEqual(got, []string{"text/csv"}) {
+		t.Errorf("/reports response content types = %v, want [text/csv]", got)
+	}
+}
+
+func TestParse_SecurityRequirements(t *testing.T) {
+	specContent := `
+openapi: "3.0.0"
+info:
+  title: "Secured API"
+  version: "1.0.0"
+security:
+  - api_key: []
+components:
+  securitySchemes:
+    api_key:
+      type: apiKey
+      in: header
+      name: X-API-Key
+    bearerAuth:
+      type: http
+      scheme: bearer
+    basicAuth:
+      type: http
+      scheme: basic
+paths:
+  /widgets:
+    get:
+      security:
+        - bearerAuth: []
+          api_key: []
+        - basicAuth: []
+      responses:
+        "200":
+          description: Success
+    post:
+      responses:
+        "201":
+          description: Created
+  /widgets/{id}:
+    get:
+      parameters:
+        - name: id
+          in: path
+          required: true
+          schema:
+            type: string
+      responses:
+        "200":
+          description: Success
+  /health:
+    get:
+      security: []
+      responses:
+        "200":
+          description: Success
+`
+
+	tmpDir := t.TempDir()
+	specPath := filepath.Join(tmpDir, "openapi.yaml")
+	if err := os.WriteFile(specPath, []byte(specContent), 0644); err != nil {
+		t.Fatalf("failed to write spec file: %v", err)
+	}
+
+	spec, err := NewParser().Parse(specPath)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	wantSchemes := map[string]SecurityScheme{
+		"api_key":    {Name: "api_key", Type: "apiKey", In: "header", ParamName: "X-API-Key"},
+		"bearerAuth": {Name: "bearerAuth", Type: "http", Scheme: "bearer"},
+		"basicAuth":  {Name: "basicAuth", Type: "http", Scheme: "basic"},
+	}
+	if !reflect.DeepEqual(spec.SecuritySchemes, wantSchemes) {
+		t.Errorf("SecuritySchemes = %+v, want %+v", spec.SecuritySchemes, wantSchemes)
+	}
+
+	security := make(map[string][]SecurityRequirement)
+	for _, res := range spec.Resources {
+		for _, op := range res.Operations {
+			security[op.Method+" "+op.Path] = op.Security
+		}
+	}
+	for _, qe := range spec.QueryEndpoints {
+		security["GET "+qe.Path] = qe.Security
+	}
+
+	// Both schemes of the first requirement apply together; basicAuth is the alternative
+	if got, want := security["GET /widgets"], []SecurityRequirement{{Schemes: []string{"api_key", "bearerAuth"}}, {Schemes: []string{"basicAuth"}}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("GET /widgets security = %+v, want %+v", got, want)
+	}
+	// Operations without a security list inherit the spec-level one
+	if got, want := security["POST /widgets"], []SecurityRequirement{{Schemes: []string{"api_key"}}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("POST /widgets security = %+v, want %+v", got, want)
+	}
+	if got, want := security["GET /widgets/{id}"], []SecurityRequirement{{Schemes: []string{"api_key"}}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("GET /widgets/{id} security = %+v, want %+v", got, want)
+	}
+	// An empty security list turns authentication off
+	if got, ok := security["GET /health"]; !ok || got != nil {
+		t.Errorf("GET /health security = %+v (found %v), want nil", got, ok)
+	}
+}
+
+func TestParse_SuccessCodesExtension(t *testing.T) {
+	specContent := `
+openapi: "3.0.0"
+info:
+  title: "Success Codes API"
+  version: "1.0.0"
+paths:
+  /widgets:
+    post:
+      x-k8s-success-codes: [201, 202]
+      responses:
+        "201":
+          description: Created
+  /widgets/{id}:
+    get:
+      parameters:
+        - name: id
+          in: path
+          required: true
+          schema:
+            type: string
+      responses:
+        "200":
+          description: Success
+    delete:
+      x-k8s-success-codes: "204"
+      parameters:
+        - name: id
+          in: path
+          required: true
+          schema:
+            type: string
+      responses:
+        "204":
+          description: Deleted
+`
+
+	tmpDir := t.TempDir()
+	specPath := filepath.Join(tmpDir, "openapi.yaml")
+	if err := os.WriteFile(specPath, []byte(specContent), 0644); err != nil {
+		t.Fatalf("failed to write spec file: %v", err)
+	}
+
+	spec, err := NewParser().Parse(specPath)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	codes := make(map[string][]string)
+	for _, res := range spec.Resources {
+		for _, op := range res.Operations {
+			codes[op.Method] = op.SuccessCodes
+		}
+	}
+	if got := codes["POST"]; !reflect.DeepEqual(got, []string{"201", "202"}) {
+		t.Errorf("POST success codes = %v, want [201 202]", got)
+	}
+	if got := codes["DELETE"]; !reflect.DeepEqual(got, []string{"204"}) {
+		t.Errorf("DELETE success codes = %v, want [204]", got)
+	}
+	if got := codes["GET"]; got != nil {
+		t.Errorf("GET success codes = %v, want none", got)
+	}
+}
+
+func TestParse_RequestBodyExample(t *testing.T) {
+	specContent := `
+openapi: "3.0.0"
+info:
+  title: "Examples API"
+  version: "1.0.0"
+paths:
+  /widgets:
+    post:
+      requestBody:
+        content:
+          application/json:
+            schema:
+              $ref: '#/components/schemas/Widget'
+            examples:
+              small:
+                value:
+                  name: small
+              big:
+                value:
+                  name: big
+      responses:
+        "201":
+          description: Created
+  /widgets/{id}:
+    put:
+      parameters:
+        - name: id
+          in: path
+          required: true
+          schema:
+            type: string
+      requestBody:
+        content:
+          application/json:
+            schema:
+              $ref: '#/components/schemas/Widget'
+      responses:
+        "200":
+          description: Success
+components:
+  schemas:
+    Widget:
+      type: object
+      example:
+        name: schema-example
+      properties:
+        name:
+          type: string
+`
+
+	tmpDir := t.TempDir()
+	specPath := filepath.Join(tmpDir, "openapi.yaml")
+	if err := os.WriteFile(specPath, []byte(specContent), 0644); err != nil {
+		t.Fatalf("failed to write spec file: %v", err)
+	}
+
+	spec, err := NewParser().Parse(specPath)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	examples := make(map[string]interface{})
+	for _, res := range spec.Resources {
+		for _, op := range res.Operations {
+			examples[op.Method] = op.RequestBodyExample
+		}
+	}
+	// Named examples are taken in name order; without one, the schema's example is used
+	if got := examples["POST"]; !reflect.DeepEqual(got, map[string]interface{}{"name": "big"}) {
+		t.Errorf("POST request body example = %v, want the big example", got)
+	}
+	if got := examples["PUT"]; !reflect.DeepEqual(got, map[string]interface{}{"name": "schema-example"}) {
+		t.Errorf("PUT request body example = %v, want the schema example", got)
+	}
+}
+
+func TestParse_Servers(t *testing.T) {
+	specContent := `
+openapi: "3.0.0"
+info:
+  title: "Servers API"
+  version: "1.0.0"
+servers:
+  - url: https://api.example.com/v1
+    description: Production
+    x-name: prod
+  - url: https://{region}.staging.example.com/v1
+    description: Staging
+    variables:
+      region:
+        default: eu
+  - url: /v1
+paths: {}
+`
+	tmpDir := t.TempDir()
+	specPath := filepath.Join(tmpDir, "openapi.yaml")
+	if err := os.WriteFile(specPath, []byte(specContent), 0644); err != nil {
+		t.Fatalf("failed to write spec file: %v", err)
+	}
+
+	spec, err := NewParser().Parse(specPath)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	want := []Server{
+		{Name: "prod", URL: "https://api.example.com/v1", Description: "Production"},
+		{URL: "https://eu.staging.example.com/v1", Description: "Staging"},
+		{URL: "/v1"},
+	}
+	if len(spec.Servers) != len(want) {
+		t.Fatalf("expected %d servers, got %d: %+v", len(want), len(spec.Servers), spec.Servers)
+	}
+	for i, w := range want {
+		if spec.Servers[i] != w {
+			t.Errorf("Servers[%d] = %+v, want %+v", i, spec.Servers[i], w)
+		}
+	}
+	if spec.BaseURL != "https://api.example.com/v1" {
+		t.Errorf("expected BaseURL of the first server, got %q", spec.BaseURL)
+	}
+}
+
+func TestParse_PluralExtension(t *testing.T) {
+	specContent := `
+openapi: "3.0.0"
+info:
+  title: "Plural API"
+  version: "1.0.0"
+paths:
+  /data:
+    post:
+      x-k8s-plural: datasets
+      requestBody:
+        content:
+          application/json:
+            schema:
+              $ref: '#/components/schemas/Data'
+      responses:
+        "201":
+          description: Created
+  /data/{id}:
+    parameters:
+      - name: id
+        in: path
+        required: true
+        schema:
+          type: string
+    get:
+      responses:
+        "200":
+          description: Success
+    delete:
+      responses:
+        "204":
+          description: Deleted
+  /stats:
+    get:
+      x-k8s-plural: statistics
+      responses:
+        "200":
+          description: Success
+components:
+  schemas:
+    Data:
+      type: object
+      x-k8s-plural: data
+      properties:
+        name:
+          type: string
+`
+
+	tmpDir := t.TempDir()
+	specPath := filepath.Join(tmpDir, "openapi.yaml")
+	if err := os.WriteFile(specPath, []byte(specContent), 0644); err != nil {
+		t.Fatalf("failed to write spec file: %v", err)
+	}
+
+	spec, err := NewParser().Parse(specPath)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	if len(spec.Resources) != 1 {
+		t.Fatalf("expected 1 resource, got %d", len(spec.Resources))
+	}
+	resource := spec.Resources[0]
+	if resource.Schema == nil || resource.Schema.Plural != "data" {
+		t.Errorf("expected x-k8s-plural on the resource schema, got %+v", resource.Schema)
+	}
+	var opPlural string
+	for _, op := range resource.Operations {
+		if op.Method == "POST" {
+			opPlural = op.Plural
+		}
+	}
+	if opPlural != "datasets" {
+		t.Errorf("expected x-k8s-plural on the POST operation, got %q", opPlural)
+	}
+
+	if len(spec.QueryEndpoints) != 1 || spec.QueryEndpoints[0].Plural != "statistics" {
+		t.Errorf("expected x-k8s-plural on the query endpoint, got %d query endpoints", len(spec.QueryEndpoints))
+	}
+}
+
+func TestParse_NamespaceExtension(t *testing.T) {
+	tests := []struct {
+		name   string
+		header string
+		want   string
+	}{
+		{"info", "info:\n  title: \"NS API\"\n  version: \"1.0.0\"\n  x-k8s-namespace: team-a\n", "team-a"},
+		{"document", "info:\n  title: \"NS API\"\n  version: \"1.0.0\"\nx-k8s-namespace: team-b\n", "team-b"},
+		{"none", "info:\n  title: \"NS API\"\n  version: \"1.0.0\"\n", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			specContent := "openapi: \"3.0.0\"\n" + tt.header + `paths:
+  /notes:
+    get:
+      responses:
+        "200":
+          description: OK
+`
+			specPath := filepath.Join(t.TempDir(), "openapi.yaml")
+			if err := os.WriteFile(specPath, []byte(specContent), 0644); err != nil {
+				t.Fatalf("failed to write spec file: %v", err)
+			}
+
+			spec, err := NewParser().Parse(specPath)
+			if err != nil {
+				t.Fatalf("Parse failed: %v", err)
+			}
+			if spec.Namespace != tt.want {
+				t.Errorf("Namespace = %q, want %q", spec.Namespace, tt.want)
+			}
+		})
+	}
+}
+
+func TestParse_StatusFieldExtension(t *testing.T) {
+	specContent := `
+openapi: "3.0.0"
+info:
+  title: "Status API"
+  version: "1.0.0"
+paths:
+  /vms:
+    post:
+      requestBody:
+        content:
+          application/json:
+            schema:
+              $ref: '#/components/schemas/VM'
+      responses:
+        "201":
+          description: Created
+  /vms/{id}:
+    parameters:
+      - name: id
+        in: path
+        required: true
+        schema:
+          type: string
+    get:
+      x-k8s-status-field:
+        provisioningState: properties.provisioningState
+        ip: .network.address
+      responses:
+        "200":
+          description: Success
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/VM'
+    delete:
+      responses:
+        "204":
+          description: Deleted
+components:
+  schemas:
+    VM:
+      type: object
+      x-k8s-status-field: [properties.powerState]
+      properties:
+        name:
+          type: string
+`
+
+	tmpDir := t.TempDir()
+	specPath := filepath.Join(tmpDir, "openapi.yaml")
+	if err := os.WriteFile(specPath, []byte(specContent), 0644); err != nil {
+		t.Fatalf("failed to write spec file: %v", err)
+	}
+
+	spec, err := NewParser().Parse(specPath)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	if len(spec.Resources) != 1 {
+		t.Fatalf("expected 1 resource, got %d", len(spec.Resources))
+	}
+	resource := spec.Resources[0]
+	if got := resource.Schema.StatusFields; len(got) != 1 || got["powerState"] != "properties.powerState" {
+		t.Errorf("expected the list form on the schema to name the field by its last segment, got %v", got)
+	}
+	var getFields map[string]string
+	for _, op := range resource.Operations {
+		if op.Method == "GET" {
+			getFields = op.StatusFields
+		} else if op.StatusFields != nil {
+			t.Errorf("expected no status fields on %s, got %v", op.Method, op.StatusFields)
+		}
+	}
+	if len(getFields) != 2 || getFields["provisioningState"] != "properties.provisioningState" || getFields["ip"] != ".network.address" {
+		t.Errorf("expected the object form on the GET operation, got %v", getFields)
+	}
+}
+
+func TestPollConditionExtension(t *testing.T) {
+	tests := []struct {
+		name string
+		raw  interface{}
+		want *PollCondition
+	}{
+		{name: "expression", raw: `status == "available"`, want: &PollCondition{Field: "status", Values: []string{"available"}}},
+		{name: "unquoted expression", raw: "properties.ready == true", want: &PollCondition{Field: "properties.ready", Values: []string{"true"}}},
+		{name: "malformed expression", raw: "status", want: &PollCondition{Field: "status"}},
+		{
+			name: "object",
+			raw:  map[string]interface{}{"field": "status", "values": []interface{}{"available", "active"}, "interval": "30s"},
+			want: &PollCondition{Field: "status", Values: []string{"available", "active"}, Interval: "30s"},
+		},
+		{
+			name: "object with a number",
+			raw:  map[string]interface{}{"field": "replicas", "value": float64(3)},
+			want: &PollCondition{Field: "replicas", Values: []string{"3"}},
+		},
+		{name: "absent"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			extensions := map[string]interface{}{}
+			if tt.raw != nil {
+				extensions["x-k8s-poll-condition"] = tt.raw
+			}
+			if got := pollConditionExtension(extensions); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("pollConditionExtension() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParse_AnyOf(t *testing.T) {
+	specContent := `
+openapi: "3.0.0"
+info:
+  title: "Notify API"
+  version: "1.0.0"
+paths:
+  /subscribers:
+    post:
+      requestBody:
+        content:
+          application/json:
+            schema:
+              $ref: '#/components/schemas/Subscriber'
+      responses:
+        "201":
+          description: Created
+  /subscribers/{id}:
+    parameters:
+      - name: id
+        in: path
+        required: true
+        schema:
+          type: string
+    get:
+      responses:
+        "200":
+          description: Success
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/Subscriber'
+components:
+  schemas:
+    Subscriber:
+      anyOf:
+        - type: object
+          required: [email]
+          properties:
+            email: {type: string}
+        - type: object
+          required: [phone, country]
+          properties:
+            phone: {type: string}
+            country: {type: string}
+      properties:
+        name: {type: string}
+        channel:
+          anyOf:
+            - type: object
+              required: [slack]
+              properties:
+                slack: {type: string}
+            - type: object
+              properties:
+                webhook: {type: string}
+        level:
+          anyOf: [{type: string, enum: [low]}, {type: string, enum: [high]}]
+        mixed:
+          anyOf: [{type: string}, {type: integer}]
+`
+
+	tmpDir := t.TempDir()
+	specPath := filepath.Join(tmpDir, "anyof.yaml")
+	if err := os.WriteFile(specPath, []byte(specContent), 0644); err != nil {
+		t.Fatalf("failed to write spec file: %v", err)
+	}
+
+	spec, err := NewParser().Parse(specPath)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	if len(spec.Resources) != 1 {
+		t.Fatalf("expected 1 resource, got %d", len(spec.Resources))
+	}
+	schema := spec.Resources[0].Schema
+
+	for _, name := range []string{"name", "email", "phone", "country"} {
+		if schema.Properties[name] == nil {
+			t.Errorf("expected the anyOf member property %q to be merged, got %v", name, schema.Properties)
+		}
+	}
+	var sets []string
+	for _, set := range schema.AnyOfRequired {
+		sets = append(sets, strings.Join(set, "+"))
+	}
+	if got := strings.Join(sets, ","); got != "email,phone+country" {
+		t.Errorf("expected the required sets of each member, got %v", schema.AnyOfRequired)
+	}
+
+	channel := schema.Properties["channel"]
+	if channel.Type != "object" || channel.Properties["slack"] == nil || channel.Properties["webhook"] == nil {
+		t.Errorf("expected channel to merge both members into an object, got %+v", channel)
+	}
+	if channel.AnyOfRequired != nil {
+		t.Errorf("expected no required sets when a member requires nothing, got %v", channel.AnyOfRequired)
+	}
+	if level := schema.Properties["level"]; level.Type != "string" {
+		t.Errorf("expected anyOf of strings to be a string, got %q", level.Type)
+	}
+	if mixed := schema.Properties["mixed"]; mixed.Type != "" {
+		t.Errorf("expected anyOf of incompatible types to stay untyped, got %q", mixed.Type)
+	}
+}
+
+func TestParse_ConstantPathParams(t *testing.T) {
+	specContent := `
+openapi: "3.0.0"
+info:
+  title: "Versioned API"
+  version: "1.0.0"
+paths:
+  /api/{version}/pets:
+    parameters:
+      - name: version
+        in: path
+        required: true
+        schema:
+          type: string
+    post:
+      responses:
+        "201":
+          description: Created
+  /api/{version}/pets/{petId}:
+    get:
+      parameters:
+        - name: version
+          in: path
+          required: true
+          schema:
+            type: string
+        - name: petId
+          in: path
+          required: true
+          schema:
+            type: string
+      responses:
+        "200":
+          description: Success
+`
+
+	tmpDir := t.TempDir()
+	specPath := filepath.Join(tmpDir, "openapi.yaml")
+	if err := os.WriteFile(specPath, []byte(specContent), 0644); err != nil {
+		t.Fatalf("failed to write spec file: %v", err)
+	}
+
+	p := NewParser()
+	p.LogWriter = io.Discard
+	p.ConstantPathParams = map[string]string{"version": "v1"}
+	spec, err := p.Parse(specPath)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	if len(spec.Resources) != 1 {
+		t.Fatalf("expected 1 resource, got %d", len(spec.Resources))
+	}
+	resource := spec.Resources[0]
+	if resource.Name != "Pet" || resource.Path != "/api/v1/pets" {
+		t.Errorf("expected the Pet resource at /api/v1/pets, got %s at %s", resource.Name, resource.Path)
+	}
+	for _, op := range resource.Operations {
+		if strings.Contains(op.Path, "{version}") {
+			t.Errorf("%s: expected the constant in the path, got %s", op.Method, op.Path)
+		}
+		for _, param := range op.PathParams {
+			if param.Name == "version" {
+				t.Errorf("%s: expected the constant path param to be dropped", op.Method)
+			}
+		}
+	}
+
+	p.ConstantPathParams = map[string]string{"version": "pets"}
+	specContent = strings.Replace(specContent, "paths:\n", "paths:\n  /api/pets/pets:\n    get:\n      responses:\n        \"200\":\n          description: Success\n", 1)
+	collidingPath := filepath.Join(tmpDir, "colliding.yaml")
+	if err := os.WriteFile(collidingPath, []byte(specContent), 0644); err != nil {
+		t.Fatalf("failed to write spec file: %v", err)
+	}
+	if _, err := p.Parse(collidingPath); err == nil || !strings.Contains(err.Error(), "into /api/pets/pets") {
+		t.Errorf("expected a path collision error, got %v", err)
+	}
+}
+
+func TestParse_Not(t *testing.T) {
+	specContent := `
+openapi: "3.0.0"
+info:
+  title: "Account API"
+  version: "1.0.0"
+paths:
+  /accounts/{id}:
+    get:
+      parameters:
+        - name: id
+          in: path
+          required: true
+          schema:
+            type: string
+      responses:
+        "200":
+          description: Success
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/Account'
+    put:
+      parameters:
+        - name: id
+          in: path
+          required: true
+          schema:
+            type: string
+      requestBody:
+        content:
+          application/json:
+            schema:
+              $ref: '#/components/schemas/Account'
+      responses:
+        "200":
+          description: Success
+components:
+  schemas:
+    Account:
+      type: object
+      properties:
+        username:
+          type: string
+          not: {pattern: "^admin"}
+        plan:
+          type: string
+          not: {enum: [legacy]}
+        quota:
+          type: integer
+          not: {minimum: 100, maximum: 200}
+        tags:
+          type: array
+          items: {type: string}
+          not: {maxItems: 0}
+        owner:
+          type: string
+          not: {format: email}
+`
+
+	tmpDir := t.TempDir()
+	specPath := filepath.Join(tmpDir, "not.yaml")
+	if err := os.WriteFile(specPath, []byte(specContent), 0644); err != nil {
+		t.Fatalf("failed to write spec file: %v", err)
+	}
+
+	var buf bytes.Buffer
+	p := NewParser()
+	p.LogWriter = &buf
+	spec, err := p.Parse(specPath)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	if len(spec.Resources) != 1 {
+		t.Fatalf("expected 1 resource, got %d", len(spec.Resources))
+	}
+	props := spec.Resources[0].Schema.Properties
+
+	if not := props["username"].Not; not == nil || not.Pattern != "^admin" || not.Type != "string" {
+		t.Errorf("username: expected a not pattern, got %+v", not)
+	}
+	if not := props["plan"].Not; not == nil || len(not.Enum) != 1 {
+		t.Errorf("plan: expected a not enum, got %+v", not)
+	}
+	if not := props["quota"].Not; not == nil || not.Minimum == nil || not.Maximum == nil || not.Type != "integer" {
+		t.Errorf("quota: expected a not range, got %+v", not)
+	}
+	for _, name := range []string{"tags", "owner"} {
+		if props[name].Not != nil {
+			t.Errorf("%s: expected the unsupported not schema to be dropped, got %+v", name, props[name].Not)
+		}
+		warning := "Warning: skipping the not schema of " + name
+		if strings.Count(buf.String(), warning) != 1 {
+			t.Errorf("expected one warning for %s, got %q", name, buf.String())
+		}
+	}
+}
+
+func TestParse_LogWriter(t *testing.T) {
+	specContent := `
+openapi: "3.0.0"
+info:
+  title: "Log API"
+  version: "1.0.0"
+paths:
+  /widgets/{id}:
+    get:
+      parameters:
+        - name: id
+          in: path
+          required: true
+          schema:
+            type: string
+      responses:
+        "200":
+          description: Success
+`
+
+	tmpDir := t.TempDir()
+	specPath := filepath.Join(tmpDir, "openapi.yaml")
+	if err := os.WriteFile(specPath, []byte(specContent), 0644); err != nil {
+		t.Fatalf("failed to write spec file: %v", err)
+	}
+
+	var buf bytes.Buffer
+	p := NewParser()
+	p.LogWriter = &buf
+	if _, err := p.Parse(specPath); err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	if !strings.Contains(buf.String(), "Endpoint Classification") {
+		t.Errorf("expected classification table in LogWriter output, got %q", buf.String())
+	}
+}
+
+func TestParse_DefaultValues(t *testing.T) {
+	specContent := `
+openapi: "3.0.0"
+info:
+  title: "Default Values API"
+  version: "1.0.0"
+paths:
+  /test:
+    get:
+      responses:
+        "200":
+          description: Success
+components:
+  schemas:
+    DefaultsTest:
+      type: object
+      properties:
+        count:
+          type: integer
+          default: 10
+        enabled:
+          type: boolean
+          default: true
+        name:
+          type: string
+          default: "default_name"
+`
+
+	tmpDir := t.TempDir()
+	specPath := filepath.Join(tmpDir, "openapi.yaml")
+	if err := os.WriteFile(specPath, []byte(specContent), 0644); err != nil {
+		t.Fatalf("failed to write spec file: %v", err)
+	}
+
+	p := NewParser()
+	spec, err := p.Parse(specPath)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	schema := spec.Schemas["DefaultsTest"]
+	if schema == nil {
+		t.Fatal("DefaultsTest schema not found")
+	}
+
+	countField := schema.Properties["count"]
+	if countField == nil {
+		t.Fatal("count field not found")
+	}
+	if countField.Default == nil {
+		t.Error("expected count to have default value")
+	}
+
+	enabledField := schema.Properties["enabled"]
+	if enabledField == nil {
+		t.Fatal("enabled field not found")
+	}
+	if enabledField.Default == nil {
+		t.Error("expected enabled to have default value")
+	}
+
+	nameField := schema.Properties["name"]
+	if nameField == nil {
+		t.Fatal("name field not found")
+	}
+	if nameField.Default == nil {
+		t.Error("expected name to have default value")
+	}
+}
+
+// =============================================================================
+// isResourceIDPath Tests
+// =============================================================================
+
+func TestIsResourceIDPath(t *testing.T) {
+	p := &Parser{}
+
+	tests := []struct {
+		path     string
+		expected bool
+	}{
+		{"/pet/{petId}", true},
+		{"/store/order/{orderId}", true},
+		{"/users/{id}", true},
+		{"/users/{userId}", true},
+		{"/api/v1/users/{userId}", true},
+		{"/pet", false},                     // No ID parameter
+		{"/store/order", false},             // No ID parameter
+		{"/{id}", false},                    // Only 1 segment
+		{"/pet/{petId}/uploadImage", false}, // ID param not at end
+		{"/pet/findByStatus", false},        // No ID parameter
+		{"/user/{userId}/posts", false},     // Extra segment after ID
+		{"/", false},                        // Root path
+		{"", false},                         // Empty path
+		{"/pet/{randomId}", false},          // ID param doesn't match resource name
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			result := p.isResourceIDPath(tt.path)
+			if result != tt.expected {
+				t.Errorf("isResourceIDPath(%q) = %v, expected %v", tt.path, result, tt.expected)
+			}
+		})
+	}
+}
+
+// =============================================================================
+// getBasePathForIDPath Tests
+// =============================================================================
+
+func TestGetBasePathForIDPath(t *testing.T) {
+	p := &Parser{}
+
+	tests := []struct {
+		path     string
+		expected string
+	}{
+		{"/pet/{petId}", "/pet"},
+		{"/store/order/{orderId}", "/store/order"},
+		{"/users/{id}", "/users"},
+		{"/api/v1/users/{userId}", "/api/v1/users"},
+		{"/pet", ""},                     // Not an ID path
+		{"/store/order", ""},             // Not an ID path
+		{"/pet/{petId}/uploadImage", ""}, // Not an ID path (ID not at end)
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			result := p.getBasePathForIDPath(tt.path)
+			if result != tt.expected {
+				t.Errorf("getBasePathForIDPath(%q) = %q, expected %q", tt.path, result, tt.expected)
+			}
+		})
+	}
+}
+
+// =============================================================================
+// Combined Path Tests
+// =============================================================================
+
+func TestParse_CombinedPaths(t *testing.T) {
+	// Test that /pet (POST) and /pet/{petId} (GET/PUT/DELETE) are combined into one resource
+	specContent := `
+openapi: "3.0.0"
+info:
+  title: "Combined Paths API"
+  version: "1.0.0"
+paths:
+  /pet:
+    post:
+      operationId: createPet
+      summary: Create a pet
+      requestBody:
+        content:
+          application/json:
+            schema:
+              type: object
+              properties:
+                name:
+                  type: string
+      responses:
+        "201":
+          description: Created
+  /pet/{petId}:
+    get:
+      operationId: getPet
+      parameters:
+        - name: petId
+          in: path
+          required: true
+          schema:
+            type: integer
+      responses:
+        "200":
+          description: Success
+    put:
+      operationId: updatePet
+      parameters:
+        - name: petId
+          in: path
+          required: true
+          schema:
+            type: integer
+      requestBody:
+        content:
+          application/json:
+            schema:
+              type: object
+              properties:
+                name:
+                  type: string
+      responses:
+        "200":
+          description: Updated
+    delete:
+      operationId: deletePet
+      parameters:
+        - name: petId
+          in: path
+          required: true
+          schema:
+            type: integer
+      responses:
+        "204":
+          description: Deleted
+`
+
+	tmpDir := t.TempDir()
+	specPath := filepath.Join(tmpDir, "openapi.yaml")
+	if err := os.WriteFile(specPath, []byte(specContent), 0644); err != nil {
+		t.Fatalf("failed to write spec file: %v", err)
+	}
+
+	p := NewParser()
+	spec, err := p.Parse(specPath)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	// Should have exactly 1 resource (Pet), not 2
+	if len(spec.Resources) != 1 {
+		t.Fatalf("expected 1 resource (combined), got %d", len(spec.Resources))
+	}
+
+	resource := spec.Resources[0]
+	if resource.Name != "Pet" {
+		t.Errorf("expected resource name 'Pet', got %q", resource.Name)
+	}
+
+	// Should have all 4 operations (POST from /pet, GET/PUT/DELETE from /pet/{petId})
+	if len(resource.Operations) != 4 {
+		t.Errorf("expected 4 operations, got %d", len(resource.Operations))
+	}
+
+	methods := make(map[string]bool)
+	for _, op := range resource.Operations {
+		methods[op.Method] = true
+	}
+
+	expectedMethods := []string{"POST", "GET", "PUT", "DELETE"}
+	for _, m := range expectedMethods {
+		if !methods[m] {
+			t.Errorf("expected method %s to be present", m)
+		}
+	}
+
+	// Should NOT be classified as an ActionEndpoint
+	if len(spec.ActionEndpoints) != 0 {
+		t.Errorf("expected 0 action endpoints, got %d", len(spec.ActionEndpoints))
+	}
+}
+
+func TestParse_CombinedPathsWithQueryEndpoints(t *testing.T) {
+	// Test that combined paths work alongside query endpoints
+	specContent := `
+openapi: "3.0.0"
+info:
+  title: "Combined with Query API"
+  version: "1.0.0"
+paths:
+  /pet:
+    post:
+      operationId: createPet
+      responses:
+        "201":
+          description: Created
+  /pet/{petId}:
+    get:
+      operationId: getPet
+      parameters:
+        - name: petId
+          in: path
+          required: true
+          schema:
+            type: integer
+      responses:
+        "200":
+          description: Success
+    delete:
+      operationId: deletePet
+      parameters:
+        - name: petId
+          in: path
+          required: true
+          schema:
+            type: integer
+      responses:
+        "204":
+          description: Deleted
+  /pet/findByStatus:
+    get:
+      operationId: findPetsByStatus
+      parameters:
+        - name: status
+          in: query
+          schema:
+            type: string
+      responses:
+        "200":
+          description: Success
+`
+
+	tmpDir := t.TempDir()
+	specPath := filepath.Join(tmpDir, "openapi.yaml")
+	if err := os.WriteFile(specPath, []byte(specContent), 0644); err != nil {
+		t.Fatalf("failed to write spec file: %v", err)
+	}
+
+	p := NewParser()
+	spec, err := p.Parse(specPath)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	// Should have 1 resource (Pet)
+	if len(spec.Resources) != 1 {
+		t.Fatalf("expected 1 resource, got %d", len(spec.Resources))
+	}
+
+	resource := spec.Resources[0]
+	if resource.Name != "Pet" {
+		t.Errorf("expected resource name 'Pet', got %q", resource.Name)
+	}
+
+	// Should have 3 operations (POST, GET, DELETE)
+	if len(resource.Operations) != 3 {
+		t.Errorf("expected 3 operations, got %d", len(resource.Operations))
+	}
+
+	// Should have 1 query endpoint (findByStatus)
+	if len(spec.QueryEndpoints) != 1 {
+		t.Errorf("expected 1 query endpoint, got %d", len(spec.QueryEndpoints))
+	}
+}
+
+func TestParse_PostOnlyWithoutIDPath_IsAction(t *testing.T) {
+	// Test that POST-only endpoints WITHOUT a corresponding ID path are still actions
+	specContent := `
+openapi: "3.0.0"
+info:
+  title: "Action Only API"
+  version: "1.0.0"
+paths:
+  /store:
+    post:
+      operationId: createStore
+      responses:
+        "201":
+          description: Created
+`
+
+	tmpDir := t.TempDir()
+	specPath := filepath.Join(tmpDir, "openapi.yaml")
+	if err := os.WriteFile(specPath, []byte(specContent), 0644); err != nil {
+		t.Fatalf("failed to write spec file: %v", err)
+	}
+
+	p := NewParser()
+	spec, err := p.Parse(specPath)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	// Should have 0 resources (no corresponding ID path)
+	if len(spec.Resources) != 0 {
+		t.Errorf("expected 0 resources, got %d", len(spec.Resources))
+	}
+
+	// Should have 1 action endpoint
+	if len(spec.ActionEndpoints) != 1 {
+		t.Errorf("expected 1 action endpoint, got %d", len(spec.ActionEndpoints))
+	}
+}
+
+func TestParse_ReadOnlyResources(t *testing.T) {
+	specContent := `
+openapi: "3.0.0"
+info:
+  title: "Read-Only API"
+  version: "1.0.0"
+paths:
+  /regions:
+    get:
+      operationId: listRegions
+      responses:
+        "200":
+          description: Success
+          content:
+            application/json:
+              schema:
+                type: array
+                items:
+                  $ref: '#/components/schemas/Region'
+  /regions/{regionId}:
+    get:
+      operationId: getRegion
+      parameters:
+        - name: regionId
+          in: path
+          required: true
+          schema:
+            type: string
+      responses:
+        "200":
+          description: Success
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/Region'
+  /quotas/{name}:
+    get:
+      operationId: getQuota
+      x-k8s-readonly: true
+      parameters:
+        - name: name
+          in: path
+          required: true
+          schema:
+            type: string
+      responses:
+        "200":
+          description: Success
+  /zones:
+    get:
+      operationId: listZones
+      responses:
+        "200":
+          description: Success
+  /zones/{zoneId}:
+    x-k8s-readonly: false
+    get:
+      operationId: getZone
+      parameters:
+        - name: zoneId
+          in: path
+          required: true
+          schema:
+            type: string
+      responses:
+        "200":
+          description: Success
+  /items/{itemId}:
+    get:
+      operationId: getItem
+      parameters:
+        - name: itemId
+          in: path
+          required: true
+          schema:
+            type: string
+      responses:
+        "200":
+          description: Success
+components:
+  schemas:
+    Region:
+      type: object
+      properties:
+        id:
+          type: string
+        name:
+          type: string
+`
 
-	enabledField := schema.Properties["enabled"]
-	if enabledField == nil {
-		t.Fatal("enabled field not found")
+	tmpDir := t.TempDir()
+	specPath := filepath.Join(tmpDir, "openapi.yaml")
+	if err := os.WriteFile(specPath, []byte(specContent), 0644); err != nil {
+		t.Fatalf("failed to write spec file: %v", err)
 	}
-	if enabledField.Default == nil {
-		t.Error("expected enabled to have default value")
+
+	spec, err := NewParser().Parse(specPath)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
 	}
 
-	nameField := schema.Properties["name"]
-	if nameField == nil {
-		t.Fatal("name field not found")
+	resources := make(map[string]*Resource)
+	for _, r := range spec.Resources {
+		resources[r.Name] = r
+	}
+	// /regions/{regionId} is detected from its GET-only collection; /quotas/{name} is marked
+	for _, name := range []string{"Region", "Quota"} {
+		r, ok := resources[name]
+		if !ok {
+			t.Errorf("expected read-only resource %s", name)
+			continue
+		}
+		if !r.ReadOnly {
+			t.Errorf("%s: expected ReadOnly", name)
+		}
+		if len(r.Operations) != 1 || r.Operations[0].Method != "GET" {
+			t.Errorf("%s: expected only the GET operation, got %+v", name, r.Operations)
+		}
 	}
-	if nameField.Default == nil {
-		t.Error("expected name to have default value")
+	if len(resources) != 2 {
+		t.Errorf("expected 2 resources, got %d", len(spec.Resources))
+	}
+
+	// x-k8s-readonly: false and a lone GET-only ID path stay query endpoints
+	queries := make(map[string]bool)
+	for _, q := range spec.QueryEndpoints {
+		queries[q.Path] = true
+	}
+	for _, path := range []string{"/zones/{zoneId}", "/items/{itemId}"} {
+		if !queries[path] {
+			t.Errorf("expected %s to be a query endpoint", path)
+		}
 	}
 }
 
-// =============================================================================
-// isResourceIDPath Tests
-// =============================================================================
+func TestParse_OwnerExtension(t *testing.T) {
+	specContent := `
+openapi: "3.0.0"
+info:
+  title: "Orders API"
+  version: "1.0.0"
+paths:
+  /orders/{orderId}:
+    get:
+      operationId: getOrder
+      parameters:
+        - name: orderId
+          in: path
+          required: true
+          schema:
+            type: string
+      responses:
+        "200":
+          description: Success
+    put:
+      operationId: updateOrder
+      parameters:
+        - name: orderId
+          in: path
+          required: true
+          schema:
+            type: string
+      responses:
+        "200":
+          description: Success
+  /orders/{orderId}/items/{itemId}:
+    x-k8s-owner: Order
+    parameters:
+      - name: orderId
+        in: path
+        required: true
+        schema:
+          type: string
+      - name: itemId
+        in: path
+        required: true
+        schema:
+          type: string
+    get:
+      operationId: getItem
+      responses:
+        "200":
+          description: Success
+    delete:
+      operationId: deleteItem
+      responses:
+        "204":
+          description: Deleted
+`
 
-func TestIsResourceIDPath(t *testing.T) {
-	p := &Parser{}
+	tmpDir := t.TempDir()
+	specPath := filepath.Join(tmpDir, "openapi.yaml")
+	if err := os.WriteFile(specPath, []byte(specContent), 0644); err != nil {
+		t.Fatalf("failed to write spec file: %v", err)
+	}
 
-	tests := []struct {
-		path     string
-		expected bool
-	}{
-		{"/pet/{petId}", true},
-		{"/store/order/{orderId}", true},
-		{"/users/{id}", true},
-		{"/users/{userId}", true},
-		{"/api/v1/users/{userId}", true},
-		{"/pet", false},                     // No ID parameter
-		{"/store/order", false},             // No ID parameter
-		{"/{id}", false},                    // Only 1 segment
-		{"/pet/{petId}/uploadImage", false}, // ID param not at end
-		{"/pet/findByStatus", false},        // No ID parameter
-		{"/user/{userId}/posts", false},     // Extra segment after ID
-		{"/", false},                        // Root path
-		{"", false},                         // Empty path
-		{"/pet/{randomId}", false},          // ID param doesn't match resource name
+	spec, err := NewParser().Parse(specPath)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
 	}
 
-	for _, tt := range tests {
-		t.Run(tt.path, func(t *testing.T) {
-			result := p.isResourceIDPath(tt.path)
-			if result != tt.expected {
-				t.Errorf("isResourceIDPath(%q) = %v, expected %v", tt.path, result, tt.expected)
-			}
-		})
+	owners := make(map[string]string)
+	for _, r := range spec.Resources {
+		owners[r.Name] = r.Owner
+	}
+	if owners["Item"] != "Order" {
+		t.Errorf("expected Item to be owned by Order, got %q", owners["Item"])
+	}
+	if owner, ok := owners["Order"]; !ok || owner != "" {
+		t.Errorf("expected an Order resource without an owner, got %q (found %v)", owner, ok)
 	}
 }
 
-// =============================================================================
-// getBasePathForIDPath Tests
-// =============================================================================
+func TestParse_MultipartFormFields(t *testing.T) {
+	specContent := `
+openapi: "3.0.0"
+info:
+  title: "Upload API"
+  version: "1.0.0"
+paths:
+  /documents/{documentId}/upload:
+    post:
+      operationId: uploadDocument
+      parameters:
+        - name: documentId
+          in: path
+          required: true
+          schema:
+            type: string
+      requestBody:
+        content:
+          multipart/form-data:
+            schema:
+              type: object
+              required: [description]
+              properties:
+                description:
+                  type: string
+                pages:
+                  type: integer
+                file:
+                  type: string
+                  format: binary
+      responses:
+        "200":
+          description: OK
+`
 
-func TestGetBasePathForIDPath(t *testing.T) {
-	p := &Parser{}
+	tmpDir := t.TempDir()
+	specPath := filepath.Join(tmpDir, "openapi.yaml")
+	if err := os.WriteFile(specPath, []byte(specContent), 0644); err != nil {
+		t.Fatalf("failed to write spec file: %v", err)
+	}
 
-	tests := []struct {
-		path     string
-		expected string
-	}{
-		{"/pet/{petId}", "/pet"},
-		{"/store/order/{orderId}", "/store/order"},
-		{"/users/{id}", "/users"},
-		{"/api/v1/users/{userId}", "/api/v1/users"},
-		{"/pet", ""},                     // Not an ID path
-		{"/store/order", ""},             // Not an ID path
-		{"/pet/{petId}/uploadImage", ""}, // Not an ID path (ID not at end)
+	spec, err := NewParser().Parse(specPath)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	if len(spec.ActionEndpoints) != 1 {
+		t.Fatalf("expected 1 action endpoint, got %d", len(spec.ActionEndpoints))
 	}
 
-	for _, tt := range tests {
-		t.Run(tt.path, func(t *testing.T) {
-			result := p.getBasePathForIDPath(tt.path)
-			if result != tt.expected {
-				t.Errorf("getBasePathForIDPath(%q) = %q, expected %q", tt.path, result, tt.expected)
-			}
-		})
+	action := spec.ActionEndpoints[0]
+	if !action.IsMultipart() || !action.HasBinaryBody || action.BinaryContentType != "multipart/form-data" {
+		t.Errorf("expected a multipart binary body, got multipart=%v binary=%v contentType=%q",
+			action.IsMultipart(), action.HasBinaryBody, action.BinaryContentType)
+	}
+	if action.FileField != "file" {
+		t.Errorf("expected file field 'file', got %q", action.FileField)
+	}
+	want := []FormField{
+		{Name: "description", Type: "string", Required: true},
+		{Name: "pages", Type: "integer"},
+	}
+	if len(action.FormFields) != len(want) {
+		t.Fatalf("expected form fields %v, got %v", want, action.FormFields)
+	}
+	for i, field := range want {
+		if action.FormFields[i] != field {
+			t.Errorf("form field %d: expected %+v, got %+v", i, field, action.FormFields[i])
+		}
 	}
 }
 
-// =============================================================================
-// Combined Path Tests
-// =============================================================================
-
-func TestParse_CombinedPaths(t *testing.T) {
-	// Test that /pet (POST) and /pet/{petId} (GET/PUT/DELETE) are combined into one resource
+func TestParse_FormURLEncodedBody(t *testing.T) {
 	specContent := `
 openapi: "3.0.0"
 info:
-  title: "Combined Paths API"
+  title: "Legacy API"
   version: "1.0.0"
 paths:
-  /pet:
+  /accounts:
     post:
-      operationId: createPet
-      summary: Create a pet
+      operationId: createAccount
       requestBody:
         content:
-          application/json:
+          application/x-www-form-urlencoded:
             schema:
-              type: object
-              properties:
-                name:
-                  type: string
+              $ref: '#/components/schemas/Account'
       responses:
         "201":
           description: Created
-  /pet/{petId}:
+  /accounts/{accountId}:
     get:
-      operationId: getPet
+      operationId: getAccount
       parameters:
-        - name: petId
+        - name: accountId
           in: path
           required: true
           schema:
-            type: integer
+            type: string
       responses:
         "200":
-          description: Success
-    put:
-      operationId: updatePet
+          description: OK
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/Account'
+  /notes:
+    post:
+      operationId: createNote
+      requestBody:
+        content:
+          application/json:
+            schema:
+              $ref: '#/components/schemas/Note'
+          application/x-www-form-urlencoded:
+            schema:
+              $ref: '#/components/schemas/Note'
+      responses:
+        "201":
+          description: Created
+  /notes/{noteId}:
+    get:
+      operationId: getNote
       parameters:
-        - name: petId
+        - name: noteId
           in: path
           required: true
           schema:
-            type: integer
+            type: string
+      responses:
+        "200":
+          description: OK
+  /login:
+    post:
+      operationId: login
       requestBody:
         content:
-          application/json:
+          application/x-www-form-urlencoded:
             schema:
               type: object
+              required: [username]
               properties:
-                name:
+                username:
+                  type: string
+                password:
                   type: string
       responses:
         "200":
-          description: Updated
-    delete:
-      operationId: deletePet
-      parameters:
-        - name: petId
-          in: path
-          required: true
-          schema:
-            type: integer
-      responses:
-        "204":
-          description: Deleted
+          description: OK
+components:
+  schemas:
+    Account:
+      type: object
+      properties:
+        name:
+          type: string
+        plan:
+          type: string
+    Note:
+      type: object
+      properties:
+        text:
+          type: string
 `
 
 	tmpDir := t.TempDir()
@@ -1440,164 +3700,253 @@ paths:
 		t.Fatalf("failed to write spec file: %v", err)
 	}
 
-	p := NewParser()
-	spec, err := p.Parse(specPath)
+	spec, err := NewParser().Parse(specPath)
 	if err != nil {
 		t.Fatalf("Parse failed: %v", err)
 	}
 
-	// Should have exactly 1 resource (Pet), not 2
-	if len(spec.Resources) != 1 {
-		t.Fatalf("expected 1 resource (combined), got %d", len(spec.Resources))
+	resources := make(map[string]*Resource)
+	for _, r := range spec.Resources {
+		resources[r.Name] = r
 	}
-
-	resource := spec.Resources[0]
-	if resource.Name != "Pet" {
-		t.Errorf("expected resource name 'Pet', got %q", resource.Name)
+	account, ok := resources["Account"]
+	if !ok {
+		t.Fatalf("expected an Account resource, got %v", spec.Resources)
 	}
-
-	// Should have all 4 operations (POST from /pet, GET/PUT/DELETE from /pet/{petId})
-	if len(resource.Operations) != 4 {
-		t.Errorf("expected 4 operations, got %d", len(resource.Operations))
+	if account.Schema == nil || account.Schema.Properties["plan"] == nil {
+		t.Errorf("expected the Account schema from the form body, got %+v", account.Schema)
 	}
-
-	methods := make(map[string]bool)
-	for _, op := range resource.Operations {
-		methods[op.Method] = true
+	for _, op := range account.Operations {
+		if op.Method == "POST" && (!op.FormEncoded || op.RequestBody == nil) {
+			t.Errorf("expected the create POST to be form-encoded with a body, got FormEncoded=%v body=%v", op.FormEncoded, op.RequestBody)
+		}
 	}
-
-	expectedMethods := []string{"POST", "GET", "PUT", "DELETE"}
-	for _, m := range expectedMethods {
-		if !methods[m] {
-			t.Errorf("expected method %s to be present", m)
+	for _, op := range resources["Note"].Operations {
+		if op.FormEncoded {
+			t.Errorf("expected JSON to be preferred for %s %s", op.Method, op.Path)
 		}
 	}
 
-	// Should NOT be classified as an ActionEndpoint
-	if len(spec.ActionEndpoints) != 0 {
-		t.Errorf("expected 0 action endpoints, got %d", len(spec.ActionEndpoints))
+	if len(spec.ActionEndpoints) != 1 {
+		t.Fatalf("expected 1 action endpoint, got %d", len(spec.ActionEndpoints))
+	}
+	login := spec.ActionEndpoints[0]
+	if !login.FormEncoded || login.IsMultipart() || login.HasBinaryBody {
+		t.Errorf("expected a form-encoded login action, got FormEncoded=%v multipart=%v binary=%v",
+			login.FormEncoded, login.IsMultipart(), login.HasBinaryBody)
+	}
+	if login.RequestSchema == nil || login.RequestSchema.Properties["username"] == nil {
+		t.Errorf("expected the login request schema from the form body, got %+v", login.RequestSchema)
 	}
 }
 
-func TestParse_CombinedPathsWithQueryEndpoints(t *testing.T) {
-	// Test that combined paths work alongside query endpoints
+func TestParse_RequestContentType(t *testing.T) {
 	specContent := `
 openapi: "3.0.0"
 info:
-  title: "Combined with Query API"
+  title: "Vendor API"
   version: "1.0.0"
 paths:
-  /pet:
+  /widgets:
     post:
-      operationId: createPet
+      operationId: createWidget
+      requestBody:
+        content:
+          application/vnd.example.widget+json:
+            schema:
+              $ref: '#/components/schemas/Widget'
+          application/x-www-form-urlencoded:
+            schema:
+              $ref: '#/components/schemas/Widget'
       responses:
         "201":
           description: Created
-  /pet/{petId}:
+  /widgets/{widgetId}:
+    parameters:
+      - name: widgetId
+        in: path
+        required: true
+        schema:
+          type: string
     get:
-      operationId: getPet
-      parameters:
-        - name: petId
-          in: path
-          required: true
-          schema:
-            type: integer
+      operationId: getWidget
       responses:
         "200":
-          description: Success
-    delete:
-      operationId: deletePet
+          description: OK
+    patch:
+      operationId: patchWidget
+      requestBody:
+        content:
+          application/merge-patch+json:
+            schema:
+              $ref: '#/components/schemas/Widget'
+      responses:
+        "200":
+          description: OK
+    put:
+      operationId: replaceWidget
+      requestBody:
+        content:
+          application/json:
+            schema:
+              $ref: '#/components/schemas/Widget'
+          application/vnd.example.widget+json:
+            schema:
+              $ref: '#/components/schemas/Widget'
+      responses:
+        "200":
+          description: OK
+  /widgets/{widgetId}/rename:
+    post:
+      operationId: renameWidget
       parameters:
-        - name: petId
+        - name: widgetId
           in: path
           required: true
-          schema:
-            type: integer
-      responses:
-        "204":
-          description: Deleted
-  /pet/findByStatus:
-    get:
-      operationId: findPetsByStatus
-      parameters:
-        - name: status
-          in: query
           schema:
             type: string
+      requestBody:
+        content:
+          application/json; charset=utf-8:
+            schema:
+              type: object
+              properties:
+                name:
+                  type: string
       responses:
         "200":
-          description: Success
+          description: OK
+components:
+  schemas:
+    Widget:
+      type: object
+      properties:
+        name:
+          type: string
 `
 
-	tmpDir := t.TempDir()
-	specPath := filepath.Join(tmpDir, "openapi.yaml")
+	specPath := filepath.Join(t.TempDir(), "openapi.yaml")
 	if err := os.WriteFile(specPath, []byte(specContent), 0644); err != nil {
 		t.Fatalf("failed to write spec file: %v", err)
 	}
-
-	p := NewParser()
-	spec, err := p.Parse(specPath)
+	spec, err := NewParser().Parse(specPath)
 	if err != nil {
 		t.Fatalf("Parse failed: %v", err)
 	}
 
-	// Should have 1 resource (Pet)
-	if len(spec.Resources) != 1 {
-		t.Fatalf("expected 1 resource, got %d", len(spec.Resources))
+	var widget *Resource
+	for _, r := range spec.Resources {
+		if r.Name == "Widget" {
+			widget = r
+		}
 	}
-
-	resource := spec.Resources[0]
-	if resource.Name != "Pet" {
-		t.Errorf("expected resource name 'Pet', got %q", resource.Name)
+	if widget == nil {
+		t.Fatalf("expected a Widget resource, got %v", spec.Resources)
 	}
-
-	// Should have 3 operations (POST, GET, DELETE)
-	if len(resource.Operations) != 3 {
-		t.Errorf("expected 3 operations, got %d", len(resource.Operations))
+	if widget.Schema == nil || widget.Schema.Properties["name"] == nil {
+		t.Errorf("expected the Widget schema from the vendor JSON body, got %+v", widget.Schema)
 	}
-
-	// Should have 1 query endpoint (findByStatus)
-	if len(spec.QueryEndpoints) != 1 {
-		t.Errorf("expected 1 query endpoint, got %d", len(spec.QueryEndpoints))
+	want := map[string]string{
+		"POST /widgets":                   "application/vnd.example.widget+json",
+		"PATCH /widgets/{widgetId}":       "application/merge-patch+json",
+		"PUT /widgets/{widgetId}":         "application/json",
+		"GET /widgets/{widgetId}":         "",
+		"POST /widgets/{widgetId}/rename": "application/json; charset=utf-8",
+	}
+	for _, op := range widget.Operations {
+		key := op.Method + " " + op.Path
+		if op.RequestContentType != want[key] {
+			t.Errorf("%s: RequestContentType = %q, want %q", key, op.RequestContentType, want[key])
+		}
+		if op.FormEncoded {
+			t.Errorf("%s: expected JSON to be preferred over the form", key)
+		}
 	}
 }
 
-func TestParse_PostOnlyWithoutIDPath_IsAction(t *testing.T) {
-	// Test that POST-only endpoints WITHOUT a corresponding ID path are still actions
+func TestParse_Webhooks(t *testing.T) {
 	specContent := `
-openapi: "3.0.0"
+openapi: "3.1.0"
 info:
-  title: "Action Only API"
+  title: "Pet Events API"
   version: "1.0.0"
 paths:
-  /store:
+  /pets/{petId}:
+    get:
+      operationId: getPet
+      parameters:
+        - name: petId
+          in: path
+          required: true
+          schema:
+            type: string
+      responses:
+        "200":
+          description: OK
+webhooks:
+  petUpdated:
     post:
-      operationId: createStore
+      operationId: petUpdated
+      summary: A pet changed
+      requestBody:
+        $ref: '#/components/requestBodies/PetEvent'
       responses:
-        "201":
-          description: Created
+        "200":
+          description: OK
+  newPet:
+    post:
+      operationId: newPet
+      x-k8s-kind: Pet
+      requestBody:
+        content:
+          application/json:
+            schema:
+              $ref: '#/components/schemas/Pet'
+      responses:
+        "200":
+          description: OK
+    put:
+      operationId: replacePet
+      tags: [internal]
+      requestBody:
+        content:
+          text/plain:
+            schema:
+              type: string
+      responses:
+        "200":
+          description: OK
+components:
+  requestBodies:
+    PetEvent:
+      content:
+        application/json:
+          schema:
+            $ref: '#/components/schemas/Pet'
+  schemas:
+    Pet:
+      type: object
+      properties:
+        name:
+          type: string
 `
 
-	tmpDir := t.TempDir()
-	specPath := filepath.Join(tmpDir, "openapi.yaml")
+	specPath := filepath.Join(t.TempDir(), "openapi.yaml")
 	if err := os.WriteFile(specPath, []byte(specContent), 0644); err != nil {
 		t.Fatalf("failed to write spec file: %v", err)
 	}
-
-	p := NewParser()
-	spec, err := p.Parse(specPath)
+	spec, err := NewParser().Parse(specPath)
 	if err != nil {
 		t.Fatalf("Parse failed: %v", err)
 	}
 
-	// Should have 0 resources (no corresponding ID path)
-	if len(spec.Resources) != 0 {
-		t.Errorf("expected 0 resources, got %d", len(spec.Resources))
+	want := []Webhook{
+		{Name: "newPet", Method: "POST", OperationID: "newPet", ContentType: "application/json", PayloadSchemaRef: "Pet", Kind: "Pet"},
+		{Name: "newPet", Method: "PUT", OperationID: "replacePet"},
+		{Name: "petUpdated", Method: "POST", OperationID: "petUpdated", Summary: "A pet changed", ContentType: "application/json", PayloadSchemaRef: "Pet"},
 	}
-
-	// Should have 1 action endpoint
-	if len(spec.ActionEndpoints) != 1 {
-		t.Errorf("expected 1 action endpoint, got %d", len(spec.ActionEndpoints))
+	if !reflect.DeepEqual(spec.Webhooks, want) {
+		t.Errorf("Webhooks = %+v, want %+v", spec.Webhooks, want)
 	}
 }
 
@@ -1975,6 +4324,85 @@ func TestParse_Swagger2JSON(t *testing.T) {
 	}
 }
 
+func TestParse_SpecFormat(t *testing.T) {
+	jsonSpec := `{
+  "openapi": "3.0.0",
+  "info": {"title": "Plain Text API", "version": "1.0.0"},
+  "paths": {
+    "/users/{id}": {
+      "get": {
+        "operationId": "getUser",
+        "parameters": [{"name": "id", "in": "path", "required": true, "schema": {"type": "string"}}],
+        "responses": {"200": {"description": "Success"}}
+      }
+    }
+  }
+}`
+	yamlSpec := `
+swagger: "2.0"
+info:
+  title: "YAML Swagger API"
+  version: "1.0.0"
+paths:
+  /users/{id}:
+    get:
+      operationId: getUser
+      parameters:
+        - name: id
+          in: path
+          required: true
+          type: string
+      responses:
+        "200":
+          description: Success
+`
+	// Serve the specs from extension-less URLs with a misleading content type
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain")
+		if r.URL.Path == "/yaml-spec" {
+			_, _ = w.Write([]byte(yamlSpec))
+			return
+		}
+		_, _ = w.Write([]byte(jsonSpec))
+	}))
+	defer srv.Close()
+
+	tests := []struct {
+		name      string
+		path      string
+		format    string
+		wantTitle string
+		wantErr   bool
+	}{
+		{name: "auto json", path: "/json-spec", format: "", wantTitle: "Plain Text API"},
+		{name: "forced json", path: "/json-spec", format: SpecFormatJSON, wantTitle: "Plain Text API"},
+		{name: "forced yaml reads json", path: "/json-spec", format: SpecFormatYAML, wantTitle: "Plain Text API"},
+		{name: "forced yaml swagger", path: "/yaml-spec", format: SpecFormatYAML, wantTitle: "YAML Swagger API"},
+		{name: "forced json rejects yaml", path: "/yaml-spec", format: SpecFormatJSON, wantErr: true},
+		{name: "unknown format", path: "/json-spec", format: "toml", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := NewParser()
+			p.LogWriter = io.Discard
+			p.SpecFormat = tt.format
+			spec, err := p.Parse(srv.URL + tt.path)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Parse failed: %v", err)
+			}
+			if spec.Title != tt.wantTitle {
+				t.Errorf("expected Title %q, got %q", tt.wantTitle, spec.Title)
+			}
+		})
+	}
+}
+
 func TestParse_Swagger2WithQueryParams(t *testing.T) {
 	specContent := `
 swagger: "2.0"
@@ -2089,3 +4517,107 @@ func TestParse_Swagger2FromURL(t *testing.T) {
 		t.Error("expected at least some resources, queries, or actions")
 	}
 }
+
+func TestParse_SpecDirectory(t *testing.T) {
+	root := `openapi: "3.0.3"
+info:
+  title: Split API
+  version: "1.0.0"
+paths:
+  /pets:
+    $ref: "./paths/pets.yaml"
+  /pets/{petId}:
+    $ref: "./paths/pet.yaml"
+`
+	pets := `get:
+  responses:
+    "200":
+      description: OK
+      content:
+        application/json:
+          schema:
+            type: array
+            items:
+              $ref: "../schemas/pet.yaml"
+post:
+  requestBody:
+    content:
+      application/json:
+        schema:
+          $ref: "../schemas/pet.yaml"
+  responses:
+    "201":
+      description: Created
+`
+	pet := `parameters:
+  - name: petId
+    in: path
+    required: true
+    schema:
+      type: string
+get:
+  responses:
+    "200":
+      description: OK
+      content:
+        application/json:
+          schema:
+            $ref: "../schemas/pet.yaml"
+delete:
+  responses:
+    "204":
+      description: Deleted
+`
+	petSchema := `type: object
+properties:
+  id:
+    type: string
+  name:
+    type: string
+`
+
+	tmpDir := t.TempDir()
+	files := map[string]string{
+		"openapi.yaml":     root,
+		"paths/pets.yaml":  pets,
+		"paths/pet.yaml":   pet,
+		"schemas/pet.yaml": petSchema,
+	}
+	for name, content := range files {
+		path := filepath.Join(tmpDir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("failed to write %s: %v", name, err)
+		}
+	}
+
+	spec, err := NewParser().Parse(tmpDir)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	if spec.Title != "Split API" {
+		t.Errorf("expected Title 'Split API', got %q", spec.Title)
+	}
+	if len(spec.Resources) != 1 {
+		t.Fatalf("expected 1 resource, got %d", len(spec.Resources))
+	}
+	if s := spec.Resources[0].Schema; s == nil || s.Properties["name"] == nil {
+		t.Errorf("expected resource schema resolved from schemas/pet.yaml, got %+v", s)
+	}
+
+	// A second root document makes the directory ambiguous
+	if err := os.WriteFile(filepath.Join(tmpDir, "legacy.yaml"), []byte(root), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := NewParser().Parse(tmpDir); err == nil || !strings.Contains(err.Error(), "multiple OpenAPI root documents") {
+		t.Errorf("expected ambiguity error, got %v", err)
+	}
+
+	p := NewParser()
+	p.SpecRootFile = "openapi.yaml"
+	if _, err := p.Parse(tmpDir); err != nil {
+		t.Errorf("Parse with SpecRootFile failed: %v", err)
+	}
+}
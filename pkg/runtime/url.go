@@ -32,6 +32,10 @@ type URLBuilder struct {
 	pathParams  map[string]string
 	queryParams url.Values
 	resourceID  string
+	// rawPathParams holds already-escaped path segments (e.g., joined array params)
+	rawPathParams map[string]string
+	// pathStyles holds the label or matrix style of scalar path params
+	pathStyles map[string]string
 }
 
 // NewURLBuilder creates a new URLBuilder with the given base path template.
@@ -42,9 +46,11 @@ type URLBuilder struct {
 //	builder := NewURLBuilder("/pet/{petId}/uploadImage")
 func NewURLBuilder(basePath string) *URLBuilder {
 	return &URLBuilder{
-		basePath:    basePath,
-		pathParams:  make(map[string]string),
-		queryParams: make(url.Values),
+		basePath:      basePath,
+		pathParams:    make(map[string]string),
+		queryParams:   make(url.Values),
+		rawPathParams: make(map[string]string),
+		pathStyles:    make(map[string]string),
 	}
 }
 
@@ -74,6 +80,72 @@ func (b *URLBuilder) WithPathParamInt(name string, value int64) *URLBuilder {
 	return b
 }
 
+// WithPathParamArray adds an array path parameter, serialized per the OpenAPI style
+// ("simple", "label" or "matrix") and explode flag. Each value is escaped individually
+// so the separators stay literal. Empty arrays are ignored.
+//
+// Example:
+//
+//	builder.WithPathParamArray("ids", []string{"1", "2"}, "simple", false) // replaces {ids} with 1,2
+//	builder.WithPathParamArray("ids", []string{"1", "2"}, "matrix", true)  // replaces {ids} with ;ids=1;ids=2
+func (b *URLBuilder) WithPathParamArray(name string, values []string, style string, explode bool) *URLBuilder {
+	if len(values) == 0 {
+		return b
+	}
+	escaped := make([]string, len(values))
+	for i, v := range values {
+		escaped[i] = url.PathEscape(v)
+	}
+
+	var value string
+	switch style {
+	case "label":
+		if explode {
+			value = "." + strings.Join(escaped, ".")
+		} else {
+			value = "." + strings.Join(escaped, ",")
+		}
+	case "matrix":
+		if explode {
+			value = ";" + name + "=" + strings.Join(escaped, ";"+name+"=")
+		} else {
+			value = ";" + name + "=" + strings.Join(escaped, ",")
+		}
+	default:
+		// simple: explode makes no difference for arrays
+		value = strings.Join(escaped, ",")
+	}
+	b.rawPathParams[name] = value
+	return b
+}
+
+// WithPathParamIntArray adds an integer array path parameter; see WithPathParamArray.
+//
+// Example:
+//
+//	builder.WithPathParamIntArray("ids", []int64{1, 2, 3}, "simple", false) // replaces {ids} with 1,2,3
+func (b *URLBuilder) WithPathParamIntArray(name string, values []int64, style string, explode bool) *URLBuilder {
+	strValues := make([]string, len(values))
+	for i, v := range values {
+		strValues[i] = fmt.Sprintf("%d", v)
+	}
+	return b.WithPathParamArray(name, strValues, style, explode)
+}
+
+// WithPathParamStyle sets the OpenAPI style of a scalar path parameter: "label" prefixes
+// the value with a dot and "matrix" with ;name=. The default "simple" style substitutes
+// the value as is. The style applies whichever of WithPathParam or WithPathParamInt sets
+// the value, so it can be declared once up front.
+//
+// Example:
+//
+//	builder.WithPathParamStyle("petId", "label").WithPathParam("petId", "123")  // replaces {petId} with .123
+//	builder.WithPathParamStyle("petId", "matrix").WithPathParam("petId", "123") // replaces {petId} with ;petId=123
+func (b *URLBuilder) WithPathParamStyle(name, style string) *URLBuilder {
+	b.pathStyles[name] = style
+	return b
+}
+
 // WithPathParams adds multiple path parameters at once.
 // Empty values are ignored.
 func (b *URLBuilder) WithPathParams(params map[string]string) *URLBuilder {
@@ -96,6 +168,17 @@ func (b *URLBuilder) WithQueryParam(name, value string) *URLBuilder {
 	return b
 }
 
+// WithQueryParamAllowEmpty adds a query parameter even when its value is empty,
+// for parameters declared with allowEmptyValue (e.g., flag-style ?verbose=).
+//
+// Example:
+//
+//	builder.WithQueryParamAllowEmpty("verbose", "") // adds ?verbose=
+func (b *URLBuilder) WithQueryParamAllowEmpty(name, value string) *URLBuilder {
+	b.queryParams.Set(name, value)
+	return b
+}
+
 // WithQueryParamInt adds an integer query parameter.
 // Zero values are ignored.
 //
@@ -244,7 +327,17 @@ func (b *URLBuilder) substitutePath() string {
 	for name, value := range b.pathParams {
 		placeholder := "{" + name + "}"
 		// URL-encode the value for safe path usage
-		path = strings.Replace(path, placeholder, url.PathEscape(value), 1)
+		value = url.PathEscape(value)
+		switch b.pathStyles[name] {
+		case "label":
+			value = "." + value
+		case "matrix":
+			value = ";" + name + "=" + value
+		}
+		path = strings.Replace(path, placeholder, value, 1)
+	}
+	for name, value := range b.rawPathParams {
+		path = strings.Replace(path, "{"+name+"}", value, 1)
 	}
 
 	return path
@@ -306,11 +399,29 @@ func (b *URLBuilder) GetUnsubstitutedParams() []string {
 	return params
 }
 
+// ResourceLink turns a URL produced by Build into a link suitable for display,
+// such as status.externalResourceURL. The query string is dropped because it can
+// carry filters or credentials. An empty string is returned if the URL still
+// contains unsubstituted path parameters, since it would not point at a single resource.
+//
+// Example:
+//
+//	ResourceLink("https://api.example.com/pet/123?fields=name") // https://api.example.com/pet/123
+func ResourceLink(requestURL string) string {
+	link, _, _ := strings.Cut(requestURL, "?")
+	if strings.Contains(link, "{") && strings.Contains(link, "}") {
+		return ""
+	}
+	return link
+}
+
 // Reset clears all path parameters, query parameters, and resource ID.
 // The base path is preserved.
 func (b *URLBuilder) Reset() *URLBuilder {
 	b.pathParams = make(map[string]string)
 	b.queryParams = make(url.Values)
+	b.rawPathParams = make(map[string]string)
+	b.pathStyles = make(map[string]string)
 	b.resourceID = ""
 	return b
 }
@@ -319,16 +430,26 @@ func (b *URLBuilder) Reset() *URLBuilder {
 // Useful for building multiple similar URLs.
 func (b *URLBuilder) Clone() *URLBuilder {
 	clone := &URLBuilder{
-		basePath:    b.basePath,
-		pathParams:  make(map[string]string),
-		queryParams: make(url.Values),
-		resourceID:  b.resourceID,
+		basePath:      b.basePath,
+		pathParams:    make(map[string]string),
+		queryParams:   make(url.Values),
+		resourceID:    b.resourceID,
+		rawPathParams: make(map[string]string),
+		pathStyles:    make(map[string]string),
 	}
 
 	for k, v := range b.pathParams {
 		clone.pathParams[k] = v
 	}
 
+	for k, v := range b.rawPathParams {
+		clone.rawPathParams[k] = v
+	}
+
+	for k, v := range b.pathStyles {
+		clone.pathStyles[k] = v
+	}
+
 	for k, v := range b.queryParams {
 		clone.queryParams[k] = append([]string{}, v...)
 	}
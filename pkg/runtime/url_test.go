@@ -111,6 +111,112 @@ func TestWithPathParamInt(t *testing.T) {
 	}
 }
 
+func TestWithPathParamArray(t *testing.T) {
+	tests := []struct {
+		name     string
+		style    string
+		explode  bool
+		values   []string
+		expected string
+	}{
+		{name: "simple", style: "simple", values: []string{"1", "2"}, expected: "https://api.example.com/items/1,2"},
+		{name: "simple exploded", style: "simple", explode: true, values: []string{"1", "2"}, expected: "https://api.example.com/items/1,2"},
+		{name: "label", style: "label", values: []string{"1", "2"}, expected: "https://api.example.com/items/.1,2"},
+		{name: "label exploded", style: "label", explode: true, values: []string{"1", "2"}, expected: "https://api.example.com/items/.1.2"},
+		{name: "matrix", style: "matrix", values: []string{"1", "2"}, expected: "https://api.example.com/items/;ids=1,2"},
+		{name: "matrix exploded", style: "matrix", explode: true, values: []string{"1", "2"}, expected: "https://api.example.com/items/;ids=1;ids=2"},
+		{name: "values escaped individually", style: "simple", values: []string{"a b", "c/d"}, expected: "https://api.example.com/items/a%20b,c%2Fd"},
+		{name: "empty ignored", style: "simple", values: nil, expected: "https://api.example.com/items/{ids}"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := NewURLBuilder("/items/{ids}").
+				WithPathParamArray("ids", tt.values, tt.style, tt.explode).
+				Build("https://api.example.com")
+			if result != tt.expected {
+				t.Errorf("expected '%s', got '%s'", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestWithPathParamIntArray(t *testing.T) {
+	builder := NewURLBuilder("/items/{ids}").
+		WithPathParamIntArray("ids", []int64{1, 2, 3}, "simple", false)
+
+	expected := "https://api.example.com/items/1,2,3"
+	if result := builder.Clone().Build("https://api.example.com"); result != expected {
+		t.Errorf("expected '%s', got '%s'", expected, result)
+	}
+	if !builder.Reset().HasUnsubstitutedParams() {
+		t.Error("expected Reset to clear array path params")
+	}
+}
+
+func TestWithPathParamStyle(t *testing.T) {
+	tests := []struct {
+		name     string
+		builder  *URLBuilder
+		expected string
+	}{
+		{
+			name:     "simple",
+			builder:  NewURLBuilder("/pets/{petId}").WithPathParamStyle("petId", "simple").WithPathParam("petId", "rex"),
+			expected: "https://api.example.com/pets/rex",
+		},
+		{
+			name:     "label string",
+			builder:  NewURLBuilder("/pets/{petId}").WithPathParamStyle("petId", "label").WithPathParam("petId", "rex"),
+			expected: "https://api.example.com/pets/.rex",
+		},
+		{
+			name:     "label int",
+			builder:  NewURLBuilder("/pets/{petId}").WithPathParamStyle("petId", "label").WithPathParamInt("petId", 42),
+			expected: "https://api.example.com/pets/.42",
+		},
+		{
+			name:     "matrix string",
+			builder:  NewURLBuilder("/pets/{petId}").WithPathParamStyle("petId", "matrix").WithPathParam("petId", "a b"),
+			expected: "https://api.example.com/pets/;petId=a%20b",
+		},
+		{
+			name:     "matrix int",
+			builder:  NewURLBuilder("/pets/{petId}").WithPathParamStyle("petId", "matrix").WithPathParamInt("petId", 42),
+			expected: "https://api.example.com/pets/;petId=42",
+		},
+		{
+			name: "mixed with array",
+			builder: NewURLBuilder("/stores/{storeId}/pets/{ids}").
+				WithPathParamStyle("storeId", "matrix").
+				WithPathParam("storeId", "s1").
+				WithPathParamIntArray("ids", []int64{1, 2}, "label", true),
+			expected: "https://api.example.com/stores/;storeId=s1/pets/.1.2",
+		},
+		{
+			name:     "unset value stays a placeholder",
+			builder:  NewURLBuilder("/pets/{petId}").WithPathParamStyle("petId", "label"),
+			expected: "https://api.example.com/pets/{petId}",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := tt.builder.Build("https://api.example.com"); result != tt.expected {
+				t.Errorf("expected '%s', got '%s'", tt.expected, result)
+			}
+		})
+	}
+
+	builder := NewURLBuilder("/pets/{petId}").WithPathParamStyle("petId", "label")
+	if result := builder.Clone().WithPathParam("petId", "rex").Build("https://api.example.com"); result != "https://api.example.com/pets/.rex" {
+		t.Errorf("expected Clone to keep the style, got '%s'", result)
+	}
+	if result := builder.Reset().WithPathParam("petId", "rex").Build("https://api.example.com"); result != "https://api.example.com/pets/rex" {
+		t.Errorf("expected Reset to clear the style, got '%s'", result)
+	}
+}
+
 func TestWithQueryParam(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -216,6 +322,17 @@ func TestWithQueryParamArrayEmpty(t *testing.T) {
 	}
 }
 
+func TestWithQueryParamAllowEmpty(t *testing.T) {
+	result := NewURLBuilder("/pets").
+		WithQueryParamAllowEmpty("verbose", "").
+		Build("https://api.example.com")
+
+	expected := "https://api.example.com/pets?verbose="
+	if result != expected {
+		t.Errorf("expected '%s', got '%s'", expected, result)
+	}
+}
+
 func TestWithQueryParamIntArray(t *testing.T) {
 	result := NewURLBuilder("/items").
 		WithQueryParamIntArray("ids", []int64{1, 2, 3}).
@@ -322,6 +439,38 @@ func TestBaseURLTrailingSlash(t *testing.T) {
 	}
 }
 
+func TestResourceLink(t *testing.T) {
+	tests := []struct {
+		name     string
+		url      string
+		expected string
+	}{
+		{
+			name:     "plain URL unchanged",
+			url:      "https://api.example.com/pet/123",
+			expected: "https://api.example.com/pet/123",
+		},
+		{
+			name:     "query string dropped",
+			url:      "https://api.example.com/pet/123?api_key=secret",
+			expected: "https://api.example.com/pet/123",
+		},
+		{
+			name:     "unsubstituted path param",
+			url:      "https://api.example.com/pet/{petId}",
+			expected: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := ResourceLink(tt.url); result != tt.expected {
+				t.Errorf("expected '%s', got '%s'", tt.expected, result)
+			}
+		})
+	}
+}
+
 func TestHasUnsubstitutedParams(t *testing.T) {
 	tests := []struct {
 		name     string
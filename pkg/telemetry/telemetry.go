@@ -34,6 +34,18 @@ type Config struct {
 	ServiceVersion string
 	// Insecure disables TLS for the OTLP connection
 	Insecure bool
+	// DisableTracing skips the trace exporter so only metrics are exported
+	DisableTracing bool
+}
+
+// Option adjusts the Config built by InitProviderFromEnv
+type Option func(*Config)
+
+// WithoutTracing disables trace export, leaving only metrics
+func WithoutTracing() Option {
+	return func(c *Config) {
+		c.DisableTracing = true
+	}
 }
 
 // Provider wraps the OpenTelemetry providers for cleanup
@@ -85,30 +97,32 @@ func InitProvider(ctx context.Context, cfg Config) (*Provider, error) {
 
 	provider := &Provider{}
 
-	// Initialize trace exporter
-	traceOpts := []otlptracegrpc.Option{
-		otlptracegrpc.WithEndpoint(cfg.Endpoint),
-	}
-	if cfg.Insecure {
-		traceOpts = append(traceOpts, otlptracegrpc.WithInsecure())
-	}
-
-	traceExporter, err := otlptracegrpc.New(ctx, traceOpts...)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create trace exporter: %w", err)
-	}
+	if !cfg.DisableTracing {
+		// Initialize trace exporter
+		traceOpts := []otlptracegrpc.Option{
+			otlptracegrpc.WithEndpoint(cfg.Endpoint),
+		}
+		if cfg.Insecure {
+			traceOpts = append(traceOpts, otlptracegrpc.WithInsecure())
+		}
 
-	// Create tracer provider
-	provider.TracerProvider = trace.NewTracerProvider(
-		trace.WithBatcher(traceExporter,
-			trace.WithBatchTimeout(5*time.Second),
-		),
-		trace.WithResource(res),
-		trace.WithSampler(trace.AlwaysSample()),
-	)
+		traceExporter, err := otlptracegrpc.New(ctx, traceOpts...)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create trace exporter: %w", err)
+		}
 
-	// Set global tracer provider
-	otel.SetTracerProvider(provider.TracerProvider)
+		// Create tracer provider
+		provider.TracerProvider = trace.NewTracerProvider(
+			trace.WithBatcher(traceExporter,
+				trace.WithBatchTimeout(5*time.Second),
+			),
+			trace.WithResource(res),
+			trace.WithSampler(trace.AlwaysSample()),
+		)
+
+		// Set global tracer provider
+		otel.SetTracerProvider(provider.TracerProvider)
+	}
 
 	// Set global propagator
 	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
@@ -127,7 +141,9 @@ func InitProvider(ctx context.Context, cfg Config) (*Provider, error) {
 	metricExporter, err := otlpmetricgrpc.New(ctx, metricOpts...)
 	if err != nil {
 		// Clean up tracer provider before returning error
-		provider.TracerProvider.Shutdown(ctx)
+		if provider.TracerProvider != nil {
+			provider.TracerProvider.Shutdown(ctx)
+		}
 		return nil, fmt.Errorf("failed to create metric exporter: %w", err)
 	}
 
@@ -152,7 +168,8 @@ func InitProvider(ctx context.Context, cfg Config) (*Provider, error) {
 //   - OTEL_INSECURE: Set to "true" to disable TLS
 //
 // Returns nil provider (no-op) if OTEL_EXPORTER_OTLP_ENDPOINT is not set.
-func InitProviderFromEnv(ctx context.Context, serviceName, serviceVersion string) (*Provider, error) {
+// Options adjust the resulting Config, e.g. WithoutTracing to export only metrics.
+func InitProviderFromEnv(ctx context.Context, serviceName, serviceVersion string, opts ...Option) (*Provider, error) {
 	endpoint := os.Getenv("OTEL_EXPORTER_OTLP_ENDPOINT")
 	if endpoint == "" {
 		// OpenTelemetry not configured, return nil provider (no-op)
@@ -165,10 +182,14 @@ func InitProviderFromEnv(ctx context.Context, serviceName, serviceVersion string
 
 	insecure := os.Getenv("OTEL_INSECURE") == "true"
 
-	return InitProvider(ctx, Config{
+	cfg := Config{
 		Endpoint:       endpoint,
 		ServiceName:    serviceName,
 		ServiceVersion: serviceVersion,
 		Insecure:       insecure,
-	})
+	}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	return InitProvider(ctx, cfg)
 }
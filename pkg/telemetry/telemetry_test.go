@@ -69,6 +69,39 @@ func TestInitProviderFromEnv_WithEndpoint(t *testing.T) {
 	_ = provider.Shutdown(shutdownCtx)
 }
 
+func TestInitProviderFromEnv_WithoutTracing(t *testing.T) {
+	origEndpoint := os.Getenv("OTEL_EXPORTER_OTLP_ENDPOINT")
+	origInsecure := os.Getenv("OTEL_INSECURE")
+	defer func() {
+		restoreEnv("OTEL_EXPORTER_OTLP_ENDPOINT", origEndpoint)
+		restoreEnv("OTEL_INSECURE", origInsecure)
+	}()
+
+	os.Setenv("OTEL_EXPORTER_OTLP_ENDPOINT", "localhost:4317")
+	os.Setenv("OTEL_INSECURE", "true")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	provider, err := InitProviderFromEnv(ctx, "test-service", "v1.0.0", WithoutTracing())
+	if err != nil {
+		t.Fatalf("InitProviderFromEnv() unexpected error: %v", err)
+	}
+	if provider == nil {
+		t.Fatal("InitProviderFromEnv() expected non-nil provider")
+	}
+	if provider.TracerProvider != nil {
+		t.Error("InitProviderFromEnv() expected no TracerProvider with WithoutTracing")
+	}
+	if provider.MeterProvider == nil {
+		t.Error("InitProviderFromEnv() MeterProvider is nil")
+	}
+
+	shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer shutdownCancel()
+	_ = provider.Shutdown(shutdownCtx)
+}
+
 func TestInitProviderFromEnv_ServiceNameOverride(t *testing.T) {
 	// Save original env and restore after test
 	origEndpoint := os.Getenv("OTEL_EXPORTER_OTLP_ENDPOINT")
@@ -59,6 +59,11 @@ var ServiceAccountYAMLTemplate string
 //go:embed role_binding.yaml.tmpl
 var RoleBindingYAMLTemplate string
 
+// NamespacedRoleBindingYAMLTemplate is the template for generating namespaced_role_binding.yaml
+//
+//go:embed namespaced_role_binding.yaml.tmpl
+var NamespacedRoleBindingYAMLTemplate string
+
 // LeaderElectionRoleTemplate is the template for generating leader_election_role.yaml
 //
 //go:embed leader_election_role.yaml.tmpl
@@ -74,6 +79,16 @@ var LeaderElectionRoleBindingTemplate string
 //go:embed manager.yaml.tmpl
 var ManagerYAMLTemplate string
 
+// PDBYAMLTemplate is the template for generating pdb.yaml (PodDisruptionBudget)
+//
+//go:embed pdb.yaml.tmpl
+var PDBYAMLTemplate string
+
+// PriorityClassYAMLTemplate is the template for generating priority_class.yaml
+//
+//go:embed priority_class.yaml.tmpl
+var PriorityClassYAMLTemplate string
+
 // KustomizationManagerTemplate is the template for config/manager/kustomization.yaml
 //
 //go:embed kustomization_manager.yaml.tmpl
@@ -94,6 +109,51 @@ var KustomizationCRDTemplate string
 //go:embed kustomization_default.yaml.tmpl
 var KustomizationDefaultTemplate string
 
+// CRDWebhookPatchTemplate is the template for config/crd/patches/webhook_in_<plural>.yaml
+//
+//go:embed crd_webhook_patch.yaml.tmpl
+var CRDWebhookPatchTemplate string
+
+// CRDCAInjectionPatchTemplate is the template for config/crd/patches/cainjection_in_<plural>.yaml
+//
+//go:embed crd_cainjection_patch.yaml.tmpl
+var CRDCAInjectionPatchTemplate string
+
+// KustomizationCRDPatchesTemplate is the template for config/crd/kustomization.yaml (bases plus webhook patches)
+//
+//go:embed kustomization_crd_patches.yaml.tmpl
+var KustomizationCRDPatchesTemplate string
+
+// CertificateTemplate is the template for config/certmanager/certificate.yaml
+//
+//go:embed certificate.yaml.tmpl
+var CertificateTemplate string
+
+// KustomizationCertManagerTemplate is the template for config/certmanager/kustomization.yaml
+//
+//go:embed kustomization_certmanager.yaml.tmpl
+var KustomizationCertManagerTemplate string
+
+// WebhookServiceTemplate is the template for config/webhook/service.yaml
+//
+//go:embed webhook_service.yaml.tmpl
+var WebhookServiceTemplate string
+
+// KustomizationWebhookTemplate is the template for config/webhook/kustomization.yaml
+//
+//go:embed kustomization_webhook.yaml.tmpl
+var KustomizationWebhookTemplate string
+
+// ManagerWebhookPatchTemplate is the template for config/manager_webhook_patch.yaml
+//
+//go:embed manager_webhook_patch.yaml.tmpl
+var ManagerWebhookPatchTemplate string
+
+// GrafanaDashboardTemplate is the template for dashboards/<app>.json
+//
+//go:embed grafana_dashboard.json.tmpl
+var GrafanaDashboardTemplate string
+
 // DockerfileTemplate is the template for generating the Dockerfile
 //
 //go:embed dockerfile.tmpl
@@ -104,6 +164,11 @@ var DockerfileTemplate string
 //go:embed makefile.tmpl
 var MakefileTemplate string
 
+// GolangciTemplate is the template for generating .golangci.yml
+//
+//go:embed golangci.yml.tmpl
+var GolangciTemplate string
+
 // GoModTemplate is the template for generating the go.mod file
 //
 //go:embed go.mod.tmpl
@@ -114,6 +179,11 @@ var GoModTemplate string
 //go:embed boilerplate.go.txt.tmpl
 var BoilerplateTemplate string
 
+// RBACAuditScriptTemplate is the template for generating hack/rbac-audit.sh
+//
+//go:embed rbac_audit.sh.tmpl
+var RBACAuditScriptTemplate string
+
 // ExampleCRTemplate is the template for generating example CR YAML files
 //
 //go:embed example_cr.yaml.tmpl
@@ -149,6 +219,21 @@ var ClaudeMdTemplate string
 //go:embed copilot-instructions.md.tmpl
 var CopilotInstructionsTemplate string
 
+// MigrationTemplate is the template for generating MIGRATION.md with --patch-existing-crds
+//
+//go:embed migration.md.tmpl
+var MigrationTemplate string
+
+// SpecEmbedTemplate is the template for generating spec_embed.go with --embed-spec
+//
+//go:embed spec_embed.go.tmpl
+var SpecEmbedTemplate string
+
+// ConditionsTemplate is the template for the condition reason constants shared by the controllers
+//
+//go:embed conditions.go.tmpl
+var ConditionsTemplate string
+
 // SuiteTestTemplate is the template for generating the envtest suite_test.go file
 //
 //go:embed suite_test.go.tmpl
@@ -159,6 +244,11 @@ var SuiteTestTemplate string
 //go:embed integration_test.go.tmpl
 var IntegrationTestTemplate string
 
+// CELValidationTestTemplate is the template for generating envtest cases that exercise CRD CEL validation rules
+//
+//go:embed cel_validation_test.go.tmpl
+var CELValidationTestTemplate string
+
 // AggregateControllerTemplate is the template for generating status aggregator controller
 //
 //go:embed aggregate_controller.go.tmpl
@@ -184,6 +274,11 @@ var BundleTypesTemplate string
 //go:embed bundle_controller.go.tmpl
 var BundleControllerTemplate string
 
+// BundleControllerTestTemplate is the template for generating bundle controller ownership tests
+//
+//go:embed bundle_controller_test.go.tmpl
+var BundleControllerTestTemplate string
+
 // ExampleBundleCRTemplate is the template for generating example bundle CR YAML files
 //
 //go:embed example_bundle_cr.yaml.tmpl
@@ -281,6 +376,11 @@ var KubectlPluginGoModTemplate string
 //go:embed kubectl_plugin/makefile.tmpl
 var KubectlPluginMakefileTemplate string
 
+// KubectlPluginKrewManifestTemplate is the template for the kubectl plugin krew manifest
+//
+//go:embed kubectl_plugin/krew_manifest.yaml.tmpl
+var KubectlPluginKrewManifestTemplate string
+
 // Phase 2: Diagnostic Commands
 
 // KubectlPluginCompareCmdTemplate is the template for the kubectl plugin compare command
@@ -328,6 +428,22 @@ var KubectlPluginCleanupCmdTemplate string
 //go:embed kubectl_plugin/create_cmd.go.tmpl
 var KubectlPluginCreateCmdTemplate string
 
+// KubectlPluginExportCmdTemplate is the template for the kubectl plugin export command
+//
+//go:embed kubectl_plugin/export_cmd.go.tmpl
+var KubectlPluginExportCmdTemplate string
+
+// KubectlPluginImportCmdTemplate is the template for the kubectl plugin import command
+//
+//go:embed kubectl_plugin/import_cmd.go.tmpl
+var KubectlPluginImportCmdTemplate string
+
+// KubectlPluginCmdTestTemplate is the template for the kubectl plugin command tests, which run
+// against a fake dynamic client
+//
+//go:embed kubectl_plugin/cmd_test.go.tmpl
+var KubectlPluginCmdTestTemplate string
+
 // KubectlPluginTargetingTemplate is the template for the kubectl plugin shared targeting helpers
 //
 //go:embed kubectl_plugin/targeting.go.tmpl
@@ -338,6 +454,11 @@ var KubectlPluginTargetingTemplate string
 //go:embed kubectl_plugin/nodes_cmd.go.tmpl
 var KubectlPluginNodesCmdTemplate string
 
+// KubectlPluginGraphCmdTemplate is the template for the kubectl plugin graph command
+//
+//go:embed kubectl_plugin/graph_cmd.go.tmpl
+var KubectlPluginGraphCmdTemplate string
+
 // TargetAPIDeploymentTemplate is the template for the target API Deployment+Service
 //
 //go:embed target_api_deployment.yaml.tmpl
@@ -348,6 +469,27 @@ var TargetAPIDeploymentTemplate string
 //go:embed docker_compose.yaml.tmpl
 var DockerComposeTemplate string
 
+// TiltfileTemplate is the template for the Tiltfile live-reload development loop
+//
+//go:embed tiltfile.tmpl
+var TiltfileTemplate string
+
+// E2ETestTemplate is the template for the kind-based end-to-end test (test/e2e)
+//
+//go:embed e2e_test.go.tmpl
+var E2ETestTemplate string
+
+// WebhookReceiverTemplate is the template for the receiver of the spec's webhooks
+// (internal/webhookreceiver/receiver.go)
+//
+//go:embed webhook_receiver.go.tmpl
+var WebhookReceiverTemplate string
+
+// WebhookReceiverServiceTemplate is the template for config/webhook-receiver/service.yaml
+//
+//go:embed webhook_receiver_service.yaml.tmpl
+var WebhookReceiverServiceTemplate string
+
 // Rundeck Project Templates
 
 // RundeckProjectPropertiesTemplate is the template for Rundeck project.properties
@@ -725,3 +867,8 @@ var RundeckPluginYAMLTemplate string
 //
 //go:embed rundeck_plugin/nodes.sh.tmpl
 var RundeckPluginNodesScriptTemplate string
+
+// ProjectTemplate is the template for generating the kubebuilder PROJECT file with --kubebuilder
+//
+//go:embed project.tmpl
+var ProjectTemplate string
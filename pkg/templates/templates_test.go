@@ -5,6 +5,7 @@ import (
 	"strings"
 	"testing"
 	"text/template"
+	"time"
 )
 
 // controllerFuncMap provides the template functions needed by the controller template
@@ -12,6 +13,15 @@ var controllerFuncMap = template.FuncMap{
 	"sub": func(a, b int) int {
 		return a - b
 	},
+	"failedStatus": func(codes []int) string {
+		return "resp.StatusCode < 200 || resp.StatusCode >= 300"
+	},
+	"accept": func(accept map[string]string, method string) string {
+		return "application/json"
+	},
+	"contentType": func(contentType map[string]string, method, fallback string) string {
+		return fallback
+	},
 }
 
 // =============================================================================
@@ -125,14 +135,14 @@ func TestControllerTemplateParseable(t *testing.T) {
 }
 
 func TestQueryControllerTemplateParseable(t *testing.T) {
-	_, err := template.New("querycontroller").Parse(QueryControllerTemplate)
+	_, err := template.New("querycontroller").Funcs(controllerFuncMap).Parse(QueryControllerTemplate)
 	if err != nil {
 		t.Errorf("Failed to parse QueryControllerTemplate: %v", err)
 	}
 }
 
 func TestActionControllerTemplateParseable(t *testing.T) {
-	_, err := template.New("actioncontroller").Parse(ActionControllerTemplate)
+	_, err := template.New("actioncontroller").Funcs(controllerFuncMap).Parse(ActionControllerTemplate)
 	if err != nil {
 		t.Errorf("Failed to parse ActionControllerTemplate: %v", err)
 	}
@@ -165,6 +175,21 @@ type FieldData struct {
 	Required    bool
 	Validation  *ValidationData
 	Enum        []string
+	Immutable   bool
+	Deprecated  bool
+	// ItemValidation mirrors the item constraints of scalar array fields
+	ItemValidation *ValidationData
+	// KeyRule mirrors the patternProperties key validation of map fields
+	KeyRule        string
+	KeyRuleMessage string
+	// ValueRule mirrors the value validation of scalar map fields
+	ValueRule        string
+	ValueRuleMessage string
+	NotRule          string
+	NotRuleMessage   string
+	// AnyOfRule mirrors the anyOf validation of merged struct fields
+	AnyOfRule        string
+	AnyOfRuleMessage string
 }
 
 // ValidationData mimics validation rules
@@ -174,6 +199,7 @@ type ValidationData struct {
 	Minimum   *float64
 	Maximum   *float64
 	Pattern   string
+	Format    string
 	Enum      []string
 	MinItems  *int64
 	MaxItems  *int64
@@ -188,7 +214,9 @@ type SpecData struct {
 type CRDTypeData struct {
 	Kind            string
 	Plural          string
+	CustomPlural    bool
 	ShortNames      []string
+	StateEnum       string
 	Spec            *SpecData
 	IsQuery         bool
 	QueryPath       string
@@ -210,6 +238,7 @@ type CRDTypeData struct {
 	// Binary upload support for actions
 	HasBinaryBody     bool
 	BinaryContentType string
+	WatchDataFrom     bool
 
 	// HTTP method availability
 	HasDelete bool
@@ -217,11 +246,31 @@ type CRDTypeData struct {
 	HasPatch  bool
 	HasPut    bool
 
+	// UseETag adds status.etag
+	UseETag bool
+	// HasWriteOnlyFields adds status.writeOnlyGeneration
+	HasWriteOnlyFields bool
+
+	// StatusFields are response fields copied into the status
+	StatusFields []struct {
+		Name, JSONName, GoType, SchemaType, Description string
+		Path                                            []string
+	}
+	// ExcludedStatus are the status fields dropped by --exclude-status-fields
+	ExcludedStatus map[string]bool
+
+	// Default spec.target
+	TargetDefault        string
+	TargetDefaultSummary string
+
 	// ExternalIDRef handling
 	NeedsExternalIDRef bool
+	ReadOnly           bool
+	HasExtraHeaders    bool
 
 	// CEL validation rules for conditional field requirements
 	CELValidationRules []CELValidationRule
+	SpecAnyOfRule      *CELValidationRule
 }
 
 // CELValidationRule for testing
@@ -246,6 +295,19 @@ type TypesTemplateData struct {
 	CRDs             []CRDTypeData
 	NestedTypes      []NestedTypeData
 	HasBinaryActions bool // True if any action CRD has binary body support
+	// SharedResultTypes mirrors query result types shared by several queries
+	SharedResultTypes []SharedResultTypeData
+	StatusSubresource bool
+	SpecVersion       string
+	SpecVersionLabel  string
+}
+
+// SharedResultTypeData mimics a query result type shared by several queries
+type SharedResultTypeData struct {
+	Name   string
+	Schema string
+	Kinds  []string
+	Fields []FieldData
 }
 
 func TestTypesTemplateExecution(t *testing.T) {
@@ -265,6 +327,7 @@ func TestTypesTemplateExecution(t *testing.T) {
 				Kind:       "Pet",
 				Plural:     "pets",
 				ShortNames: []string{"pt"},
+				StateEnum:  "Pending;Syncing;Synced;Failed;Observed;NotFound;Paused",
 				Spec: &SpecData{
 					Fields: []FieldData{
 						{
@@ -326,6 +389,7 @@ func TestTypesTemplateQueryCRDExecution(t *testing.T) {
 				Kind:            "PetFindByTags",
 				Plural:          "petfindbytags",
 				ShortNames:      []string{"pfbt"},
+				StateEnum:       "Pending;Querying;Queried;Failed;Paused",
 				IsQuery:         true,
 				QueryPath:       "/pet/findByTags",
 				ResponseType:    "[]Pet",
@@ -419,6 +483,12 @@ type ActionRequestBodyField struct {
 	GoName   string
 }
 
+// ActionFormField for multipart action controller templates
+type ActionFormField struct {
+	PartName string
+	GoName   string
+}
+
 type ResourceQueryParam struct {
 	Name     string // Parameter name as it appears in URL (e.g., "status")
 	JSONName string // JSON field name (e.g., "status")
@@ -438,13 +508,46 @@ type QueryParamField struct {
 	ItemType    string // Type of array items if IsArray is true
 }
 
+// SecurityScheme mimics mapper.SecurityScheme
+type SecurityScheme struct {
+	Name      string
+	Type      string
+	In        string
+	ParamName string
+	Scheme    string
+	EnvVar    string
+}
+
+// SecurityRequirement mimics mapper.SecurityRequirement
+type SecurityRequirement struct {
+	Schemes []SecurityScheme
+}
+
+// ConditionTypeNames mimics generator.ConditionTypeNames
+type ConditionTypeNames struct {
+	Ready       string
+	Reconciling string
+	Stalled     string
+}
+
+// OwnerData mimics generator.OwnerData
+type OwnerData struct {
+	Kind          string
+	Plural        string
+	IDField       string
+	OwnerIDField  string
+	HasExternalID bool
+}
+
 // ControllerTemplateData mimics the data structure for controller template
 type ControllerTemplateData struct {
 	Year               int
 	GeneratorVersion   string
 	APIGroup           string
+	FinalizerName      string
 	APIVersion         string
 	ModuleName         string
+	APIPackage         string
 	Kind               string
 	KindLower          string
 	Plural             string
@@ -461,6 +564,7 @@ type ControllerTemplateData struct {
 	UsesSharedType     bool
 	IsPrimitiveArray   bool
 	PrimitiveArrayType string
+	MaxQueryResults    int
 
 	// Action endpoint fields
 	IsAction            bool
@@ -470,6 +574,7 @@ type ControllerTemplateData struct {
 	ParentIDParam       string
 	ParentIDField       string
 	ParentIDGoType      string
+	ParentIDStyle       string
 	HasParentID         bool
 	ActionName          string
 	PathParams          []ActionPathParam
@@ -488,10 +593,41 @@ type ControllerTemplateData struct {
 	// Binary upload support for actions
 	HasBinaryBody     bool
 	BinaryContentType string
+	WatchDataFrom     bool
+	IsMultipart       bool
+	FormFields        []ActionFormField
+	FileField         string
 
 	// UpdateWithPost enables using POST for updates when PUT is not available
 	UpdateWithPost bool
 
+	// UseETag sends the stored ETag as If-Match on updates
+	UseETag bool
+
+	// StatusFields are response fields copied into the status
+	StatusFields []struct {
+		Name, JSONName, GoType, SchemaType, Description string
+		Path                                            []string
+	}
+
+	// PollCondition polls an asynchronously created resource until it is ready
+	PollCondition *struct {
+		Path, Values []string
+		Interval     time.Duration
+		Expression   string
+	}
+
+	// PauseSwitch checks the operator-wide pause ConfigMap
+	PauseSwitch bool
+	// DeleteTimeout retries failed DELETEs for this long (Go duration expression)
+	DeleteTimeout string
+	// OrphanOnDeleteTimeout removes the finalizer once DeleteTimeout has passed
+	OrphanOnDeleteTimeout bool
+	// StatusSubresource writes status through the status subresource
+	StatusSubresource bool
+	// GenerationPredicate filters reconciles to spec and annotation changes
+	GenerationPredicate bool
+
 	// Per-method paths (when different methods use different paths)
 	GetPath        string
 	PutPath        string
@@ -500,6 +636,24 @@ type ControllerTemplateData struct {
 
 	// ExternalIDRef handling
 	NeedsExternalIDRef bool
+	ReadOnly           bool
+	Owner              *OwnerData
+	HasExtraHeaders    bool
+	SupportDryRun      bool
+	FormEncoded        bool
+	IdempotencyHeader  string
+
+	// SuccessCodes are the response codes treated as success per HTTP method
+	SuccessCodes map[string][]int
+	Accept       map[string]string
+	ContentType  map[string]string
+	Conditions   ConditionTypeNames
+	Security     map[string][]SecurityRequirement
+
+	// WriteOnlyFields are excluded from drift detection
+	WriteOnlyFields []string
+	// SpecFieldRenames maps renamed spec fields to their API names
+	SpecFieldRenames map[string]string
 }
 
 func TestControllerTemplateExecution(t *testing.T) {
@@ -514,6 +668,7 @@ func TestControllerTemplateExecution(t *testing.T) {
 		APIGroup:          "petstore.example.com",
 		APIVersion:        "v1alpha1",
 		ModuleName:        "github.com/example/petstore-operator",
+		APIPackage:        "github.com/example/petstore-operator/api/v1alpha1",
 		Kind:              "Pet",
 		KindLower:         "pet",
 		Plural:            "pets",
@@ -557,6 +712,7 @@ func TestControllerTemplateWithUpdateWithPost(t *testing.T) {
 		APIGroup:          "petstore.example.com",
 		APIVersion:        "v1alpha1",
 		ModuleName:        "github.com/example/petstore-operator",
+		APIPackage:        "github.com/example/petstore-operator/api/v1alpha1",
 		Kind:              "Widget",
 		KindLower:         "widget",
 		Plural:            "widgets",
@@ -601,7 +757,7 @@ func TestControllerTemplateWithUpdateWithPost(t *testing.T) {
 }
 
 func TestQueryControllerTemplateExecution(t *testing.T) {
-	tmpl, err := template.New("querycontroller").Parse(QueryControllerTemplate)
+	tmpl, err := template.New("querycontroller").Funcs(controllerFuncMap).Parse(QueryControllerTemplate)
 	if err != nil {
 		t.Fatalf("Failed to parse QueryControllerTemplate: %v", err)
 	}
@@ -612,6 +768,7 @@ func TestQueryControllerTemplateExecution(t *testing.T) {
 		APIGroup:         "petstore.example.com",
 		APIVersion:       "v1alpha1",
 		ModuleName:       "github.com/example/petstore-operator",
+		APIPackage:       "github.com/example/petstore-operator/api/v1alpha1",
 		Kind:             "PetFindByTags",
 		KindLower:        "petfindbytags",
 		Plural:           "petfindbytags",
@@ -647,7 +804,7 @@ func TestQueryControllerTemplateExecution(t *testing.T) {
 }
 
 func TestQueryControllerTemplateWithoutTypedResults(t *testing.T) {
-	tmpl, err := template.New("querycontroller").Parse(QueryControllerTemplate)
+	tmpl, err := template.New("querycontroller").Funcs(controllerFuncMap).Parse(QueryControllerTemplate)
 	if err != nil {
 		t.Fatalf("Failed to parse QueryControllerTemplate: %v", err)
 	}
@@ -658,6 +815,7 @@ func TestQueryControllerTemplateWithoutTypedResults(t *testing.T) {
 		APIGroup:         "petstore.example.com",
 		APIVersion:       "v1alpha1",
 		ModuleName:       "github.com/example/petstore-operator",
+		APIPackage:       "github.com/example/petstore-operator/api/v1alpha1",
 		Kind:             "SearchQuery",
 		KindLower:        "searchquery",
 		Plural:           "searchqueries",
@@ -683,7 +841,7 @@ func TestQueryControllerTemplateWithoutTypedResults(t *testing.T) {
 }
 
 func TestActionControllerTemplateExecution(t *testing.T) {
-	tmpl, err := template.New("actioncontroller").Parse(ActionControllerTemplate)
+	tmpl, err := template.New("actioncontroller").Funcs(controllerFuncMap).Parse(ActionControllerTemplate)
 	if err != nil {
 		t.Fatalf("Failed to parse ActionControllerTemplate: %v", err)
 	}
@@ -694,6 +852,7 @@ func TestActionControllerTemplateExecution(t *testing.T) {
 		APIGroup:         "petstore.example.com",
 		APIVersion:       "v1alpha1",
 		ModuleName:       "github.com/example/petstore-operator",
+		APIPackage:       "github.com/example/petstore-operator/api/v1alpha1",
 		Kind:             "PetUploadImage",
 		KindLower:        "petuploadimage",
 		Plural:           "petuploadimages",
@@ -739,7 +898,7 @@ func TestActionControllerTemplateExecution(t *testing.T) {
 }
 
 func TestActionControllerTemplateWithTypedResults(t *testing.T) {
-	tmpl, err := template.New("actioncontroller").Parse(ActionControllerTemplate)
+	tmpl, err := template.New("actioncontroller").Funcs(controllerFuncMap).Parse(ActionControllerTemplate)
 	if err != nil {
 		t.Fatalf("Failed to parse ActionControllerTemplate: %v", err)
 	}
@@ -750,6 +909,7 @@ func TestActionControllerTemplateWithTypedResults(t *testing.T) {
 		APIGroup:         "petstore.example.com",
 		APIVersion:       "v1alpha1",
 		ModuleName:       "github.com/example/petstore-operator",
+		APIPackage:       "github.com/example/petstore-operator/api/v1alpha1",
 		Kind:             "PetUploadImage",
 		KindLower:        "petuploadimage",
 		Plural:           "petuploadimages",
@@ -791,7 +951,7 @@ func TestActionControllerTemplateWithTypedResults(t *testing.T) {
 }
 
 func TestActionControllerTemplateWithArrayTypedResults(t *testing.T) {
-	tmpl, err := template.New("actioncontroller").Parse(ActionControllerTemplate)
+	tmpl, err := template.New("actioncontroller").Funcs(controllerFuncMap).Parse(ActionControllerTemplate)
 	if err != nil {
 		t.Fatalf("Failed to parse ActionControllerTemplate: %v", err)
 	}
@@ -802,6 +962,7 @@ func TestActionControllerTemplateWithArrayTypedResults(t *testing.T) {
 		APIGroup:         "petstore.example.com",
 		APIVersion:       "v1alpha1",
 		ModuleName:       "github.com/example/petstore-operator",
+		APIPackage:       "github.com/example/petstore-operator/api/v1alpha1",
 		Kind:             "PetBatchUpdate",
 		KindLower:        "petbatchupdate",
 		Plural:           "petbatchupdates",
@@ -855,6 +1016,7 @@ func TestTypesTemplateActionCRDExecution(t *testing.T) {
 				Kind:           "PetUploadImage",
 				Plural:         "petuploadimages",
 				ShortNames:     []string{"pui"},
+				StateEnum:      "Pending;Executing;Completed;Failed;Paused",
 				IsAction:       true,
 				ActionPath:     "/pet/{petId}/uploadImage",
 				ActionMethod:   "POST",
@@ -918,6 +1080,18 @@ type CRDYAMLFieldData struct {
 	Description string
 	Required    bool
 	Enum        []string
+	Immutable   bool
+	// MapValueType and KeyRule mirror patternProperties map fields
+	MapValueType     string
+	KeyRule          string
+	KeyRuleMessage   string
+	ValueRule        string
+	ValueRuleMessage string
+	NotRule          string
+	NotRuleMessage   string
+	// AnyOfRule mirrors merged anyOf struct fields
+	AnyOfRule        string
+	AnyOfRuleMessage string
 }
 
 // CRDYAMLSpecData mimics spec data for CRD YAML template
@@ -936,7 +1110,22 @@ type CRDYAMLData struct {
 	Singular         string
 	ShortNames       []string
 	Scope            string
-	Spec             *CRDYAMLSpecData
+	UseETag          bool
+	StatusFields     []struct {
+		Name, JSONName, GoType, SchemaType, Description string
+		Path                                            []string
+	}
+	ExcludedStatus  map[string]bool
+	Spec            *CRDYAMLSpecData
+	KindType        string
+	States          []string
+	TargetDefault   []struct{ Key, Value string }
+	HasExtraHeaders bool
+
+	HasWriteOnlyFields bool
+	StatusSubresource  bool
+	SpecVersion        string
+	SpecVersionLabel   string
 }
 
 func TestCRDYAMLTemplateExecution(t *testing.T) {
@@ -955,6 +1144,8 @@ func TestCRDYAMLTemplateExecution(t *testing.T) {
 		Singular:         "pet",
 		ShortNames:       []string{"pt", "pet"},
 		Scope:            "Namespaced",
+		KindType:         "resource",
+		States:           []string{"Pending", "Synced", "Failed"},
 		Spec: &CRDYAMLSpecData{
 			Fields: []CRDYAMLFieldData{
 				{
@@ -989,9 +1180,23 @@ func TestCRDYAMLTemplateExecution(t *testing.T) {
 
 // MainTemplateData mimics the data structure for main template
 type CRDMainData struct {
-	Kind     string
-	IsQuery  bool
-	IsAction bool
+	Kind           string
+	IsQuery        bool
+	IsAction       bool
+	ControllerFile string
+	OperationID    string
+}
+
+type SpecServer struct {
+	Name        string
+	Environment string
+	URL         string
+	Description string
+}
+
+type EmbeddedSpec struct {
+	ServePath   string
+	ContentType string
 }
 
 type MainTemplateData struct {
@@ -1010,6 +1215,27 @@ type MainTemplateData struct {
 	OperatorVersion string
 	CommitHash      string
 	CommitTimestamp string
+	// HTTP transport defaults
+	HTTPMaxIdleConns    int
+	HTTPMaxConnsPerHost int
+	HTTPIdleConnTimeout string
+	HTTP2               bool
+	EnableTracing       bool
+	EnablePprof         bool
+	PprofAddr           string
+
+	SlowReconcileThreshold string
+	KubeClientQPS          float32
+	KubeClientBurst        int
+	PauseConfigMapRef      string
+	Servers                []SpecServer
+	ServerSelector         string
+	WatchNamespaces        string
+	HighAvailability       bool
+	SupportDryRun          bool
+	EmbeddedSpec           *EmbeddedSpec
+	Kubebuilder            bool
+	WebhookReceiver        bool
 }
 
 func TestMainTemplateExecution(t *testing.T) {
@@ -1033,6 +1259,15 @@ func TestMainTemplateExecution(t *testing.T) {
 		OperatorVersion: "v0.0.2-0.20260115203556-d5024c8e6620",
 		CommitHash:      "d5024c8e6620",
 		CommitTimestamp: "20260115203556",
+
+		HTTPMaxIdleConns:    250,
+		HTTPMaxConnsPerHost: 20,
+		HTTPIdleConnTimeout: "45 * time.Second",
+		HTTP2:               false,
+
+		SlowReconcileThreshold: "5 * time.Second",
+		KubeClientQPS:          50.5,
+		KubeClientBurst:        100,
 	}
 
 	var buf bytes.Buffer
@@ -1042,6 +1277,35 @@ func TestMainTemplateExecution(t *testing.T) {
 	}
 
 	output := buf.String()
+	httpFlags := []string{
+		`"http-max-idle-conns", 250,`,
+		`"http-max-conns-per-host", 20,`,
+		`"http-idle-conn-timeout", 45 * time.Second,`,
+		`"http2", false,`,
+		"controllerutil2.SharedHTTPTransport(",
+	}
+	for _, flag := range httpFlags {
+		if !strings.Contains(output, flag) {
+			t.Errorf("Output doesn't contain expected HTTP transport setting %q", flag)
+		}
+	}
+	if !strings.Contains(output, `"slow-reconcile-threshold", 5 * time.Second,`) {
+		t.Error("Output doesn't contain expected slow reconcile threshold flag")
+	}
+	if !strings.Contains(output, "SlowReconcileThreshold: slowReconcileThreshold,") {
+		t.Error("Output doesn't pass the slow reconcile threshold to the reconcilers")
+	}
+	for _, want := range []string{
+		`"kube-api-qps", 50.5,`,
+		`"kube-api-burst", 100,`,
+		"restConfig.QPS = float32(kubeAPIQPS)",
+		"restConfig.Burst = kubeAPIBurst",
+		"ctrl.NewManager(restConfig, mgrOpts)",
+	} {
+		if !strings.Contains(output, want) {
+			t.Errorf("Output doesn't contain expected Kubernetes client setting %q", want)
+		}
+	}
 	if !strings.Contains(output, "package main") {
 		t.Error("Output doesn't contain expected package declaration")
 	}